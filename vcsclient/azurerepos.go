@@ -2,20 +2,44 @@ package vcsclient
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/Azure/go-ntlmssp"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/utils/binary"
+	utildiff "github.com/go-git/go-git/v5/utils/diff"
+	"github.com/google/uuid"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"golang.org/x/exp/slices"
 	"io"
 	"net/http"
-	"os"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,519 +47,2336 @@ const (
 	defaultAzureBaseUrl              = "https://dev.azure.com/"
 	azurePullRequestDetailsSizeLimit = 4000
 	azurePullRequestCommentSizeLimit = 150000
+	azureWebhookPublisherID          = "tfs"
+	azureWebhookConsumerID           = "webHooks"
+	azureWebhookConsumerActionID     = "httpRequest"
+	azureWebhookIDSeparator          = ","
+	firstCommentInThreadID           = 1
+	// The type of variable groups whose variables are stored in Azure DevOps, rather than linked to an Azure key vault
+	azureVariableGroupType = "Vsts"
+	// AzureWebhookBasicAuthUsername is the basic authentication username of requests sent by webhooks created with CreateWebhook.
+	// The password is the webhook token.
+	AzureWebhookBasicAuthUsername = "froggit-go"
 )
 
-// Azure Devops API version 6
+var (
+	azureAPIVersions = []string{"6.0", "7.0", "7.1"}
+	// The SDK sets the API version of each request in the Accept header, such as application/json;api-version=7.1-preview.1
+	azureAPIVersionRegexp           = regexp.MustCompile(`api-version=[\d.]+(-preview)?(\.\d+)?`)
+	azureMinimumReviewersPolicyType = uuid.MustParse("fa4e907d-c16b-4a4c-9dfa-4906e5d171dd")
+	azureStatusPolicyType           = uuid.MustParse("cbdc66da-9728-4af8-aada-9a5a32e4a226")
+	azureMergeStrategyPolicyType    = uuid.MustParse("fa4e907d-c16b-4a4c-9dfa-4916e5d171ab")
+)
+
+// Azure Devops API version 7.1, unless another version is pinned
 type AzureReposClient struct {
 	vcsInfo           VcsInfo
 	connectionDetails *azuredevops.Connection
-	logger            vcsutils.Log
+	// httpClient is built once, so its transport and NTLM negotiation are shared by all the requests
+	httpClient *http.Client
+	// connectionToken is the token of the connection details, when using a token provider
+	connectionToken string
+	connectionLock  sync.Mutex
+	// gitClient is built on first use, and rebuilt when the connection details are recreated
+	gitClient           git.Client
+	gitClientConnection *azuredevops.Connection
+	gitClientLock       sync.Mutex
+	logger              vcsutils.Log
 }
 
 // NewAzureReposClient create a new AzureReposClient
 func NewAzureReposClient(vcsInfo VcsInfo, logger vcsutils.Log) (*AzureReposClient, error) {
+	if vcsInfo.AzureAPIVersion != "" && !slices.Contains(azureAPIVersions, vcsInfo.AzureAPIVersion) {
+		return nil, fmt.Errorf("unsupported Azure DevOps REST API version %s, the supported versions are %s", vcsInfo.AzureAPIVersion, strings.Join(azureAPIVersions, ", "))
+	}
+	if vcsInfo.ArchiveFormat != "" && vcsInfo.ArchiveFormat != vcsutils.ZipArchive {
+		return nil, fmt.Errorf("unsupported archive format %s, Azure Repos supports only zip archives", vcsInfo.ArchiveFormat)
+	}
 	client := &AzureReposClient{vcsInfo: vcsInfo, logger: logger}
-	baseUrl := strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/")
-	client.connectionDetails = azuredevops.NewPatConnection(baseUrl, client.vcsInfo.Token)
+	client.httpClient = client.buildHttpClient()
+	client.connectionDetails = client.newConnection(client.vcsInfo.Token)
 	return client, nil
 }
 
-func (client *AzureReposClient) buildAzureReposClient(ctx context.Context) (git.Client, error) {
-	if client.connectionDetails == nil {
-		return nil, errors.New("connection details wasn't initialized")
+// newConnection creates the connection details, authenticated by a personal access token, by an Azure AD bearer token or by NTLM.
+// With NTLM, the basic authentication credentials are converted to NTLM authentication by the HTTP client.
+func (client *AzureReposClient) newConnection(token string) *azuredevops.Connection {
+	baseUrl := strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/")
+	switch {
+	case client.vcsInfo.AzureADToken:
+		connection := azuredevops.NewAnonymousConnection(baseUrl)
+		connection.AuthorizationString = "Bearer " + token
+		return connection
+	case client.vcsInfo.AzureNTLMAuth:
+		connection := azuredevops.NewAnonymousConnection(baseUrl)
+		connection.AuthorizationString = "Basic " + base64.StdEncoding.EncodeToString([]byte(client.vcsInfo.Username+":"+token))
+		return connection
+	default:
+		return azuredevops.NewPatConnection(baseUrl, token)
 	}
-	return git.NewClient(ctx, client.connectionDetails)
 }
 
-// TestConnection on Azure Repos
-func (client *AzureReposClient) TestConnection(ctx context.Context) error {
-	buildClient := azuredevops.NewClient(client.connectionDetails, client.connectionDetails.BaseUrl)
-	_, err := buildClient.GetResourceAreas(ctx)
-	return err
+// buildHttpClient returns the HTTP client which sends the requests.
+// It pins the API version of the requests, and negotiates NTLM authentication with Azure DevOps Server if needed.
+func (client *AzureReposClient) buildHttpClient() *http.Client {
+	httpClient := newHttpClient(client.vcsInfo)
+	if client.vcsInfo.AzureAPIVersion != "" {
+		httpClient.Transport = &azureAPIVersionTransport{base: httpClient.Transport, apiVersion: client.vcsInfo.AzureAPIVersion}
+	}
+	if client.vcsInfo.AzureNTLMAuth {
+		httpClient.Transport = ntlmssp.Negotiator{RoundTripper: httpClient.Transport}
+	}
+	return httpClient
 }
 
-// ListRepositories on Azure Repos
-func (client *AzureReposClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+// azureAPIVersionTransport replaces the API version which the SDK requests with the pinned API version.
+// The resource version of preview APIs is omitted, so the latest resource version of the pinned API version is used.
+type azureAPIVersionTransport struct {
+	base       http.RoundTripper
+	apiVersion string
+}
+
+func (transport *azureAPIVersionTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := transport.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	accept := request.Header.Get("Accept")
+	if !azureAPIVersionRegexp.MatchString(accept) {
+		return base.RoundTrip(request)
+	}
+	request = request.Clone(request.Context())
+	request.Header.Set("Accept", azureAPIVersionRegexp.ReplaceAllString(accept, "api-version="+transport.apiVersion+"$1"))
+	return base.RoundTrip(request)
+}
+
+// buildAzureReposClient returns the git client of the connection details, which is built once per connection
+func (client *AzureReposClient) buildAzureReposClient(ctx context.Context) (git.Client, error) {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
-	repositories := make(map[string][]string)
-	resp, err := azureReposGitClient.GetRepositories(ctx, git.GetRepositoriesArgs{Project: &client.vcsInfo.Project})
-	if err != nil {
-		return repositories, err
+	client.gitClientLock.Lock()
+	defer client.gitClientLock.Unlock()
+	if client.gitClient != nil && client.gitClientConnection == connection {
+		return client.gitClient, nil
 	}
-	for _, repo := range *resp {
-		repositories[client.vcsInfo.Project] = append(repositories[client.vcsInfo.Project], *repo.Name)
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, git.ResourceAreaId)
+	if err != nil {
+		return nil, err
 	}
-	return repositories, nil
+	client.gitClient = &git.ClientImpl{Client: *sdkClient}
+	client.gitClientConnection = connection
+	return client.gitClient, nil
 }
 
-// ListBranches on Azure Repos
-func (client *AzureReposClient) ListBranches(ctx context.Context, _, repository string) ([]string, error) {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+func (client *AzureReposClient) buildWorkItemTrackingClient(ctx context.Context) (workitemtracking.Client, error) {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var branches []string
-	gitBranchStats, err := azureReposGitClient.GetBranches(ctx, git.GetBranchesArgs{Project: &client.vcsInfo.Project, RepositoryId: &repository})
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, workitemtracking.ResourceAreaId)
 	if err != nil {
 		return nil, err
 	}
-	for _, branch := range *gitBranchStats {
-		branches = append(branches, *branch.Name)
-	}
-	return branches, nil
+	return &workitemtracking.ClientImpl{Client: *sdkClient}, nil
 }
 
-// DownloadRepository on Azure Repos
-func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) (err error) {
-	wd, err := os.Getwd()
+func (client *AzureReposClient) buildBuildClient(ctx context.Context) (build.Client, error) {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
-		return
+		return nil, err
 	}
-	// Changing dir to localPath will download the repository there.
-	if err = os.Chdir(localPath); err != nil {
-		return
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, build.ResourceAreaId)
+	if err != nil {
+		return nil, err
 	}
-	defer func() {
-		err = errors.Join(err, os.Chdir(wd))
-	}()
-	res, err := client.sendDownloadRepoRequest(ctx, repository, branch)
-	defer func() {
-		if res.Body != nil {
-			err = errors.Join(err, res.Body.Close())
-		}
-	}()
+	return &build.ClientImpl{Client: *sdkClient}, nil
+}
+
+func (client *AzureReposClient) buildTaskAgentClient(ctx context.Context) (taskagent.Client, error) {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
-		return
+		return nil, err
 	}
-	zipFileContent, err := io.ReadAll(res.Body)
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, taskagent.ResourceAreaId)
 	if err != nil {
-		return
+		return nil, err
 	}
-	err = vcsutils.Unzip(zipFileContent, localPath)
+	return &taskagent.ClientImpl{Client: *sdkClient}, nil
+}
+
+func (client *AzureReposClient) buildPolicyClient(ctx context.Context) (policy.Client, error) {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
-	repoInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, policy.ResourceAreaId)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	httpsCloneUrl := repoInfo.CloneInfo.HTTP
-	// Generate .git folder with remote details
-	return vcsutils.CreateDotGitFolderWithRemote(
-		localPath,
-		vcsutils.RemoteName,
-		httpsCloneUrl)
+	return &policy.ClientImpl{Client: *sdkClient}, nil
 }
 
-func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, repository string, branch string) (res *http.Response, err error) {
-	downloadRepoUrl := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip",
-		client.connectionDetails.BaseUrl,
-		client.vcsInfo.Project,
-		repository,
-		branch)
-	client.logger.Debug("Download url:", downloadRepoUrl)
-	headers := map[string]string{
-		"Authorization":  client.connectionDetails.AuthorizationString,
-		"download":       "true",
-		"resolveLfs":     "true",
-		"includeContent": "true",
-	}
-	httpClient := &http.Client{}
-	var req *http.Request
-	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, downloadRepoUrl, nil); err != nil {
-		return
-	}
-	for key, val := range headers {
-		req.Header.Add(key, val)
-	}
-	if res, err = httpClient.Do(req); err != nil {
-		return
+func (client *AzureReposClient) buildServiceHooksClient(ctx context.Context) (servicehooks.Client, error) {
+	connection, err := client.getConnection(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if err = vcsutils.CheckResponseStatusWithBody(res, http.StatusOK); err != nil {
-		return &http.Response{}, err
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, uuid.Nil)
+	if err != nil {
+		return nil, err
 	}
-	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
-	return
-}
-
-func (client *AzureReposClient) GetPullRequestCommentSizeLimit() int {
-	return azurePullRequestCommentSizeLimit
+	return &servicehooks.ClientImpl{Client: *sdkClient}, nil
 }
 
-func (client *AzureReposClient) GetPullRequestDetailsSizeLimit() int {
-	return azurePullRequestDetailsSizeLimit
+func (client *AzureReposClient) buildCoreClient(ctx context.Context) (core.Client, error) {
+	connection, err := client.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, core.ResourceAreaId)
+	if err != nil {
+		return nil, err
+	}
+	return &core.ClientImpl{Client: *sdkClient}, nil
 }
 
-// CreatePullRequest on Azure Repos
-func (client *AzureReposClient) CreatePullRequest(ctx context.Context, _, repository, sourceBranch, targetBranch, title, description string) error {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+func (client *AzureReposClient) buildSecurityClient(ctx context.Context) (security.Client, error) {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	sourceBranch = vcsutils.AddBranchPrefix(sourceBranch)
-	targetBranch = vcsutils.AddBranchPrefix(targetBranch)
-	client.logger.Debug(vcsutils.CreatingPullRequest, title)
-	_, err = azureReposGitClient.CreatePullRequest(ctx, git.CreatePullRequestArgs{
-		GitPullRequestToCreate: &git.GitPullRequest{
-			Description:   &description,
-			SourceRefName: &sourceBranch,
-			TargetRefName: &targetBranch,
-			Title:         &title,
-		},
-		RepositoryId: &repository,
-		Project:      &client.vcsInfo.Project,
-	})
-	return err
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	return &security.ClientImpl{Client: *sdkClient}, nil
 }
 
-// UpdatePullRequest on Azure Repos
-func (client *AzureReposClient) UpdatePullRequest(ctx context.Context, _, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+func (client *AzureReposClient) buildLocationClient(ctx context.Context) (location.Client, error) {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	targetBranchName = vcsutils.AddBranchPrefix(targetBranchName)
-	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
-	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
-		GitPullRequestToUpdate: &git.GitPullRequest{
-			Description:   vcsutils.GetNilIfZeroVal(body),
-			Status:        azureMapPullRequestState(state),
-			TargetRefName: vcsutils.GetNilIfZeroVal(targetBranchName),
-			Title:         vcsutils.GetNilIfZeroVal(title),
-		},
-		RepositoryId:  vcsutils.GetNilIfZeroVal(repository),
-		PullRequestId: vcsutils.GetNilIfZeroVal(prId),
-		Project:       vcsutils.GetNilIfZeroVal(client.vcsInfo.Project),
-	})
-	return err
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	return &location.ClientImpl{Client: *sdkClient}, nil
 }
 
-// AddPullRequestComment on Azure Repos
-func (client *AzureReposClient) AddPullRequestComment(ctx context.Context, _, repository, content string, pullRequestID int) error {
-	return client.addPullRequestComment(ctx, repository, pullRequestID, PullRequestComment{CommentInfo: CommentInfo{Content: content}})
+// newAzureDevOpsClient returns the client which sends the requests of the SDK client of a resource area, or of the organization if the resource area is nil.
+// The SDK resolves the URLs of resource areas with its own HTTP client, so with a custom HTTP client, connection settings, NTLM authentication
+// or a pinned API version the organization URL is used, which serves the Git, core, build, policy and work item tracking resource areas.
+// An Azure DevOps Server collection serves all the resource areas, so they aren't resolved.
+func (client *AzureReposClient) newAzureDevOpsClient(ctx context.Context, connection *azuredevops.Connection, resourceAreaId uuid.UUID) (*azuredevops.Client, error) {
+	if !isDefaultHttpClient(client.vcsInfo) || client.vcsInfo.AzureNTLMAuth || client.vcsInfo.AzureAPIVersion != "" {
+		return azuredevops.NewClientWithOptions(connection, connection.BaseUrl, azuredevops.WithHTTPClient(client.httpClient)), nil
+	}
+	if resourceAreaId == uuid.Nil || client.vcsInfo.AzureDevOpsServer {
+		return connection.GetClientByUrl(connection.BaseUrl), nil
+	}
+	return connection.GetClientByResourceAreaId(ctx, resourceAreaId)
 }
 
-// AddPullRequestReviewComments on Azure Repos
-func (client *AzureReposClient) AddPullRequestReviewComments(ctx context.Context, _, repository string, pullRequestID int, comments ...PullRequestComment) error {
-	if len(comments) == 0 {
-		return errors.New(vcsutils.ErrNoCommentsProvided)
-	}
-	for _, comment := range comments {
-		if err := client.addPullRequestComment(ctx, repository, pullRequestID, comment); err != nil {
-			return err
+// getConnection returns the connection details. With a token provider, the connection is recreated whenever the token changes.
+func (client *AzureReposClient) getConnection(ctx context.Context) (*azuredevops.Connection, error) {
+	if client.vcsInfo.TokenProvider == nil {
+		if client.connectionDetails == nil {
+			return nil, errors.New("connection details wasn't initialized")
 		}
+		return client.connectionDetails, nil
 	}
-	return nil
+	token, err := client.vcsInfo.TokenProvider(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an access token from the token provider: %w", err)
+	}
+	client.connectionLock.Lock()
+	defer client.connectionLock.Unlock()
+	if client.connectionDetails == nil || token != client.connectionToken {
+		client.connectionDetails = client.newConnection(token)
+		client.connectionToken = token
+	}
+	return client.connectionDetails, nil
 }
 
-func (client *AzureReposClient) addPullRequestComment(ctx context.Context, repository string, pullRequestID int, comment PullRequestComment) error {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+// TestConnection on Azure Repos
+func (client *AzureReposClient) TestConnection(ctx context.Context) error {
+	connection, err := client.getConnection(ctx)
 	if err != nil {
 		return err
 	}
-	threadArgs := getThreadArgs(repository, client.vcsInfo.Project, pullRequestID, comment)
-	_, err = azureReposGitClient.CreateThread(ctx, threadArgs)
+	sdkClient, err := client.newAzureDevOpsClient(ctx, connection, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	_, err = sdkClient.GetResourceAreas(ctx)
 	return err
 }
 
-func getThreadArgs(repository, project string, prId int, comment PullRequestComment) git.CreateThreadArgs {
-	filePath := vcsutils.GetPullRequestFilePath(comment.NewFilePath)
-	return git.CreateThreadArgs{
-		CommentThread: &git.GitPullRequestCommentThread{
-			Comments: &[]git.Comment{{Content: &comment.Content}},
-			Status:   &git.CommentThreadStatusValues.Active,
-			ThreadContext: &git.CommentThreadContext{
-				FilePath:       &filePath,
-				LeftFileStart:  &git.CommentPosition{Line: &comment.OriginalStartLine, Offset: &comment.OriginalStartColumn},
-				LeftFileEnd:    &git.CommentPosition{Line: &comment.OriginalEndLine, Offset: &comment.OriginalEndColumn},
-				RightFileStart: &git.CommentPosition{Line: &comment.NewStartLine, Offset: &comment.NewStartColumn},
-				RightFileEnd:   &git.CommentPosition{Line: &comment.NewEndLine, Offset: &comment.NewEndColumn},
-			},
-		},
-		RepositoryId:  &repository,
-		PullRequestId: &prId,
-		Project:       &project,
+// TestConnectionWithScopes on Azure Repos.
+// The permissions of the authenticated user in the client's project are checked, rather than the scopes of the token itself.
+func (client *AzureReposClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	if err := client.TestConnection(ctx); err != nil {
+		return err
 	}
-}
-
-// ListPullRequestReviewComments on Azure Repos
-func (client *AzureReposClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
-	return client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
-}
-
-// ListPullRequestComments on Azure Repos
-func (client *AzureReposClient) ListPullRequestComments(ctx context.Context, _, repository string, pullRequestID int) ([]CommentInfo, error) {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if len(scopes) == 0 {
+		return nil
+	}
+	projectID, err := client.getProjectUUID(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	threads, err := azureReposGitClient.GetThreads(ctx, git.GetThreadsArgs{
-		RepositoryId:  &repository,
-		PullRequestId: &pullRequestID,
-		Project:       &client.vcsInfo.Project,
-	})
+	securityClient, err := client.buildSecurityClient(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var commentInfo []CommentInfo
-	for _, thread := range *threads {
-		if thread.IsDeleted != nil && *thread.IsDeleted {
+	hasPermissions := make(map[TokenScope]bool, len(scopes))
+	for _, scope := range scopes {
+		permission, exists := azureReposScopePermissions[scope]
+		if !exists {
 			continue
 		}
-		var commentsAggregator strings.Builder
-		for _, comment := range *thread.Comments {
-			if comment.IsDeleted != nil && *comment.IsDeleted {
-				continue
-			}
-			_, err = commentsAggregator.WriteString(
-				fmt.Sprintf("Author: %s, Id: %d, Content:%s\n",
-					*comment.Author.DisplayName,
-					*comment.Id,
-					*comment.Content))
-			if err != nil {
-				return nil, err
-			}
-		}
-		commentInfo = append(commentInfo, CommentInfo{
-			ID:      int64(*thread.Id),
-			Created: thread.PublishedDate.Time,
-			Content: commentsAggregator.String(),
+		results, err := securityClient.HasPermissions(ctx, security.HasPermissionsArgs{
+			SecurityNamespaceId: &permission.securityNamespaceId,
+			Permissions:         &permission.permissionBits,
+			Tokens:              vcsutils.PointerOf(permission.tokenPrefix + projectID.String()),
 		})
-	}
-	return commentInfo, nil
-}
-
-// DeletePullRequestReviewComments on Azure Repos
-func (client *AzureReposClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
-	for _, comment := range comments {
-		if err := client.DeletePullRequestComment(ctx, owner, repository, pullRequestID, int(comment.ID)); err != nil {
+		if err != nil {
 			return err
 		}
+		hasPermissions[scope] = results != nil && len(*results) > 0 && (*results)[0]
 	}
-	return nil
+	return validateTokenScopes(scopes, func(scope TokenScope) bool {
+		return hasPermissions[scope]
+	})
 }
 
-// DeletePullRequestComment on Azure Repos
-func (client *AzureReposClient) DeletePullRequestComment(ctx context.Context, _, repository string, pullRequestID, commentID int) error {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+// getProjectUUID returns the ID of the client's project
+func (client *AzureReposClient) getProjectUUID(ctx context.Context) (uuid.UUID, error) {
+	coreClient, err := client.buildCoreClient(ctx)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
-	firstCommentInThreadID := 1
-	return azureReposGitClient.DeleteComment(ctx, git.DeleteCommentArgs{
-		RepositoryId:  &repository,
-		PullRequestId: &pullRequestID,
-		ThreadId:      &commentID,
-		Project:       &client.vcsInfo.Project,
-		CommentId:     &firstCommentInThreadID,
-	})
+	project, err := coreClient.GetProject(ctx, core.GetProjectArgs{ProjectId: &client.vcsInfo.Project})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if project.Id == nil {
+		return uuid.Nil, fmt.Errorf("couldn't find the ID of the %s project", client.vcsInfo.Project)
+	}
+	return *project.Id, nil
 }
 
-// ListOpenPullRequestsWithBody on Azure Repos
-func (client *AzureReposClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, true)
+type azureReposScopePermission struct {
+	securityNamespaceId uuid.UUID
+	permissionBits      int
+	tokenPrefix         string
 }
 
-// ListOpenPullRequests on Azure Repos
-func (client *AzureReposClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, false)
+// azureReposScopePermissions maps each token scope to the Azure DevOps permission which grants it
+var azureReposScopePermissions = map[TokenScope]azureReposScopePermission{
+	// The GenericRead permission of the Git Repositories security namespace
+	RepositoryReadScope: {uuid.MustParse("2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87"), 2, "repoV2/"},
+	// The GenericContribute permission of the Git Repositories security namespace
+	RepositoryWriteScope: {uuid.MustParse("2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87"), 4, "repoV2/"},
+	// The EditSubscriptions permission of the ServiceHooks security namespace
+	WebhookAdminScope: {uuid.MustParse("cb594ebe-87dd-4fc9-ac2c-6a10a4c92046"), 2, "PublisherSecurity/"},
 }
 
-func (client *AzureReposClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+// GetAuthenticatedUser on Azure Repos.
+// The username of Azure Repos users is their account name, which is usually their email address.
+func (client *AzureReposClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	locationClient, err := client.buildLocationClient(ctx)
 	if err != nil {
-		return nil, err
+		return UserInfo{}, err
 	}
-	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
-	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
-		RepositoryId:   &repository,
-		Project:        &client.vcsInfo.Project,
-		SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active},
-	})
+	connectionData, err := locationClient.GetConnectionData(ctx, location.GetConnectionDataArgs{})
 	if err != nil {
-		return nil, err
+		return UserInfo{}, err
 	}
-	var pullRequestsInfo []PullRequestInfo
-	for _, pullRequest := range *pullRequests {
-		pullRequestDetails := parsePullRequestDetails(client, pullRequest, owner, repository, withBody)
-		pullRequestsInfo = append(pullRequestsInfo, pullRequestDetails)
+	user := connectionData.AuthenticatedUser
+	if user == nil {
+		return UserInfo{}, errors.New("the token isn't associated with an Azure Repos user")
 	}
-	return pullRequestsInfo, nil
-}
-
-// GetPullRequestById in Azure Repos
-func (client *AzureReposClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
+	userInfo := UserInfo{DisplayName: vcsutils.DefaultIfNotNil(user.ProviderDisplayName)}
+	if properties, ok := user.Properties.(map[string]interface{}); ok {
+		if account, ok := properties["Account"].(map[string]interface{}); ok {
+			userInfo.Username, _ = account["$value"].(string)
+		}
+		if mail, ok := properties["Mail"].(map[string]interface{}); ok {
+			userInfo.Email, _ = mail["$value"].(string)
+		}
+	}
+	if userInfo.Email == "" && strings.Contains(userInfo.Username, "@") {
+		userInfo.Email = userInfo.Username
+	}
+	return userInfo, nil
+}
+
+// ListRepositories on Azure Repos.
+// When the client isn't configured with a project, the repositories of all the projects in the organization are listed.
+func (client *AzureReposClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
-		return
+		return nil, err
 	}
-	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
-	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
-		PullRequestId: &pullRequestId,
-		Project:       &client.vcsInfo.Project,
-	})
-	if err != nil {
-		return
+	projects := []string{client.vcsInfo.Project}
+	if client.vcsInfo.Project == "" {
+		if projects, err = client.listProjects(ctx); err != nil {
+			return nil, err
+		}
 	}
-	pullRequestInfo = parsePullRequestDetails(client, *pullRequest, owner, repository, false)
-	return
+	repositories := make(map[string][]string)
+	for _, project := range projects {
+		resp, err := azureReposGitClient.GetRepositories(ctx, git.GetRepositoriesArgs{Project: vcsutils.PointerOf(project)})
+		if err != nil {
+			return repositories, err
+		}
+		for _, repo := range *resp {
+			repositories[project] = append(repositories[project], *repo.Name)
+		}
+	}
+	return repositories, nil
 }
 
-// GetLatestCommit on Azure Repos
-func (client *AzureReposClient) GetLatestCommit(ctx context.Context, _, repository, branch string) (CommitInfo, error) {
-	commitsInfo, err := client.GetCommits(ctx, "", repository, branch)
+// Lists the names of all the projects in the organization
+func (client *AzureReposClient) listProjects(ctx context.Context) ([]string, error) {
+	coreClient, err := client.buildCoreClient(ctx)
 	if err != nil {
-		return CommitInfo{}, err
+		return nil, err
+	}
+	var projects []string
+	args := core.GetProjectsArgs{}
+	for {
+		response, err := coreClient.GetProjects(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range response.Value {
+			projects = append(projects, vcsutils.DefaultIfNotNil(project.Name))
+		}
+		if response.ContinuationToken == "" {
+			return projects, nil
+		}
+		continuationToken, err := strconv.Atoi(response.ContinuationToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the continuation token of the projects listing: %w", err)
+		}
+		args.ContinuationToken = &continuationToken
 	}
+}
 
-	var latestCommit CommitInfo
-	if len(commitsInfo) > 0 {
-		latestCommit = commitsInfo[0]
+// ListRepositoriesWithOptions on Azure Repos.
+// The API doesn't paginate the repositories, hence all the repositories are listed and the page is taken from them.
+func (client *AzureReposClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	repositories, err := client.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return latestCommit, nil
+	return getRepositoriesPage(repositories, options), nil
 }
 
-// GetCommits on Azure Repos
-func (client *AzureReposClient) GetCommits(ctx context.Context, _, repository, branch string) ([]CommitInfo, error) {
+// ListAppInstallations on Azure Repos
+func (client *AzureReposClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, getUnsupportedInAzureError("list app installations")
+}
+
+// ListInstallationRepositories on Azure Repos
+func (client *AzureReposClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, getUnsupportedInAzureError("list installation repositories")
+}
+
+// ListBranches on Azure Repos
+func (client *AzureReposClient) ListBranches(ctx context.Context, _, repository string) ([]string, error) {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
-		RepositoryId:   &repository,
-		Project:        &client.vcsInfo.Project,
-		SearchCriteria: &git.GitQueryCommitsCriteria{ItemVersion: &git.GitVersionDescriptor{Version: &branch, VersionType: &git.GitVersionTypeValues.Branch}},
-	})
+	var branches []string
+	gitBranchStats, err := azureReposGitClient.GetBranches(ctx, git.GetBranchesArgs{Project: &client.vcsInfo.Project, RepositoryId: &repository})
 	if err != nil {
 		return nil, err
 	}
-	if commits == nil {
-		return nil, fmt.Errorf("could not retrieve commits for <%s/%s>", repository, branch)
+	for _, branch := range *gitBranchStats {
+		branches = append(branches, *branch.Name)
 	}
+	return branches, nil
+}
 
-	var commitsInfo []CommitInfo
-	for _, commit := range *commits {
-		commitInfo := mapAzureReposCommitsToCommitInfo(commit)
-		commitsInfo = append(commitsInfo, commitInfo)
+// ListBranchesWithOptions on Azure Repos.
+// The API doesn't paginate the branches, hence all the branches are listed and the page is taken from them.
+func (client *AzureReposClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	branches, err := client.ListBranches(ctx, owner, repository)
+	if err != nil {
+		return nil, err
 	}
-	return commitsInfo, nil
+	sort.Strings(branches)
+	return getListPage(branches, options), nil
 }
 
-func mapAzureReposCommitsToCommitInfo(commit git.GitCommitRef) CommitInfo {
-	var authorName, authorEmail string
-	if commit.Author != nil {
-		authorName = vcsutils.DefaultIfNotNil(commit.Author.Name)
-		authorEmail = vcsutils.DefaultIfNotNil(commit.Author.Email)
+// CreateBranch on Azure Repos
+func (client *AzureReposClient) CreateBranch(ctx context.Context, _, repository, sourceRef, newBranch string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "sourceRef": sourceRef, "newBranch": newBranch})
+	if err != nil {
+		return err
 	}
-	var committerName string
-	var timestamp int64
-	if commit.Committer != nil {
-		committerName = vcsutils.DefaultIfNotNil(commit.Committer.Name)
-		timestamp = vcsutils.DefaultIfNotNil(commit.Committer.Date).Time.Unix()
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
 	}
-	return CommitInfo{
-		Hash:          vcsutils.DefaultIfNotNil(commit.CommitId),
-		AuthorName:    authorName,
-		CommitterName: committerName,
-		Url:           vcsutils.DefaultIfNotNil(commit.Url),
-		Timestamp:     timestamp,
-		Message:       vcsutils.DefaultIfNotNil(commit.Comment),
-		ParentHashes:  vcsutils.DefaultIfNotNil(commit.Parents),
-		AuthorEmail:   authorEmail,
+
+	sourceSha := sourceRef
+	if !plumbing.IsHash(sourceRef) {
+		if sourceSha, err = client.getBranchLatestCommitSha(ctx, azureReposGitClient, repository, sourceRef); err != nil {
+			return err
+		}
 	}
+	return client.updateRef(ctx, azureReposGitClient, repository, vcsutils.AddBranchPrefix(newBranch), plumbing.ZeroHash.String(), sourceSha)
 }
 
-func getUnsupportedInAzureError(functionName string) error {
-	return fmt.Errorf("%s is currently not supported for Azure Repos", functionName)
+// DeleteBranch on Azure Repos
+func (client *AzureReposClient) DeleteBranch(ctx context.Context, _, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// Azure Repos deletes a ref by updating it from its current commit to the zero object ID
+	branchSha, err := client.getBranchLatestCommitSha(ctx, azureReposGitClient, repository, branch)
+	if err != nil {
+		return err
+	}
+	return client.updateRef(ctx, azureReposGitClient, repository, vcsutils.AddBranchPrefix(branch), branchSha, plumbing.ZeroHash.String())
 }
 
-// AddSshKeyToRepository on Azure Repos
-func (client *AzureReposClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
-	return getUnsupportedInAzureError("add ssh key to repository")
+// GetDefaultBranch on Azure Repos
+func (client *AzureReposClient) GetDefaultBranch(ctx context.Context, _, repository string) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return "", err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	response, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(vcsutils.DefaultIfNotNil(response.DefaultBranch), "refs/heads/"), nil
 }
 
-// GetRepositoryInfo on Azure Repos
-func (client *AzureReposClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+// SetDefaultBranch on Azure Repos
+func (client *AzureReposClient) SetDefaultBranch(ctx context.Context, _, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return err
+	}
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
-		return RepositoryInfo{}, err
+		return err
 	}
+	// The repository is updated by its ID rather than by its name
 	response, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
 		RepositoryId: &repository,
 		Project:      &client.vcsInfo.Project,
 	})
 	if err != nil {
-		return RepositoryInfo{}, fmt.Errorf("an error occured while retrieving <%s/%s/%s> repository info:\n%s", owner, client.vcsInfo.Project, repository, err.Error())
+		return err
 	}
-	if response == nil {
-		return RepositoryInfo{}, fmt.Errorf("failed to retreive <%s/%s/%s> repository info, received empty response", owner, client.vcsInfo.Project, repository)
+	_, err = azureReposGitClient.UpdateRepository(ctx, git.UpdateRepositoryArgs{
+		NewRepositoryInfo: &git.GitRepository{DefaultBranch: vcsutils.PointerOf(vcsutils.AddBranchPrefix(branch))},
+		RepositoryId:      response.Id,
+		Project:           &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// GetBranchProtection on Azure Repos.
+// Azure Repos blocks direct pushes to branches with blocking policies.
+func (client *AzureReposClient) GetBranchProtection(ctx context.Context, _, repository, branch string) (BranchProtectionInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return BranchProtectionInfo{}, err
 	}
-	if response.Project == nil {
-		return RepositoryInfo{}, fmt.Errorf("failed to retreive <%s/%s/%s> repository info, received empty project info", owner, client.vcsInfo.Project, repository)
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	configurations, _, err := client.getBranchPolicyConfigurations(ctx, azureReposGitClient, repository, branch)
+	if err != nil {
+		return BranchProtectionInfo{}, err
 	}
 
-	visibility := Private
-	visibilityFromResponse := *response.Project.Visibility
-	if visibilityFromResponse == core.ProjectVisibilityValues.Public {
-		visibility = Public
+	var branchProtection BranchProtectionInfo
+	for _, configuration := range configurations {
+		if !vcsutils.DefaultIfNotNil(configuration.IsEnabled) || !vcsutils.DefaultIfNotNil(configuration.IsBlocking) {
+			continue
+		}
+		branchProtection.RestrictPushes = true
+		settings, _ := configuration.Settings.(map[string]interface{})
+		switch *configuration.Type.Id {
+		case azureMinimumReviewersPolicyType:
+			if minimumApproverCount, ok := settings["minimumApproverCount"].(float64); ok {
+				branchProtection.RequiredApprovals = int(minimumApproverCount)
+			}
+		case azureStatusPolicyType:
+			if statusGenre, ok := settings["statusGenre"].(string); ok {
+				branchProtection.RequiredStatusChecks = append(branchProtection.RequiredStatusChecks, statusGenre)
+			}
+		}
 	}
-	return RepositoryInfo{
-		CloneInfo:            CloneInfo{HTTP: *response.RemoteUrl, SSH: *response.SshUrl},
-		RepositoryVisibility: visibility,
-	}, nil
+	return branchProtection, nil
 }
 
-// GetCommitBySha on Azure Repos
-func (client *AzureReposClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
-	return CommitInfo{}, getUnsupportedInAzureError("get commit by sha")
+// SetBranchProtection on Azure Repos.
+// Replaces the minimum reviewers, status and merge strategy policies of the branch.
+// Required status checks match statuses set by SetCommitStatus, whose genre is the status title and name is the owner.
+// Pushes are restricted by any blocking policy, hence a merge strategy policy is set if no other policy is required.
+func (client *AzureReposClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtectionInfo) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	configurations, repositoryId, err := client.getBranchPolicyConfigurations(ctx, azureReposGitClient, repository, branch)
+	if err != nil {
+		return err
+	}
+	policyClient, err := client.buildPolicyClient(ctx)
+	if err != nil {
+		return err
+	}
+	for _, configuration := range configurations {
+		switch *configuration.Type.Id {
+		case azureMinimumReviewersPolicyType, azureStatusPolicyType, azureMergeStrategyPolicyType:
+			err = policyClient.DeletePolicyConfiguration(ctx, policy.DeletePolicyConfigurationArgs{
+				Project:         &client.vcsInfo.Project,
+				ConfigurationId: configuration.Id,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	scope := []map[string]interface{}{{"repositoryId": repositoryId.String(), "refName": vcsutils.AddBranchPrefix(branch), "matchKind": "exact"}}
+	newPolicies := map[uuid.UUID][]map[string]interface{}{}
+	if protection.RequiredApprovals > 0 {
+		newPolicies[azureMinimumReviewersPolicyType] = append(newPolicies[azureMinimumReviewersPolicyType], map[string]interface{}{
+			"minimumApproverCount": protection.RequiredApprovals,
+			"creatorVoteCounts":    false,
+			"scope":                scope,
+		})
+	}
+	for _, statusCheck := range protection.RequiredStatusChecks {
+		newPolicies[azureStatusPolicyType] = append(newPolicies[azureStatusPolicyType], map[string]interface{}{
+			"statusName":  owner,
+			"statusGenre": statusCheck,
+			"scope":       scope,
+		})
+	}
+	if protection.RestrictPushes && len(newPolicies) == 0 {
+		newPolicies[azureMergeStrategyPolicyType] = append(newPolicies[azureMergeStrategyPolicyType], map[string]interface{}{
+			"allowNoFastForward": true,
+			"allowSquash":        true,
+			"allowRebase":        true,
+			"allowRebaseMerge":   true,
+			"scope":              scope,
+		})
+	}
+	for _, policyType := range []uuid.UUID{azureMinimumReviewersPolicyType, azureStatusPolicyType, azureMergeStrategyPolicyType} {
+		for _, settings := range newPolicies[policyType] {
+			_, err = policyClient.CreatePolicyConfiguration(ctx, policy.CreatePolicyConfigurationArgs{
+				Project: &client.vcsInfo.Project,
+				Configuration: &policy.PolicyConfiguration{
+					IsEnabled:  vcsutils.PointerOf(true),
+					IsBlocking: vcsutils.PointerOf(true),
+					Type:       &policy.PolicyTypeRef{Id: vcsutils.PointerOf(policyType)},
+					Settings:   settings,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-// CreateLabel on Azure Repos
-func (client *AzureReposClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	return getUnsupportedInAzureError("create label")
+// Returns the policy configurations of a branch, and the ID of its repository
+func (client *AzureReposClient) getBranchPolicyConfigurations(ctx context.Context, azureReposGitClient git.Client, repository, branch string) ([]policy.PolicyConfiguration, *uuid.UUID, error) {
+	repositoryInfo, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if repositoryInfo.Id == nil {
+		return nil, nil, fmt.Errorf("couldn't find the ID of repository %s", repository)
+	}
+
+	var configurations []policy.PolicyConfiguration
+	var continuationToken *string
+	for {
+		response, err := azureReposGitClient.GetPolicyConfigurations(ctx, git.GetPolicyConfigurationsArgs{
+			Project:           &client.vcsInfo.Project,
+			RepositoryId:      repositoryInfo.Id,
+			RefName:           vcsutils.PointerOf(vcsutils.AddBranchPrefix(branch)),
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, configuration := range vcsutils.DefaultIfNotNil(response.PolicyConfigurations) {
+			if configuration.Type != nil && configuration.Type.Id != nil && !vcsutils.DefaultIfNotNil(configuration.IsDeleted) {
+				configurations = append(configurations, configuration)
+			}
+		}
+		if response.ContinuationToken == nil || *response.ContinuationToken == "" {
+			return configurations, repositoryInfo.Id, nil
+		}
+		continuationToken = response.ContinuationToken
+	}
 }
 
-// GetLabel on Azure Repos
-func (client *AzureReposClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	return nil, getUnsupportedInAzureError("get label")
+// ListTags on Azure Repos
+func (client *AzureReposClient) ListTags(ctx context.Context, _, repository string) ([]TagInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []TagInfo
+	var continuationToken *string
+	for {
+		refs, err := azureReposGitClient.GetRefs(ctx, git.GetRefsArgs{
+			RepositoryId:      &repository,
+			Project:           &client.vcsInfo.Project,
+			Filter:            vcsutils.PointerOf(strings.TrimPrefix(vcsutils.TagPrefix, "refs/")),
+			PeelTags:          vcsutils.PointerOf(true),
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs.Value {
+			// Annotated tags point to a tag object, which is peeled to the tagged commit
+			commitSha := vcsutils.DefaultIfNotNil(ref.PeeledObjectId)
+			if commitSha == "" {
+				commitSha = vcsutils.DefaultIfNotNil(ref.ObjectId)
+			}
+			results = append(results, TagInfo{
+				Name:      strings.TrimPrefix(vcsutils.DefaultIfNotNil(ref.Name), vcsutils.TagPrefix),
+				CommitSha: commitSha,
+			})
+		}
+		if refs.ContinuationToken == "" {
+			return results, nil
+		}
+		continuationToken = &refs.ContinuationToken
+	}
 }
 
-// ListPullRequestLabels on Azure Repos
-func (client *AzureReposClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	return nil, getUnsupportedInAzureError("list pull request labels")
+// CreateTag on Azure Repos
+func (client *AzureReposClient) CreateTag(ctx context.Context, _, repository, tagName, sourceRef, message string) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "tagName": tagName, "sourceRef": sourceRef})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	sourceSha := sourceRef
+	if !plumbing.IsHash(sourceRef) {
+		if sourceSha, err = client.getBranchLatestCommitSha(ctx, azureReposGitClient, repository, sourceRef); err != nil {
+			return err
+		}
+	}
+	if message == "" {
+		return client.updateRef(ctx, azureReposGitClient, repository, vcsutils.TagPrefix+tagName, plumbing.ZeroHash.String(), sourceSha)
+	}
+	_, err = azureReposGitClient.CreateAnnotatedTag(ctx, git.CreateAnnotatedTagArgs{
+		TagObject: &git.GitAnnotatedTag{
+			Name:         &tagName,
+			Message:      &message,
+			TaggedObject: &git.GitObject{ObjectId: &sourceSha},
+		},
+		Project:      &client.vcsInfo.Project,
+		RepositoryId: &repository,
+	})
+	return err
 }
 
-// UnlabelPullRequest on Azure Repos
-func (client *AzureReposClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	return getUnsupportedInAzureError("unlabel pull request")
+// GetTagInfo on Azure Repos
+func (client *AzureReposClient) GetTagInfo(ctx context.Context, _, repository, tagName string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "tagName": tagName}); err != nil {
+		return CommitInfo{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+		SearchCriteria: &git.GitQueryCommitsCriteria{
+			ItemVersion: &git.GitVersionDescriptor{Version: &tagName, VersionType: vcsutils.PointerOf(git.GitVersionTypeValues.Tag)},
+			Top:         vcsutils.PointerOf(1),
+		},
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if len(vcsutils.DefaultIfNotNil(commits)) == 0 {
+		return CommitInfo{}, fmt.Errorf("couldn't find the commit of tag %s", tagName)
+	}
+	return mapAzureReposCommitsToCommitInfo((*commits)[0]), nil
 }
 
-// UploadCodeScanning on Azure Repos
-func (client *AzureReposClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
-	return "", getUnsupportedInAzureError("upload code scanning")
+// CreateRelease on Azure Repos
+func (client *AzureReposClient) CreateRelease(ctx context.Context, owner, repository, tagName, name, releaseNotes string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, getUnsupportedInAzureError("create release")
 }
 
-// CreateWebhook on Azure Repos
-func (client *AzureReposClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
-	return "", "", getUnsupportedInAzureError("create webhook")
+// ListReleases on Azure Repos
+func (client *AzureReposClient) ListReleases(ctx context.Context, owner, repository string) ([]ReleaseInfo, error) {
+	return nil, getUnsupportedInAzureError("list releases")
 }
 
-// UpdateWebhook on Azure Repos
-func (client *AzureReposClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
-	return getUnsupportedInAzureError("update webhook")
+// GetLatestRelease on Azure Repos
+func (client *AzureReposClient) GetLatestRelease(ctx context.Context, owner, repository string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, getUnsupportedInAzureError("get latest release")
 }
 
-// DeleteWebhook on Azure Repos
-func (client *AzureReposClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
-	return getUnsupportedInAzureError("delete webhook")
+// UploadReleaseAsset on Azure Repos
+func (client *AzureReposClient) UploadReleaseAsset(ctx context.Context, owner, repository, releaseID, name string, asset io.Reader) (string, error) {
+	return "", getUnsupportedInAzureError("upload release asset")
 }
 
-// SetCommitStatus on Azure Repos
-func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
+func (client *AzureReposClient) getBranchLatestCommitSha(ctx context.Context, azureReposGitClient git.Client, repository, branch string) (string, error) {
+	branchStats, err := azureReposGitClient.GetBranch(ctx, git.GetBranchArgs{
+		RepositoryId: &repository,
+		Name:         &branch,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return "", err
+	}
+	if branchStats.Commit == nil || branchStats.Commit.CommitId == nil {
+		return "", fmt.Errorf("couldn't find the latest commit of branch %s", branch)
+	}
+	return *branchStats.Commit.CommitId, nil
+}
+
+// updateRef moves a ref from oldObjectId to newObjectId. A zero object ID creates or deletes the ref.
+func (client *AzureReposClient) updateRef(ctx context.Context, azureReposGitClient git.Client, repository, refName, oldObjectId, newObjectId string) error {
+	refUpdateResults, err := azureReposGitClient.UpdateRefs(ctx, git.UpdateRefsArgs{
+		RefUpdates:   &[]git.GitRefUpdate{{Name: &refName, OldObjectId: &oldObjectId, NewObjectId: &newObjectId}},
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	for _, refUpdateResult := range vcsutils.DefaultIfNotNil(refUpdateResults) {
+		if !vcsutils.DefaultIfNotNil(refUpdateResult.Success) {
+			return fmt.Errorf("failed to update ref %s: %s", refName, vcsutils.DefaultIfNotNil(refUpdateResult.UpdateStatus))
+		}
+	}
+	return nil
+}
+
+// DownloadRepository on Azure Repos
+func (client *AzureReposClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	if err := client.extractItemArchive(ctx, repository, branch, "", localPath); err != nil {
+		return err
+	}
+	return client.createDotGitFolder(ctx, owner, repository, localPath)
+}
+
+// DownloadRepositoryPaths on Azure Repos.
+// An archive is downloaded for each of the paths.
+func (client *AzureReposClient) DownloadRepositoryPaths(ctx context.Context, owner, repository, ref string, paths []string, localPath string) error {
+	paths, err := normalizeDownloadPaths(paths)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(paths, "") {
+		// The root of the repository contains all the other paths
+		paths = []string{""}
+	}
+	for _, itemPath := range paths {
+		// The archive of an item is rooted at the item itself, so it's extracted to the parent directory of the item
+		if err = client.extractItemArchive(ctx, repository, ref, itemPath, filepath.Join(localPath, filepath.FromSlash(path.Dir(itemPath)))); err != nil {
+			return err
+		}
+	}
+	return client.createDotGitFolder(ctx, owner, repository, localPath)
+}
+
+// extractItemArchive downloads the zip archive of itemPath, or of the whole repository if it's empty, and extracts it to destination
+func (client *AzureReposClient) extractItemArchive(ctx context.Context, repository, branch, itemPath, destination string) (err error) {
+	res, err := client.sendDownloadRepoRequest(ctx, repository, branch, itemPath)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, res.Body.Close())
+	}()
+	// The items API archives only as zip
+	if err = vcsutils.ExtractArchive(destination, vcsutils.LimitReader(res.Body, client.vcsInfo.MaxDownloadSize), vcsutils.ZipArchive, false, nil, client.vcsInfo.ExtractionLimits); err != nil {
+		return
+	}
+	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
+	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	return
+}
+
+func (client *AzureReposClient) createDotGitFolder(ctx context.Context, owner, repository, localPath string) error {
+	repoInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
+	if err != nil {
+		return err
+	}
+	httpsCloneUrl := repoInfo.CloneInfo.HTTP
+	// Generate .git folder with remote details
+	return vcsutils.CreateDotGitFolderWithRemote(
+		localPath,
+		vcsutils.RemoteName,
+		httpsCloneUrl)
+}
+
+func (client *AzureReposClient) sendDownloadRepoRequest(ctx context.Context, repository, branch, itemPath string) (res *http.Response, err error) {
+	connection, err := client.getConnection(ctx)
+	if err != nil {
+		return
+	}
+	downloadRepoUrl := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items/items?path=%s&versionDescriptor[version]=%s&$format=zip",
+		connection.BaseUrl,
+		client.vcsInfo.Project,
+		repository,
+		(&url.URL{Path: "/" + itemPath}).EscapedPath(),
+		branch)
+	if client.vcsInfo.AzureAPIVersion != "" {
+		downloadRepoUrl += "&api-version=" + client.vcsInfo.AzureAPIVersion
+	}
+	client.logger.Debug("Download url:", downloadRepoUrl)
+	headers := map[string]string{
+		"Authorization":  connection.AuthorizationString,
+		"download":       "true",
+		"resolveLfs":     "true",
+		"includeContent": "true",
+	}
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, downloadRepoUrl, nil); err != nil {
+		return
+	}
+	for key, val := range headers {
+		req.Header.Add(key, val)
+	}
+	if res, err = client.httpClient.Do(req); err != nil {
+		return
+	}
+	if err = vcsutils.CheckResponseStatusWithBody(res, http.StatusOK); err != nil {
+		return &http.Response{}, err
+	}
+	return
+}
+
+func (client *AzureReposClient) GetPullRequestCommentSizeLimit() int {
+	return azurePullRequestCommentSizeLimit
+}
+
+func (client *AzureReposClient) GetPullRequestDetailsSizeLimit() int {
+	return azurePullRequestDetailsSizeLimit
+}
+
+// CreatePullRequest on Azure Repos
+func (client *AzureReposClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) (PullRequestInfo, error) {
+	return client.CreatePullRequestWithOptions(ctx, owner, repository, sourceBranch, targetBranch, title, description, CreatePullRequestOptions{})
+}
+
+// CreatePullRequestWithOptions on Azure Repos.
+// The reviewers are given by their identity IDs.
+func (client *AzureReposClient) CreatePullRequestWithOptions(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string, options CreatePullRequestOptions) (PullRequestInfo, error) {
+	if err := validateCreatePullRequestOptions(options, "not supported on Azure Repos", assigneesPullRequestOption, milestonePullRequestOption); err != nil {
+		return PullRequestInfo{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	sourceBranch = vcsutils.AddBranchPrefix(sourceBranch)
+	targetBranch = vcsutils.AddBranchPrefix(targetBranch)
+	pullRequestToCreate := &git.GitPullRequest{
+		Description:   &description,
+		SourceRefName: &sourceBranch,
+		TargetRefName: &targetBranch,
+		Title:         &title,
+		IsDraft:       vcsutils.GetNilIfZeroVal(options.Draft),
+	}
+	if options.DeleteSourceBranch {
+		pullRequestToCreate.CompletionOptions = &git.GitPullRequestCompletionOptions{DeleteSourceBranch: &options.DeleteSourceBranch}
+	}
+	if len(options.Reviewers) > 0 {
+		reviewers := make([]git.IdentityRefWithVote, len(options.Reviewers))
+		for i := range options.Reviewers {
+			reviewers[i] = git.IdentityRefWithVote{Id: &options.Reviewers[i]}
+		}
+		pullRequestToCreate.Reviewers = &reviewers
+	}
+	if len(options.Labels) > 0 {
+		labels := make([]core.WebApiTagDefinition, len(options.Labels))
+		for i := range options.Labels {
+			labels[i] = core.WebApiTagDefinition{Name: &options.Labels[i]}
+		}
+		pullRequestToCreate.Labels = &labels
+	}
+	if len(options.WorkItems) > 0 {
+		workItemRefs := make([]webapi.ResourceRef, len(options.WorkItems))
+		for i, workItemID := range options.WorkItems {
+			workItemRefs[i] = webapi.ResourceRef{Id: vcsutils.PointerOf(strconv.Itoa(workItemID))}
+		}
+		pullRequestToCreate.WorkItemRefs = &workItemRefs
+	}
+	client.logger.Debug(vcsutils.CreatingPullRequest, title)
+	pullRequest, err := azureReposGitClient.CreatePullRequest(ctx, git.CreatePullRequestArgs{
+		GitPullRequestToCreate: pullRequestToCreate,
+		RepositoryId:           &repository,
+		Project:                &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return parsePullRequestDetails(client, *pullRequest, owner, repository, true), nil
+}
+
+// UpdatePullRequest on Azure Repos
+func (client *AzureReposClient) UpdatePullRequest(ctx context.Context, _, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	targetBranchName = vcsutils.AddBranchPrefix(targetBranchName)
+	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			Description:   vcsutils.GetNilIfZeroVal(body),
+			Status:        azureMapPullRequestState(state),
+			TargetRefName: vcsutils.GetNilIfZeroVal(targetBranchName),
+			Title:         vcsutils.GetNilIfZeroVal(title),
+		},
+		RepositoryId:  vcsutils.GetNilIfZeroVal(repository),
+		PullRequestId: vcsutils.GetNilIfZeroVal(prId),
+		Project:       vcsutils.GetNilIfZeroVal(client.vcsInfo.Project),
+	})
+	return err
+}
+
+// MergePullRequest on Azure Repos
+func (client *AzureReposClient) MergePullRequest(ctx context.Context, _, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	mergeStrategy, err := getAzureReposMergeStrategy(mergeMethod)
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := azureReposGitClient.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &prId,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	// Completing a pull request requires the last merge source commit, to make sure the merged changes are the reviewed ones
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			Status:                &git.PullRequestStatusValues.Completed,
+			LastMergeSourceCommit: pullRequest.LastMergeSourceCommit,
+			CompletionOptions:     &git.GitPullRequestCompletionOptions{MergeStrategy: &mergeStrategy},
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &prId,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// SetPullRequestAutoMerge on Azure Repos.
+// The pull request is set to auto-complete by the authenticated user.
+func (client *AzureReposClient) SetPullRequestAutoMerge(ctx context.Context, _, repository string, pullRequestID int, mergeMethod vcsutils.MergeMethod) error {
+	mergeStrategy, err := getAzureReposMergeStrategy(mergeMethod)
+	if err != nil {
+		return err
+	}
+	if err = validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	userID, err := client.getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = azureReposGitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			AutoCompleteSetBy: &webapi.IdentityRef{Id: &userID},
+			CompletionOptions: &git.GitPullRequestCompletionOptions{MergeStrategy: &mergeStrategy},
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// AddPullRequestComment on Azure Repos
+func (client *AzureReposClient) AddPullRequestComment(ctx context.Context, _, repository, content string, pullRequestID int) error {
+	return client.addPullRequestComment(ctx, repository, pullRequestID, PullRequestComment{CommentInfo: CommentInfo{Content: content}})
+}
+
+// AddPullRequestReviewComments on Azure Repos
+func (client *AzureReposClient) AddPullRequestReviewComments(ctx context.Context, _, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	if len(comments) == 0 {
+		return errors.New(vcsutils.ErrNoCommentsProvided)
+	}
+	for _, comment := range comments {
+		if err := client.addPullRequestComment(ctx, repository, pullRequestID, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *AzureReposClient) addPullRequestComment(ctx context.Context, repository string, pullRequestID int, comment PullRequestComment) error {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	threadArgs := getThreadArgs(repository, client.vcsInfo.Project, pullRequestID, comment)
+	_, err = azureReposGitClient.CreateThread(ctx, threadArgs)
+	return err
+}
+
+func getThreadArgs(repository, project string, prId int, comment PullRequestComment) git.CreateThreadArgs {
+	filePath := vcsutils.GetPullRequestFilePath(comment.NewFilePath)
+	return git.CreateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{
+			Comments: &[]git.Comment{{Content: &comment.Content}},
+			Status:   &git.CommentThreadStatusValues.Active,
+			ThreadContext: &git.CommentThreadContext{
+				FilePath:       &filePath,
+				LeftFileStart:  &git.CommentPosition{Line: &comment.OriginalStartLine, Offset: &comment.OriginalStartColumn},
+				LeftFileEnd:    &git.CommentPosition{Line: &comment.OriginalEndLine, Offset: &comment.OriginalEndColumn},
+				RightFileStart: &git.CommentPosition{Line: &comment.NewStartLine, Offset: &comment.NewStartColumn},
+				RightFileEnd:   &git.CommentPosition{Line: &comment.NewEndLine, Offset: &comment.NewEndColumn},
+			},
+		},
+		RepositoryId:  &repository,
+		PullRequestId: &prId,
+		Project:       &project,
+	}
+}
+
+// ListPullRequestReviewComments on Azure Repos
+func (client *AzureReposClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return filterReviewComments(comments), nil
+}
+
+// ListPullRequestComments on Azure Repos
+func (client *AzureReposClient) ListPullRequestComments(ctx context.Context, _, repository string, pullRequestID int) ([]CommentInfo, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := azureReposGitClient.GetThreads(ctx, git.GetThreadsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var commentInfo []CommentInfo
+	for _, thread := range *threads {
+		if (thread.IsDeleted != nil && *thread.IsDeleted) || thread.Comments == nil {
+			continue
+		}
+		threadID := strconv.Itoa(vcsutils.DefaultIfNotNil(thread.Id))
+		filePath, line := getAzureReposThreadPosition(thread.ThreadContext)
+		for _, comment := range *thread.Comments {
+			if comment.IsDeleted != nil && *comment.IsDeleted {
+				continue
+			}
+			info := mapAzureReposCommentToCommentInfo(comment, threadID)
+			info.FilePath, info.Line = filePath, line
+			commentInfo = append(commentInfo, info)
+		}
+	}
+	return commentInfo, nil
+}
+
+// ListPullRequestCommentsWithOptions on Azure Repos.
+// The threads of a pull request can't be paginated, hence the page is taken from all the matching comments.
+func (client *AzureReposClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return getPullRequestCommentsPage(comments, options), nil
+}
+
+// getAzureReposThreadPosition returns the file path and line a thread is anchored to, or empty values for general comment threads.
+func getAzureReposThreadPosition(threadContext *git.CommentThreadContext) (filePath string, line int) {
+	if threadContext == nil || threadContext.FilePath == nil {
+		return
+	}
+	filePath = strings.TrimPrefix(*threadContext.FilePath, "/")
+	switch {
+	case threadContext.RightFileStart != nil:
+		line = vcsutils.DefaultIfNotNil(threadContext.RightFileStart.Line)
+	case threadContext.LeftFileStart != nil:
+		line = vcsutils.DefaultIfNotNil(threadContext.LeftFileStart.Line)
+	}
+	return
+}
+
+// mapAzureReposCommentToCommentInfo maps a comment of a thread.
+// Comment IDs are only unique within their thread, hence the ID is the thread ID, which DeletePullRequestComment and UpdatePullRequestComment expect,
+// and NativeID is the ID of the comment within the thread.
+func mapAzureReposCommentToCommentInfo(comment git.Comment, threadID string) CommentInfo {
+	id, _ := strconv.Atoi(threadID)
+	commentInfo := CommentInfo{
+		ID:       int64(id),
+		NativeID: strconv.Itoa(vcsutils.DefaultIfNotNil(comment.Id)),
+		ThreadID: threadID,
+		Content:  vcsutils.DefaultIfNotNil(comment.Content),
+	}
+	if parentID := vcsutils.DefaultIfNotNil(comment.ParentCommentId); parentID != 0 {
+		commentInfo.ParentID = strconv.Itoa(parentID)
+	}
+	if comment.Author != nil {
+		commentInfo.Author = vcsutils.DefaultIfNotNil(comment.Author.DisplayName)
+		commentInfo.AuthorUsername = vcsutils.DefaultIfNotNil(comment.Author.UniqueName)
+	}
+	if comment.PublishedDate != nil {
+		commentInfo.Created = comment.PublishedDate.Time
+	}
+	if comment.LastUpdatedDate != nil {
+		commentInfo.Updated = comment.LastUpdatedDate.Time
+	}
+	return commentInfo
+}
+
+// DeletePullRequestReviewComments on Azure Repos
+func (client *AzureReposClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
+	for _, comment := range comments {
+		threadID, commentID, err := getAzureReposCommentLocation(comment)
+		if err != nil {
+			return err
+		}
+		if err = client.deletePullRequestComment(ctx, repository, pullRequestID, threadID, commentID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAzureReposCommentLocation returns the thread of a comment, and the ID of the comment within the thread.
+// Comments listed by ListPullRequestComments carry both of them. Otherwise, the ID is the thread ID, and the first comment of the thread is addressed.
+func getAzureReposCommentLocation(comment CommentInfo) (threadID, commentID int, err error) {
+	threadID, commentID = int(comment.ID), firstCommentInThreadID
+	if comment.ThreadID != "" {
+		if threadID, err = strconv.Atoi(comment.ThreadID); err != nil {
+			return 0, 0, fmt.Errorf("invalid Azure Repos thread ID: '%s'", comment.ThreadID)
+		}
+	}
+	if comment.NativeID != "" {
+		if commentID, err = strconv.Atoi(comment.NativeID); err != nil {
+			return 0, 0, fmt.Errorf("invalid Azure Repos comment ID: '%s'", comment.NativeID)
+		}
+	}
+	return
+}
+
+// UpdatePullRequestComment on Azure Repos.
+// The commentID is the ID of the thread (CommentInfo.ID), whose first comment is updated.
+func (client *AzureReposClient) UpdatePullRequestComment(ctx context.Context, _, repository, content string, pullRequestID, commentID int) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "content": content}); err != nil {
+		return err
+	}
+	threadID, threadCommentID, err := getAzureReposCommentLocation(CommentInfo{ID: int64(commentID)})
+	if err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = azureReposGitClient.UpdateComment(ctx, git.UpdateCommentArgs{
+		Comment:       &git.Comment{Content: &content},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &threadID,
+		CommentId:     &threadCommentID,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// DeletePullRequestComment on Azure Repos.
+// The commentID is the ID of the thread (CommentInfo.ID), whose first comment is deleted.
+func (client *AzureReposClient) DeletePullRequestComment(ctx context.Context, _, repository string, pullRequestID, commentID int) error {
+	threadID, threadCommentID, err := getAzureReposCommentLocation(CommentInfo{ID: int64(commentID)})
+	if err != nil {
+		return err
+	}
+	return client.deletePullRequestComment(ctx, repository, pullRequestID, threadID, threadCommentID)
+}
+
+// SetPullRequestThreadStatus on Azure Repos.
+// Resolved threads are set to the fixed status, and unresolved threads to the active status.
+func (client *AzureReposClient) SetPullRequestThreadStatus(ctx context.Context, _, repository string, pullRequestID int, threadID string, resolved bool) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "thread ID": threadID}); err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(threadID)
+	if err != nil {
+		return fmt.Errorf("invalid Azure Repos thread ID: '%s'", threadID)
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	status := git.CommentThreadStatusValues.Active
+	if resolved {
+		status = git.CommentThreadStatusValues.Fixed
+	}
+	_, err = azureReposGitClient.UpdateThread(ctx, git.UpdateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{Status: &status},
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &id,
+		Project:       &client.vcsInfo.Project,
+	})
+	return err
+}
+
+func (client *AzureReposClient) deletePullRequestComment(ctx context.Context, repository string, pullRequestID, threadID, commentID int) error {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	return azureReposGitClient.DeleteComment(ctx, git.DeleteCommentArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		ThreadId:      &threadID,
+		Project:       &client.vcsInfo.Project,
+		CommentId:     &commentID,
+	})
+}
+
+var azureReposReviewerVotes = map[vcsutils.ReviewVerdict]int{
+	vcsutils.ReviewVerdictApprove:        10,
+	vcsutils.ReviewVerdictRequestChanges: -5,
+}
+
+// CreatePullRequestReview on Azure Repos.
+// The review sets the vote of the authenticated user, and the body is added as a pull request comment.
+func (client *AzureReposClient) CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if err := validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	if vote, ok := azureReposReviewerVotes[verdict]; ok {
+		azureReposGitClient, err := client.buildAzureReposClient(ctx)
+		if err != nil {
+			return err
+		}
+		reviewerID, err := client.getAuthenticatedUserID(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err = azureReposGitClient.CreatePullRequestReviewer(ctx, git.CreatePullRequestReviewerArgs{
+			Reviewer:      &git.IdentityRefWithVote{Vote: &vote},
+			RepositoryId:  &repository,
+			PullRequestId: &pullRequestID,
+			ReviewerId:    &reviewerID,
+			Project:       &client.vcsInfo.Project,
+		}); err != nil {
+			return err
+		}
+	}
+	if body == "" {
+		return nil
+	}
+	return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+}
+
+// ListPullRequestReviews on Azure Repos.
+// The reviewers who voted are returned, since Azure Repos doesn't report when a vote was cast.
+func (client *AzureReposClient) ListPullRequestReviews(ctx context.Context, _, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := azureReposGitClient.GetPullRequestReviewers(ctx, git.GetPullRequestReviewersArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil || reviewers == nil {
+		return nil, err
+	}
+	var reviews []PullRequestReviewInfo
+	for _, reviewer := range *reviewers {
+		var state vcsutils.ReviewVerdict
+		// Votes: 10 approved, 5 approved with suggestions, 0 no vote, -5 waiting for author and -10 rejected
+		switch vote := vcsutils.DefaultIfNotNil(reviewer.Vote); {
+		case vote > 0:
+			state = vcsutils.ReviewVerdictApprove
+		case vote < 0:
+			state = vcsutils.ReviewVerdictRequestChanges
+		default:
+			continue
+		}
+		reviews = append(reviews, PullRequestReviewInfo{Reviewer: vcsutils.DefaultIfNotNil(reviewer.UniqueName), State: state})
+	}
+	return reviews, nil
+}
+
+// getAuthenticatedUserID returns the identity ID of the user the client is authenticated as
+func (client *AzureReposClient) getAuthenticatedUserID(ctx context.Context) (string, error) {
+	locationClient, err := client.buildLocationClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	connectionData, err := locationClient.GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return "", err
+	}
+	if connectionData.AuthenticatedUser == nil || connectionData.AuthenticatedUser.Id == nil {
+		return "", errors.New("failed to get the authenticated user from the Azure Repos connection data")
+	}
+	return connectionData.AuthenticatedUser.Id.String(), nil
+}
+
+// ListOpenPullRequestsWithBody on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.getOpenPullRequests(ctx, owner, repository, true)
+}
+
+// ListOpenPullRequests on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.getOpenPullRequests(ctx, owner, repository, false)
+}
+
+// ListOpenPullRequestsWithOptions on Azure Repos
+func (client *AzureReposClient) ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	if !options.UpdatedSince.IsZero() {
+		return nil, getUnsupportedInAzureError("filter pull requests by update time")
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	searchCriteria := &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active}
+	if options.SourceBranch != "" {
+		searchCriteria.SourceRefName = vcsutils.PointerOf(vcsutils.AddBranchPrefix(options.SourceBranch))
+	}
+	if options.TargetBranch != "" {
+		searchCriteria.TargetRefName = vcsutils.PointerOf(vcsutils.AddBranchPrefix(options.TargetBranch))
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
+		RepositoryId:   &repository,
+		Project:        &client.vcsInfo.Project,
+		SearchCriteria: searchCriteria,
+		Top:            &perPage,
+		Skip:           vcsutils.PointerOf((page - 1) * perPage),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The API can filter the author only by its ID, hence the pull requests of the page are filtered here
+	var results []PullRequestInfo
+	for _, pullRequest := range vcsutils.DefaultIfNotNil(pullRequests) {
+		pullRequestInfo := parsePullRequestDetails(client, pullRequest, owner, repository, options.WithBody)
+		if options.matches(pullRequestInfo) {
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
+var azureReposPullRequestStatuses = map[vcsutils.PullRequestState]git.PullRequestStatus{
+	vcsutils.Open:   git.PullRequestStatusValues.Active,
+	vcsutils.Closed: git.PullRequestStatusValues.Abandoned,
+	vcsutils.Merged: git.PullRequestStatusValues.Completed,
+}
+
+// ListPullRequestsWithState on Azure Repos
+func (client *AzureReposClient) ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	if err := validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	perPage := 100
+	var results []PullRequestInfo
+	for skip := 0; ; skip += perPage {
+		pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
+			RepositoryId:   &repository,
+			Project:        &client.vcsInfo.Project,
+			SearchCriteria: &git.GitPullRequestSearchCriteria{Status: vcsutils.PointerOf(azureReposPullRequestStatuses[state])},
+			Top:            &perPage,
+			Skip:           &skip,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range vcsutils.DefaultIfNotNil(pullRequests) {
+			results = append(results, parsePullRequestDetails(client, pullRequest, owner, repository, true))
+		}
+		if len(vcsutils.DefaultIfNotNil(pullRequests)) < perPage {
+			return results, nil
+		}
+	}
+}
+
+func (client *AzureReposClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	perPage := 100
+	var pullRequestsInfo []PullRequestInfo
+	for skip := 0; ; skip += perPage {
+		pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
+			RepositoryId:   &repository,
+			Project:        &client.vcsInfo.Project,
+			SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active},
+			Top:            &perPage,
+			Skip:           &skip,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range vcsutils.DefaultIfNotNil(pullRequests) {
+			pullRequestsInfo = append(pullRequestsInfo, parsePullRequestDetails(client, pullRequest, owner, repository, withBody))
+		}
+		if len(vcsutils.DefaultIfNotNil(pullRequests)) < perPage {
+			return pullRequestsInfo, nil
+		}
+	}
+}
+
+// GetPullRequestByID on Azure Repos
+func (client *AzureReposClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return
+	}
+	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
+	pullRequest, err := azureReposGitClient.GetPullRequestById(ctx, git.GetPullRequestByIdArgs{
+		PullRequestId: &pullRequestId,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return
+	}
+	pullRequestInfo = parsePullRequestDetails(client, *pullRequest, owner, repository, true)
+	return
+}
+
+// GetLatestCommit on Azure Repos
+func (client *AzureReposClient) GetLatestCommit(ctx context.Context, _, repository, branch string) (CommitInfo, error) {
+	commitsInfo, err := client.ListCommits(ctx, "", repository, ListCommitsOptions{Branch: branch, PerPage: 1})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	var latestCommit CommitInfo
+	if len(commitsInfo) > 0 {
+		latestCommit = commitsInfo[0]
+	}
+	return latestCommit, nil
+}
+
+// GetCommits on Azure Repos
+func (client *AzureReposClient) GetCommits(ctx context.Context, _, repository, branch string) ([]CommitInfo, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
+		RepositoryId:   &repository,
+		Project:        &client.vcsInfo.Project,
+		SearchCriteria: &git.GitQueryCommitsCriteria{ItemVersion: &git.GitVersionDescriptor{Version: &branch, VersionType: &git.GitVersionTypeValues.Branch}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if commits == nil {
+		return nil, fmt.Errorf("could not retrieve commits for <%s/%s>", repository, branch)
+	}
+
+	var commitsInfo []CommitInfo
+	for _, commit := range *commits {
+		commitInfo := mapAzureReposCommitsToCommitInfo(commit)
+		commitsInfo = append(commitsInfo, commitInfo)
+	}
+	return commitsInfo, nil
+}
+
+// ListCommits on Azure Repos
+func (client *AzureReposClient) ListCommits(ctx context.Context, _, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": options.Branch}); err != nil {
+		return nil, err
+	}
+
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	searchCriteria := &git.GitQueryCommitsCriteria{
+		ItemVersion: &git.GitVersionDescriptor{Version: &options.Branch, VersionType: vcsutils.PointerOf(git.GitVersionTypeValues.Branch)},
+		Top:         &perPage,
+		Skip:        vcsutils.PointerOf((page - 1) * perPage),
+	}
+	if options.Path != "" {
+		searchCriteria.ItemPath = &options.Path
+	}
+	if options.Author != "" {
+		searchCriteria.Author = &options.Author
+	}
+	if !options.Since.IsZero() {
+		searchCriteria.FromDate = vcsutils.PointerOf(options.Since.UTC().Format(time.RFC3339))
+	}
+	if !options.Until.IsZero() {
+		searchCriteria.ToDate = vcsutils.PointerOf(options.Until.UTC().Format(time.RFC3339))
+	}
+	commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
+		RepositoryId:   &repository,
+		Project:        &client.vcsInfo.Project,
+		SearchCriteria: searchCriteria,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var commitsInfo []CommitInfo
+	for _, commit := range vcsutils.DefaultIfNotNil(commits) {
+		commitsInfo = append(commitsInfo, mapAzureReposCommitsToCommitInfo(commit))
+	}
+	return commitsInfo, nil
+}
+
+func mapAzureReposCommitsToCommitInfo(commit git.GitCommitRef) CommitInfo {
+	var authorName, authorEmail string
+	if commit.Author != nil {
+		authorName = vcsutils.DefaultIfNotNil(commit.Author.Name)
+		authorEmail = vcsutils.DefaultIfNotNil(commit.Author.Email)
+	}
+	var committerName string
+	var timestamp int64
+	if commit.Committer != nil {
+		committerName = vcsutils.DefaultIfNotNil(commit.Committer.Name)
+		timestamp = vcsutils.DefaultIfNotNil(commit.Committer.Date).Time.Unix()
+	}
+	return CommitInfo{
+		Hash:          vcsutils.DefaultIfNotNil(commit.CommitId),
+		AuthorName:    authorName,
+		CommitterName: committerName,
+		Url:           vcsutils.DefaultIfNotNil(commit.Url),
+		Timestamp:     timestamp,
+		Message:       vcsutils.DefaultIfNotNil(commit.Comment),
+		ParentHashes:  vcsutils.DefaultIfNotNil(commit.Parents),
+		AuthorEmail:   authorEmail,
+	}
+}
+
+func getUnsupportedInAzureError(functionName string) error {
+	return fmt.Errorf("%s is currently not supported for Azure Repos", functionName)
+}
+
+// AddSshKeyToRepository on Azure Repos
+func (client *AzureReposClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	return getUnsupportedInAzureError("add ssh key to repository")
+}
+
+// GetRepositoryInfo on Azure Repos
+func (client *AzureReposClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	response, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return RepositoryInfo{}, fmt.Errorf("an error occured while retrieving <%s/%s/%s> repository info:\n%s", owner, client.vcsInfo.Project, repository, err.Error())
+	}
+	if response == nil {
+		return RepositoryInfo{}, fmt.Errorf("failed to retreive <%s/%s/%s> repository info, received empty response", owner, client.vcsInfo.Project, repository)
+	}
+	if response.Project == nil {
+		return RepositoryInfo{}, fmt.Errorf("failed to retreive <%s/%s/%s> repository info, received empty project info", owner, client.vcsInfo.Project, repository)
+	}
+	return mapAzureReposRepositoryToRepositoryInfo(response), nil
+}
+
+// CreateRepository on Azure Repos.
+// The repository is created in the project of the client and shares its visibility, hence the Private option is ignored.
+// Repositories have no description, hence the description is added to the README if InitReadme is set.
+// The README is pushed to the default branch, which is main unless another branch is specified.
+func (client *AzureReposClient) CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	response, err := azureReposGitClient.CreateRepository(ctx, git.CreateRepositoryArgs{
+		GitRepositoryToCreate: &git.GitRepositoryCreateOptions{Name: &repository},
+		Project:               &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	if response == nil {
+		return RepositoryInfo{}, fmt.Errorf("failed to create <%s/%s/%s> repository, received empty response", owner, client.vcsInfo.Project, repository)
+	}
+
+	if options.InitReadme {
+		defaultBranch := options.DefaultBranch
+		if defaultBranch == "" {
+			defaultBranch = "main"
+		}
+		readme := "# " + repository + "\n"
+		if options.Description != "" {
+			readme += "\n" + options.Description + "\n"
+		}
+		// The first branch pushed to an empty repository becomes its default branch
+		changes := []interface{}{git.GitChange{
+			ChangeType: vcsutils.PointerOf(git.VersionControlChangeTypeValues.Add),
+			Item:       git.GitItem{Path: vcsutils.PointerOf("/README.md")},
+			NewContent: &git.ItemContent{
+				Content:     vcsutils.PointerOf(base64.StdEncoding.EncodeToString([]byte(readme))),
+				ContentType: vcsutils.PointerOf(git.ItemContentTypeValues.Base64Encoded),
+			},
+		}}
+		_, err = azureReposGitClient.CreatePush(ctx, git.CreatePushArgs{
+			Push: &git.GitPush{
+				RefUpdates: &[]git.GitRefUpdate{{Name: vcsutils.PointerOf(vcsutils.AddBranchPrefix(defaultBranch)), OldObjectId: vcsutils.PointerOf(plumbing.ZeroHash.String())}},
+				Commits:    &[]git.GitCommitRef{{Comment: vcsutils.PointerOf("Initial commit"), Changes: &changes}},
+			},
+			RepositoryId: &repository,
+			Project:      &client.vcsInfo.Project,
+		})
+		if err != nil {
+			return RepositoryInfo{}, err
+		}
+	}
+	return mapAzureReposRepositoryToRepositoryInfo(response), nil
+}
+
+// DeleteRepository on Azure Repos.
+// The repository is moved to the recycle bin of the project.
+func (client *AzureReposClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// The repository is deleted by its ID rather than by its name
+	response, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return err
+	}
+	if response == nil || response.Id == nil {
+		return fmt.Errorf("failed to retreive <%s/%s/%s> repository ID, received empty response", owner, client.vcsInfo.Project, repository)
+	}
+	return azureReposGitClient.DeleteRepository(ctx, git.DeleteRepositoryArgs{
+		RepositoryId: response.Id,
+		Project:      &client.vcsInfo.Project,
+	})
+}
+
+// ForkRepository on Azure Repos.
+// Repositories belong to projects rather than to users, hence the target owner is the project to fork the repository to.
+// The fork has the name of the repository, so the target project must differ from the project of the client.
+func (client *AzureReposClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "targetOwner": targetOwner}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	parent, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	if parent == nil || parent.Id == nil || parent.Project == nil {
+		return RepositoryInfo{}, fmt.Errorf("failed to retreive <%s/%s/%s> repository info, received empty response", owner, client.vcsInfo.Project, repository)
+	}
+
+	fork, err := azureReposGitClient.CreateRepository(ctx, git.CreateRepositoryArgs{
+		GitRepositoryToCreate: &git.GitRepositoryCreateOptions{
+			Name:             &repository,
+			ParentRepository: &git.GitRepositoryRef{Id: parent.Id, Project: &core.TeamProjectReference{Id: parent.Project.Id}},
+		},
+		Project: &targetOwner,
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	if fork == nil {
+		return RepositoryInfo{}, fmt.Errorf("failed to fork <%s/%s/%s> repository, received empty response", owner, client.vcsInfo.Project, repository)
+	}
+	return mapAzureReposRepositoryToRepositoryInfo(fork), nil
+}
+
+// ListRepositoryCollaborators on Azure Repos
+func (client *AzureReposClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	return nil, getUnsupportedInAzureError("list repository collaborators")
+}
+
+// GetRepositoryPermission on Azure Repos
+func (client *AzureReposClient) GetRepositoryPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	return NoPermission, getUnsupportedInAzureError("get repository permission")
+}
+
+func mapAzureReposRepositoryToRepositoryInfo(repository *git.GitRepository) RepositoryInfo {
+	visibility := Private
+	if repository.Project != nil && vcsutils.DefaultIfNotNil(repository.Project.Visibility) == core.ProjectVisibilityValues.Public {
+		visibility = Public
+	}
+	return RepositoryInfo{
+		CloneInfo:            CloneInfo{HTTP: vcsutils.DefaultIfNotNil(repository.RemoteUrl), SSH: vcsutils.DefaultIfNotNil(repository.SshUrl)},
+		RepositoryVisibility: visibility,
+		DefaultBranch:        strings.TrimPrefix(vcsutils.DefaultIfNotNil(repository.DefaultBranch), "refs/heads/"),
+	}
+}
+
+// GetCommitBySha on Azure Repos
+func (client *AzureReposClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	return CommitInfo{}, getUnsupportedInAzureError("get commit by sha")
+}
+
+// CreateLabel on Azure Repos.
+// Azure Repos labels are project tags, which are created when they are first added to a pull request.
+// Hence, only the label name is validated, and the description and the color are ignored.
+func (client *AzureReposClient) CreateLabel(_ context.Context, _, _ string, labelInfo LabelInfo) error {
+	return validateParametersNotBlank(map[string]string{"name": labelInfo.Name})
+}
+
+// GetLabel on Azure Repos.
+// The label is looked up in the tags of the project, which have no description or color.
+func (client *AzureReposClient) GetLabel(ctx context.Context, _, _, name string) (*LabelInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"name": name}); err != nil {
+		return nil, err
+	}
+	workItemTrackingClient, err := client.buildWorkItemTrackingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := workItemTrackingClient.GetTag(ctx, workitemtracking.GetTagArgs{
+		Project:     &client.vcsInfo.Project,
+		TagIdOrName: &name,
+	})
+	if err != nil {
+		if isAzureReposNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &LabelInfo{Name: vcsutils.DefaultIfNotNil(tag.Name)}, nil
+}
+
+// ListRepositoryLabels on Azure Repos.
+// Returns the tags of the project, which have no description or color.
+func (client *AzureReposClient) ListRepositoryLabels(ctx context.Context, _, _ string) ([]LabelInfo, error) {
+	workItemTrackingClient, err := client.buildWorkItemTrackingClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := workItemTrackingClient.GetTags(ctx, workitemtracking.GetTagsArgs{Project: &client.vcsInfo.Project})
+	if err != nil {
+		return nil, err
+	}
+	var results []LabelInfo
+	for _, tag := range vcsutils.DefaultIfNotNil(tags) {
+		results = append(results, LabelInfo{Name: vcsutils.DefaultIfNotNil(tag.Name)})
+	}
+	return results, nil
+}
+
+// UpdateLabel on Azure Repos.
+// Only the label name can be updated, the description and the color are ignored.
+func (client *AzureReposClient) UpdateLabel(ctx context.Context, _, _, name string, labelInfo LabelInfo) error {
+	if err := validateParametersNotBlank(map[string]string{"name": name, "LabelInfo.name": labelInfo.Name}); err != nil {
+		return err
+	}
+	workItemTrackingClient, err := client.buildWorkItemTrackingClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = workItemTrackingClient.UpdateTag(ctx, workitemtracking.UpdateTagArgs{
+		TagData:     &workitemtracking.WorkItemTagDefinition{Name: &labelInfo.Name},
+		Project:     &client.vcsInfo.Project,
+		TagIdOrName: &name,
+	})
+	return err
+}
+
+// DeleteLabel on Azure Repos.
+// The tag is deleted from the project, and removed from all the pull requests and work items it is assigned to.
+func (client *AzureReposClient) DeleteLabel(ctx context.Context, _, _, name string) error {
+	if err := validateParametersNotBlank(map[string]string{"name": name}); err != nil {
+		return err
+	}
+	workItemTrackingClient, err := client.buildWorkItemTrackingClient(ctx)
+	if err != nil {
+		return err
+	}
+	return workItemTrackingClient.DeleteTag(ctx, workitemtracking.DeleteTagArgs{
+		Project:     &client.vcsInfo.Project,
+		TagIdOrName: &name,
+	})
+}
+
+// ListPullRequestLabels on Azure Repos
+func (client *AzureReposClient) ListPullRequestLabels(ctx context.Context, _, repository string, pullRequestID int) ([]string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := azureReposGitClient.GetPullRequestLabels(ctx, git.GetPullRequestLabelsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, label := range vcsutils.DefaultIfNotNil(labels) {
+		names = append(names, vcsutils.DefaultIfNotNil(label.Name))
+	}
+	return names, nil
+}
+
+// LabelPullRequest on Azure Repos
+func (client *AzureReposClient) LabelPullRequest(ctx context.Context, _, repository string, pullRequestID int, labels []string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if _, err = azureReposGitClient.CreatePullRequestLabel(ctx, git.CreatePullRequestLabelArgs{
+			Label:         &core.WebApiCreateTagRequestData{Name: vcsutils.PointerOf(label)},
+			RepositoryId:  &repository,
+			PullRequestId: &pullRequestID,
+			Project:       &client.vcsInfo.Project,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnlabelPullRequest on Azure Repos
+func (client *AzureReposClient) UnlabelPullRequest(ctx context.Context, _, repository, name string, pullRequestID int) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "name": name}); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	return azureReposGitClient.DeletePullRequestLabels(ctx, git.DeletePullRequestLabelsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		LabelIdOrName: &name,
+		Project:       &client.vcsInfo.Project,
+	})
+}
+
+// CreateIssue on Azure Repos
+func (client *AzureReposClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	return IssueInfo{}, getUnsupportedInAzureError("create issue")
+}
+
+// ListIssues on Azure Repos
+func (client *AzureReposClient) ListIssues(ctx context.Context, owner, repository string, options ListIssuesOptions) ([]IssueInfo, error) {
+	return nil, getUnsupportedInAzureError("list issues")
+}
+
+// AddIssueComment on Azure Repos
+func (client *AzureReposClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	return getUnsupportedInAzureError("add issue comment")
+}
+
+// CloseIssue on Azure Repos
+func (client *AzureReposClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	return getUnsupportedInAzureError("close issue")
+}
+
+// UploadCodeScanning on Azure Repos.
+// The analysis result is set as a status of the latest commit of the branch,
+// and each finding is added as a comment thread to the open pull requests from the branch.
+// The returned ID is the hash of the commit.
+func (client *AzureReposClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	analysis, err := parseCodeScanningAnalysis(scanResults)
+	if err != nil {
+		return "", err
+	}
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", err
+	}
+	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
+
+	status := Pass
+	if len(analysis.Findings) > 0 {
+		status = Fail
+	}
+	if err = client.setCommitStatus(ctx, status, analysis.ToolName, codeScanningReportKey, repository, commit.Hash, analysis.getDetails(), ""); err != nil {
+		return "", err
+	}
+	if len(analysis.Findings) == 0 {
+		return commit.Hash, nil
+	}
+
+	pullRequests, err := client.ListOpenPullRequestsWithOptions(ctx, owner, repository, ListPullRequestsOptions{SourceBranch: branch})
+	if err != nil {
+		return "", err
+	}
+	for _, pullRequest := range pullRequests {
+		for _, finding := range analysis.Findings {
+			if err = client.addCodeScanningFindingComment(ctx, owner, repository, int(pullRequest.ID), finding); err != nil {
+				return "", err
+			}
+		}
+	}
+	return commit.Hash, nil
+}
+
+// addCodeScanningFindingComment adds a comment thread on the line of the finding, or a general comment if the finding has no line
+func (client *AzureReposClient) addCodeScanningFindingComment(ctx context.Context, owner, repository string, pullRequestID int, finding codeScanningFinding) error {
+	content := fmt.Sprintf("**%s** %s", finding.Severity, finding.getSummary())
+	if finding.FilePath == "" || finding.Line == 0 {
+		return client.AddPullRequestComment(ctx, owner, repository, content, pullRequestID)
+	}
+	return client.addPullRequestComment(ctx, repository, pullRequestID, PullRequestComment{
+		CommentInfo: CommentInfo{Content: content},
+		PullRequestDiff: PullRequestDiff{
+			NewFilePath:    finding.FilePath,
+			NewStartLine:   finding.Line,
+			NewEndLine:     finding.Line,
+			NewStartColumn: 1,
+			NewEndColumn:   1,
+		},
+	})
+}
+
+// CreateWebhook on Azure Repos
+// Azure service hooks are bound to a single event type, so a subscription is created for each of the requested events.
+// The returned webhook ID is a comma-separated list of the created subscription IDs.
+// If one of the subscriptions can't be created, the subscriptions which were already created are deleted.
+func (client *AzureReposClient) CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "payloadURL": payloadURL}); err != nil {
+		return "", "", err
+	}
+	eventTypes := getAzureReposWebhookEventTypes(webhookEvents...)
+	if len(eventTypes) == 0 {
+		return "", "", errors.New("could not create a webhook, no supported webhook events were provided")
+	}
+	projectID, repositoryID, err := client.getProjectAndRepositoryIDs(ctx, owner, repository)
+	if err != nil {
+		return "", "", err
+	}
+	serviceHooksClient, err := client.buildServiceHooksClient(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	token := vcsutils.CreateToken()
+	var subscriptionIDs []uuid.UUID
+	var rawSubscriptionIDs []string
+	for _, eventType := range eventTypes {
+		subscription, err := serviceHooksClient.CreateSubscription(ctx, servicehooks.CreateSubscriptionArgs{
+			Subscription: createAzureReposSubscription(projectID, repositoryID, branch, payloadURL, token, eventType),
+		})
+		if err == nil && subscription.Id == nil {
+			err = fmt.Errorf("failed to create a %s service hook subscription, received empty subscription ID", eventType)
+		}
+		if err != nil {
+			return "", "", errors.Join(err, deleteAzureReposSubscriptions(ctx, serviceHooksClient, subscriptionIDs))
+		}
+		subscriptionIDs = append(subscriptionIDs, *subscription.Id)
+		rawSubscriptionIDs = append(rawSubscriptionIDs, subscription.Id.String())
+	}
+	return strings.Join(rawSubscriptionIDs, azureWebhookIDSeparator), token, nil
+}
+
+// CreateOrUpdateWebhook on Azure Repos
+// Since each service hook subscription is bound to a single event type, the existing webhook is recreated if the
+// number of its subscriptions doesn't match the number of the distinct Azure event types of the webhook events.
+func (client *AzureReposClient) CreateOrUpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	webhook, err := findWebhookByPayloadURL(ctx, client, owner, repository, payloadURL)
+	if err != nil {
+		return "", "", err
+	}
+	if webhook != nil && len(strings.Split(webhook.ID, azureWebhookIDSeparator)) != len(getAzureReposWebhookEventTypes(webhookEvents...)) {
+		if err = client.DeleteWebhook(ctx, owner, repository, webhook.ID); err != nil {
+			return "", "", err
+		}
+		webhook = nil
+	}
+	if webhook == nil {
+		return client.CreateWebhook(ctx, owner, repository, branch, payloadURL, webhookEvents...)
+	}
+	token := vcsutils.CreateToken()
+	if err = client.UpdateWebhook(ctx, owner, repository, branch, payloadURL, token, webhook.ID, webhookEvents...); err != nil {
+		return "", "", err
+	}
+	return webhook.ID, token, nil
+}
+
+// UpdateWebhook on Azure Repos
+// The webhook ID is the comma-separated list of subscription IDs returned from CreateWebhook.
+// Each subscription is replaced according to the webhook event in the same position, so the number of the distinct
+// Azure event types the webhook events are mapped to must match the number of subscriptions.
+func (client *AzureReposClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token, webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "payloadURL": payloadURL, "webhookID": webhookID}); err != nil {
+		return err
+	}
+	subscriptionIDs, err := parseAzureReposWebhookID(webhookID)
+	if err != nil {
+		return err
+	}
+	eventTypes := getAzureReposWebhookEventTypes(webhookEvents...)
+	if len(eventTypes) != len(subscriptionIDs) {
+		return fmt.Errorf("webhook events are mapped to %d Azure event types while webhook ID %s contains %d subscriptions", len(eventTypes), webhookID, len(subscriptionIDs))
+	}
+	projectID, repositoryID, err := client.getProjectAndRepositoryIDs(ctx, owner, repository)
+	if err != nil {
+		return err
+	}
+	serviceHooksClient, err := client.buildServiceHooksClient(ctx)
+	if err != nil {
+		return err
+	}
+	for i, subscriptionID := range subscriptionIDs {
+		if _, err = serviceHooksClient.ReplaceSubscription(ctx, servicehooks.ReplaceSubscriptionArgs{
+			Subscription:   createAzureReposSubscription(projectID, repositoryID, branch, payloadURL, token, eventTypes[i]),
+			SubscriptionId: &subscriptionID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteWebhook on Azure Repos
+// The webhook ID is the comma-separated list of subscription IDs returned from CreateWebhook.
+func (client *AzureReposClient) DeleteWebhook(ctx context.Context, _, _, webhookID string) error {
+	subscriptionIDs, err := parseAzureReposWebhookID(webhookID)
+	if err != nil {
+		return err
+	}
+	serviceHooksClient, err := client.buildServiceHooksClient(ctx)
+	if err != nil {
+		return err
+	}
+	return deleteAzureReposSubscriptions(ctx, serviceHooksClient, subscriptionIDs)
+}
+
+func deleteAzureReposSubscriptions(ctx context.Context, serviceHooksClient servicehooks.Client, subscriptionIDs []uuid.UUID) error {
+	for _, subscriptionID := range subscriptionIDs {
+		if err := serviceHooksClient.DeleteSubscription(ctx, servicehooks.DeleteSubscriptionArgs{SubscriptionId: &subscriptionID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListWebhooks on Azure Repos
+// The service hook subscriptions of the repository are grouped by their payload URL, as created by CreateWebhook.
+// The webhook ID is the comma-separated list of the subscription IDs.
+func (client *AzureReposClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	_, repositoryID, err := client.getProjectAndRepositoryIDs(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	serviceHooksClient, err := client.buildServiceHooksClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := serviceHooksClient.ListSubscriptions(ctx, servicehooks.ListSubscriptionsArgs{
+		PublisherId: vcsutils.PointerOf(azureWebhookPublisherID),
+		ConsumerId:  vcsutils.PointerOf(azureWebhookConsumerID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var payloadURLs []string
+	subscriptionsByURL := map[string][]servicehooks.Subscription{}
+	for _, subscription := range vcsutils.DefaultIfNotNil(subscriptions) {
+		if subscription.PublisherInputs == nil || (*subscription.PublisherInputs)["repository"] != repositoryID {
+			continue
+		}
+		payloadURL := getAzureReposSubscriptionPayloadURL(subscription)
+		if _, exists := subscriptionsByURL[payloadURL]; !exists {
+			payloadURLs = append(payloadURLs, payloadURL)
+		}
+		subscriptionsByURL[payloadURL] = append(subscriptionsByURL[payloadURL], subscription)
+	}
+	results := make([]WebhookInfo, 0, len(payloadURLs))
+	for _, payloadURL := range payloadURLs {
+		results = append(results, mapAzureReposSubscriptionsToWebhookInfo(subscriptionsByURL[payloadURL]))
+	}
+	return results, nil
+}
+
+// GetWebhook on Azure Repos
+// The webhook ID is the comma-separated list of subscription IDs returned from CreateWebhook.
+func (client *AzureReposClient) GetWebhook(ctx context.Context, _, _, webhookID string) (WebhookInfo, error) {
+	subscriptionIDs, err := parseAzureReposWebhookID(webhookID)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	serviceHooksClient, err := client.buildServiceHooksClient(ctx)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	var subscriptions []servicehooks.Subscription
+	for _, subscriptionID := range subscriptionIDs {
+		subscription, err := serviceHooksClient.GetSubscription(ctx, servicehooks.GetSubscriptionArgs{SubscriptionId: &subscriptionID})
+		if err != nil {
+			return WebhookInfo{}, err
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return mapAzureReposSubscriptionsToWebhookInfo(subscriptions), nil
+}
+
+// RotateWebhookSecret on Azure Repos
+// The webhook ID is the comma-separated list of subscription IDs returned from CreateWebhook.
+// The token is the basic authentication password of the subscriptions.
+func (client *AzureReposClient) RotateWebhookSecret(ctx context.Context, _, _, webhookID string) (string, error) {
+	subscriptionIDs, err := parseAzureReposWebhookID(webhookID)
+	if err != nil {
+		return "", err
+	}
+	serviceHooksClient, err := client.buildServiceHooksClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	token := vcsutils.CreateToken()
+	for _, subscriptionID := range subscriptionIDs {
+		subscription, err := serviceHooksClient.GetSubscription(ctx, servicehooks.GetSubscriptionArgs{SubscriptionId: &subscriptionID})
+		if err != nil {
+			return "", err
+		}
+		consumerInputs := map[string]string{}
+		if subscription.ConsumerInputs != nil {
+			consumerInputs = *subscription.ConsumerInputs
+		}
+		consumerInputs["basicAuthUsername"] = AzureWebhookBasicAuthUsername
+		consumerInputs["basicAuthPassword"] = token
+		subscription.ConsumerInputs = &consumerInputs
+		if _, err = serviceHooksClient.ReplaceSubscription(ctx, servicehooks.ReplaceSubscriptionArgs{
+			Subscription:   subscription,
+			SubscriptionId: &subscriptionID,
+		}); err != nil {
+			return "", err
+		}
+	}
+	return token, nil
+}
+
+// getProjectAndRepositoryIDs returns the IDs of the configured project and the input repository, as required by the service hooks API.
+func (client *AzureReposClient) getProjectAndRepositoryIDs(ctx context.Context, owner, repository string) (projectID, repositoryID string, err error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return
+	}
+	response, err := azureReposGitClient.GetRepository(ctx, git.GetRepositoryArgs{
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return
+	}
+	if response == nil || response.Id == nil || response.Project == nil || response.Project.Id == nil {
+		err = fmt.Errorf("failed to retrieve the IDs of <%s/%s/%s> repository", owner, client.vcsInfo.Project, repository)
+		return
+	}
+	return response.Project.Id.String(), response.Id.String(), nil
+}
+
+// SetCommitStatus on Azure Repos
+func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
+	return client.setCommitStatus(ctx, commitStatus, owner, title, repository, ref, description, detailsURL)
+}
+
+// setCommitStatus sets a commit status, identified by the name and genre of its context
+func (client *AzureReposClient) setCommitStatus(ctx context.Context, commitStatus CommitStatus, contextName, contextGenre, repository, ref, description, detailsURL string) error {
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
 		return err
@@ -547,8 +2388,8 @@ func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatu
 			State:       &statusState,
 			TargetUrl:   &detailsURL,
 			Context: &git.GitStatusContext{
-				Name:  &owner,
-				Genre: &title,
+				Name:  &contextName,
+				Genre: &contextGenre,
 			},
 		},
 		CommitId:     &ref,
@@ -559,96 +2400,648 @@ func (client *AzureReposClient) SetCommitStatus(ctx context.Context, commitStatu
 	return err
 }
 
-// GetCommitStatuses on Azure Repos
-func (client *AzureReposClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
-	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+// GetCommitStatuses on Azure Repos
+func (client *AzureReposClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commitStatusArgs := git.GetStatusesArgs{
+		CommitId:     &ref,
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	}
+	resGitStatus, err := azureReposGitClient.GetStatuses(ctx, commitStatusArgs)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CommitStatusInfo, 0)
+	for _, singleStatus := range *resGitStatus {
+		var statusContext, creator string
+		if singleStatus.Context != nil {
+			// The title of the status is set as the context genre
+			statusContext = vcsutils.DefaultIfNotNil(singleStatus.Context.Genre)
+		}
+		if singleStatus.CreatedBy != nil {
+			creator = vcsutils.DefaultIfNotNil(singleStatus.CreatedBy.DisplayName)
+		}
+		rawState := string(vcsutils.DefaultIfNotNil(singleStatus.State))
+		results = append(results, CommitStatusInfo{
+			State:         commitStatusAsStringToStatus(rawState),
+			RawState:      rawState,
+			Context:       statusContext,
+			Description:   vcsutils.DefaultIfNotNil(singleStatus.Description),
+			DetailsUrl:    vcsutils.DefaultIfNotNil(singleStatus.TargetUrl),
+			Creator:       creator,
+			LastUpdatedAt: extractTimeFromAzuredevopsTime(singleStatus.UpdatedDate),
+			CreatedAt:     extractTimeFromAzuredevopsTime(singleStatus.CreationDate),
+		})
+	}
+	return results, err
+}
+
+// GetCombinedCommitStatus on Azure Repos.
+// Combines the latest commit statuses with the blocking policy evaluations of the active pull requests of the ref.
+// The ref is either a commit SHA or a branch name.
+func (client *AzureReposClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return Error, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return Error, err
+	}
+	commitSha := ref
+	if !plumbing.IsHash(ref) {
+		if commitSha, err = client.getBranchLatestCommitSha(ctx, azureReposGitClient, repository, ref); err != nil {
+			return Error, err
+		}
+	}
+
+	gitStatuses, err := azureReposGitClient.GetStatuses(ctx, git.GetStatusesArgs{
+		CommitId:     &commitSha,
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+		LatestOnly:   vcsutils.PointerOf(true),
+	})
+	if err != nil {
+		return Error, err
+	}
+	var statuses []CommitStatus
+	for _, gitStatus := range *gitStatuses {
+		state := vcsutils.DefaultIfNotNil(gitStatus.State)
+		if state == git.GitStatusStateValues.NotApplicable || state == git.GitStatusStateValues.NotSet {
+			continue
+		}
+		statuses = append(statuses, commitStatusAsStringToStatus(string(state)))
+	}
+
+	policyStatuses, err := client.getPolicyEvaluationStatuses(ctx, azureReposGitClient, repository, commitSha)
+	if err != nil {
+		return Error, err
+	}
+	return combineCommitStatuses(append(statuses, policyStatuses...)...), nil
+}
+
+// TriggerPipeline on Azure Repos
+func (client *AzureReposClient) TriggerPipeline(_ context.Context, _, _, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, getUnsupportedInAzureError("trigger pipeline")
+}
+
+// GetLatestPipelineStatus on Azure Repos
+func (client *AzureReposClient) GetLatestPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, getUnsupportedInAzureError("get latest pipeline status")
+}
+
+// GetPipelineStatus on Azure Repos
+func (client *AzureReposClient) GetPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, getUnsupportedInAzureError("get pipeline status")
+}
+
+// TriggerWorkflow on Azure Repos
+func (client *AzureReposClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return getUnsupportedInAzureError("trigger workflow")
+}
+
+// GetWorkflowRunStatus on Azure Repos
+func (client *AzureReposClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, getUnsupportedInAzureError("get workflow run status")
+}
+
+// QueueBuild on Azure Repos.
+// The build is queued in the client's project, hence the owner and repository are ignored, and the variables are set as queue time variables of the pipeline.
+func (client *AzureReposClient) QueueBuild(ctx context.Context, _, _ string, definitionID int, branch string, variables map[string]string) (PipelineInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"project": client.vcsInfo.Project, "branch": branch}); err != nil {
+		return PipelineInfo{}, err
+	}
+	buildClient, err := client.buildBuildClient(ctx)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	buildToQueue := &build.Build{
+		Definition:   &build.DefinitionReference{Id: &definitionID},
+		SourceBranch: vcsutils.PointerOf(vcsutils.AddBranchPrefix(branch)),
+	}
+	if len(variables) > 0 {
+		parameters, err := json.Marshal(variables)
+		if err != nil {
+			return PipelineInfo{}, err
+		}
+		buildToQueue.Parameters = vcsutils.PointerOf(string(parameters))
+	}
+	queuedBuild, err := buildClient.QueueBuild(ctx, build.QueueBuildArgs{Build: buildToQueue, Project: &client.vcsInfo.Project})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapAzureBuildToPipelineInfo(*queuedBuild), nil
+}
+
+// GetBuildStatus on Azure Repos.
+// Returns the latest queued build of the pipeline definition on the branch.
+func (client *AzureReposClient) GetBuildStatus(ctx context.Context, _, _ string, definitionID int, branch string) (PipelineInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"project": client.vcsInfo.Project, "branch": branch}); err != nil {
+		return PipelineInfo{}, err
+	}
+	buildClient, err := client.buildBuildClient(ctx)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	builds, err := buildClient.GetBuilds(ctx, build.GetBuildsArgs{
+		Project:     &client.vcsInfo.Project,
+		Definitions: &[]int{definitionID},
+		BranchName:  vcsutils.PointerOf(vcsutils.AddBranchPrefix(branch)),
+		QueryOrder:  &build.BuildQueryOrderValues.QueueTimeDescending,
+		Top:         vcsutils.PointerOf(1),
+	})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	if len(builds.Value) == 0 {
+		return PipelineInfo{}, fmt.Errorf("no build of definition %d was found for branch '%s'", definitionID, branch)
+	}
+	return mapAzureBuildToPipelineInfo(builds.Value[0]), nil
+}
+
+func mapAzureBuildToPipelineInfo(azureBuild build.Build) PipelineInfo {
+	status := InProgress
+	rawStatus := string(vcsutils.DefaultIfNotNil(azureBuild.Status))
+	if rawStatus == string(build.BuildStatusValues.Completed) {
+		rawStatus = string(vcsutils.DefaultIfNotNil(azureBuild.Result))
+		status = Fail
+		if rawStatus == string(build.BuildResultValues.Succeeded) {
+			status = Pass
+		}
+	}
+	return PipelineInfo{
+		ID:            strconv.Itoa(vcsutils.DefaultIfNotNil(azureBuild.Id)),
+		Status:        status,
+		RawStatus:     rawStatus,
+		Ref:           strings.TrimPrefix(vcsutils.DefaultIfNotNil(azureBuild.SourceBranch), "refs/heads/"),
+		CommitSHA:     vcsutils.DefaultIfNotNil(azureBuild.SourceVersion),
+		WebURL:        getAzureWebLink(azureBuild.Links),
+		CreatedAt:     extractTimeFromAzuredevopsTime(azureBuild.QueueTime),
+		LastUpdatedAt: extractTimeFromAzuredevopsTime(azureBuild.LastChangedDate),
+	}
+}
+
+// getAzureWebLink returns the URL of the web page of a resource, from the reference links of the resource
+func getAzureWebLink(links interface{}) string {
+	linksMap, _ := links.(map[string]interface{})
+	webLink, _ := linksMap["web"].(map[string]interface{})
+	href, _ := webLink["href"].(string)
+	return href
+}
+
+// Returns the statuses of the blocking policy evaluations of the active pull requests, whose source is the given commit
+func (client *AzureReposClient) getPolicyEvaluationStatuses(ctx context.Context, azureReposGitClient git.Client, repository, commitSha string) ([]CommitStatus, error) {
+	pullRequests, err := azureReposGitClient.GetPullRequests(ctx, git.GetPullRequestsArgs{
+		RepositoryId:   &repository,
+		Project:        &client.vcsInfo.Project,
+		SearchCriteria: &git.GitPullRequestSearchCriteria{Status: &git.PullRequestStatusValues.Active},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var policyClient policy.Client
+	var statuses []CommitStatus
+	for _, pullRequest := range *pullRequests {
+		if pullRequest.LastMergeSourceCommit == nil || vcsutils.DefaultIfNotNil(pullRequest.LastMergeSourceCommit.CommitId) != commitSha {
+			continue
+		}
+		if pullRequest.Repository == nil || pullRequest.Repository.Project == nil || pullRequest.Repository.Project.Id == nil {
+			return nil, fmt.Errorf("couldn't find the project of pull request %d", vcsutils.DefaultIfNotNil(pullRequest.PullRequestId))
+		}
+		if policyClient == nil {
+			if policyClient, err = client.buildPolicyClient(ctx); err != nil {
+				return nil, err
+			}
+		}
+		projectId := pullRequest.Repository.Project.Id.String()
+		evaluations, err := policyClient.GetPolicyEvaluations(ctx, policy.GetPolicyEvaluationsArgs{
+			Project:    &projectId,
+			ArtifactId: vcsutils.PointerOf(fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", projectId, vcsutils.DefaultIfNotNil(pullRequest.PullRequestId))),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, evaluation := range *evaluations {
+			if evaluation.Configuration == nil || !vcsutils.DefaultIfNotNil(evaluation.Configuration.IsBlocking) {
+				continue
+			}
+			statuses = append(statuses, mapPolicyEvaluationStatusToCommitStatus(vcsutils.DefaultIfNotNil(evaluation.Status)))
+		}
+	}
+	return statuses, nil
+}
+
+func mapPolicyEvaluationStatusToCommitStatus(evaluationStatus policy.PolicyEvaluationStatus) CommitStatus {
+	switch evaluationStatus {
+	case policy.PolicyEvaluationStatusValues.Approved, policy.PolicyEvaluationStatusValues.NotApplicable:
+		return Pass
+	case policy.PolicyEvaluationStatusValues.Rejected, policy.PolicyEvaluationStatusValues.Broken:
+		return Fail
+	default:
+		return InProgress
+	}
+}
+
+// CreateCodeInsightsReport on Azure Repos
+func (client *AzureReposClient) CreateCodeInsightsReport(_ context.Context, _, _, _ string, _ CodeInsightsReport) error {
+	return getUnsupportedInAzureError("create code insights report")
+}
+
+// AddCodeInsightsAnnotations on Azure Repos
+func (client *AzureReposClient) AddCodeInsightsAnnotations(_ context.Context, _, _, _, _ string, _ []CodeInsightsAnnotation) error {
+	return getUnsupportedInAzureError("add code insights annotations")
+}
+
+// CreateCheckRun on Azure Repos
+func (client *AzureReposClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, getUnsupportedInAzureError("create check run")
+}
+
+// UpdateCheckRun on Azure Repos
+func (client *AzureReposClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return getUnsupportedInAzureError("update check run")
+}
+
+// DownloadFileFromRepo on Azure Repos
+func (client *AzureReposClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	if err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"path":       path,
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	trueVal := true
+	output, err := azureReposGitClient.GetItemContent(ctx, git.GetItemContentArgs{
+		RepositoryId:      &repository,
+		Path:              &path,
+		Project:           &client.vcsInfo.Project,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &branch, VersionType: &git.GitVersionTypeValues.Branch},
+		IncludeContent:    &trueVal,
+	})
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	reader := bufio.NewReader(output)
+	// read the contents of the ReadCloser into a byte slice
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	return contents, http.StatusOK, nil
+}
+
+// ListDirectoryContents on Azure Repos
+func (client *AzureReposClient) ListDirectoryContents(ctx context.Context, _, repository, ref, path string) ([]DirectoryEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scopePath := "/" + strings.Trim(path, "/")
+	items, err := azureReposGitClient.GetItems(ctx, git.GetItemsArgs{
+		RepositoryId:      &repository,
+		Project:           &client.vcsInfo.Project,
+		ScopePath:         &scopePath,
+		RecursionLevel:    vcsutils.PointerOf(git.VersionControlRecursionTypeValues.OneLevel),
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &ref, VersionType: getAzureReposVersionType(ref)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []DirectoryEntry
+	for _, item := range vcsutils.DefaultIfNotNil(items) {
+		itemPath := vcsutils.DefaultIfNotNil(item.Path)
+		// The requested directory itself is returned as the first item
+		if itemPath == scopePath {
+			if !vcsutils.DefaultIfNotNil(item.IsFolder) {
+				return nil, fmt.Errorf("path '%s' is not a directory", path)
+			}
+			continue
+		}
+		// Azure returns all paths with '/' prefix. Other providers doesn't, so let's
+		// remove the prefix here to produce output of the same format.
+		itemPath = strings.TrimPrefix(itemPath, "/")
+		entries = append(entries, DirectoryEntry{
+			Name:  itemPath[strings.LastIndex(itemPath, "/")+1:],
+			Path:  itemPath,
+			IsDir: vcsutils.DefaultIfNotNil(item.IsFolder),
+		})
+	}
+	return entries, nil
+}
+
+// CommitFiles on Azure Repos
+func (client *AzureReposClient) CommitFiles(ctx context.Context, _, repository, branch, commitMessage string, files []FileToCommit) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch, "commitMessage": commitMessage})
+	if err != nil {
+		return err
+	}
+	if err = validateFilesToCommit(files); err != nil {
+		return err
+	}
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return err
+	}
+	// The push is rejected if the branch was updated since its latest commit was fetched
+	branchSha, err := client.getBranchLatestCommitSha(ctx, azureReposGitClient, repository, branch)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]interface{}, 0, len(files))
+	for _, file := range files {
+		change := git.GitChange{Item: git.GitItem{Path: vcsutils.PointerOf("/" + strings.TrimPrefix(file.Path, "/"))}}
+		switch file.ChangeType {
+		case FileAdded:
+			change.ChangeType = vcsutils.PointerOf(git.VersionControlChangeTypeValues.Add)
+		case FileDeleted:
+			change.ChangeType = vcsutils.PointerOf(git.VersionControlChangeTypeValues.Delete)
+		default:
+			change.ChangeType = vcsutils.PointerOf(git.VersionControlChangeTypeValues.Edit)
+		}
+		if file.ChangeType != FileDeleted {
+			change.NewContent = &git.ItemContent{
+				Content:     vcsutils.PointerOf(base64.StdEncoding.EncodeToString(file.Content)),
+				ContentType: vcsutils.PointerOf(git.ItemContentTypeValues.Base64Encoded),
+			}
+		}
+		changes = append(changes, change)
+	}
+	_, err = azureReposGitClient.CreatePush(ctx, git.CreatePushArgs{
+		Push: &git.GitPush{
+			RefUpdates: &[]git.GitRefUpdate{{Name: vcsutils.PointerOf(vcsutils.AddBranchPrefix(branch)), OldObjectId: &branchSha}},
+			Commits:    &[]git.GitCommitRef{{Comment: &commitMessage, Changes: &changes}},
+		},
+		RepositoryId: &repository,
+		Project:      &client.vcsInfo.Project,
+	})
+	return err
+}
+
+// GetRepositoryEnvironmentInfo on GitLab
+func (client *AzureReposClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, getUnsupportedInAzureError("get repository environment info")
+}
+
+// ListEnvironments on Azure Repos
+func (client *AzureReposClient) ListEnvironments(_ context.Context, _, _ string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, getUnsupportedInAzureError("list environments")
+}
+
+// CreateDeployment on Azure Repos
+func (client *AzureReposClient) CreateDeployment(_ context.Context, _, _, _, _, _ string) (DeploymentInfo, error) {
+	return DeploymentInfo{}, getUnsupportedInAzureError("create deployment")
+}
+
+// SetDeploymentStatus on Azure Repos
+func (client *AzureReposClient) SetDeploymentStatus(_ context.Context, _, _, _ string, _ DeploymentState, _, _ string) error {
+	return getUnsupportedInAzureError("set deployment status")
+}
+
+// CreateOrUpdateRepositorySecret on Azure Repos.
+// Azure Pipelines variables belong to variable groups of the project, hence the secret is set in the variable group named after the repository,
+// which is created if it doesn't exist.
+func (client *AzureReposClient) CreateOrUpdateRepositorySecret(ctx context.Context, _, repository, name, value string) error {
+	err := validateParametersNotBlank(map[string]string{"project": client.vcsInfo.Project, "repository": repository, "name": name, "value": value})
+	if err != nil {
+		return err
+	}
+	taskAgentClient, err := client.buildTaskAgentClient(ctx)
+	if err != nil {
+		return err
+	}
+	variableGroup, err := client.getRepositoryVariableGroup(ctx, taskAgentClient, repository)
+	if err != nil {
+		return err
+	}
+	secret := taskagent.VariableValue{Value: &value, IsSecret: vcsutils.PointerOf(true)}
+	if variableGroup == nil {
+		projectID, err := client.getProjectUUID(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = taskAgentClient.AddVariableGroup(ctx, taskagent.AddVariableGroupArgs{VariableGroupParameters: &taskagent.VariableGroupParameters{
+			Name:      &repository,
+			Type:      vcsutils.PointerOf(azureVariableGroupType),
+			Variables: &map[string]interface{}{name: secret},
+			VariableGroupProjectReferences: &[]taskagent.VariableGroupProjectReference{{
+				Name:             &repository,
+				ProjectReference: &taskagent.ProjectReference{Id: &projectID, Name: &client.vcsInfo.Project},
+			}},
+		}})
+		return err
+	}
+	// The existing secrets are returned without their values, and Azure DevOps keeps the values of secrets which are updated without a value
+	variables := map[string]interface{}{name: secret}
+	if variableGroup.Variables != nil {
+		for variableName, variableValue := range *variableGroup.Variables {
+			if variableName != name {
+				variables[variableName] = variableValue
+			}
+		}
+	}
+	_, err = taskAgentClient.UpdateVariableGroup(ctx, taskagent.UpdateVariableGroupArgs{
+		GroupId: variableGroup.Id,
+		VariableGroupParameters: &taskagent.VariableGroupParameters{
+			Name:                           variableGroup.Name,
+			Description:                    variableGroup.Description,
+			Type:                           variableGroup.Type,
+			Variables:                      &variables,
+			VariableGroupProjectReferences: variableGroup.VariableGroupProjectReferences,
+		},
+	})
+	return err
+}
+
+// ListRepositoryVariables on Azure Repos.
+// Returns the variables of the variable group named after the repository, sorted by their names.
+func (client *AzureReposClient) ListRepositoryVariables(ctx context.Context, _, repository string) ([]RepositoryVariableInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"project": client.vcsInfo.Project, "repository": repository})
 	if err != nil {
 		return nil, err
 	}
-	commitStatusArgs := git.GetStatusesArgs{
-		CommitId:     &ref,
-		RepositoryId: &repository,
-		Project:      &client.vcsInfo.Project,
+	taskAgentClient, err := client.buildTaskAgentClient(ctx)
+	if err != nil {
+		return nil, err
 	}
-	resGitStatus, err := azureReposGitClient.GetStatuses(ctx, commitStatusArgs)
+	variableGroup, err := client.getRepositoryVariableGroup(ctx, taskAgentClient, repository)
+	if err != nil || variableGroup == nil || variableGroup.Variables == nil {
+		return nil, err
+	}
+	// The variables are decoded as generic maps, so they are converted to variable values through JSON
+	rawVariables, err := json.Marshal(variableGroup.Variables)
 	if err != nil {
 		return nil, err
 	}
-	results := make([]CommitStatusInfo, 0)
-	for _, singleStatus := range *resGitStatus {
-		results = append(results, CommitStatusInfo{
-			State:         commitStatusAsStringToStatus(string(*singleStatus.State)),
-			Description:   *singleStatus.Description,
-			DetailsUrl:    *singleStatus.TargetUrl,
-			Creator:       *singleStatus.CreatedBy.DisplayName,
-			LastUpdatedAt: extractTimeFromAzuredevopsTime(singleStatus.UpdatedDate),
-			CreatedAt:     extractTimeFromAzuredevopsTime(singleStatus.CreationDate),
-		})
+	var variableValues map[string]taskagent.VariableValue
+	if err = json.Unmarshal(rawVariables, &variableValues); err != nil {
+		return nil, err
 	}
-	return results, err
+	variables := make([]RepositoryVariableInfo, 0, len(variableValues))
+	for name, variableValue := range variableValues {
+		variable := RepositoryVariableInfo{Name: name, IsSecret: variableValue.IsSecret != nil && *variableValue.IsSecret}
+		if !variable.IsSecret && variableValue.Value != nil {
+			variable.Value = *variableValue.Value
+		}
+		variables = append(variables, variable)
+	}
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+	return variables, nil
 }
 
-// DownloadFileFromRepo on Azure Repos
-func (client *AzureReposClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+// CreateOrUpdateOrganizationSecret on Azure Repos
+func (client *AzureReposClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return getUnsupportedInAzureError("create or update organization secret")
+}
+
+// ListOrganizationSecrets on Azure Repos
+func (client *AzureReposClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, getUnsupportedInAzureError("list organization secrets")
+}
+
+// ListOrganizationSecretRepositories on Azure Repos
+func (client *AzureReposClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, getUnsupportedInAzureError("list organization secret repositories")
+}
+
+// DeleteOrganizationSecret on Azure Repos
+func (client *AzureReposClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return getUnsupportedInAzureError("delete organization secret")
+}
+
+// CreateOrUpdateEnvironmentSecret on Azure Repos
+func (client *AzureReposClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return getUnsupportedInAzureError("create or update environment secret")
+}
+
+// ListEnvironmentSecrets on Azure Repos
+func (client *AzureReposClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, getUnsupportedInAzureError("list environment secrets")
+}
+
+// DeleteEnvironmentSecret on Azure Repos
+func (client *AzureReposClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return getUnsupportedInAzureError("delete environment secret")
+}
+
+// getRepositoryVariableGroup returns the variable group named after the repository, or nil if the project has no such group
+func (client *AzureReposClient) getRepositoryVariableGroup(ctx context.Context, taskAgentClient taskagent.Client, repository string) (*taskagent.VariableGroup, error) {
+	variableGroups, err := taskAgentClient.GetVariableGroups(ctx, taskagent.GetVariableGroupsArgs{Project: &client.vcsInfo.Project, GroupName: &repository})
+	if err != nil || variableGroups == nil {
+		return nil, err
+	}
+	// The group name filter supports wildcards, so the name is matched exactly
+	for i := range *variableGroups {
+		if variableGroup := &(*variableGroups)[i]; variableGroup.Name != nil && *variableGroup.Name == repository {
+			return variableGroup, nil
+		}
+	}
+	return nil, nil
+}
+
+func (client *AzureReposClient) GetModifiedFiles(ctx context.Context, _, repository, refBefore, refAfter string) ([]string, error) {
 	if err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
 		"repository": repository,
-		"path":       path,
+		"refBefore":  refBefore,
+		"refAfter":   refAfter,
 	}); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	trueVal := true
-	output, err := azureReposGitClient.GetItemContent(ctx, git.GetItemContentArgs{
-		RepositoryId:      &repository,
-		Path:              &path,
-		Project:           &client.vcsInfo.Project,
-		VersionDescriptor: &git.GitVersionDescriptor{Version: &branch, VersionType: &git.GitVersionTypeValues.Branch},
-		IncludeContent:    &trueVal,
-	})
+	files, err := client.getCommitDiffsFiles(ctx, azureReposGitClient, repository, refBefore, refAfter)
 	if err != nil {
-		return nil, http.StatusNotFound, err
+		return nil, err
 	}
 
-	reader := bufio.NewReader(output)
-	// read the contents of the ReadCloser into a byte slice
-	contents, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, 0, err
+	fileNamesSet := datastructures.MakeSet[string]()
+	for _, file := range files {
+		fileNamesSet.Add(file.Path)
 	}
-	return contents, http.StatusOK, nil
-}
-
-// GetRepositoryEnvironmentInfo on GitLab
-func (client *AzureReposClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
-	return RepositoryEnvironmentInfo{}, getUnsupportedInAzureError("get repository environment info")
+	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
+	fileNamesList := fileNamesSet.ToSlice()
+	sort.Strings(fileNamesList)
+	return fileNamesList, nil
 }
 
-func (client *AzureReposClient) GetModifiedFiles(ctx context.Context, _, repository, refBefore, refAfter string) ([]string, error) {
+// CompareCommits on Azure Repos
+func (client *AzureReposClient) CompareCommits(ctx context.Context, _, repository, base, head string) (CommitsComparison, error) {
 	if err := validateParametersNotBlank(map[string]string{
 		"repository": repository,
-		"refBefore":  refBefore,
-		"refAfter":   refAfter,
+		"base":       base,
+		"head":       head,
 	}); err != nil {
-		return nil, err
+		return CommitsComparison{}, err
 	}
 
 	azureReposGitClient, err := client.buildAzureReposClient(ctx)
 	if err != nil {
-		return nil, err
+		return CommitsComparison{}, err
 	}
 
-	fileNamesSet := datastructures.MakeSet[string]()
+	var commitsComparison CommitsComparison
+	searchCriteria := &git.GitQueryCommitsCriteria{
+		ItemVersion:    &git.GitVersionDescriptor{Version: &head, VersionType: getAzureReposVersionType(head)},
+		CompareVersion: &git.GitVersionDescriptor{Version: &base, VersionType: getAzureReposVersionType(base)},
+		Top:            vcsutils.PointerOf(100),
+		Skip:           vcsutils.PointerOf(0),
+	}
+	for {
+		commits, err := azureReposGitClient.GetCommits(ctx, git.GetCommitsArgs{
+			RepositoryId:   &repository,
+			Project:        &client.vcsInfo.Project,
+			SearchCriteria: searchCriteria,
+		})
+		if err != nil {
+			return CommitsComparison{}, err
+		}
+		for _, commit := range vcsutils.DefaultIfNotNil(commits) {
+			commitsComparison.Commits = append(commitsComparison.Commits, mapAzureReposCommitsToCommitInfo(commit))
+		}
+		if len(vcsutils.DefaultIfNotNil(commits)) < *searchCriteria.Top {
+			break
+		}
+		searchCriteria.Skip = vcsutils.PointerOf(*searchCriteria.Skip + *searchCriteria.Top)
+	}
+
+	if commitsComparison.Files, err = client.getCommitDiffsFiles(ctx, azureReposGitClient, repository, base, head); err != nil {
+		return CommitsComparison{}, err
+	}
+	return commitsComparison, nil
+}
+
+// Returns the files changed between the common commit of the references and refAfter
+func (client *AzureReposClient) getCommitDiffsFiles(ctx context.Context, gitClient git.Client, repository, refBefore, refAfter string) ([]PullRequestFile, error) {
+	var files []PullRequestFile
 	changesToReturn := vcsutils.PointerOf(100)
 	changesToSkip := vcsutils.PointerOf(0)
 
 	for *changesToReturn >= 0 {
-		commitDiffs, err := azureReposGitClient.GetCommitDiffs(ctx, git.GetCommitDiffsArgs{
+		commitDiffs, err := gitClient.GetCommitDiffs(ctx, git.GetCommitDiffsArgs{
 			Top:                     changesToReturn,
 			Skip:                    changesToSkip,
 			RepositoryId:            &repository,
@@ -684,15 +3077,267 @@ func (client *AzureReposClient) GetModifiedFiles(ctx context.Context, _, reposit
 				continue
 			}
 
-			// Azure returns all paths with '/' prefix. Other providers doesn't, so let's
-			// remove the prefix here to produce output of the same format.
-			fileNamesSet.Add(strings.TrimPrefix(vcsutils.DefaultIfNotNil(changedItem.Path), "/"))
+			files = append(files, mapAzureReposChangeToPullRequestFile(
+				git.GitPullRequestChange{ChangeType: change.ChangeType, OriginalPath: change.OriginalPath},
+				vcsutils.DefaultIfNotNil(changedItem.Path)))
 		}
 	}
-	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
-	fileNamesList := fileNamesSet.ToSlice()
-	sort.Strings(fileNamesList)
-	return fileNamesList, nil
+	return files, nil
+}
+
+// Returns the version type of a VCS reference - commit for SHAs, and branch otherwise
+func getAzureReposVersionType(ref string) *git.GitVersionType {
+	if plumbing.IsHash(ref) {
+		return vcsutils.PointerOf(git.GitVersionTypeValues.Commit)
+	}
+	return vcsutils.PointerOf(git.GitVersionTypeValues.Branch)
+}
+
+// ListPullRequestFiles on Azure Repos
+func (client *AzureReposClient) ListPullRequestFiles(ctx context.Context, _, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latestIteration, err := client.getLatestPullRequestIteration(ctx, azureReposGitClient, repository, pullRequestID)
+	if err != nil || latestIteration == nil {
+		return nil, err
+	}
+	return client.listPullRequestIterationFiles(ctx, azureReposGitClient, repository, pullRequestID, latestIteration.Id)
+}
+
+// GetPullRequestDiff on Azure Repos
+func (client *AzureReposClient) GetPullRequestDiff(ctx context.Context, _, repository string, pullRequestID int) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return "", err
+	}
+
+	azureReposGitClient, err := client.buildAzureReposClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	latestIteration, err := client.getLatestPullRequestIteration(ctx, azureReposGitClient, repository, pullRequestID)
+	if err != nil || latestIteration == nil {
+		return "", err
+	}
+	files, err := client.listPullRequestIterationFiles(ctx, azureReposGitClient, repository, pullRequestID, latestIteration.Id)
+	if err != nil {
+		return "", err
+	}
+
+	// Azure Repos doesn't provide the diff itself, so it is computed from the file contents before and after the changes
+	baseCommit := vcsutils.DefaultIfNotNil(latestIteration.CommonRefCommit.CommitId)
+	sourceCommit := vcsutils.DefaultIfNotNil(latestIteration.SourceRefCommit.CommitId)
+	var patch azureReposPatch
+	for _, file := range files {
+		var from, to *azureReposDiffFile
+		if file.ChangeType != FileAdded {
+			fromPath := file.Path
+			if file.ChangeType == FileRenamed {
+				fromPath = file.PreviousPath
+			}
+			if from, err = client.getDiffFile(ctx, azureReposGitClient, repository, fromPath, baseCommit); err != nil {
+				return "", err
+			}
+		}
+		if file.ChangeType != FileDeleted {
+			if to, err = client.getDiffFile(ctx, azureReposGitClient, repository, file.Path, sourceCommit); err != nil {
+				return "", err
+			}
+		}
+		patch = append(patch, newAzureReposFilePatch(from, to))
+	}
+
+	var unifiedDiff strings.Builder
+	err = diff.NewUnifiedEncoder(&unifiedDiff, diff.DefaultContextLines).Encode(patch)
+	return unifiedDiff.String(), err
+}
+
+// Returns the latest iteration of a pull request, or nil if the pull request has no iterations.
+// The changes of the latest iteration are compared against the common commit of the source and target branches.
+func (client *AzureReposClient) getLatestPullRequestIteration(ctx context.Context, gitClient git.Client, repository string, pullRequestID int) (*git.GitPullRequestIteration, error) {
+	iterations, err := gitClient.GetPullRequestIterations(ctx, git.GetPullRequestIterationsArgs{
+		RepositoryId:  &repository,
+		PullRequestId: &pullRequestID,
+		Project:       &client.vcsInfo.Project,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if iterations == nil || len(*iterations) == 0 {
+		return nil, nil
+	}
+	return &(*iterations)[len(*iterations)-1], nil
+}
+
+func (client *AzureReposClient) listPullRequestIterationFiles(ctx context.Context, gitClient git.Client, repository string, pullRequestID int, iterationID *int) ([]PullRequestFile, error) {
+	var files []PullRequestFile
+	for changesToReturn, changesToSkip := 100, 0; changesToReturn > 0; {
+		iterationChanges, err := gitClient.GetPullRequestIterationChanges(ctx, git.GetPullRequestIterationChangesArgs{
+			RepositoryId:  &repository,
+			PullRequestId: &pullRequestID,
+			IterationId:   iterationID,
+			Project:       &client.vcsInfo.Project,
+			Top:           &changesToReturn,
+			Skip:          &changesToSkip,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range vcsutils.DefaultIfNotNil(iterationChanges.ChangeEntries) {
+			changedItem, err := vcsutils.RemapFields[git.GitItem](change.Item, "json")
+			if err != nil {
+				return nil, err
+			}
+			if vcsutils.DefaultIfNotNil(changedItem.IsFolder) {
+				continue
+			}
+			files = append(files, mapAzureReposChangeToPullRequestFile(change, vcsutils.DefaultIfNotNil(changedItem.Path)))
+		}
+		changesToReturn, changesToSkip = vcsutils.DefaultIfNotNil(iterationChanges.NextTop), vcsutils.DefaultIfNotNil(iterationChanges.NextSkip)
+	}
+	return files, nil
+}
+
+func (client *AzureReposClient) getDiffFile(ctx context.Context, gitClient git.Client, repository, path, commit string) (diffFile *azureReposDiffFile, err error) {
+	content, err := gitClient.GetItemContent(ctx, git.GetItemContentArgs{
+		RepositoryId:      &repository,
+		Path:              vcsutils.PointerOf("/" + path),
+		Project:           &client.vcsInfo.Project,
+		VersionDescriptor: &git.GitVersionDescriptor{Version: &commit, VersionType: vcsutils.PointerOf(git.GitVersionTypeValues.Commit)},
+	})
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, content.Close())
+	}()
+	contentBytes, err := io.ReadAll(content)
+	if err != nil {
+		return
+	}
+	return &azureReposDiffFile{path: path, content: contentBytes}, nil
+}
+
+func mapAzureReposChangeToPullRequestFile(change git.GitPullRequestChange, path string) PullRequestFile {
+	// Azure returns all paths with '/' prefix. Other providers doesn't, so let's
+	// remove the prefix here to produce output of the same format.
+	file := PullRequestFile{Path: strings.TrimPrefix(path, "/")}
+	// The change type may combine several types, such as "edit, rename"
+	changeType := string(vcsutils.DefaultIfNotNil(change.ChangeType))
+	switch {
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Delete)):
+		file.ChangeType = FileDeleted
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Add)):
+		file.ChangeType = FileAdded
+	case strings.Contains(changeType, string(git.VersionControlChangeTypeValues.Rename)):
+		file.ChangeType = FileRenamed
+		file.PreviousPath = strings.TrimPrefix(vcsutils.DefaultIfNotNil(change.OriginalPath), "/")
+	default:
+		file.ChangeType = FileModified
+	}
+	return file
+}
+
+// The following types implement the go-git diff interfaces, to encode the pull request changes as a unified diff
+type azureReposPatch []diff.FilePatch
+
+func (p azureReposPatch) FilePatches() []diff.FilePatch {
+	return p
+}
+
+func (p azureReposPatch) Message() string {
+	return ""
+}
+
+type azureReposFilePatch struct {
+	from, to *azureReposDiffFile
+	isBinary bool
+	chunks   []diff.Chunk
+}
+
+func newAzureReposFilePatch(from, to *azureReposDiffFile) *azureReposFilePatch {
+	filePatch := &azureReposFilePatch{from: from, to: to}
+	var fromContent, toContent []byte
+	if from != nil {
+		fromContent = from.content
+	}
+	if to != nil {
+		toContent = to.content
+	}
+	if isBinaryContent(fromContent) || isBinaryContent(toContent) {
+		filePatch.isBinary = true
+		return filePatch
+	}
+	for _, textDiff := range utildiff.Do(string(fromContent), string(toContent)) {
+		operation := diff.Equal
+		switch textDiff.Type {
+		case diffmatchpatch.DiffDelete:
+			operation = diff.Delete
+		case diffmatchpatch.DiffInsert:
+			operation = diff.Add
+		}
+		filePatch.chunks = append(filePatch.chunks, azureReposDiffChunk{content: textDiff.Text, operation: operation})
+	}
+	return filePatch
+}
+
+func isBinaryContent(content []byte) bool {
+	isBinary, err := binary.IsBinary(bytes.NewReader(content))
+	return err == nil && isBinary
+}
+
+func (p *azureReposFilePatch) IsBinary() bool {
+	return p.isBinary
+}
+
+func (p *azureReposFilePatch) Files() (from, to diff.File) {
+	// Avoid returning typed nil pointers, which the encoder wouldn't recognize as missing files
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return
+}
+
+func (p *azureReposFilePatch) Chunks() []diff.Chunk {
+	return p.chunks
+}
+
+type azureReposDiffFile struct {
+	path    string
+	content []byte
+}
+
+func (f *azureReposDiffFile) Hash() plumbing.Hash {
+	return plumbing.ComputeHash(plumbing.BlobObject, f.content)
+}
+
+func (f *azureReposDiffFile) Mode() filemode.FileMode {
+	return filemode.Regular
+}
+
+func (f *azureReposDiffFile) Path() string {
+	return f.path
+}
+
+type azureReposDiffChunk struct {
+	content   string
+	operation diff.Operation
+}
+
+func (c azureReposDiffChunk) Content() string {
+	return c.content
+}
+
+func (c azureReposDiffChunk) Type() diff.Operation {
+	return c.operation
 }
 
 func parsePullRequestDetails(client *AzureReposClient, pullRequest git.GitPullRequest, owner, repository string, withBody bool) PullRequestInfo {
@@ -714,10 +3359,20 @@ func parsePullRequestDetails(client *AzureReposClient, pullRequest git.GitPullRe
 		}
 	}
 
+	var author string
+	if pullRequest.CreatedBy != nil {
+		author = vcsutils.DefaultIfNotNil(pullRequest.CreatedBy.UniqueName)
+	}
+
 	return PullRequestInfo{
-		ID:   int64(*pullRequest.PullRequestId),
-		Body: prBody,
-		URL:  vcsutils.DefaultIfNotNil(pullRequest.Url),
+		ID:        int64(*pullRequest.PullRequestId),
+		Title:     vcsutils.DefaultIfNotNil(pullRequest.Title),
+		Body:      prBody,
+		URL:       client.getPullRequestWebURL(repository, *pullRequest.PullRequestId),
+		Author:    author,
+		State:     mapAzureReposPullRequestStatus(pullRequest.Status),
+		Mergeable: pullRequest.MergeStatus != nil && *pullRequest.MergeStatus == git.PullRequestAsyncStatusValues.Succeeded,
+		CreatedAt: extractTimeFromAzuredevopsTime(pullRequest.CreationDate),
 		Source: BranchInfo{
 			Name:       shortSourceName,
 			Repository: repository,
@@ -731,17 +3386,45 @@ func parsePullRequestDetails(client *AzureReposClient, pullRequest git.GitPullRe
 	}
 }
 
-// Extract the repository owner of a forked source
+// The pull request URL returned by the API points to the REST resource, so the web URL is built from the API endpoint
+func (client *AzureReposClient) getPullRequestWebURL(repository string, pullRequestID int) string {
+	return fmt.Sprintf("%s/%s/_git/%s/pullrequest/%d", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/"),
+		url.PathEscape(client.vcsInfo.Project), url.PathEscape(repository), pullRequestID)
+}
+
+func isAzureReposNotFoundError(err error) bool {
+	var wrappedError azuredevops.WrappedError
+	if errors.As(err, &wrappedError) {
+		return wrappedError.StatusCode != nil && *wrappedError.StatusCode == http.StatusNotFound
+	}
+	var wrappedErrorPointer *azuredevops.WrappedError
+	return errors.As(err, &wrappedErrorPointer) && wrappedErrorPointer.StatusCode != nil && *wrappedErrorPointer.StatusCode == http.StatusNotFound
+}
+
+// Extract the repository owner of a forked source.
+// It's the organization on Azure DevOps Services, and the collection on Azure DevOps Server.
 func extractOwnerFromForkedRepoUrl(forkedGit *git.GitForkRef) string {
 	if forkedGit == nil || forkedGit.Repository == nil || forkedGit.Repository.Url == nil {
 		return ""
 	}
-	url := *forkedGit.Repository.Url
-	if !strings.Contains(url, defaultAzureBaseUrl) {
+	repositoryUrl := *forkedGit.Repository.Url
+	if strings.Contains(repositoryUrl, defaultAzureBaseUrl) {
+		return strings.Split(strings.TrimPrefix(repositoryUrl, defaultAzureBaseUrl), "/")[0]
+	}
+	// The URL of a repository on Azure DevOps Server is <collection URL>/<project>/_apis/git/repositories/<repository>
+	parsedUrl, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return ""
+	}
+	collectionAndProject, _, found := strings.Cut(parsedUrl.Path, "/_apis/")
+	if !found {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(collectionAndProject, "/"), "/")
+	if len(segments) < 2 {
 		return ""
 	}
-	owner := strings.Split(strings.TrimPrefix(url, defaultAzureBaseUrl), "/")[0]
-	return owner
+	return segments[len(segments)-2]
 }
 
 // mapStatusToString maps commit status enum to string, specific for azure.
@@ -772,3 +3455,117 @@ func azureMapPullRequestState(state vcsutils.PullRequestState) *git.PullRequestS
 		return nil
 	}
 }
+
+// createAzureReposSubscription creates a service hook subscription which sends the event to the payload URL.
+// Azure doesn't sign the payloads, hence the token is sent as the basic authentication password.
+func createAzureReposSubscription(projectID, repositoryID, branch, payloadURL, token, eventType string) *servicehooks.Subscription {
+	publisherInputs := map[string]string{
+		"projectId":  projectID,
+		"repository": repositoryID,
+	}
+	if branch != "" {
+		publisherInputs["branch"] = branch
+	}
+	return &servicehooks.Subscription{
+		PublisherId:      vcsutils.PointerOf(azureWebhookPublisherID),
+		EventType:        &eventType,
+		ResourceVersion:  vcsutils.PointerOf("1.0"),
+		ConsumerId:       vcsutils.PointerOf(azureWebhookConsumerID),
+		ConsumerActionId: vcsutils.PointerOf(azureWebhookConsumerActionID),
+		PublisherInputs:  &publisherInputs,
+		ConsumerInputs: &map[string]string{
+			"url":               payloadURL,
+			"basicAuthUsername": AzureWebhookBasicAuthUsername,
+			"basicAuthPassword": token,
+		},
+	}
+}
+
+func getAzureReposSubscriptionPayloadURL(subscription servicehooks.Subscription) string {
+	if subscription.ConsumerInputs == nil {
+		return ""
+	}
+	return (*subscription.ConsumerInputs)["url"]
+}
+
+// The webhook is active if all of its subscriptions are enabled
+func mapAzureReposSubscriptionsToWebhookInfo(subscriptions []servicehooks.Subscription) WebhookInfo {
+	webhook := WebhookInfo{Active: len(subscriptions) > 0}
+	var subscriptionIDs, eventTypes []string
+	for _, subscription := range subscriptions {
+		if subscription.Id != nil {
+			subscriptionIDs = append(subscriptionIDs, subscription.Id.String())
+		}
+		eventTypes = append(eventTypes, vcsutils.DefaultIfNotNil(subscription.EventType))
+		webhook.PayloadURL = getAzureReposSubscriptionPayloadURL(subscription)
+		webhook.Active = webhook.Active && vcsutils.DefaultIfNotNil(subscription.Status) == servicehooks.SubscriptionStatusValues.Enabled
+	}
+	webhook.ID = strings.Join(subscriptionIDs, azureWebhookIDSeparator)
+	webhook.Events = getSubscribedWebhookEvents(eventTypes, getAzureReposWebhookEventTypes)
+	return webhook
+}
+
+// Get varargs of webhook events and return a slice of distinct Azure Repos service hook event types
+func getAzureReposWebhookEventTypes(webhookEvents ...vcsutils.WebhookEvent) []string {
+	var eventTypes []string
+	for _, event := range webhookEvents {
+		var eventType string
+		switch event {
+		case vcsutils.PrOpened:
+			eventType = "git.pullrequest.created"
+		case vcsutils.PrEdited, vcsutils.PrRejected, vcsutils.PrClosed:
+			eventType = "git.pullrequest.updated"
+		case vcsutils.PrMerged:
+			eventType = "git.pullrequest.merged"
+		case vcsutils.PrCommentCreated, vcsutils.PrCommentEdited:
+			eventType = "ms.vss-code.git-pullrequest-comment-event"
+		case vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved:
+			eventType = "git.push"
+		default:
+			continue
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	return eventTypes
+}
+
+func parseAzureReposWebhookID(webhookID string) ([]uuid.UUID, error) {
+	var subscriptionIDs []uuid.UUID
+	for _, rawID := range strings.Split(webhookID, azureWebhookIDSeparator) {
+		subscriptionID, err := uuid.Parse(strings.TrimSpace(rawID))
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook ID %s: %w", webhookID, err)
+		}
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+	return subscriptionIDs, nil
+}
+
+func getAzureReposMergeStrategy(mergeMethod vcsutils.MergeMethod) (git.GitPullRequestMergeStrategy, error) {
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge:
+		return git.GitPullRequestMergeStrategyValues.NoFastForward, nil
+	case vcsutils.MergeMethodSquash:
+		return git.GitPullRequestMergeStrategyValues.Squash, nil
+	case vcsutils.MergeMethodRebase:
+		return git.GitPullRequestMergeStrategyValues.Rebase, nil
+	default:
+		return "", getUnsupportedMergeMethodError(mergeMethod)
+	}
+}
+
+func mapAzureReposPullRequestStatus(status *git.PullRequestStatus) vcsutils.PullRequestState {
+	if status == nil {
+		return ""
+	}
+	switch *status {
+	case git.PullRequestStatusValues.Completed:
+		return vcsutils.Merged
+	case git.PullRequestStatusValues.Abandoned:
+		return vcsutils.Closed
+	default:
+		return vcsutils.Open
+	}
+}
@@ -7,13 +7,20 @@ import (
 	"fmt"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -26,6 +33,219 @@ func TestAzureRepos_Connection(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAzureRepos_TestConnectionWithScopes(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(createPermissionsAzureReposHandler(t, "", nil, http.StatusOK))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Username("frogger").Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.TestConnectionWithScopes(ctx))
+	assert.NoError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope, RepositoryWriteScope))
+	assert.EqualError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope, WebhookAdminScope), "the token is missing the following scopes: webhook admin")
+}
+
+func TestAzureRepos_AzureADToken(t *testing.T) {
+	ctx := context.Background()
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			_, err = w.Write(jsonVal)
+			assert.NoError(t, err)
+		case "/_apis/ResourceAreas/connectionData":
+			_, err := w.Write([]byte(`{"authenticatedUser": {"providerDisplayName": "Frogger App", "properties": {"Account": {"$type": "System.String", "$value": "frogger-app"}}}}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).AzureADToken(token).Project(project).Build()
+	assert.NoError(t, err)
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "frogger-app", user.Username)
+	for _, authHeader := range authHeaders {
+		assert.Equal(t, "Bearer "+token, authHeader)
+	}
+
+	authHeaders = nil
+	client, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).AzureADTokenProvider(func(context.Context) (string, error) {
+		return "azure-ad-token", nil
+	}).Project(project).Build()
+	assert.NoError(t, err)
+	_, err = client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, authHeaders)
+	for _, authHeader := range authHeaders {
+		assert.Equal(t, "Bearer azure-ad-token", authHeader)
+	}
+}
+
+func TestAzureRepos_GitClientIsCached(t *testing.T) {
+	ctx := context.Background()
+	accessToken := "token-1"
+	// The client of Azure DevOps Server is built without requests to the server
+	client, err := NewAzureReposClient(VcsInfo{APIEndpoint: "https://azure.jfrog.io/tfs/DefaultCollection", Project: project, AzureDevOpsServer: true,
+		TokenProvider: func(context.Context) (string, error) {
+			return accessToken, nil
+		}}, vcsutils.EmptyLogger{})
+	assert.NoError(t, err)
+
+	gitClient, err := client.buildAzureReposClient(ctx)
+	assert.NoError(t, err)
+	cachedGitClient, err := client.buildAzureReposClient(ctx)
+	assert.NoError(t, err)
+	assert.Same(t, gitClient, cachedGitClient)
+
+	// The git client is rebuilt when the token changes
+	accessToken = "token-2"
+	rebuiltGitClient, err := client.buildAzureReposClient(ctx)
+	assert.NoError(t, err)
+	assert.NotSame(t, gitClient, rebuiltGitClient)
+}
+
+func TestAzureRepos_AzureDevOpsServer(t *testing.T) {
+	ctx := context.Background()
+	var acceptHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		// The SDK sends the requests to the lowercase collection URL
+		switch r.URL.Path {
+		case "/tfs/defaultcollection/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "serverResourcesResponse.json"))
+			assert.NoError(t, err)
+			_, err = w.Write(jsonVal)
+			assert.NoError(t, err)
+			return
+		case "/tfs/defaultcollection/_apis/ConnectionData":
+			_, err := w.Write([]byte(`{"authenticatedUser": {"providerDisplayName": "Frog Ger", "properties": {"Account": {"$type": "System.String", "$value": "JFROG\\frogger"}}}}`))
+			assert.NoError(t, err)
+		case "/tfs/defaultcollection/" + project + "/_apis/git/repositories/" + repo1 + "/pullRequests":
+			_, err := w.Write([]byte(`{"count": 1, "value": [{"pullRequestId": 1, "sourceRefName": "refs/heads/branch-1", "targetRefName": "refs/heads/branch-2"}]}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+			return
+		}
+		acceptHeaders = append(acceptHeaders, r.Header.Get("Accept"))
+	}))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.AzureRepos).AzureDevOpsServer(server.URL + "/tfs/DefaultCollection/").Token(token).Project(project).Build()
+	assert.NoError(t, err)
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "JFROG\\frogger", user.Username)
+	pullRequests, err := client.ListOpenPullRequests(ctx, owner, repo1)
+	assert.NoError(t, err)
+	if assert.Len(t, pullRequests, 1) {
+		assert.Equal(t, int64(1), pullRequests[0].ID)
+	}
+	// The API version is negotiated down to the latest version of the collection
+	assert.Equal(t, []string{"application/json;api-version=6.0", "application/json;api-version=6.0"}, acceptHeaders)
+}
+
+func TestAzureRepos_NTLMCredentials(t *testing.T) {
+	ctx := context.Background()
+	var authSchemes []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authScheme, _, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		authSchemes = append(authSchemes, authScheme)
+		if authScheme != "NTLM" {
+			w.Header().Set("WWW-Authenticate", "NTLM")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.RequestURI {
+		case "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "serverResourcesResponse.json"))
+			assert.NoError(t, err)
+			_, err = w.Write(jsonVal)
+			assert.NoError(t, err)
+		case "/_apis/ConnectionData":
+			_, err := w.Write([]byte(`{"authenticatedUser": {"providerDisplayName": "Frog Ger", "properties": {"Account": {"$type": "System.String", "$value": "JFROG\\frogger"}}}}`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+	}))
+	var connections int
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections++
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.AzureRepos).AzureDevOpsServer(server.URL).AzureNTLMCredentials("JFROG\\frogger", "password").Project(project).
+		InsecureSkipVerify(true).Build()
+	assert.NoError(t, err)
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "JFROG\\frogger", user.Username)
+	// Each request is sent anonymously first, and then with an NTLM negotiation message
+	assert.Equal(t, []string{"", "NTLM", "", "NTLM"}, authSchemes)
+
+	// The HTTP client is built once, so its connections are reused
+	_, err = client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, connections)
+}
+
+func TestAzureRepos_AzureAPIVersion(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, vcsutils.RemoveTempDir(dir)) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "hello_world.zip"))
+	assert.NoError(t, err)
+	downloadURL := fmt.Sprintf("/%s/_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip&api-version=6.0", project, repo1, branch1)
+	handler := createGetRepositoryAzureReposHandler(t, downloadURL, repoFile, http.StatusOK)
+	var acceptHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI != "/_apis" {
+			acceptHeaders = append(acceptHeaders, r.Header.Get("Accept"))
+		}
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).AzureAPIVersion("6.0").Build()
+	assert.NoError(t, err)
+	assert.NoError(t, client.DownloadRepository(ctx, owner, repo1, branch1, dir))
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	// The download request is sent without an Accept header, and the SDK requests version 7.1-preview.1 of the repository
+	assert.Equal(t, []string{"", "application/json;api-version=6.0-preview"}, acceptHeaders)
+
+	_, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).AzureAPIVersion("5.1").Build()
+	assert.EqualError(t, err, "unsupported Azure DevOps REST API version 5.1, the supported versions are 6.0, 7.0, 7.1")
+}
+
+func TestAzureRepos_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"authenticatedUser": {"providerDisplayName": "Frog Ger", "properties": {"Account": {"$type": "System.String", "$value": "frogger@jfrog.com"}}}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "/_apis/ResourceAreas/connectionData", createAzureReposHandler)
+	defer cleanUp()
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: "frogger@jfrog.com", DisplayName: "Frog Ger", Email: "frogger@jfrog.com"}, user)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetAuthenticatedUser(ctx)
+	assert.Error(t, err)
+}
+
 func TestAzureRepos_ListRepositories(t *testing.T) {
 	type ListRepositoryResponse struct {
 		Value []git.GitRepository
@@ -39,13 +259,13 @@ func TestAzureRepos_ListRepositories(t *testing.T) {
 	jsonRes, err := json.Marshal(res)
 	assert.NoError(t, err)
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getRepository", createAzureReposHandler)
-	defer cleanUp()
+	server := httptest.NewServer(createAzureReposHandler(t, "getRepository", jsonRes, http.StatusOK))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
 	reposMap, err := client.ListRepositories(ctx)
 	assert.NoError(t, err)
-	for _, repos := range reposMap {
-		assert.ElementsMatch(t, repos, testRepos)
-	}
+	assert.Equal(t, map[string][]string{project: testRepos}, reposMap)
 
 	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
 	defer badClientCleanup()
@@ -53,6 +273,73 @@ func TestAzureRepos_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_ListRepositoriesWithOptions(t *testing.T) {
+	type ListRepositoryResponse struct {
+		Value []git.GitRepository
+		Count int
+	}
+	testRepos := []string{"test_repo_2", "test_repo_1"}
+	res := ListRepositoryResponse{
+		Value: []git.GitRepository{{Name: &testRepos[0]}, {Name: &testRepos[1]}},
+		Count: 2,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	server := httptest.NewServer(createAzureReposHandler(t, "getRepository", jsonRes, http.StatusOK))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+	reposMap, err := client.ListRepositoriesWithOptions(ctx, ListOptions{Page: 1, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{project: {"test_repo_1"}}, reposMap)
+}
+
+func TestAzureRepos_ListRepositoriesOfAllProjects(t *testing.T) {
+	ctx := context.Background()
+	// The route of the repositories in the test resources doesn't include the project, hence the repositories are returned by the order of the projects
+	repositoriesResponses := []string{
+		`{"count": 2, "value": [{"name": "repo-1"}, {"name": "repo-2"}]}`,
+		`{"count": 1, "value": [{"name": "repo-3"}]}`,
+	}
+	var projectsRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/projects"):
+			projectsRequests = append(projectsRequests, r.URL.Query().Get("continuationToken"))
+			if r.URL.Query().Get("continuationToken") == "" {
+				w.Header().Set("X-MS-ContinuationToken", "1")
+				response = `{"count": 1, "value": [{"name": "project-1"}]}`
+			} else {
+				response = `{"count": 1, "value": [{"name": "project-2"}]}`
+			}
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/getRepository"):
+			if assert.NotEmpty(t, repositoriesResponses) {
+				response, repositoriesResponses = repositoriesResponses[0], repositoriesResponses[1:]
+			}
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Build()
+	assert.NoError(t, err)
+
+	reposMap, err := client.ListRepositories(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"project-1": {"repo-1", "repo-2"}, "project-2": {"repo-3"}}, reposMap)
+	assert.Equal(t, []string{"", "1"}, projectsRequests)
+}
+
 func TestAzureRepos_TestListBranches(t *testing.T) {
 	type ListBranchesResponse struct {
 		Value []git.GitBranchStats
@@ -78,6 +365,401 @@ func TestAzureRepos_TestListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestListBranchesWithOptions(t *testing.T) {
+	type ListBranchesResponse struct {
+		Value []git.GitBranchStats
+		Count int
+	}
+	testBranches := []string{"test_branch_3", "test_branch_1", "test_branch_2"}
+	res := ListBranchesResponse{
+		Value: []git.GitBranchStats{{Name: &testBranches[0]}, {Name: &testBranches[1]}, {Name: &testBranches[2]}},
+		Count: 3,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "listBranches", createAzureReposHandler)
+	defer cleanUp()
+	resp, err := client.ListBranchesWithOptions(ctx, "", repo1, ListOptions{Page: 2, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"test_branch_3"}, resp)
+
+	resp, err = client.ListBranchesWithOptions(ctx, "", repo1, ListOptions{Page: 3, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Empty(t, resp)
+}
+
+func TestAzureRepos_TestCreateBranch(t *testing.T) {
+	ctx := context.Background()
+	sourceSha := "86d6919952702f9ab03bc95b45687f145a663de0"
+
+	t.Run("from commit", func(t *testing.T) {
+		response := []byte(`{"value": [{"name": "refs/heads/new-branch", "success": true}], "count": 1}`)
+		client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "refs", createAzureReposHandler)
+		defer cleanUp()
+		assert.NoError(t, client.CreateBranch(ctx, "", repo1, sourceSha, "new-branch"))
+	})
+
+	t.Run("from branch", func(t *testing.T) {
+		// The same response is used for both the source branch and the ref update requests
+		response := []byte(`{"name": "master", "commit": {"commitId": "` + sourceSha + `"}, "value": [{"name": "refs/heads/new-branch", "success": true}], "count": 1}`)
+		client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "ResourceAreas", createAzureReposHandler)
+		defer cleanUp()
+		assert.NoError(t, client.CreateBranch(ctx, "", repo1, "master", "new-branch"))
+	})
+
+	t.Run("rejected update", func(t *testing.T) {
+		response := []byte(`{"value": [{"name": "refs/heads/new-branch", "success": false, "updateStatus": "createBranchPermissionRequired"}], "count": 1}`)
+		client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "refs", createAzureReposHandler)
+		defer cleanUp()
+		err := client.CreateBranch(ctx, "", repo1, sourceSha, "new-branch")
+		assert.EqualError(t, err, "failed to update ref refs/heads/new-branch: createBranchPermissionRequired")
+	})
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	assert.Error(t, badClient.CreateBranch(ctx, "", repo1, "master", "new-branch"))
+}
+
+func TestAzureRepos_TestDeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	// The same response is used for both the branch and the ref update requests
+	response := []byte(`{"name": "branch-1", "commit": {"commitId": "86d6919952702f9ab03bc95b45687f145a663de0"}, "value": [{"name": "refs/heads/branch-1", "success": true}], "count": 1}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "ResourceAreas", createAzureReposHandler)
+	defer cleanUp()
+	assert.NoError(t, client.DeleteBranch(ctx, "", repo1, branch1))
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	assert.Error(t, badClient.DeleteBranch(ctx, "", repo1, branch1))
+}
+
+func TestAzureRepos_TestListTags(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"value": [
+		{"name": "refs/tags/v1.0.0", "objectId": "940bd336248efae0f9ee5bc7b2d5c985887b16ac", "peeledObjectId": "86d6919952702f9ab03bc95b45687f145a663de0"},
+		{"name": "refs/tags/v0.9.0", "objectId": "4aa8367809020c4e97af29e2b57f7528d5d27702"}
+	], "count": 2}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "refs", createAzureReposHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, "", repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{
+		{Name: "v1.0.0", CommitSha: "86d6919952702f9ab03bc95b45687f145a663de0"},
+		{Name: "v0.9.0", CommitSha: "4aa8367809020c4e97af29e2b57f7528d5d27702"},
+	}, tags)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListTags(ctx, "", repo1)
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestCreateTag(t *testing.T) {
+	ctx := context.Background()
+	sourceSha := "86d6919952702f9ab03bc95b45687f145a663de0"
+
+	t.Run("lightweight", func(t *testing.T) {
+		response := []byte(`{"value": [{"name": "refs/tags/v1.0.0", "success": true}], "count": 1}`)
+		client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "refs", createAzureReposHandler)
+		defer cleanUp()
+		assert.NoError(t, client.CreateTag(ctx, "", repo1, "v1.0.0", sourceSha, ""))
+	})
+
+	t.Run("annotated", func(t *testing.T) {
+		response := []byte(`{"name": "v1.0.0", "message": "Release 1.0.0", "objectId": "940bd336248efae0f9ee5bc7b2d5c985887b16ac"}`)
+		client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "annotatedtags", createAzureReposHandler)
+		defer cleanUp()
+		// Annotated tags are created in the scope of the project
+		client.(*AzureReposClient).vcsInfo.Project = "project"
+		assert.NoError(t, client.CreateTag(ctx, "", repo1, "v1.0.0", sourceSha, "Release 1.0.0"))
+	})
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	assert.Error(t, badClient.CreateTag(ctx, "", repo1, "v1.0.0", sourceSha, ""))
+}
+
+func TestAzureRepos_TestGetTagInfo(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "getCommits", createAzureReposHandler)
+	defer cleanUp()
+
+	commit, err := client.GetTagInfo(ctx, "", repo1, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "86d6919952702f9ab03bc95b45687f145a663de0", commit.Hash)
+	assert.Equal(t, "Updated package.json", commit.Message)
+
+	emptyClient, emptyCleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"value": [], "count": 0}`), "getCommits", createAzureReposHandler)
+	defer emptyCleanUp()
+	_, err = emptyClient.GetTagInfo(ctx, "", repo1, "v1.0.0")
+	assert.EqualError(t, err, "couldn't find the commit of tag v1.0.0")
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetTagInfo(ctx, "", repo1, "v1.0.0")
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestReleases(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateRelease(ctx, owner, repo1, "v1.0.0", "Release 1.0.0", "Release notes")
+	assert.EqualError(t, err, "create release is currently not supported for Azure Repos")
+	_, err = client.ListReleases(ctx, owner, repo1)
+	assert.EqualError(t, err, "list releases is currently not supported for Azure Repos")
+	_, err = client.GetLatestRelease(ctx, owner, repo1)
+	assert.EqualError(t, err, "get latest release is currently not supported for Azure Repos")
+	_, err = client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", "app.zip", strings.NewReader("content"))
+	assert.EqualError(t, err, "upload release asset is currently not supported for Azure Repos")
+}
+
+func TestAzureReposClient_RepositoryCollaborators(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListRepositoryCollaborators(ctx, owner, repo1)
+	assert.EqualError(t, err, "list repository collaborators is currently not supported for Azure Repos")
+	_, err = client.GetRepositoryPermission(ctx, owner, repo1, "frogger")
+	assert.EqualError(t, err, "get repository permission is currently not supported for Azure Repos")
+}
+
+func TestAzureReposClient_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createBranchProtectionAzureReposHandler)
+	defer cleanUp()
+	client.(*AzureReposClient).vcsInfo.Project = project
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{RequiredApprovals: 2, RequiredStatusChecks: []string{"frogbot"}, RestrictPushes: true}, protection)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createBranchProtectionAzureReposHandler)
+	defer cleanUp()
+	client.(*AzureReposClient).vcsInfo.Project = project
+
+	err := client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredStatusChecks: []string{"frogbot"}, RestrictPushes: true})
+	assert.NoError(t, err)
+}
+
+func TestAzureReposClient_GetCombinedCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	commitHash := "86d6919952702f9ab03bc95b45687f145a663de0"
+	testCases := []struct {
+		name                string
+		statusesResponse    string
+		evaluationsResponse string
+		expectedStatus      CommitStatus
+	}{
+		{
+			name:                "No statuses or policies",
+			statusesResponse:    `{"value": [], "count": 0}`,
+			evaluationsResponse: `{"value": [], "count": 0}`,
+			expectedStatus:      Pass,
+		},
+		{
+			name:                "Non blocking policy is ignored",
+			statusesResponse:    `{"value": [{"state": "succeeded"}, {"state": "notApplicable"}], "count": 2}`,
+			evaluationsResponse: `{"value": [{"status": "rejected", "configuration": {"isBlocking": false}}], "count": 1}`,
+			expectedStatus:      Pass,
+		},
+		{
+			name:                "Running policy",
+			statusesResponse:    `{"value": [{"state": "succeeded"}], "count": 1}`,
+			evaluationsResponse: `{"value": [{"status": "running", "configuration": {"isBlocking": true}}], "count": 1}`,
+			expectedStatus:      InProgress,
+		},
+		{
+			name:                "Rejected policy",
+			statusesResponse:    `{"value": [{"state": "pending"}], "count": 1}`,
+			evaluationsResponse: `{"value": [{"status": "rejected", "configuration": {"isBlocking": true}}], "count": 1}`,
+			expectedStatus:      Fail,
+		},
+		{
+			name:                "Failed status",
+			statusesResponse:    `{"value": [{"state": "failed"}], "count": 1}`,
+			evaluationsResponse: `{"value": [{"status": "approved", "configuration": {"isBlocking": true}}], "count": 1}`,
+			expectedStatus:      Fail,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createGetCombinedCommitStatusAzureReposHandler(tc.statusesResponse, tc.evaluationsResponse))
+			defer cleanUp()
+			status, err := client.GetCombinedCommitStatus(ctx, owner, repo1, commitHash)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err := badClient.GetCombinedCommitStatus(ctx, owner, repo1, commitHash)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_QueueBuild(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id": 42, "status": "notStarted", "sourceBranch": "refs/heads/branch-1", "sourceVersion": "86d6919952702f9ab03bc95b45687f145a663de0",
+		"queueTime": "2024-05-01T10:00:00Z", "_links": {"web": {"href": "https://dev.azure.com/jfrog/project/_build/results?buildId=42"}}}`)
+	handler := createAzureReposHandler(t, "/_apis/ResourceAreas/builds", response, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var queuedBuild build.Build
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&queuedBuild))
+			if assert.NotNil(t, queuedBuild.Definition) && assert.NotNil(t, queuedBuild.SourceBranch) && assert.NotNil(t, queuedBuild.Parameters) {
+				assert.Equal(t, 7, *queuedBuild.Definition.Id)
+				assert.Equal(t, "refs/heads/branch-1", *queuedBuild.SourceBranch)
+				assert.JSONEq(t, `{"environment": "staging"}`, *queuedBuild.Parameters)
+			}
+		}
+		handler(w, r)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	queuedBuild, err := client.QueueBuild(ctx, owner, repo1, 7, branch1, map[string]string{"environment": "staging"})
+	assert.NoError(t, err)
+	assert.Equal(t, PipelineInfo{
+		ID:        "42",
+		Status:    InProgress,
+		RawStatus: "notStarted",
+		Ref:       branch1,
+		CommitSHA: "86d6919952702f9ab03bc95b45687f145a663de0",
+		WebURL:    "https://dev.azure.com/jfrog/project/_build/results?buildId=42",
+		CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}, queuedBuild)
+
+	badServer := httptest.NewServer(createAzureReposHandler(t, "bad^endpoint", nil, http.StatusNotFound))
+	defer badServer.Close()
+	client, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(badServer.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+	_, err = client.QueueBuild(ctx, owner, repo1, 7, branch1, nil)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_GetBuildStatus(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"value": [{"id": 42, "status": "completed", "result": "partiallySucceeded", "sourceBranch": "refs/heads/branch-1"}], "count": 1}`)
+	server := httptest.NewServer(createAzureReposHandler(t,
+		"/_apis/ResourceAreas/builds?%24top=1&branchName=refs%2Fheads%2Fbranch-1&definitions=7&queryOrder=queueTimeDescending", response, http.StatusOK))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	latestBuild, err := client.GetBuildStatus(ctx, owner, repo1, 7, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "42", Status: Fail, RawStatus: "partiallySucceeded", Ref: branch1}, latestBuild)
+
+	emptyServer := httptest.NewServer(createAzureReposHandler(t, "/_apis/ResourceAreas/builds", []byte(`{"value": [], "count": 0}`), http.StatusOK))
+	defer emptyServer.Close()
+	client, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(emptyServer.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+	_, err = client.GetBuildStatus(ctx, owner, repo1, 7, branch1)
+	assert.EqualError(t, err, "no build of definition 7 was found for branch 'branch-1'")
+
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createAzureReposHandler)
+	defer cleanUp()
+	_, err = client.GetBuildStatus(ctx, owner, repo1, 7, branch1)
+	assert.ErrorContains(t, err, "required parameter 'project' is missing")
+}
+
+func TestAzureReposClient_CreateOrUpdateRepositorySecret(t *testing.T) {
+	ctx := context.Background()
+	existingGroups := `{"count": 0, "value": []}`
+	var requests []string
+	var variableGroupParameters map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case r.RequestURI == "/_apis/ResourceAreas/projects/"+project:
+			response = `{"id": "638e3921-f5e3-46e6-a11f-a139cb9bd511", "name": "jfrog-project"}`
+		case r.Method == http.MethodGet && r.RequestURI == "/_apis/ResourceAreas/variablegroups?groupName="+repo1:
+			response = existingGroups
+		case r.Method == http.MethodPost && r.RequestURI == "/_apis/ResourceAreas/variablegroups",
+			r.Method == http.MethodPut && r.RequestURI == "/_apis/ResourceAreas/variablegroups/5":
+			requests = append(requests, r.Method+" "+r.RequestURI)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&variableGroupParameters))
+			response = `{"id": 5}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	// The variable group of the repository is created if it doesn't exist
+	assert.NoError(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"))
+	assert.Equal(t, []string{"POST /_apis/ResourceAreas/variablegroups"}, requests)
+	assert.Equal(t, repo1, variableGroupParameters["name"])
+	assert.Equal(t, map[string]interface{}{"JF_TOKEN": map[string]interface{}{"isSecret": true, "value": "secret-value"}}, variableGroupParameters["variables"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name":             repo1,
+		"projectReference": map[string]interface{}{"id": "638e3921-f5e3-46e6-a11f-a139cb9bd511", "name": project},
+	}}, variableGroupParameters["variableGroupProjectReferences"])
+
+	// The existing variables are kept when the group is updated
+	existingGroups = `{"count": 2, "value": [{"id": 4, "name": "repo-10"}, {"id": 5, "name": "repo-1", "type": "Vsts",
+		"variables": {"JF_URL": {"value": "https://jfrog.io"}, "JF_TOKEN": {"isSecret": true}}}]}`
+	requests = nil
+	assert.NoError(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "new-value"))
+	assert.Equal(t, []string{"PUT /_apis/ResourceAreas/variablegroups/5"}, requests)
+	assert.Equal(t, map[string]interface{}{
+		"JF_URL":   map[string]interface{}{"value": "https://jfrog.io"},
+		"JF_TOKEN": map[string]interface{}{"isSecret": true, "value": "new-value"},
+	}, variableGroupParameters["variables"])
+
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createAzureReposHandler)
+	defer cleanUp()
+	assert.ErrorContains(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"), "required parameter 'project' is missing")
+}
+
+func TestAzureReposClient_ListRepositoryVariables(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"count": 2, "value": [{"id": 4, "name": "repo-10", "variables": {"OTHER": {"value": "other"}}},
+		{"id": 5, "name": "repo-1", "variables": {"JF_URL": {"value": "https://jfrog.io"}, "JF_TOKEN": {"isSecret": true}}}]}`)
+	server := httptest.NewServer(createAzureReposHandler(t, "/_apis/ResourceAreas/variablegroups?groupName=repo-1", response, http.StatusOK))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	variables, err := client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryVariableInfo{{Name: "JF_TOKEN", IsSecret: true}, {Name: "JF_URL", Value: "https://jfrog.io"}}, variables)
+
+	emptyServer := httptest.NewServer(createAzureReposHandler(t, "/_apis/ResourceAreas/variablegroups", []byte(`{"count": 0, "value": []}`), http.StatusOK))
+	defer emptyServer.Close()
+	client, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(emptyServer.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+	variables, err = client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Empty(t, variables)
+}
+
 func TestAzureRepos_TestDownloadRepository(t *testing.T) {
 	ctx := context.Background()
 	dir, err := os.MkdirTemp("", "")
@@ -105,36 +787,191 @@ func TestAzureRepos_TestDownloadRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestAzureRepos_TestCreatePullRequest(t *testing.T) {
-	type CreatePullRequestResponse struct {
-		Value git.GitPullRequest
-		Count int
+func TestAzureRepos_DownloadRepositoryWithRoundTripper(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, vcsutils.RemoveTempDir(dir)) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "hello_world.zip"))
+	assert.NoError(t, err)
+	downloadURL := fmt.Sprintf("//_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip", repo1, branch1)
+	server := httptest.NewServer(createGetRepositoryAzureReposHandler(t, downloadURL, repoFile, http.StatusOK))
+	defer server.Close()
+
+	roundTripper := &traceRoundTripper{}
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).RoundTripper(roundTripper).Build()
+	assert.NoError(t, err)
+	assert.NoError(t, client.DownloadRepository(ctx, "", repo1, branch1, dir))
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.NotZero(t, roundTripper.requests)
+}
+
+func TestAzureRepos_DownloadRepositoryPaths(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	archives := map[string][]byte{
+		"/src/app":   createZip(t, map[string]string{"app/go.mod": "module frog"}),
+		"/README.md": createZip(t, map[string]string{"README.md": "Hello"}),
+	}
+	var itemPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/items/items") {
+			createGetRepositoryAzureReposHandler(t, "", nil, http.StatusOK)(w, r)
+			return
+		}
+		assert.Equal(t, branch1, r.URL.Query().Get("versionDescriptor[version]"))
+		itemPath := r.URL.Query().Get("path")
+		itemPaths = append(itemPaths, itemPath)
+		_, err := w.Write(archives[itemPath])
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Build()
+	assert.NoError(t, err)
+	err = client.DownloadRepositoryPaths(ctx, "", repo1, branch1, []string{"src/app", "README.md"}, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/src/app", "/README.md"}, itemPaths)
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.FileExists(t, filepath.Join(dir, "src", "app", "go.mod"))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+}
+
+func TestAzureRepos_ArchiveFormat(t *testing.T) {
+	_, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint("https://dev.azure.com/jfrog").Token(token).ArchiveFormat(vcsutils.ZipArchive).Build()
+	assert.NoError(t, err)
+	_, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint("https://dev.azure.com/jfrog").Token(token).ArchiveFormat(vcsutils.TarGzArchive).Build()
+	assert.EqualError(t, err, "unsupported archive format tar.gz, Azure Repos supports only zip archives")
+}
+
+func TestAzureRepos_DownloadRepositoryConcurrently(t *testing.T) {
+	ctx := context.Background()
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "hello_world.zip"))
+	assert.NoError(t, err)
+	downloadURL := fmt.Sprintf("//_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip", repo1, branch1)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, repoFile, downloadURL, createGetRepositoryAzureReposHandler)
+	defer cleanUp()
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	dirs := make([]string, 4)
+	errs := make([]error, len(dirs))
+	var wg sync.WaitGroup
+	for i := range dirs {
+		dirs[i] = t.TempDir()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = client.DownloadRepository(ctx, "", repo1, branch1, dirs[i])
+		}(i)
 	}
+	wg.Wait()
+	for i, dir := range dirs {
+		assert.NoError(t, errs[i])
+		assert.FileExists(t, filepath.Join(dir, "README.md"))
+		assert.DirExists(t, filepath.Join(dir, ".git"))
+	}
+	// The working directory of the process isn't changed by the downloads
+	currentWd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, wd, currentWd)
+}
+
+func TestAzureRepos_DownloadRepositorySizeLimit(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, vcsutils.RemoveTempDir(dir)) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "hello_world.zip"))
+	assert.NoError(t, err)
+	downloadURL := fmt.Sprintf("//_apis/git/repositories/%s/items/items?path=/&versionDescriptor[version]=%s&$format=zip", repo1, branch1)
+	server := httptest.NewServer(createGetRepositoryAzureReposHandler(t, downloadURL, repoFile, http.StatusOK))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).MaxDownloadSize(int64(len(repoFile) - 1)).Build()
+	assert.NoError(t, err)
+	err = client.DownloadRepository(ctx, "", repo1, branch1, dir)
+	assert.ErrorIs(t, err, vcsutils.ErrDownloadSizeLimitExceeded)
+	assert.NoFileExists(t, filepath.Join(dir, "README.md"))
+
+	client, err = NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).MaxDownloadSize(int64(len(repoFile))).Build()
+	assert.NoError(t, err)
+	assert.NoError(t, client.DownloadRepository(ctx, "", repo1, branch1, dir))
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+}
+
+func TestAzureRepos_TestCreatePullRequest(t *testing.T) {
 	helloWorld := "hello world"
-	res := CreatePullRequestResponse{
-		Value: git.GitPullRequest{
-			Repository:    &git.GitRepository{Name: &repo1},
-			SourceRefName: &branch1,
-			TargetRefName: &branch2,
-			Title:         &helloWorld,
-			Description:   &helloWorld,
-		},
-		Count: 1,
+	pullRequestId := 47
+	res := git.GitPullRequest{
+		PullRequestId: &pullRequestId,
+		Repository:    &git.GitRepository{Name: &repo1},
+		SourceRefName: &branch1,
+		TargetRefName: &branch2,
+		Title:         &helloWorld,
+		Description:   &helloWorld,
 	}
 	jsonRes, err := json.Marshal(res)
 	assert.NoError(t, err)
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	client, serverUrl, cleanUp := createServerWithUrlAndClientReturningStatus(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", http.StatusOK, createAzureReposHandler)
 	defer cleanUp()
-	err = client.CreatePullRequest(ctx, "", repo1, branch1, branch2, "Hello World", "Hello World")
+	pullRequestInfo, err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "Hello World", "Hello World")
 	assert.NoError(t, err)
+	assert.Equal(t, int64(pullRequestId), pullRequestInfo.ID)
+	assert.Equal(t, fmt.Sprintf("%s//_git/%s/pullrequest/%d", serverUrl, repo1, pullRequestId), pullRequestInfo.URL)
+	assert.Equal(t, BranchInfo{Name: branch1, Repository: repo1, Owner: owner}, pullRequestInfo.Source)
 
 	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
 	defer cleanUp()
-	err = badClient.CreatePullRequest(ctx, "", repo1, branch1, branch2, "Hello World", "Hello World")
+	_, err = badClient.CreatePullRequest(ctx, "", repo1, branch1, branch2, "Hello World", "Hello World")
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_CreatePullRequestWithOptions(t *testing.T) {
+	pullRequestId := 47
+	jsonRes, err := json.Marshal(git.GitPullRequest{PullRequestId: &pullRequestId, Repository: &git.GitRepository{Name: &repo1}, SourceRefName: &branch1, TargetRefName: &branch2})
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests",
+		func(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
+			handler := createAzureReposHandler(t, expectedURI, response, expectedStatusCode)
+			return func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					var pullRequest git.GitPullRequest
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&pullRequest))
+					if assert.NotNil(t, pullRequest.IsDraft) {
+						assert.True(t, *pullRequest.IsDraft)
+					}
+					if assert.NotNil(t, pullRequest.Reviewers) && assert.Len(t, *pullRequest.Reviewers, 1) {
+						assert.Equal(t, "b2d6d7a8-cb4c-4d1f-9a6e-0c1d5a0e3f6b", *(*pullRequest.Reviewers)[0].Id)
+					}
+					if assert.NotNil(t, pullRequest.Labels) && assert.Len(t, *pullRequest.Labels, 1) {
+						assert.Equal(t, "dependencies", *(*pullRequest.Labels)[0].Name)
+					}
+					if assert.NotNil(t, pullRequest.WorkItemRefs) && assert.Len(t, *pullRequest.WorkItemRefs, 1) {
+						assert.Equal(t, "42", *(*pullRequest.WorkItemRefs)[0].Id)
+					}
+					if assert.NotNil(t, pullRequest.CompletionOptions) {
+						assert.True(t, *pullRequest.CompletionOptions.DeleteSourceBranch)
+					}
+				}
+				handler(w, r)
+			}
+		})
+	defer cleanUp()
+
+	options := CreatePullRequestOptions{Draft: true, Reviewers: []string{"b2d6d7a8-cb4c-4d1f-9a6e-0c1d5a0e3f6b"}, Labels: []string{"dependencies"},
+		WorkItems: []int{42}, DeleteSourceBranch: true}
+	pullRequestInfo, err := client.CreatePullRequestWithOptions(context.Background(), owner, repo1, branch1, branch2, "Hello World", "Hello World", options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(pullRequestId), pullRequestInfo.ID)
+
+	_, err = client.CreatePullRequestWithOptions(context.Background(), owner, repo1, branch1, branch2, "Hello World", "Hello World", CreatePullRequestOptions{Assignees: []string{username}})
+	assert.EqualError(t, err, "assigning pull requests is not supported on Azure Repos")
+}
+
 func TestAzureReposClient_TestUpdatePullRequest(t *testing.T) {
 	ctx := context.Background()
 	pullRequestId := 1
@@ -155,6 +992,53 @@ func TestAzureReposClient_TestUpdatePullRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullRequestId := 1
+	response := []byte(`{"pullRequestId":1,"lastMergeSourceCommit":{"commitId":"86d6919952702f9ab03bc95b45687f145a663de0"}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+	err := client.MergePullRequest(ctx, owner, repo1, pullRequestId, vcsutils.MergeMethodSquash)
+	assert.NoError(t, err)
+
+	err = client.MergePullRequest(ctx, owner, repo1, pullRequestId, "fast-forward")
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.MergePullRequest(ctx, owner, repo1, pullRequestId, vcsutils.MergeMethodMerge)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_SetPullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	userID := "b2d6d7a8-cb4c-4d1f-9a6e-0c1d5a0e3f6b"
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"pullRequestId":1}`), "getPullRequests",
+		func(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
+			handler := createAzureReposHandler(t, expectedURI, response, expectedStatusCode)
+			return func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/connectionData") {
+					_, err := w.Write([]byte(`{"authenticatedUser": {"id": "` + userID + `"}}`))
+					assert.NoError(t, err)
+					return
+				}
+				if r.Method == http.MethodPatch {
+					var pullRequest git.GitPullRequest
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&pullRequest))
+					if assert.NotNil(t, pullRequest.AutoCompleteSetBy) && assert.NotNil(t, pullRequest.CompletionOptions) {
+						assert.Equal(t, userID, *pullRequest.AutoCompleteSetBy.Id)
+						assert.Equal(t, git.GitPullRequestMergeStrategyValues.Squash, *pullRequest.CompletionOptions.MergeStrategy)
+					}
+				}
+				handler(w, r)
+			}
+		})
+	defer cleanUp()
+
+	assert.NoError(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 1, vcsutils.MergeMethodSquash))
+	assert.Error(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 1, "fast-forward"))
+}
+
 func TestAzureRepos_TestAddPullRequestComment(t *testing.T) {
 	type AddPullRequestCommentResponse struct {
 		Value git.GitPullRequestCommentThread
@@ -262,7 +1146,7 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 	jsonRes, err := json.Marshal(res)
 	assert.NoError(t, err)
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	client, serverUrl, cleanUp := createServerWithUrlAndClientReturningStatus(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", http.StatusOK, createAzureReposHandler)
 	defer cleanUp()
 	pullRequestsInfo, err := client.ListOpenPullRequests(ctx, "", repo1)
 	assert.NoError(t, err)
@@ -271,7 +1155,7 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 			ID:     1,
 			Source: BranchInfo{Name: branch1, Repository: repo1},
 			Target: BranchInfo{Name: branch2, Repository: repo1},
-			URL:    url,
+			URL:    fmt.Sprintf("%s//_git/%s/pullrequest/%d", serverUrl, repo1, pullRequestId),
 		},
 	})
 
@@ -300,7 +1184,7 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 	jsonRes, err = json.Marshal(res)
 	assert.NoError(t, err)
 	ctx = context.Background()
-	client, cleanUp = createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	client, serverUrl, cleanUp = createServerWithUrlAndClientReturningStatus(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", http.StatusOK, createAzureReposHandler)
 	defer cleanUp()
 	pullRequestsInfo, err = client.ListOpenPullRequestsWithBody(ctx, "", repo1)
 	assert.NoError(t, err)
@@ -310,7 +1194,7 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 			Body:   prBody,
 			Source: BranchInfo{Name: branch1, Repository: repo1},
 			Target: BranchInfo{Name: branch2, Repository: repo1},
-			URL:    url,
+			URL:    fmt.Sprintf("%s//_git/%s/pullrequest/%d", serverUrl, repo1, pullRequestId),
 		},
 	})
 
@@ -320,6 +1204,90 @@ func TestAzureRepos_TestListOpenPullRequests(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestListOpenPullRequestsWithOptions(t *testing.T) {
+	pullRequestId := 1
+	author := "frogger@jfrog.com"
+	branch1WithPrefix := "refs/heads/" + branch1
+	branch2WithPrefix := "refs/heads/" + branch2
+	res := struct {
+		Value []git.GitPullRequest
+		Count int
+	}{
+		Value: []git.GitPullRequest{{
+			PullRequestId: &pullRequestId,
+			SourceRefName: &branch1WithPrefix,
+			TargetRefName: &branch2WithPrefix,
+			CreatedBy:     &webapi.IdentityRef{UniqueName: &author},
+		}},
+		Count: 1,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+
+	options := ListPullRequestsOptions{SourceBranch: branch1, TargetBranch: branch2, Author: author}
+	result, err := client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(pullRequestId), result[0].ID)
+
+	// Filtered by the author
+	options.Author = "someone@jfrog.com"
+	result, err = client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, ListPullRequestsOptions{UpdatedSince: time.Now()})
+	assert.Error(t, err)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	_, err = badClient.ListOpenPullRequestsWithOptions(ctx, owner, repo1, ListPullRequestsOptions{})
+	assert.Error(t, err)
+}
+
+func TestAzureRepos_TestListPullRequestsWithState(t *testing.T) {
+	pullRequestId := 1
+	prBody := "hello world"
+	branch1WithPrefix := "refs/heads/" + branch1
+	branch2WithPrefix := "refs/heads/" + branch2
+	res := struct {
+		Value []git.GitPullRequest
+		Count int
+	}{
+		Value: []git.GitPullRequest{{
+			PullRequestId: &pullRequestId,
+			Description:   &prBody,
+			SourceRefName: &branch1WithPrefix,
+			TargetRefName: &branch2WithPrefix,
+			Status:        &git.PullRequestStatusValues.Completed,
+		}},
+		Count: 1,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "getPullRequests", createAzureReposHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Merged)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(pullRequestId), result[0].ID)
+	assert.Equal(t, prBody, result[0].Body)
+	assert.Equal(t, vcsutils.Merged, result[0].State)
+
+	_, err = client.ListPullRequestsWithState(ctx, owner, repo1, "draft")
+	assert.EqualError(t, err, "unsupported pull request state: 'draft'")
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	_, err = badClient.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Merged)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_GetPullRequest(t *testing.T) {
 	pullRequestId := 1
 	repoName := "repoName"
@@ -327,7 +1295,10 @@ func TestAzureReposClient_GetPullRequest(t *testing.T) {
 	targetName := "master"
 	forkedOwner := "jfrogForked"
 	forkedSourceUrl := fmt.Sprintf("https://dev.azure.com/%s/201f2c7f-305a-446c-a1d6-a04ec811093b/_apis/git/repositories/82d33a66-8971-4279-9687-19c69e66e114", forkedOwner)
-	url := "https://dev.azure.com/owner/project/_git/repo/pullrequest/47"
+	url := "https://dev.azure.com/owner/project/_apis/git/repositories/repo/pullRequests/1"
+	title := "Pull request title"
+	author := "frogger@jfrog.com"
+	creationDate := time.Date(2023, 6, 20, 9, 0, 47, 0, time.UTC)
 	res := git.GitPullRequest{
 		SourceRefName: &sourceName,
 		TargetRefName: &targetName,
@@ -335,20 +1306,30 @@ func TestAzureReposClient_GetPullRequest(t *testing.T) {
 		ForkSource: &git.GitForkRef{
 			Repository: &git.GitRepository{Url: &forkedSourceUrl},
 		},
-		Url: &url,
+		Url:          &url,
+		Title:        &title,
+		CreatedBy:    &webapi.IdentityRef{UniqueName: &author},
+		Status:       &git.PullRequestStatusValues.Completed,
+		MergeStatus:  &git.PullRequestAsyncStatusValues.Succeeded,
+		CreationDate: &azuredevops.Time{Time: creationDate},
 	}
 	jsonRes, err := json.Marshal(res)
 	assert.NoError(t, err)
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, fmt.Sprintf("getPullRequests/%d", pullRequestId), createAzureReposHandler)
+	client, serverUrl, cleanUp := createServerWithUrlAndClientReturningStatus(t, vcsutils.AzureRepos, true, jsonRes, fmt.Sprintf("getPullRequests/%d", pullRequestId), http.StatusOK, createAzureReposHandler)
 	defer cleanUp()
 	pullRequestsInfo, err := client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, pullRequestsInfo, PullRequestInfo{
-		ID:     1,
-		Source: BranchInfo{Name: sourceName, Repository: repoName, Owner: forkedOwner},
-		Target: BranchInfo{Name: targetName, Repository: repoName, Owner: owner},
-		URL:    url,
+		ID:        1,
+		Title:     title,
+		Author:    author,
+		State:     vcsutils.Merged,
+		Mergeable: true,
+		Source:    BranchInfo{Name: sourceName, Repository: repoName, Owner: forkedOwner},
+		Target:    BranchInfo{Name: targetName, Repository: repoName, Owner: owner},
+		URL:       fmt.Sprintf("%s//_git/%s/pullrequest/%d", serverUrl, repoName, pullRequestId),
+		CreatedAt: creationDate,
 	})
 
 	// Fail source repository owner extraction, should be empty string and not fail the process.
@@ -363,14 +1344,14 @@ func TestAzureReposClient_GetPullRequest(t *testing.T) {
 	}
 	jsonRes, err = json.Marshal(res)
 	assert.NoError(t, err)
-	client, _ = createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, fmt.Sprintf("getPullRequests/%d", pullRequestId), createAzureReposHandler)
+	client, serverUrl, _ = createServerWithUrlAndClientReturningStatus(t, vcsutils.AzureRepos, true, jsonRes, fmt.Sprintf("getPullRequests/%d", pullRequestId), http.StatusOK, createAzureReposHandler)
 	pullRequestsInfo, err = client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, pullRequestsInfo, PullRequestInfo{
 		ID:     1,
 		Source: BranchInfo{Name: sourceName, Repository: repoName, Owner: ""},
 		Target: BranchInfo{Name: targetName, Repository: repoName, Owner: owner},
-		URL:    url,
+		URL:    fmt.Sprintf("%s//_git/%s/pullrequest/%d", serverUrl, repoName, pullRequestId),
 	},
 	)
 
@@ -382,7 +1363,40 @@ func TestAzureReposClient_GetPullRequest(t *testing.T) {
 }
 
 func TestListPullRequestReviewComments(t *testing.T) {
-	TestListPullRequestComments(t)
+	type ListPullRequestCommentsResponse struct {
+		Value []git.GitPullRequestCommentThread
+		Count int
+	}
+	generalThreadID, reviewThreadID, commentID := 1, 2, 1
+	generalContent, reviewContent := "general comment", "review comment"
+	filePath, line := "/path/to/file.go", 12
+	res := ListPullRequestCommentsResponse{
+		Value: []git.GitPullRequestCommentThread{
+			{
+				Id:       &generalThreadID,
+				Comments: &[]git.Comment{{Id: &commentID, Content: &generalContent}},
+			},
+			{
+				Id:            &reviewThreadID,
+				ThreadContext: &git.CommentThreadContext{FilePath: &filePath, RightFileStart: &git.CommentPosition{Line: &line}},
+				Comments:      &[]git.Comment{{Id: &commentID, Content: &reviewContent}},
+			},
+		},
+		Count: 2,
+	}
+	jsonRes, err := json.Marshal(res)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "pullRequestComments", createAzureReposHandler)
+	defer cleanUp()
+	commentInfo, err := client.ListPullRequestReviewComments(ctx, "", repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{{ID: 2, NativeID: "1", ThreadID: "2", Content: reviewContent, FilePath: "path/to/file.go", Line: line}}, commentInfo)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	_, err = badClient.ListPullRequestReviewComments(ctx, "", repo1, 1)
+	assert.Error(t, err)
 }
 
 func TestListPullRequestComments(t *testing.T) {
@@ -394,21 +1408,34 @@ func TestListPullRequestComments(t *testing.T) {
 	id2 := 2
 	firstCommentContent := "first comment"
 	secondCommentContent := "second comment"
-	author := "test author"
+	author, authorUsername := "test author", "frogger@jfrog.com"
+	threadID := 7
+	deleted := true
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := created.Add(time.Hour)
 	res := ListPullRequestCommentsResponse{
 		Value: []git.GitPullRequestCommentThread{{
-			Id:            &id1,
-			PublishedDate: &azuredevops.Time{Time: time.Now()},
+			Id:            &threadID,
+			PublishedDate: &azuredevops.Time{Time: created},
 			Comments: &[]git.Comment{
 				{
-					Id:      &id1,
-					Content: &firstCommentContent,
-					Author:  &webapi.IdentityRef{DisplayName: &author},
+					Id:              &id1,
+					Content:         &firstCommentContent,
+					Author:          &webapi.IdentityRef{DisplayName: &author, UniqueName: &authorUsername},
+					PublishedDate:   &azuredevops.Time{Time: created},
+					LastUpdatedDate: &azuredevops.Time{Time: updated},
 				},
 				{
-					Id:      &id2,
-					Content: &secondCommentContent,
-					Author:  &webapi.IdentityRef{DisplayName: &author},
+					Id:              &id2,
+					ParentCommentId: &id1,
+					Content:         &secondCommentContent,
+					Author:          &webapi.IdentityRef{DisplayName: &author, UniqueName: &authorUsername},
+					PublishedDate:   &azuredevops.Time{Time: created},
+				},
+				{
+					Id:        &id2,
+					Content:   &secondCommentContent,
+					IsDeleted: &deleted,
 				},
 			},
 		}},
@@ -420,9 +1447,23 @@ func TestListPullRequestComments(t *testing.T) {
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, jsonRes, "pullRequestComments", createAzureReposHandler)
 	defer cleanUp()
 	commentInfo, err := client.ListPullRequestComments(ctx, "", repo1, id1)
-	expected := "Author: test author, Id: 1, Content:first comment\nAuthor: test author, Id: 2, Content:second comment\n"
-	assert.Equal(t, expected, commentInfo[0].Content)
 	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{
+		{ID: 7, NativeID: "1", ThreadID: "7", Content: firstCommentContent, Author: author, AuthorUsername: authorUsername, Created: created, Updated: updated},
+		{ID: 7, NativeID: "2", ThreadID: "7", ParentID: "1", Content: secondCommentContent, Author: author, AuthorUsername: authorUsername, Created: created},
+	}, commentInfo)
+
+	// The first comment was updated after the second comment was created
+	commentInfo, err = client.ListPullRequestCommentsWithOptions(ctx, "", repo1, id1, ListPullRequestCommentsOptions{UpdatedAfter: created})
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{
+		{ID: 7, NativeID: "1", ThreadID: "7", Content: firstCommentContent, Author: author, AuthorUsername: authorUsername, Created: created, Updated: updated},
+	}, commentInfo)
+	commentInfo, err = client.ListPullRequestCommentsWithOptions(ctx, "", repo1, id1, ListPullRequestCommentsOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{
+		{ID: 7, NativeID: "2", ThreadID: "7", ParentID: "1", Content: secondCommentContent, Author: author, AuthorUsername: authorUsername, Created: created},
+	}, commentInfo)
 
 	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
 	defer cleanUp()
@@ -435,7 +1476,8 @@ func TestAzureRepos_TestGetLatestCommit(t *testing.T) {
 	response, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
 	assert.NoError(t, err)
 
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "getCommits", createAzureReposHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response,
+		"getCommits?searchCriteria.%24skip=0&searchCriteria.%24top=1&searchCriteria.itemVersion.version=branch-1&searchCriteria.itemVersion.versionType=branch", createAzureReposHandler)
 	defer cleanUp()
 
 	commit, err := client.GetLatestCommit(ctx, "", repo1, branch1)
@@ -500,6 +1542,34 @@ func TestAzureRepos_TestGetCommits(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureRepos_TestListCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response,
+		"getCommits?searchCriteria.%24skip=10&searchCriteria.%24top=10&searchCriteria.author=Test+User&searchCriteria.fromDate=2022-11-01T00%3A00%3A00Z&searchCriteria.itemPath=README.md&searchCriteria.itemVersion.version=branch-1&searchCriteria.itemVersion.versionType=branch",
+		createAzureReposHandler)
+	defer cleanUp()
+
+	commits, err := client.ListCommits(ctx, "", repo1, ListCommitsOptions{
+		Branch:  branch1,
+		Path:    "README.md",
+		Author:  "Test User",
+		Since:   time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		PerPage: 10,
+		Page:    2,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, commits, 3)
+	assert.Equal(t, "86d6919952702f9ab03bc95b45687f145a663de0", commits[0].Hash)
+
+	badClient, cleanUp := createBadAzureReposClient(t, []byte{})
+	defer cleanUp()
+	_, err = badClient.ListCommits(ctx, "", repo1, ListCommitsOptions{Branch: branch1})
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_AddSshKeyToRepository(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "getLatestCommit", createAzureReposHandler)
@@ -511,6 +1581,7 @@ func TestAzureReposClient_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
 	defer cleanUp()
+	assert.NoError(t, client.CreateLabel(ctx, owner, repo1, LabelInfo{Name: "label", Color: "4AB548"}))
 	assert.Error(t, client.CreateLabel(ctx, owner, repo1, LabelInfo{}))
 }
 
@@ -520,115 +1591,587 @@ func TestAzureReposClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	defer cleanUp()
 	_, err := client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, "")
 	assert.Error(t, err)
+	_, err = client.ListEnvironments(ctx, owner, repo1)
+	assert.Error(t, err)
+	assert.Error(t, client.SetDeploymentStatus(ctx, owner, repo1, "42", DeploymentSuccess, "", ""))
+	_, err = client.ListOrganizationSecrets(ctx, owner)
+	assert.Error(t, err)
+	assert.Error(t, client.DeleteEnvironmentSecret(ctx, owner, repo1, envName, "JF_TOKEN"))
+}
+
+func TestAzureReposClient_GetCommitBySha(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.GetCommitBySha(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ListPullRequestLabels(t *testing.T) {
+	ctx := context.Background()
+	response, err := json.Marshal(map[string]interface{}{
+		"value": []core.WebApiTagDefinition{{Name: vcsutils.PointerOf("label1")}, {Name: vcsutils.PointerOf("label2")}},
+		"count": 2,
+	})
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "pullRequestLabels", createAzureReposHandler)
+	defer cleanUp()
+	labels, err := client.ListPullRequestLabels(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"label1", "label2"}, labels)
+
+	badClient, badCleanUp := createBadAzureReposClient(t, []byte{})
+	defer badCleanUp()
+	_, err = badClient.ListPullRequestLabels(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	response, err := json.Marshal(core.WebApiTagDefinition{Name: vcsutils.PointerOf("label1")})
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "pullRequestLabels", createAzureReposHandler)
+	defer cleanUp()
+	assert.NoError(t, client.LabelPullRequest(ctx, owner, repo1, 1, []string{"label1", "label2"}))
+	assert.Error(t, client.LabelPullRequest(ctx, owner, "", 1, []string{"label1"}))
+
+	badClient, badCleanUp := createBadAzureReposClient(t, []byte{})
+	defer badCleanUp()
+	assert.Error(t, badClient.LabelPullRequest(ctx, owner, repo1, 1, []string{"label1"}))
+}
+
+func TestAzureReposClient_UnlabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "pullRequestLabels/label1", createAzureReposHandler)
+	defer cleanUp()
+	assert.NoError(t, client.UnlabelPullRequest(ctx, owner, repo1, "label1", 1))
+	assert.Error(t, client.UnlabelPullRequest(ctx, owner, repo1, "", 1))
+
+	badClient, badCleanUp := createBadAzureReposClient(t, []byte{})
+	defer badCleanUp()
+	assert.Error(t, badClient.UnlabelPullRequest(ctx, owner, repo1, "label1", 1))
+}
+
+func TestAzureReposClient_Issues(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "")
+	assert.Error(t, err)
+	_, err = client.ListIssues(ctx, owner, repo1, ListIssuesOptions{})
+	assert.Error(t, err)
+	assert.Error(t, client.AddIssueComment(ctx, owner, repo1, "Fixed", 1))
+	assert.Error(t, client.CloseIssue(ctx, owner, repo1, 1))
+}
+
+func TestAzureReposClient_UploadCodeScanning(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.UploadCodeScanning(ctx, owner, repo1, "", "1")
+	assert.Error(t, err)
+
+	var threads []string
+	client, cleanUp = createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createUploadCodeScanningAzureReposHandler(&threads))
+	defer cleanUp()
+	commitHash, err := client.UploadCodeScanning(ctx, owner, repo1, "master", codeScanningTestSarif)
+	assert.NoError(t, err)
+	assert.Equal(t, "86d6919952702f9ab03bc95b45687f145a663de0", commitHash)
+	if assert.Len(t, threads, 2) {
+		assert.Contains(t, threads[0], `"content":"**CRITICAL** XRAY-174176: json 9.0.6. Fixed in Versions: [11.0.0]"`)
+		assert.Contains(t, threads[0], `"filePath":"/package.json"`)
+		assert.Contains(t, threads[1], `"content":"**MEDIUM** XRAY-174177: lodash 4.17.0. Fixed in Versions: [4.17.21]"`)
+		assert.Contains(t, threads[1], `"filePath":""`)
+	}
+}
+
+func TestAzureReposClient_CodeInsights(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	assert.Error(t, client.CreateCodeInsightsReport(ctx, owner, repo1, "abcdef", CodeInsightsReport{Key: "xray-scan", Title: "Xray"}))
+	assert.Error(t, client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", nil))
+}
+
+func TestAzureReposClient_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "Xray", HeadSHA: "abcdef"})
+	assert.Error(t, err)
+	assert.Error(t, client.UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{Name: "Xray"}))
+}
+
+func TestAzureReposClient_AppInstallations(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	defer cleanUp()
+	_, err := client.ListAppInstallations(ctx)
+	assert.Error(t, err)
+	_, err = client.ListInstallationRepositories(ctx, 5)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_DownloadFileFromRepo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "badTest", createAzureReposHandler)
+	defer cleanUp()
+	_, _, err := client.DownloadFileFromRepo(ctx, owner, repo1, "", "")
+	assert.Error(t, err)
+	client, cleanUp = createServerAndClient(t, vcsutils.AzureRepos, true, []byte("good"), "/_apis/ResourceAreas/DownloadFileFromRepo?includeContent=true&path=file.txt&versionDescriptor.version=&versionDescriptor.versionType=branch", createAzureReposHandler)
+	defer cleanUp()
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, "", "file.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "good", string(content))
+	client, cleanUp = createServerAndClient(t, vcsutils.AzureRepos, true, "", "bad^endpoint", createAzureReposHandler)
+	defer cleanUp()
+	_, statusCode, err = client.DownloadFileFromRepo(ctx, owner, repo1, "", "file.txt")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+func TestAzureReposClient_ListDirectoryContents(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"count": 3, "value": [
+		{"path": "/src", "isFolder": true},
+		{"path": "/src/go.mod"},
+		{"path": "/src/pkg", "isFolder": true}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "DownloadFileFromRepo", createAzureReposHandler)
+	defer cleanUp()
+
+	entries, err := client.ListDirectoryContents(ctx, "", repo1, "master", "src")
+	assert.NoError(t, err)
+	assert.Equal(t, []DirectoryEntry{
+		{Name: "go.mod", Path: "src/go.mod", IsDir: false},
+		{Name: "pkg", Path: "src/pkg", IsDir: true},
+	}, entries)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListDirectoryContents(ctx, "", repo1, "master", "src")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	files := []FileToCommit{
+		{Path: "go.mod", Content: []byte("module example"), ChangeType: FileModified},
+		{Path: "new.go", Content: []byte("package main"), ChangeType: FileAdded},
+		{Path: "go.sum", ChangeType: FileDeleted},
+	}
+	expectedPush := `{"commits": [{"comment": "Update dependencies", "changes": [
+		{"changeType": "edit", "item": {"path": "/go.mod"}, "newContent": {"content": "bW9kdWxlIGV4YW1wbGU=", "contentType": "base64Encoded"}},
+		{"changeType": "add", "item": {"path": "/new.go"}, "newContent": {"content": "cGFja2FnZSBtYWlu", "contentType": "base64Encoded"}},
+		{"changeType": "delete", "item": {"path": "/go.sum"}}
+	]}], "refUpdates": [{"name": "refs/heads/branch-1", "oldObjectId": "86d6919952702f9ab03bc95b45687f145a663de0"}]}`
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(expectedPush), "", createCommitFilesAzureReposHandler)
+	defer cleanUp()
+
+	err := client.CommitFiles(ctx, "", repo1, branch1, "Update dependencies", files)
+	assert.NoError(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.CommitFiles(ctx, "", repo1, branch1, "Update dependencies", files)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	subscriptionID := "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10"
+	response := []byte(`{"id":"` + subscriptionID + `","eventType":"git.pullrequest.created"}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "/_apis/hooks/subscriptions", createGetRepositoryAzureReposHandler)
+	defer cleanUp()
+	webhookID, webhookToken, err := client.CreateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrRejected)
+	assert.NoError(t, err)
+	assert.Equal(t, subscriptionID+","+subscriptionID, webhookID)
+	assert.NotEmpty(t, webhookToken)
+
+	_, _, err = client.CreateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything")
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, _, err = badClient.CreateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", vcsutils.PrOpened)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CreateWebhookRollback(t *testing.T) {
+	ctx := context.Background()
+	subscriptionID := "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10"
+	var requests []string
+	getRepositoryHandler := createGetRepositoryAzureReposHandler(t, "", nil, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.RequestURI, "/_apis/hooks/subscriptions") {
+			getRepositoryHandler(w, r)
+			return
+		}
+		requests = append(requests, r.Method+" "+strings.Split(r.RequestURI, "?")[0])
+		switch r.Method {
+		case http.MethodPost:
+			// The first subscription is created, and the second one fails
+			if len(requests) > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, err := w.Write([]byte(`{"id":"` + subscriptionID + `"}`))
+			assert.NoError(t, err)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	_, _, err := client.CreateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", vcsutils.PrOpened, vcsutils.Push)
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"POST /_apis/hooks/subscriptions",
+		"POST /_apis/hooks/subscriptions",
+		"DELETE /_apis/hooks/subscriptions/" + subscriptionID,
+	}, requests)
+}
+
+func TestAzureReposClient_CreateOrUpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	webhookID := "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10,1f7a0c2e-54a4-4a8f-a3e6-0c2b1f6d9e21"
+	createdID := "5c2b7a1d-3e9f-4b8a-9d6c-2a1e0f7b8c93"
+	subscriptions := `{"count":2,"value":[
+{"id":"9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10","eventType":"git.push","status":"enabled","publisherInputs":{"repository":"23d122fb-c6c1-4f03-8117-a10a08f8b0d6"},"consumerInputs":{"url":"https://httpbin.org/anything"}},
+{"id":"1f7a0c2e-54a4-4a8f-a3e6-0c2b1f6d9e21","eventType":"git.pullrequest.created","status":"enabled","publisherInputs":{"repository":"23d122fb-c6c1-4f03-8117-a10a08f8b0d6"},"consumerInputs":{"url":"https://httpbin.org/anything"}}]}`
+	var methods []string
+	getRepositoryHandler := createGetRepositoryAzureReposHandler(t, "", nil, http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.RequestURI, "/_apis/hooks/subscriptions") {
+			getRepositoryHandler(w, r)
+			return
+		}
+		methods = append(methods, r.Method)
+		var response string
+		switch r.Method {
+		case http.MethodGet:
+			response = subscriptions
+		case http.MethodPut:
+			response = `{"id":"9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10"}`
+		case http.MethodPost:
+			response = `{"id":"` + createdID + `"}`
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.AzureRepos, true, server)
+
+	// The subscriptions are replaced if the number of the Azure event types is unchanged
+	actualID, token, err := client.CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", vcsutils.Push, vcsutils.PrOpened)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, webhookID, actualID)
+	assert.Equal(t, []string{http.MethodGet, http.MethodPut, http.MethodPut}, methods)
+
+	// Otherwise, the webhook is recreated
+	methods = nil
+	actualID, token, err = client.CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", vcsutils.Push)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, createdID, actualID)
+	assert.Equal(t, []string{http.MethodGet, http.MethodDelete, http.MethodDelete, http.MethodPost}, methods)
+
+	// A new webhook is created if no webhook with the payload URL exists
+	methods = nil
+	actualID, _, err = client.CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/new", vcsutils.Push)
+	assert.NoError(t, err)
+	assert.Equal(t, createdID, actualID)
+	assert.Equal(t, []string{http.MethodGet, http.MethodPost}, methods)
+}
+
+func TestAzureReposClient_UpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	webhookID := "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10,1f7a0c2e-54a4-4a8f-a3e6-0c2b1f6d9e21"
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{}`), "/_apis/hooks/subscriptions/", createGetRepositoryAzureReposHandler)
+	defer cleanUp()
+	err := client.UpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", token, webhookID, vcsutils.PrOpened, vcsutils.Push, vcsutils.TagPushed)
+	assert.NoError(t, err)
+
+	err = client.UpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", token, webhookID, vcsutils.PrOpened)
+	assert.Error(t, err)
+
+	err = client.UpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", token, "1", vcsutils.PrOpened)
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.UpdateWebhook(ctx, owner, repo1, branch1, "https://httpbin.org/anything", token, webhookID, vcsutils.PrOpened, vcsutils.Push)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_RotateWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	webhookID := "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10,1f7a0c2e-54a4-4a8f-a3e6-0c2b1f6d9e21"
+	response := []byte(`{"consumerInputs":{"url":"https://httpbin.org/anything","basicAuthUsername":"froggit-go","basicAuthPassword":"old-token"}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "/_apis/hooks/subscriptions/", createAzureReposHandler)
+	defer cleanUp()
+	newToken, err := client.RotateWebhookSecret(ctx, owner, repo1, webhookID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, newToken)
+
+	_, err = client.RotateWebhookSecret(ctx, owner, repo1, "")
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.RotateWebhookSecret(ctx, owner, repo1, webhookID)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"count":3,"value":[
+{"id":"9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10","eventType":"git.push","status":"enabled","publisherInputs":{"repository":"23d122fb-c6c1-4f03-8117-a10a08f8b0d6"},"consumerInputs":{"url":"https://httpbin.org/anything"}},
+{"id":"1f7a0c2e-54a4-4a8f-a3e6-0c2b1f6d9e21","eventType":"git.pullrequest.created","status":"enabled","publisherInputs":{"repository":"23d122fb-c6c1-4f03-8117-a10a08f8b0d6"},"consumerInputs":{"url":"https://httpbin.org/anything"}},
+{"id":"5c2b7a1d-3e9f-4b8a-9d6c-2a1e0f7b8c93","eventType":"git.push","status":"enabled","publisherInputs":{"repository":"another-repository"},"consumerInputs":{"url":"https://httpbin.org/anything"}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "/_apis/hooks/subscriptions", createGetRepositoryAzureReposHandler)
+	defer cleanUp()
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{
+		ID:         "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10,1f7a0c2e-54a4-4a8f-a3e6-0c2b1f6d9e21",
+		PayloadURL: "https://httpbin.org/anything",
+		Events:     []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved},
+		Active:     true,
+	}}, webhooks)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListWebhooks(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_GetWebhook(t *testing.T) {
+	ctx := context.Background()
+	webhookID := "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10"
+	response := []byte(`{"id":"` + webhookID + `","eventType":"git.pullrequest.merged","status":"disabledByUser","consumerInputs":{"url":"https://httpbin.org/anything"}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "/_apis/hooks/subscriptions/", createAzureReposHandler)
+	defer cleanUp()
+	webhook, err := client.GetWebhook(ctx, owner, repo1, webhookID)
+	assert.NoError(t, err)
+	assert.Equal(t, WebhookInfo{ID: webhookID, PayloadURL: "https://httpbin.org/anything", Events: []vcsutils.WebhookEvent{vcsutils.PrMerged}}, webhook)
+
+	_, err = client.GetWebhook(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_DeleteWebhook(t *testing.T) {
+	ctx := context.Background()
+	webhookID := "9b6cf6a4-8e7c-4bd4-8d2c-7bbd1b2a3f10,1f7a0c2e-54a4-4a8f-a3e6-0c2b1f6d9e21"
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "/_apis/hooks/subscriptions/", createAzureReposHandler)
+	defer cleanUp()
+	err := client.DeleteWebhook(ctx, owner, repo1, webhookID)
+	assert.NoError(t, err)
+
+	err = client.DeleteWebhook(ctx, owner, repo1, "")
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.DeleteWebhook(ctx, owner, repo1, webhookID)
+	assert.Error(t, err)
+}
+
+func TestGetAzureReposWebhookEventTypes(t *testing.T) {
+	assert.Equal(t, []string{"git.pullrequest.updated", "git.pullrequest.merged", "git.push"},
+		getAzureReposWebhookEventTypes(vcsutils.PrRejected, vcsutils.PrMerged, vcsutils.PrEdited, vcsutils.PrClosed, vcsutils.Push, vcsutils.TagRemoved))
+	assert.Equal(t, []string{"ms.vss-code.git-pullrequest-comment-event"},
+		getAzureReposWebhookEventTypes(vcsutils.PrCommentCreated, vcsutils.PrCommentEdited, vcsutils.IssueCommentCreated))
+	assert.Equal(t, []string{"git.push"}, getAzureReposWebhookEventTypes(vcsutils.BranchDeleted, vcsutils.TagPushed))
+	assert.Empty(t, getAzureReposWebhookEventTypes())
+}
+
+func TestAzureReposClient_SetCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	commitHash := "86d6919952702f9ab03bc95b45687f145a663de0"
+	expectedUri := "/_apis/ResourceAreas/commitStatus"
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, expectedUri, createAzureReposHandler)
+	defer cleanUp()
+	err := client.SetCommitStatus(ctx, 1, owner, repo1, commitHash, "", "", "")
+	assert.NoError(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.SetCommitStatus(ctx, 1, owner, repo1, commitHash, "", "", "")
+	assert.Error(t, err)
 }
 
-func TestAzureReposClient_GetCommitBySha(t *testing.T) {
+func TestAzureReposClient_GetRepositoryInfo(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "/_apis/ResourceAreas/getRepository", createGetRepositoryAzureReposHandler)
 	defer cleanUp()
-	_, err := client.GetCommitBySha(ctx, owner, repo1, "")
-	assert.Error(t, err)
+	repositoryInfo, err := client.GetRepositoryInfo(ctx, "jfrog", "froggit-go")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://jfrog@dev.azure.com/jfrog/froggit-go/_git/froggit-go", repositoryInfo.CloneInfo.HTTP)
+	assert.Equal(t, "git@ssh.dev.azure.com:v3/jfrog/froggit-go/froggit-go", repositoryInfo.CloneInfo.SSH)
+	assert.Equal(t, repositoryInfo.RepositoryVisibility, Public)
+	assert.Equal(t, "main", repositoryInfo.DefaultBranch)
 }
 
-func TestAzureReposClient_ListPullRequestLabels(t *testing.T) {
+func TestAzureReposClient_CreateRepository(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte(`{"name": "froggit-go"}`), "", createRepositoryAzureReposHandler)
 	defer cleanUp()
-	_, err := client.ListPullRequestLabels(ctx, owner, repo1, 1)
+	repositoryInfo, err := client.CreateRepository(ctx, owner, "froggit-go", CreateRepositoryOptions{Private: true, Description: "Frogs", DefaultBranch: "develop", InitReadme: true})
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryInfo{
+		RepositoryVisibility: Public,
+		CloneInfo:            CloneInfo{HTTP: "https://jfrog@dev.azure.com/jfrog/froggit-go/_git/froggit-go", SSH: "git@ssh.dev.azure.com:v3/jfrog/froggit-go/froggit-go"},
+	}, repositoryInfo)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.CreateRepository(ctx, owner, "froggit-go", CreateRepositoryOptions{})
 	assert.Error(t, err)
 }
 
-func TestAzureReposClient_UnlabelPullRequest(t *testing.T) {
+func TestAzureReposClient_DeleteRepository(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createRepositoryAzureReposHandler)
 	defer cleanUp()
-	err := client.UnlabelPullRequest(ctx, owner, repo1, "", 1)
+	err := client.DeleteRepository(ctx, owner, "froggit-go")
+	assert.NoError(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.DeleteRepository(ctx, owner, "froggit-go")
 	assert.Error(t, err)
 }
 
-func TestAzureReposClient_UploadCodeScanning(t *testing.T) {
+func TestAzureReposClient_ForkRepository(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	expectedCreateBody := []byte(`{"name": "froggit-go", "parentRepository": {"id": "23d122fb-c6c1-4f03-8117-a10a08f8b0d6", "project": {"id": "638e3921-f5e3-46e6-a11f-a139cb9bd511"}}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, expectedCreateBody, "", createRepositoryAzureReposHandler)
 	defer cleanUp()
-	_, err := client.UploadCodeScanning(ctx, owner, repo1, "", "1")
+	repositoryInfo, err := client.ForkRepository(ctx, owner, "froggit-go", "frogs")
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryInfo{
+		RepositoryVisibility: Public,
+		CloneInfo:            CloneInfo{HTTP: "https://jfrog@dev.azure.com/jfrog/froggit-go/_git/froggit-go", SSH: "git@ssh.dev.azure.com:v3/jfrog/froggit-go/froggit-go"},
+	}, repositoryInfo)
+
+	_, err = client.ForkRepository(ctx, owner, "froggit-go", "")
+	assert.EqualError(t, err, "validation failed: required parameter 'targetOwner' is missing")
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ForkRepository(ctx, owner, "froggit-go", "frogs")
 	assert.Error(t, err)
 }
 
-func TestAzureReposClient_DownloadFileFromRepo(t *testing.T) {
+func TestAzureReposClient_GetDefaultBranch(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "badTest", createAzureReposHandler)
-	defer cleanUp()
-	_, _, err := client.DownloadFileFromRepo(ctx, owner, repo1, "", "")
-	assert.Error(t, err)
-	client, cleanUp = createServerAndClient(t, vcsutils.AzureRepos, true, []byte("good"), "/_apis/ResourceAreas/DownloadFileFromRepo?includeContent=true&path=file.txt&versionDescriptor.version=&versionDescriptor.versionType=branch", createAzureReposHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "/_apis/ResourceAreas/getRepository", createGetRepositoryAzureReposHandler)
 	defer cleanUp()
-	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, "", "file.txt")
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, "froggit-go")
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusOK, statusCode)
-	assert.Equal(t, "good", string(content))
-	client, cleanUp = createServerAndClient(t, vcsutils.AzureRepos, true, "", "bad^endpoint", createAzureReposHandler)
-	defer cleanUp()
-	_, statusCode, err = client.DownloadFileFromRepo(ctx, owner, repo1, "", "file.txt")
+	assert.Equal(t, "main", defaultBranch)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetDefaultBranch(ctx, owner, "froggit-go")
 	assert.Error(t, err)
-	assert.Equal(t, http.StatusNotFound, statusCode)
 }
 
-func TestAzureReposClient_CreateWebhook(t *testing.T) {
+func TestAzureReposClient_SetDefaultBranch(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createRepositoryAzureReposHandler)
 	defer cleanUp()
-	_, _, err := client.CreateWebhook(ctx, owner, repo1, "", "1", vcsutils.PrRejected)
+	err := client.SetDefaultBranch(ctx, owner, "froggit-go", "develop")
+	assert.NoError(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.SetDefaultBranch(ctx, owner, "froggit-go", "develop")
 	assert.Error(t, err)
 }
 
-func TestAzureReposClient_UpdateWebhook(t *testing.T) {
+func TestAzureReposClient_GetLabel(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	response, err := json.Marshal(workitemtracking.WorkItemTagDefinition{Name: vcsutils.PointerOf("label1")})
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "tags/label1", createAzureReposHandler)
 	defer cleanUp()
-	err := client.UpdateWebhook(ctx, owner, repo1, "", "1", "", "", vcsutils.PrRejected)
+	// The tags are scoped to the project
+	client.(*AzureReposClient).vcsInfo.Project = "project"
+	labelInfo, err := client.GetLabel(ctx, owner, repo1, "label1")
+	assert.NoError(t, err)
+	assert.Equal(t, &LabelInfo{Name: "label1"}, labelInfo)
+
+	_, err = client.GetLabel(ctx, owner, repo1, "")
 	assert.Error(t, err)
-}
 
-func TestAzureReposClient_DeleteWebhook(t *testing.T) {
-	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
-	defer cleanUp()
-	err := client.DeleteWebhook(ctx, owner, repo1, "")
+	// Label not found
+	notFoundClient, notFoundCleanUp := createServerAndClientReturningStatus(t, vcsutils.AzureRepos, true, []byte{}, "tags/label1", http.StatusNotFound, createAzureReposHandler)
+	defer notFoundCleanUp()
+	notFoundClient.(*AzureReposClient).vcsInfo.Project = "project"
+	labelInfo, err = notFoundClient.GetLabel(ctx, owner, repo1, "label1")
+	assert.NoError(t, err)
+	assert.Nil(t, labelInfo)
+
+	badClient, badCleanUp := createBadAzureReposClient(t, []byte{})
+	defer badCleanUp()
+	_, err = badClient.GetLabel(ctx, owner, repo1, "label1")
 	assert.Error(t, err)
 }
 
-func TestAzureReposClient_SetCommitStatus(t *testing.T) {
+func TestAzureReposClient_ListRepositoryLabels(t *testing.T) {
 	ctx := context.Background()
-	commitHash := "86d6919952702f9ab03bc95b45687f145a663de0"
-	expectedUri := "/_apis/ResourceAreas/commitStatus"
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, expectedUri, createAzureReposHandler)
+	response, err := json.Marshal(map[string]interface{}{
+		"value": []workitemtracking.WorkItemTagDefinition{{Name: vcsutils.PointerOf("label1")}, {Name: vcsutils.PointerOf("label2")}},
+		"count": 2,
+	})
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "tags", createAzureReposHandler)
 	defer cleanUp()
-	err := client.SetCommitStatus(ctx, 1, owner, repo1, commitHash, "", "", "")
+	client.(*AzureReposClient).vcsInfo.Project = "project"
+	labels, err := client.ListRepositoryLabels(ctx, owner, repo1)
 	assert.NoError(t, err)
+	assert.Equal(t, []LabelInfo{{Name: "label1"}, {Name: "label2"}}, labels)
 
-	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
-	defer badClientCleanup()
-	err = badClient.SetCommitStatus(ctx, 1, owner, repo1, commitHash, "", "", "")
+	badClient, badCleanUp := createBadAzureReposClient(t, []byte{})
+	defer badCleanUp()
+	_, err = badClient.ListRepositoryLabels(ctx, owner, repo1)
 	assert.Error(t, err)
 }
 
-func TestAzureReposClient_GetRepositoryInfo(t *testing.T) {
+func TestAzureReposClient_UpdateLabel(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "/_apis/ResourceAreas/getRepository", createGetRepositoryAzureReposHandler)
-	defer cleanUp()
-	repositoryInfo, err := client.GetRepositoryInfo(ctx, "jfrog", "froggit-go")
+	response, err := json.Marshal(workitemtracking.WorkItemTagDefinition{Name: vcsutils.PointerOf("label2")})
 	assert.NoError(t, err)
-	assert.Equal(t, "https://jfrog@dev.azure.com/jfrog/froggit-go/_git/froggit-go", repositoryInfo.CloneInfo.HTTP)
-	assert.Equal(t, "git@ssh.dev.azure.com:v3/jfrog/froggit-go/froggit-go", repositoryInfo.CloneInfo.SSH)
-	assert.Equal(t, repositoryInfo.RepositoryVisibility, Public)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "tags/label1", createAzureReposHandler)
+	defer cleanUp()
+	client.(*AzureReposClient).vcsInfo.Project = "project"
+	assert.NoError(t, client.UpdateLabel(ctx, owner, repo1, "label1", LabelInfo{Name: "label2"}))
+	assert.Error(t, client.UpdateLabel(ctx, owner, repo1, "label1", LabelInfo{}))
+
+	badClient, badCleanUp := createBadAzureReposClient(t, []byte{})
+	defer badCleanUp()
+	assert.Error(t, badClient.UpdateLabel(ctx, owner, repo1, "label1", LabelInfo{Name: "label2"}))
 }
 
-func TestAzureReposClient_GetLabel(t *testing.T) {
+func TestAzureReposClient_DeleteLabel(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "unsupportedTest", createAzureReposHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte{}, "tags/label1", createAzureReposHandler)
 	defer cleanUp()
-	_, err := client.GetLabel(ctx, owner, repo1, "")
-	assert.Error(t, err)
+	client.(*AzureReposClient).vcsInfo.Project = "project"
+	assert.NoError(t, client.DeleteLabel(ctx, owner, repo1, "label1"))
+	assert.Error(t, client.DeleteLabel(ctx, owner, repo1, ""))
+
+	badClient, badCleanUp := createBadAzureReposClient(t, []byte{})
+	defer badCleanUp()
+	assert.Error(t, badClient.DeleteLabel(ctx, owner, repo1, "label1"))
 }
 
 func TestGetUnsupportedInAzureError(t *testing.T) {
@@ -700,17 +2243,156 @@ func TestAzureReposClient_GetModifiedFiles(t *testing.T) {
 	})
 }
 
+func TestAzureReposClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	pullRequestId := 1
+	// The same response is used for both the iterations and the iteration changes requests
+	response := []byte(`{"value": [{"id": 1}, {"id": 2}], "count": 2, "changeEntries": [
+		{"changeType": "edit", "item": {"path": "/README.md"}},
+		{"changeType": "add", "item": {"path": "/new", "isFolder": true}},
+		{"changeType": "add", "item": {"path": "/new/new.go"}},
+		{"changeType": "delete", "item": {"path": "/old.go"}},
+		{"changeType": "edit, rename", "item": {"path": "/renamed.go"}, "originalPath": "/original.go"}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "getPullRequestIterations", createAzureReposHandler)
+	defer cleanUp()
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, pullRequestId)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "new/new.go", ChangeType: FileAdded},
+		{Path: "old.go", ChangeType: FileDeleted},
+		{Path: "renamed.go", PreviousPath: "original.go", ChangeType: FileRenamed},
+	}, files)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListPullRequestFiles(ctx, owner, repo1, pullRequestId)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createGetPullRequestDiffAzureReposHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, `diff --git a/README.md b/README.md
+index ce013625030ba8dba906f756967f9e9ca394464a..94954abda49de8615a048f8d2e64b5de848e27a1 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1,2 @@
+ hello
++world
+diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000000000000000000000000000000000000..06ab7d0f9a35a7d1070711496d6ca1cb892a258f
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1 @@
++package main
+`, diff)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createCompareCommitsAzureReposHandler)
+	defer cleanUp()
+
+	result, err := client.CompareCommits(ctx, "", repo1, "main", "feature")
+	assert.NoError(t, err)
+	assert.Len(t, result.Commits, 3)
+	assert.Equal(t, "86d6919952702f9ab03bc95b45687f145a663de0", result.Commits[0].Hash)
+	assert.Len(t, result.Files, 19)
+	assert.Equal(t, PullRequestFile{Path: "CustomerAddressModule/CustomerAddressModule.sln", ChangeType: FileAdded}, result.Files[0])
+	assert.Equal(t, PullRequestFile{Path: "MyWebSite/MyWebSite/Web.config", ChangeType: FileModified}, result.Files[18])
+
+	_, err = client.CompareCommits(ctx, "", repo1, "", "feature")
+	assert.EqualError(t, err, "validation failed: required parameter 'base' is missing")
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.CompareCommits(ctx, "", repo1, "main", "feature")
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_DeletePullRequestReviewComments(t *testing.T) {
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "deletePullRequestComments", createAzureReposHandler)
 	defer cleanUp()
 	err := client.DeletePullRequestReviewComments(context.Background(), "", repo1, 1, []CommentInfo{{ID: 1}, {ID: 2}}...)
 	assert.NoError(t, err)
+	err = client.DeletePullRequestReviewComments(context.Background(), "", repo1, 1, CommentInfo{ID: 2, ThreadID: "7"})
+	assert.NoError(t, err)
+	err = client.DeletePullRequestReviewComments(context.Background(), "", repo1, 1, CommentInfo{ID: 2, ThreadID: "not-a-number"})
+	assert.Error(t, err)
+	err = client.DeletePullRequestReviewComments(context.Background(), "", repo1, 1, CommentInfo{ID: 7, ThreadID: "7", NativeID: "not-a-number"})
+	assert.Error(t, err)
 	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
 	defer badClientCleanup()
 	err = badClient.DeletePullRequestReviewComments(context.Background(), "", repo1, 1, []CommentInfo{{ID: 1}, {ID: 2}}...)
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_UpdatePullRequestComment(t *testing.T) {
+	// The comment ID is the thread ID, whose first comment is updated
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte("{}"), "deletePullRequestComments/7/1", createAzureReposHandler)
+	defer cleanUp()
+	err := client.UpdatePullRequestComment(context.Background(), "", repo1, "Updated content", 1, 7)
+	assert.NoError(t, err)
+	err = client.UpdatePullRequestComment(context.Background(), "", repo1, "", 1, 1)
+	assert.Error(t, err)
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.UpdatePullRequestComment(context.Background(), "", repo1, "Updated content", 1, 1)
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_CreatePullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, nil, "", createPullRequestReviewAzureReposHandler)
+	defer cleanUp()
+	err := client.CreatePullRequestReview(ctx, "", repo1, 1, vcsutils.ReviewVerdictApprove, "Looks good")
+	assert.NoError(t, err)
+
+	err = client.CreatePullRequestReview(ctx, "", repo1, 1, vcsutils.ReviewVerdictComment, "")
+	assert.Error(t, err)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	err = badClient.CreatePullRequestReview(ctx, "", repo1, 1, vcsutils.ReviewVerdictApprove, "")
+	assert.Error(t, err)
+}
+
+func TestAzureReposClient_ListPullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"count": 3, "value": [
+		{"uniqueName": "reviewer1@jfrog.com", "vote": 10},
+		{"uniqueName": "reviewer2@jfrog.com", "vote": -5},
+		{"uniqueName": "reviewer3@jfrog.com", "vote": 0}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, response, "pullRequestReviewers", createAzureReposHandler)
+	defer cleanUp()
+
+	reviews, err := client.ListPullRequestReviews(ctx, "", repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewInfo{
+		{Reviewer: "reviewer1@jfrog.com", State: vcsutils.ReviewVerdictApprove},
+		{Reviewer: "reviewer2@jfrog.com", State: vcsutils.ReviewVerdictRequestChanges},
+	}, reviews)
+
+	badClient, badClientCleanup := createBadAzureReposClient(t, []byte{})
+	defer badClientCleanup()
+	_, err = badClient.ListPullRequestReviews(ctx, "", repo1, 1)
+	assert.Error(t, err)
+}
+
 func TestAzureReposClient_DeletePullRequestComment(t *testing.T) {
 	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, "", "deletePullRequestComments", createAzureReposHandler)
 	defer cleanUp()
@@ -722,6 +2404,76 @@ func TestAzureReposClient_DeletePullRequestComment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAzureReposClient_DeleteListedPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	threads := `{"count": 2, "value": [
+		{"id": 7, "comments": [{"id": 1, "content": "first comment"}, {"id": 2, "parentCommentId": 1, "content": "reply"}]},
+		{"id": 8, "comments": [{"id": 1, "content": "second thread"}]}
+	]}`
+	var deleteRequests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case r.Method == http.MethodGet && strings.Contains(r.RequestURI, "pullRequestComments"):
+			response = threads
+		case r.Method == http.MethodDelete:
+			deleteRequests = append(deleteRequests, strings.Split(r.RequestURI, "?")[0])
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.AzureRepos).ApiEndpoint(server.URL).Token(token).Project(project).Build()
+	assert.NoError(t, err)
+
+	comments, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+
+	// The ID of a listed comment is its thread ID, whose first comment is deleted
+	assert.NoError(t, client.DeletePullRequestComment(ctx, owner, repo1, 1, int(comments[2].ID)))
+	// The listed CommentInfo locates a specific comment of a thread
+	assert.NoError(t, client.DeletePullRequestReviewComments(ctx, owner, repo1, 1, comments[1]))
+	assert.Equal(t, []string{
+		"/_apis/ResourceAreas/deletePullRequestComments/8/1",
+		"/_apis/ResourceAreas/deletePullRequestComments/7/2",
+	}, deleteRequests)
+}
+
+func TestAzureReposClient_SetPullRequestThreadStatus(t *testing.T) {
+	ctx := context.Background()
+	var expectedStatus string
+	client, cleanUp := createServerAndClient(t, vcsutils.AzureRepos, true, []byte("{}"), "pullRequestComments",
+		func(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
+			handler := createAzureReposHandler(t, expectedURI, response, expectedStatusCode)
+			return func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					var thread struct {
+						Status string `json:"status"`
+					}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&thread))
+					assert.Equal(t, expectedStatus, thread.Status)
+				}
+				handler(w, r)
+			}
+		})
+	defer cleanUp()
+
+	expectedStatus = "fixed"
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, "", repo1, 1, "3", true))
+	expectedStatus = "active"
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, "", repo1, 1, "3", false))
+	assert.EqualError(t, client.SetPullRequestThreadStatus(ctx, "", repo1, 1, "thread", true), "invalid Azure Repos thread ID: 'thread'")
+}
+
 func TestAzureReposClient_GetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	commitHash := "86d6919952702f9ab03bc95b45687f145a663de0"
@@ -735,6 +2487,8 @@ func TestAzureReposClient_GetCommitStatus(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Len(t, commitStatuses, 3)
 		assert.Equal(t, Pass, commitStatuses[0].State)
+		assert.Equal(t, "succeeded", commitStatuses[0].RawState)
+		assert.Equal(t, "continuous-integration", commitStatuses[0].Context)
 		assert.Equal(t, InProgress, commitStatuses[1].State)
 		assert.Equal(t, Fail, commitStatuses[2].State)
 	})
@@ -758,6 +2512,12 @@ func TestExtractOwnerFromForkedRepoUrl(t *testing.T) {
 	resOwner := extractOwnerFromForkedRepoUrl(repository)
 	assert.Equal(t, "forkedOwner", resOwner)
 
+	// Azure DevOps Server
+	serverUrl := "https://tfs.jfrog.com/tfs/DefaultCollection/201f2c7f-305a-446c-a1d6-a04ec811093b/_apis/git/repositories/82d33a66-8971-4279-9687-19c69e66e114"
+	repository = &git.GitForkRef{Repository: &git.GitRepository{Url: &serverUrl}}
+	resOwner = extractOwnerFromForkedRepoUrl(repository)
+	assert.Equal(t, "DefaultCollection", resOwner)
+
 	// Fallback
 	repository = &git.GitForkRef{Repository: &git.GitRepository{}}
 	resOwner = extractOwnerFromForkedRepoUrl(repository)
@@ -770,6 +2530,87 @@ func TestExtractOwnerFromForkedRepoUrl(t *testing.T) {
 	assert.Equal(t, "", resOwner)
 }
 
+func createBranchProtectionAzureReposHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/getRepository"):
+			response = `{"id": "b2b2c7f6-8b8d-4d3a-9f2e-2f4c1c5e6a7b", "name": "repo-1"}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/gitPolicyConfigurations"):
+			assert.Equal(t, "b2b2c7f6-8b8d-4d3a-9f2e-2f4c1c5e6a7b", r.URL.Query().Get("repositoryId"))
+			assert.Equal(t, "refs/heads/"+branch1, r.URL.Query().Get("refName"))
+			response = `{"value": [
+				{"id": 1, "isEnabled": true, "isBlocking": true, "type": {"id": "fa4e907d-c16b-4a4c-9dfa-4906e5d171dd"}, "settings": {"minimumApproverCount": 2}},
+				{"id": 2, "isEnabled": true, "isBlocking": true, "type": {"id": "cbdc66da-9728-4af8-aada-9a5a32e4a226"}, "settings": {"statusName": "jfrog", "statusGenre": "frogbot"}},
+				{"id": 3, "isEnabled": true, "isBlocking": false, "type": {"id": "cbdc66da-9728-4af8-aada-9a5a32e4a226"}, "settings": {"statusName": "jfrog", "statusGenre": "optional"}},
+				{"id": 4, "isEnabled": true, "isBlocking": true, "type": {"id": "40e92b44-2fe1-4dd6-b3d8-74a9c21d0c6e"}}
+			], "count": 4}`
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/policyConfigurations/"):
+			assert.Contains(t, []string{"1", "2", "3"}, strings.Split(strings.TrimPrefix(r.URL.Path, "/_apis/ResourceAreas/policyConfigurations/"), "?")[0])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case r.Method == http.MethodPost && strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/policyConfigurations"):
+			var configuration map[string]interface{}
+			assert.NoError(t, json.Unmarshal(body, &configuration))
+			assert.Equal(t, true, configuration["isBlocking"])
+			assert.Equal(t, "cbdc66da-9728-4af8-aada-9a5a32e4a226", configuration["type"].(map[string]interface{})["id"])
+			assert.Equal(t, map[string]interface{}{
+				"statusName":  "jfrog",
+				"statusGenre": "frogbot",
+				"scope":       []interface{}{map[string]interface{}{"repositoryId": "b2b2c7f6-8b8d-4d3a-9f2e-2f4c1c5e6a7b", "refName": "refs/heads/" + branch1, "matchKind": "exact"}},
+			}, configuration["settings"])
+			response = string(body)
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createGetCombinedCommitStatusAzureReposHandler(statusesResponse, evaluationsResponse string) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+			assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+			var response string
+			switch {
+			case r.RequestURI == "/_apis":
+				jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+				assert.NoError(t, err)
+				response = string(jsonVal)
+			case r.RequestURI == "/_apis/ResourceAreas":
+				response = `{"value": [],"count": 0}`
+			case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/commitStatus"):
+				assert.Equal(t, "true", r.URL.Query().Get("latestOnly"))
+				response = statusesResponse
+			case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/getPullRequests"):
+				response = `{"value": [
+					{"pullRequestId": 1, "lastMergeSourceCommit": {"commitId": "86d6919952702f9ab03bc95b45687f145a663de0"}, "repository": {"project": {"id": "2d3a0c9a-4f33-4a28-9b10-2bde3a1f31a8"}}},
+					{"pullRequestId": 2, "lastMergeSourceCommit": {"commitId": "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"}, "repository": {"project": {"id": "2d3a0c9a-4f33-4a28-9b10-2bde3a1f31a8"}}}
+				], "count": 2}`
+			case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/policyEvaluations"):
+				assert.Equal(t, "vstfs:///CodeReview/CodeReviewId/2d3a0c9a-4f33-4a28-9b10-2bde3a1f31a8/1", r.URL.Query().Get("artifactId"))
+				response = evaluationsResponse
+			default:
+				assert.Fail(t, "unexpected request", r.RequestURI)
+			}
+			_, err := w.Write([]byte(response))
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func createAzureReposHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
@@ -832,6 +2673,197 @@ func createGetRepositoryAzureReposHandler(t *testing.T, expectedURI string, resp
 		w.WriteHeader(http.StatusNotFound)
 	}
 }
+
+func createPermissionsAzureReposHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/projects/"+project):
+			response = `{"id": "638e3921-f5e3-46e6-a11f-a139cb9bd511", "name": "jfrog-project"}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/permissions/2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87/"):
+			assert.Equal(t, "repoV2/638e3921-f5e3-46e6-a11f-a139cb9bd511", r.URL.Query().Get("tokens"))
+			response = `{"count": 1, "value": [true]}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/permissions/cb594ebe-87dd-4fc9-ac2c-6a10a4c92046/2"):
+			assert.Equal(t, "PublisherSecurity/638e3921-f5e3-46e6-a11f-a139cb9bd511", r.URL.Query().Get("tokens"))
+			response = `{"count": 1, "value": [false]}`
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+// The handler receives the expected repository creation request body as the response
+func createRepositoryAzureReposHandler(t *testing.T, _ string, expectedCreateBody []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/getRepository"):
+			switch r.Method {
+			case http.MethodPost:
+				assert.JSONEq(t, string(expectedCreateBody), string(body))
+			case http.MethodPatch:
+				assert.JSONEq(t, `{"defaultBranch": "refs/heads/develop"}`, string(body))
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			response = `{"id":"23d122fb-c6c1-4f03-8117-a10a08f8b0d6","name":"froggit-go","project":{"id":"638e3921-f5e3-46e6-a11f-a139cb9bd511","name":"froggit-go","visibility":"public"},"remoteUrl":"https://jfrog@dev.azure.com/jfrog/froggit-go/_git/froggit-go","sshUrl":"git@ssh.dev.azure.com:v3/jfrog/froggit-go/froggit-go"}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/pushes"):
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.JSONEq(t, `{"commits": [{"comment": "Initial commit", "changes": [
+				{"changeType": "add", "item": {"path": "/README.md"}, "newContent": {"content": "IyBmcm9nZ2l0LWdvCgpGcm9ncwo=", "contentType": "base64Encoded"}}
+			]}], "refUpdates": [{"name": "refs/heads/develop", "oldObjectId": "0000000000000000000000000000000000000000"}]}`, string(body))
+			response = `{"pushId": 1}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+// The handler receives the expected push request body as the response
+func createCommitFilesAzureReposHandler(t *testing.T, _ string, expectedPush []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/listBranches"):
+			response = `{"name": "branch-1", "commit": {"commitId": "86d6919952702f9ab03bc95b45687f145a663de0"}}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/pushes"):
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(expectedPush), string(body))
+			response = `{"pushId": 1}`
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+func createGetPullRequestDiffAzureReposHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		var response string
+		switch {
+		case r.RequestURI == "/_apis":
+			jsonVal, err := os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+			assert.NoError(t, err)
+			response = string(jsonVal)
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = `{"value": [],"count": 0}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/getPullRequestIterations/1/changes"):
+			response = `{"changeEntries": [
+				{"changeType": "edit", "item": {"path": "/README.md"}},
+				{"changeType": "add", "item": {"path": "/new.go"}}
+			]}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/getPullRequestIterations"):
+			response = `{"value": [{"id": 1, "commonRefCommit": {"commitId": "base"}, "sourceRefCommit": {"commitId": "source"}}], "count": 1}`
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/DownloadFileFromRepo"):
+			version := r.URL.Query().Get("versionDescriptor.version")
+			switch r.URL.Query().Get("path") {
+			case "/README.md":
+				response = map[string]string{"base": "hello\n", "source": "hello\nworld\n"}[version]
+			case "/new.go":
+				assert.Equal(t, "source", version)
+				response = "package main\n"
+			}
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createCompareCommitsAzureReposHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		var response []byte
+		var err error
+		switch {
+		case r.RequestURI == "/_apis":
+			response, err = os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = []byte(`{"value": [],"count": 0}`)
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/getCommits"):
+			assert.Equal(t, "feature", r.URL.Query().Get("searchCriteria.itemVersion.version"))
+			assert.Equal(t, "main", r.URL.Query().Get("searchCriteria.compareVersion.version"))
+			response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas?"):
+			response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "compare_commits.json"))
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func createPullRequestReviewAzureReposHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base64Token := base64.StdEncoding.EncodeToString([]byte(":" + token))
+		assert.Equal(t, "Basic "+base64Token, r.Header.Get("Authorization"))
+		reviewerID := "b2d6d7a8-cb4c-4d1f-9a6e-0c1d5a0e3f6b"
+		var response []byte
+		var err error
+		switch {
+		case r.RequestURI == "/_apis":
+			response, err = os.ReadFile(filepath.Join("./", "testdata", "azurerepos", "resourcesResponse.json"))
+		case r.RequestURI == "/_apis/ResourceAreas":
+			response = []byte(`{"value": [],"count": 0}`)
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/connectionData"):
+			response = []byte(`{"authenticatedUser": {"id": "` + reviewerID + `"}}`)
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/pullRequestReviewers/"+reviewerID):
+			assert.Equal(t, http.MethodPut, r.Method)
+			var reviewer git.IdentityRefWithVote
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reviewer))
+			assert.Equal(t, 10, *reviewer.Vote)
+			response = []byte("{}")
+		case strings.HasPrefix(r.RequestURI, "/_apis/ResourceAreas/pullRequestComments"):
+			assert.Equal(t, http.MethodPost, r.Method)
+			response = []byte("{}")
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
 func createBadAzureReposClient(t *testing.T, response []byte) (VcsClient, func()) {
 	client, cleanUp := createServerAndClient(
 		t,
@@ -845,3 +2877,42 @@ func createBadAzureReposClient(t *testing.T, response []byte) (VcsClient, func()
 		createAzureReposHandler)
 	return client, cleanUp
 }
+
+func createUploadCodeScanningAzureReposHandler(threads *[]string) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var response []byte
+			var err error
+			switch {
+			case r.RequestURI == "/_apis":
+				response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+				assert.NoError(t, err)
+			case r.RequestURI == "/_apis/ResourceAreas":
+				response = []byte(`{"value": [], "count": 0}`)
+			case strings.Contains(r.RequestURI, "getCommits"):
+				response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "commits.json"))
+				assert.NoError(t, err)
+			case strings.Contains(r.RequestURI, "commitStatus"):
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Contains(t, string(body), `"state":"Failed"`)
+				assert.Contains(t, string(body), `"description":"Xray found 2 issues"`)
+				assert.Contains(t, string(body), `"genre":"code-scanning"`)
+				assert.Contains(t, string(body), `"name":"Xray"`)
+				response = body
+			case strings.Contains(r.RequestURI, "getPullRequests"):
+				assert.Contains(t, r.RequestURI, "searchCriteria.sourceRefName=refs%2Fheads%2Fmaster")
+				response = []byte(`{"value": [{"pullRequestId": 1, "sourceRefName": "refs/heads/master", "targetRefName": "refs/heads/main"}], "count": 1}`)
+			case strings.Contains(r.RequestURI, "pullRequestComments"):
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				*threads = append(*threads, string(body))
+				response = []byte(`{"id": 1}`)
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+			_, err = w.Write(response)
+			assert.NoError(t, err)
+		}
+	}
+}
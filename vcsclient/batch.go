@@ -0,0 +1,65 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultBatchConcurrency = 10
+
+// BatchRepository is a repository which a batch operation runs on
+type BatchRepository struct {
+	Owner      string
+	Repository string
+}
+
+// BatchResult is the result of a batch operation on a repository
+type BatchResult[T any] struct {
+	BatchRepository
+	Value T
+	Err   error
+}
+
+// Batch runs an operation, such as GetLatestCommit or SetCommitStatus, on many repositories concurrently.
+// The operations are sent through the client of the caller, hence they're limited by its rate limiter and retried by its retry options.
+// The results are returned in the order of the repositories, with the errors of all the failed operations joined together.
+// Once the context is canceled, the remaining operations aren't run, and fail with the error of the context.
+// repositories - The repositories to run the operation on
+// concurrency  - The maximal number of operations which run at the same time. Defaults to 10
+// operation    - The operation to run on each repository
+func Batch[T any](ctx context.Context, repositories []BatchRepository, concurrency int, operation func(ctx context.Context, owner, repository string) (T, error)) ([]BatchResult[T], error) {
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	results := make([]BatchResult[T], len(repositories))
+	indexes := make(chan int)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < concurrency && worker < len(repositories); worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for i := range indexes {
+				results[i].BatchRepository = repositories[i]
+				if results[i].Err = ctx.Err(); results[i].Err != nil {
+					continue
+				}
+				results[i].Value, results[i].Err = operation(ctx, repositories[i].Owner, repositories[i].Repository)
+			}
+		}()
+	}
+	for i := range repositories {
+		indexes <- i
+	}
+	close(indexes)
+	waitGroup.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", result.Owner, result.Repository, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
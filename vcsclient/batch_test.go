@@ -0,0 +1,56 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	repositories := []BatchRepository{{owner, repo1}, {owner, repo2}, {username, repo1}, {username, repo2}, {owner, "repo-3"}}
+	var running, maxRunning int32
+	results, err := Batch(ctx, repositories, 2, func(ctx context.Context, owner, repository string) (string, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if owner == username && repository == repo2 {
+			return "", errors.New("not found")
+		}
+		return owner + "/" + repository, nil
+	})
+	assert.EqualError(t, err, "frogger/repo-2: not found")
+	assert.LessOrEqual(t, maxRunning, int32(2))
+	assert.Len(t, results, len(repositories))
+	for i, result := range results {
+		assert.Equal(t, repositories[i], result.BatchRepository)
+		if i == 3 {
+			assert.Error(t, result.Err)
+			continue
+		}
+		assert.NoError(t, result.Err)
+		assert.Equal(t, result.Owner+"/"+result.Repository, result.Value)
+	}
+
+	// The operations aren't run once the context is canceled
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	var runs int32
+	results, err = Batch(canceledCtx, repositories, 0, func(ctx context.Context, owner, repository string) (string, error) {
+		atomic.AddInt32(&runs, 1)
+		return "", nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, runs)
+	assert.Len(t, results, len(repositories))
+}
@@ -6,8 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/ktrysmt/go-bitbucket"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"sort"
@@ -48,9 +53,24 @@ func (client *BitbucketCloudClient) buildBitbucketCloudClient(_ context.Context)
 	if client.url != nil {
 		bitbucketClient.SetApiBaseURL(*client.url)
 	}
+	if client.vcsInfo.TokenProvider != nil {
+		bitbucketClient.HttpClient = newTokenProviderHttpClient(client.vcsInfo, client.setAuthorization)
+	} else if !isDefaultHttpClient(client.vcsInfo) {
+		bitbucketClient.HttpClient = newHttpClient(client.vcsInfo)
+	}
 	return bitbucketClient
 }
 
+// setAuthorization authenticates a request with the token of the token provider.
+// With a username, the token is an app password, otherwise it's an OAuth access token.
+func (client *BitbucketCloudClient) setAuthorization(request *http.Request, token string) {
+	if client.vcsInfo.Username != "" {
+		request.SetBasicAuth(client.vcsInfo.Username, token)
+		return
+	}
+	setBearerAuthorization(request, token)
+}
+
 // TestConnection on Bitbucket cloud
 func (client *BitbucketCloudClient) TestConnection(ctx context.Context) error {
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
@@ -58,6 +78,51 @@ func (client *BitbucketCloudClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// TestConnectionWithScopes on Bitbucket cloud.
+// The scopes of Bitbucket tokens can't be queried, so an error is returned if any scope is required.
+func (client *BitbucketCloudClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	if err := client.TestConnection(ctx); err != nil {
+		return err
+	}
+	if len(scopes) > 0 {
+		return errBitbucketTokenScopesNotSupported
+	}
+	return nil
+}
+
+// GetAuthenticatedUser on Bitbucket cloud.
+// The email is the user's primary email address, and requires the email scope.
+func (client *BitbucketCloudClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	user, err := bitbucketClient.User.Profile()
+	if err != nil {
+		return UserInfo{}, err
+	}
+	emailsResponse, err := bitbucketClient.User.Emails()
+	if err != nil {
+		return UserInfo{}, err
+	}
+	var emails bitbucketCloudEmailsResponse
+	if err = mapstructure.Decode(emailsResponse, &emails); err != nil {
+		return UserInfo{}, err
+	}
+	userInfo := UserInfo{Username: user.Username, DisplayName: user.DisplayName}
+	for _, email := range emails.Values {
+		if email.IsPrimary {
+			userInfo.Email = email.Email
+			break
+		}
+	}
+	return userInfo, nil
+}
+
+type bitbucketCloudEmailsResponse struct {
+	Values []struct {
+		Email     string `mapstructure:"email"`
+		IsPrimary bool   `mapstructure:"is_primary"`
+	} `mapstructure:"values"`
+}
+
 // ListRepositories on Bitbucket cloud
 func (client *BitbucketCloudClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
@@ -78,21 +143,397 @@ func (client *BitbucketCloudClient) ListRepositories(ctx context.Context) (map[s
 	return results, nil
 }
 
+// ListAppInstallations on Bitbucket cloud
+func (client *BitbucketCloudClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, errBitbucketAppInstallationsNotSupported
+}
+
+// ListInstallationRepositories on Bitbucket cloud
+func (client *BitbucketCloudClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, errBitbucketAppInstallationsNotSupported
+}
+
+// ListRepositoriesWithOptions on Bitbucket cloud.
+// The repositories are listed from all the workspaces, hence all the repositories are listed and the page is taken from them.
+func (client *BitbucketCloudClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	repositories, err := client.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getRepositoriesPage(repositories, options), nil
+}
+
 // ListBranches on Bitbucket cloud
 func (client *BitbucketCloudClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	branches, err := bitbucketClient.Repositories.Repository.ListBranches(&bitbucket.RepositoryBranchOptions{Owner: owner, RepoSlug: repository})
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return nil, err
 	}
 
-	results := make([]string, 0, len(branches.Branches))
-	for _, branch := range branches.Branches {
+	// The client library fetches only the first page of the branches, hence the branches are fetched directly
+	u := fmt.Sprintf("%s/repositories/%s/%s/refs/branches?pagelen=100", client.getApiEndpoint(), owner, repository)
+	var results []string
+	for u != "" {
+		var branches bitbucketCloudBranchesResponse
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &branches); err != nil {
+			return nil, err
+		}
+		for _, branch := range branches.Values {
+			results = append(results, branch.Name)
+		}
+		u = branches.Next
+	}
+	return results, nil
+}
+
+// ListBranchesWithOptions on Bitbucket cloud
+func (client *BitbucketCloudClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	query := url.Values{"page": {strconv.Itoa(page)}, "pagelen": {strconv.Itoa(perPage)}}
+	u := fmt.Sprintf("%s/repositories/%s/%s/refs/branches?%s", client.getApiEndpoint(), owner, repository, query.Encode())
+	var branches bitbucketCloudBranchesResponse
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &branches); err != nil {
+		return nil, err
+	}
+	results := make([]string, 0, len(branches.Values))
+	for _, branch := range branches.Values {
 		results = append(results, branch.Name)
 	}
 	return results, nil
 }
 
+type bitbucketCloudBranchesResponse struct {
+	Values []struct {
+		Name string `json:"name"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// CreateBranch on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateBranch(ctx context.Context, owner, repository, sourceRef, newBranch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sourceRef": sourceRef, "newBranch": newBranch})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	_, err = bitbucketClient.Repositories.Repository.CreateBranch(&bitbucket.RepositoryBranchCreationOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		Name:     newBranch,
+		Target:   bitbucket.RepositoryBranchTarget{Hash: sourceRef},
+	})
+	return err
+}
+
+// DeleteBranch on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	return bitbucketClient.Repositories.Repository.DeleteBranch(&bitbucket.RepositoryBranchDeleteOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		RefName:  branch,
+	})
+}
+
+// GetDefaultBranch on Bitbucket cloud
+func (client *BitbucketCloudClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return "", err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return "", err
+	}
+	return repo.Mainbranch.Name, nil
+}
+
+// SetDefaultBranch on Bitbucket cloud
+func (client *BitbucketCloudClient) SetDefaultBranch(ctx context.Context, owner, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch}); err != nil {
+		return err
+	}
+	// The client library doesn't support updating the main branch, hence the repository is updated directly
+	u := fmt.Sprintf("%s/repositories/%s/%s", client.getApiEndpoint(), owner, repository)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPut, u, bitbucketCloudSetMainBranchRequest{MainBranch: bitbucketCloudMainBranch{Name: branch}}, nil)
+}
+
+type bitbucketCloudSetMainBranchRequest struct {
+	MainBranch bitbucketCloudMainBranch `json:"mainbranch"`
+}
+
+type bitbucketCloudMainBranch struct {
+	Name string `json:"name"`
+}
+
+// GetBranchProtection on Bitbucket cloud.
+// Reads the push and required approvals branch restrictions of the branch.
+func (client *BitbucketCloudClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (BranchProtectionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	var branchProtection BranchProtectionInfo
+	for _, restriction := range restrictions {
+		switch restriction.Kind {
+		case bitbucketCloudPushRestriction:
+			branchProtection.RestrictPushes = true
+		case bitbucketCloudRequireApprovalsRestriction:
+			branchProtection.RequiredApprovals = restriction.Value
+		}
+	}
+	return branchProtection, nil
+}
+
+// SetBranchProtection on Bitbucket cloud.
+// Replaces the push and required approvals branch restrictions of the branch. Required status checks aren't supported.
+func (client *BitbucketCloudClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtectionInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	if len(protection.RequiredStatusChecks) > 0 {
+		return errBitbucketRequiredStatusChecksNotSupported
+	}
+	restrictions, err := client.getBranchRestrictions(ctx, owner, repository, branch)
+	if err != nil {
+		return err
+	}
+	restrictionsUrl := fmt.Sprintf("%s/repositories/%s/%s/branch-restrictions", client.getApiEndpoint(), owner, repository)
+	for _, restriction := range restrictions {
+		if restriction.Kind != bitbucketCloudPushRestriction && restriction.Kind != bitbucketCloudRequireApprovalsRestriction {
+			continue
+		}
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", restrictionsUrl, restriction.Id), nil, nil); err != nil {
+			return err
+		}
+	}
+
+	var newRestrictions []bitbucketCloudBranchRestriction
+	if protection.RestrictPushes {
+		// A push restriction without users and groups blocks all pushes
+		newRestrictions = append(newRestrictions, bitbucketCloudBranchRestriction{Kind: bitbucketCloudPushRestriction})
+	}
+	if protection.RequiredApprovals > 0 {
+		newRestrictions = append(newRestrictions, bitbucketCloudBranchRestriction{Kind: bitbucketCloudRequireApprovalsRestriction, Value: protection.RequiredApprovals})
+	}
+	for _, restriction := range newRestrictions {
+		restriction.BranchMatchKind = "glob"
+		restriction.Pattern = branch
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodPost, restrictionsUrl, restriction, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Branch restrictions are managed directly, as they aren't supported by the client library
+func (client *BitbucketCloudClient) getBranchRestrictions(ctx context.Context, owner, repository, branch string) ([]bitbucketCloudBranchRestriction, error) {
+	u := fmt.Sprintf("%s/repositories/%s/%s/branch-restrictions?pagelen=100&pattern=%s", client.getApiEndpoint(), owner, repository, url.QueryEscape(branch))
+	var results []bitbucketCloudBranchRestriction
+	for u != "" {
+		var restrictions struct {
+			Values []bitbucketCloudBranchRestriction `json:"values"`
+			Next   string                            `json:"next"`
+		}
+		if err := client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &restrictions); err != nil {
+			return nil, err
+		}
+		results = append(results, restrictions.Values...)
+		u = restrictions.Next
+	}
+	return results, nil
+}
+
+const (
+	bitbucketCloudPushRestriction             = "push"
+	bitbucketCloudRequireApprovalsRestriction = "require_approvals_to_merge"
+)
+
+type bitbucketCloudBranchRestriction struct {
+	Id              int    `json:"id,omitempty"`
+	Kind            string `json:"kind"`
+	BranchMatchKind string `json:"branch_match_kind"`
+	Pattern         string `json:"pattern"`
+	Value           int    `json:"value,omitempty"`
+}
+
+// ListTags on Bitbucket cloud
+func (client *BitbucketCloudClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	tags, err := bitbucketClient.Repositories.Repository.ListTags(&bitbucket.RepositoryTagOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []TagInfo
+	for _, tag := range tags.Tags {
+		parsedTag, err := vcsutils.RemapFields[bitbucketCloudTag](tag, "json")
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, TagInfo{Name: parsedTag.Name, CommitSha: parsedTag.Target.Hash})
+	}
+	return results, nil
+}
+
+// CreateTag on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateTag(ctx context.Context, owner, repository, tagName, sourceRef, message string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName, "sourceRef": sourceRef})
+	if err != nil {
+		return err
+	}
+	// The client library doesn't support sending the tag message, hence the tag is created directly
+	u := fmt.Sprintf("%s/repositories/%s/%s/refs/tags", client.getApiEndpoint(), owner, repository)
+	tag := bitbucketCloudTag{Name: tagName, Message: message}
+	tag.Target.Hash = sourceRef
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, u, tag, nil)
+}
+
+// GetTagInfo on Bitbucket cloud
+func (client *BitbucketCloudClient) GetTagInfo(ctx context.Context, owner, repository, tagName string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/refs/tags/%s", client.getApiEndpoint(), owner, repository, url.PathEscape(tagName))
+	var tag bitbucketCloudTag
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &tag); err != nil {
+		return CommitInfo{}, err
+	}
+	return client.GetCommitBySha(ctx, owner, repository, tag.Target.Hash)
+}
+
+type bitbucketCloudTag struct {
+	Name string `json:"name"`
+	// A message makes the created tag an annotated tag
+	Message string `json:"message,omitempty"`
+	Target  struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+// CreateRelease on Bitbucket cloud.
+// Bitbucket cloud has no releases, hence the release notes are uploaded to the repository downloads,
+// in a file named after the tag.
+func (client *BitbucketCloudClient) CreateRelease(ctx context.Context, owner, repository, tagName, name, releaseNotes string) (ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	fileName := tagName + "-release-notes.md"
+	body := new(bytes.Buffer)
+	formWriter := multipart.NewWriter(body)
+	fileWriter, err := formWriter.CreateFormFile("files", fileName)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	if _, err = fmt.Fprintf(fileWriter, "# %s\n\n%s\n", name, releaseNotes); err != nil {
+		return ReleaseInfo{}, err
+	}
+	if err = formWriter.Close(); err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	u := fmt.Sprintf("%s/repositories/%s/%s/downloads", client.getApiEndpoint(), owner, repository)
+	if err = client.sendRequest(ctx, http.MethodPost, u, body, formWriter.FormDataContentType(), nil); err != nil {
+		return ReleaseInfo{}, err
+	}
+	return ReleaseInfo{
+		ID:      fileName,
+		Name:    name,
+		TagName: tagName,
+		Body:    releaseNotes,
+		URL:     fmt.Sprintf("%s/%s", u, url.PathEscape(fileName)),
+	}, nil
+}
+
+// ListReleases on Bitbucket cloud.
+// Returns the files of the repository downloads.
+func (client *BitbucketCloudClient) ListReleases(ctx context.Context, owner, repository string) ([]ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/repositories/%s/%s/downloads?pagelen=100", client.getApiEndpoint(), owner, repository)
+	var results []ReleaseInfo
+	for u != "" {
+		var downloads bitbucketCloudDownloadsResponse
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &downloads); err != nil {
+			return nil, err
+		}
+		for _, download := range downloads.Values {
+			results = append(results, ReleaseInfo{
+				ID:        download.Name,
+				Name:      download.Name,
+				URL:       download.Links.Self.Href,
+				CreatedAt: download.CreatedOn,
+			})
+		}
+		u = downloads.Next
+	}
+	return results, nil
+}
+
+// GetLatestRelease on Bitbucket cloud.
+// Returns the most recently uploaded file of the repository downloads.
+func (client *BitbucketCloudClient) GetLatestRelease(ctx context.Context, owner, repository string) (ReleaseInfo, error) {
+	releases, err := client.ListReleases(ctx, owner, repository)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	if len(releases) == 0 {
+		return ReleaseInfo{}, fmt.Errorf("couldn't find any download in repository %s", repository)
+	}
+	latestRelease := releases[0]
+	for _, release := range releases[1:] {
+		if release.CreatedAt.After(latestRelease.CreatedAt) {
+			latestRelease = release
+		}
+	}
+	return latestRelease, nil
+}
+
+// UploadReleaseAsset on Bitbucket cloud
+func (client *BitbucketCloudClient) UploadReleaseAsset(ctx context.Context, owner, repository, releaseID, name string, asset io.Reader) (string, error) {
+	return "", errBitbucketUploadReleaseAssetNotSupported
+}
+
+type bitbucketCloudDownloadsResponse struct {
+	Values []struct {
+		Name      string    `json:"name"`
+		CreatedOn time.Time `json:"created_on"`
+		Links     struct {
+			Self link `json:"self"`
+		} `json:"links"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
 // AddSshKeyToRepository on Bitbucket cloud, the deploy-key is always read-only.
 func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, _ Permission) (err error) {
 	err = validateParametersNotBlank(map[string]string{
@@ -104,26 +545,40 @@ func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, o
 	if err != nil {
 		return
 	}
-	endpoint := client.vcsInfo.APIEndpoint
-	if endpoint == "" {
-		endpoint = bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
-	}
-	u := fmt.Sprintf("%s/repositories/%s/%s/deploy-keys", endpoint, owner, repository)
+	u := fmt.Sprintf("%s/repositories/%s/%s/deploy-keys", client.getApiEndpoint(), owner, repository)
 	addKeyRequest := bitbucketCloudAddSSHKeyRequest{
 		Label: keyName,
 		Key:   publicKey,
 	}
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, u, addKeyRequest, nil)
+}
+
+func (client *BitbucketCloudClient) getApiEndpoint() string {
+	if client.vcsInfo.APIEndpoint == "" {
+		return bitbucket.DEFAULT_BITBUCKET_API_BASE_URL
+	}
+	return client.vcsInfo.APIEndpoint
+}
 
+// sendRequestWithJsonBody sends a request which isn't supported by the Bitbucket cloud client library.
+// The request body is sent only if requestBody isn't nil, and the response body is decoded only if responseBody isn't nil.
+func (client *BitbucketCloudClient) sendRequestWithJsonBody(ctx context.Context, method, u string, requestBody, responseBody interface{}) error {
 	body := new(bytes.Buffer)
-	err = json.NewEncoder(body).Encode(addKeyRequest)
-	if err != nil {
-		return
+	if requestBody != nil {
+		if err := json.NewEncoder(body).Encode(requestBody); err != nil {
+			return err
+		}
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, body)
+	return client.sendRequest(ctx, method, u, body, "application/json", responseBody)
+}
+
+// sendRequest sends a request with the given body and content type, and decodes the response body into responseBody if it isn't nil.
+func (client *BitbucketCloudClient) sendRequest(ctx context.Context, method, u string, body io.Reader, contentType string, responseBody interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
 	if err != nil {
 		return
 	}
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.SetBasicAuth(client.vcsInfo.Username, client.vcsInfo.Token)
 
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
@@ -137,6 +592,10 @@ func (client *BitbucketCloudClient) AddSshKeyToRepository(ctx context.Context, o
 
 	if response.StatusCode >= 300 {
 		err = fmt.Errorf(response.Status)
+		return
+	}
+	if responseBody != nil {
+		err = json.NewDecoder(response.Body).Decode(responseBody)
 	}
 	return
 }
@@ -169,6 +628,11 @@ func (client *BitbucketCloudClient) CreateWebhook(ctx context.Context, owner, re
 	return id, token, err
 }
 
+// CreateOrUpdateWebhook on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateOrUpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	return createOrUpdateWebhook(ctx, client, owner, repository, branch, payloadURL, webhookEvents...)
+}
+
 // UpdateWebhook on Bitbucket cloud
 func (client *BitbucketCloudClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
 	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
@@ -197,6 +661,73 @@ func (client *BitbucketCloudClient) DeleteWebhook(ctx context.Context, owner, re
 	return err
 }
 
+// ListWebhooks on Bitbucket cloud
+func (client *BitbucketCloudClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	webhooks, err := bitbucketClient.Repositories.Webhooks.List(&bitbucket.WebhooksOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]WebhookInfo, 0, len(webhooks))
+	for i := range webhooks {
+		results = append(results, mapBitbucketCloudWebhookToWebhookInfo(&webhooks[i]))
+	}
+	return results, nil
+}
+
+// GetWebhook on Bitbucket cloud
+func (client *BitbucketCloudClient) GetWebhook(ctx context.Context, owner, repository, webhookID string) (WebhookInfo, error) {
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	webhook, err := bitbucketClient.Repositories.Webhooks.Get(&bitbucket.WebhooksOptions{
+		Uuid:     webhookID,
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	return mapBitbucketCloudWebhookToWebhookInfo(webhook), nil
+}
+
+// RotateWebhookSecret on Bitbucket cloud
+// Bitbucket cloud doesn't sign the payloads, hence the token is replaced in the "token" query parameter of the webhook URL.
+func (client *BitbucketCloudClient) RotateWebhookSecret(ctx context.Context, owner, repository, webhookID string) (string, error) {
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.WebhooksOptions{
+		Uuid:     webhookID,
+		Owner:    owner,
+		RepoSlug: repository,
+	}
+	webhook, err := bitbucketClient.Repositories.Webhooks.Get(options)
+	if err != nil {
+		return "", err
+	}
+	payloadURL, err := url.Parse(webhook.Url)
+	if err != nil {
+		return "", err
+	}
+	token := vcsutils.CreateToken()
+	query := payloadURL.Query()
+	query.Set("token", token)
+	payloadURL.RawQuery = query.Encode()
+
+	options.Active = webhook.Active
+	options.Description = webhook.Description
+	options.Events = webhook.Events
+	options.Url = payloadURL.String()
+	if _, err = bitbucketClient.Repositories.Webhooks.Update(options); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
 // SetCommitStatus on Bitbucket cloud
 func (client *BitbucketCloudClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository,
 	ref, title, description, detailsURL string) error {
@@ -235,25 +766,201 @@ func (client *BitbucketCloudClient) GetCommitStatuses(ctx context.Context, owner
 	return results, err
 }
 
-// DownloadRepository on Bitbucket cloud
-func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owner, repository, branch,
-	localPath string) error {
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	client.logger.Debug("getting Bitbucket Cloud archive link to download")
-	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
-		Owner:    owner,
-		RepoSlug: repository,
-	})
+// GetCombinedCommitStatus on Bitbucket cloud.
+// Combines the build statuses of the ref.
+func (client *BitbucketCloudClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	statuses, err := client.GetCommitStatuses(ctx, owner, repository, ref)
 	if err != nil {
-		return err
+		return Error, err
 	}
+	return combineCommitStatusInfos(statuses), nil
+}
 
-	downloadLink, err := getDownloadLink(repo, branch)
+// TriggerPipeline on Bitbucket cloud.
+// The ref is either a branch name or a commit SHA, and the ID of the triggered pipeline is its UUID.
+func (client *BitbucketCloudClient) TriggerPipeline(ctx context.Context, owner, repository, ref string, variables map[string]string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
 	if err != nil {
-		return err
+		return PipelineInfo{}, err
 	}
-	client.logger.Debug("received archive url:", downloadLink)
-	getRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadLink, nil)
+	// The client library doesn't support pipeline variables, hence the pipeline is triggered directly
+	request := bitbucketCloudTriggerPipelineRequest{Target: bitbucketCloudPipelineTarget{Type: "pipeline_ref_target", RefType: "branch", RefName: ref}}
+	if plumbing.IsHash(ref) {
+		request.Target = bitbucketCloudPipelineTarget{Type: "pipeline_commit_target", Commit: &bitbucketCloudPipelineCommit{Type: "commit", Hash: ref}}
+	}
+	keys := maps.Keys(variables)
+	slices.Sort(keys)
+	for _, key := range keys {
+		request.Variables = append(request.Variables, bitbucketCloudPipelineVariable{Key: key, Value: variables[key]})
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pipelines/", client.getApiEndpoint(), owner, repository)
+	var pipeline bitbucketCloudPipeline
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodPost, u, request, &pipeline); err != nil {
+		return PipelineInfo{}, err
+	}
+	return pipeline.toPipelineInfo(), nil
+}
+
+// GetLatestPipelineStatus on Bitbucket cloud
+func (client *BitbucketCloudClient) GetLatestPipelineStatus(ctx context.Context, owner, repository, ref string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	query := url.Values{"sort": {"-created_on"}, "pagelen": {"1"}}
+	if plumbing.IsHash(ref) {
+		query.Set("target.commit.hash", ref)
+	} else {
+		query.Set("target.ref_name", ref)
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pipelines/?%s", client.getApiEndpoint(), owner, repository, query.Encode())
+	var pipelines bitbucketCloudPipelinesResponse
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &pipelines); err != nil {
+		return PipelineInfo{}, err
+	}
+	if len(pipelines.Values) == 0 {
+		return PipelineInfo{}, fmt.Errorf("no pipeline was found for ref '%s'", ref)
+	}
+	return pipelines.Values[0].toPipelineInfo(), nil
+}
+
+// GetPipelineStatus on Bitbucket cloud.
+// The pipeline ID is the UUID of the pipeline, which is returned by TriggerPipeline.
+func (client *BitbucketCloudClient) GetPipelineStatus(ctx context.Context, owner, repository, pipelineID string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pipelineID": pipelineID})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s", client.getApiEndpoint(), owner, repository, url.PathEscape(pipelineID))
+	var pipeline bitbucketCloudPipeline
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &pipeline); err != nil {
+		return PipelineInfo{}, err
+	}
+	return pipeline.toPipelineInfo(), nil
+}
+
+type bitbucketCloudTriggerPipelineRequest struct {
+	Target    bitbucketCloudPipelineTarget     `json:"target"`
+	Variables []bitbucketCloudPipelineVariable `json:"variables,omitempty"`
+}
+
+type bitbucketCloudPipelineTarget struct {
+	Type    string                        `json:"type,omitempty"`
+	RefType string                        `json:"ref_type,omitempty"`
+	RefName string                        `json:"ref_name,omitempty"`
+	Commit  *bitbucketCloudPipelineCommit `json:"commit,omitempty"`
+}
+
+type bitbucketCloudPipelineCommit struct {
+	Type string `json:"type,omitempty"`
+	Hash string `json:"hash"`
+}
+
+type bitbucketCloudPipelineVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type bitbucketCloudPipelinesResponse struct {
+	Values []bitbucketCloudPipeline `json:"values"`
+}
+
+type bitbucketCloudPipeline struct {
+	UUID   string                       `json:"uuid"`
+	Target bitbucketCloudPipelineTarget `json:"target"`
+	State  struct {
+		Name   string `json:"name"`
+		Result struct {
+			Name string `json:"name"`
+		} `json:"result"`
+	} `json:"state"`
+	CreatedOn   time.Time  `json:"created_on"`
+	CompletedOn *time.Time `json:"completed_on"`
+}
+
+func (pipeline bitbucketCloudPipeline) toPipelineInfo() PipelineInfo {
+	status := InProgress
+	rawStatus := pipeline.State.Name
+	if rawStatus == "COMPLETED" {
+		rawStatus = pipeline.State.Result.Name
+		status = Fail
+		if rawStatus == "SUCCESSFUL" {
+			status = Pass
+		}
+	}
+	pipelineInfo := PipelineInfo{
+		ID:            pipeline.UUID,
+		Status:        status,
+		RawStatus:     rawStatus,
+		Ref:           pipeline.Target.RefName,
+		CreatedAt:     pipeline.CreatedOn,
+		LastUpdatedAt: extractTimeWithFallback(pipeline.CompletedOn),
+	}
+	if pipeline.Target.Commit != nil {
+		pipelineInfo.CommitSHA = pipeline.Target.Commit.Hash
+	}
+	return pipelineInfo
+}
+
+// TriggerWorkflow on Bitbucket cloud
+func (client *BitbucketCloudClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return errBitbucketCloudWorkflowsNotSupported
+}
+
+// GetWorkflowRunStatus on Bitbucket cloud
+func (client *BitbucketCloudClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketCloudWorkflowsNotSupported
+}
+
+// QueueBuild on Bitbucket cloud
+func (client *BitbucketCloudClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketCloudWorkflowsNotSupported
+}
+
+// GetBuildStatus on Bitbucket cloud
+func (client *BitbucketCloudClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketCloudWorkflowsNotSupported
+}
+
+// DownloadRepository on Bitbucket cloud
+func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owner, repository, branch,
+	localPath string) error {
+	return client.downloadRepository(ctx, owner, repository, branch, localPath, nil)
+}
+
+// DownloadRepositoryPaths on Bitbucket cloud.
+// Bitbucket cloud doesn't archive selected paths, so the paths are filtered while the archive is extracted.
+func (client *BitbucketCloudClient) DownloadRepositoryPaths(ctx context.Context, owner, repository, ref string, paths []string,
+	localPath string) error {
+	paths, err := normalizeDownloadPaths(paths)
+	if err != nil {
+		return err
+	}
+	return client.downloadRepository(ctx, owner, repository, ref, localPath, paths)
+}
+
+func (client *BitbucketCloudClient) downloadRepository(ctx context.Context, owner, repository, branch,
+	localPath string, paths []string) (err error) {
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	client.logger.Debug("getting Bitbucket Cloud archive link to download")
+	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return err
+	}
+
+	format, err := getArchiveFormat(client.vcsInfo, vcsutils.TarGzArchive)
+	if err != nil {
+		return err
+	}
+	downloadLink, err := getDownloadLink(repo, branch, format)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug("received archive url:", downloadLink)
+	getRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadLink, nil)
 	if err != nil {
 		return err
 	}
@@ -265,11 +972,12 @@ func (client *BitbucketCloudClient) DownloadRepository(ctx context.Context, owne
 	if err != nil {
 		return err
 	}
+	defer func() { err = errors.Join(err, response.Body.Close()) }()
 	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK); err != nil {
 		return err
 	}
 	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
-	err = vcsutils.Untar(localPath, response.Body, true)
+	err = vcsutils.ExtractArchive(localPath, vcsutils.LimitReader(response.Body, client.vcsInfo.MaxDownloadSize), format, true, paths, client.vcsInfo.ExtractionLimits)
 	if err != nil {
 		return err
 	}
@@ -292,7 +1000,18 @@ func (client *BitbucketCloudClient) GetPullRequestDetailsSizeLimit() int {
 
 // CreatePullRequest on Bitbucket cloud
 func (client *BitbucketCloudClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch,
-	targetBranch, title, description string) error {
+	targetBranch, title, description string) (PullRequestInfo, error) {
+	return client.CreatePullRequestWithOptions(ctx, owner, repository, sourceBranch, targetBranch, title, description, CreatePullRequestOptions{})
+}
+
+// CreatePullRequestWithOptions on Bitbucket cloud.
+// The reviewers are given by their account UUIDs.
+func (client *BitbucketCloudClient) CreatePullRequestWithOptions(ctx context.Context, owner, repository, sourceBranch,
+	targetBranch, title, description string, createOptions CreatePullRequestOptions) (PullRequestInfo, error) {
+	if err := validateCreatePullRequestOptions(createOptions, notSupportedOnBitbucket+" cloud", draftPullRequestOption, labelsPullRequestOption,
+		assigneesPullRequestOption, milestonePullRequestOption, workItemsPullRequestOption); err != nil {
+		return PullRequestInfo{}, err
+	}
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	client.logger.Debug(vcsutils.CreatingPullRequest, title)
 	options := &bitbucket.PullRequestsOptions{
@@ -303,29 +1022,83 @@ func (client *BitbucketCloudClient) CreatePullRequest(ctx context.Context, owner
 		DestinationBranch: targetBranch,
 		Title:             title,
 		Description:       description,
+		Reviewers:         createOptions.Reviewers,
+		CloseSourceBranch: createOptions.DeleteSourceBranch,
 	}
-	_, err := bitbucketClient.Repositories.PullRequests.Create(options)
-	return err
+	pullRequestRaw, err := bitbucketClient.Repositories.PullRequests.Create(options)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	pullRequestDetails, err := vcsutils.RemapFields[pullRequestsDetails](pullRequestRaw, "json")
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapBitbucketCloudPullRequestDetailsToPullRequestInfo(pullRequestDetails), nil
 }
 
 // UpdatePullRequest on Bitbucket cloud
+// Closing a pull request declines it. Declined pull requests can't be reopened on Bitbucket cloud.
 func (client *BitbucketCloudClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	client.logger.Debug(vcsutils.CreatingPullRequest, title)
-	options := &bitbucket.PullRequestsOptions{
-		Owner:             owner,
-		SourceRepository:  owner + "/" + repository,
-		RepoSlug:          repository,
-		Title:             title,
-		Description:       body,
-		DestinationBranch: targetBranchName,
-		ID:                strconv.Itoa(prId),
-		States:            []string{*vcsutils.MapPullRequestState(&state)},
+	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
+	// The Bitbucket cloud client library overrides unset fields, such as the reviewers, hence the pull request is edited directly
+	updateRequest := bitbucketCloudUpdatePullRequestRequest{
+		Title:       title,
+		Description: body,
+	}
+	if targetBranchName != "" {
+		updateRequest.Destination = &bitbucketCloudDestination{}
+		updateRequest.Destination.Branch.Name = targetBranchName
+	}
+	if updateRequest != (bitbucketCloudUpdatePullRequestRequest{}) {
+		u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d", client.getApiEndpoint(), owner, repository, prId)
+		if err := client.sendRequestWithJsonBody(ctx, http.MethodPut, u, updateRequest, nil); err != nil {
+			return err
+		}
 	}
-	_, err := bitbucketClient.Repositories.PullRequests.Update(options)
+	if state != vcsutils.Closed {
+		return nil
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	_, err := bitbucketClient.Repositories.PullRequests.Decline(&bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       strconv.Itoa(prId),
+	})
 	return err
 }
 
+type bitbucketCloudUpdatePullRequestRequest struct {
+	Title       string                     `json:"title,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Destination *bitbucketCloudDestination `json:"destination,omitempty"`
+}
+
+type bitbucketCloudDestination struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+}
+
+// MergePullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) MergePullRequest(ctx context.Context, owner, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	mergeStrategy, err := getBitbucketCloudMergeStrategy(mergeMethod)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/merge", client.getApiEndpoint(), owner, repository, prId)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, u, bitbucketCloudMergePullRequestRequest{MergeStrategy: mergeStrategy}, nil)
+}
+
+// SetPullRequestAutoMerge on Bitbucket cloud
+func (client *BitbucketCloudClient) SetPullRequestAutoMerge(_ context.Context, _, _ string, _ int, _ vcsutils.MergeMethod) error {
+	return errBitbucketCloudAutoMergeNotSupported
+}
+
+type bitbucketCloudMergePullRequestRequest struct {
+	MergeStrategy string `json:"merge_strategy"`
+}
+
 // ListOpenPullRequestsWithBody on Bitbucket cloud
 func (client *BitbucketCloudClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) (res []PullRequestInfo, err error) {
 	return client.getOpenPullRequests(ctx, owner, repository, true)
@@ -336,6 +1109,77 @@ func (client *BitbucketCloudClient) ListOpenPullRequests(ctx context.Context, ow
 	return client.getOpenPullRequests(ctx, owner, repository, false)
 }
 
+// ListOpenPullRequestsWithOptions on Bitbucket cloud
+func (client *BitbucketCloudClient) ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	// The client library doesn't support pagination and filtering, hence the pull requests are fetched directly
+	page, perPage := options.getPagination()
+	query := url.Values{"state": {"OPEN"}, "page": {strconv.Itoa(page)}, "pagelen": {strconv.Itoa(perPage)}}
+	var filters []string
+	if options.SourceBranch != "" {
+		filters = append(filters, fmt.Sprintf("source.branch.name=%q", options.SourceBranch))
+	}
+	if options.TargetBranch != "" {
+		filters = append(filters, fmt.Sprintf("destination.branch.name=%q", options.TargetBranch))
+	}
+	if !options.UpdatedSince.IsZero() {
+		filters = append(filters, "updated_on>="+options.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	if len(filters) > 0 {
+		query.Set("q", strings.Join(filters, " AND "))
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests?%s", client.getApiEndpoint(), owner, repository, query.Encode())
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	var pullRequests pullRequestsResponse
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &pullRequests); err != nil {
+		return nil, err
+	}
+
+	// The API can't filter by the author display name, hence the pull requests of the page are filtered here
+	var results []PullRequestInfo
+	for _, pullRequestInfo := range mapBitbucketCloudPullRequestToPullRequestInfo(&pullRequests, options.WithBody) {
+		if options.matches(pullRequestInfo) {
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
+var bitbucketCloudPullRequestStates = map[vcsutils.PullRequestState][]string{
+	vcsutils.Open:   {"OPEN"},
+	vcsutils.Closed: {"DECLINED", "SUPERSEDED"},
+	vcsutils.Merged: {"MERGED"},
+}
+
+// ListPullRequestsWithState on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+
+	// The client library keeps only the last of several states, hence the pull requests are fetched directly
+	query := url.Values{"state": bitbucketCloudPullRequestStates[state], "pagelen": {"50"}}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests?%s", client.getApiEndpoint(), owner, repository, query.Encode())
+	var results []PullRequestInfo
+	for u != "" {
+		var pullRequests pullRequestsResponse
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &pullRequests); err != nil {
+			return nil, err
+		}
+		results = append(results, mapBitbucketCloudPullRequestToPullRequestInfo(&pullRequests, true)...)
+		u = pullRequests.Next
+	}
+	return results, nil
+}
+
 func (client *BitbucketCloudClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) (res []PullRequestInfo, err error) {
 	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
@@ -359,6 +1203,7 @@ func (client *BitbucketCloudClient) getOpenPullRequests(ctx context.Context, own
 	return mapBitbucketCloudPullRequestToPullRequestInfo(&parsedPullRequests, withBody), nil
 }
 
+// GetPullRequestByID on Bitbucket cloud
 func (client *BitbucketCloudClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
 	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
@@ -381,22 +1226,7 @@ func (client *BitbucketCloudClient) GetPullRequestByID(ctx context.Context, owne
 		return
 	}
 
-	sourceOwner, sourceRepository := splitBitbucketCloudRepoName(pullRequestDetails.Source.Repository.Name)
-	targetOwner, targetRepository := splitBitbucketCloudRepoName(pullRequestDetails.Target.Repository.Name)
-
-	pullRequestInfo = PullRequestInfo{
-		ID: pullRequestDetails.ID,
-		Source: BranchInfo{
-			Name:       pullRequestDetails.Source.Name.Str,
-			Repository: sourceRepository,
-			Owner:      sourceOwner,
-		},
-		Target: BranchInfo{
-			Name:       pullRequestDetails.Target.Name.Str,
-			Repository: targetRepository,
-			Owner:      targetOwner,
-		},
-	}
+	pullRequestInfo = mapBitbucketCloudPullRequestDetailsToPullRequestInfo(pullRequestDetails)
 	return
 }
 
@@ -417,14 +1247,52 @@ func (client *BitbucketCloudClient) AddPullRequestComment(ctx context.Context, o
 	return err
 }
 
+type bitbucketCloudAddCommentRequest struct {
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Inline *bitbucketCloudCommentInline `json:"inline,omitempty"`
+}
+
+type bitbucketCloudCommentInline struct {
+	Path string `json:"path"`
+	To   int    `json:"to,omitempty"`
+}
+
 // AddPullRequestReviewComments on Bitbucket cloud
-func (client *BitbucketCloudClient) AddPullRequestReviewComments(_ context.Context, _, _ string, _ int, _ ...PullRequestComment) error {
-	return errBitbucketAddPullRequestReviewCommentsNotSupported
+func (client *BitbucketCloudClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return errors.New(vcsutils.ErrNoCommentsProvided)
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments", client.getApiEndpoint(), owner, repository, pullRequestID)
+	for _, comment := range comments {
+		if err = validateParametersNotBlank(map[string]string{"content": comment.Content}); err != nil {
+			return err
+		}
+		addCommentRequest := bitbucketCloudAddCommentRequest{}
+		addCommentRequest.Content.Raw = comment.Content
+		// Bitbucket cloud expects file paths relative to the repository root
+		if filePath := strings.TrimPrefix(comment.NewFilePath, "/"); filePath != "" {
+			addCommentRequest.Inline = &bitbucketCloudCommentInline{Path: filePath, To: comment.NewStartLine}
+		}
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodPost, u, addCommentRequest, nil); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ListPullRequestReviewComments on Bitbucket cloud
-func (client *BitbucketCloudClient) ListPullRequestReviewComments(_ context.Context, _, _ string, _ int) ([]CommentInfo, error) {
-	return nil, errBitbucketListPullRequestReviewCommentsNotSupported
+func (client *BitbucketCloudClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return filterReviewComments(comments), nil
 }
 
 // ListPullRequestComments on Bitbucket cloud
@@ -445,156 +1313,862 @@ func (client *BitbucketCloudClient) ListPullRequestComments(ctx context.Context,
 	}
 	parsedComments, err := vcsutils.RemapFields[commentsResponse](comments, "json")
 	if err != nil {
-		return
+		return
+	}
+	return mapBitbucketCloudCommentToCommentInfo(&parsedComments), nil
+}
+
+// ListPullRequestCommentsWithOptions on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	query := url.Values{"page": {strconv.Itoa(page)}, "pagelen": {strconv.Itoa(perPage)}}
+	if !options.UpdatedAfter.IsZero() {
+		query.Set("q", "updated_on > "+options.UpdatedAfter.UTC().Format(time.RFC3339))
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments?%s", client.getApiEndpoint(), owner, repository, pullRequestID, query.Encode())
+	var comments commentsResponse
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &comments); err != nil {
+		return nil, err
+	}
+	return mapBitbucketCloudCommentToCommentInfo(&comments), nil
+}
+
+// DeletePullRequestReviewComments on Bitbucket cloud
+func (client *BitbucketCloudClient) DeletePullRequestReviewComments(_ context.Context, _, _ string, _ int, _ ...CommentInfo) error {
+	return errBitbucketDeletePullRequestReviewCommentsNotSupported
+}
+
+// UpdatePullRequestComment on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID, commentID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.PullRequestCommentOptions{
+		Owner:         owner,
+		RepoSlug:      repository,
+		PullRequestID: fmt.Sprint(pullRequestID),
+		Content:       content,
+		CommentId:     fmt.Sprint(commentID),
+	}
+	_, err = bitbucketClient.Repositories.PullRequests.UpdateComment(options)
+	return err
+}
+
+// DeletePullRequestComment on Bitbucket cloud
+func (client *BitbucketCloudClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments/%d", client.getApiEndpoint(), owner, repository, pullRequestID, commentID)
+	return client.sendRequestWithJsonBody(ctx, http.MethodDelete, u, nil, nil)
+}
+
+// SetPullRequestThreadStatus on Bitbucket cloud.
+// The thread is identified by the ID of its first comment.
+func (client *BitbucketCloudClient) SetPullRequestThreadStatus(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "thread ID": threadID}); err != nil {
+		return err
+	}
+	method := http.MethodPost
+	if !resolved {
+		method = http.MethodDelete
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments/%s/resolve", client.getApiEndpoint(), owner, repository, pullRequestID, url.PathEscape(threadID))
+	return client.sendRequestWithJsonBody(ctx, method, u, nil, nil)
+}
+
+// CreatePullRequestReview on Bitbucket cloud.
+// The body is added as a pull request comment.
+func (client *BitbucketCloudClient) CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       fmt.Sprint(pullRequestID),
+	}
+	switch verdict {
+	case vcsutils.ReviewVerdictApprove:
+		_, err = bitbucketClient.Repositories.PullRequests.Approve(options)
+	case vcsutils.ReviewVerdictRequestChanges:
+		_, err = bitbucketClient.Repositories.PullRequests.RequestChanges(options)
+	}
+	if err != nil || body == "" {
+		return err
+	}
+	return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+}
+
+type bitbucketCloudPullRequestParticipants struct {
+	Participants []struct {
+		User           user      `json:"user"`
+		State          string    `json:"state"`
+		ParticipatedOn time.Time `json:"participated_on"`
+	} `json:"participants"`
+}
+
+var bitbucketCloudParticipantStates = map[string]vcsutils.ReviewVerdict{
+	"approved":          vcsutils.ReviewVerdictApprove,
+	"changes_requested": vcsutils.ReviewVerdictRequestChanges,
+}
+
+// ListPullRequestReviews on Bitbucket cloud.
+// The participants who approved or requested changes are returned.
+func (client *BitbucketCloudClient) ListPullRequestReviews(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d", client.getApiEndpoint(), owner, repository, pullRequestID)
+	var pullRequest bitbucketCloudPullRequestParticipants
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &pullRequest); err != nil {
+		return nil, err
+	}
+	var reviews []PullRequestReviewInfo
+	for _, participant := range pullRequest.Participants {
+		state, ok := bitbucketCloudParticipantStates[participant.State]
+		if !ok {
+			continue
+		}
+		reviews = append(reviews, PullRequestReviewInfo{
+			Reviewer:  participant.User.DisplayName,
+			State:     state,
+			Submitted: participant.ParticipatedOn,
+		})
+	}
+	return reviews, nil
+}
+
+// GetLatestCommit on Bitbucket cloud
+func (client *BitbucketCloudClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     branch,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	bitbucketClient.Pagelen = 1
+	options := &bitbucket.CommitsOptions{
+		Owner:       owner,
+		RepoSlug:    repository,
+		Branchortag: branch,
+	}
+	commits, err := bitbucketClient.Repositories.Commits.GetCommits(options)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	parsedCommits, err := vcsutils.RemapFields[commitResponse](commits, "json")
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if len(parsedCommits.Values) > 0 {
+		latestCommit := parsedCommits.Values[0]
+		return mapBitbucketCloudCommitToCommitInfo(latestCommit), nil
+	}
+	return CommitInfo{}, nil
+}
+
+// GetCommits on Bitbucket Cloud
+func (client *BitbucketCloudClient) GetCommits(_ context.Context, _, _, _ string) ([]CommitInfo, error) {
+	return nil, errBitbucketGetCommitsNotSupported
+}
+
+// ListCommits on Bitbucket cloud
+func (client *BitbucketCloudClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     options.Branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The client library doesn't support filtering the commits by path, hence the commits are fetched directly
+	page, perPage := options.getPagination()
+	query := url.Values{"page": {strconv.Itoa(page)}, "pagelen": {strconv.Itoa(perPage)}}
+	if options.Path != "" {
+		query.Set("path", options.Path)
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/commits/%s?%s", client.getApiEndpoint(), owner, repository, url.PathEscape(options.Branch), query.Encode())
+	var commits commitResponse
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &commits); err != nil {
+		return nil, err
+	}
+
+	// The API doesn't support filtering by time or author, hence the commits of the page are filtered here
+	var commitsInfo []CommitInfo
+	for _, commit := range commits.Values {
+		commitInfo := mapBitbucketCloudCommitToCommitInfo(commit)
+		if options.matches(commitInfo, commit.Date) {
+			commitsInfo = append(commitsInfo, commitInfo)
+		}
+	}
+	return commitsInfo, nil
+}
+
+// GetRepositoryInfo on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapBitbucketCloudRepositoryToRepositoryInfo(repo)
+}
+
+func mapBitbucketCloudRepositoryToRepositoryInfo(repo *bitbucket.Repository) (RepositoryInfo, error) {
+	holder := struct {
+		Clone []struct {
+			Name string `mapstructure:"name"`
+			HRef string `mapstructure:"href"`
+		} `mapstructure:"clone"`
+	}{}
+
+	if err := mapstructure.Decode(repo.Links, &holder); err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	var info CloneInfo
+	for _, link := range holder.Clone {
+		switch strings.ToLower(link.Name) {
+		case "https":
+			info.HTTP = link.HRef
+		case "ssh":
+			info.SSH = link.HRef
+		}
+	}
+	return RepositoryInfo{
+		RepositoryVisibility: getBitbucketCloudRepositoryVisibility(repo),
+		CloneInfo:            info,
+		DefaultBranch:        repo.Mainbranch.Name,
+		Description:          repo.Description,
+	}, nil
+}
+
+// CreateRepository on Bitbucket cloud.
+// The repository is created empty, hence its default branch is the first branch pushed to it.
+func (client *BitbucketCloudClient) CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	if options.InitReadme {
+		return RepositoryInfo{}, errBitbucketInitReadmeNotSupported
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	repo, err := bitbucketClient.Repositories.Repository.Create(&bitbucket.RepositoryOptions{
+		Owner:       owner,
+		RepoSlug:    repository,
+		Scm:         "git",
+		IsPrivate:   strconv.FormatBool(options.Private),
+		Description: options.Description,
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapBitbucketCloudRepositoryToRepositoryInfo(repo)
+}
+
+// DeleteRepository on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	_, err := bitbucketClient.Repositories.Repository.Delete(&bitbucket.RepositoryOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+	})
+	return err
+}
+
+// ForkRepository on Bitbucket cloud.
+// The target owner is a workspace. The repository is forked to the workspace of the authenticated user if it's empty.
+func (client *BitbucketCloudClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	fork, err := bitbucketClient.Repositories.Repository.Fork(&bitbucket.RepositoryForkOptions{
+		FromOwner: owner,
+		FromSlug:  repository,
+		Owner:     targetOwner,
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapBitbucketCloudRepositoryToRepositoryInfo(fork)
+}
+
+// ListRepositoryCollaborators on Bitbucket cloud.
+// The collaborators are the users granted a permission on the repository explicitly. The username of a collaborator is its nickname.
+func (client *BitbucketCloudClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	// Repository permissions aren't supported by the client library, hence they are fetched directly
+	u := fmt.Sprintf("%s/workspaces/%s/permissions/repositories/%s?pagelen=100", client.getApiEndpoint(), owner, repository)
+	var results []CollaboratorInfo
+	for u != "" {
+		var userPermissions struct {
+			Values []struct {
+				Permission string `json:"permission"`
+				User       struct {
+					Nickname string `json:"nickname"`
+				} `json:"user"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+		if err := client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &userPermissions); err != nil {
+			return nil, err
+		}
+		for _, userPermission := range userPermissions.Values {
+			results = append(results, CollaboratorInfo{
+				Username:   userPermission.User.Nickname,
+				Permission: mapBitbucketCloudPermissionToRepositoryPermission(userPermission.Permission),
+			})
+		}
+		u = userPermissions.Next
+	}
+	return results, nil
+}
+
+// GetRepositoryPermission on Bitbucket cloud.
+// Only the permissions granted to the user on the repository explicitly are considered.
+func (client *BitbucketCloudClient) GetRepositoryPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username}); err != nil {
+		return NoPermission, err
+	}
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repository)
+	if err != nil {
+		return NoPermission, err
+	}
+	return getBitbucketCollaboratorPermission(collaborators, username), nil
+}
+
+func mapBitbucketCloudPermissionToRepositoryPermission(permission string) RepositoryPermission {
+	switch permission {
+	case "admin":
+		return AdminPermission
+	case "write":
+		return WritePermission
+	case "read":
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// GetCommitBySha on Bitbucket cloud
+func (client *BitbucketCloudClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	options := &bitbucket.CommitsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		Revision: sha,
+	}
+	commit, err := bitbucketClient.Repositories.Commits.GetCommit(options)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	parsedCommit, err := vcsutils.RemapFields[commitDetails](commit, "json")
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapBitbucketCloudCommitToCommitInfo(parsedCommit), nil
+}
+
+// CreateLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	return errLabelsNotSupported
+}
+
+// GetLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	return nil, errLabelsNotSupported
+}
+
+// ListRepositoryLabels on Bitbucket cloud
+func (client *BitbucketCloudClient) ListRepositoryLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return nil, errLabelsNotSupported
+}
+
+// UpdateLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	return errLabelsNotSupported
+}
+
+// DeleteLabel on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	return errLabelsNotSupported
+}
+
+// ListPullRequestLabels on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	return nil, errLabelsNotSupported
+}
+
+// LabelPullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) LabelPullRequest(ctx context.Context, owner, repository string, pullRequestID int, labels []string) error {
+	return errLabelsNotSupported
+}
+
+// UnlabelPullRequest on Bitbucket cloud
+func (client *BitbucketCloudClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	return errLabelsNotSupported
+}
+
+// CreateIssue on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	if err = client.validateIssueTrackerEnabled(ctx, owner, repository); err != nil {
+		return IssueInfo{}, err
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/issues", client.getApiEndpoint(), owner, repository)
+	request := bitbucketCloudIssueRequest{Title: title, Content: &commentContent{Raw: body}}
+	var issue bitbucketCloudIssue
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodPost, u, request, &issue); err != nil {
+		return IssueInfo{}, err
+	}
+	return mapBitbucketCloudIssueToIssueInfo(issue), nil
+}
+
+var bitbucketCloudIssueStates = map[vcsutils.IssueState][]string{
+	vcsutils.IssueOpen:   {"new", "open", "on hold"},
+	vcsutils.IssueClosed: {"resolved", "closed", "invalid", "duplicate", "wontfix"},
+}
+
+// ListIssues on Bitbucket cloud
+func (client *BitbucketCloudClient) ListIssues(ctx context.Context, owner, repository string, options ListIssuesOptions) ([]IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	if err = validateIssueState(options.State); err != nil {
+		return nil, err
+	}
+	if len(options.Labels) > 0 {
+		return nil, errLabelsNotSupported
+	}
+	if err = client.validateIssueTrackerEnabled(ctx, owner, repository); err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	query := url.Values{"page": {strconv.Itoa(page)}, "pagelen": {strconv.Itoa(perPage)}}
+	if options.State != "" {
+		var filters []string
+		for _, state := range bitbucketCloudIssueStates[options.State] {
+			filters = append(filters, fmt.Sprintf("state=%q", state))
+		}
+		query.Set("q", strings.Join(filters, " OR "))
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/issues?%s", client.getApiEndpoint(), owner, repository, query.Encode())
+	var issues struct {
+		Values []bitbucketCloudIssue `json:"values"`
+	}
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &issues); err != nil {
+		return nil, err
+	}
+
+	// The API can't filter by the author display name, hence the issues of the page are filtered here
+	var results []IssueInfo
+	for _, issue := range issues.Values {
+		if options.Author == "" || options.Author == issue.Reporter.DisplayName {
+			results = append(results, mapBitbucketCloudIssueToIssueInfo(issue))
+		}
+	}
+	return results, nil
+}
+
+// AddIssueComment on Bitbucket cloud
+func (client *BitbucketCloudClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	if err = client.validateIssueTrackerEnabled(ctx, owner, repository); err != nil {
+		return err
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/issues/%d/comments", client.getApiEndpoint(), owner, repository, issueID)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, u, bitbucketCloudIssueRequest{Content: &commentContent{Raw: content}}, nil)
+}
+
+// CloseIssue on Bitbucket cloud.
+// The issue is closed by resolving it.
+func (client *BitbucketCloudClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = client.validateIssueTrackerEnabled(ctx, owner, repository); err != nil {
+		return err
+	}
+	u := fmt.Sprintf("%s/repositories/%s/%s/issues/%d", client.getApiEndpoint(), owner, repository, issueID)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPut, u, bitbucketCloudIssueRequest{State: "resolved"}, nil)
+}
+
+// validateIssueTrackerEnabled returns an error if the issue tracker of the repository is disabled
+func (client *BitbucketCloudClient) validateIssueTrackerEnabled(ctx context.Context, owner, repository string) error {
+	u := fmt.Sprintf("%s/repositories/%s/%s", client.getApiEndpoint(), owner, repository)
+	var repositoryDetails struct {
+		HasIssues bool `json:"has_issues"`
+	}
+	if err := client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &repositoryDetails); err != nil {
+		return err
+	}
+	if !repositoryDetails.HasIssues {
+		return errBitbucketCloudIssueTrackerDisabled
+	}
+	return nil
+}
+
+type bitbucketCloudIssueRequest struct {
+	Title   string          `json:"title,omitempty"`
+	Content *commentContent `json:"content,omitempty"`
+	State   string          `json:"state,omitempty"`
+}
+
+type bitbucketCloudIssue struct {
+	ID       int64          `json:"id"`
+	Title    string         `json:"title"`
+	Content  commentContent `json:"content"`
+	State    string         `json:"state"`
+	Reporter user           `json:"reporter"`
+	Links    struct {
+		Html link `json:"html"`
+	} `json:"links"`
+}
+
+func mapBitbucketCloudIssueToIssueInfo(issue bitbucketCloudIssue) IssueInfo {
+	issueInfo := IssueInfo{
+		ID:     issue.ID,
+		Title:  issue.Title,
+		Body:   issue.Content.Raw,
+		URL:    issue.Links.Html.Href,
+		Author: issue.Reporter.DisplayName,
+		State:  vcsutils.IssueClosed,
+	}
+	if slices.Contains(bitbucketCloudIssueStates[vcsutils.IssueOpen], issue.State) {
+		issueInfo.State = vcsutils.IssueOpen
+	}
+	return issueInfo
+}
+
+// UploadCodeScanning on Bitbucket cloud.
+// The analysis is published as a Code Insights report on the latest commit of the branch, with an annotation for each finding.
+// The returned ID is the key of the report.
+func (client *BitbucketCloudClient) UploadCodeScanning(ctx context.Context, owner string, repository string, branch string, scanResults string) (string, error) {
+	analysis, err := parseCodeScanningAnalysis(scanResults)
+	if err != nil {
+		return "", err
+	}
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", err
 	}
-	return mapBitbucketCloudCommentToCommentInfo(&parsedComments), nil
-}
+	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
 
-// DeletePullRequestReviewComments on Bitbucket cloud
-func (client *BitbucketCloudClient) DeletePullRequestReviewComments(_ context.Context, _, _ string, _ int, _ ...CommentInfo) error {
-	return errBitbucketDeletePullRequestComment
-}
+	report := CodeInsightsReport{
+		Key:      codeScanningReportKey,
+		Title:    analysis.ToolName,
+		Details:  analysis.getDetails(),
+		Result:   CodeInsightsPass,
+		Reporter: analysis.ToolName,
+	}
+	if len(analysis.Findings) > 0 {
+		report.Result = CodeInsightsFail
+	}
+	if err = client.CreateCodeInsightsReport(ctx, owner, repository, commit.Hash, report); err != nil {
+		return "", err
+	}
 
-// DeletePullRequestComment on Bitbucket cloud
-func (client *BitbucketCloudClient) DeletePullRequestComment(_ context.Context, _, _ string, _, _ int) error {
-	return errBitbucketDeletePullRequestComment
+	var annotations []CodeInsightsAnnotation
+	for _, finding := range analysis.Findings {
+		if len(annotations) == maxCodeInsightsAnnotations {
+			client.logger.Warn(fmt.Sprintf("Code Insights reports are limited to %d annotations, the rest of the findings are omitted", maxCodeInsightsAnnotations))
+			break
+		}
+		annotations = append(annotations, CodeInsightsAnnotation{
+			Path:     finding.FilePath,
+			Line:     finding.Line,
+			Message:  finding.getSummary(),
+			Severity: CodeInsightsSeverity(finding.Severity),
+			Type:     CodeInsightsVulnerability,
+		})
+	}
+	return report.Key, client.AddCodeInsightsAnnotations(ctx, owner, repository, commit.Hash, report.Key, annotations)
 }
 
-// GetLatestCommit on Bitbucket cloud
-func (client *BitbucketCloudClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"branch":     branch,
-	})
+// CreateCodeInsightsReport on Bitbucket cloud.
+// The report is created as a security report.
+func (client *BitbucketCloudClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, commitHash string, report CodeInsightsReport) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "commit hash": commitHash, "report key": report.Key, "report title": report.Title})
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	bitbucketClient.Pagelen = 1
-	options := &bitbucket.CommitsOptions{
-		Owner:       owner,
-		RepoSlug:    repository,
-		Branchortag: branch,
+	body := bitbucketCloudCodeInsightsReport{
+		Title:      report.Title,
+		Details:    report.Details,
+		ReportType: "SECURITY",
+		Reporter:   report.Reporter,
+		Link:       report.Link,
 	}
-	commits, err := bitbucketClient.Repositories.Commits.GetCommits(options)
-	if err != nil {
-		return CommitInfo{}, err
+	switch report.Result {
+	case CodeInsightsPass:
+		body.Result = "PASSED"
+	case CodeInsightsFail:
+		body.Result = "FAILED"
 	}
-	parsedCommits, err := vcsutils.RemapFields[commitResponse](commits, "json")
+	return client.sendRequestWithJsonBody(ctx, http.MethodPut, client.getCodeInsightsReportUrl(owner, repository, commitHash, report.Key), body, nil)
+}
+
+// AddCodeInsightsAnnotations on Bitbucket cloud.
+// The annotations are added in batches, and messages longer than the limit of Bitbucket are truncated.
+func (client *BitbucketCloudClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, commitHash, reportKey string, annotations []CodeInsightsAnnotation) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "commit hash": commitHash, "report key": reportKey})
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
-	if len(parsedCommits.Values) > 0 {
-		latestCommit := parsedCommits.Values[0]
-		return mapBitbucketCloudCommitToCommitInfo(latestCommit), nil
+	if len(annotations) > maxCodeInsightsAnnotations {
+		return fmt.Errorf("a Code Insights report can have up to %d annotations, got %d", maxCodeInsightsAnnotations, len(annotations))
+	}
+	body := make([]bitbucketCloudCodeInsightsAnnotation, 0, len(annotations))
+	for i, annotation := range annotations {
+		body = append(body, bitbucketCloudCodeInsightsAnnotation{
+			ExternalId:     fmt.Sprintf("%s-%d", reportKey, i+1),
+			AnnotationType: string(annotation.Type),
+			Path:           annotation.Path,
+			Line:           annotation.Line,
+			Summary:        truncateCodeScanningMessage(annotation.Message, bitbucketCloudAnnotationSummaryLimit),
+			Severity:       string(annotation.Severity),
+			Link:           annotation.Link,
+		})
+	}
+	annotationsUrl := client.getCodeInsightsReportUrl(owner, repository, commitHash, reportKey) + "/annotations"
+	for start := 0; start < len(body); start += bitbucketCloudAnnotationsBatchSize {
+		end := start + bitbucketCloudAnnotationsBatchSize
+		if end > len(body) {
+			end = len(body)
+		}
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodPost, annotationsUrl, body[start:end], nil); err != nil {
+			return err
+		}
 	}
-	return CommitInfo{}, nil
+	return nil
 }
 
-// GetCommits on Bitbucket Cloud
-func (client *BitbucketCloudClient) GetCommits(_ context.Context, _, _, _ string) ([]CommitInfo, error) {
-	return nil, errBitbucketGetCommitsNotSupported
+// CreateCheckRun on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errBitbucketCheckRunsNotSupported
 }
 
-// GetRepositoryInfo on Bitbucket cloud
-func (client *BitbucketCloudClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
-	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
-		return RepositoryInfo{}, err
+// UpdateCheckRun on Bitbucket cloud
+func (client *BitbucketCloudClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errBitbucketCheckRunsNotSupported
+}
+
+func (client *BitbucketCloudClient) getCodeInsightsReportUrl(owner, repository, commitHash, reportKey string) string {
+	return fmt.Sprintf("%s/repositories/%s/%s/commit/%s/reports/%s", client.getApiEndpoint(), owner, repository, commitHash, url.PathEscape(reportKey))
+}
+
+const (
+	bitbucketCloudAnnotationsBatchSize   = 100
+	bitbucketCloudAnnotationSummaryLimit = 450
+)
+
+type bitbucketCloudCodeInsightsReport struct {
+	Title      string `json:"title"`
+	Details    string `json:"details,omitempty"`
+	ReportType string `json:"report_type"`
+	Reporter   string `json:"reporter,omitempty"`
+	Link       string `json:"link,omitempty"`
+	Result     string `json:"result,omitempty"`
+}
+
+type bitbucketCloudCodeInsightsAnnotation struct {
+	ExternalId     string `json:"external_id"`
+	AnnotationType string `json:"annotation_type"`
+	Path           string `json:"path,omitempty"`
+	Line           int    `json:"line,omitempty"`
+	Summary        string `json:"summary"`
+	Severity       string `json:"severity"`
+	Link           string `json:"link,omitempty"`
+}
+
+// DownloadFileFromRepo on Bitbucket cloud
+func (client *BitbucketCloudClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	return nil, 0, errBitbucketDownloadFileFromRepoNotSupported
+}
+
+// ListDirectoryContents on Bitbucket cloud
+func (client *BitbucketCloudClient) ListDirectoryContents(ctx context.Context, owner, repository, ref, path string) ([]DirectoryEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
 	}
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	repo, err := bitbucketClient.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+	files, err := bitbucketClient.Repositories.Repository.ListFiles(&bitbucket.RepositoryFilesOptions{
 		Owner:    owner,
 		RepoSlug: repository,
+		Ref:      ref,
+		Path:     path,
 	})
 	if err != nil {
-		return RepositoryInfo{}, err
-	}
-
-	holder := struct {
-		Clone []struct {
-			Name string `mapstructure:"name"`
-			HRef string `mapstructure:"href"`
-		} `mapstructure:"clone"`
-	}{}
-
-	if err := mapstructure.Decode(repo.Links, &holder); err != nil {
-		return RepositoryInfo{}, err
+		return nil, err
 	}
 
-	var info CloneInfo
-	for _, link := range holder.Clone {
-		switch strings.ToLower(link.Name) {
-		case "https":
-			info.HTTP = link.HRef
-		case "ssh":
-			info.SSH = link.HRef
-		}
+	entries := make([]DirectoryEntry, 0, len(files))
+	for _, file := range files {
+		entries = append(entries, DirectoryEntry{
+			Name:  file.Path[strings.LastIndex(file.Path, "/")+1:],
+			Path:  file.Path,
+			IsDir: file.Type == "commit_directory",
+		})
 	}
-	return RepositoryInfo{RepositoryVisibility: getBitbucketCloudRepositoryVisibility(repo), CloneInfo: info}, nil
+	return entries, nil
 }
 
-// GetCommitBySha on Bitbucket cloud
-func (client *BitbucketCloudClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"sha":        sha,
-	})
+// CommitFiles on Bitbucket cloud
+func (client *BitbucketCloudClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, files []FileToCommit) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "commitMessage": commitMessage})
 	if err != nil {
-		return CommitInfo{}, err
+		return err
+	}
+	if err = validateFilesToCommit(files); err != nil {
+		return err
 	}
 
-	bitbucketClient := client.buildBitbucketCloudClient(ctx)
-	options := &bitbucket.CommitsOptions{
-		Owner:    owner,
-		RepoSlug: repository,
-		Revision: sha,
+	// The src endpoint receives the content of every added or modified file as a form field named after the file path,
+	// and the paths of the deleted files as 'files' fields
+	body := new(bytes.Buffer)
+	formWriter := multipart.NewWriter(body)
+	if err = formWriter.WriteField("message", commitMessage); err != nil {
+		return err
 	}
-	commit, err := bitbucketClient.Repositories.Commits.GetCommit(options)
-	if err != nil {
-		return CommitInfo{}, err
+	if err = formWriter.WriteField("branch", branch); err != nil {
+		return err
 	}
-	parsedCommit, err := vcsutils.RemapFields[commitDetails](commit, "json")
-	if err != nil {
-		return CommitInfo{}, err
+	for _, file := range files {
+		if file.ChangeType == FileDeleted {
+			if err = formWriter.WriteField("files", file.Path); err != nil {
+				return err
+			}
+			continue
+		}
+		fileWriter, err := formWriter.CreateFormFile(file.Path, file.Path)
+		if err != nil {
+			return err
+		}
+		if _, err = fileWriter.Write(file.Content); err != nil {
+			return err
+		}
 	}
-	return mapBitbucketCloudCommitToCommitInfo(parsedCommit), nil
+	if err = formWriter.Close(); err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/repositories/%s/%s/src", client.getApiEndpoint(), owner, repository)
+	return client.sendRequest(ctx, http.MethodPost, u, body, formWriter.FormDataContentType(), nil)
 }
 
-// CreateLabel on Bitbucket cloud
-func (client *BitbucketCloudClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	return errLabelsNotSupported
+// GetRepositoryEnvironmentInfo on Bitbucket cloud
+func (client *BitbucketCloudClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
 }
 
-// GetLabel on Bitbucket cloud
-func (client *BitbucketCloudClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	return nil, errLabelsNotSupported
+// ListEnvironments on Bitbucket cloud
+func (client *BitbucketCloudClient) ListEnvironments(_ context.Context, _, _ string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errBitbucketDeploymentsNotSupported
 }
 
-// ListPullRequestLabels on Bitbucket cloud
-func (client *BitbucketCloudClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	return nil, errLabelsNotSupported
+// CreateDeployment on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateDeployment(_ context.Context, _, _, _, _, _ string) (DeploymentInfo, error) {
+	return DeploymentInfo{}, errBitbucketDeploymentsNotSupported
 }
 
-// UnlabelPullRequest on Bitbucket cloud
-func (client *BitbucketCloudClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	return errLabelsNotSupported
+// SetDeploymentStatus on Bitbucket cloud
+func (client *BitbucketCloudClient) SetDeploymentStatus(_ context.Context, _, _, _ string, _ DeploymentState, _, _ string) error {
+	return errBitbucketDeploymentsNotSupported
 }
 
-// UploadCodeScanning on Bitbucket cloud
-func (client *BitbucketCloudClient) UploadCodeScanning(ctx context.Context, owner string, repository string, branch string, scanResults string) (string, error) {
-	return "", errBitbucketCodeScanningNotSupported
+// CreateOrUpdateRepositorySecret on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateOrUpdateRepositorySecret(_ context.Context, _, _, _, _ string) error {
+	return errBitbucketRepositorySecretsNotSupported
 }
 
-// DownloadFileFromRepo on Bitbucket cloud
-func (client *BitbucketCloudClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
-	return nil, 0, errBitbucketDownloadFileFromRepoNotSupported
+// ListRepositoryVariables on Bitbucket cloud
+func (client *BitbucketCloudClient) ListRepositoryVariables(_ context.Context, _, _ string) ([]RepositoryVariableInfo, error) {
+	return nil, errBitbucketRepositorySecretsNotSupported
 }
 
-// GetRepositoryEnvironmentInfo on Bitbucket cloud
-func (client *BitbucketCloudClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
-	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
+// CreateOrUpdateOrganizationSecret on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return errBitbucketSecretsNotSupported
+}
+
+// ListOrganizationSecrets on Bitbucket cloud
+func (client *BitbucketCloudClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, errBitbucketSecretsNotSupported
+}
+
+// ListOrganizationSecretRepositories on Bitbucket cloud
+func (client *BitbucketCloudClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, errBitbucketSecretsNotSupported
+}
+
+// DeleteOrganizationSecret on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return errBitbucketSecretsNotSupported
+}
+
+// CreateOrUpdateEnvironmentSecret on Bitbucket cloud
+func (client *BitbucketCloudClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return errBitbucketSecretsNotSupported
+}
+
+// ListEnvironmentSecrets on Bitbucket cloud
+func (client *BitbucketCloudClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, errBitbucketSecretsNotSupported
+}
+
+// DeleteEnvironmentSecret on Bitbucket cloud
+func (client *BitbucketCloudClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return errBitbucketSecretsNotSupported
 }
 
 func (client *BitbucketCloudClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
@@ -608,6 +2182,69 @@ func (client *BitbucketCloudClient) GetModifiedFiles(ctx context.Context, owner,
 		return nil, err
 	}
 
+	diffStats, err := client.getDiffStats(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	fileNamesSet := datastructures.MakeSet[string]()
+	for _, diffStat := range diffStats {
+		if path, ok := diffStat.New["path"].(string); ok {
+			fileNamesSet.Add(path)
+		}
+		if path, ok := diffStat.Old["path"].(string); ok {
+			fileNamesSet.Add(path)
+		}
+	}
+	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
+	fileNamesList := fileNamesSet.ToSlice()
+	sort.Strings(fileNamesList)
+	return fileNamesList, nil
+}
+
+// CompareCommits on Bitbucket cloud
+func (client *BitbucketCloudClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	commits, err := bitbucketClient.Repositories.Commits.GetCommits(&bitbucket.CommitsOptions{
+		Owner:       owner,
+		RepoSlug:    repository,
+		Branchortag: head,
+		Exclude:     base,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	parsedCommits, err := vcsutils.RemapFields[commitResponse](commits, "json")
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	diffStats, err := client.getDiffStats(ctx, owner, repository, base, head)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+
+	var commitsComparison CommitsComparison
+	for _, commit := range parsedCommits.Values {
+		commitsComparison.Commits = append(commitsComparison.Commits, mapBitbucketCloudCommitToCommitInfo(commit))
+	}
+	for _, diffStat := range diffStats {
+		commitsComparison.Files = append(commitsComparison.Files, mapBitbucketCloudDiffStatToPullRequestFile(diffStat))
+	}
+	return commitsComparison, nil
+}
+
+// Returns the files changed between the common ancestor of the references and refAfter
+func (client *BitbucketCloudClient) getDiffStats(ctx context.Context, owner, repository, refBefore, refAfter string) ([]*bitbucket.DiffStat, error) {
 	bitbucketClient := client.buildBitbucketCloudClient(ctx)
 	options := &bitbucket.DiffStatOptions{
 		Owner:    owner,
@@ -620,7 +2257,7 @@ func (client *BitbucketCloudClient) GetModifiedFiles(ctx context.Context, owner,
 		Merge:   true,
 	}
 
-	fileNamesSet := datastructures.MakeSet[string]()
+	var diffStats []*bitbucket.DiffStat
 	nextPage := 1
 
 	for nextPage > 0 {
@@ -635,31 +2272,94 @@ func (client *BitbucketCloudClient) GetModifiedFiles(ctx context.Context, owner,
 		} else {
 			nextPage++
 		}
+		diffStats = append(diffStats, diffStatRes.DiffStats...)
+	}
+	return diffStats, nil
+}
+
+// ListPullRequestFiles on Bitbucket cloud
+func (client *BitbucketCloudClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
 
+	// The client library doesn't support the diffstat of pull requests, hence it is fetched directly
+	var files []PullRequestFile
+	u := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/diffstat", client.getApiEndpoint(), owner, repository, pullRequestID)
+	for u != "" {
+		var diffStatRes bitbucket.DiffStatRes
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, u, nil, &diffStatRes); err != nil {
+			return nil, err
+		}
 		for _, diffStat := range diffStatRes.DiffStats {
-			if path, ok := diffStat.New["path"].(string); ok {
-				fileNamesSet.Add(path)
-			}
-			if path, ok := diffStat.Old["path"].(string); ok {
-				fileNamesSet.Add(path)
-			}
+			files = append(files, mapBitbucketCloudDiffStatToPullRequestFile(diffStat))
 		}
+		u = diffStatRes.Next
+	}
+	return files, nil
+}
+
+// GetPullRequestDiff on Bitbucket cloud
+func (client *BitbucketCloudClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (diff string, err error) {
+	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return
+	}
+
+	bitbucketClient := client.buildBitbucketCloudClient(ctx)
+	response, err := bitbucketClient.Repositories.PullRequests.Diff(&bitbucket.PullRequestsOptions{
+		Owner:    owner,
+		RepoSlug: repository,
+		ID:       strconv.Itoa(pullRequestID),
+	})
+	if err != nil {
+		return
+	}
+	diffReader, ok := response.(io.ReadCloser)
+	if !ok {
+		return "", fmt.Errorf("unexpected response type %T", response)
+	}
+	defer func() {
+		err = errors.Join(err, diffReader.Close())
+	}()
+	diffBytes, err := io.ReadAll(diffReader)
+	return string(diffBytes), err
+}
+
+func mapBitbucketCloudDiffStatToPullRequestFile(diffStat *bitbucket.DiffStat) PullRequestFile {
+	newPath, _ := diffStat.New["path"].(string)
+	oldPath, _ := diffStat.Old["path"].(string)
+	switch diffStat.Status {
+	case "added":
+		return PullRequestFile{Path: newPath, ChangeType: FileAdded}
+	case "removed":
+		return PullRequestFile{Path: oldPath, ChangeType: FileDeleted}
+	case "renamed":
+		return PullRequestFile{Path: newPath, PreviousPath: oldPath, ChangeType: FileRenamed}
+	default:
+		return PullRequestFile{Path: newPath, ChangeType: FileModified}
 	}
-	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
-	fileNamesList := fileNamesSet.ToSlice()
-	sort.Strings(fileNamesList)
-	return fileNamesList, nil
 }
 
 type pullRequestsResponse struct {
 	Values []pullRequestsDetails `json:"values"`
+	Next   string                `json:"next"`
 }
 
 type pullRequestsDetails struct {
-	ID     int64             `json:"id"`
-	Body   string            `json:"description"`
-	Source pullRequestBranch `json:"source"`
-	Target pullRequestBranch `json:"destination"`
+	ID        int64             `json:"id"`
+	Title     string            `json:"title"`
+	Body      string            `json:"description"`
+	State     string            `json:"state"`
+	Author    user              `json:"author"`
+	Source    pullRequestBranch `json:"source"`
+	Target    pullRequestBranch `json:"destination"`
+	CreatedOn time.Time         `json:"created_on"`
+	UpdatedOn time.Time         `json:"updated_on"`
+	Links     struct {
+		Html link `json:"html"`
+	} `json:"links"`
 }
 
 type pullRequestBranch struct {
@@ -683,6 +2383,18 @@ type commentDetails struct {
 	IsDeleted bool           `json:"deleted"`
 	Content   commentContent `json:"content"`
 	Created   time.Time      `json:"created_on"`
+	Updated   time.Time      `json:"updated_on"`
+	Inline    *commentInline `json:"inline,omitempty"`
+	Parent    *struct {
+		ID int64 `json:"id"`
+	} `json:"parent,omitempty"`
+}
+
+type commentInline struct {
+	Path string `json:"path"`
+	// From is the line in the original file, and To is the line in the new file
+	From int `json:"from,omitempty"`
+	To   int `json:"to,omitempty"`
 }
 
 type commentContent struct {
@@ -710,6 +2422,7 @@ type commitDetails struct {
 
 type user struct {
 	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
 }
 type link struct {
 	Href string `json:"href"`
@@ -729,6 +2442,23 @@ func getBitbucketCloudWebhookID(r interface{}) (string, error) {
 	return strings.TrimRight(strings.TrimLeft(webhook.Uuid, "{"), "}"), nil
 }
 
+// The token is removed from the URL of the webhook, to return the payload URL passed to CreateWebhook
+func mapBitbucketCloudWebhookToWebhookInfo(webhook *bitbucket.Webhook) WebhookInfo {
+	payloadURL := webhook.Url
+	if parsedURL, err := url.Parse(webhook.Url); err == nil {
+		query := parsedURL.Query()
+		query.Del("token")
+		parsedURL.RawQuery = query.Encode()
+		payloadURL = parsedURL.String()
+	}
+	return WebhookInfo{
+		ID:         strings.TrimRight(strings.TrimLeft(webhook.Uuid, "{"), "}"),
+		PayloadURL: payloadURL,
+		Events:     getSubscribedWebhookEvents(webhook.Events, getBitbucketCloudWebhookEvents),
+		Active:     webhook.Active,
+	}
+}
+
 // Get varargs of webhook events and return a slice of Bitbucket cloud webhook events
 func getBitbucketCloudWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
 	events := datastructures.MakeSet[string]()
@@ -742,7 +2472,13 @@ func getBitbucketCloudWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []st
 			events.Add("pullrequest:rejected")
 		case vcsutils.PrMerged:
 			events.Add("pullrequest:fulfilled")
-		case vcsutils.Push, vcsutils.TagPushed, vcsutils.TagRemoved:
+		case vcsutils.PrCommentCreated:
+			events.Add("pullrequest:comment_created")
+		case vcsutils.PrCommentEdited:
+			events.Add("pullrequest:comment_updated")
+		case vcsutils.IssueCommentCreated:
+			events.Add("issue:comment_created")
+		case vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved:
 			events.Add("repo:push")
 		}
 	}
@@ -750,7 +2486,7 @@ func getBitbucketCloudWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []st
 }
 
 // The get repository request returns HTTP link to the repository - extract the link from the response.
-func getDownloadLink(repo *bitbucket.Repository, branch string) (string, error) {
+func getDownloadLink(repo *bitbucket.Repository, branch string, format vcsutils.ArchiveFormat) (string, error) {
 	repositoryHTMLLinks := &link{}
 	b, err := json.Marshal(repo.Links["html"])
 	if err != nil {
@@ -764,7 +2500,7 @@ func getDownloadLink(repo *bitbucket.Repository, branch string) (string, error)
 	if htmlLink == "" {
 		return "", fmt.Errorf("couldn't find repository HTML link: %s", repo.Links["html"])
 	}
-	return htmlLink + "/get/" + branch + ".tar.gz", err
+	return htmlLink + "/get/" + branch + "." + string(format), err
 }
 
 func mapBitbucketCloudCommitToCommitInfo(parsedCommit commitDetails) CommitInfo {
@@ -787,14 +2523,54 @@ func mapBitbucketCloudCommentToCommentInfo(parsedComments *commentsResponse) []C
 	comments := make([]CommentInfo, len(parsedComments.Values))
 	for i, comment := range parsedComments.Values {
 		comments[i] = CommentInfo{
-			ID:      comment.ID,
-			Content: comment.Content.Raw,
-			Created: comment.Created,
+			ID:             comment.ID,
+			NativeID:       strconv.FormatInt(comment.ID, 10),
+			Content:        comment.Content.Raw,
+			Author:         comment.User.DisplayName,
+			AuthorUsername: comment.User.Nickname,
+			Created:        comment.Created,
+			Updated:        comment.Updated,
+		}
+		if comment.Parent != nil {
+			comments[i].ParentID = strconv.FormatInt(comment.Parent.ID, 10)
+		}
+		if comment.Inline != nil {
+			comments[i].FilePath = comment.Inline.Path
+			comments[i].Line = comment.Inline.To
+			if comments[i].Line == 0 {
+				comments[i].Line = comment.Inline.From
+			}
 		}
 	}
 	return comments
 }
 
+func mapBitbucketCloudPullRequestDetailsToPullRequestInfo(pullRequestDetails pullRequestsDetails) PullRequestInfo {
+	sourceOwner, sourceRepository := splitBitbucketCloudRepoName(pullRequestDetails.Source.Repository.Name)
+	targetOwner, targetRepository := splitBitbucketCloudRepoName(pullRequestDetails.Target.Repository.Name)
+
+	return PullRequestInfo{
+		ID:        pullRequestDetails.ID,
+		Title:     pullRequestDetails.Title,
+		Body:      pullRequestDetails.Body,
+		URL:       pullRequestDetails.Links.Html.Href,
+		Author:    pullRequestDetails.Author.Nickname,
+		State:     mapBitbucketPullRequestState(pullRequestDetails.State),
+		CreatedAt: pullRequestDetails.CreatedOn,
+		UpdatedAt: pullRequestDetails.UpdatedOn,
+		Source: BranchInfo{
+			Name:       pullRequestDetails.Source.Name.Str,
+			Repository: sourceRepository,
+			Owner:      sourceOwner,
+		},
+		Target: BranchInfo{
+			Name:       pullRequestDetails.Target.Name.Str,
+			Repository: targetRepository,
+			Owner:      targetOwner,
+		},
+	}
+}
+
 func mapBitbucketCloudPullRequestToPullRequestInfo(parsedPullRequests *pullRequestsResponse, withBody bool) []PullRequestInfo {
 	pullRequests := make([]PullRequestInfo, len(parsedPullRequests.Values))
 	for i, pullRequest := range parsedPullRequests.Values {
@@ -803,8 +2579,14 @@ func mapBitbucketCloudPullRequestToPullRequestInfo(parsedPullRequests *pullReque
 			body = pullRequest.Body
 		}
 		pullRequests[i] = PullRequestInfo{
-			ID:   pullRequest.ID,
-			Body: body,
+			ID:        pullRequest.ID,
+			Title:     pullRequest.Title,
+			Body:      body,
+			URL:       pullRequest.Links.Html.Href,
+			Author:    pullRequest.Author.Nickname,
+			State:     mapBitbucketPullRequestState(pullRequest.State),
+			CreatedAt: pullRequest.CreatedOn,
+			UpdatedAt: pullRequest.UpdatedOn,
 			Source: BranchInfo{
 				Name:       pullRequest.Source.Name.Str,
 				Repository: pullRequest.Source.Repository.Name,
@@ -834,3 +2616,16 @@ func splitBitbucketCloudRepoName(name string) (string, string) {
 	}
 	return split[0], split[1]
 }
+
+func getBitbucketCloudMergeStrategy(mergeMethod vcsutils.MergeMethod) (string, error) {
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge:
+		return "merge_commit", nil
+	case vcsutils.MergeMethodSquash:
+		return "squash", nil
+	case vcsutils.MergeMethodRebase:
+		return "rebase_fast_forward", nil
+	default:
+		return "", getUnsupportedMergeMethodError(mergeMethod)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -51,6 +54,26 @@ func TestBitbucketCloud_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestBitbucketCloud_TestConnectionWithScopes(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucket.User{"values": {}}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse, "/user", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.TestConnectionWithScopes(ctx))
+	assert.ErrorIs(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope), errBitbucketTokenScopesNotSupported)
+}
+
+func TestBitbucketCloud_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createAuthenticatedUserBitbucketCloudHandler)
+	defer cleanUp()
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: "frogger", DisplayName: "Frog Ger", Email: "frogger@jfrog.com"}, user)
+}
+
 func TestBitbucketCloud_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucket.Repository{
@@ -64,12 +87,25 @@ func TestBitbucketCloud_ListRepositories(t *testing.T) {
 	assert.Equal(t, map[string][]string{username: {repo1, repo2}}, actualRepositories)
 }
 
+func TestBitbucketCloud_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucket.Repository{
+		"values": {{Slug: repo2}, {Slug: repo1}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse, "/repositories/"+username, createBitbucketCloudHandler)
+	defer cleanUp()
+
+	actualRepositories, err := client.ListRepositoriesWithOptions(ctx, ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{username: {repo2}}, actualRepositories)
+}
+
 func TestBitbucketCloud_ListBranches(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucket.BranchModel{
 		"values": {{Name: branch1}, {Name: branch2}},
 	}
-	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse, "/repositories/jfrog/repo-1/refs/branches?", createBitbucketCloudHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse, "/repositories/jfrog/repo-1/refs/branches?pagelen=100", createBitbucketCloudHandler)
 	defer cleanUp()
 
 	actualRepositories, err := client.ListBranches(ctx, owner, repo1)
@@ -77,6 +113,201 @@ func TestBitbucketCloud_ListBranches(t *testing.T) {
 	assert.ElementsMatch(t, actualRepositories, []string{branch1, branch2})
 }
 
+func TestBitbucketCloud_ListBranchesAllPages(t *testing.T) {
+	ctx := context.Background()
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		var response string
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/refs/branches?pagelen=100":
+			response = `{"values": [{"name": "branch-1"}], "next": "` + serverURL + `/repositories/jfrog/repo-1/refs/branches?pagelen=100&page=2"}`
+		case "/repositories/jfrog/repo-1/refs/branches?pagelen=100&page=2":
+			response = `{"values": [{"name": "branch-2"}]}`
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	actualBranches, err := buildClient(t, vcsutils.BitbucketCloud, true, server).ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch1, branch2}, actualBranches)
+}
+
+func TestBitbucketCloud_ListBranchesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucket.BranchModel{
+		"values": {{Name: branch2}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, mockResponse, "/repositories/jfrog/repo-1/refs/branches?page=2&pagelen=1", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch2}, actualBranches)
+}
+
+func TestBitbucketCloud_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, bitbucket.RepositoryBranch{Name: "new-branch"}, "/repositories/jfrog/repo-1/refs/branches", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "new-branch")
+	assert.NoError(t, err)
+
+	err = client.CreateBranch(ctx, owner, repo1, branch1, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'newBranch' is missing")
+}
+
+func TestBitbucketCloud_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/jfrog/repo-1/refs/branches/branch-1", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "repository_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "master", defaultBranch)
+}
+
+func TestBitbucketCloud_SetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"mainbranch":{"name":"develop"}}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1", http.StatusOK, expectedBody, http.MethodPut, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetDefaultBranch(ctx, owner, repo1, "develop")
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloud_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createBranchProtectionBitbucketCloudHandler)
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{RequiredApprovals: 2, RestrictPushes: true}, protection)
+}
+
+func TestBitbucketCloud_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createBranchProtectionBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredApprovals: 1})
+	assert.NoError(t, err)
+
+	err = client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredStatusChecks: []string{"frogbot"}})
+	assert.ErrorIs(t, err, errBitbucketRequiredStatusChecksNotSupported)
+}
+
+func TestBitbucketCloud_ListTags(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values": [{"type": "tag", "name": "v1.0.0", "target": {"hash": "f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/refs/tags?", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitSha: "f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"}}, tags)
+}
+
+func TestBitbucketCloud_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"name":"v1.0.0","message":"Release 1.0.0","target":{"hash":"branch-1"}}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/refs/tags", http.StatusCreated, expectedBody, http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", branch1, "Release 1.0.0")
+	assert.NoError(t, err)
+
+	err = client.CreateTag(ctx, owner, repo1, "v1.0.0", "", "")
+	assert.EqualError(t, err, "validation failed: required parameter 'sourceRef' is missing")
+}
+
+func TestBitbucketCloud_GetTagInfo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createGetTagInfoBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.GetTagInfo(ctx, owner, repo1, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c", result.Hash)
+	assert.Equal(t, "Update image name\n", result.Message)
+}
+
+func TestBitbucketCloud_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	client, serverUrl, cleanUp := createServerWithUrlAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/downloads", http.StatusCreated, createCreateReleaseBitbucketCloudHandler)
+	defer cleanUp()
+
+	release, err := client.CreateRelease(ctx, owner, repo1, "v1.0.0", "Release 1.0.0", "Release notes")
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseInfo{
+		ID:      "v1.0.0-release-notes.md",
+		Name:    "Release 1.0.0",
+		TagName: "v1.0.0",
+		Body:    "Release notes",
+		URL:     serverUrl + "/repositories/jfrog/repo-1/downloads/v1.0.0-release-notes.md",
+	}, release)
+
+	_, err = client.CreateRelease(ctx, owner, repo1, "", "Release 1.0.0", "Release notes")
+	assert.EqualError(t, err, "validation failed: required parameter 'tagName' is missing")
+}
+
+func TestBitbucketCloud_ListReleases(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values": [
+		{"name": "app-1.0.0.zip", "created_on": "2023-01-02T03:04:05+00:00", "links": {"self": {"href": "https://api.bitbucket.org/2.0/repositories/jfrog/repo-1/downloads/app-1.0.0.zip"}}},
+		{"name": "app-1.0.1.zip", "created_on": "2023-02-02T03:04:05+00:00", "links": {"self": {"href": "https://api.bitbucket.org/2.0/repositories/jfrog/repo-1/downloads/app-1.0.1.zip"}}}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/downloads?pagelen=100", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	releases, err := client.ListReleases(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Len(t, releases, 2)
+	createdAt, err := time.Parse(time.RFC3339, "2023-01-02T03:04:05+00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseInfo{
+		ID:        "app-1.0.0.zip",
+		Name:      "app-1.0.0.zip",
+		URL:       "https://api.bitbucket.org/2.0/repositories/jfrog/repo-1/downloads/app-1.0.0.zip",
+		CreatedAt: createdAt,
+	}, releases[0])
+
+	latestRelease, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "app-1.0.1.zip", latestRelease.Name)
+}
+
+func TestBitbucketCloud_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.UploadReleaseAsset(ctx, owner, repo1, "app-1.0.0.zip", "app.zip", strings.NewReader("content"))
+	assert.ErrorIs(t, err, errBitbucketUploadReleaseAssetNotSupported)
+}
+
 func TestBitbucketCloud_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id, err := uuid.NewUUID()
@@ -104,6 +335,50 @@ func TestBitbucketCloud_UpdateWebhook(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBitbucketCloud_RotateWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	id, err := uuid.NewUUID()
+	assert.NoError(t, err)
+	response := map[string]interface{}{"uuid": id.String(), "url": "https://httpbin.org/anything?token=old-token", "active": true, "events": []string{"repo:push"}}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, fmt.Sprintf("/repositories/jfrog/repo-1/hooks/%s", id.String()), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	newToken, err := client.RotateWebhookSecret(ctx, owner, repo1, id.String())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, newToken)
+}
+
+func TestBitbucketCloud_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	id, err := uuid.NewUUID()
+	assert.NoError(t, err)
+	response := map[string]interface{}{"values": []map[string]interface{}{{"uuid": "{" + id.String() + "}", "url": "https://httpbin.org/anything?token=abc123", "active": true, "events": []string{"repo:push"}}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/hooks/", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{
+		ID:         id.String(),
+		PayloadURL: "https://httpbin.org/anything",
+		Events:     []vcsutils.WebhookEvent{vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved},
+		Active:     true,
+	}}, webhooks)
+}
+
+func TestBitbucketCloud_GetWebhook(t *testing.T) {
+	ctx := context.Background()
+	id, err := uuid.NewUUID()
+	assert.NoError(t, err)
+	response := map[string]interface{}{"uuid": "{" + id.String() + "}", "url": "https://httpbin.org/anything?token=abc123", "active": false, "events": []string{"pullrequest:created"}}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, fmt.Sprintf("/repositories/jfrog/repo-1/hooks/%s", id.String()), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	webhook, err := client.GetWebhook(ctx, owner, repo1, id.String())
+	assert.NoError(t, err)
+	assert.Equal(t, WebhookInfo{ID: id.String(), PayloadURL: "https://httpbin.org/anything", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened}}, webhook)
+}
+
 func TestBitbucketCloud_DeleteWebhook(t *testing.T) {
 	ctx := context.Background()
 	id, err := uuid.NewUUID()
@@ -144,11 +419,51 @@ func TestBitbucketCloud_DownloadRepository(t *testing.T) {
 
 func TestBitbucketCloud_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/jfrog/repo-1/pullrequests/", createBitbucketCloudHandler)
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "get_pull_request_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/pullrequests/", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	pullRequestInfo, err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pullRequestInfo.ID)
+	assert.Equal(t, "https://bitbucket.org/workspace/froggit/pull-requests/1", pullRequestInfo.URL)
+}
+
+func TestBitbucketCloud_CreatePullRequestWithOptions(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	_, err = client.CreatePullRequestWithOptions(context.Background(), owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{Draft: true})
+	assert.EqualError(t, err, "creating draft pull requests is currently not supported on Bitbucket cloud")
+	_, err = client.CreatePullRequestWithOptions(context.Background(), owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{Labels: []string{"dependencies"}})
+	assert.EqualError(t, err, "labeling pull requests on creation is currently not supported on Bitbucket cloud")
+}
+
+func TestBitbucketCloud_CreatePullRequestWithReviewers(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "get_pull_request_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/pullrequests/",
+		func(t *testing.T, expectedURI string, response []byte, _ int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, expectedURI, r.RequestURI)
+				var body struct {
+					Reviewers         []map[string]string `json:"reviewers"`
+					CloseSourceBranch bool                `json:"close_source_branch"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, []map[string]string{{"uuid": "{a1b2c3}"}}, body.Reviewers)
+				assert.True(t, body.CloseSourceBranch)
+				_, err := w.Write(response)
+				assert.NoError(t, err)
+			}
+		})
 	defer cleanUp()
 
-	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	pullRequestInfo, err := client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body",
+		CreatePullRequestOptions{Reviewers: []string{"{a1b2c3}"}, DeleteSourceBranch: true})
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pullRequestInfo.ID)
 }
 
 func TestBitbucketCloudClient_UpdatePullRequest(t *testing.T) {
@@ -159,6 +474,41 @@ func TestBitbucketCloudClient_UpdatePullRequest(t *testing.T) {
 
 	err := client.UpdatePullRequest(ctx, owner, repo1, "PR title", "PR body", "master", prId, vcsutils.Open)
 	assert.NoError(t, err)
+
+	expectedBody := []byte(`{"title":"PR title","destination":{"branch":{"name":"master"}}}` + "\n")
+	bodyClient, bodyCleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		fmt.Sprintf("/repositories/jfrog/repo-1/pullrequests/%v", prId), http.StatusOK,
+		expectedBody, http.MethodPut, createBitbucketCloudWithBodyHandler)
+	defer bodyCleanUp()
+	err = bodyClient.UpdatePullRequest(ctx, owner, repo1, "PR title", "", "master", prId, vcsutils.Open)
+	assert.NoError(t, err)
+
+	declineClient, declineCleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, fmt.Sprintf("/repositories/jfrog/repo-1/pullrequests/%v/decline", prId), createBitbucketCloudHandler)
+	defer declineCleanUp()
+	err = declineClient.UpdatePullRequest(ctx, owner, repo1, "", "", "", prId, vcsutils.Closed)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketCloudClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	prId := 3
+	expectedBody := []byte(`{"merge_strategy":"squash"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		fmt.Sprintf("/repositories/jfrog/repo-1/pullrequests/%v/merge", prId), http.StatusOK,
+		expectedBody, http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, prId, vcsutils.MergeMethodSquash)
+	assert.NoError(t, err)
+
+	err = client.MergePullRequest(ctx, owner, repo1, prId, "fast-forward")
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloudClient_SetPullRequestAutoMerge(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, client.SetPullRequestAutoMerge(context.Background(), owner, repo1, 3, vcsutils.MergeMethodSquash), errBitbucketCloudAutoMergeNotSupported)
 }
 
 func TestBitbucketCloud_ListOpenPullRequests(t *testing.T) {
@@ -169,14 +519,24 @@ func TestBitbucketCloud_ListOpenPullRequests(t *testing.T) {
 		fmt.Sprintf("/repositories/%s/%s/pullrequests/?state=OPEN", owner, repo1), createBitbucketCloudHandler)
 	defer cleanUp()
 
+	createdAt, err := time.Parse(time.RFC3339, "2022-05-16T11:03:45.627623+00:00")
+	assert.NoError(t, err)
+	updatedAt, err := time.Parse(time.RFC3339, "2022-05-16T11:05:33.889646+00:00")
+	assert.NoError(t, err)
 	result, err := client.ListOpenPullRequests(ctx, owner, repo1)
 
 	assert.NoError(t, err)
 	assert.Len(t, result, 3)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     3,
-		Source: BranchInfo{Name: "test-2", Repository: "user17/test"},
-		Target: BranchInfo{Name: "master", Repository: "user17/test"},
+		ID:        3,
+		Title:     "A change",
+		URL:       "https://bitbucket.org/user17/test/pull-requests/3",
+		Author:    "user",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "test-2", Repository: "user17/test"},
+		Target:    BranchInfo{Name: "master", Repository: "user17/test"},
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
 	}, result[0])
 
 	// With Body
@@ -185,13 +545,61 @@ func TestBitbucketCloud_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 3)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     3,
-		Body:   "hello world",
-		Source: BranchInfo{Name: "test-2", Repository: "user17/test"},
-		Target: BranchInfo{Name: "master", Repository: "user17/test"},
+		ID:        3,
+		Title:     "A change",
+		Body:      "hello world",
+		URL:       "https://bitbucket.org/user17/test/pull-requests/3",
+		Author:    "user",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "test-2", Repository: "user17/test"},
+		Target:    BranchInfo{Name: "master", Repository: "user17/test"},
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
 	}, result[0])
 }
 
+func TestBitbucketCloud_ListOpenPullRequestsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests?page=1&pagelen=50&q=%s&state=OPEN", owner, repo1,
+			url.QueryEscape(`source.branch.name="test-2" AND updated_on>=2022-05-01T00:00:00Z`)), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	options := ListPullRequestsOptions{SourceBranch: "test-2", UpdatedSince: time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)}
+	result, err := client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	// The mock server ignores the query, hence the pull requests are also filtered by the update time here
+	assert.Len(t, result, 2)
+	assert.Equal(t, int64(3), result[0].ID)
+	assert.Equal(t, int64(2), result[1].ID)
+
+	// Filtered by the author
+	options.Author = "someone"
+	result, err = client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestBitbucketCloud_ListPullRequestsWithState(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests?pagelen=50&state=DECLINED&state=SUPERSEDED", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Closed)
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, int64(3), result[0].ID)
+	assert.Equal(t, "hello world", result[0].Body)
+
+	_, err = client.ListPullRequestsWithState(ctx, owner, repo1, "draft")
+	assert.EqualError(t, err, "unsupported pull request state: 'draft'")
+}
+
 func TestBitbucketCloudClient_GetPullRequest(t *testing.T) {
 	pullRequestId := 1
 	repoName := "froggit"
@@ -203,12 +611,23 @@ func TestBitbucketCloudClient_GetPullRequest(t *testing.T) {
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
 		fmt.Sprintf("/repositories/%s/%s/pullrequests/%d", owner, repoName, pullRequestId), createBitbucketCloudHandler)
 	defer cleanUp()
+	createdAt, err := time.Parse(time.RFC3339, "2023-06-20T09:00:47.082738+00:00")
+	assert.NoError(t, err)
+	updatedAt, err := time.Parse(time.RFC3339, "2023-06-20T09:00:47.725250+00:00")
+	assert.NoError(t, err)
 	result, err := client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     int64(pullRequestId),
-		Source: BranchInfo{Name: "pr", Repository: "froggit", Owner: "forkedWorkspace"},
-		Target: BranchInfo{Name: "main", Repository: "froggit", Owner: "workspace"},
+		ID:        int64(pullRequestId),
+		Title:     "s",
+		Body:      "s",
+		URL:       "https://bitbucket.org/workspace/froggit/pull-requests/1",
+		Author:    "frogger",
+		State:     vcsutils.Closed,
+		Source:    BranchInfo{Name: "pr", Repository: "froggit", Owner: "forkedWorkspace"},
+		Target:    BranchInfo{Name: "main", Repository: "froggit", Owner: "workspace"},
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
 	}, result)
 
 	// Bad Response
@@ -250,12 +669,36 @@ func TestBitbucketCloud_ListPullRequestComments(t *testing.T) {
 	assert.NoError(t, err)
 	expectedCreated, err := time.Parse(time.RFC3339, "2022-05-16T11:04:07.075827+00:00")
 	assert.NoError(t, err)
+	expectedUpdated, err := time.Parse(time.RFC3339, "2022-05-16T11:04:07.075911+00:00")
+	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 	assert.Equal(t, CommentInfo{
-		ID:      301545835,
-		Content: "I’m a comment ",
-		Created: expectedCreated,
+		ID:             301545835,
+		NativeID:       "301545835",
+		Content:        "I’m a comment ",
+		Author:         "user",
+		AuthorUsername: "user",
+		Created:        expectedCreated,
+		Updated:        expectedUpdated,
 	}, result[0])
+	assert.Equal(t, "301545835", result[1].ParentID)
+}
+
+func TestBitbucketCloud_ListPullRequestCommentsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments?page=2&pagelen=10&q=updated_on+%%3E+2022-05-16T11%%3A00%%3A00Z", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	options := ListPullRequestCommentsOptions{UpdatedAfter: time.Date(2022, 5, 16, 11, 0, 0, 0, time.UTC), Page: 2, PerPage: 10}
+	result, err := client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, options)
+	assert.NoError(t, err)
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, int64(301545835), result[0].ID)
+		assert.Equal(t, "I’m a comment ", result[0].Content)
+	}
 }
 
 func TestBitbucketCloud_GetLatestCommit(t *testing.T) {
@@ -296,6 +739,37 @@ func TestBitbucketCloud_GetCommits(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestBitbucketCloud_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commit_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/commits/%s?page=1&pagelen=50&path=README.md", owner, repo1, "master"), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 13)
+	assert.Equal(t, "ec05bacb91d757b4b6b2a11a0676471020e89fb5", result[0].Hash)
+
+	// The author and the time are filtered on the client side
+	result, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{
+		Branch: "master",
+		Path:   "README.md",
+		Since:  time.Date(2020, 6, 1, 19, 0, 0, 0, time.UTC),
+		Until:  time.Date(2020, 6, 1, 19, 45, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "774aa0fb252bccbc2a7e01060ef4d4be0b0eeaa9", result[0].Hash)
+	assert.Equal(t, "1807e7d3f7a8f9a7cd3925d321a009f81da0d415", result[1].Hash)
+
+	result, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", Author: "frogger"})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
 func TestBitbucketCloud_GetLatestCommitNotFound(t *testing.T) {
 	ctx := context.Background()
 	response := []byte(`<!DOCTYPE html><html lang="en"></html>`)
@@ -450,45 +924,211 @@ func TestBitbucketCloud_GetRepositoryInfo(t *testing.T) {
 				HTTP: "https://bitbucket.org/jfrog/jfrog-setup-cli.git",
 				SSH:  "git@bitbucket.org:jfrog/jfrog-setup-cli.git",
 			},
+			DefaultBranch: "master",
 		},
 		res,
 	)
 }
 
-func TestBitbucketCloud_CreateLabel(t *testing.T) {
+func TestBitbucketCloud_CreateRepository(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "repository_response.json"))
 	assert.NoError(t, err)
+	expectedBody := []byte(`{"description":"Frogs","is_private":false,"name":"repo-1","scm":"git"}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s", owner, repo1), http.StatusOK, expectedBody, http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
 
-	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{})
-	assert.ErrorIs(t, err, errLabelsNotSupported)
-}
-
-func TestBitbucketCloud_AddPullRequestReviewComments(t *testing.T) {
-	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	res, err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Description: "Frogs"})
 	assert.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo: CloneInfo{
+				HTTP: "https://bitbucket.org/jfrog/jfrog-setup-cli.git",
+				SSH:  "git@bitbucket.org:jfrog/jfrog-setup-cli.git",
+			},
+			DefaultBranch: "master",
+		},
+		res,
+	)
 
-	err = client.AddPullRequestReviewComments(ctx, owner, repo1, 1)
-	assert.ErrorIs(t, err, errBitbucketAddPullRequestReviewCommentsNotSupported)
+	_, err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{InitReadme: true})
+	assert.ErrorIs(t, err, errBitbucketInitReadmeNotSupported)
 }
 
-func TestBitbucketCloudClient_ListPullRequestReviewComments(t *testing.T) {
+func TestBitbucketCloud_DeleteRepository(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
-	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, fmt.Sprintf("/repositories/%s/%s", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
 
-	_, err = client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
-	assert.ErrorIs(t, err, errBitbucketListPullRequestReviewCommentsNotSupported)
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
 }
 
-func TestBitbucketCloudClient_DeletePullRequestComment(t *testing.T) {
+func TestBitbucketCloud_ForkRepository(t *testing.T) {
 	ctx := context.Background()
-	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "repository_response.json"))
+	assert.NoError(t, err)
+	expectedBody := []byte(`{"workspace":{"slug":"frogs"}}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/forks", owner, repo1), http.StatusCreated, expectedBody, http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	res, err := client.ForkRepository(ctx, owner, repo1, "frogs")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo: CloneInfo{
+				HTTP: "https://bitbucket.org/jfrog/jfrog-setup-cli.git",
+				SSH:  "git@bitbucket.org:jfrog/jfrog-setup-cli.git",
+			},
+			DefaultBranch: "master",
+		},
+		res,
+	)
+}
+
+func TestBitbucketCloud_ListRepositoryCollaborators(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values": [{"permission": "admin", "user": {"nickname": "frogger"}}, {"permission": "read", "user": {"nickname": "toad"}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/workspaces/jfrog/permissions/repositories/repo-1?pagelen=100", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: AdminPermission}, {Username: "toad", Permission: ReadPermission}}, collaborators)
+
+	permission, err := client.GetRepositoryPermission(ctx, owner, repo1, "toad")
+	assert.NoError(t, err)
+	assert.Equal(t, ReadPermission, permission)
+}
+
+func TestBitbucketCloud_CreateLabel(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketCloud_AddPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/jfrog/repo-1/pullrequests/1/comments", createAddPullRequestReviewCommentsBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.AddPullRequestReviewComments(ctx, owner, repo1, 1,
+		PullRequestComment{CommentInfo: CommentInfo{Content: "Inline comment"}, PullRequestDiff: PullRequestDiff{NewFilePath: "/path/to/file.go", NewStartLine: 12}},
+		PullRequestComment{CommentInfo: CommentInfo{Content: "General comment"}})
+	assert.NoError(t, err)
+
+	err = client.AddPullRequestReviewComments(ctx, owner, repo1, 1)
+	assert.EqualError(t, err, vcsutils.ErrNoCommentsProvided)
+
+	err = client.AddPullRequestReviewComments(ctx, owner, repo1, 1, PullRequestComment{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloudClient_ListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments/", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(301546211), result[0].ID)
+	assert.Equal(t, "path/to/file.go", result[0].FilePath)
+	assert.Equal(t, 12, result[0].Line)
+}
+
+func TestBitbucketCloudClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte("{}"),
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments/2", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, "Updated content", 1, 2)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 2)
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloudClient_CreatePullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte("{}"),
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/approve", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+	err := client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictApprove, "")
 	assert.NoError(t, err)
 
-	err = client.DeletePullRequestComment(ctx, owner, repo1, 1, 1)
-	assert.ErrorIs(t, err, errBitbucketDeletePullRequestComment)
+	client, cleanUp = createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte("{}"),
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/request-changes", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+	err = client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictRequestChanges, "")
+	assert.NoError(t, err)
+
+	err = client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictComment, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloudClient_ListPullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"id": 1, "participants": [
+		{"user": {"display_name": "Reviewer 1"}, "role": "REVIEWER", "approved": true, "state": "approved", "participated_on": "2023-06-01T10:00:00.000000+00:00"},
+		{"user": {"display_name": "Reviewer 2"}, "role": "REVIEWER", "approved": false, "state": "changes_requested", "participated_on": "2023-06-02T10:00:00.000000+00:00"},
+		{"user": {"display_name": "Participant"}, "role": "PARTICIPANT", "approved": false, "state": null, "participated_on": "2023-06-03T10:00:00.000000+00:00"}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	reviews, err := client.ListPullRequestReviews(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 2)
+	assert.Equal(t, "Reviewer 1", reviews[0].Reviewer)
+	assert.Equal(t, vcsutils.ReviewVerdictApprove, reviews[0].State)
+	assert.True(t, reviews[0].Submitted.Equal(time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Reviewer 2", reviews[1].Reviewer)
+	assert.Equal(t, vcsutils.ReviewVerdictRequestChanges, reviews[1].State)
+}
+
+func TestBitbucketCloudClient_DeletePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments/2", owner, repo1), createBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.DeletePullRequestComment(ctx, owner, repo1, 1, 2)
+	assert.NoError(t, err)
+
+	badClient, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, nil,
+		fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments/2", owner, repo1), http.StatusNotFound, createBitbucketCloudHandler)
+	defer cleanUp()
+	err = badClient.DeletePullRequestComment(ctx, owner, repo1, 1, 2)
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloudClient_SetPullRequestThreadStatus(t *testing.T) {
+	ctx := context.Background()
+	expectedURI := fmt.Sprintf("/repositories/%s/%s/pullrequests/1/comments/2/resolve", owner, repo1)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil, expectedURI, http.StatusOK,
+		[]byte{}, http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "2", true))
+
+	client, cleanUp = createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil, expectedURI, http.StatusOK,
+		[]byte{}, http.MethodDelete, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "2", false))
+
+	assert.Error(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "", false))
 }
 
 func TestBitbucketCloudClient_DeletePullRequestReviewComment(t *testing.T) {
@@ -497,7 +1137,7 @@ func TestBitbucketCloudClient_DeletePullRequestReviewComment(t *testing.T) {
 	assert.NoError(t, err)
 
 	err = client.DeletePullRequestReviewComments(ctx, owner, repo1, 1, CommentInfo{})
-	assert.ErrorIs(t, err, errBitbucketDeletePullRequestComment)
+	assert.ErrorIs(t, err, errBitbucketDeletePullRequestReviewCommentsNotSupported)
 }
 
 func TestBitbucketCloudClient_DownloadFileFromRepo(t *testing.T) {
@@ -509,6 +1149,36 @@ func TestBitbucketCloudClient_DownloadFileFromRepo(t *testing.T) {
 	assert.ErrorIs(t, err, errBitbucketDownloadFileFromRepoNotSupported)
 }
 
+func TestBitbucketCloudClient_ListDirectoryContents(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"values": [
+		{"path": "src/go.mod", "type": "commit_file"},
+		{"path": "src/pkg", "type": "commit_directory"}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/src/master/src/", createBitbucketCloudHandler)
+	defer cleanUp()
+
+	entries, err := client.ListDirectoryContents(ctx, owner, repo1, "master", "src")
+	assert.NoError(t, err)
+	assert.Equal(t, []DirectoryEntry{
+		{Name: "go.mod", Path: "src/go.mod", IsDir: false},
+		{Name: "pkg", Path: "src/pkg", IsDir: true},
+	}, entries)
+}
+
+func TestBitbucketCloudClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	files := []FileToCommit{
+		{Path: "go.mod", Content: []byte("module example"), ChangeType: FileModified},
+		{Path: "go.sum", ChangeType: FileDeleted},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/jfrog/repo-1/src", createCommitFilesBitbucketCloudHandler)
+	defer cleanUp()
+
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", files)
+	assert.NoError(t, err)
+}
+
 func TestBitbucketCloud_GetLabel(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -518,6 +1188,33 @@ func TestBitbucketCloud_GetLabel(t *testing.T) {
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
+func TestBitbucketCloud_ListRepositoryLabels(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListRepositoryLabels(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketCloud_UpdateLabel(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	err = client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: labelName})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketCloud_DeleteLabel(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	err = client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
 func TestBitbucketCloud_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -527,6 +1224,15 @@ func TestBitbucketCloud_ListPullRequestLabels(t *testing.T) {
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
+func TestBitbucketCloud_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
+	assert.NoError(t, err)
+
+	err = client.LabelPullRequest(ctx, owner, repo1, 1, []string{labelName})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
 func TestBitbucketCloud_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -536,6 +1242,83 @@ func TestBitbucketCloud_UnlabelPullRequest(t *testing.T) {
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
+func TestBitbucketCloud_Issues(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createIssuesBitbucketCloudHandler(true))
+	defer cleanUp()
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade it")
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{ID: 1, Title: "Vulnerable dependency", Body: "Upgrade it", URL: "https://bitbucket.org/jfrog/repo-1/issues/1",
+		Author: "Frog Ger", State: vcsutils.IssueOpen}, issue)
+
+	issues, err := client.ListIssues(ctx, owner, repo1, ListIssuesOptions{State: vcsutils.IssueClosed, Author: "Frog Ger"})
+	assert.NoError(t, err)
+	assert.Equal(t, []IssueInfo{{ID: 2, Title: "Old dependency", Author: "Frog Ger", State: vcsutils.IssueClosed}}, issues)
+
+	_, err = client.ListIssues(ctx, owner, repo1, ListIssuesOptions{Labels: []string{"security"}})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+
+	assert.NoError(t, client.AddIssueComment(ctx, owner, repo1, "Fixed", 1))
+	assert.NoError(t, client.CloseIssue(ctx, owner, repo1, 1))
+
+	client, cleanUp = createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createIssuesBitbucketCloudHandler(false))
+	defer cleanUp()
+	_, err = client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade it")
+	assert.ErrorIs(t, err, errBitbucketCloudIssueTrackerDisabled)
+}
+
+func TestBitbucketCloud_UploadCodeScanning(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createUploadCodeScanningBitbucketCloudHandler)
+	defer cleanUp()
+	reportKey, err := client.UploadCodeScanning(ctx, owner, repo1, "master", codeScanningTestSarif)
+	assert.NoError(t, err)
+	assert.Equal(t, "code-scanning", reportKey)
+
+	_, err = client.UploadCodeScanning(ctx, owner, repo1, "master", "1")
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_CreateCodeInsightsReport(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"Xray","details":"Xray found no issues","report_type":"SECURITY","reporter":"Xray","result":"PASSED"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/commit/abcdef/reports/xray-scan", http.StatusOK, expectedBody, http.MethodPut, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateCodeInsightsReport(ctx, owner, repo1, "abcdef", CodeInsightsReport{
+		Key:      "xray-scan",
+		Title:    "Xray",
+		Details:  "Xray found no issues",
+		Result:   CodeInsightsPass,
+		Reporter: "Xray",
+	})
+	assert.NoError(t, err)
+
+	err = client.CreateCodeInsightsReport(ctx, owner, repo1, "", CodeInsightsReport{Key: "xray-scan", Title: "Xray"})
+	assert.Error(t, err)
+}
+
+func TestBitbucketCloud_AddCodeInsightsAnnotations(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`[` +
+		`{"external_id":"xray-scan-1","annotation_type":"VULNERABILITY","path":"package.json","line":12,"summary":"Vulnerable dependency","severity":"CRITICAL"},` +
+		`{"external_id":"xray-scan-2","annotation_type":"BUG","summary":"Nil dereference","severity":"MEDIUM","link":"https://jfrog.com"}]` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, nil,
+		"/repositories/jfrog/repo-1/commit/abcdef/reports/xray-scan/annotations", http.StatusOK, expectedBody, http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", []CodeInsightsAnnotation{
+		{Path: "package.json", Line: 12, Message: "Vulnerable dependency", Severity: CodeInsightsCriticalSeverity, Type: CodeInsightsVulnerability},
+		{Message: "Nil dereference", Severity: CodeInsightsMediumSeverity, Type: CodeInsightsBug, Link: "https://jfrog.com"},
+	})
+	assert.NoError(t, err)
+
+	err = client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", make([]CodeInsightsAnnotation, maxCodeInsightsAnnotations+1))
+	assert.Error(t, err)
+}
+
 func TestBitbucketCloud_GetRepositoryEnvironmentInfo(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketCloud).Build()
@@ -543,6 +1326,16 @@ func TestBitbucketCloud_GetRepositoryEnvironmentInfo(t *testing.T) {
 
 	_, err = client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
 	assert.ErrorIs(t, err, errBitbucketGetRepoEnvironmentInfoNotSupported)
+	_, err = client.ListEnvironments(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	_, err = client.CreateDeployment(ctx, owner, repo1, branch1, envName, "")
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	assert.ErrorIs(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"), errBitbucketRepositorySecretsNotSupported)
+	_, err = client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketRepositorySecretsNotSupported)
+	assert.ErrorIs(t, client.CreateOrUpdateOrganizationSecret(ctx, owner, "JF_TOKEN", "secret-value", VisibleToAllRepositories, nil), errBitbucketSecretsNotSupported)
+	_, err = client.ListOrganizationSecretRepositories(ctx, owner, "JF_TOKEN")
+	assert.ErrorIs(t, err, errBitbucketSecretsNotSupported)
 }
 
 func TestBitbucketCloud_getRepositoryVisibility(t *testing.T) {
@@ -595,6 +1388,92 @@ func TestBitbucketCloudClient_GetModifiedFiles(t *testing.T) {
 	})
 }
 
+func TestBitbucketCloudClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	prId := 3
+	expectedURI := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diffstat", owner, repo1, prId)
+
+	t.Run("ok", func(t *testing.T) {
+		response := []byte(`{"values": [
+			{"status": "modified", "old": {"path": "README.md"}, "new": {"path": "README.md"}},
+			{"status": "added", "new": {"path": "new.go"}},
+			{"status": "removed", "old": {"path": "old.go"}},
+			{"status": "renamed", "old": {"path": "original.go"}, "new": {"path": "renamed.go"}}
+		]}`)
+		client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, response, expectedURI, http.StatusOK,
+			createBitbucketCloudHandler)
+		defer cleanUp()
+
+		files, err := client.ListPullRequestFiles(ctx, owner, repo1, prId)
+		assert.NoError(t, err)
+		assert.Equal(t, []PullRequestFile{
+			{Path: "README.md", ChangeType: FileModified},
+			{Path: "new.go", ChangeType: FileAdded},
+			{Path: "old.go", ChangeType: FileDeleted},
+			{Path: "renamed.go", PreviousPath: "original.go", ChangeType: FileRenamed},
+		}, files)
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, nil, expectedURI, http.StatusInternalServerError,
+			createBitbucketCloudHandler)
+		defer cleanUp()
+		_, err := client.ListPullRequestFiles(ctx, owner, repo1, prId)
+		assert.EqualError(t, err, "500 Internal Server Error")
+	})
+}
+
+func TestBitbucketCloudClient_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	prId := 3
+	expectedURI := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diff", owner, repo1, prId)
+	expectedDiff := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n hello\n+world\n"
+
+	t.Run("ok", func(t *testing.T) {
+		client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, []byte(expectedDiff), expectedURI, http.StatusOK,
+			createBitbucketCloudHandler)
+		defer cleanUp()
+
+		diff, err := client.GetPullRequestDiff(ctx, owner, repo1, prId)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedDiff, diff)
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketCloud, true, nil, expectedURI, http.StatusInternalServerError,
+			createBitbucketCloudHandler)
+		defer cleanUp()
+		_, err := client.GetPullRequestDiff(ctx, owner, repo1, prId)
+		assert.Error(t, err)
+	})
+}
+
+func TestBitbucketCloudClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "", createCompareCommitsBitbucketCloudHandler)
+	defer cleanUp()
+
+	result, err := client.CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.NoError(t, err)
+	assert.Equal(t, CommitsComparison{
+		Commits: []CommitInfo{{
+			Hash:         "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			AuthorName:   "frogger",
+			Url:          "https://api.bitbucket.org/2.0/repositories/jfrog/repo-1/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			Timestamp:    1667812601,
+			Message:      "Fix all the bugs",
+			ParentHashes: []string{"7638417db6d59f3c431d3e1f261cc637155684cd"},
+		}},
+		Files: []PullRequestFile{
+			{Path: "README.md", ChangeType: FileModified},
+			{Path: "new.go", ChangeType: FileAdded},
+		},
+	}, result)
+
+	_, err = client.CompareCommits(ctx, "", repo1, "main", "feature")
+	assert.EqualError(t, err, "validation failed: required parameter 'owner' is missing")
+}
+
 func TestBitbucketCloudClient_GetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	t.Run("empty response", func(t *testing.T) {
@@ -618,6 +1497,93 @@ func TestBitbucketCloudClient_GetCommitStatus(t *testing.T) {
 	})
 }
 
+func TestBitbucketCloudClient_GetCombinedCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	t.Run("no statuses", func(t *testing.T) {
+		client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, nil, "/repositories/owner/repo/commit/ref/statuses", createBitbucketCloudHandler)
+		defer cleanUp()
+		status, err := client.GetCombinedCommitStatus(ctx, "owner", "repo", "ref")
+		assert.NoError(t, err)
+		assert.Equal(t, Pass, status)
+	})
+
+	t.Run("failed status", func(t *testing.T) {
+		response, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commits_statuses.json"))
+		assert.NoError(t, err)
+		client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/owner/repo/commit/ref/statuses", createBitbucketCloudHandler)
+		defer cleanUp()
+		status, err := client.GetCombinedCommitStatus(ctx, "owner", "repo", "ref")
+		assert.NoError(t, err)
+		assert.Equal(t, Fail, status)
+	})
+}
+
+func TestBitbucketCloudClient_TriggerPipeline(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"uuid": "{6d5a3b52-8b8e-4f4e-b1f3-4f6a6e1b2c3d}", "state": {"name": "PENDING"},
+		"target": {"type": "pipeline_ref_target", "ref_type": "branch", "ref_name": "branch-1", "commit": {"hash": "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"}},
+		"created_on": "2024-05-01T10:00:00Z"}`)
+	expected := PipelineInfo{
+		ID:        "{6d5a3b52-8b8e-4f4e-b1f3-4f6a6e1b2c3d}",
+		Status:    InProgress,
+		RawStatus: "PENDING",
+		Ref:       branch1,
+		CommitSHA: "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69",
+		CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/pipelines/", http.StatusCreated,
+		[]byte(`{"target":{"type":"pipeline_ref_target","ref_type":"branch","ref_name":"branch-1"},"variables":[{"key":"DEPLOY","value":"false"},{"key":"ENV","value":"staging"}]}`+"\n"),
+		http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+	pipeline, err := client.TriggerPipeline(ctx, owner, repo1, branch1, map[string]string{"ENV": "staging", "DEPLOY": "false"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pipeline)
+
+	client, cleanUp = createBodyHandlingServerAndClient(t, vcsutils.BitbucketCloud, true, response, "/repositories/jfrog/repo-1/pipelines/", http.StatusCreated,
+		[]byte(`{"target":{"type":"pipeline_commit_target","commit":{"type":"commit","hash":"5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"}}}`+"\n"),
+		http.MethodPost, createBitbucketCloudWithBodyHandler)
+	defer cleanUp()
+	_, err = client.TriggerPipeline(ctx, owner, repo1, expected.CommitSHA, nil)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, client.TriggerWorkflow(ctx, owner, repo1, "build.yml", branch1, nil), errBitbucketCloudWorkflowsNotSupported)
+}
+
+func TestBitbucketCloudClient_GetPipelineStatus(t *testing.T) {
+	ctx := context.Background()
+	pipeline := `{"uuid": "{6d5a3b52-8b8e-4f4e-b1f3-4f6a6e1b2c3d}", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}},
+		"target": {"ref_name": "branch-1"}, "created_on": "2024-05-01T10:00:00Z", "completed_on": "2024-05-01T10:05:00Z"}`
+	expected := PipelineInfo{
+		ID:            "{6d5a3b52-8b8e-4f4e-b1f3-4f6a6e1b2c3d}",
+		Status:        Fail,
+		RawStatus:     "FAILED",
+		Ref:           branch1,
+		CreatedAt:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		LastUpdatedAt: time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC),
+	}
+
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(pipeline),
+		"/repositories/jfrog/repo-1/pipelines/%7B6d5a3b52-8b8e-4f4e-b1f3-4f6a6e1b2c3d%7D", createBitbucketCloudHandler)
+	defer cleanUp()
+	actual, err := client.GetPipelineStatus(ctx, owner, repo1, expected.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	client, cleanUp = createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"values": [`+pipeline+`]}`),
+		"/repositories/jfrog/repo-1/pipelines/?pagelen=1&sort=-created_on&target.ref_name=branch-1", createBitbucketCloudHandler)
+	defer cleanUp()
+	actual, err = client.GetLatestPipelineStatus(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	client, cleanUp = createServerAndClient(t, vcsutils.BitbucketCloud, true, []byte(`{"values": []}`),
+		"/repositories/jfrog/repo-1/pipelines/?pagelen=1&sort=-created_on&target.ref_name=branch-1", createBitbucketCloudHandler)
+	defer cleanUp()
+	_, err = client.GetLatestPipelineStatus(ctx, owner, repo1, branch1)
+	assert.EqualError(t, err, "no pipeline was found for ref 'branch-1'")
+}
+
 func TestSplitWorkSpaceAndOwner(t *testing.T) {
 	valid := "work/repo"
 	workspace, repo := splitBitbucketCloudRepoName(valid)
@@ -648,3 +1614,194 @@ func createBitbucketCloudHandler(t *testing.T, expectedURI string, response []by
 		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
 	}
 }
+
+func createIssuesBitbucketCloudHandler(hasIssues bool) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var response string
+			switch r.Method + " " + r.RequestURI {
+			case "GET /repositories/jfrog/repo-1":
+				response = fmt.Sprintf(`{"has_issues": %t}`, hasIssues)
+			case "POST /repositories/jfrog/repo-1/issues":
+				assert.Equal(t, `{"title":"Vulnerable dependency","content":{"raw":"Upgrade it"}}`+"\n", string(body))
+				response = `{"id": 1, "title": "Vulnerable dependency", "content": {"raw": "Upgrade it"}, "state": "new",
+					"reporter": {"display_name": "Frog Ger"}, "links": {"html": {"href": "https://bitbucket.org/jfrog/repo-1/issues/1"}}}`
+			case "GET /repositories/jfrog/repo-1/issues?page=1&pagelen=50&q=state%3D%22resolved%22+OR+state%3D%22closed%22+OR+state%3D%22invalid%22+OR+state%3D%22duplicate%22+OR+state%3D%22wontfix%22":
+				response = `{"values": [{"id": 2, "title": "Old dependency", "state": "resolved", "reporter": {"display_name": "Frog Ger"}},
+					{"id": 3, "title": "Other dependency", "state": "wontfix", "reporter": {"display_name": "Toad"}}]}`
+			case "POST /repositories/jfrog/repo-1/issues/1/comments":
+				assert.Equal(t, `{"content":{"raw":"Fixed"}}`+"\n", string(body))
+			case "PUT /repositories/jfrog/repo-1/issues/1":
+				assert.Equal(t, `{"state":"resolved"}`+"\n", string(body))
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+			_, err = w.Write([]byte(response))
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func createAuthenticatedUserBitbucketCloudHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /user":
+			response = `{"type": "user", "username": "frogger", "display_name": "Frog Ger"}`
+		case "GET /user/emails":
+			response = `{"values": [{"email": "frog@jfrog.com", "is_primary": false}, {"email": "frogger@jfrog.com", "is_primary": true}]}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createAddPullRequestReviewCommentsBitbucketCloudHandler(t *testing.T, expectedURI string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, expectedURI, r.RequestURI)
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		switch {
+		case strings.Contains(string(body), "Inline comment"):
+			assert.JSONEq(t, `{"content":{"raw":"Inline comment"},"inline":{"path":"path/to/file.go","to":12}}`, string(body))
+		case strings.Contains(string(body), "General comment"):
+			assert.JSONEq(t, `{"content":{"raw":"General comment"}}`, string(body))
+		default:
+			assert.Fail(t, "unexpected request body", string(body))
+		}
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func createCompareCommitsBitbucketCloudHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		var response string
+		switch {
+		case r.RequestURI == "/repositories/jfrog/repo-1/commits/feature?exclude=main":
+			response = `{"values": [{"hash": "6dcb09b5b57875f334f61aebed695e2e4193db5e", "date": "2022-11-07T09:16:41+00:00",
+				"message": "Fix all the bugs", "author": {"user": {"display_name": "frogger"}},
+				"links": {"self": {"href": "https://api.bitbucket.org/2.0/repositories/jfrog/repo-1/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e"}},
+				"parents": [{"hash": "7638417db6d59f3c431d3e1f261cc637155684cd"}]}]}`
+		case strings.HasPrefix(r.RequestURI, "/repositories/jfrog/repo-1/diffstat/feature..main"):
+			response = `{"values": [
+				{"status": "modified", "old": {"path": "README.md"}, "new": {"path": "README.md"}},
+				{"status": "added", "new": {"path": "new.go"}}
+			]}`
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createGetTagInfoBitbucketCloudHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		var response []byte
+		var err error
+		switch r.RequestURI {
+		case "/repositories/jfrog/repo-1/refs/tags/v1.0.0":
+			response = []byte(`{"type": "tag", "name": "v1.0.0", "target": {"hash": "f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c"}}`)
+		case "/repositories/jfrog/repo-1/commit/f62ea5359e7af59880b4a5e23e0ce6c1b32b5d3c":
+			response, err = os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commit_single_response.json"))
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func createBranchProtectionBitbucketCloudHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		restrictionsPath := "/repositories/jfrog/repo-1/branch-restrictions"
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET " + restrictionsPath + "?pagelen=100&pattern=" + branch1:
+			response = `{"values": [
+				{"id": 1, "kind": "push", "branch_match_kind": "glob", "pattern": "` + branch1 + `"},
+				{"id": 2, "kind": "require_approvals_to_merge", "branch_match_kind": "glob", "pattern": "` + branch1 + `", "value": 2},
+				{"id": 3, "kind": "delete", "branch_match_kind": "glob", "pattern": "` + branch1 + `"}
+			]}`
+		case "DELETE " + restrictionsPath + "/1", "DELETE " + restrictionsPath + "/2":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case "POST " + restrictionsPath:
+			assert.JSONEq(t, `{"kind": "require_approvals_to_merge", "branch_match_kind": "glob", "pattern": "`+branch1+`", "value": 1}`, string(body))
+			response = `{"id": 4}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createCreateReleaseBitbucketCloudHandler(t *testing.T, expectedURI string, _ []byte, expectedStatusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, expectedURI, r.RequestURI)
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		assert.NoError(t, r.ParseMultipartForm(1024))
+		_, fileHeader, err := r.FormFile("files")
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.0.0-release-notes.md", fileHeader.Filename)
+		assert.Equal(t, "# Release 1.0.0\n\nRelease notes\n", readMultipartFile(t, r, "files"))
+		w.WriteHeader(expectedStatusCode)
+	}
+}
+
+func createCommitFilesBitbucketCloudHandler(t *testing.T, expectedURI string, _ []byte, expectedStatusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, expectedURI, r.RequestURI)
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		assert.NoError(t, r.ParseMultipartForm(1024))
+		assert.Equal(t, []string{"Update dependencies"}, r.MultipartForm.Value["message"])
+		assert.Equal(t, []string{branch1}, r.MultipartForm.Value["branch"])
+		assert.Equal(t, []string{"go.sum"}, r.MultipartForm.Value["files"])
+		assert.Equal(t, "module example", readMultipartFile(t, r, "go.mod"))
+		w.WriteHeader(expectedStatusCode)
+	}
+}
+
+func createUploadCodeScanningBitbucketCloudHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basicAuthHeader, r.Header.Get("Authorization"))
+		reportUri := "/repositories/jfrog/repo-1/commit/ec05bacb91d757b4b6b2a11a0676471020e89fb5/reports/code-scanning"
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repositories/jfrog/repo-1/commits/master?pagelen=1":
+			response, err = os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "commit_list_response.json"))
+			assert.NoError(t, err)
+		case "PUT " + reportUri:
+			assert.JSONEq(t, `{"title":"Xray","details":"Xray found 2 issues","report_type":"SECURITY","reporter":"Xray","result":"FAILED"}`, string(body))
+			response = []byte(`{"uuid":"{b0f1c6b3-7a1c-4b5e-8d4e-3c1f0e7a9d2f}"}`)
+		case "POST " + reportUri + "/annotations":
+			assert.JSONEq(t, `[`+
+				`{"external_id":"code-scanning-1","annotation_type":"VULNERABILITY","path":"package.json","line":12,"summary":"XRAY-174176: json 9.0.6. Fixed in Versions: [11.0.0]","severity":"CRITICAL"},`+
+				`{"external_id":"code-scanning-2","annotation_type":"VULNERABILITY","summary":"XRAY-174177: lodash 4.17.0. Fixed in Versions: [4.17.21]","severity":"MEDIUM"}]`, string(body))
+			response = []byte("[]")
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
@@ -1,26 +1,44 @@
 package vcsclient
 
 import (
+	"errors"
 	"fmt"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/mitchellh/mapstructure"
+	"strings"
 	"time"
 )
 
 const (
 	notSupportedOnBitbucket     = "currently not supported on Bitbucket"
 	bitbucketPrContentSizeLimit = 32768
+	// The maximal number of annotations in a Code Insights report
+	maxCodeInsightsAnnotations = 1000
 )
 
 var (
-	errLabelsNotSupported                                 = fmt.Errorf("labels are %s", notSupportedOnBitbucket)
-	errBitbucketCodeScanningNotSupported                  = fmt.Errorf("code scanning is %s", notSupportedOnBitbucket)
-	errBitbucketDownloadFileFromRepoNotSupported          = fmt.Errorf("download file from repo is %s", notSupportedOnBitbucket)
-	errBitbucketGetCommitsNotSupported                    = fmt.Errorf("get commits is %s", notSupportedOnBitbucket)
-	errBitbucketGetRepoEnvironmentInfoNotSupported        = fmt.Errorf("get repository environment info is %s", notSupportedOnBitbucket)
-	errBitbucketListPullRequestReviewCommentsNotSupported = fmt.Errorf("list pull request review comments is %s", notSupportedOnBitbucket)
-	errBitbucketAddPullRequestReviewCommentsNotSupported  = fmt.Errorf("add pull request review comment is %s", notSupportedOnBitbucket)
-	errBitbucketDeletePullRequestComment                  = fmt.Errorf("delete pull request comment is %s", notSupportedOnBitbucket)
+	errLabelsNotSupported                                   = fmt.Errorf("labels are %s", notSupportedOnBitbucket)
+	errBitbucketDownloadFileFromRepoNotSupported            = fmt.Errorf("download file from repo is %s", notSupportedOnBitbucket)
+	errBitbucketGetCommitsNotSupported                      = fmt.Errorf("get commits is %s", notSupportedOnBitbucket)
+	errBitbucketGetRepoEnvironmentInfoNotSupported          = fmt.Errorf("get repository environment info is %s", notSupportedOnBitbucket)
+	errBitbucketDeploymentsNotSupported                     = fmt.Errorf("deployments and environments are %s", notSupportedOnBitbucket)
+	errBitbucketRepositorySecretsNotSupported               = fmt.Errorf("repository secrets and variables are %s", notSupportedOnBitbucket)
+	errBitbucketSecretsNotSupported                         = fmt.Errorf("organization and environment secrets are %s", notSupportedOnBitbucket)
+	errBitbucketDeletePullRequestReviewCommentsNotSupported = fmt.Errorf("delete pull request review comments is %s", notSupportedOnBitbucket)
+	errBitbucketServerCommitDeletedFileNotSupported         = fmt.Errorf("committing deleted files is %s server", notSupportedOnBitbucket)
+	errBitbucketServerReleasesNotSupported                  = fmt.Errorf("releases are %s server", notSupportedOnBitbucket)
+	errBitbucketUploadReleaseAssetNotSupported              = fmt.Errorf("upload release asset is %s", notSupportedOnBitbucket)
+	errBitbucketRequiredStatusChecksNotSupported            = fmt.Errorf("required status checks are %s", notSupportedOnBitbucket)
+	errBitbucketServerRequiredApprovalsNotSupported         = fmt.Errorf("branch required approvals are %s server", notSupportedOnBitbucket)
+	errBitbucketServerIssuesNotSupported                    = fmt.Errorf("issues are %s server", notSupportedOnBitbucket)
+	errBitbucketCloudIssueTrackerDisabled                   = errors.New("issues are not supported on Bitbucket cloud repositories whose issue tracker is disabled")
+	errBitbucketAppInstallationsNotSupported                = fmt.Errorf("app installations are %s", notSupportedOnBitbucket)
+	errBitbucketCheckRunsNotSupported                       = fmt.Errorf("check runs are %s, use code insights reports instead", notSupportedOnBitbucket)
+	errBitbucketTokenScopesNotSupported                     = fmt.Errorf("verifying token scopes is %s", notSupportedOnBitbucket)
+	errBitbucketInitReadmeNotSupported                      = fmt.Errorf("initializing a repository with a README is %s", notSupportedOnBitbucket)
+	errBitbucketCloudAutoMergeNotSupported                  = fmt.Errorf("auto-merge is %s cloud", notSupportedOnBitbucket)
+	errBitbucketServerPipelinesNotSupported                 = fmt.Errorf("pipelines are %s server", notSupportedOnBitbucket)
+	errBitbucketCloudWorkflowsNotSupported                  = fmt.Errorf("workflows and builds are %s cloud, use pipelines instead", notSupportedOnBitbucket)
 )
 
 type BitbucketCommitInfo struct {
@@ -46,6 +64,16 @@ func getBitbucketCommitState(commitState CommitStatus) string {
 	return ""
 }
 
+// getBitbucketCollaboratorPermission returns the permission of a user among the collaborators of a repository
+func getBitbucketCollaboratorPermission(collaborators []CollaboratorInfo, username string) RepositoryPermission {
+	for _, collaborator := range collaborators {
+		if strings.EqualFold(collaborator.Username, username) {
+			return collaborator.Permission
+		}
+	}
+	return NoPermission
+}
+
 // bitbucketParseCommitStatuses parse raw response into CommitStatusInfo slice
 func bitbucketParseCommitStatuses(rawStatuses interface{}, provider vcsutils.VcsProvider) ([]CommitStatusInfo, error) {
 	statuses := struct {
@@ -83,6 +111,8 @@ func getBitbucketServerCommitStatusInfo(commitStatus *BitbucketCommitInfo) Commi
 	timeInNanoSec := (int64(commitStatus.DateAdded) - (timeInSec * int64(time.Microsecond))) * int64(time.Millisecond)
 	return CommitStatusInfo{
 		State:       commitStatusAsStringToStatus(commitStatus.State),
+		RawState:    commitStatus.State,
+		Context:     commitStatus.Title,
 		Description: commitStatus.Description,
 		DetailsUrl:  commitStatus.Url,
 		Creator:     commitStatus.Title,
@@ -109,6 +139,8 @@ func getBitbucketCloudCommitStatusInfo(commitStatus *BitbucketCommitInfo) (Commi
 
 	return CommitStatusInfo{
 		State:         commitStatusAsStringToStatus(commitStatus.State),
+		RawState:      commitStatus.State,
+		Context:       commitStatus.Title,
 		Description:   commitStatus.Description,
 		DetailsUrl:    commitStatus.Url,
 		Creator:       commitStatus.Creator,
@@ -116,3 +148,17 @@ func getBitbucketCloudCommitStatusInfo(commitStatus *BitbucketCommitInfo) (Commi
 		LastUpdatedAt: updatedOn,
 	}, nil
 }
+
+// Maps the pull request state, which is shared by Bitbucket server and Bitbucket cloud
+func mapBitbucketPullRequestState(state string) vcsutils.PullRequestState {
+	switch strings.ToUpper(state) {
+	case "OPEN":
+		return vcsutils.Open
+	case "MERGED":
+		return vcsutils.Merged
+	case "DECLINED", "SUPERSEDED", "CLOSED":
+		return vcsutils.Closed
+	default:
+		return ""
+	}
+}
@@ -1,6 +1,7 @@
 package vcsclient
 
 import (
+	"context"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"testing"
 	"time"
@@ -40,6 +41,8 @@ func TestBitbucketParseCommitStatuses(t *testing.T) {
 	expectedStatuses := []CommitStatusInfo{
 		{
 			State:       Pass,
+			RawState:    "SUCCESSFUL",
+			Context:     "jenkins",
 			Description: "Build successful",
 			DetailsUrl:  "https://example.com/build/1234",
 			Creator:     "jenkins",
@@ -47,6 +50,8 @@ func TestBitbucketParseCommitStatuses(t *testing.T) {
 		},
 		{
 			State:       Fail,
+			RawState:    "FAILED",
+			Context:     "jenkins",
 			Description: "Build failed",
 			DetailsUrl:  "https://example.com/build/5678",
 			Creator:     "jenkins",
@@ -70,6 +75,8 @@ func TestGetCommitStatusInfoByBitbucketProvider_BitbucketServer(t *testing.T) {
 
 	expectedStatus := CommitStatusInfo{
 		State:       Pass,
+		RawState:    "SUCCESSFUL",
+		Context:     "jenkins",
 		Description: "Build successful",
 		DetailsUrl:  "https://example.com/build/1234",
 		Creator:     "jenkins",
@@ -84,6 +91,7 @@ func TestGetCommitStatusInfoByBitbucketProvider_BitbucketServer(t *testing.T) {
 func TestGetCommitStatusInfoByBitbucketProvider_BitbucketCloud(t *testing.T) {
 	commitStatus := &BitbucketCommitInfo{
 		State:       "success",
+		Title:       "build",
 		Description: "Test commit",
 		Url:         "https://example.com/commit",
 		Creator:     "John Doe",
@@ -93,6 +101,8 @@ func TestGetCommitStatusInfoByBitbucketProvider_BitbucketCloud(t *testing.T) {
 
 	expectedResult := CommitStatusInfo{
 		State:         Pass,
+		RawState:      "success",
+		Context:       "build",
 		Description:   "Test commit",
 		DetailsUrl:    "https://example.com/commit",
 		Creator:       "John Doe",
@@ -104,3 +114,26 @@ func TestGetCommitStatusInfoByBitbucketProvider_BitbucketCloud(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResult, result)
 }
+
+func TestBitbucketClients_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	for _, provider := range []vcsutils.VcsProvider{vcsutils.BitbucketServer, vcsutils.BitbucketCloud} {
+		client, err := NewClientBuilder(provider).Build()
+		assert.NoError(t, err)
+		_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "Xray", HeadSHA: "abcdef"})
+		assert.ErrorIs(t, err, errBitbucketCheckRunsNotSupported)
+		assert.ErrorIs(t, client.UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{Name: "Xray"}), errBitbucketCheckRunsNotSupported)
+	}
+}
+
+func TestBitbucketClients_AppInstallations(t *testing.T) {
+	ctx := context.Background()
+	for _, provider := range []vcsutils.VcsProvider{vcsutils.BitbucketServer, vcsutils.BitbucketCloud} {
+		client, err := NewClientBuilder(provider).Build()
+		assert.NoError(t, err)
+		_, err = client.ListAppInstallations(ctx)
+		assert.ErrorIs(t, err, errBitbucketAppInstallationsNotSupported)
+		_, err = client.ListInstallationRepositories(ctx, 5)
+		assert.ErrorIs(t, err, errBitbucketAppInstallationsNotSupported)
+	}
+}
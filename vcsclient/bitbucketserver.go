@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"github.com/jfrog/gofrog/datastructures"
 	"io"
+	"mime/multipart"
 	"net/http"
+	neturl "net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,9 +19,16 @@ import (
 	bitbucketv1 "github.com/gfleury/go-bitbucket-v1"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/mitchellh/mapstructure"
+	"golang.org/x/exp/slices"
 	"golang.org/x/oauth2"
 )
 
+const (
+	bitbucketServerOpenPullRequestState     = "OPEN"
+	bitbucketServerDeclinedPullRequestState = "DECLINED"
+	bitbucketServerPageLimit                = 100
+)
+
 // BitbucketServerClient API version 1.0
 type BitbucketServerClient struct {
 	vcsInfo VcsInfo
@@ -49,9 +58,11 @@ func (client *BitbucketServerClient) buildBitbucketClient(ctx context.Context) *
 }
 
 func (client *BitbucketServerClient) buildHTTPClient(ctx context.Context) *http.Client {
-	httpClient := &http.Client{}
-	if client.vcsInfo.Token != "" {
-		httpClient = oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: client.vcsInfo.Token}))
+	httpClient := newHttpClient(client.vcsInfo)
+	if client.vcsInfo.TokenProvider != nil {
+		httpClient = newTokenProviderHttpClient(client.vcsInfo, setBearerAuthorization)
+	} else if client.vcsInfo.Token != "" {
+		httpClient = newOAuth2HttpClient(client.vcsInfo, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: client.vcsInfo.Token}))
 	}
 	return httpClient
 }
@@ -65,6 +76,68 @@ func (client *BitbucketServerClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// TestConnectionWithScopes on Bitbucket server.
+// The scopes of Bitbucket tokens can't be queried, so an error is returned if any scope is required.
+func (client *BitbucketServerClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	if err := client.TestConnection(ctx); err != nil {
+		return err
+	}
+	if len(scopes) > 0 {
+		return errBitbucketTokenScopesNotSupported
+	}
+	return nil
+}
+
+// GetAuthenticatedUser on Bitbucket server
+func (client *BitbucketServerClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	username, err := client.getAuthenticatedUsername(ctx)
+	if err != nil {
+		return UserInfo{}, err
+	}
+	baseUrl := strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest")
+	url := fmt.Sprintf("%s/rest/api/1.0/users?filter=%s", baseUrl, neturl.QueryEscape(username))
+	var usersResponse struct {
+		Values []bitbucketv1.User `json:"values"`
+	}
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, url, nil, &usersResponse); err != nil {
+		return UserInfo{}, err
+	}
+	for _, user := range usersResponse.Values {
+		if user.Name == username {
+			return UserInfo{Username: user.Name, DisplayName: user.DisplayName, Email: user.EmailAddress}, nil
+		}
+	}
+	return UserInfo{Username: username}, nil
+}
+
+// getAuthenticatedUsername returns the username of the token's owner, which the whoami servlet returns as plain text
+func (client *BitbucketServerClient) getAuthenticatedUsername(ctx context.Context) (username string, err error) {
+	url := strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest") + "/plugins/servlet/applinks/whoami"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	response, err := client.buildHTTPClient(ctx).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, response.Body.Close())
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode >= 300 {
+		return "", fmt.Errorf("status: %v, body: %s", response.Status, body)
+	}
+	username = strings.TrimSpace(string(body))
+	if username == "" {
+		return "", errors.New("the token isn't associated with a Bitbucket server user")
+	}
+	return username, nil
+}
+
 // ListRepositories on Bitbucket server
 func (client *BitbucketServerClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
 	bitbucketClient := client.buildBitbucketClient(ctx)
@@ -95,6 +168,39 @@ func (client *BitbucketServerClient) ListRepositories(ctx context.Context) (map[
 	return results, nil
 }
 
+// ListRepositoriesWithOptions on Bitbucket server
+func (client *BitbucketServerClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	page, perPage := options.getPagination()
+	// Get a page of the repositories for which the authenticated user has the REPO_READ permission
+	apiResponse, err := bitbucketClient.GetRepositories_19(map[string]interface{}{"start": (page - 1) * perPage, "limit": perPage})
+	if err != nil {
+		return nil, err
+	}
+	repos, err := bitbucketv1.GetRepositoriesResponse(apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for _, repo := range repos {
+		if repo.Project == nil {
+			continue
+		}
+		results[repo.Project.Key] = append(results[repo.Project.Key], repo.Slug)
+	}
+	return results, nil
+}
+
+// ListAppInstallations on Bitbucket server
+func (client *BitbucketServerClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, errBitbucketAppInstallationsNotSupported
+}
+
+// ListInstallationRepositories on Bitbucket server
+func (client *BitbucketServerClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, errBitbucketAppInstallationsNotSupported
+}
+
 // ListBranches on Bitbucket server
 func (client *BitbucketServerClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
 	bitbucketClient := client.buildBitbucketClient(ctx)
@@ -119,6 +225,272 @@ func (client *BitbucketServerClient) ListBranches(ctx context.Context, owner, re
 	return results, nil
 }
 
+// ListBranchesWithOptions on Bitbucket server
+func (client *BitbucketServerClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	page, perPage := options.getPagination()
+	apiResponse, err := bitbucketClient.GetBranches(owner, repository, map[string]interface{}{"start": (page - 1) * perPage, "limit": perPage})
+	if err != nil {
+		return nil, err
+	}
+	branches, err := bitbucketv1.GetBranchesResponse(apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		results = append(results, branch.ID)
+	}
+	return results, nil
+}
+
+// CreateBranch on Bitbucket server
+func (client *BitbucketServerClient) CreateBranch(ctx context.Context, owner, repository, sourceRef, newBranch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sourceRef": sourceRef, "newBranch": newBranch})
+	if err != nil {
+		return err
+	}
+	// The client library doesn't support sending the new branch details, hence the branch is created directly
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/branches", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, url, bitbucketServerCreateBranchRequest{Name: newBranch, StartPoint: sourceRef}, nil)
+}
+
+// DeleteBranch on Bitbucket server
+func (client *BitbucketServerClient) DeleteBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	// Branches are deleted through the branch utils REST API, which isn't supported by the client library
+	url := fmt.Sprintf("%s/rest/branch-utils/1.0/projects/%s/repos/%s/branches", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository)
+	return client.sendRequestWithJsonBody(ctx, http.MethodDelete, url, bitbucketServerDeleteBranchRequest{Name: vcsutils.AddBranchPrefix(branch)}, nil)
+}
+
+// GetDefaultBranch on Bitbucket server
+func (client *BitbucketServerClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return "", err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	apiResponse, err := bitbucketClient.GetDefaultBranch(owner, repository)
+	if err != nil {
+		return "", err
+	}
+	defaultBranch := struct {
+		DisplayID string `mapstructure:"displayId"`
+	}{}
+	if err = mapstructure.Decode(apiResponse.Values, &defaultBranch); err != nil {
+		return "", err
+	}
+	return defaultBranch.DisplayID, nil
+}
+
+// SetDefaultBranch on Bitbucket server
+func (client *BitbucketServerClient) SetDefaultBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	// The client library doesn't support sending the default branch, hence it is set directly
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/branches/default", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPut, url, bitbucketServerSetDefaultBranchRequest{Id: vcsutils.AddBranchPrefix(branch)}, nil)
+}
+
+type bitbucketServerSetDefaultBranchRequest struct {
+	Id string `json:"id"`
+}
+
+// GetBranchProtection on Bitbucket server.
+// Pushes are restricted by a pull-request-only branch restriction.
+func (client *BitbucketServerClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (BranchProtectionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	restrictions, err := client.getPullRequestOnlyRestrictions(ctx, owner, repository, branch)
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	return BranchProtectionInfo{RestrictPushes: len(restrictions) > 0}, nil
+}
+
+// SetBranchProtection on Bitbucket server.
+// Pushes are restricted by a pull-request-only branch restriction. Required approvals and status checks aren't supported.
+func (client *BitbucketServerClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtectionInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	if protection.RequiredApprovals > 0 {
+		return errBitbucketServerRequiredApprovalsNotSupported
+	}
+	if len(protection.RequiredStatusChecks) > 0 {
+		return errBitbucketRequiredStatusChecksNotSupported
+	}
+	restrictions, err := client.getPullRequestOnlyRestrictions(ctx, owner, repository, branch)
+	if err != nil {
+		return err
+	}
+	if protection.RestrictPushes {
+		if len(restrictions) > 0 {
+			return nil
+		}
+		return client.sendRequestWithJsonBody(ctx, http.MethodPost, client.getBranchRestrictionsUrl(owner, repository), bitbucketServerBranchRestriction{
+			Type: bitbucketServerPullRequestOnlyRestriction,
+			Matcher: bitbucketServerBranchRestrictionMatcher{
+				Id:        vcsutils.AddBranchPrefix(branch),
+				DisplayId: branch,
+				Type:      bitbucketServerBranchRestrictionMatcherType{Id: "BRANCH", Name: "Branch"},
+				Active:    true,
+			},
+			Users:      []string{},
+			Groups:     []string{},
+			AccessKeys: []int{},
+		}, nil)
+	}
+	for _, restriction := range restrictions {
+		err = client.sendRequestWithJsonBody(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", client.getBranchRestrictionsUrl(owner, repository), restriction.Id), nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Branch restrictions are managed through the branch permissions REST API, which isn't supported by the client library
+func (client *BitbucketServerClient) getBranchRestrictionsUrl(owner, repository string) string {
+	return fmt.Sprintf("%s/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository)
+}
+
+func (client *BitbucketServerClient) getPullRequestOnlyRestrictions(ctx context.Context, owner, repository, branch string) ([]bitbucketServerBranchRestriction, error) {
+	query := neturl.Values{
+		"matcherType": []string{"BRANCH"},
+		"matcherId":   []string{vcsutils.AddBranchPrefix(branch)},
+		"type":        []string{bitbucketServerPullRequestOnlyRestriction},
+		"limit":       []string{"100"},
+	}
+	var restrictions struct {
+		Values []bitbucketServerBranchRestriction `json:"values"`
+	}
+	err := client.sendRequestWithJsonBody(ctx, http.MethodGet, client.getBranchRestrictionsUrl(owner, repository)+"?"+query.Encode(), nil, &restrictions)
+	return restrictions.Values, err
+}
+
+const bitbucketServerPullRequestOnlyRestriction = "pull-request-only"
+
+type bitbucketServerBranchRestriction struct {
+	Id         int                                     `json:"id,omitempty"`
+	Type       string                                  `json:"type"`
+	Matcher    bitbucketServerBranchRestrictionMatcher `json:"matcher"`
+	Users      []string                                `json:"users"`
+	Groups     []string                                `json:"groups"`
+	AccessKeys []int                                   `json:"accessKeys"`
+}
+
+type bitbucketServerBranchRestrictionMatcher struct {
+	Id        string                                      `json:"id"`
+	DisplayId string                                      `json:"displayId"`
+	Type      bitbucketServerBranchRestrictionMatcherType `json:"type"`
+	Active    bool                                        `json:"active"`
+}
+
+type bitbucketServerBranchRestrictionMatcherType struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type bitbucketServerCreateBranchRequest struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
+}
+
+type bitbucketServerDeleteBranchRequest struct {
+	Name   string `json:"name"`
+	DryRun bool   `json:"dryRun"`
+}
+
+// ListTags on Bitbucket server
+func (client *BitbucketServerClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	var results []TagInfo
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		apiResponse, err = bitbucketClient.GetTags(owner, repository, createPaginationOptions(nextPageStart))
+		if err != nil {
+			return nil, err
+		}
+		tags, err := bitbucketv1.GetTagsResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			results = append(results, TagInfo{Name: tag.DisplayID, CommitSha: tag.LatestCommit})
+		}
+	}
+	return results, nil
+}
+
+// CreateTag on Bitbucket server
+func (client *BitbucketServerClient) CreateTag(ctx context.Context, owner, repository, tagName, sourceRef, message string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName, "sourceRef": sourceRef})
+	if err != nil {
+		return err
+	}
+	// The client library doesn't support sending the new tag details, hence the tag is created directly
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/tags", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, url, bitbucketServerCreateTagRequest{Name: tagName, StartPoint: sourceRef, Message: message}, nil)
+}
+
+// GetTagInfo on Bitbucket server
+func (client *BitbucketServerClient) GetTagInfo(ctx context.Context, owner, repository, tagName string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	apiResponse, err := bitbucketClient.GetTag(owner, repository, tagName)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	tag := bitbucketv1.Tag{}
+	if err = unmarshalAPIResponseValues(apiResponse, &tag); err != nil {
+		return CommitInfo{}, err
+	}
+	return client.GetCommitBySha(ctx, owner, repository, tag.LatestCommit)
+}
+
+// CreateRelease on Bitbucket server
+func (client *BitbucketServerClient) CreateRelease(ctx context.Context, owner, repository, tagName, name, releaseNotes string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errBitbucketServerReleasesNotSupported
+}
+
+// ListReleases on Bitbucket server
+func (client *BitbucketServerClient) ListReleases(ctx context.Context, owner, repository string) ([]ReleaseInfo, error) {
+	return nil, errBitbucketServerReleasesNotSupported
+}
+
+// GetLatestRelease on Bitbucket server
+func (client *BitbucketServerClient) GetLatestRelease(ctx context.Context, owner, repository string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errBitbucketServerReleasesNotSupported
+}
+
+// UploadReleaseAsset on Bitbucket server
+func (client *BitbucketServerClient) UploadReleaseAsset(ctx context.Context, owner, repository, releaseID, name string, asset io.Reader) (string, error) {
+	return "", errBitbucketUploadReleaseAssetNotSupported
+}
+
+// A message makes the created tag an annotated tag
+type bitbucketServerCreateTagRequest struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
+	Message    string `json:"message,omitempty"`
+}
+
 // AddSshKeyToRepository on Bitbucket server
 func (client *BitbucketServerClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) (err error) {
 	// https://docs.atlassian.com/bitbucket-server/rest/5.16.0/bitbucket-ssh-rest.html
@@ -143,16 +515,28 @@ func (client *BitbucketServerClient) AddSshKeyToRepository(ctx context.Context,
 		Permission: accessPermission,
 	}
 
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, url, addKeyRequest, nil)
+}
+
+// sendRequestWithJsonBody sends a request which isn't supported by the Bitbucket server client library.
+// The request body is sent only if requestBody isn't nil, and the response body is decoded only if responseBody isn't nil.
+func (client *BitbucketServerClient) sendRequestWithJsonBody(ctx context.Context, method, url string, requestBody, responseBody interface{}) error {
 	body := new(bytes.Buffer)
-	err = json.NewEncoder(body).Encode(addKeyRequest)
-	if err != nil {
-		return err
+	if requestBody != nil {
+		if err := json.NewEncoder(body).Encode(requestBody); err != nil {
+			return err
+		}
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	return client.sendRequest(ctx, method, url, body, "application/json", responseBody)
+}
+
+// sendRequest sends a request with the given body and content type, and decodes the response body into responseBody if it isn't nil.
+func (client *BitbucketServerClient) sendRequest(ctx context.Context, method, url string, body io.Reader, contentType string, responseBody interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 
 	httpClient := client.buildHTTPClient(ctx)
 	response, err := httpClient.Do(req)
@@ -171,6 +555,9 @@ func (client *BitbucketServerClient) AddSshKeyToRepository(ctx context.Context,
 		}
 		return fmt.Errorf("status: %v, body: %s", response.Status, bodyBytes)
 	}
+	if responseBody != nil {
+		return json.NewDecoder(response.Body).Decode(responseBody)
+	}
 	return nil
 }
 
@@ -201,6 +588,11 @@ func (client *BitbucketServerClient) CreateWebhook(ctx context.Context, owner, r
 	return webhoodID, token, err
 }
 
+// CreateOrUpdateWebhook on Bitbucket server
+func (client *BitbucketServerClient) CreateOrUpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	return createOrUpdateWebhook(ctx, client, owner, repository, branch, payloadURL, webhookEvents...)
+}
+
 // UpdateWebhook on Bitbucket server
 func (client *BitbucketServerClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
 	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
@@ -225,6 +617,70 @@ func (client *BitbucketServerClient) DeleteWebhook(ctx context.Context, owner, r
 	return err
 }
 
+// ListWebhooks on Bitbucket server
+func (client *BitbucketServerClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	// The webhooks API isn't paginated
+	apiResponse, err := bitbucketClient.FindWebhooks(owner, repository, nil)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := bitbucketv1.GetWebhooksResponse(apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]WebhookInfo, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		results = append(results, mapBitbucketServerWebhookToWebhookInfo(webhook))
+	}
+	return results, nil
+}
+
+// GetWebhook on Bitbucket server
+func (client *BitbucketServerClient) GetWebhook(ctx context.Context, owner, repository, webhookID string) (WebhookInfo, error) {
+	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	response, err := bitbucketClient.GetWebhook(owner, repository, int32(webhookIDInt32), nil)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	webhook := bitbucketv1.Webhook{}
+	if err = unmarshalAPIResponseValues(response, &webhook); err != nil {
+		return WebhookInfo{}, err
+	}
+	return mapBitbucketServerWebhookToWebhookInfo(webhook), nil
+}
+
+// RotateWebhookSecret on Bitbucket server
+func (client *BitbucketServerClient) RotateWebhookSecret(ctx context.Context, owner, repository, webhookID string) (string, error) {
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	webhookIDInt32, err := strconv.ParseInt(webhookID, 10, 32)
+	if err != nil {
+		return "", err
+	}
+	response, err := bitbucketClient.GetWebhook(owner, repository, int32(webhookIDInt32), nil)
+	if err != nil {
+		return "", err
+	}
+	webhook := &bitbucketv1.Webhook{}
+	if err = unmarshalAPIResponseValues(response, webhook); err != nil {
+		return "", err
+	}
+	token := vcsutils.CreateToken()
+	webhook.Configuration.Secret = token
+	if _, err = bitbucketClient.UpdateWebhook(owner, repository, int32(webhookIDInt32), webhook, []string{}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
 // SetCommitStatus on Bitbucket server
 func (client *BitbucketServerClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, _, _, ref, title,
 	description, detailsURL string) error {
@@ -248,25 +704,104 @@ func (client *BitbucketServerClient) GetCommitStatuses(ctx context.Context, owne
 	return bitbucketParseCommitStatuses(response.Values, vcsutils.BitbucketServer)
 }
 
+// GetCombinedCommitStatus on Bitbucket server.
+// Combines the build statuses of the ref.
+func (client *BitbucketServerClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	statuses, err := client.GetCommitStatuses(ctx, owner, repository, ref)
+	if err != nil {
+		return Error, err
+	}
+	return combineCommitStatusInfos(statuses), nil
+}
+
+// TriggerPipeline on Bitbucket server
+func (client *BitbucketServerClient) TriggerPipeline(_ context.Context, _, _, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
+// GetLatestPipelineStatus on Bitbucket server
+func (client *BitbucketServerClient) GetLatestPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
+// GetPipelineStatus on Bitbucket server
+func (client *BitbucketServerClient) GetPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
+// TriggerWorkflow on Bitbucket server
+func (client *BitbucketServerClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return errBitbucketServerPipelinesNotSupported
+}
+
+// GetWorkflowRunStatus on Bitbucket server
+func (client *BitbucketServerClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
+// QueueBuild on Bitbucket server
+func (client *BitbucketServerClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
+// GetBuildStatus on Bitbucket server
+func (client *BitbucketServerClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errBitbucketServerPipelinesNotSupported
+}
+
 // DownloadRepository on Bitbucket server
 func (client *BitbucketServerClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
-	bitbucketClient := client.buildBitbucketClient(ctx)
-	params := map[string]interface{}{"format": "tgz"}
-	branch = strings.TrimSpace(branch)
-	if branch != "" {
-		params["at"] = branch
-	}
-	response, err := bitbucketClient.GetArchive(owner, repository, params)
+	return client.downloadRepository(ctx, owner, repository, branch, localPath, nil)
+}
+
+// DownloadRepositoryPaths on Bitbucket server
+func (client *BitbucketServerClient) DownloadRepositoryPaths(ctx context.Context, owner, repository, ref string, paths []string, localPath string) error {
+	paths, err := normalizeDownloadPaths(paths)
 	if err != nil {
 		return err
 	}
-	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
-	err = vcsutils.Untar(localPath, bytes.NewReader(response.Payload), false)
+	return client.downloadRepository(ctx, owner, repository, ref, localPath, paths)
+}
+
+func (client *BitbucketServerClient) downloadRepository(ctx context.Context, owner, repository, branch, localPath string, paths []string) (err error) {
+	format, err := getArchiveFormat(client.vcsInfo, vcsutils.TarGzArchive)
 	if err != nil {
 		return err
 	}
-	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
-	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
+	query := neturl.Values{"format": {"tgz"}}
+	if format == vcsutils.ZipArchive {
+		query.Set("format", "zip")
+	}
+	branch = strings.TrimSpace(branch)
+	if branch != "" {
+		query.Set("at", branch)
+	}
+	// Only the given paths are archived, unless the root of the repository is one of them
+	if len(paths) > 0 && !slices.Contains(paths, "") {
+		query["path"] = paths
+	}
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/archive?%s",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	// The archive is streamed into the extraction, rather than loaded into memory
+	response, err := client.buildHTTPClient(ctx).Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, response.Body.Close()) }()
+	if err = vcsutils.CheckResponseStatusWithBody(response, http.StatusOK); err != nil {
+		return err
+	}
+	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
+	err = vcsutils.ExtractArchive(localPath, vcsutils.LimitReader(response.Body, client.vcsInfo.MaxDownloadSize), format, false, paths, client.vcsInfo.ExtractionLimits)
+	if err != nil {
+		return err
+	}
+	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
 	if err != nil {
 		return err
 	}
@@ -287,7 +822,17 @@ func (client *BitbucketServerClient) GetPullRequestDetailsSizeLimit() int {
 
 // CreatePullRequest on Bitbucket server
 func (client *BitbucketServerClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
-	title, description string) error {
+	title, description string) (PullRequestInfo, error) {
+	return client.CreatePullRequestWithOptions(ctx, owner, repository, sourceBranch, targetBranch, title, description, CreatePullRequestOptions{})
+}
+
+// CreatePullRequestWithOptions on Bitbucket server
+func (client *BitbucketServerClient) CreatePullRequestWithOptions(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string, createOptions CreatePullRequestOptions) (PullRequestInfo, error) {
+	if err := validateCreatePullRequestOptions(createOptions, notSupportedOnBitbucket+" server", draftPullRequestOption, labelsPullRequestOption,
+		assigneesPullRequestOption, milestonePullRequestOption, deleteSourceBranchPullRequestOption, workItemsPullRequestOption); err != nil {
+		return PullRequestInfo{}, err
+	}
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	bitbucketRepo := &bitbucketv1.Repository{
 		Slug: repository,
@@ -307,30 +852,134 @@ func (client *BitbucketServerClient) CreatePullRequest(ctx context.Context, owne
 			Repository: *bitbucketRepo,
 		},
 	}
-	_, err := bitbucketClient.CreatePullRequest(owner, repository, options)
-	return err
+	for _, reviewer := range createOptions.Reviewers {
+		options.Reviewers = append(options.Reviewers, bitbucketv1.UserWithMetadata{User: bitbucketv1.UserWithLinks{Name: reviewer}})
+	}
+	apiResponse, err := bitbucketClient.CreatePullRequest(owner, repository, options)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(apiResponse)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, true, owner)
 }
 
 // UpdatePullRequest on bitbucket server
-// Changing targetBranchRef currently not supported.
+// Closing a pull request declines it, and opening a declined pull request reopens it.
 func (client *BitbucketServerClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchRef string, prId int, state vcsutils.PullRequestState) (err error) {
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	apiResponse, err := bitbucketClient.GetPullRequest(owner, repository, prId)
 	if err != nil {
 		return
 	}
-	version := apiResponse.Values["version"]
-	editOptions := bitbucketv1.EditPullRequestOptions{
-		Version:     fmt.Sprintf("%v", version),
-		ID:          int64(prId),
-		State:       *vcsutils.MapPullRequestState(&state),
-		Title:       title,
-		Description: body,
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(apiResponse)
+	if err != nil {
+		return
+	}
+	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
+	version := pullRequest.Version
+	if title != "" || body != "" || targetBranchRef != "" {
+		if version, err = client.editPullRequest(ctx, owner, repository, prId, pullRequest, title, body, targetBranchRef); err != nil {
+			return
+		}
+	}
+	versionOptions := map[string]interface{}{"version": version}
+	switch {
+	case state == vcsutils.Closed && pullRequest.State == bitbucketServerOpenPullRequestState:
+		_, err = bitbucketClient.Decline(owner, repository, int64(prId), versionOptions)
+	case state == vcsutils.Open && pullRequest.State == bitbucketServerDeclinedPullRequestState:
+		_, err = bitbucketClient.Reopen(owner, repository, int64(prId), versionOptions)
+	}
+	return
+}
+
+// The Bitbucket server client library doesn't send the target branch, hence the pull request is edited directly.
+// Returns the version of the pull request after the edit.
+func (client *BitbucketServerClient) editPullRequest(ctx context.Context, owner, repository string, prId int, pullRequest bitbucketv1.PullRequest, title, body, targetBranchRef string) (int32, error) {
+	editRequest := bitbucketServerEditPullRequestRequest{
+		Version:     pullRequest.Version,
+		Title:       pullRequest.Title,
+		Description: pullRequest.Description,
 	}
-	_, err = bitbucketClient.UpdatePullRequest(owner, repository, &editOptions)
+	if title != "" {
+		editRequest.Title = title
+	}
+	if body != "" {
+		editRequest.Description = body
+	}
+	if targetBranchRef != "" {
+		editRequest.ToRef = &bitbucketServerRef{ID: vcsutils.AddBranchPrefix(targetBranchRef)}
+	}
+	url := fmt.Sprintf("%s/api/1.0/projects/%s/repos/%s/pull-requests/%d", client.vcsInfo.APIEndpoint, owner, repository, prId)
+	var editedPullRequest bitbucketv1.PullRequest
+	if err := client.sendRequestWithJsonBody(ctx, http.MethodPut, url, editRequest, &editedPullRequest); err != nil {
+		return 0, err
+	}
+	return editedPullRequest.Version, nil
+}
+
+type bitbucketServerEditPullRequestRequest struct {
+	Version     int32               `json:"version"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	ToRef       *bitbucketServerRef `json:"toRef,omitempty"`
+}
+
+type bitbucketServerRef struct {
+	ID string `json:"id"`
+}
+
+// MergePullRequest on Bitbucket server
+func (client *BitbucketServerClient) MergePullRequest(ctx context.Context, owner, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	strategyID, err := getBitbucketServerMergeStrategyID(mergeMethod)
+	if err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	apiResponse, err := bitbucketClient.GetPullRequest(owner, repository, prId)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(apiResponse)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	_, err = bitbucketClient.Merge(owner, repository, prId, map[string]interface{}{"version": pullRequest.Version},
+		map[string]string{"strategyId": strategyID}, []string{"application/json"})
 	return err
 }
 
+type bitbucketServerAutoMergeRequest struct {
+	AutoMerge  bool   `json:"autoMerge"`
+	StrategyID string `json:"strategyId"`
+}
+
+// SetPullRequestAutoMerge on Bitbucket server.
+// The pull request is merged once its merge checks pass. Auto-merge requires Bitbucket Data Center 8.15 or later.
+func (client *BitbucketServerClient) SetPullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod vcsutils.MergeMethod) error {
+	strategyID, err := getBitbucketServerMergeStrategyID(mergeMethod)
+	if err != nil {
+		return err
+	}
+	if err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	apiResponse, err := client.buildBitbucketClient(ctx).GetPullRequest(owner, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := bitbucketv1.GetPullRequestResponse(apiResponse)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge?version=%d",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, pullRequestID, pullRequest.Version)
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, url, bitbucketServerAutoMergeRequest{AutoMerge: true, StrategyID: strategyID}, nil)
+}
+
 // ListOpenPullRequestsWithBody on Bitbucket server
 func (client *BitbucketServerClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
 	return client.getOpenPullRequests(ctx, owner, repository, true)
@@ -341,6 +990,93 @@ func (client *BitbucketServerClient) ListOpenPullRequests(ctx context.Context, o
 	return client.getOpenPullRequests(ctx, owner, repository, false)
 }
 
+// ListOpenPullRequestsWithOptions on Bitbucket server
+func (client *BitbucketServerClient) ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	queryOptions := map[string]interface{}{
+		"state": "OPEN",
+		"start": (page - 1) * perPage,
+		"limit": perPage,
+	}
+	// The API can filter by a single branch, which is the target branch unless the direction is outgoing
+	if options.TargetBranch != "" {
+		queryOptions["at"] = vcsutils.AddBranchPrefix(options.TargetBranch)
+	} else if options.SourceBranch != "" {
+		queryOptions["at"] = vcsutils.AddBranchPrefix(options.SourceBranch)
+		queryOptions["direction"] = "OUTGOING"
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	apiResponse, err := bitbucketClient.GetPullRequestsPage(owner, repository, queryOptions)
+	if err != nil {
+		return nil, err
+	}
+	pullRequests, err := bitbucketv1.GetPullRequestsResponse(apiResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	// The remaining filters aren't supported by the API, hence the pull requests of the page are filtered here
+	var results []PullRequestInfo
+	for _, pullRequest := range pullRequests {
+		pullRequestInfo, err := mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, options.WithBody, owner)
+		if err != nil {
+			return nil, err
+		}
+		if options.matches(pullRequestInfo) {
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
+var errBitbucketServerCommentNotFound = errors.New("pull request comment not found")
+
+var bitbucketServerPullRequestStates = map[vcsutils.PullRequestState]string{
+	vcsutils.Open:   "OPEN",
+	vcsutils.Closed: "DECLINED",
+	vcsutils.Merged: "MERGED",
+}
+
+// ListPullRequestsWithState on Bitbucket server
+func (client *BitbucketServerClient) ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	var results []PullRequestInfo
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		queryOptions := createPaginationOptions(nextPageStart)
+		queryOptions["state"] = bitbucketServerPullRequestStates[state]
+		apiResponse, err = bitbucketClient.GetPullRequestsPage(owner, repository, queryOptions)
+		if err != nil {
+			return nil, err
+		}
+		var pullRequests []bitbucketv1.PullRequest
+		pullRequests, err = bitbucketv1.GetPullRequestsResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range pullRequests {
+			var pullRequestInfo PullRequestInfo
+			if pullRequestInfo, err = mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, true, owner); err != nil {
+				return nil, err
+			}
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
 func (client *BitbucketServerClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
 	bitbucketClient := client.buildBitbucketClient(ctx)
 	var results []PullRequestInfo
@@ -369,7 +1105,7 @@ func (client *BitbucketServerClient) getOpenPullRequests(ctx context.Context, ow
 	return results, nil
 }
 
-// GetPullRequestInfoById on bitbucket server
+// GetPullRequestByID on bitbucket server
 func (client *BitbucketServerClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
 	client.logger.Debug("fetching pull request by ID in ", repository)
 	bitbucketClient := client.buildBitbucketClient(ctx)
@@ -386,7 +1122,7 @@ func (client *BitbucketServerClient) GetPullRequestByID(ctx context.Context, own
 	if err != nil {
 		return
 	}
-	pullRequestInfo, err = mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, false, owner)
+	pullRequestInfo, err = mapBitbucketServerPullRequestToPullRequestInfo(pullRequest, true, owner)
 	return
 }
 
@@ -399,12 +1135,22 @@ func mapBitbucketServerPullRequestToPullRequestInfo(pullRequest bitbucketv1.Pull
 	if withBody {
 		body = pullRequest.Description
 	}
+	var author string
+	if pullRequest.Author != nil {
+		author = pullRequest.Author.User.Name
+	}
 	return PullRequestInfo{
-		ID:     int64(pullRequest.ID),
-		Source: BranchInfo{Name: pullRequest.FromRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: sourceOwner},
-		Target: BranchInfo{Name: pullRequest.ToRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: owner},
-		Body:   body,
-		URL:    pullRequest.Links.Self[0].Href,
+		ID:        int64(pullRequest.ID),
+		Title:     pullRequest.Title,
+		Source:    BranchInfo{Name: pullRequest.FromRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: sourceOwner},
+		Target:    BranchInfo{Name: pullRequest.ToRef.DisplayID, Repository: pullRequest.ToRef.Repository.Slug, Owner: owner},
+		Body:      body,
+		URL:       pullRequest.Links.Self[0].Href,
+		Author:    author,
+		State:     mapBitbucketPullRequestState(pullRequest.State),
+		Mergeable: pullRequest.Properties.MergeResult.Outcome == "CLEAN",
+		CreatedAt: time.UnixMilli(pullRequest.CreatedDate),
+		UpdatedAt: time.UnixMilli(pullRequest.UpdatedDate),
 	}, nil
 }
 
@@ -455,7 +1201,11 @@ func (client *BitbucketServerClient) addPullRequestComment(ctx context.Context,
 
 // ListPullRequestReviewComments on Bitbucket server
 func (client *BitbucketServerClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
-	return client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return filterReviewComments(comments), nil
 }
 
 // ListPullRequestComments on Bitbucket server
@@ -477,10 +1227,16 @@ func (client *BitbucketServerClient) ListPullRequestComments(ctx context.Context
 			// Add activity only if from type new comment.
 			if activity.Action == "COMMENTED" && activity.CommentAction == "ADDED" {
 				results = append(results, CommentInfo{
-					ID:      int64(activity.Comment.ID),
-					Created: time.Unix(activity.Comment.CreatedDate, 0),
-					Content: activity.Comment.Text,
-					Version: activity.Comment.Version,
+					ID:             int64(activity.Comment.ID),
+					NativeID:       strconv.Itoa(activity.Comment.ID),
+					Author:         activity.Comment.Author.DisplayName,
+					AuthorUsername: activity.Comment.Author.Name,
+					Created:        time.Unix(activity.Comment.CreatedDate, 0),
+					Updated:        time.UnixMilli(activity.Comment.UpdatedDate),
+					Content:        activity.Comment.Text,
+					Version:        activity.Comment.Version,
+					FilePath:       activity.CommentAnchor.Path,
+					Line:           activity.CommentAnchor.Line,
 				})
 			}
 		}
@@ -488,6 +1244,16 @@ func (client *BitbucketServerClient) ListPullRequestComments(ctx context.Context
 	return results, nil
 }
 
+// ListPullRequestCommentsWithOptions on Bitbucket server.
+// The comments are listed from the activities of the pull request, hence the page is taken from all the matching comments.
+func (client *BitbucketServerClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return getPullRequestCommentsPage(comments, options), nil
+}
+
 // DeletePullRequestReviewComments on Bitbucket server
 func (client *BitbucketServerClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
 	for _, comment := range comments {
@@ -498,24 +1264,161 @@ func (client *BitbucketServerClient) DeletePullRequestReviewComments(ctx context
 	return nil
 }
 
+type bitbucketServerUpdateCommentRequest struct {
+	Text           string `json:"text"`
+	Version        int    `json:"version"`
+	ThreadResolved *bool  `json:"threadResolved,omitempty"`
+}
+
+// UpdatePullRequestComment on Bitbucket Server
+func (client *BitbucketServerClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID, commentID int) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content}); err != nil {
+		return err
+	}
+	commentVersion, err := client.getPullRequestCommentVersion(ctx, owner, repository, pullRequestID, commentID)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, pullRequestID, commentID)
+	if err = client.sendRequestWithJsonBody(ctx, http.MethodPut, url, bitbucketServerUpdateCommentRequest{Text: content, Version: commentVersion}, nil); err != nil {
+		return fmt.Errorf("an error occurred while updating pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
 // DeletePullRequestComment on Bitbucket Server
 func (client *BitbucketServerClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
 	bitbucketClient := client.buildBitbucketClient(ctx)
+	// A comment which isn't listed is deleted with the initial version, and Bitbucket server reports whether it exists
+	commentVersion, err := client.getPullRequestCommentVersion(ctx, owner, repository, pullRequestID, commentID)
+	if err != nil && !errors.Is(err, errBitbucketServerCommentNotFound) {
+		return err
+	}
+	if _, err = bitbucketClient.DeleteComment_2(owner, repository, int64(pullRequestID), int64(commentID), map[string]interface{}{"version": int32(commentVersion)}); err != nil && err != io.EOF {
+		return fmt.Errorf("an error occurred while deleting pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// SetPullRequestThreadStatus on Bitbucket server.
+// The thread is identified by the ID of its first comment, whose current version is required to update it.
+func (client *BitbucketServerClient) SetPullRequestThreadStatus(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "thread ID": threadID}); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%s",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, pullRequestID, neturl.PathEscape(threadID))
+	var comment bitbucketServerUpdateCommentRequest
+	if err := client.sendRequestWithJsonBody(ctx, http.MethodGet, url, nil, &comment); err != nil {
+		return err
+	}
+	comment.ThreadResolved = &resolved
+	return client.sendRequestWithJsonBody(ctx, http.MethodPut, url, comment, nil)
+}
+
+// getPullRequestCommentVersion returns the current version of a pull request comment, which Bitbucket server requires to modify it.
+func (client *BitbucketServerClient) getPullRequestCommentVersion(ctx context.Context, owner, repository string, pullRequestID, commentID int) (int, error) {
 	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	commentVersion := 0
 	for _, comment := range comments {
 		if comment.ID == int64(commentID) {
-			commentVersion = comment.Version
-			break
+			return comment.Version, nil
 		}
 	}
-	if _, err = bitbucketClient.DeleteComment_2(owner, repository, int64(pullRequestID), int64(commentID), map[string]interface{}{"version": int32(commentVersion)}); err != nil && err != io.EOF {
-		return fmt.Errorf("an error occurred while deleting pull request comment:\n%s", err.Error())
+	return 0, fmt.Errorf("%w: comment %d of pull request %d", errBitbucketServerCommentNotFound, commentID, pullRequestID)
+}
+
+type bitbucketServerParticipantStatusRequest struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+	Approved bool   `json:"approved"`
+	Status   string `json:"status"`
+}
+
+var bitbucketServerParticipantStatuses = map[vcsutils.ReviewVerdict]string{
+	vcsutils.ReviewVerdictApprove:        "APPROVED",
+	vcsutils.ReviewVerdictRequestChanges: "NEEDS_WORK",
+}
+
+// CreatePullRequestReview on Bitbucket server.
+// The review sets the participant status of the client's user, and the body is added as a pull request comment.
+func (client *BitbucketServerClient) CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
 	}
-	return nil
+	if err := validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	if status, ok := bitbucketServerParticipantStatuses[verdict]; ok {
+		if err := validateParametersNotBlank(map[string]string{"username": client.vcsInfo.Username}); err != nil {
+			return err
+		}
+		statusRequest := bitbucketServerParticipantStatusRequest{Approved: verdict == vcsutils.ReviewVerdictApprove, Status: status}
+		statusRequest.User.Name = client.vcsInfo.Username
+		url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/participants/%s",
+			strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, pullRequestID, client.vcsInfo.Username)
+		if err := client.sendRequestWithJsonBody(ctx, http.MethodPut, url, statusRequest, nil); err != nil {
+			return err
+		}
+	}
+	if body == "" {
+		return nil
+	}
+	return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+}
+
+// ListPullRequestReviews on Bitbucket server.
+// The latest approval or "needs work" activity of each participant is returned, unless it was later revoked.
+func (client *BitbucketServerClient) ListPullRequestReviews(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	var reviews []PullRequestReviewInfo
+	// Activities are returned from the newest to the oldest, so only the first review activity of each user is relevant
+	reviewedUsers := datastructures.MakeSet[string]()
+	var apiResponse *bitbucketv1.APIResponse
+	for isLastPage, nextPageStart := true, 0; isLastPage; isLastPage, nextPageStart = bitbucketv1.HasNextPage(apiResponse) {
+		var err error
+		apiResponse, err = bitbucketClient.GetActivities(owner, repository, int64(pullRequestID), createPaginationOptions(nextPageStart))
+		if err != nil {
+			return nil, err
+		}
+		activities, err := bitbucketv1.GetActivitiesResponse(apiResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, activity := range activities.Values {
+			var state vcsutils.ReviewVerdict
+			switch activity.Action {
+			case bitbucketv1.ActionApproved:
+				state = vcsutils.ReviewVerdictApprove
+			case "REVIEWED":
+				state = vcsutils.ReviewVerdictRequestChanges
+			case "UNAPPROVED":
+				// A revoked approval hides the earlier reviews of the user
+			default:
+				continue
+			}
+			if reviewedUsers.Exists(activity.User.Name) {
+				continue
+			}
+			reviewedUsers.Add(activity.User.Name)
+			if state == "" {
+				continue
+			}
+			reviews = append(reviews, PullRequestReviewInfo{
+				Reviewer:  activity.User.Name,
+				State:     state,
+				Submitted: time.UnixMilli(int64(activity.CreatedDate)),
+			})
+		}
+	}
+	return reviews, nil
 }
 
 type projectsResponse struct {
@@ -526,89 +1429,312 @@ type projectsResponse struct {
 
 // GetLatestCommit on Bitbucket server
 func (client *BitbucketServerClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
-	commits, err := client.GetCommits(ctx, owner, repository, branch)
+	commits, err := client.ListCommits(ctx, owner, repository, ListCommitsOptions{Branch: branch, PerPage: 1})
 	if err != nil {
 		return CommitInfo{}, err
 	}
 
-	latestCommit := CommitInfo{}
-	if len(commits) > 0 {
-		latestCommit = commits[0]
-	}
-	return latestCommit, nil
+	latestCommit := CommitInfo{}
+	if len(commits) > 0 {
+		latestCommit = commits[0]
+	}
+	return latestCommit, nil
+}
+
+// GetCommits on Bitbucket server
+func (client *BitbucketServerClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options := map[string]interface{}{
+		"limit": vcsutils.NumberOfCommitsToFetch,
+		"until": branch,
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+
+	apiResponse, err := bitbucketClient.GetCommits(owner, repository, options)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := bitbucketv1.GetCommitsResponse(apiResponse)
+	if err != nil {
+		return nil, err
+	}
+	var commitsInfo []CommitInfo
+	for _, commit := range commits {
+		commitInfo := client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository)
+		commitsInfo = append(commitsInfo, commitInfo)
+	}
+	return commitsInfo, nil
+}
+
+// ListCommits on Bitbucket server
+func (client *BitbucketServerClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     options.Branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	queryOptions := map[string]interface{}{
+		"until": options.Branch,
+		"start": (page - 1) * perPage,
+		"limit": perPage,
+	}
+	if options.Path != "" {
+		queryOptions["path"] = options.Path
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	apiResponse, err := bitbucketClient.GetCommits(owner, repository, queryOptions)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := bitbucketv1.GetCommitsResponse(apiResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API doesn't support filtering by time or author, hence the commits of the page are filtered here
+	var commitsInfo []CommitInfo
+	for _, commit := range commits {
+		commitInfo := client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository)
+		if options.matches(commitInfo, time.UnixMilli(commit.CommitterTimestamp)) {
+			commitsInfo = append(commitsInfo, commitInfo)
+		}
+	}
+	return commitsInfo, nil
+}
+
+// GetRepositoryInfo on Bitbucket server.
+// The default branch isn't part of the repository, hence it's fetched by another request.
+func (client *BitbucketServerClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	bitbucketClient := client.buildBitbucketClient(ctx)
+
+	repo, err := bitbucketClient.GetRepository(owner, repository)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	holder := struct {
+		Links struct {
+			Clone []struct {
+				Name string `mapstructure:"name"`
+				HRef string `mapstructure:"href"`
+			} `mapstructure:"clone"`
+		} `mapstructure:"links"`
+		Public      bool   `mapstructure:"public"`
+		Archived    bool   `mapstructure:"archived"`
+		Description string `mapstructure:"description"`
+	}{}
+
+	if err := mapstructure.Decode(repo.Values, &holder); err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	var info CloneInfo
+	for _, cloneLink := range holder.Links.Clone {
+		switch cloneLink.Name {
+		case "http":
+			info.HTTP = cloneLink.HRef
+		case "ssh":
+			info.SSH = cloneLink.HRef
+		}
+	}
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repository)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return RepositoryInfo{
+		RepositoryVisibility: getBitbucketServerRepositoryVisibility(holder.Public),
+		CloneInfo:            info,
+		DefaultBranch:        defaultBranch,
+		Archived:             holder.Archived,
+		Description:          holder.Description,
+	}, nil
+}
+
+// CreateRepository on Bitbucket server.
+// The owner is the project key, or ~username for a personal repository.
+func (client *BitbucketServerClient) CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	if options.InitReadme {
+		return RepositoryInfo{}, errBitbucketInitReadmeNotSupported
+	}
+	// The client library doesn't support sending the default branch, hence the repository is created directly
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner)
+	var repo bitbucketv1.Repository
+	err := client.sendRequestWithJsonBody(ctx, http.MethodPost, url, bitbucketServerCreateRepositoryRequest{
+		Name:          repository,
+		ScmId:         "git",
+		Public:        !options.Private,
+		Description:   options.Description,
+		DefaultBranch: options.DefaultBranch,
+	}, &repo)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapBitbucketServerRepositoryToRepositoryInfo(repo), nil
+}
+
+type bitbucketServerCreateRepositoryRequest struct {
+	Name          string `json:"name"`
+	ScmId         string `json:"scmId"`
+	Public        bool   `json:"public"`
+	Description   string `json:"description,omitempty"`
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+}
+
+func mapBitbucketServerRepositoryToRepositoryInfo(repo bitbucketv1.Repository) RepositoryInfo {
+	var info CloneInfo
+	if repo.Links != nil {
+		for _, cloneLink := range repo.Links.Clone {
+			switch cloneLink.Name {
+			case "http":
+				info.HTTP = cloneLink.Href
+			case "ssh":
+				info.SSH = cloneLink.Href
+			}
+		}
+	}
+	return RepositoryInfo{RepositoryVisibility: getBitbucketServerRepositoryVisibility(repo.Public), CloneInfo: info, Description: repo.Description}
+}
+
+// DeleteRepository on Bitbucket server
+func (client *BitbucketServerClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	_, err := bitbucketClient.DeleteRepository(owner, repository)
+	return err
+}
+
+// ForkRepository on Bitbucket server.
+// The target owner is a project key. The repository is forked to the personal project of the authenticated user if it's empty.
+func (client *BitbucketServerClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	forkRequest := bitbucketServerForkRepositoryRequest{}
+	if targetOwner != "" {
+		forkRequest.Project = &bitbucketServerProjectKey{Key: targetOwner}
+	}
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository)
+	var fork bitbucketv1.Repository
+	if err := client.sendRequestWithJsonBody(ctx, http.MethodPost, url, forkRequest, &fork); err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapBitbucketServerRepositoryToRepositoryInfo(fork), nil
+}
+
+type bitbucketServerForkRepositoryRequest struct {
+	Project *bitbucketServerProjectKey `json:"project,omitempty"`
 }
 
-// GetCommits on Bitbucket server
-func (client *BitbucketServerClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"branch":     branch,
-	})
-	if err != nil {
-		return nil, err
-	}
+type bitbucketServerProjectKey struct {
+	Key string `json:"key"`
+}
 
-	options := map[string]interface{}{
-		"limit": vcsutils.NumberOfCommitsToFetch,
-		"until": branch,
+// ListRepositoryCollaborators on Bitbucket server.
+// The collaborators are the users granted a permission on the repository or on its project. Permissions granted to groups aren't listed.
+func (client *BitbucketServerClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
 	}
-	bitbucketClient := client.buildBitbucketClient(ctx)
-
-	apiResponse, err := bitbucketClient.GetCommits(owner, repository, options)
+	baseUrl := fmt.Sprintf("%s/rest/api/1.0/projects/%s", strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner)
+	projectPermissions, err := client.getUserPermissions(ctx, baseUrl+"/permissions/users")
 	if err != nil {
 		return nil, err
 	}
-	commits, err := bitbucketv1.GetCommitsResponse(apiResponse)
+	repositoryPermissions, err := client.getUserPermissions(ctx, fmt.Sprintf("%s/repos/%s/permissions/users", baseUrl, repository))
 	if err != nil {
 		return nil, err
 	}
-	var commitsInfo []CommitInfo
-	for _, commit := range commits {
-		commitInfo := client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository)
-		commitsInfo = append(commitsInfo, commitInfo)
+
+	// A user may have a permission on both the project and the repository, so the highest permission is kept
+	var results []CollaboratorInfo
+	indexes := map[string]int{}
+	for _, userPermission := range append(projectPermissions, repositoryPermissions...) {
+		permission := mapBitbucketServerPermissionToRepositoryPermission(userPermission.Permission)
+		index, exists := indexes[userPermission.User.Name]
+		if !exists {
+			indexes[userPermission.User.Name] = len(results)
+			results = append(results, CollaboratorInfo{Username: userPermission.User.Name, Permission: permission})
+			continue
+		}
+		if permission > results[index].Permission {
+			results[index].Permission = permission
+		}
 	}
-	return commitsInfo, nil
+	return results, nil
 }
 
-// GetRepositoryInfo on Bitbucket server
-func (client *BitbucketServerClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
-	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
-		return RepositoryInfo{}, err
+// GetRepositoryPermission on Bitbucket server.
+// Only the permissions granted to the user on the repository or on its project are considered.
+func (client *BitbucketServerClient) GetRepositoryPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username}); err != nil {
+		return NoPermission, err
 	}
-
-	bitbucketClient := client.buildBitbucketClient(ctx)
-
-	repo, err := bitbucketClient.GetRepository(owner, repository)
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repository)
 	if err != nil {
-		return RepositoryInfo{}, err
-	}
-
-	holder := struct {
-		Links struct {
-			Clone []struct {
-				Name string `mapstructure:"name"`
-				HRef string `mapstructure:"href"`
-			} `mapstructure:"clone"`
-		} `mapstructure:"links"`
-		Public bool `mapstructure:"public"`
-	}{}
-
-	if err := mapstructure.Decode(repo.Values, &holder); err != nil {
-		return RepositoryInfo{}, err
+		return NoPermission, err
 	}
+	return getBitbucketCollaboratorPermission(collaborators, username), nil
+}
 
-	var info CloneInfo
-	for _, cloneLink := range holder.Links.Clone {
-		switch cloneLink.Name {
-		case "http":
-			info.HTTP = cloneLink.HRef
-		case "ssh":
-			info.SSH = cloneLink.HRef
+func (client *BitbucketServerClient) getUserPermissions(ctx context.Context, permissionsUrl string) ([]bitbucketServerUserPermission, error) {
+	var results []bitbucketServerUserPermission
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		var userPermissions struct {
+			Values        []bitbucketServerUserPermission `json:"values"`
+			IsLastPage    bool                            `json:"isLastPage"`
+			NextPageStart int                             `json:"nextPageStart"`
 		}
+		query := neturl.Values{"start": {strconv.Itoa(nextPageStart)}, "limit": {"100"}}
+		if err := client.sendRequestWithJsonBody(ctx, http.MethodGet, permissionsUrl+"?"+query.Encode(), nil, &userPermissions); err != nil {
+			return nil, err
+		}
+		results = append(results, userPermissions.Values...)
+		isLastPage, nextPageStart = userPermissions.IsLastPage, userPermissions.NextPageStart
 	}
+	return results, nil
+}
 
-	return RepositoryInfo{RepositoryVisibility: getBitbucketServerRepositoryVisibility(holder.Public), CloneInfo: info}, nil
+type bitbucketServerUserPermission struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+	Permission string `json:"permission"`
+}
+
+func mapBitbucketServerPermissionToRepositoryPermission(permission string) RepositoryPermission {
+	switch permission {
+	case "REPO_ADMIN", "PROJECT_ADMIN":
+		return AdminPermission
+	case "REPO_WRITE", "PROJECT_WRITE":
+		return WritePermission
+	case "REPO_READ", "PROJECT_READ":
+		return ReadPermission
+	default:
+		return NoPermission
+	}
 }
 
 // GetCommitBySha on Bitbucket server
@@ -646,21 +1772,121 @@ func (client *BitbucketServerClient) GetLabel(ctx context.Context, owner, reposi
 	return nil, errLabelsNotSupported
 }
 
+// ListRepositoryLabels on Bitbucket server
+func (client *BitbucketServerClient) ListRepositoryLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	return nil, errLabelsNotSupported
+}
+
+// UpdateLabel on Bitbucket server
+func (client *BitbucketServerClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	return errLabelsNotSupported
+}
+
+// DeleteLabel on Bitbucket server
+func (client *BitbucketServerClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	return errLabelsNotSupported
+}
+
 // ListPullRequestLabels on Bitbucket server
 func (client *BitbucketServerClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
 	return nil, errLabelsNotSupported
 }
 
+// LabelPullRequest on Bitbucket server
+func (client *BitbucketServerClient) LabelPullRequest(ctx context.Context, owner, repository string, pullRequestID int, labels []string) error {
+	return errLabelsNotSupported
+}
+
 // UnlabelPullRequest on Bitbucket server
 func (client *BitbucketServerClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
 	return errLabelsNotSupported
 }
 
+// CreateIssue on Bitbucket server
+func (client *BitbucketServerClient) CreateIssue(_ context.Context, _, _, _, _ string) (IssueInfo, error) {
+	return IssueInfo{}, errBitbucketServerIssuesNotSupported
+}
+
+// ListIssues on Bitbucket server
+func (client *BitbucketServerClient) ListIssues(_ context.Context, _, _ string, _ ListIssuesOptions) ([]IssueInfo, error) {
+	return nil, errBitbucketServerIssuesNotSupported
+}
+
+// AddIssueComment on Bitbucket server
+func (client *BitbucketServerClient) AddIssueComment(_ context.Context, _, _, _ string, _ int) error {
+	return errBitbucketServerIssuesNotSupported
+}
+
+// CloseIssue on Bitbucket server
+func (client *BitbucketServerClient) CloseIssue(_ context.Context, _, _ string, _ int) error {
+	return errBitbucketServerIssuesNotSupported
+}
+
 // GetRepositoryEnvironmentInfo on Bitbucket server
 func (client *BitbucketServerClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
 	return RepositoryEnvironmentInfo{}, errBitbucketGetRepoEnvironmentInfoNotSupported
 }
 
+// ListEnvironments on Bitbucket server
+func (client *BitbucketServerClient) ListEnvironments(_ context.Context, _, _ string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errBitbucketDeploymentsNotSupported
+}
+
+// CreateDeployment on Bitbucket server
+func (client *BitbucketServerClient) CreateDeployment(_ context.Context, _, _, _, _, _ string) (DeploymentInfo, error) {
+	return DeploymentInfo{}, errBitbucketDeploymentsNotSupported
+}
+
+// SetDeploymentStatus on Bitbucket server
+func (client *BitbucketServerClient) SetDeploymentStatus(_ context.Context, _, _, _ string, _ DeploymentState, _, _ string) error {
+	return errBitbucketDeploymentsNotSupported
+}
+
+// CreateOrUpdateRepositorySecret on Bitbucket server
+func (client *BitbucketServerClient) CreateOrUpdateRepositorySecret(_ context.Context, _, _, _, _ string) error {
+	return errBitbucketRepositorySecretsNotSupported
+}
+
+// ListRepositoryVariables on Bitbucket server
+func (client *BitbucketServerClient) ListRepositoryVariables(_ context.Context, _, _ string) ([]RepositoryVariableInfo, error) {
+	return nil, errBitbucketRepositorySecretsNotSupported
+}
+
+// CreateOrUpdateOrganizationSecret on Bitbucket server
+func (client *BitbucketServerClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return errBitbucketSecretsNotSupported
+}
+
+// ListOrganizationSecrets on Bitbucket server
+func (client *BitbucketServerClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, errBitbucketSecretsNotSupported
+}
+
+// ListOrganizationSecretRepositories on Bitbucket server
+func (client *BitbucketServerClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, errBitbucketSecretsNotSupported
+}
+
+// DeleteOrganizationSecret on Bitbucket server
+func (client *BitbucketServerClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return errBitbucketSecretsNotSupported
+}
+
+// CreateOrUpdateEnvironmentSecret on Bitbucket server
+func (client *BitbucketServerClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return errBitbucketSecretsNotSupported
+}
+
+// ListEnvironmentSecrets on Bitbucket server
+func (client *BitbucketServerClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, errBitbucketSecretsNotSupported
+}
+
+// DeleteEnvironmentSecret on Bitbucket server
+func (client *BitbucketServerClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return errBitbucketSecretsNotSupported
+}
+
 // Get all projects for which the authenticated user has the PROJECT_VIEW permission
 func (client *BitbucketServerClient) listProjects(bitbucketClient *bitbucketv1.DefaultApiService) ([]string, error) {
 	var apiResponse *bitbucketv1.APIResponse
@@ -705,6 +1931,118 @@ func (client *BitbucketServerClient) DownloadFileFromRepo(ctx context.Context, o
 	return bbResp.Payload, statusCode, err
 }
 
+// ListDirectoryContents on Bitbucket server
+func (client *BitbucketServerClient) ListDirectoryContents(ctx context.Context, owner, repository, ref, path string) ([]DirectoryEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+
+	// The client library doesn't decode the directory children, hence they are fetched directly
+	path = strings.Trim(path, "/")
+	var entries []DirectoryEntry
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/browse/%s?at=%s&start=%d",
+			strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, (&neturl.URL{Path: path}).EscapedPath(), neturl.QueryEscape(ref), nextPageStart)
+		var browseResponse bitbucketServerBrowseResponse
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, url, nil, &browseResponse); err != nil {
+			return nil, err
+		}
+		if browseResponse.Children == nil {
+			return nil, fmt.Errorf("path '%s' is not a directory", path)
+		}
+		for _, child := range browseResponse.Children.Values {
+			// The path of every child is relative to the requested directory
+			entryPath := child.Path.ToString
+			if path != "" {
+				entryPath = path + "/" + entryPath
+			}
+			entries = append(entries, DirectoryEntry{Name: child.Path.Name, Path: entryPath, IsDir: child.Type == "DIRECTORY"})
+		}
+		isLastPage, nextPageStart = browseResponse.Children.IsLastPage, browseResponse.Children.NextPageStart
+	}
+	return entries, nil
+}
+
+type bitbucketServerBrowseResponse struct {
+	Children *struct {
+		Values []struct {
+			Path struct {
+				Name     string `json:"name"`
+				ToString string `json:"toString"`
+			} `json:"path"`
+			Type string `json:"type"`
+		} `json:"values"`
+		IsLastPage    bool `json:"isLastPage"`
+		NextPageStart int  `json:"nextPageStart"`
+	} `json:"children"`
+}
+
+// CommitFiles on Bitbucket server.
+// Bitbucket server can only edit a single file per commit, hence a commit is created for every file.
+func (client *BitbucketServerClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, files []FileToCommit) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "commitMessage": commitMessage})
+	if err != nil {
+		return err
+	}
+	if err = validateFilesToCommit(files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.ChangeType == FileDeleted {
+			return errBitbucketServerCommitDeletedFileNotSupported
+		}
+	}
+
+	latestCommit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return err
+	}
+	sourceCommitID := latestCommit.Hash
+	for _, file := range files {
+		if sourceCommitID, err = client.commitFile(ctx, owner, repository, branch, commitMessage, sourceCommitID, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitFile commits a single file on top of sourceCommitID and returns the ID of the created commit
+func (client *BitbucketServerClient) commitFile(ctx context.Context, owner, repository, branch, commitMessage, sourceCommitID string, file FileToCommit) (string, error) {
+	body := new(bytes.Buffer)
+	formWriter := multipart.NewWriter(body)
+	fileWriter, err := formWriter.CreateFormFile("content", file.Path)
+	if err != nil {
+		return "", err
+	}
+	if _, err = fileWriter.Write(file.Content); err != nil {
+		return "", err
+	}
+	if err = formWriter.WriteField("message", commitMessage); err != nil {
+		return "", err
+	}
+	if err = formWriter.WriteField("branch", branch); err != nil {
+		return "", err
+	}
+	// The source commit is used to verify that an existing file wasn't changed since, and must not be sent for new files
+	if file.ChangeType != FileAdded {
+		if err = formWriter.WriteField("sourceCommitId", sourceCommitID); err != nil {
+			return "", err
+		}
+	}
+	if err = formWriter.Close(); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/browse/%s",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, (&neturl.URL{Path: file.Path}).EscapedPath())
+	var commit bitbucketv1.Commit
+	if err = client.sendRequest(ctx, http.MethodPut, url, body, formWriter.FormDataContentType(), &commit); err != nil {
+		return "", err
+	}
+	return commit.ID, nil
+}
+
 func createPaginationOptions(nextPageStart int) map[string]interface{} {
 	return map[string]interface{}{"start": nextPageStart}
 }
@@ -734,6 +2072,15 @@ func createBitbucketServerHook(token, payloadURL string, webhookEvents ...vcsuti
 	}
 }
 
+func mapBitbucketServerWebhookToWebhookInfo(webhook bitbucketv1.Webhook) WebhookInfo {
+	return WebhookInfo{
+		ID:         strconv.Itoa(webhook.ID),
+		PayloadURL: webhook.Url,
+		Events:     getSubscribedWebhookEvents(webhook.Events, getBitbucketServerWebhookEvents),
+		Active:     webhook.Active,
+	}
+}
+
 // Get varargs of webhook events and return a slice of Bitbucket server webhook events
 func getBitbucketServerWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
 	events := make([]string, 0, len(webhookEvents))
@@ -746,8 +2093,14 @@ func getBitbucketServerWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []s
 		case vcsutils.PrMerged:
 			events = append(events, "pr:merged")
 		case vcsutils.PrRejected:
-			events = append(events, "pr:declined", "pr:deleted")
-		case vcsutils.Push, vcsutils.TagPushed, vcsutils.TagRemoved:
+			events = append(events, "pr:declined")
+		case vcsutils.PrClosed:
+			events = append(events, "pr:deleted")
+		case vcsutils.PrCommentCreated:
+			events = append(events, "pr:comment:added")
+		case vcsutils.PrCommentEdited:
+			events = append(events, "pr:comment:edited")
+		case vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved:
 			events = append(events, "repo:refs_changed")
 		}
 	}
@@ -774,8 +2127,138 @@ func (client *BitbucketServerClient) mapBitbucketServerCommitToCommitInfo(commit
 	}
 }
 
+// UploadCodeScanning on Bitbucket server.
+// The analysis is published as a Code Insights report on the latest commit of the branch, with an annotation for each finding.
+// The returned ID is the key of the report.
 func (client *BitbucketServerClient) UploadCodeScanning(ctx context.Context, owner string, repository string, branch string, scanResults string) (string, error) {
-	return "", errBitbucketCodeScanningNotSupported
+	analysis, err := parseCodeScanningAnalysis(scanResults)
+	if err != nil {
+		return "", err
+	}
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", err
+	}
+	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
+
+	report := CodeInsightsReport{
+		Key:      codeScanningReportKey,
+		Title:    analysis.ToolName,
+		Details:  analysis.getDetails(),
+		Result:   CodeInsightsPass,
+		Reporter: analysis.ToolName,
+	}
+	if len(analysis.Findings) > 0 {
+		report.Result = CodeInsightsFail
+	}
+	if err = client.CreateCodeInsightsReport(ctx, owner, repository, commit.Hash, report); err != nil {
+		return "", err
+	}
+	if len(analysis.Findings) == 0 {
+		return report.Key, nil
+	}
+
+	var annotations []CodeInsightsAnnotation
+	for _, finding := range analysis.Findings {
+		if len(annotations) == maxCodeInsightsAnnotations {
+			client.logger.Warn(fmt.Sprintf("Code Insights reports are limited to %d annotations, the rest of the findings are omitted", maxCodeInsightsAnnotations))
+			break
+		}
+		annotations = append(annotations, CodeInsightsAnnotation{
+			Path:     finding.FilePath,
+			Line:     finding.Line,
+			Message:  finding.getSummary(),
+			Severity: CodeInsightsSeverity(finding.Severity),
+			Type:     CodeInsightsVulnerability,
+		})
+	}
+	return report.Key, client.AddCodeInsightsAnnotations(ctx, owner, repository, commit.Hash, report.Key, annotations)
+}
+
+// CreateCodeInsightsReport on Bitbucket server
+func (client *BitbucketServerClient) CreateCodeInsightsReport(ctx context.Context, owner, repository, commitHash string, report CodeInsightsReport) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "commit hash": commitHash, "report key": report.Key, "report title": report.Title})
+	if err != nil {
+		return err
+	}
+	body := bitbucketServerCodeInsightsReport{
+		Title:    report.Title,
+		Details:  report.Details,
+		Result:   string(report.Result),
+		Reporter: report.Reporter,
+		Link:     report.Link,
+	}
+	return client.sendRequestWithJsonBody(ctx, http.MethodPut, client.getCodeInsightsReportUrl(owner, repository, commitHash, report.Key), body, nil)
+}
+
+// AddCodeInsightsAnnotations on Bitbucket server.
+// Messages longer than the limit of Bitbucket are truncated.
+func (client *BitbucketServerClient) AddCodeInsightsAnnotations(ctx context.Context, owner, repository, commitHash, reportKey string, annotations []CodeInsightsAnnotation) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "commit hash": commitHash, "report key": reportKey})
+	if err != nil {
+		return err
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	if len(annotations) > maxCodeInsightsAnnotations {
+		return fmt.Errorf("a Code Insights report can have up to %d annotations, got %d", maxCodeInsightsAnnotations, len(annotations))
+	}
+	body := bitbucketServerAddCodeInsightsAnnotationsRequest{}
+	for _, annotation := range annotations {
+		// Bitbucket server doesn't have a critical severity
+		if annotation.Severity == CodeInsightsCriticalSeverity {
+			annotation.Severity = CodeInsightsHighSeverity
+		}
+		body.Annotations = append(body.Annotations, bitbucketServerCodeInsightsAnnotation{
+			Path:     annotation.Path,
+			Line:     annotation.Line,
+			Message:  truncateCodeScanningMessage(annotation.Message, bitbucketServerAnnotationMessageLimit),
+			Severity: string(annotation.Severity),
+			Type:     string(annotation.Type),
+			Link:     annotation.Link,
+		})
+	}
+	annotationsUrl := client.getCodeInsightsReportUrl(owner, repository, commitHash, reportKey) + "/annotations"
+	return client.sendRequestWithJsonBody(ctx, http.MethodPost, annotationsUrl, body, nil)
+}
+
+// CreateCheckRun on Bitbucket server
+func (client *BitbucketServerClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errBitbucketCheckRunsNotSupported
+}
+
+// UpdateCheckRun on Bitbucket server
+func (client *BitbucketServerClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errBitbucketCheckRunsNotSupported
+}
+
+func (client *BitbucketServerClient) getCodeInsightsReportUrl(owner, repository, commitHash, reportKey string) string {
+	return fmt.Sprintf("%s/rest/insights/1.0/projects/%s/repos/%s/commits/%s/reports/%s",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, commitHash, neturl.PathEscape(reportKey))
+}
+
+const bitbucketServerAnnotationMessageLimit = 2000
+
+type bitbucketServerCodeInsightsReport struct {
+	Title    string `json:"title"`
+	Details  string `json:"details,omitempty"`
+	Result   string `json:"result,omitempty"`
+	Reporter string `json:"reporter,omitempty"`
+	Link     string `json:"link,omitempty"`
+}
+
+type bitbucketServerAddCodeInsightsAnnotationsRequest struct {
+	Annotations []bitbucketServerCodeInsightsAnnotation `json:"annotations"`
+}
+
+type bitbucketServerCodeInsightsAnnotation struct {
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+	Type     string `json:"type,omitempty"`
+	Link     string `json:"link,omitempty"`
 }
 
 type diffPayload struct {
@@ -824,6 +2307,121 @@ func (client *BitbucketServerClient) GetModifiedFiles(ctx context.Context, owner
 	return fileNamesList, nil
 }
 
+// ListPullRequestFiles on Bitbucket server
+func (client *BitbucketServerClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	return client.listChanges(ctx, fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/changes?",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository, pullRequestID))
+}
+
+// CompareCommits on Bitbucket server
+func (client *BitbucketServerClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+
+	// The client library doesn't support comparing refs, hence the commits and the changes are fetched directly
+	compareURL := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/compare",
+		strings.TrimSuffix(client.vcsInfo.APIEndpoint, "/rest"), owner, repository)
+	query := fmt.Sprintf("?from=%s&to=%s&", neturl.QueryEscape(head), neturl.QueryEscape(base))
+	var commitsComparison CommitsComparison
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		var commitsPage bitbucketServerCommitsPage
+		url := fmt.Sprintf("%s/commits%sstart=%d&limit=%d", compareURL, query, nextPageStart, bitbucketServerPageLimit)
+		if err = client.sendRequestWithJsonBody(ctx, http.MethodGet, url, nil, &commitsPage); err != nil {
+			return CommitsComparison{}, err
+		}
+		for _, commit := range commitsPage.Values {
+			commitsComparison.Commits = append(commitsComparison.Commits, client.mapBitbucketServerCommitToCommitInfo(commit, owner, repository))
+		}
+		isLastPage, nextPageStart = commitsPage.IsLastPage, commitsPage.NextPageStart
+	}
+	if commitsComparison.Files, err = client.listChanges(ctx, compareURL+"/changes"+query); err != nil {
+		return CommitsComparison{}, err
+	}
+	return commitsComparison, nil
+}
+
+// Lists all pages of the changes returned by changesURL, which must end with a query separator ('?' or '&')
+func (client *BitbucketServerClient) listChanges(ctx context.Context, changesURL string) ([]PullRequestFile, error) {
+	var files []PullRequestFile
+	for isLastPage, nextPageStart := false, 0; !isLastPage; {
+		var changesPage bitbucketServerChangesPage
+		url := fmt.Sprintf("%sstart=%d&limit=%d", changesURL, nextPageStart, bitbucketServerPageLimit)
+		if err := client.sendRequestWithJsonBody(ctx, http.MethodGet, url, nil, &changesPage); err != nil {
+			return nil, err
+		}
+		for _, change := range changesPage.Values {
+			files = append(files, mapBitbucketServerChangeToPullRequestFile(change))
+		}
+		isLastPage, nextPageStart = changesPage.IsLastPage, changesPage.NextPageStart
+	}
+	return files, nil
+}
+
+// GetPullRequestDiff on Bitbucket server
+func (client *BitbucketServerClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return "", err
+	}
+	bitbucketClient := client.buildBitbucketClient(ctx)
+	apiResponse, err := bitbucketClient.GetPullRequestDiffRaw(owner, repository, pullRequestID, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(apiResponse.Payload), nil
+}
+
+type bitbucketServerCommitsPage struct {
+	Values        []bitbucketv1.Commit `json:"values"`
+	IsLastPage    bool                 `json:"isLastPage"`
+	NextPageStart int                  `json:"nextPageStart"`
+}
+
+type bitbucketServerChangesPage struct {
+	Values        []bitbucketServerChange `json:"values"`
+	IsLastPage    bool                    `json:"isLastPage"`
+	NextPageStart int                     `json:"nextPageStart"`
+}
+
+type bitbucketServerChange struct {
+	Path    bitbucketServerChangePath  `json:"path"`
+	SrcPath *bitbucketServerChangePath `json:"srcPath"`
+	Type    string                     `json:"type"`
+}
+
+type bitbucketServerChangePath struct {
+	ToString string `json:"toString"`
+}
+
+func mapBitbucketServerChangeToPullRequestFile(change bitbucketServerChange) PullRequestFile {
+	file := PullRequestFile{Path: change.Path.ToString}
+	switch change.Type {
+	case "ADD", "COPY":
+		file.ChangeType = FileAdded
+	case "DELETE":
+		file.ChangeType = FileDeleted
+	case "MOVE", "RENAME":
+		file.ChangeType = FileRenamed
+		if change.SrcPath != nil {
+			file.PreviousPath = change.SrcPath.ToString
+		}
+	default:
+		file.ChangeType = FileModified
+	}
+	return file
+}
+
 func getBitbucketServerRepositoryVisibility(public bool) RepositoryVisibility {
 	if public {
 		return Public
@@ -838,3 +2436,16 @@ func getSourceRepositoryOwner(pullRequest bitbucketv1.PullRequest) (string, erro
 	}
 	return project.Key, nil
 }
+
+func getBitbucketServerMergeStrategyID(mergeMethod vcsutils.MergeMethod) (string, error) {
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge:
+		return "no-ff", nil
+	case vcsutils.MergeMethodSquash:
+		return "squash", nil
+	case vcsutils.MergeMethodRebase:
+		return "rebase-ff-only", nil
+	default:
+		return "", getUnsupportedMergeMethodError(mergeMethod)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -56,6 +57,31 @@ func TestBitbucketServer_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestBitbucketServer_TestConnectionWithScopes(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := make(map[string][]bitbucketv1.User)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, mockResponse,
+		"/rest/api/1.0/admin/users?limit=1", createBitbucketServerHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.TestConnectionWithScopes(ctx))
+	assert.ErrorIs(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope), errBitbucketTokenScopesNotSupported)
+	assert.Error(t, createBadBitbucketServerClient(t).TestConnectionWithScopes(ctx))
+}
+
+func TestBitbucketServer_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createAuthenticatedUserBitbucketServerHandler)
+	defer cleanUp()
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: "frogger", DisplayName: "Frog Ger", Email: "frogger@jfrog.com"}, user)
+
+	_, err = createBadBitbucketServerClient(t).GetAuthenticatedUser(ctx)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBitbucketServerListRepositoriesHandler)
@@ -69,6 +95,22 @@ func TestBitbucketServer_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucketv1.Repository{
+		"values": {{Slug: repo1, Project: &bitbucketv1.Project{Key: owner}}, {Slug: repo2, Project: &bitbucketv1.Project{Key: "~" + strings.ToUpper(username)}}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, mockResponse, "/rest/api/1.0/repos?limit=50&start=50", createBitbucketServerHandler)
+	defer cleanUp()
+
+	actualRepositories, err := client.ListRepositoriesWithOptions(ctx, ListOptions{Page: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{owner: {repo1}, "~" + strings.ToUpper(username): {repo2}}, actualRepositories)
+
+	_, err = createBadBitbucketServerClient(t).ListRepositoriesWithOptions(ctx, ListOptions{})
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_ListBranches(t *testing.T) {
 	ctx := context.Background()
 	mockResponse := map[string][]bitbucketv1.Branch{
@@ -85,6 +127,168 @@ func TestBitbucketServer_ListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListBranchesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucketv1.Branch{
+		"values": {{ID: branch2}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, mockResponse, "/rest/api/1.0/projects/jfrog/repos/repo-1/branches?limit=1&start=1", createBitbucketServerHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch2}, actualBranches)
+
+	_, err = createBadBitbucketServerClient(t).ListBranchesWithOptions(ctx, owner, repo1, ListOptions{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"name":"new-branch","startPoint":"branch-1"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/branches", http.StatusOK, expectedBody, http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "new-branch")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).CreateBranch(ctx, owner, repo1, branch1, "new-branch")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"name":"refs/heads/branch-1","dryRun":false}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		"/rest/branch-utils/1.0/projects/jfrog/repos/repo-1/branches", http.StatusOK, expectedBody, http.MethodDelete, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).DeleteBranch(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, []byte(`{"id":"refs/heads/main","displayId":"main","type":"BRANCH","isDefault":true}`),
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default", createBitbucketServerHandler)
+	defer cleanUp()
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", defaultBranch)
+
+	_, err = createBadBitbucketServerClient(t).GetDefaultBranch(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"id":"refs/heads/develop"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default", http.StatusOK, expectedBody, http.MethodPut, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetDefaultBranch(ctx, owner, repo1, "develop")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).SetDefaultBranch(ctx, owner, repo1, "develop")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBranchProtectionBitbucketServerHandler(true))
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{RestrictPushes: true}, protection)
+
+	_, err = createBadBitbucketServerClient(t).GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBranchProtectionBitbucketServerHandler(false))
+	defer cleanUp()
+	err := client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RestrictPushes: true})
+	assert.NoError(t, err)
+
+	restrictedClient, restrictedCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBranchProtectionBitbucketServerHandler(true))
+	defer restrictedCleanUp()
+	err = restrictedClient.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{})
+	assert.NoError(t, err)
+
+	err = client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredApprovals: 1})
+	assert.ErrorIs(t, err, errBitbucketServerRequiredApprovalsNotSupported)
+	err = client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredStatusChecks: []string{"frogbot"}})
+	assert.ErrorIs(t, err, errBitbucketRequiredStatusChecksNotSupported)
+}
+
+func TestBitbucketServer_ListTags(t *testing.T) {
+	ctx := context.Background()
+	mockResponse := map[string][]bitbucketv1.Tag{
+		"values": {{ID: "refs/tags/v1.0.0", DisplayID: "v1.0.0", LatestCommit: "abcdef0123abcdef4567abcdef8987abcdef6543"}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, mockResponse, "/rest/api/1.0/projects/jfrog/repos/repo-1/tags?start=0", createBitbucketServerHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitSha: "abcdef0123abcdef4567abcdef8987abcdef6543"}}, tags)
+
+	_, err = createBadBitbucketServerClient(t).ListTags(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"name":"v1.0.0","startPoint":"branch-1","message":"Release 1.0.0"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/tags", http.StatusOK, expectedBody, http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", branch1, "Release 1.0.0")
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).CreateTag(ctx, owner, repo1, "v1.0.0", branch1, "Release 1.0.0")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetTagInfo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createGetTagInfoBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.GetTagInfo(ctx, owner, repo1, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdef0123abcdef4567abcdef8987abcdef6543", result.Hash)
+	assert.Equal(t, "WIP on feature 1", result.Message)
+
+	_, err = createBadBitbucketServerClient(t).GetTagInfo(ctx, owner, repo1, "v1.0.0")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_Releases(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateRelease(ctx, owner, repo1, "v1.0.0", "Release 1.0.0", "Release notes")
+	assert.ErrorIs(t, err, errBitbucketServerReleasesNotSupported)
+	_, err = client.ListReleases(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketServerReleasesNotSupported)
+	_, err = client.GetLatestRelease(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketServerReleasesNotSupported)
+	_, err = client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", "app.zip", strings.NewReader("content"))
+	assert.ErrorIs(t, err, errBitbucketUploadReleaseAssetNotSupported)
+}
+
 func TestBitbucketServer_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int31()
@@ -118,6 +322,64 @@ func TestBitbucketServer_UpdateWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_RotateWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int31()
+	stringID := strconv.Itoa(int(id))
+
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, bitbucketv1.Webhook{ID: int(id), Url: "https://httpbin.org/anything"}, fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/webhooks/%s", stringID), createBitbucketServerHandler)
+	defer cleanUp()
+
+	newToken, err := client.RotateWebhookSecret(ctx, owner, repo1, stringID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, newToken)
+
+	_, err = client.RotateWebhookSecret(ctx, owner, repo1, "invalid-id")
+	assert.Error(t, err)
+
+	_, err = createBadBitbucketServerClient(t).RotateWebhookSecret(ctx, owner, repo1, stringID)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int31()
+	response := map[string][]bitbucketv1.Webhook{"values": {{ID: int(id), Url: "https://httpbin.org/anything", Active: true, Events: []string{"repo:refs_changed"}}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response, "/rest/api/1.0/projects/jfrog/repos/repo-1/webhooks", createBitbucketServerHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{
+		ID:         strconv.Itoa(int(id)),
+		PayloadURL: "https://httpbin.org/anything",
+		Events:     []vcsutils.WebhookEvent{vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved},
+		Active:     true,
+	}}, webhooks)
+
+	_, err = createBadBitbucketServerClient(t).ListWebhooks(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetWebhook(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int31()
+	stringID := strconv.Itoa(int(id))
+	response := bitbucketv1.Webhook{ID: int(id), Url: "https://httpbin.org/anything", Events: []string{"pr:opened"}}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response, fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/webhooks/%s", stringID), createBitbucketServerHandler)
+	defer cleanUp()
+
+	webhook, err := client.GetWebhook(ctx, owner, repo1, stringID)
+	assert.NoError(t, err)
+	assert.Equal(t, WebhookInfo{ID: stringID, PayloadURL: "https://httpbin.org/anything", Events: []vcsutils.WebhookEvent{vcsutils.PrOpened}}, webhook)
+
+	_, err = client.GetWebhook(ctx, owner, repo1, "invalid-id")
+	assert.Error(t, err)
+
+	_, err = createBadBitbucketServerClient(t).GetWebhook(ctx, owner, repo1, stringID)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_DeleteWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int31()
@@ -172,18 +434,106 @@ func TestBitbucketServer_DownloadRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_DownloadRepositoryPaths(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repoFile := createTarGz(t, "", map[string]string{"README.md": "Hello", "src/go.mod": "module frog", "docs/guide.md": "Guide"})
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, repoFile,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/archive?at=%s&format=tgz&path=src&path=README.md", owner, repo1, branch1),
+		createBitbucketServerDownloadRepositoryHandler)
+	defer cleanUp()
+
+	err := client.DownloadRepositoryPaths(ctx, owner, repo1, branch1, []string{"src", "README.md"}, dir)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.FileExists(t, filepath.Join(dir, "src", "go.mod"))
+	// Archived paths are filtered again on extraction
+	assert.NoDirExists(t, filepath.Join(dir, "docs"))
+}
+
+func TestBitbucketServer_DownloadRepositoryZipArchive(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repoFile := createZip(t, map[string]string{"README.md": "Hello"})
+	server := httptest.NewServer(createBitbucketServerDownloadRepositoryHandler(t,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/archive?at=%s&format=zip", owner, repo1, branch1), repoFile, http.StatusOK))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint(server.URL).Token(token).ArchiveFormat(vcsutils.ZipArchive).Build()
+	assert.NoError(t, err)
+	assert.NoError(t, client.DownloadRepository(ctx, owner, repo1, branch1, dir))
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+}
+
+func TestBitbucketServer_DownloadRepositorySizeLimit(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, vcsutils.RemoveTempDir(dir)) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "hello-world-main.tar.gz"))
+	assert.NoError(t, err)
+	server := httptest.NewServer(createBitbucketServerDownloadRepositoryHandler(t,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/archive?at=%s&format=tgz", owner, repo1, branch1), repoFile, http.StatusOK))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint(server.URL).Token(token).MaxDownloadSize(100).Build()
+	assert.NoError(t, err)
+	err = client.DownloadRepository(ctx, owner, repo1, branch1, dir)
+	assert.ErrorIs(t, err, vcsutils.ErrDownloadSizeLimitExceeded)
+
+	client, err = NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint(server.URL).Token(token).
+		ExtractionLimits(vcsutils.ExtractionLimits{MaxExtractedSize: 1}).Build()
+	assert.NoError(t, err)
+	err = client.DownloadRepository(ctx, owner, repo1, branch1, dir)
+	assert.ErrorIs(t, err, vcsutils.ErrExtractionLimitExceeded)
+}
+
 func TestBitbucketServer_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests", createBitbucketServerHandler)
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "get_pull_request_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests", createBitbucketServerHandler)
 	defer cleanUp()
 
-	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	pullRequestInfo, err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
 	assert.NoError(t, err)
+	assert.Equal(t, int64(6), pullRequestInfo.ID)
+	assert.Equal(t, "https://git.bbServerHost.info/users/owner/repos/repoName/pull-requests/6", pullRequestInfo.URL)
 
-	err = createBadBitbucketServerClient(t).CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	_, err = createBadBitbucketServerClient(t).CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_CreatePullRequestWithOptions(t *testing.T) {
+	_, err := createBadBitbucketServerClient(t).CreatePullRequestWithOptions(context.Background(), owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{Draft: true})
+	assert.EqualError(t, err, "creating draft pull requests is currently not supported on Bitbucket server")
+}
+
+func TestBitbucketServer_CreatePullRequestWithReviewers(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "get_pull_request_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests",
+		func(t *testing.T, expectedURI string, response []byte, _ int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, expectedURI, r.RequestURI)
+				var pullRequest bitbucketv1.PullRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&pullRequest))
+				if assert.Len(t, pullRequest.Reviewers, 1) {
+					assert.Equal(t, "reviewer1", pullRequest.Reviewers[0].User.Name)
+				}
+				_, err := w.Write(response)
+				assert.NoError(t, err)
+			}
+		})
+	defer cleanUp()
+
+	pullRequestInfo, err := client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{Reviewers: []string{"reviewer1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), pullRequestInfo.ID)
+}
+
 func TestBitbucketServer_UpdatePullRequest(t *testing.T) {
 	prId := 4
 	ctx := context.Background()
@@ -193,10 +543,140 @@ func TestBitbucketServer_UpdatePullRequest(t *testing.T) {
 	err := client.UpdatePullRequest(ctx, owner, repo1, "PR title", "PR body", "", prId, vcsutils.Open)
 	assert.NoError(t, err)
 
+	err = client.UpdatePullRequest(ctx, owner, repo1, "", "", "master", prId, vcsutils.Open)
+	assert.NoError(t, err)
+
 	err = createBadBitbucketServerClient(t).UpdatePullRequest(ctx, owner, repo1, "PR title", "PR body", "", prId, vcsutils.Open)
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_UpdatePullRequestState(t *testing.T) {
+	prId := 4
+	ctx := context.Background()
+	pullRequestURI := fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v", prId)
+
+	openClient, openCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, bitbucketv1.PullRequest{ID: prId, Version: 1, State: "OPEN"},
+		pullRequestURI+"/decline?version=1", createBitbucketServerHandler)
+	defer openCleanUp()
+	err := openClient.UpdatePullRequest(ctx, owner, repo1, "", "", "", prId, vcsutils.Closed)
+	assert.NoError(t, err)
+
+	declinedClient, declinedCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, bitbucketv1.PullRequest{ID: prId, Version: 2, State: "DECLINED"},
+		pullRequestURI+"/reopen?version=2", createBitbucketServerHandler)
+	defer declinedCleanUp()
+	err = declinedClient.UpdatePullRequest(ctx, owner, repo1, "", "", "", prId, vcsutils.Open)
+	assert.NoError(t, err)
+}
+
+func TestBitbucketServer_MergePullRequest(t *testing.T) {
+	prId := 4
+	ctx := context.Background()
+	expectedURI := fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v/merge?version=0", prId)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, expectedURI, createBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, prId, vcsutils.MergeMethodRebase)
+	assert.NoError(t, err)
+
+	err = client.MergePullRequest(ctx, owner, repo1, prId, "fast-forward")
+	assert.Error(t, err)
+
+	err = createBadBitbucketServerClient(t).MergePullRequest(ctx, owner, repo1, prId, vcsutils.MergeMethodMerge)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_SetPullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	pullRequestURI := "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/4"
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, pullRequestURI,
+		func(t *testing.T, expectedURI string, _ []byte, _ int) http.HandlerFunc {
+			return func(writer http.ResponseWriter, request *http.Request) {
+				var response []byte
+				switch request.RequestURI {
+				case expectedURI:
+					response = []byte(`{"id": 4, "version": 3}`)
+				case expectedURI + "/merge?version=3":
+					assert.Equal(t, http.MethodPost, request.Method)
+					body, err := io.ReadAll(request.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"autoMerge": true, "strategyId": "squash"}`, string(body))
+					response = []byte("{}")
+				default:
+					assert.Fail(t, "unexpected request", request.RequestURI)
+				}
+				_, err := writer.Write(response)
+				assert.NoError(t, err)
+			}
+		})
+	defer cleanUp()
+
+	assert.NoError(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 4, vcsutils.MergeMethodSquash))
+	assert.Error(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 4, "fast-forward"))
+	assert.Error(t, createBadBitbucketServerClient(t).SetPullRequestAutoMerge(ctx, owner, repo1, 4, vcsutils.MergeMethodMerge))
+}
+
+func TestBitbucketServer_ListPullRequestFiles(t *testing.T) {
+	prId := 4
+	ctx := context.Background()
+	response := []byte(`{"isLastPage": true, "values": [
+		{"path": {"toString": "README.md"}, "type": "MODIFY"},
+		{"path": {"toString": "new.go"}, "type": "ADD"},
+		{"path": {"toString": "old.go"}, "type": "DELETE"},
+		{"path": {"toString": "renamed.go"}, "srcPath": {"toString": "original.go"}, "type": "MOVE"}
+	]}`)
+	expectedURI := fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v/changes?start=0&limit=100", prId)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response, expectedURI, createBitbucketServerHandler)
+	defer cleanUp()
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, prId)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "new.go", ChangeType: FileAdded},
+		{Path: "old.go", ChangeType: FileDeleted},
+		{Path: "renamed.go", PreviousPath: "original.go", ChangeType: FileRenamed},
+	}, files)
+
+	_, err = createBadBitbucketServerClient(t).ListPullRequestFiles(ctx, owner, repo1, prId)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetPullRequestDiff(t *testing.T) {
+	prId := 4
+	ctx := context.Background()
+	expectedDiff := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n hello\n+world\n"
+	expectedURI := fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v.diff", prId)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, []byte(expectedDiff), expectedURI, createBitbucketServerHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, prId)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDiff, diff)
+
+	_, err = createBadBitbucketServerClient(t).GetPullRequestDiff(ctx, owner, repo1, prId)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "", createCompareCommitsBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.NoError(t, err)
+	assert.Len(t, result.Commits, 1)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", result.Commits[0].Hash)
+	assert.Equal(t, "Fix all the bugs", result.Commits[0].Message)
+	assert.Equal(t, []string{"7638417db6d59f3c431d3e1f261cc637155684cd"}, result.Commits[0].ParentHashes)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "new.go", ChangeType: FileAdded},
+	}, result.Files)
+
+	_, err = createBadBitbucketServerClient(t).CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_AddPullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/comments", createBitbucketServerHandler)
@@ -234,10 +714,15 @@ func TestBitbucketServer_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     101,
-		Source: BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
-		Target: BranchInfo{Name: "master", Repository: repo1, Owner: owner},
-		URL:    "https://link/to/pullrequest",
+		ID:        101,
+		Title:     "Talking Nerdy",
+		Author:    "tom",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		URL:       "https://link/to/pullrequest",
+		CreatedAt: time.UnixMilli(1359075920),
+		UpdatedAt: time.UnixMilli(1359085920),
 	}, result[0])
 
 	// With body:
@@ -246,14 +731,64 @@ func TestBitbucketServer_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     101,
-		Body:   "hello world",
-		Source: BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
-		Target: BranchInfo{Name: "master", Repository: repo1, Owner: owner},
-		URL:    "https://link/to/pullrequest",
+		ID:        101,
+		Title:     "Talking Nerdy",
+		Body:      "hello world",
+		Author:    "tom",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "feature-ABC-123", Repository: repo1, Owner: forkedOwner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		URL:       "https://link/to/pullrequest",
+		CreatedAt: time.UnixMilli(1359075920),
+		UpdatedAt: time.UnixMilli(1359085920),
 	}, result[0])
 }
 
+func TestBitbucketServer_ListOpenPullRequestsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests?at=refs%%2Fheads%%2Fmaster&limit=25&start=25&state=OPEN", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	options := ListPullRequestsOptions{TargetBranch: "master", Author: "tom", Page: 2, PerPage: 25}
+	result, err := client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(101), result[0].ID)
+
+	// Filtered by the author
+	options.Author = "jerry"
+	result, err = client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = createBadBitbucketServerClient(t).ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListPullRequestsWithState(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests?start=0&state=DECLINED", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Closed)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(101), result[0].ID)
+	assert.Equal(t, "hello world", result[0].Body)
+
+	_, err = client.ListPullRequestsWithState(ctx, owner, repo1, "draft")
+	assert.EqualError(t, err, "unsupported pull request state: 'draft'")
+
+	_, err = createBadBitbucketServerClient(t).ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Closed)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServerClient_GetPullRequest(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "get_pull_request_response.json"))
@@ -267,10 +802,16 @@ func TestBitbucketServerClient_GetPullRequest(t *testing.T) {
 	result, err := client.GetPullRequestByID(ctx, owner, repo1, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     int64(pullRequestId),
-		Source: BranchInfo{Name: "new_vul_2", Repository: "repoName", Owner: "~fromOwner"},
-		Target: BranchInfo{Name: "master", Repository: "repoName", Owner: owner},
-		URL:    "https://git.bbServerHost.info/users/owner/repos/repoName/pull-requests/6",
+		ID:        int64(pullRequestId),
+		Title:     "New vul 2",
+		Body:      "* add vul\n* test",
+		Author:    "owner",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "new_vul_2", Repository: "repoName", Owner: "~fromOwner"},
+		Target:    BranchInfo{Name: "master", Repository: "repoName", Owner: owner},
+		URL:       "https://git.bbServerHost.info/users/owner/repos/repoName/pull-requests/6",
+		CreatedAt: time.UnixMilli(1686651080688),
+		UpdatedAt: time.UnixMilli(1686651080688),
 	}, result)
 
 	// Failed owner extraction
@@ -295,7 +836,19 @@ func TestBitbucketServerClient_GetPullRequest(t *testing.T) {
 }
 
 func TestBitbucketServer_ListPullRequestReviewComments(t *testing.T) {
-	TestBitbucketServer_ListPullRequestComments(t)
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/activities?start=0", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "path/to/file", result[0].FilePath)
+	assert.Equal(t, 1, result[0].Line)
 }
 
 func TestBitbucketServer_ListPullRequestComments(t *testing.T) {
@@ -311,13 +864,44 @@ func TestBitbucketServer_ListPullRequestComments(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, CommentInfo{
-		ID:      1,
-		Content: "A measured reply.",
-		Created: time.Unix(1548720847370, 0),
-		Version: 1,
+		ID:             1,
+		NativeID:       "1",
+		Content:        "A measured reply.",
+		Author:         "Jane Citizen",
+		AuthorUsername: "jcitizen",
+		Created:        time.Unix(1548720847370, 0),
+		Updated:        time.UnixMilli(1548720847370),
+		Version:        1,
+		FilePath:       "path/to/file",
+		Line:           1,
 	}, result[0])
 }
 
+func TestBitbucketServer_ListPullRequestCommentsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/activities?start=0", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, ListPullRequestCommentsOptions{UpdatedAfter: time.UnixMilli(1548720847000)})
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, int64(1), result[0].ID)
+	}
+
+	// The comment was updated before the given time
+	result, err = client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, ListPullRequestCommentsOptions{UpdatedAfter: time.UnixMilli(1548720848000)})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	// The page is taken from all the comments
+	result, err = client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, ListPullRequestCommentsOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
 func TestBitbucketServer_GetLatestCommit(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
@@ -326,7 +910,7 @@ func TestBitbucketServer_GetLatestCommit(t *testing.T) {
 	// limit=1 appears twice because it is added twice by: github.com/gfleury/go-bitbucket-v1@v0.0.0-20210826163055-dff2223adeac/default_api.go:3848
 	client, serverUrl, cleanUp := createServerWithUrlAndClientReturningStatus(t, vcsutils.BitbucketServer, false,
 		response,
-		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=50&limit=50&until=master", owner, repo1),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=1&limit=1&start=0&until=master", owner, repo1),
 		http.StatusOK, createBitbucketServerHandler)
 	defer cleanUp()
 
@@ -391,6 +975,33 @@ func TestBitbucketServer_GetCommits(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=10&limit=10&path=README.md&start=10&until=master", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	result, err := client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", PerPage: 10, Page: 2})
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	// The author and the time are filtered on the client side
+	result, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", PerPage: 10, Page: 2, Author: "marly@example.com"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "marly", result[0].AuthorName)
+
+	result, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master", Path: "README.md", PerPage: 10, Page: 2, Since: time.UnixMilli(1548720847611)})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = createBadBitbucketServerClient(t).ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master"})
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_GetLatestCommitNotFound(t *testing.T) {
 	ctx := context.Background()
 	response := []byte(`{
@@ -403,7 +1014,7 @@ func TestBitbucketServer_GetLatestCommitNotFound(t *testing.T) {
     	]
 	}`)
 	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketServer, false, response,
-		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=50&limit=50&until=master", owner, repo1),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=1&limit=1&start=0&until=master", owner, repo1),
 		http.StatusNotFound, createBitbucketServerHandler)
 	defer cleanUp()
 
@@ -426,7 +1037,7 @@ func TestBitbucketServer_GetLatestCommitUnknownBranch(t *testing.T) {
 		]
 	}`)
 	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketServer, false, response,
-		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=50&limit=50&until=unknown", owner, repo1),
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/commits?limit=1&limit=1&start=0&until=unknown", owner, repo1),
 		http.StatusNotFound, createBitbucketServerHandler)
 	defer cleanUp()
 
@@ -504,18 +1115,7 @@ func TestBitbucketServer_AddSshKeyToRepositoryNotFound(t *testing.T) {
 func TestBitbucketServer_GetRepositoryInfo(t *testing.T) {
 	ctx := context.Background()
 
-	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
-	assert.NoError(t, err)
-
-	client, cleanUp := createServerAndClientReturningStatus(
-		t,
-		vcsutils.BitbucketServer,
-		false,
-		response,
-		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", owner, repo1),
-		http.StatusOK,
-		createBitbucketServerHandler,
-	)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createGetRepositoryInfoBitbucketServerHandler)
 	defer cleanUp()
 
 	t.Run("ok", func(t *testing.T) {
@@ -528,30 +1128,59 @@ func TestBitbucketServer_GetRepositoryInfo(t *testing.T) {
 					HTTP: "https://bitbucket.org/jfrog/repo-1.git",
 					SSH:  "ssh://git@bitbucket.org:jfrog/repo-1.git",
 				},
+				DefaultBranch: "main",
+				Description:   "My repo description.",
 			},
 			res,
 		)
 	})
 
-	_, err = createBadBitbucketServerClient(t).GetRepositoryInfo(ctx, owner, repo1)
-	assert.Error(t, err)
+	_, err := createBadBitbucketServerClient(t).GetRepositoryInfo(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CreateLabel(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketServer_GetLabel(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.GetLabel(ctx, owner, repo1, labelName)
+	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
-func TestBitbucketServer_CreateLabel(t *testing.T) {
+func TestBitbucketServer_ListRepositoryLabels(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
 	assert.NoError(t, err)
 
-	err = client.CreateLabel(ctx, owner, repo1, LabelInfo{})
+	_, err = client.ListRepositoryLabels(ctx, owner, repo1)
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
-func TestBitbucketServer_GetLabel(t *testing.T) {
+func TestBitbucketServer_UpdateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
 	assert.NoError(t, err)
 
-	_, err = client.GetLabel(ctx, owner, repo1, labelName)
+	err = client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: labelName})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
+func TestBitbucketServer_DeleteLabel(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	err = client.DeleteLabel(ctx, owner, repo1, labelName)
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
@@ -564,6 +1193,15 @@ func TestBitbucketServer_ListPullRequestLabels(t *testing.T) {
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
+func TestBitbucketServer_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	err = client.LabelPullRequest(ctx, owner, repo1, 1, []string{labelName})
+	assert.ErrorIs(t, err, errLabelsNotSupported)
+}
+
 func TestBitbucketServer_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
@@ -573,6 +1211,19 @@ func TestBitbucketServer_UnlabelPullRequest(t *testing.T) {
 	assert.ErrorIs(t, err, errLabelsNotSupported)
 }
 
+func TestBitbucketServer_Issues(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+
+	_, err = client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "")
+	assert.ErrorIs(t, err, errBitbucketServerIssuesNotSupported)
+	_, err = client.ListIssues(ctx, owner, repo1, ListIssuesOptions{})
+	assert.ErrorIs(t, err, errBitbucketServerIssuesNotSupported)
+	assert.ErrorIs(t, client.AddIssueComment(ctx, owner, repo1, "Fixed", 1), errBitbucketServerIssuesNotSupported)
+	assert.ErrorIs(t, client.CloseIssue(ctx, owner, repo1, 1), errBitbucketServerIssuesNotSupported)
+}
+
 func TestBitbucketServer_GetRepositoryEnvironmentInfo(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
@@ -580,6 +1231,116 @@ func TestBitbucketServer_GetRepositoryEnvironmentInfo(t *testing.T) {
 
 	_, err = client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
 	assert.ErrorIs(t, err, errBitbucketGetRepoEnvironmentInfoNotSupported)
+	_, err = client.ListEnvironments(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	_, err = client.CreateDeployment(ctx, owner, repo1, branch1, envName, "")
+	assert.ErrorIs(t, err, errBitbucketDeploymentsNotSupported)
+	assert.ErrorIs(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"), errBitbucketRepositorySecretsNotSupported)
+	_, err = client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errBitbucketRepositorySecretsNotSupported)
+	assert.ErrorIs(t, client.CreateOrUpdateOrganizationSecret(ctx, owner, "JF_TOKEN", "secret-value", VisibleToAllRepositories, nil), errBitbucketSecretsNotSupported)
+	_, err = client.ListOrganizationSecretRepositories(ctx, owner, "JF_TOKEN")
+	assert.ErrorIs(t, err, errBitbucketSecretsNotSupported)
+}
+
+func TestBitbucketServer_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
+	assert.NoError(t, err)
+	expectedBody := []byte(`{"name":"repo-1","scmId":"git","public":true,"description":"Frogs","defaultBranch":"develop"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		"/rest/api/1.0/projects/jfrog/repos", http.StatusCreated, expectedBody, http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	result, err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Description: "Frogs", DefaultBranch: "develop"})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo: CloneInfo{
+				HTTP: "https://bitbucket.org/jfrog/repo-1.git",
+				SSH:  "ssh://git@bitbucket.org:jfrog/repo-1.git",
+			},
+			Description: "My repo description.",
+		},
+		result,
+	)
+
+	_, err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{InitReadme: true})
+	assert.ErrorIs(t, err, errBitbucketInitReadmeNotSupported)
+
+	_, err = createBadBitbucketServerClient(t).CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.BitbucketServer, false, nil,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", owner, repo1), http.StatusAccepted, createBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+
+	err = createBadBitbucketServerClient(t).DeleteRepository(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
+	assert.NoError(t, err)
+	expectedBody := []byte(`{"project":{"key":"FROGS"}}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1", http.StatusCreated, expectedBody, http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	result, err := client.ForkRepository(ctx, owner, repo1, "FROGS")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Public,
+			CloneInfo: CloneInfo{
+				HTTP: "https://bitbucket.org/jfrog/repo-1.git",
+				SSH:  "ssh://git@bitbucket.org:jfrog/repo-1.git",
+			},
+			Description: "My repo description.",
+		},
+		result,
+	)
+
+	_, err = createBadBitbucketServerClient(t).ForkRepository(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_ListRepositoryCollaborators(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createUserPermissionsBitbucketServerHandler)
+	defer cleanUp()
+
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: WritePermission}, {Username: "toad", Permission: AdminPermission}}, collaborators)
+
+	_, err = createBadBitbucketServerClient(t).ListRepositoryCollaborators(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_GetRepositoryPermission(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createUserPermissionsBitbucketServerHandler)
+	defer cleanUp()
+
+	permission, err := client.GetRepositoryPermission(ctx, owner, repo1, "toad")
+	assert.NoError(t, err)
+	assert.Equal(t, AdminPermission, permission)
+
+	permission, err = client.GetRepositoryPermission(ctx, owner, repo1, "newt")
+	assert.NoError(t, err)
+	assert.Equal(t, NoPermission, permission)
+
+	_, err = createBadBitbucketServerClient(t).GetRepositoryPermission(ctx, owner, repo1, "toad")
+	assert.Error(t, err)
 }
 
 func TestBitbucketServer_GetCommitBySha(t *testing.T) {
@@ -643,6 +1404,56 @@ func TestBitbucketServer_UploadCodeScanning(t *testing.T) {
 	defer cleanUp()
 	_, err := client.UploadCodeScanning(ctx, owner, repo1, "", "1")
 	assert.Error(t, err)
+
+	client, cleanUp = createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createUploadCodeScanningBitbucketServerHandler)
+	defer cleanUp()
+	reportKey, err := client.UploadCodeScanning(ctx, owner, repo1, "master", codeScanningTestSarif)
+	assert.NoError(t, err)
+	assert.Equal(t, "code-scanning", reportKey)
+}
+
+func TestBitbucketServer_CreateCodeInsightsReport(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"title":"Xray","details":"Xray found 2 issues","result":"FAIL","reporter":"Xray","link":"https://jfrog.com/xray"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		"/rest/insights/1.0/projects/jfrog/repos/repo-1/commits/abcdef/reports/xray-scan", http.StatusOK, expectedBody, http.MethodPut, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateCodeInsightsReport(ctx, owner, repo1, "abcdef", CodeInsightsReport{
+		Key:      "xray-scan",
+		Title:    "Xray",
+		Details:  "Xray found 2 issues",
+		Result:   CodeInsightsFail,
+		Reporter: "Xray",
+		Link:     "https://jfrog.com/xray",
+	})
+	assert.NoError(t, err)
+
+	err = client.CreateCodeInsightsReport(ctx, owner, repo1, "abcdef", CodeInsightsReport{Title: "Xray"})
+	assert.Error(t, err)
+	err = createBadBitbucketServerClient(t).CreateCodeInsightsReport(ctx, owner, repo1, "abcdef", CodeInsightsReport{Key: "xray-scan", Title: "Xray"})
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_AddCodeInsightsAnnotations(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"annotations":[` +
+		`{"path":"package.json","line":12,"message":"Vulnerable dependency","severity":"HIGH","type":"VULNERABILITY","link":"https://jfrog.com/xray"},` +
+		`{"message":"Unused variable","severity":"LOW","type":"CODE_SMELL"}]}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, false, nil,
+		"/rest/insights/1.0/projects/jfrog/repos/repo-1/commits/abcdef/reports/xray-scan/annotations", http.StatusOK, expectedBody, http.MethodPost, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", []CodeInsightsAnnotation{
+		{Path: "package.json", Line: 12, Message: "Vulnerable dependency", Severity: CodeInsightsCriticalSeverity, Type: CodeInsightsVulnerability, Link: "https://jfrog.com/xray"},
+		{Message: "Unused variable", Severity: CodeInsightsLowSeverity, Type: CodeInsightsCodeSmell},
+	})
+	assert.NoError(t, err)
+
+	err = client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", make([]CodeInsightsAnnotation, maxCodeInsightsAnnotations+1))
+	assert.Error(t, err)
+	err = createBadBitbucketServerClient(t).AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", []CodeInsightsAnnotation{{Message: "Unused variable"}})
+	assert.Error(t, err)
 }
 
 func TestBitbucketServer_DownloadFileFromRepo(t *testing.T) {
@@ -667,6 +1478,53 @@ func TestBitbucketServer_DownloadFileFromRepo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServer_ListDirectoryContents(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"path": {"toString": "src"}, "children": {"isLastPage": true, "values": [
+		{"path": {"name": "go.mod", "toString": "go.mod"}, "type": "FILE"},
+		{"path": {"name": "pkg", "toString": "pkg"}, "type": "DIRECTORY"}
+	]}}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/browse/src?at=master&start=0", createBitbucketServerHandler)
+	defer cleanUp()
+
+	entries, err := client.ListDirectoryContents(ctx, owner, repo1, "master", "src")
+	assert.NoError(t, err)
+	assert.Equal(t, []DirectoryEntry{
+		{Name: "go.mod", Path: "src/go.mod", IsDir: false},
+		{Name: "pkg", Path: "src/pkg", IsDir: true},
+	}, entries)
+
+	fileClient, fileCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, []byte(`{"lines": [{"text": "module example"}]}`),
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/browse/go.mod?at=master&start=0", createBitbucketServerHandler)
+	defer fileCleanUp()
+	_, err = fileClient.ListDirectoryContents(ctx, owner, repo1, "master", "go.mod")
+	assert.EqualError(t, err, "path 'go.mod' is not a directory")
+
+	_, err = createBadBitbucketServerClient(t).ListDirectoryContents(ctx, owner, repo1, "master", "src")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServer_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	files := []FileToCommit{
+		{Path: "go.mod", Content: []byte("module example"), ChangeType: FileModified},
+		{Path: "src/main file.go", Content: []byte("package main"), ChangeType: FileModified},
+		{Path: "new.go", Content: []byte("package new"), ChangeType: FileAdded},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createCommitFilesBitbucketServerHandler)
+	defer cleanUp()
+
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", files)
+	assert.NoError(t, err)
+
+	err = client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileToCommit{{Path: "go.sum", ChangeType: FileDeleted}})
+	assert.ErrorIs(t, err, errBitbucketServerCommitDeletedFileNotSupported)
+
+	err = createBadBitbucketServerClient(t).CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", files)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServer_getRepositoryVisibility(t *testing.T) {
 	assert.Equal(t, Public, getBitbucketServerRepositoryVisibility(true))
 	assert.Equal(t, Private, getBitbucketServerRepositoryVisibility(false))
@@ -734,8 +1592,122 @@ func createBitbucketServerHandler(t *testing.T, expectedURI string, response []b
 	}
 }
 
+func createAuthenticatedUserBitbucketServerHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /plugins/servlet/applinks/whoami":
+			response = "frogger"
+		case "GET /rest/api/1.0/users?filter=frogger":
+			response = `{"values": [{"name": "frogger2", "displayName": "Frog Ger 2"}, {"name": "frogger", "displayName": "Frog Ger", "emailAddress": "frogger@jfrog.com"}]}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createUserPermissionsBitbucketServerHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /rest/api/1.0/projects/jfrog/permissions/users?limit=100&start=0":
+			response = `{"isLastPage": true, "values": [{"user": {"name": "frogger"}, "permission": "PROJECT_READ"}]}`
+		case "GET /rest/api/1.0/projects/jfrog/repos/repo-1/permissions/users?limit=100&start=0":
+			response = `{"isLastPage": true, "values": [{"user": {"name": "frogger"}, "permission": "REPO_WRITE"}, {"user": {"name": "toad"}, "permission": "REPO_ADMIN"}]}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createCompareCommitsBitbucketServerHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/compare/commits?from=feature&to=main&start=0&limit=100":
+			response = `{"isLastPage": true, "values": [{"id": "6dcb09b5b57875f334f61aebed695e2e4193db5e", "message": "Fix all the bugs",
+				"author": {"name": "frogger"}, "committer": {"name": "frogger"}, "parents": [{"id": "7638417db6d59f3c431d3e1f261cc637155684cd"}]}]}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/compare/changes?from=feature&to=main&start=0&limit=100":
+			response = `{"isLastPage": true, "values": [
+				{"path": {"toString": "README.md"}, "type": "MODIFY"},
+				{"path": {"toString": "new.go"}, "type": "ADD"}
+			]}`
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createGetTagInfoBitbucketServerHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response []byte
+		var err error
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/tags/v1.0.0":
+			response = []byte(`{"id": "refs/tags/v1.0.0", "displayId": "v1.0.0", "latestCommit": "abcdef0123abcdef4567abcdef8987abcdef6543"}`)
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/commits/abcdef0123abcdef4567abcdef8987abcdef6543":
+			response, err = os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_single_response.json"))
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		assert.NoError(t, err)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func createBranchProtectionBitbucketServerHandler(restricted bool) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			restrictionsPath := "/rest/branch-permissions/2.0/projects/jfrog/repos/repo-1/restrictions"
+			var response string
+			switch r.Method + " " + r.RequestURI {
+			case "GET " + restrictionsPath + "?limit=100&matcherId=refs%2Fheads%2F" + branch1 + "&matcherType=BRANCH&type=pull-request-only":
+				response = `{"values": []}`
+				if restricted {
+					response = `{"values": [{"id": 3, "type": "pull-request-only", "matcher": {"id": "refs/heads/` + branch1 + `"}}]}`
+				}
+			case "POST " + restrictionsPath:
+				assert.False(t, restricted)
+				assert.JSONEq(t, `{
+					"type": "pull-request-only",
+					"matcher": {"id": "refs/heads/`+branch1+`", "displayId": "`+branch1+`", "type": {"id": "BRANCH", "name": "Branch"}, "active": true},
+					"users": [], "groups": [], "accessKeys": []
+				}`, string(body))
+				response = `{"id": 4}`
+			case "DELETE " + restrictionsPath + "/3":
+				assert.True(t, restricted)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+			_, err = w.Write([]byte(response))
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func createBitbucketServerDownloadRepositoryHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
+	repositoryInfoHandler := createGetRepositoryInfoBitbucketServerHandler(t, "", nil, 0)
 	return func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default" {
+			repositoryInfoHandler(w, r)
+			return
+		}
 		if r.RequestURI == "/rest/api/1.0/projects/jfrog/repos/repo-1" {
 			repositoryResponse, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
 			assert.NoError(t, err)
@@ -750,6 +1722,25 @@ func createBitbucketServerDownloadRepositoryHandler(t *testing.T, expectedURI st
 	}
 }
 
+func createGetRepositoryInfoBitbucketServerHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		var response []byte
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1":
+			var err error
+			response, err = os.ReadFile(filepath.Join("testdata", "bitbucketserver", "repository_response.json"))
+			assert.NoError(t, err)
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/branches/default":
+			response = []byte(`{"id":"refs/heads/main","displayId":"main","type":"BRANCH","isDefault":true}`)
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
 func createBitbucketServerListRepositoriesHandler(t *testing.T, _ string, _ []byte, expectedStatusCode int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var responseObj interface{}
@@ -787,6 +1778,43 @@ func createBitbucketServerDownloadFileFromRepositoryHandler(t *testing.T, _ stri
 	}
 }
 
+// The handler expects every file to be committed on top of the commit created for the previous file
+func createCommitFilesBitbucketServerHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		if r.Method == http.MethodGet {
+			assert.True(t, strings.HasPrefix(r.RequestURI, "/rest/api/1.0/projects/jfrog/repos/repo-1/commits?"))
+			_, err := w.Write([]byte(`{"values": [{"id": "latest-commit"}]}`))
+			assert.NoError(t, err)
+			return
+		}
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.NoError(t, r.ParseMultipartForm(1024))
+		assert.Equal(t, []string{"Update dependencies"}, r.MultipartForm.Value["message"])
+		assert.Equal(t, []string{branch1}, r.MultipartForm.Value["branch"])
+		var response string
+		switch r.RequestURI {
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/browse/go.mod":
+			assert.Equal(t, []string{"latest-commit"}, r.MultipartForm.Value["sourceCommitId"])
+			assert.Equal(t, "module example", readMultipartFile(t, r, "content"))
+			response = `{"id": "go-mod-commit"}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/browse/src/main%20file.go":
+			assert.Equal(t, []string{"go-mod-commit"}, r.MultipartForm.Value["sourceCommitId"])
+			assert.Equal(t, "package main", readMultipartFile(t, r, "content"))
+			response = `{"id": "main-file-commit"}`
+		case "/rest/api/1.0/projects/jfrog/repos/repo-1/browse/new.go":
+			// Added files don't have a source commit
+			assert.Empty(t, r.MultipartForm.Value["sourceCommitId"])
+			assert.Equal(t, "package new", readMultipartFile(t, r, "content"))
+			response = `{"id": "new-file-commit"}`
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
 func createBitbucketServerWithBodyHandler(t *testing.T, expectedURI string, response []byte, expectedRequestBody []byte,
 	expectedStatusCode int, expectedHTTPMethod string) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
@@ -845,6 +1873,33 @@ func TestBitbucketServer_TestGetCommitStatus(t *testing.T) {
 	})
 }
 
+func TestBitbucketServer_GetCombinedCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	ref := "9caf1c431fb783b669f0f909bd018b40f2ea3808"
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commits_statuses.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, response,
+		fmt.Sprintf("/rest/build-status/1.0/commits/%s", ref), createBitbucketServerHandler)
+	defer cleanUp()
+
+	status, err := client.GetCombinedCommitStatus(ctx, owner, repo1, ref)
+	assert.NoError(t, err)
+	assert.Equal(t, Fail, status)
+
+	_, err = createBadBitbucketServerClient(t).GetCombinedCommitStatus(ctx, owner, repo1, ref)
+	assert.Error(t, err)
+}
+
+func TestBitbucketServerClient_Pipelines(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.BitbucketServer).Build()
+	assert.NoError(t, err)
+	_, err = client.TriggerPipeline(ctx, owner, repo1, branch1, nil)
+	assert.ErrorIs(t, err, errBitbucketServerPipelinesNotSupported)
+	_, err = client.GetLatestPipelineStatus(ctx, owner, repo1, branch1)
+	assert.ErrorIs(t, err, errBitbucketServerPipelinesNotSupported)
+}
+
 func TestBitbucketServerClient_DeletePullRequestReviewComments(t *testing.T) {
 	ctx := context.Background()
 	prId := 4
@@ -861,6 +1916,30 @@ func TestBitbucketServerClient_DeletePullRequestReviewComments(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServerClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	prId := 4
+	commentId := 1
+	response, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response, fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v/activities?start=0", prId)+
+		fmt.Sprintf("/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/%v/comments/%v", prId, commentId), createBitbucketServerHandler)
+	defer cleanUp()
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "Updated content", prId, commentId)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", prId, commentId)
+	assert.Error(t, err)
+
+	// The current version of the comment can't be found, hence it isn't updated
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "Updated content", prId, 10)
+	assert.ErrorIs(t, err, errBitbucketServerCommentNotFound)
+
+	err = createBadBitbucketServerClient(t).UpdatePullRequestComment(ctx, owner, repo1, "Updated content", prId, commentId)
+	assert.Error(t, err)
+}
+
 func TestBitbucketServerClient_DeletePullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	prId := 4
@@ -877,8 +1956,116 @@ func TestBitbucketServerClient_DeletePullRequestComment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestBitbucketServerClient_SetPullRequestThreadStatus(t *testing.T) {
+	ctx := context.Background()
+	expectedURI := "/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/4/comments/10"
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, nil, expectedURI,
+		func(t *testing.T, expectedURI string, _ []byte, _ int) http.HandlerFunc {
+			return func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, expectedURI, request.RequestURI)
+				if request.Method == http.MethodGet {
+					_, err := writer.Write([]byte(`{"id": 10, "text": "Please fix", "version": 2}`))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, http.MethodPut, request.Method)
+				body, err := io.ReadAll(request.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"text": "Please fix", "version": 2, "threadResolved": true}`, string(body))
+				_, err = writer.Write([]byte("{}"))
+				assert.NoError(t, err)
+			}
+		})
+	defer cleanUp()
+
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 4, "10", true))
+	assert.Error(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 4, "", true))
+	assert.Error(t, createBadBitbucketServerClient(t).SetPullRequestThreadStatus(ctx, owner, repo1, 4, "10", true))
+}
+
+func TestBitbucketServerClient_CreatePullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"user":{"name":"frogger"},"approved":true,"status":"APPROVED"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.BitbucketServer, true, []byte("{}"),
+		"/rest/api/1.0/projects/jfrog/repos/repo-1/pull-requests/1/participants/frogger", http.StatusOK,
+		expectedBody, http.MethodPut, createBitbucketServerWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictApprove, "")
+	assert.NoError(t, err)
+
+	err = client.CreatePullRequestReview(ctx, owner, repo1, 1, "unknown", "")
+	assert.Error(t, err)
+
+	// The participant status is set for the client's user, which must be provided
+	noUserClient, noUserCleanUp := createServerAndClient(t, vcsutils.BitbucketServer, false, nil, "", createBitbucketServerHandler)
+	defer noUserCleanUp()
+	err = noUserClient.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictRequestChanges, "")
+	assert.Error(t, err)
+}
+
+func TestBitbucketServerClient_ListPullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"isLastPage": true, "values": [
+		{"id": 5, "createdDate": 1685613600000, "user": {"name": "reviewer1"}, "action": "APPROVED"},
+		{"id": 4, "createdDate": 1685613500000, "user": {"name": "reviewer2"}, "action": "UNAPPROVED"},
+		{"id": 3, "createdDate": 1685613400000, "user": {"name": "reviewer3"}, "action": "REVIEWED"},
+		{"id": 2, "createdDate": 1685613300000, "user": {"name": "reviewer2"}, "action": "APPROVED"},
+		{"id": 1, "createdDate": 1685613200000, "user": {"name": "reviewer4"}, "action": "COMMENTED", "commentAction": "ADDED"}
+	]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.BitbucketServer, true, response,
+		fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/1/activities?start=0", owner, repo1), createBitbucketServerHandler)
+	defer cleanUp()
+
+	reviews, err := client.ListPullRequestReviews(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewInfo{
+		{Reviewer: "reviewer1", State: vcsutils.ReviewVerdictApprove, Submitted: time.UnixMilli(1685613600000)},
+		{Reviewer: "reviewer3", State: vcsutils.ReviewVerdictRequestChanges, Submitted: time.UnixMilli(1685613400000)},
+	}, reviews)
+
+	_, err = createBadBitbucketServerClient(t).ListPullRequestReviews(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
 func createBadBitbucketServerClient(t *testing.T) VcsClient {
 	client, err := NewClientBuilder(vcsutils.BitbucketServer).ApiEndpoint("https://bad^endpoint").Build()
 	assert.NoError(t, err)
 	return client
 }
+
+func createUploadCodeScanningBitbucketServerHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		reportUri := "/rest/insights/1.0/projects/jfrog/repos/repo-1/commits/def0123abcdef4567abcdef8987abcdef6543abc/reports/code-scanning"
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /rest/api/1.0/projects/jfrog/repos/repo-1/commits?limit=1&limit=1&start=0&until=master":
+			response, err = os.ReadFile(filepath.Join("testdata", "bitbucketserver", "commit_list_response.json"))
+			assert.NoError(t, err)
+		case "PUT " + reportUri:
+			assert.JSONEq(t, `{"title":"Xray","details":"Xray found 2 issues","result":"FAIL","reporter":"Xray"}`, string(body))
+		case "POST " + reportUri + "/annotations":
+			assert.JSONEq(t, `{"annotations":[`+
+				`{"path":"package.json","line":12,"message":"XRAY-174176: json 9.0.6. Fixed in Versions: [11.0.0]","severity":"HIGH","type":"VULNERABILITY"},`+
+				`{"message":"XRAY-174177: lodash 4.17.0. Fixed in Versions: [4.17.21]","severity":"MEDIUM","type":"VULNERABILITY"}]}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func TestGetBitbucketServerWebhookEvents(t *testing.T) {
+	assert.Equal(t, []string{"pr:opened", "pr:declined", "pr:deleted", "pr:merged", "repo:refs_changed"},
+		getBitbucketServerWebhookEvents(vcsutils.PrOpened, vcsutils.PrRejected, vcsutils.PrClosed, vcsutils.PrMerged, vcsutils.Push))
+	assert.Equal(t, []string{"pr:comment:added", "pr:comment:edited"},
+		getBitbucketServerWebhookEvents(vcsutils.PrCommentCreated, vcsutils.PrCommentEdited))
+	assert.Equal(t, []string{"repo:refs_changed", "repo:refs_changed"},
+		getBitbucketServerWebhookEvents(vcsutils.BranchDeleted, vcsutils.TagPushed))
+	assert.Empty(t, getBitbucketServerWebhookEvents(vcsutils.PrReopened, vcsutils.IssueCommentCreated))
+}
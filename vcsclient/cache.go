@@ -0,0 +1,194 @@
+package vcsclient
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	defaultResponseCacheMaxEntries = 1000
+	// Larger responses, such as repository archives, aren't cached
+	maxCachedResponseBodySize = 1 << 20
+)
+
+// CachedResponse is a response of the VCS provider, which is revalidated with conditional requests.
+// ETag         - The ETag header of the response, sent as the If-None-Match header of the next request
+// LastModified - The Last-Modified header of the response, sent as the If-Modified-Since header of the next request
+type CachedResponse struct {
+	ETag         string
+	LastModified string
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+}
+
+// ResponseCache stores the responses of the VCS provider, by the method, the URL and the credentials of the request.
+// Implement it to store the responses in an external cache, which can be shared by several processes.
+type ResponseCache interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, response CachedResponse)
+}
+
+// InMemoryResponseCache is a ResponseCache which keeps the most recently used responses in memory.
+// It can be shared by several clients.
+type InMemoryResponseCache struct {
+	maxEntries   int
+	entries      map[string]*list.Element
+	recentlyUsed *list.List
+	lock         sync.Mutex
+}
+
+type inMemoryResponseCacheEntry struct {
+	key      string
+	response CachedResponse
+}
+
+// NewInMemoryResponseCache creates an InMemoryResponseCache.
+// maxEntries - The maximal number of cached responses, after which the least recently used responses are evicted. Defaults to 1000
+func NewInMemoryResponseCache(maxEntries int) *InMemoryResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultResponseCacheMaxEntries
+	}
+	return &InMemoryResponseCache{maxEntries: maxEntries, entries: map[string]*list.Element{}, recentlyUsed: list.New()}
+}
+
+// Get returns the cached response of the key
+func (cache *InMemoryResponseCache) Get(key string) (CachedResponse, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	element, exists := cache.entries[key]
+	if !exists {
+		return CachedResponse{}, false
+	}
+	cache.recentlyUsed.MoveToFront(element)
+	return element.Value.(*inMemoryResponseCacheEntry).response, true
+}
+
+// Set caches the response of the key, and evicts the least recently used response if the cache is full
+func (cache *InMemoryResponseCache) Set(key string, response CachedResponse) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if element, exists := cache.entries[key]; exists {
+		element.Value.(*inMemoryResponseCacheEntry).response = response
+		cache.recentlyUsed.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.recentlyUsed.PushFront(&inMemoryResponseCacheEntry{key: key, response: response})
+	if cache.recentlyUsed.Len() > cache.maxEntries {
+		oldest := cache.recentlyUsed.Back()
+		cache.recentlyUsed.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*inMemoryResponseCacheEntry).key)
+	}
+}
+
+// cacheTransport sends the GET requests as conditional requests, with the ETag and the Last-Modified headers of the cached responses.
+// The cached response is returned when the VCS provider responds with 304 Not Modified, which isn't counted by the rate limit of GitHub.
+type cacheTransport struct {
+	base  http.RoundTripper
+	cache ResponseCache
+}
+
+func (transport *cacheTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Requests which are already conditional are sent as they are, since their caller handles the 304 responses
+	if request.Method != http.MethodGet || request.Header.Get("Range") != "" ||
+		request.Header.Get("If-None-Match") != "" || request.Header.Get("If-Modified-Since") != "" {
+		return transport.base.RoundTrip(request)
+	}
+	key := getResponseCacheKey(request)
+	cachedResponse, isCached := transport.cache.Get(key)
+	if isCached {
+		request = request.Clone(request.Context())
+		if cachedResponse.ETag != "" {
+			request.Header.Set("If-None-Match", cachedResponse.ETag)
+		}
+		if cachedResponse.LastModified != "" {
+			request.Header.Set("If-Modified-Since", cachedResponse.LastModified)
+		}
+	}
+	response, err := transport.base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	if isCached && response.StatusCode == http.StatusNotModified {
+		if err = errors.Join(vcsutils.DiscardResponseBody(response), response.Body.Close()); err != nil {
+			return nil, err
+		}
+		return cachedResponse.toHttpResponse(request), nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return response, nil
+	}
+	return transport.cacheResponse(key, response)
+}
+
+// cacheResponse caches a response which has an ETag or a Last-Modified header, and restores its body for the caller.
+// Responses which must not be stored are skipped. Private responses are cached, since the cache key includes the credentials of the request.
+func (transport *cacheTransport) cacheResponse(key string, response *http.Response) (*http.Response, error) {
+	etag, lastModified := response.Header.Get("ETag"), response.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" || response.ContentLength > maxCachedResponseBodySize || hasNoStoreDirective(response.Header) {
+		return response, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxCachedResponseBodySize+1))
+	if err != nil {
+		return nil, errors.Join(err, response.Body.Close())
+	}
+	if len(body) > maxCachedResponseBodySize {
+		// The rest of the body is read by the caller
+		response.Body = &multiReadCloser{Reader: io.MultiReader(bytes.NewReader(body), response.Body), Closer: response.Body}
+		return response, nil
+	}
+	if err = response.Body.Close(); err != nil {
+		return nil, err
+	}
+	transport.cache.Set(key, CachedResponse{ETag: etag, LastModified: lastModified, StatusCode: response.StatusCode, Header: response.Header.Clone(), Body: body})
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	return response, nil
+}
+
+// hasNoStoreDirective returns true if the Cache-Control header of the response forbids storing it
+func hasNoStoreDirective(header http.Header) bool {
+	for _, cacheControl := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(cacheControl, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (cachedResponse CachedResponse) toHttpResponse(request *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cachedResponse.StatusCode, http.StatusText(cachedResponse.StatusCode)),
+		StatusCode:    cachedResponse.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cachedResponse.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cachedResponse.Body)),
+		ContentLength: int64(len(cachedResponse.Body)),
+		Request:       request,
+	}
+}
+
+// getResponseCacheKey returns the key of the request's response.
+// The credentials are part of the key, since users with different permissions get different responses. They are hashed, to avoid storing them in the cache.
+func getResponseCacheKey(request *http.Request) string {
+	credentials := sha256.Sum256([]byte(request.Header.Get("Authorization") + "\n" + request.Header.Get("Private-Token") + "\n" + request.Header.Get("Job-Token")))
+	return request.Method + " " + request.URL.String() + " " + request.Header.Get("Accept") + " " + hex.EncodeToString(credentials[:])
+}
+
+type multiReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,134 @@
+package vcsclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheTransport(t *testing.T) {
+	const etag = `"0123456789abcdef"`
+	var requests, notModifiedResponses int
+	body := "branches"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method == http.MethodGet && r.Header.Get("If-None-Match") == etag {
+			notModifiedResponses++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	httpClient := newHttpClient(VcsInfo{ResponseCache: NewInMemoryResponseCache(0)})
+	send := func(method, authorization string) (int, string) {
+		request, err := http.NewRequestWithContext(context.Background(), method, server.URL+"/branches", nil)
+		assert.NoError(t, err)
+		request.Header.Set("Authorization", authorization)
+		response, err := httpClient.Do(request)
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, response.Body.Close()) }()
+		responseBody, err := io.ReadAll(response.Body)
+		assert.NoError(t, err)
+		return response.StatusCode, string(responseBody)
+	}
+
+	for i := 0; i < 3; i++ {
+		status, actual := send(http.MethodGet, "Bearer "+token)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "branches", actual)
+	}
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 2, notModifiedResponses)
+
+	// The responses of other credentials and other methods aren't cached
+	_, actual := send(http.MethodGet, "Bearer other-token")
+	assert.Equal(t, "branches", actual)
+	_, actual = send(http.MethodPost, "Bearer "+token)
+	assert.Equal(t, "branches", actual)
+	assert.Equal(t, 2, notModifiedResponses)
+}
+
+func TestCacheTransportNoStore(t *testing.T) {
+	var conditionalRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			conditionalRequests++
+		}
+		w.Header().Set("ETag", `"0123456789abcdef"`)
+		w.Header().Set("Cache-Control", "private, No-Store")
+		_, err := w.Write([]byte("secrets"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	cache := NewInMemoryResponseCache(0)
+	httpClient := newHttpClient(VcsInfo{ResponseCache: cache})
+	for i := 0; i < 2; i++ {
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/secrets", nil)
+		assert.NoError(t, err)
+		response, err := httpClient.Do(request)
+		assert.NoError(t, err)
+		responseBody, err := io.ReadAll(response.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, response.Body.Close())
+		assert.Equal(t, "secrets", string(responseBody))
+	}
+	// The response isn't stored, hence it isn't revalidated
+	assert.Zero(t, conditionalRequests)
+	assert.Zero(t, cache.recentlyUsed.Len())
+}
+
+func TestInMemoryResponseCache(t *testing.T) {
+	cache := NewInMemoryResponseCache(2)
+	cache.Set("first", CachedResponse{ETag: "1"})
+	cache.Set("second", CachedResponse{ETag: "2"})
+	_, exists := cache.Get("first")
+	assert.True(t, exists)
+
+	// The least recently used response is evicted
+	cache.Set("third", CachedResponse{ETag: "3"})
+	_, exists = cache.Get("second")
+	assert.False(t, exists)
+	response, exists := cache.Get("first")
+	assert.True(t, exists)
+	assert.Equal(t, "1", response.ETag)
+	response, exists = cache.Get("third")
+	assert.True(t, exists)
+	assert.Equal(t, "3", response.ETag)
+}
+
+func TestGitHubClient_ResponseCache(t *testing.T) {
+	ctx := context.Background()
+	const lastModified = "Mon, 02 Sep 2024 10:00:00 GMT"
+	var notModifiedResponses int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/jfrog/repo-1/branches", r.URL.Path)
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			notModifiedResponses++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		_, err := w.Write([]byte(`[{"name": "master"}, {"name": "dev"}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).ResponseCache(NewInMemoryResponseCache(0)).Build()
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		branches, err := client.ListBranches(ctx, owner, repo1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"master", "dev"}, branches)
+	}
+	assert.Equal(t, 1, notModifiedResponses)
+}
@@ -0,0 +1,1399 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/gofrog/datastructures"
+)
+
+const (
+	// The maximal size of the pull request descriptions and comments on AWS CodeCommit
+	codeCommitPrContentSizeLimit = 10240
+	// The maximal page size of the AWS CodeCommit API
+	codeCommitPageSize = 100
+	// The maximal number of repositories which can be fetched together
+	codeCommitRepositoriesBatchSize = 25
+	notSupportedOnCodeCommit        = "not supported on AWS CodeCommit"
+)
+
+var (
+	errCodeCommitMissingRegion                  = errors.New("the AWS region is missing, set it or use the regional AWS CodeCommit endpoint")
+	errCodeCommitAuthenticatedUserNotSupported  = fmt.Errorf("get authenticated user is %s, use AWS STS instead", notSupportedOnCodeCommit)
+	errCodeCommitTokenScopesNotSupported        = fmt.Errorf("verifying token scopes is %s, permissions are granted by IAM policies", notSupportedOnCodeCommit)
+	errCodeCommitAppInstallationsNotSupported   = fmt.Errorf("app installations are %s", notSupportedOnCodeCommit)
+	errCodeCommitBranchProtectionNotSupported   = fmt.Errorf("branch protection is %s, use approval rule templates instead", notSupportedOnCodeCommit)
+	errCodeCommitTagsNotSupported               = fmt.Errorf("tags are %s", notSupportedOnCodeCommit)
+	errCodeCommitReleasesNotSupported           = fmt.Errorf("releases are %s", notSupportedOnCodeCommit)
+	errCodeCommitWebhooksNotSupported           = fmt.Errorf("webhooks are %s, use Amazon EventBridge rules instead", notSupportedOnCodeCommit)
+	errCodeCommitCommitStatusesNotSupported     = fmt.Errorf("commit statuses are %s", notSupportedOnCodeCommit)
+	errCodeCommitDownloadRepositoryNotSupported = fmt.Errorf("download repository is %s, clone it instead", notSupportedOnCodeCommit)
+	errCodeCommitNumericCommentIDsNotSupported  = errors.New("comments can't be identified by numeric IDs on AWS CodeCommit, use their thread IDs instead")
+	errCodeCommitRequestChangesNotSupported     = fmt.Errorf("requesting changes is %s, add a comment instead", notSupportedOnCodeCommit)
+	errCodeCommitChangeTargetBranchNotSupported = fmt.Errorf("changing the target branch of a pull request is %s", notSupportedOnCodeCommit)
+	errCodeCommitPullRequestDiffNotSupported    = fmt.Errorf("get pull request diff is %s", notSupportedOnCodeCommit)
+	errCodeCommitCommitsHistoryNotSupported     = fmt.Errorf("listing the commits history is %s", notSupportedOnCodeCommit)
+	errCodeCommitCompareCommitsNotSupported     = fmt.Errorf("compare commits is %s, use get modified files instead", notSupportedOnCodeCommit)
+	errCodeCommitSshKeysNotSupported            = fmt.Errorf("repository SSH keys are %s, SSH keys are uploaded to IAM users", notSupportedOnCodeCommit)
+	errCodeCommitCollaboratorsNotSupported      = fmt.Errorf("repository collaborators are %s, permissions are granted by IAM policies", notSupportedOnCodeCommit)
+	errCodeCommitForkNotSupported               = fmt.Errorf("forking repositories is %s", notSupportedOnCodeCommit)
+	errCodeCommitInitReadmeNotSupported         = fmt.Errorf("initializing a repository with a README is %s", notSupportedOnCodeCommit)
+	errCodeCommitAutoMergeNotSupported          = fmt.Errorf("auto-merge is %s", notSupportedOnCodeCommit)
+	errCodeCommitLabelsNotSupported             = fmt.Errorf("labels are %s", notSupportedOnCodeCommit)
+	errCodeCommitIssuesNotSupported             = fmt.Errorf("issues are %s", notSupportedOnCodeCommit)
+	errCodeCommitCodeScanningNotSupported       = fmt.Errorf("code scanning is %s", notSupportedOnCodeCommit)
+	errCodeCommitCodeInsightsNotSupported       = fmt.Errorf("code insights reports are %s", notSupportedOnCodeCommit)
+	errCodeCommitCheckRunsNotSupported          = fmt.Errorf("check runs are %s", notSupportedOnCodeCommit)
+	errCodeCommitEnvironmentsNotSupported       = fmt.Errorf("get repository environment info is %s", notSupportedOnCodeCommit)
+	errCodeCommitThreadStatusNotSupported       = fmt.Errorf("resolving comment threads is %s", notSupportedOnCodeCommit)
+	errCodeCommitPipelinesNotSupported          = fmt.Errorf("pipelines are %s, use AWS CodePipeline instead", notSupportedOnCodeCommit)
+	errCodeCommitDeploymentsNotSupported        = fmt.Errorf("deployments and environments are %s, use AWS CodeDeploy instead", notSupportedOnCodeCommit)
+	errCodeCommitRepositorySecretsNotSupported  = fmt.Errorf("repository secrets and variables are %s, use AWS Secrets Manager instead", notSupportedOnCodeCommit)
+	errCodeCommitSecretsNotSupported            = fmt.Errorf("organization and environment secrets are %s, use AWS Secrets Manager instead", notSupportedOnCodeCommit)
+)
+
+// CodeCommitClient API version 2015-04-13.
+// The repositories of AWS CodeCommit belong to the AWS account and region of the client, hence the owner of the repositories is ignored.
+type CodeCommitClient struct {
+	vcsInfo          VcsInfo
+	logger           vcsutils.Log
+	codeCommitClient *codecommit.Client
+}
+
+// NewCodeCommitClient create a new CodeCommitClient.
+// The requests are signed with Signature Version 4, by the AWS credentials provider if set, or by the static credentials otherwise.
+// The static credentials are the access key ID, taken from the username, the secret access key, taken from the token, and the optional session token.
+// The API endpoint is optional, and is used instead of the regional AWS CodeCommit endpoint, for example for VPC endpoints.
+func NewCodeCommitClient(vcsInfo VcsInfo, logger vcsutils.Log) (*CodeCommitClient, error) {
+	if vcsInfo.AWSRegion == "" {
+		vcsInfo.AWSRegion = getCodeCommitRegionFromEndpoint(vcsInfo.APIEndpoint)
+	}
+	if vcsInfo.AWSRegion == "" {
+		return nil, errCodeCommitMissingRegion
+	}
+	options := codecommit.Options{
+		Region:       vcsInfo.AWSRegion,
+		BaseEndpoint: vcsutils.GetNilIfZeroVal(strings.TrimSuffix(vcsInfo.APIEndpoint, "/")),
+		Credentials:  vcsInfo.AWSCredentialsProvider,
+		HTTPClient:   newHttpClient(vcsInfo),
+		// The requests are retried according to the retry options of the client, rather than by the AWS SDK
+		Retryer: aws.NopRetryer{},
+	}
+	if options.Credentials == nil && vcsInfo.Username != "" {
+		options.Credentials = credentials.NewStaticCredentialsProvider(vcsInfo.Username, vcsInfo.Token, vcsInfo.AWSSessionToken)
+	}
+	return &CodeCommitClient{vcsInfo: vcsInfo, logger: logger, codeCommitClient: codecommit.New(options)}, nil
+}
+
+// getCodeCommitRegionFromEndpoint returns the region of a regional AWS CodeCommit endpoint, such as https://codecommit.us-east-1.amazonaws.com
+func getCodeCommitRegionFromEndpoint(endpoint string) string {
+	parsedEndpoint, err := neturl.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	hostParts := strings.Split(parsedEndpoint.Hostname(), ".")
+	if len(hostParts) < 4 || !strings.HasPrefix(hostParts[0], "codecommit") || !strings.HasSuffix(parsedEndpoint.Hostname(), ".amazonaws.com") {
+		return ""
+	}
+	return hostParts[1]
+}
+
+// TestConnection on AWS CodeCommit
+func (client *CodeCommitClient) TestConnection(ctx context.Context) error {
+	_, err := client.codeCommitClient.ListRepositories(ctx, &codecommit.ListRepositoriesInput{})
+	return err
+}
+
+// TestConnectionWithScopes on AWS CodeCommit.
+// The permissions of AWS credentials are granted by IAM policies, so an error is returned if any scope is required.
+func (client *CodeCommitClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	if err := client.TestConnection(ctx); err != nil {
+		return err
+	}
+	if len(scopes) > 0 {
+		return errCodeCommitTokenScopesNotSupported
+	}
+	return nil
+}
+
+// GetAuthenticatedUser on AWS CodeCommit
+func (client *CodeCommitClient) GetAuthenticatedUser(_ context.Context) (UserInfo, error) {
+	return UserInfo{}, errCodeCommitAuthenticatedUserNotSupported
+}
+
+// ListRepositories on AWS CodeCommit.
+// The repositories are grouped by the ID of the AWS account they belong to.
+func (client *CodeCommitClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	var repositoryNames []string
+	for nextToken := (*string)(nil); ; {
+		output, err := client.codeCommitClient.ListRepositories(ctx, &codecommit.ListRepositoriesInput{NextToken: nextToken})
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range output.Repositories {
+			repositoryNames = append(repositoryNames, aws.ToString(repository.RepositoryName))
+		}
+		if nextToken = output.NextToken; nextToken == nil {
+			break
+		}
+	}
+
+	results := make(map[string][]string)
+	for start := 0; start < len(repositoryNames); start += codeCommitRepositoriesBatchSize {
+		end := start + codeCommitRepositoriesBatchSize
+		if end > len(repositoryNames) {
+			end = len(repositoryNames)
+		}
+		output, err := client.codeCommitClient.BatchGetRepositories(ctx, &codecommit.BatchGetRepositoriesInput{RepositoryNames: repositoryNames[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range output.Repositories {
+			accountID := aws.ToString(repository.AccountId)
+			results[accountID] = append(results[accountID], aws.ToString(repository.RepositoryName))
+		}
+	}
+	return results, nil
+}
+
+// ListAppInstallations on AWS CodeCommit
+func (client *CodeCommitClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, errCodeCommitAppInstallationsNotSupported
+}
+
+// ListInstallationRepositories on AWS CodeCommit
+func (client *CodeCommitClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, errCodeCommitAppInstallationsNotSupported
+}
+
+// ListRepositoriesWithOptions on AWS CodeCommit.
+// The repositories are grouped by the accounts they belong to only after they are listed, hence all the repositories are listed and the page is taken from them.
+func (client *CodeCommitClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	repositories, err := client.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getRepositoriesPage(repositories, options), nil
+}
+
+// ListBranches on AWS CodeCommit
+func (client *CodeCommitClient) ListBranches(ctx context.Context, _, repository string) ([]string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	var results []string
+	for nextToken := (*string)(nil); ; {
+		output, err := client.codeCommitClient.ListBranches(ctx, &codecommit.ListBranchesInput{RepositoryName: &repository, NextToken: nextToken})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, output.Branches...)
+		if nextToken = output.NextToken; nextToken == nil {
+			return results, nil
+		}
+	}
+}
+
+// ListBranchesWithOptions on AWS CodeCommit.
+// The API lists the branches by continuation tokens without an order, hence all the branches are listed and the page is taken from them.
+func (client *CodeCommitClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	branches, err := client.ListBranches(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(branches)
+	return getListPage(branches, options), nil
+}
+
+// CreateBranch on AWS CodeCommit.
+// The source reference is either a branch name or a commit ID.
+func (client *CodeCommitClient) CreateBranch(ctx context.Context, _, repository, sourceRef, newBranch string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "sourceRef": sourceRef, "newBranch": newBranch}); err != nil {
+		return err
+	}
+	commitID, err := client.resolveCommitID(ctx, repository, sourceRef)
+	if err != nil {
+		return err
+	}
+	_, err = client.codeCommitClient.CreateBranch(ctx, &codecommit.CreateBranchInput{
+		RepositoryName: &repository,
+		BranchName:     &newBranch,
+		CommitId:       &commitID,
+	})
+	return err
+}
+
+// resolveCommitID returns the ID of the last commit of a branch, or the reference itself if it isn't a branch
+func (client *CodeCommitClient) resolveCommitID(ctx context.Context, repository, ref string) (string, error) {
+	output, err := client.codeCommitClient.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: &repository, BranchName: &ref})
+	if err != nil {
+		var branchNotFound *types.BranchDoesNotExistException
+		if errors.As(err, &branchNotFound) {
+			return ref, nil
+		}
+		return "", err
+	}
+	return aws.ToString(output.Branch.CommitId), nil
+}
+
+// DeleteBranch on AWS CodeCommit
+func (client *CodeCommitClient) DeleteBranch(ctx context.Context, _, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return err
+	}
+	_, err := client.codeCommitClient.DeleteBranch(ctx, &codecommit.DeleteBranchInput{RepositoryName: &repository, BranchName: &branch})
+	return err
+}
+
+// GetDefaultBranch on AWS CodeCommit
+func (client *CodeCommitClient) GetDefaultBranch(ctx context.Context, _, repository string) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return "", err
+	}
+	output, err := client.codeCommitClient.GetRepository(ctx, &codecommit.GetRepositoryInput{RepositoryName: &repository})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.RepositoryMetadata.DefaultBranch), nil
+}
+
+// SetDefaultBranch on AWS CodeCommit
+func (client *CodeCommitClient) SetDefaultBranch(ctx context.Context, _, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return err
+	}
+	_, err := client.codeCommitClient.UpdateDefaultBranch(ctx, &codecommit.UpdateDefaultBranchInput{RepositoryName: &repository, DefaultBranchName: &branch})
+	return err
+}
+
+// GetBranchProtection on AWS CodeCommit
+func (client *CodeCommitClient) GetBranchProtection(_ context.Context, _, _, _ string) (BranchProtectionInfo, error) {
+	return BranchProtectionInfo{}, errCodeCommitBranchProtectionNotSupported
+}
+
+// SetBranchProtection on AWS CodeCommit
+func (client *CodeCommitClient) SetBranchProtection(_ context.Context, _, _, _ string, _ BranchProtectionInfo) error {
+	return errCodeCommitBranchProtectionNotSupported
+}
+
+// ListTags on AWS CodeCommit
+func (client *CodeCommitClient) ListTags(_ context.Context, _, _ string) ([]TagInfo, error) {
+	return nil, errCodeCommitTagsNotSupported
+}
+
+// CreateTag on AWS CodeCommit
+func (client *CodeCommitClient) CreateTag(_ context.Context, _, _, _, _, _ string) error {
+	return errCodeCommitTagsNotSupported
+}
+
+// GetTagInfo on AWS CodeCommit
+func (client *CodeCommitClient) GetTagInfo(_ context.Context, _, _, _ string) (CommitInfo, error) {
+	return CommitInfo{}, errCodeCommitTagsNotSupported
+}
+
+// CreateRelease on AWS CodeCommit
+func (client *CodeCommitClient) CreateRelease(_ context.Context, _, _, _, _, _ string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errCodeCommitReleasesNotSupported
+}
+
+// ListReleases on AWS CodeCommit
+func (client *CodeCommitClient) ListReleases(_ context.Context, _, _ string) ([]ReleaseInfo, error) {
+	return nil, errCodeCommitReleasesNotSupported
+}
+
+// GetLatestRelease on AWS CodeCommit
+func (client *CodeCommitClient) GetLatestRelease(_ context.Context, _, _ string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errCodeCommitReleasesNotSupported
+}
+
+// UploadReleaseAsset on AWS CodeCommit
+func (client *CodeCommitClient) UploadReleaseAsset(_ context.Context, _, _, _, _ string, _ io.Reader) (string, error) {
+	return "", errCodeCommitReleasesNotSupported
+}
+
+// AddSshKeyToRepository on AWS CodeCommit
+func (client *CodeCommitClient) AddSshKeyToRepository(_ context.Context, _, _, _, _ string, _ Permission) error {
+	return errCodeCommitSshKeysNotSupported
+}
+
+// CreateWebhook on AWS CodeCommit
+func (client *CodeCommitClient) CreateWebhook(_ context.Context, _, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", errCodeCommitWebhooksNotSupported
+}
+
+// CreateOrUpdateWebhook on AWS CodeCommit
+func (client *CodeCommitClient) CreateOrUpdateWebhook(_ context.Context, _, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", errCodeCommitWebhooksNotSupported
+}
+
+// UpdateWebhook on AWS CodeCommit
+func (client *CodeCommitClient) UpdateWebhook(_ context.Context, _, _, _, _, _, _ string, _ ...vcsutils.WebhookEvent) error {
+	return errCodeCommitWebhooksNotSupported
+}
+
+// DeleteWebhook on AWS CodeCommit
+func (client *CodeCommitClient) DeleteWebhook(_ context.Context, _, _, _ string) error {
+	return errCodeCommitWebhooksNotSupported
+}
+
+// ListWebhooks on AWS CodeCommit
+func (client *CodeCommitClient) ListWebhooks(_ context.Context, _, _ string) ([]WebhookInfo, error) {
+	return nil, errCodeCommitWebhooksNotSupported
+}
+
+// GetWebhook on AWS CodeCommit
+func (client *CodeCommitClient) GetWebhook(_ context.Context, _, _, _ string) (WebhookInfo, error) {
+	return WebhookInfo{}, errCodeCommitWebhooksNotSupported
+}
+
+// RotateWebhookSecret on AWS CodeCommit
+func (client *CodeCommitClient) RotateWebhookSecret(_ context.Context, _, _, _ string) (string, error) {
+	return "", errCodeCommitWebhooksNotSupported
+}
+
+// SetCommitStatus on AWS CodeCommit
+func (client *CodeCommitClient) SetCommitStatus(_ context.Context, _ CommitStatus, _, _, _, _, _, _ string) error {
+	return errCodeCommitCommitStatusesNotSupported
+}
+
+// GetCommitStatuses on AWS CodeCommit
+func (client *CodeCommitClient) GetCommitStatuses(_ context.Context, _, _, _ string) ([]CommitStatusInfo, error) {
+	return nil, errCodeCommitCommitStatusesNotSupported
+}
+
+// GetCombinedCommitStatus on AWS CodeCommit
+func (client *CodeCommitClient) GetCombinedCommitStatus(_ context.Context, _, _, _ string) (CommitStatus, error) {
+	return 0, errCodeCommitCommitStatusesNotSupported
+}
+
+// TriggerPipeline on AWS CodeCommit
+func (client *CodeCommitClient) TriggerPipeline(_ context.Context, _, _, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errCodeCommitPipelinesNotSupported
+}
+
+// GetLatestPipelineStatus on AWS CodeCommit
+func (client *CodeCommitClient) GetLatestPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errCodeCommitPipelinesNotSupported
+}
+
+// GetPipelineStatus on AWS CodeCommit
+func (client *CodeCommitClient) GetPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errCodeCommitPipelinesNotSupported
+}
+
+// TriggerWorkflow on AWS CodeCommit
+func (client *CodeCommitClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return errCodeCommitPipelinesNotSupported
+}
+
+// GetWorkflowRunStatus on AWS CodeCommit
+func (client *CodeCommitClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errCodeCommitPipelinesNotSupported
+}
+
+// QueueBuild on AWS CodeCommit
+func (client *CodeCommitClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errCodeCommitPipelinesNotSupported
+}
+
+// GetBuildStatus on AWS CodeCommit
+func (client *CodeCommitClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errCodeCommitPipelinesNotSupported
+}
+
+// DownloadRepository on AWS CodeCommit
+func (client *CodeCommitClient) DownloadRepository(_ context.Context, _, _, _, _ string) error {
+	return errCodeCommitDownloadRepositoryNotSupported
+}
+
+// DownloadRepositoryPaths on AWS CodeCommit
+func (client *CodeCommitClient) DownloadRepositoryPaths(_ context.Context, _, _, _ string, _ []string, _ string) error {
+	return errCodeCommitDownloadRepositoryNotSupported
+}
+
+func (client *CodeCommitClient) GetPullRequestCommentSizeLimit() int {
+	return codeCommitPrContentSizeLimit
+}
+
+func (client *CodeCommitClient) GetPullRequestDetailsSizeLimit() int {
+	return codeCommitPrContentSizeLimit
+}
+
+// CreatePullRequest on AWS CodeCommit
+func (client *CodeCommitClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) (PullRequestInfo, error) {
+	return client.CreatePullRequestWithOptions(ctx, owner, repository, sourceBranch, targetBranch, title, description, CreatePullRequestOptions{})
+}
+
+// CreatePullRequestWithOptions on AWS CodeCommit
+func (client *CodeCommitClient) CreatePullRequestWithOptions(ctx context.Context, _, repository, sourceBranch, targetBranch,
+	title, description string, options CreatePullRequestOptions) (PullRequestInfo, error) {
+	if err := validateCreatePullRequestOptions(options, notSupportedOnCodeCommit, draftPullRequestOption, reviewersPullRequestOption, labelsPullRequestOption,
+		assigneesPullRequestOption, milestonePullRequestOption, deleteSourceBranchPullRequestOption, workItemsPullRequestOption); err != nil {
+		return PullRequestInfo{}, err
+	}
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "sourceBranch": sourceBranch, "targetBranch": targetBranch, "title": title}); err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug(vcsutils.CreatingPullRequest, title)
+	output, err := client.codeCommitClient.CreatePullRequest(ctx, &codecommit.CreatePullRequestInput{
+		Title:       &title,
+		Description: vcsutils.GetNilIfZeroVal(description),
+		Targets: []types.Target{{
+			RepositoryName:       &repository,
+			SourceReference:      &sourceBranch,
+			DestinationReference: &targetBranch,
+		}},
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return client.mapCodeCommitPullRequestToPullRequestInfo(output.PullRequest, true), nil
+}
+
+// UpdatePullRequest on AWS CodeCommit.
+// The target branch of a pull request can't be changed, and merged pull requests can't be reopened.
+func (client *CodeCommitClient) UpdatePullRequest(ctx context.Context, _, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if targetBranchName != "" {
+		return errCodeCommitChangeTargetBranchNotSupported
+	}
+	pullRequestID := strconv.Itoa(prId)
+	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
+	if title != "" {
+		if _, err := client.codeCommitClient.UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{PullRequestId: &pullRequestID, Title: &title}); err != nil {
+			return err
+		}
+	}
+	if body != "" {
+		if _, err := client.codeCommitClient.UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{PullRequestId: &pullRequestID, Description: &body}); err != nil {
+			return err
+		}
+	}
+	if status := mapCodeCommitPullRequestState(state); status != "" {
+		_, err := client.codeCommitClient.UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{PullRequestId: &pullRequestID, PullRequestStatus: status})
+		return err
+	}
+	return nil
+}
+
+// MergePullRequest on AWS CodeCommit.
+// Pull requests are merged by a three-way merge, or by a squash merge.
+// The merge fails if the source branch was updated after the pull request was fetched.
+func (client *CodeCommitClient) MergePullRequest(ctx context.Context, _, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if mergeMethod != vcsutils.MergeMethodMerge && mergeMethod != vcsutils.MergeMethodSquash {
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	pullRequest, err := client.getPullRequest(ctx, prId)
+	if err != nil {
+		return err
+	}
+	target := getCodeCommitPullRequestTarget(pullRequest)
+	pullRequestID := strconv.Itoa(prId)
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	if mergeMethod == vcsutils.MergeMethodSquash {
+		_, err = client.codeCommitClient.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  &pullRequestID,
+			RepositoryName: &repository,
+			SourceCommitId: target.SourceCommit,
+		})
+		return err
+	}
+	_, err = client.codeCommitClient.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+		PullRequestId:  &pullRequestID,
+		RepositoryName: &repository,
+		SourceCommitId: target.SourceCommit,
+	})
+	return err
+}
+
+// SetPullRequestAutoMerge on AWS CodeCommit
+func (client *CodeCommitClient) SetPullRequestAutoMerge(_ context.Context, _, _ string, _ int, _ vcsutils.MergeMethod) error {
+	return errCodeCommitAutoMergeNotSupported
+}
+
+// ListOpenPullRequestsWithBody on AWS CodeCommit
+func (client *CodeCommitClient) ListOpenPullRequestsWithBody(ctx context.Context, _, repository string) ([]PullRequestInfo, error) {
+	return client.listAllPullRequests(ctx, repository, types.PullRequestStatusEnumOpen, true)
+}
+
+// ListOpenPullRequests on AWS CodeCommit
+func (client *CodeCommitClient) ListOpenPullRequests(ctx context.Context, _, repository string) ([]PullRequestInfo, error) {
+	return client.listAllPullRequests(ctx, repository, types.PullRequestStatusEnumOpen, false)
+}
+
+// ListOpenPullRequestsWithOptions on AWS CodeCommit.
+// The API lists the pull requests by continuation tokens, hence the pages before the requested page are listed as well.
+func (client *CodeCommitClient) ListOpenPullRequestsWithOptions(ctx context.Context, _, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	var pullRequestIDs []string
+	nextToken := (*string)(nil)
+	for currentPage := 1; currentPage <= page; currentPage++ {
+		output, err := client.codeCommitClient.ListPullRequests(ctx, &codecommit.ListPullRequestsInput{
+			RepositoryName:    &repository,
+			PullRequestStatus: types.PullRequestStatusEnumOpen,
+			MaxResults:        aws.Int32(int32(perPage)),
+			NextToken:         nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if currentPage == page {
+			pullRequestIDs = output.PullRequestIds
+		}
+		if nextToken = output.NextToken; nextToken == nil {
+			break
+		}
+	}
+
+	// The API can't filter the pull requests, hence the pull requests of the page are filtered here
+	var results []PullRequestInfo
+	for _, pullRequestID := range pullRequestIDs {
+		pullRequestInfo, err := client.getPullRequestInfo(ctx, pullRequestID, options.WithBody)
+		if err != nil {
+			return nil, err
+		}
+		if options.matches(pullRequestInfo) {
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
+// ListPullRequestsWithState on AWS CodeCommit.
+// Merged pull requests are closed on AWS CodeCommit, hence the closed pull requests are filtered by their merge status.
+func (client *CodeCommitClient) ListPullRequestsWithState(ctx context.Context, _, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	if err := validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+	status := types.PullRequestStatusEnumClosed
+	if state == vcsutils.Open {
+		status = types.PullRequestStatusEnumOpen
+	}
+	pullRequests, err := client.listAllPullRequests(ctx, repository, status, true)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestInfo
+	for _, pullRequest := range pullRequests {
+		if pullRequest.State == state {
+			results = append(results, pullRequest)
+		}
+	}
+	return results, nil
+}
+
+// listAllPullRequests lists the IDs of the pull requests, and then fetches each of the pull requests
+func (client *CodeCommitClient) listAllPullRequests(ctx context.Context, repository string, status types.PullRequestStatusEnum, withBody bool) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	var results []PullRequestInfo
+	for nextToken := (*string)(nil); ; {
+		output, err := client.codeCommitClient.ListPullRequests(ctx, &codecommit.ListPullRequestsInput{
+			RepositoryName:    &repository,
+			PullRequestStatus: status,
+			MaxResults:        aws.Int32(codeCommitPageSize),
+			NextToken:         nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequestID := range output.PullRequestIds {
+			pullRequestInfo, err := client.getPullRequestInfo(ctx, pullRequestID, withBody)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, pullRequestInfo)
+		}
+		if nextToken = output.NextToken; nextToken == nil {
+			return results, nil
+		}
+	}
+}
+
+// GetPullRequestByID on AWS CodeCommit
+func (client *CodeCommitClient) GetPullRequestByID(ctx context.Context, _, repository string, pullRequestId int) (PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
+	return client.getPullRequestInfo(ctx, strconv.Itoa(pullRequestId), true)
+}
+
+func (client *CodeCommitClient) getPullRequestInfo(ctx context.Context, pullRequestID string, withBody bool) (PullRequestInfo, error) {
+	output, err := client.codeCommitClient.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: &pullRequestID})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return client.mapCodeCommitPullRequestToPullRequestInfo(output.PullRequest, withBody), nil
+}
+
+func (client *CodeCommitClient) getPullRequest(ctx context.Context, pullRequestID int) (*types.PullRequest, error) {
+	output, err := client.codeCommitClient.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: aws.String(strconv.Itoa(pullRequestID))})
+	if err != nil {
+		return nil, err
+	}
+	return output.PullRequest, nil
+}
+
+// AddPullRequestComment on AWS CodeCommit
+func (client *CodeCommitClient) AddPullRequestComment(ctx context.Context, _, repository, content string, pullRequestID int) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "content": content}); err != nil {
+		return err
+	}
+	return client.addPullRequestComments(ctx, repository, pullRequestID, PullRequestComment{CommentInfo: CommentInfo{Content: content}})
+}
+
+// AddPullRequestReviewComments on AWS CodeCommit.
+// The comments are anchored to the lines of the source branch version of the files.
+func (client *CodeCommitClient) AddPullRequestReviewComments(ctx context.Context, _, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return errors.New(vcsutils.ErrNoCommentsProvided)
+	}
+	return client.addPullRequestComments(ctx, repository, pullRequestID, comments...)
+}
+
+// addPullRequestComments posts the comments on the difference between the target and the source commits of the pull request.
+// Comments without a file path are posted on the pull request itself.
+func (client *CodeCommitClient) addPullRequestComments(ctx context.Context, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	pullRequest, err := client.getPullRequest(ctx, pullRequestID)
+	if err != nil {
+		return err
+	}
+	target := getCodeCommitPullRequestTarget(pullRequest)
+	for _, comment := range comments {
+		input := &codecommit.PostCommentForPullRequestInput{
+			PullRequestId:  pullRequest.PullRequestId,
+			RepositoryName: &repository,
+			BeforeCommitId: target.DestinationCommit,
+			AfterCommitId:  target.SourceCommit,
+			Content:        &comment.Content,
+		}
+		if comment.NewFilePath != "" {
+			input.Location = &types.Location{
+				FilePath:            &comment.NewFilePath,
+				FilePosition:        aws.Int64(int64(comment.NewStartLine)),
+				RelativeFileVersion: types.RelativeFileVersionEnumAfter,
+			}
+		}
+		if _, err = client.codeCommitClient.PostCommentForPullRequest(ctx, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListPullRequestReviewComments on AWS CodeCommit.
+// The comment IDs are returned as thread IDs, since they aren't numeric.
+func (client *CodeCommitClient) ListPullRequestReviewComments(ctx context.Context, _, repository string, pullRequestID int) ([]CommentInfo, error) {
+	return client.listPullRequestComments(ctx, repository, pullRequestID, true)
+}
+
+// DeletePullRequestReviewComments on AWS CodeCommit.
+// The comments are identified by their thread IDs, and their content is deleted.
+func (client *CodeCommitClient) DeletePullRequestReviewComments(ctx context.Context, _, _ string, _ int, comments ...CommentInfo) error {
+	for _, comment := range comments {
+		if err := validateParametersNotBlank(map[string]string{"threadID": comment.ThreadID}); err != nil {
+			return err
+		}
+		if _, err := client.codeCommitClient.DeleteCommentContent(ctx, &codecommit.DeleteCommentContentInput{CommentId: &comment.ThreadID}); err != nil {
+			return fmt.Errorf("an error occurred while deleting pull request review comment:\n%s", err.Error())
+		}
+	}
+	return nil
+}
+
+// ListPullRequestComments on AWS CodeCommit.
+// The comment IDs are returned as thread IDs, since they aren't numeric.
+func (client *CodeCommitClient) ListPullRequestComments(ctx context.Context, _, repository string, pullRequestID int) ([]CommentInfo, error) {
+	return client.listPullRequestComments(ctx, repository, pullRequestID, false)
+}
+
+// ListPullRequestCommentsWithOptions on AWS CodeCommit.
+// The comments are paginated by continuation tokens, together with the review comments, hence the page is taken from all the matching comments.
+func (client *CodeCommitClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return getPullRequestCommentsPage(comments, options), nil
+}
+
+// listPullRequestComments lists either the comments anchored to files, or the comments on the pull request itself
+func (client *CodeCommitClient) listPullRequestComments(ctx context.Context, repository string, pullRequestID int, reviewComments bool) ([]CommentInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	var results []CommentInfo
+	for nextToken := (*string)(nil); ; {
+		output, err := client.codeCommitClient.GetCommentsForPullRequest(ctx, &codecommit.GetCommentsForPullRequestInput{
+			PullRequestId:  aws.String(strconv.Itoa(pullRequestID)),
+			RepositoryName: &repository,
+			MaxResults:     aws.Int32(codeCommitPageSize),
+			NextToken:      nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, data := range output.CommentsForPullRequestData {
+			if (data.Location != nil) != reviewComments {
+				continue
+			}
+			for _, comment := range data.Comments {
+				if comment.Deleted {
+					continue
+				}
+				results = append(results, mapCodeCommitCommentToCommentInfo(comment, data.Location))
+			}
+		}
+		if nextToken = output.NextToken; nextToken == nil {
+			return results, nil
+		}
+	}
+}
+
+// UpdatePullRequestComment on AWS CodeCommit
+func (client *CodeCommitClient) UpdatePullRequestComment(_ context.Context, _, _, _ string, _, _ int) error {
+	return errCodeCommitNumericCommentIDsNotSupported
+}
+
+// DeletePullRequestComment on AWS CodeCommit
+func (client *CodeCommitClient) DeletePullRequestComment(_ context.Context, _, _ string, _, _ int) error {
+	return errCodeCommitNumericCommentIDsNotSupported
+}
+
+// SetPullRequestThreadStatus on AWS CodeCommit
+func (client *CodeCommitClient) SetPullRequestThreadStatus(_ context.Context, _, _ string, _ int, _ string, _ bool) error {
+	return errCodeCommitThreadStatusNotSupported
+}
+
+// CreatePullRequestReview on AWS CodeCommit.
+// Approving a pull request sets the approval state of the caller on its current revision, and the body is added as a comment.
+func (client *CodeCommitClient) CreatePullRequestReview(ctx context.Context, _, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if err := validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	switch verdict {
+	case vcsutils.ReviewVerdictRequestChanges:
+		return errCodeCommitRequestChangesNotSupported
+	case vcsutils.ReviewVerdictApprove:
+		pullRequest, err := client.getPullRequest(ctx, pullRequestID)
+		if err != nil {
+			return err
+		}
+		if _, err = client.codeCommitClient.UpdatePullRequestApprovalState(ctx, &codecommit.UpdatePullRequestApprovalStateInput{
+			PullRequestId: pullRequest.PullRequestId,
+			RevisionId:    pullRequest.RevisionId,
+			ApprovalState: types.ApprovalStateApprove,
+		}); err != nil {
+			return err
+		}
+	}
+	if body == "" {
+		return nil
+	}
+	return client.AddPullRequestComment(ctx, "", repository, body, pullRequestID)
+}
+
+// ListPullRequestReviews on AWS CodeCommit.
+// Only the approvals of the current revision of the pull request are returned, identified by the name of the approving IAM identity.
+func (client *CodeCommitClient) ListPullRequestReviews(ctx context.Context, _, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	pullRequest, err := client.getPullRequest(ctx, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.codeCommitClient.GetPullRequestApprovalStates(ctx, &codecommit.GetPullRequestApprovalStatesInput{
+		PullRequestId: pullRequest.PullRequestId,
+		RevisionId:    pullRequest.RevisionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestReviewInfo
+	for _, approval := range output.Approvals {
+		if approval.ApprovalState != types.ApprovalStateApprove {
+			continue
+		}
+		results = append(results, PullRequestReviewInfo{
+			Reviewer: getCodeCommitArnIdentityName(aws.ToString(approval.UserArn)),
+			State:    vcsutils.ReviewVerdictApprove,
+		})
+	}
+	return results, nil
+}
+
+// GetLatestCommit on AWS CodeCommit
+func (client *CodeCommitClient) GetLatestCommit(ctx context.Context, _, repository, branch string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return CommitInfo{}, err
+	}
+	output, err := client.codeCommitClient.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: &repository, BranchName: &branch})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return client.GetCommitBySha(ctx, "", repository, aws.ToString(output.Branch.CommitId))
+}
+
+// GetCommits on AWS CodeCommit
+func (client *CodeCommitClient) GetCommits(_ context.Context, _, _, _ string) ([]CommitInfo, error) {
+	return nil, errCodeCommitCommitsHistoryNotSupported
+}
+
+// ListCommits on AWS CodeCommit
+func (client *CodeCommitClient) ListCommits(_ context.Context, _, _ string, _ ListCommitsOptions) ([]CommitInfo, error) {
+	return nil, errCodeCommitCommitsHistoryNotSupported
+}
+
+// GetCommitBySha on AWS CodeCommit
+func (client *CodeCommitClient) GetCommitBySha(ctx context.Context, _, repository, sha string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "sha": sha}); err != nil {
+		return CommitInfo{}, err
+	}
+	output, err := client.codeCommitClient.GetCommit(ctx, &codecommit.GetCommitInput{RepositoryName: &repository, CommitId: &sha})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return client.mapCodeCommitCommitToCommitInfo(repository, output.Commit), nil
+}
+
+// GetRepositoryInfo on AWS CodeCommit.
+// AWS CodeCommit repositories are always private, and their access is granted by IAM policies.
+func (client *CodeCommitClient) GetRepositoryInfo(ctx context.Context, _, repository string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	output, err := client.codeCommitClient.GetRepository(ctx, &codecommit.GetRepositoryInput{RepositoryName: &repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapCodeCommitRepositoryToRepositoryInfo(output.RepositoryMetadata), nil
+}
+
+// CreateRepository on AWS CodeCommit.
+// The repository is created empty, and its default branch is the first branch pushed to it, hence the visibility and the default branch are ignored.
+func (client *CodeCommitClient) CreateRepository(ctx context.Context, _, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	if options.InitReadme {
+		return RepositoryInfo{}, errCodeCommitInitReadmeNotSupported
+	}
+	output, err := client.codeCommitClient.CreateRepository(ctx, &codecommit.CreateRepositoryInput{
+		RepositoryName:        &repository,
+		RepositoryDescription: vcsutils.GetNilIfZeroVal(options.Description),
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapCodeCommitRepositoryToRepositoryInfo(output.RepositoryMetadata), nil
+}
+
+// DeleteRepository on AWS CodeCommit
+func (client *CodeCommitClient) DeleteRepository(ctx context.Context, _, repository string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	_, err := client.codeCommitClient.DeleteRepository(ctx, &codecommit.DeleteRepositoryInput{RepositoryName: &repository})
+	return err
+}
+
+// ForkRepository on AWS CodeCommit
+func (client *CodeCommitClient) ForkRepository(_ context.Context, _, _, _ string) (RepositoryInfo, error) {
+	return RepositoryInfo{}, errCodeCommitForkNotSupported
+}
+
+// ListRepositoryCollaborators on AWS CodeCommit
+func (client *CodeCommitClient) ListRepositoryCollaborators(_ context.Context, _, _ string) ([]CollaboratorInfo, error) {
+	return nil, errCodeCommitCollaboratorsNotSupported
+}
+
+// GetRepositoryPermission on AWS CodeCommit
+func (client *CodeCommitClient) GetRepositoryPermission(_ context.Context, _, _, _ string) (RepositoryPermission, error) {
+	return NoPermission, errCodeCommitCollaboratorsNotSupported
+}
+
+// CreateLabel on AWS CodeCommit
+func (client *CodeCommitClient) CreateLabel(_ context.Context, _, _ string, _ LabelInfo) error {
+	return errCodeCommitLabelsNotSupported
+}
+
+// GetLabel on AWS CodeCommit
+func (client *CodeCommitClient) GetLabel(_ context.Context, _, _, _ string) (*LabelInfo, error) {
+	return nil, errCodeCommitLabelsNotSupported
+}
+
+// ListRepositoryLabels on AWS CodeCommit
+func (client *CodeCommitClient) ListRepositoryLabels(_ context.Context, _, _ string) ([]LabelInfo, error) {
+	return nil, errCodeCommitLabelsNotSupported
+}
+
+// UpdateLabel on AWS CodeCommit
+func (client *CodeCommitClient) UpdateLabel(_ context.Context, _, _, _ string, _ LabelInfo) error {
+	return errCodeCommitLabelsNotSupported
+}
+
+// DeleteLabel on AWS CodeCommit
+func (client *CodeCommitClient) DeleteLabel(_ context.Context, _, _, _ string) error {
+	return errCodeCommitLabelsNotSupported
+}
+
+// ListPullRequestLabels on AWS CodeCommit
+func (client *CodeCommitClient) ListPullRequestLabels(_ context.Context, _, _ string, _ int) ([]string, error) {
+	return nil, errCodeCommitLabelsNotSupported
+}
+
+// LabelPullRequest on AWS CodeCommit
+func (client *CodeCommitClient) LabelPullRequest(_ context.Context, _, _ string, _ int, _ []string) error {
+	return errCodeCommitLabelsNotSupported
+}
+
+// UnlabelPullRequest on AWS CodeCommit
+func (client *CodeCommitClient) UnlabelPullRequest(_ context.Context, _, _, _ string, _ int) error {
+	return errCodeCommitLabelsNotSupported
+}
+
+// CreateIssue on AWS CodeCommit
+func (client *CodeCommitClient) CreateIssue(_ context.Context, _, _, _, _ string) (IssueInfo, error) {
+	return IssueInfo{}, errCodeCommitIssuesNotSupported
+}
+
+// ListIssues on AWS CodeCommit
+func (client *CodeCommitClient) ListIssues(_ context.Context, _, _ string, _ ListIssuesOptions) ([]IssueInfo, error) {
+	return nil, errCodeCommitIssuesNotSupported
+}
+
+// AddIssueComment on AWS CodeCommit
+func (client *CodeCommitClient) AddIssueComment(_ context.Context, _, _, _ string, _ int) error {
+	return errCodeCommitIssuesNotSupported
+}
+
+// CloseIssue on AWS CodeCommit
+func (client *CodeCommitClient) CloseIssue(_ context.Context, _, _ string, _ int) error {
+	return errCodeCommitIssuesNotSupported
+}
+
+// UploadCodeScanning on AWS CodeCommit
+func (client *CodeCommitClient) UploadCodeScanning(_ context.Context, _, _, _, _ string) (string, error) {
+	return "", errCodeCommitCodeScanningNotSupported
+}
+
+// GetRepositoryEnvironmentInfo on AWS CodeCommit
+func (client *CodeCommitClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errCodeCommitEnvironmentsNotSupported
+}
+
+// ListEnvironments on AWS CodeCommit
+func (client *CodeCommitClient) ListEnvironments(_ context.Context, _, _ string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errCodeCommitDeploymentsNotSupported
+}
+
+// CreateDeployment on AWS CodeCommit
+func (client *CodeCommitClient) CreateDeployment(_ context.Context, _, _, _, _, _ string) (DeploymentInfo, error) {
+	return DeploymentInfo{}, errCodeCommitDeploymentsNotSupported
+}
+
+// SetDeploymentStatus on AWS CodeCommit
+func (client *CodeCommitClient) SetDeploymentStatus(_ context.Context, _, _, _ string, _ DeploymentState, _, _ string) error {
+	return errCodeCommitDeploymentsNotSupported
+}
+
+// CreateOrUpdateRepositorySecret on AWS CodeCommit
+func (client *CodeCommitClient) CreateOrUpdateRepositorySecret(_ context.Context, _, _, _, _ string) error {
+	return errCodeCommitRepositorySecretsNotSupported
+}
+
+// ListRepositoryVariables on AWS CodeCommit
+func (client *CodeCommitClient) ListRepositoryVariables(_ context.Context, _, _ string) ([]RepositoryVariableInfo, error) {
+	return nil, errCodeCommitRepositorySecretsNotSupported
+}
+
+// CreateOrUpdateOrganizationSecret on AWS CodeCommit
+func (client *CodeCommitClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return errCodeCommitSecretsNotSupported
+}
+
+// ListOrganizationSecrets on AWS CodeCommit
+func (client *CodeCommitClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, errCodeCommitSecretsNotSupported
+}
+
+// ListOrganizationSecretRepositories on AWS CodeCommit
+func (client *CodeCommitClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, errCodeCommitSecretsNotSupported
+}
+
+// DeleteOrganizationSecret on AWS CodeCommit
+func (client *CodeCommitClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return errCodeCommitSecretsNotSupported
+}
+
+// CreateOrUpdateEnvironmentSecret on AWS CodeCommit
+func (client *CodeCommitClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return errCodeCommitSecretsNotSupported
+}
+
+// ListEnvironmentSecrets on AWS CodeCommit
+func (client *CodeCommitClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, errCodeCommitSecretsNotSupported
+}
+
+// DeleteEnvironmentSecret on AWS CodeCommit
+func (client *CodeCommitClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return errCodeCommitSecretsNotSupported
+}
+
+// CreateCodeInsightsReport on AWS CodeCommit
+func (client *CodeCommitClient) CreateCodeInsightsReport(_ context.Context, _, _, _ string, _ CodeInsightsReport) error {
+	return errCodeCommitCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on AWS CodeCommit
+func (client *CodeCommitClient) AddCodeInsightsAnnotations(_ context.Context, _, _, _, _ string, _ []CodeInsightsAnnotation) error {
+	return errCodeCommitCodeInsightsNotSupported
+}
+
+// CreateCheckRun on AWS CodeCommit
+func (client *CodeCommitClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errCodeCommitCheckRunsNotSupported
+}
+
+// UpdateCheckRun on AWS CodeCommit
+func (client *CodeCommitClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errCodeCommitCheckRunsNotSupported
+}
+
+// DownloadFileFromRepo on AWS CodeCommit
+func (client *CodeCommitClient) DownloadFileFromRepo(ctx context.Context, _, repository, branch, path string) ([]byte, int, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "path": path}); err != nil {
+		return nil, 0, err
+	}
+	output, err := client.codeCommitClient.GetFile(ctx, &codecommit.GetFileInput{
+		RepositoryName:  &repository,
+		CommitSpecifier: vcsutils.GetNilIfZeroVal(branch),
+		FilePath:        &path,
+	})
+	if err != nil {
+		var fileNotFound *types.FileDoesNotExistException
+		if errors.As(err, &fileNotFound) {
+			return nil, http.StatusNotFound, err
+		}
+		return nil, 0, err
+	}
+	return output.FileContent, http.StatusOK, nil
+}
+
+// ListDirectoryContents on AWS CodeCommit.
+// Submodules are listed as files.
+func (client *CodeCommitClient) ListDirectoryContents(ctx context.Context, _, repository, ref, path string) ([]DirectoryEntry, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref}); err != nil {
+		return nil, err
+	}
+	if path == "" {
+		path = "/"
+	}
+	output, err := client.codeCommitClient.GetFolder(ctx, &codecommit.GetFolderInput{RepositoryName: &repository, CommitSpecifier: &ref, FolderPath: &path})
+	if err != nil {
+		return nil, err
+	}
+	var entries []DirectoryEntry
+	for _, folder := range output.SubFolders {
+		entries = append(entries, DirectoryEntry{Name: aws.ToString(folder.RelativePath), Path: aws.ToString(folder.AbsolutePath), IsDir: true})
+	}
+	for _, file := range output.Files {
+		entries = append(entries, DirectoryEntry{Name: aws.ToString(file.RelativePath), Path: aws.ToString(file.AbsolutePath)})
+	}
+	for _, symbolicLink := range output.SymbolicLinks {
+		entries = append(entries, DirectoryEntry{Name: aws.ToString(symbolicLink.RelativePath), Path: aws.ToString(symbolicLink.AbsolutePath)})
+	}
+	for _, subModule := range output.SubModules {
+		entries = append(entries, DirectoryEntry{Name: aws.ToString(subModule.RelativePath), Path: aws.ToString(subModule.AbsolutePath)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// CommitFiles on AWS CodeCommit.
+// The files are committed on top of the last commit of the branch.
+func (client *CodeCommitClient) CommitFiles(ctx context.Context, _, repository, branch, commitMessage string, files []FileToCommit) error {
+	err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch, "commitMessage": commitMessage})
+	if err != nil {
+		return err
+	}
+	if err = validateFilesToCommit(files); err != nil {
+		return err
+	}
+	output, err := client.codeCommitClient.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: &repository, BranchName: &branch})
+	if err != nil {
+		return err
+	}
+
+	input := &codecommit.CreateCommitInput{
+		RepositoryName: &repository,
+		BranchName:     &branch,
+		ParentCommitId: output.Branch.CommitId,
+		CommitMessage:  &commitMessage,
+	}
+	for _, file := range files {
+		if file.ChangeType == FileDeleted {
+			input.DeleteFiles = append(input.DeleteFiles, types.DeleteFileEntry{FilePath: aws.String(file.Path)})
+			continue
+		}
+		input.PutFiles = append(input.PutFiles, types.PutFileEntry{FilePath: aws.String(file.Path), FileContent: file.Content})
+	}
+	_, err = client.codeCommitClient.CreateCommit(ctx, input)
+	return err
+}
+
+// GetModifiedFiles on AWS CodeCommit.
+// The files are compared between the two references, rather than between their common ancestor and the latter reference.
+func (client *CodeCommitClient) GetModifiedFiles(ctx context.Context, _, repository, refBefore, refAfter string) ([]string, error) {
+	if err := validateParametersNotBlank(map[string]string{
+		"repository": repository,
+		"refBefore":  refBefore,
+		"refAfter":   refAfter,
+	}); err != nil {
+		return nil, err
+	}
+	files, err := client.getDifferences(ctx, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	fileNamesSet := datastructures.MakeSet[string]()
+	for _, file := range files {
+		fileNamesSet.Add(file.Path)
+		fileNamesSet.Add(file.PreviousPath)
+	}
+	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
+	fileNamesList := fileNamesSet.ToSlice()
+	sort.Strings(fileNamesList)
+	return fileNamesList, nil
+}
+
+// CompareCommits on AWS CodeCommit
+func (client *CodeCommitClient) CompareCommits(_ context.Context, _, _, _, _ string) (CommitsComparison, error) {
+	return CommitsComparison{}, errCodeCommitCompareCommitsNotSupported
+}
+
+// ListPullRequestFiles on AWS CodeCommit.
+// The files are compared between the merge base of the pull request and its source commit.
+func (client *CodeCommitClient) ListPullRequestFiles(ctx context.Context, _, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	pullRequest, err := client.getPullRequest(ctx, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	target := getCodeCommitPullRequestTarget(pullRequest)
+	baseCommit := aws.ToString(target.MergeBase)
+	if baseCommit == "" {
+		baseCommit = aws.ToString(target.DestinationCommit)
+	}
+	return client.getDifferences(ctx, repository, baseCommit, aws.ToString(target.SourceCommit))
+}
+
+func (client *CodeCommitClient) getDifferences(ctx context.Context, repository, before, after string) ([]PullRequestFile, error) {
+	var files []PullRequestFile
+	for nextToken := (*string)(nil); ; {
+		output, err := client.codeCommitClient.GetDifferences(ctx, &codecommit.GetDifferencesInput{
+			RepositoryName:        &repository,
+			BeforeCommitSpecifier: &before,
+			AfterCommitSpecifier:  &after,
+			NextToken:             nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, difference := range output.Differences {
+			files = append(files, mapCodeCommitDifferenceToPullRequestFile(difference))
+		}
+		if nextToken = output.NextToken; nextToken == nil {
+			return files, nil
+		}
+	}
+}
+
+// GetPullRequestDiff on AWS CodeCommit
+func (client *CodeCommitClient) GetPullRequestDiff(_ context.Context, _, _ string, _ int) (string, error) {
+	return "", errCodeCommitPullRequestDiffNotSupported
+}
+
+// getConsoleURL returns the URL of a repository page in the AWS console
+func (client *CodeCommitClient) getConsoleURL(repository, page string) string {
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/%s?region=%s",
+		client.vcsInfo.AWSRegion, neturl.PathEscape(repository), page, client.vcsInfo.AWSRegion)
+}
+
+// getCodeCommitArnIdentityName returns the name of the IAM identity of an ARN, such as arn:aws:iam::123456789012:user/frogger
+func getCodeCommitArnIdentityName(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// getCodeCommitPullRequestTarget returns the target of the pull request.
+// Pull requests have a single target, since they are created by CreatePullRequest.
+func getCodeCommitPullRequestTarget(pullRequest *types.PullRequest) types.PullRequestTarget {
+	if pullRequest == nil || len(pullRequest.PullRequestTargets) == 0 {
+		return types.PullRequestTarget{}
+	}
+	return pullRequest.PullRequestTargets[0]
+}
+
+func mapCodeCommitPullRequestState(state vcsutils.PullRequestState) types.PullRequestStatusEnum {
+	switch state {
+	case vcsutils.Open:
+		return types.PullRequestStatusEnumOpen
+	case vcsutils.Closed:
+		return types.PullRequestStatusEnumClosed
+	default:
+		return ""
+	}
+}
+
+func (client *CodeCommitClient) mapCodeCommitPullRequestToPullRequestInfo(pullRequest *types.PullRequest, withBody bool) PullRequestInfo {
+	if pullRequest == nil {
+		return PullRequestInfo{}
+	}
+	target := getCodeCommitPullRequestTarget(pullRequest)
+	pullRequestID, _ := strconv.ParseInt(aws.ToString(pullRequest.PullRequestId), 10, 64)
+	repository := aws.ToString(target.RepositoryName)
+	pullRequestInfo := PullRequestInfo{
+		ID:        pullRequestID,
+		Title:     aws.ToString(pullRequest.Title),
+		URL:       client.getConsoleURL(repository, "pull-requests/"+aws.ToString(pullRequest.PullRequestId)+"/details"),
+		Author:    getCodeCommitArnIdentityName(aws.ToString(pullRequest.AuthorArn)),
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: strings.TrimPrefix(aws.ToString(target.SourceReference), "refs/heads/"), Repository: repository},
+		Target:    BranchInfo{Name: strings.TrimPrefix(aws.ToString(target.DestinationReference), "refs/heads/"), Repository: repository},
+		CreatedAt: aws.ToTime(pullRequest.CreationDate),
+		UpdatedAt: aws.ToTime(pullRequest.LastActivityDate),
+	}
+	if withBody {
+		pullRequestInfo.Body = aws.ToString(pullRequest.Description)
+	}
+	switch {
+	case target.MergeMetadata != nil && target.MergeMetadata.IsMerged:
+		pullRequestInfo.State = vcsutils.Merged
+	case pullRequest.PullRequestStatus == types.PullRequestStatusEnumClosed:
+		pullRequestInfo.State = vcsutils.Closed
+	}
+	return pullRequestInfo
+}
+
+func mapCodeCommitCommentToCommentInfo(comment types.Comment, location *types.Location) CommentInfo {
+	author := getCodeCommitArnIdentityName(aws.ToString(comment.AuthorArn))
+	commentInfo := CommentInfo{
+		NativeID:       aws.ToString(comment.CommentId),
+		ThreadID:       aws.ToString(comment.CommentId),
+		ParentID:       aws.ToString(comment.InReplyTo),
+		Content:        aws.ToString(comment.Content),
+		Author:         author,
+		AuthorUsername: author,
+		Created:        aws.ToTime(comment.CreationDate),
+		Updated:        aws.ToTime(comment.LastModifiedDate),
+	}
+	if location != nil {
+		commentInfo.FilePath = aws.ToString(location.FilePath)
+		commentInfo.Line = int(aws.ToInt64(location.FilePosition))
+	}
+	return commentInfo
+}
+
+func (client *CodeCommitClient) mapCodeCommitCommitToCommitInfo(repository string, commit *types.Commit) CommitInfo {
+	if commit == nil {
+		return CommitInfo{}
+	}
+	commitInfo := CommitInfo{
+		Hash:         aws.ToString(commit.CommitId),
+		Url:          client.getConsoleURL(repository, "commit/"+aws.ToString(commit.CommitId)),
+		Message:      aws.ToString(commit.Message),
+		ParentHashes: commit.Parents,
+	}
+	if commit.Author != nil {
+		commitInfo.AuthorName = aws.ToString(commit.Author.Name)
+		commitInfo.AuthorEmail = aws.ToString(commit.Author.Email)
+	}
+	if commit.Committer != nil {
+		commitInfo.CommitterName = aws.ToString(commit.Committer.Name)
+		commitInfo.Timestamp = parseCodeCommitDate(aws.ToString(commit.Committer.Date))
+	}
+	return commitInfo
+}
+
+// parseCodeCommitDate parses the dates of commits, which are the seconds from epoch followed by the time zone offset, such as "1484167798 -0800"
+func parseCodeCommitDate(date string) int64 {
+	seconds, _, _ := strings.Cut(date, " ")
+	timestamp, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return timestamp
+}
+
+func mapCodeCommitRepositoryToRepositoryInfo(repository *types.RepositoryMetadata) RepositoryInfo {
+	if repository == nil {
+		return RepositoryInfo{}
+	}
+	return RepositoryInfo{
+		RepositoryVisibility: Private,
+		CloneInfo:            CloneInfo{HTTP: aws.ToString(repository.CloneUrlHttp), SSH: aws.ToString(repository.CloneUrlSsh)},
+		DefaultBranch:        aws.ToString(repository.DefaultBranch),
+		Description:          aws.ToString(repository.RepositoryDescription),
+	}
+}
+
+func mapCodeCommitDifferenceToPullRequestFile(difference types.Difference) PullRequestFile {
+	var beforePath, afterPath string
+	if difference.BeforeBlob != nil {
+		beforePath = aws.ToString(difference.BeforeBlob.Path)
+	}
+	if difference.AfterBlob != nil {
+		afterPath = aws.ToString(difference.AfterBlob.Path)
+	}
+	switch {
+	case difference.ChangeType == types.ChangeTypeEnumAdded:
+		return PullRequestFile{Path: afterPath, ChangeType: FileAdded}
+	case difference.ChangeType == types.ChangeTypeEnumDeleted:
+		return PullRequestFile{Path: beforePath, ChangeType: FileDeleted}
+	case beforePath != "" && beforePath != afterPath:
+		return PullRequestFile{Path: afterPath, PreviousPath: beforePath, ChangeType: FileRenamed}
+	default:
+		return PullRequestFile{Path: afterPath, ChangeType: FileModified}
+	}
+}
@@ -0,0 +1,579 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const codeCommitRegion = "eu-west-1"
+
+// codeCommitRequest is an expected request to the AWS CodeCommit API, and its response
+type codeCommitRequest struct {
+	operation string
+	// The request body is checked only if it is set
+	requestBody string
+	response    string
+	// The status code of the response, defaults to http.StatusOK
+	statusCode int
+}
+
+func TestCodeCommitClient_Builder(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.CodeCommit).AWSRegion(codeCommitRegion).AWSCredentials(username, token, "session-token").Build()
+	assert.NoError(t, err)
+	codeCommitClient, _ := client.(*CodeCommitClient)
+	assert.Equal(t, codeCommitRegion, codeCommitClient.vcsInfo.AWSRegion)
+	assert.Equal(t, username, codeCommitClient.vcsInfo.Username)
+	assert.Equal(t, token, codeCommitClient.vcsInfo.Token)
+	assert.Equal(t, "session-token", codeCommitClient.vcsInfo.AWSSessionToken)
+
+	// The region is taken from the regional endpoint
+	client, err = NewClientBuilder(vcsutils.CodeCommit).ApiEndpoint("https://codecommit.us-east-2.amazonaws.com").Build()
+	assert.NoError(t, err)
+	codeCommitClient, _ = client.(*CodeCommitClient)
+	assert.Equal(t, "us-east-2", codeCommitClient.vcsInfo.AWSRegion)
+
+	_, err = NewClientBuilder(vcsutils.CodeCommit).ApiEndpoint("https://vpce-0123.codecommit.example.com").Build()
+	assert.ErrorIs(t, err, errCodeCommitMissingRegion)
+}
+
+func TestCodeCommitClient_CredentialsProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=provided-key/"))
+		assert.Equal(t, "provided-session", r.Header.Get("X-Amz-Security-Token"))
+		_, err := w.Write([]byte(`{"repositories": []}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	credentialsProvider := aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "provided-key", SecretAccessKey: "provided-secret", SessionToken: "provided-session"}, nil
+	})
+	client, err := NewClientBuilder(vcsutils.CodeCommit).ApiEndpoint(server.URL).AWSRegion(codeCommitRegion).
+		AWSCredentials(username, token, "").AWSCredentialsProvider(credentialsProvider).Build()
+	assert.NoError(t, err)
+	assert.NoError(t, client.TestConnection(context.Background()))
+}
+
+func TestCodeCommitClient_Connection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "ListRepositories", response: `{"repositories": []}`},
+		codeCommitRequest{operation: "ListRepositories", response: `{"repositories": []}`},
+		codeCommitRequest{operation: "ListRepositories", statusCode: http.StatusForbidden,
+			response: `{"__type": "AccessDeniedException", "message": "User is not authorized"}`},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.ErrorIs(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope), errCodeCommitTokenScopesNotSupported)
+	assert.ErrorContains(t, client.TestConnection(ctx), "User is not authorized")
+
+	_, err := client.GetAuthenticatedUser(ctx)
+	assert.ErrorIs(t, err, errCodeCommitAuthenticatedUserNotSupported)
+}
+
+func TestCodeCommitClient_ListRepositories(t *testing.T) {
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "ListRepositories", requestBody: `{}`,
+			response: `{"repositories": [{"repositoryName": "repo-1", "repositoryId": "1"}], "nextToken": "page-2"}`},
+		codeCommitRequest{operation: "ListRepositories", requestBody: `{"nextToken": "page-2"}`,
+			response: `{"repositories": [{"repositoryName": "repo-2", "repositoryId": "2"}]}`},
+		codeCommitRequest{operation: "BatchGetRepositories", requestBody: `{"repositoryNames": ["repo-1", "repo-2"]}`,
+			response: `{"repositories": [{"repositoryName": "repo-1", "accountId": "111111111111"}, {"repositoryName": "repo-2", "accountId": "222222222222"}]}`},
+	)
+	defer cleanUp()
+
+	actual, err := client.ListRepositories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"111111111111": {repo1}, "222222222222": {repo2}}, actual)
+}
+
+func TestCodeCommitClient_ListRepositoriesWithOptions(t *testing.T) {
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "ListRepositories", requestBody: `{}`,
+			response: `{"repositories": [{"repositoryName": "repo-2", "repositoryId": "2"}, {"repositoryName": "repo-1", "repositoryId": "1"}]}`},
+		codeCommitRequest{operation: "BatchGetRepositories", requestBody: `{"repositoryNames": ["repo-2", "repo-1"]}`,
+			response: `{"repositories": [{"repositoryName": "repo-2", "accountId": "111111111111"}, {"repositoryName": "repo-1", "accountId": "111111111111"}]}`},
+	)
+	defer cleanUp()
+
+	actual, err := client.ListRepositoriesWithOptions(context.Background(), ListOptions{PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"111111111111": {repo1}}, actual)
+}
+
+func TestCodeCommitClient_Branches(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "ListBranches", requestBody: `{"repositoryName": "repo-1"}`,
+			response: `{"branches": ["main", "branch-1"]}`},
+		codeCommitRequest{operation: "GetBranch", requestBody: `{"repositoryName": "repo-1", "branchName": "main"}`,
+			response: `{"branch": {"branchName": "main", "commitId": "9e2b1d3a7c6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d"}}`},
+		codeCommitRequest{operation: "CreateBranch", requestBody: `{"repositoryName": "repo-1", "branchName": "branch-2", "commitId": "9e2b1d3a7c6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d"}`,
+			response: `{}`},
+		codeCommitRequest{operation: "GetBranch", statusCode: http.StatusBadRequest,
+			response: `{"__type": "BranchDoesNotExistException", "message": "Branch does not exist"}`},
+		codeCommitRequest{operation: "CreateBranch", requestBody: `{"repositoryName": "repo-1", "branchName": "branch-3", "commitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}`,
+			response: `{}`},
+		codeCommitRequest{operation: "DeleteBranch", requestBody: `{"repositoryName": "repo-1", "branchName": "branch-2"}`,
+			response: `{"deletedBranch": {"branchName": "branch-2"}}`},
+		codeCommitRequest{operation: "GetRepository", requestBody: `{"repositoryName": "repo-1"}`,
+			response: `{"repositoryMetadata": {"repositoryName": "repo-1", "defaultBranch": "main"}}`},
+		codeCommitRequest{operation: "UpdateDefaultBranch", requestBody: `{"repositoryName": "repo-1", "defaultBranchName": "branch-1"}`,
+			response: `{}`},
+	)
+	defer cleanUp()
+
+	branches, err := client.ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main", branch1}, branches)
+
+	assert.NoError(t, client.CreateBranch(ctx, owner, repo1, "main", branch2))
+	// A source reference which isn't a branch is a commit ID
+	assert.NoError(t, client.CreateBranch(ctx, owner, repo1, "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21", "branch-3"))
+	assert.NoError(t, client.DeleteBranch(ctx, owner, repo1, branch2))
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", defaultBranch)
+	assert.NoError(t, client.SetDefaultBranch(ctx, owner, repo1, branch1))
+}
+
+func TestCodeCommitClient_Repository(t *testing.T) {
+	ctx := context.Background()
+	repositoryResponse := `{"repositoryMetadata": {"repositoryName": "repo-1", "defaultBranch": "main", "repositoryDescription": "Frogs",
+		"cloneUrlHttp": "https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/repo-1",
+		"cloneUrlSsh": "ssh://git-codecommit.eu-west-1.amazonaws.com/v1/repos/repo-1"}}`
+	expectedRepositoryInfo := RepositoryInfo{
+		RepositoryVisibility: Private,
+		CloneInfo: CloneInfo{
+			HTTP: "https://git-codecommit.eu-west-1.amazonaws.com/v1/repos/repo-1",
+			SSH:  "ssh://git-codecommit.eu-west-1.amazonaws.com/v1/repos/repo-1",
+		},
+		DefaultBranch: "main",
+		Description:   "Frogs",
+	}
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetRepository", requestBody: `{"repositoryName": "repo-1"}`, response: repositoryResponse},
+		codeCommitRequest{operation: "CreateRepository", requestBody: `{"repositoryName": "repo-1", "repositoryDescription": "Frogs"}`,
+			response: repositoryResponse},
+		codeCommitRequest{operation: "DeleteRepository", requestBody: `{"repositoryName": "repo-1"}`, response: `{"repositoryId": "1"}`},
+	)
+	defer cleanUp()
+
+	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRepositoryInfo, repositoryInfo)
+
+	repositoryInfo, err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Description: "Frogs", Private: true})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRepositoryInfo, repositoryInfo)
+	_, err = client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{InitReadme: true})
+	assert.ErrorIs(t, err, errCodeCommitInitReadmeNotSupported)
+
+	assert.NoError(t, client.DeleteRepository(ctx, owner, repo1))
+}
+
+func TestCodeCommitClient_CreatePullRequest(t *testing.T) {
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "CreatePullRequest",
+			requestBody: `{"title": "Update README", "description": "Improve the README",
+				"targets": [{"repositoryName": "repo-1", "sourceReference": "branch-1", "destinationReference": "main"}]}`,
+			response: readCodeCommitTestData(t, "pull_request_response.json")},
+	)
+	defer cleanUp()
+
+	actual, err := client.CreatePullRequest(context.Background(), owner, repo1, branch1, "main", "Update README", "Improve the README")
+	assert.NoError(t, err)
+	assert.Equal(t, PullRequestInfo{
+		ID:        42,
+		Title:     "Update README",
+		Body:      "Improve the README",
+		URL:       "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo-1/pull-requests/42/details?region=eu-west-1",
+		Author:    username,
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: branch1, Repository: repo1},
+		Target:    BranchInfo{Name: "main", Repository: repo1},
+		CreatedAt: time.Unix(1710234902, 0).UTC(),
+		UpdatedAt: time.Unix(1710235818, 0).UTC(),
+	}, actual)
+}
+
+func TestCodeCommitClient_CreatePullRequestWithOptions(t *testing.T) {
+	client, cleanUp := createCodeCommitServerAndClient(t)
+	defer cleanUp()
+	_, err := client.CreatePullRequestWithOptions(context.Background(), owner, repo1, branch1, "main", "Update README", "Improve the README", CreatePullRequestOptions{Draft: true})
+	assert.EqualError(t, err, "creating draft pull requests is not supported on AWS CodeCommit")
+}
+
+func TestCodeCommitClient_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "UpdatePullRequestTitle", requestBody: `{"pullRequestId": "42", "title": "New title"}`, response: `{}`},
+		codeCommitRequest{operation: "UpdatePullRequestDescription", requestBody: `{"pullRequestId": "42", "description": "New body"}`, response: `{}`},
+		codeCommitRequest{operation: "UpdatePullRequestStatus", requestBody: `{"pullRequestId": "42", "pullRequestStatus": "CLOSED"}`, response: `{}`},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.UpdatePullRequest(ctx, owner, repo1, "New title", "New body", "", 42, vcsutils.Closed))
+	assert.ErrorIs(t, client.UpdatePullRequest(ctx, owner, repo1, "", "", "main", 42, ""), errCodeCommitChangeTargetBranchNotSupported)
+}
+
+func TestCodeCommitClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullRequestResponse := readCodeCommitTestData(t, "pull_request_response.json")
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "MergePullRequestByThreeWay",
+			requestBody: `{"pullRequestId": "42", "repositoryName": "repo-1", "sourceCommitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}`,
+			response:    pullRequestResponse},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "MergePullRequestBySquash",
+			requestBody: `{"pullRequestId": "42", "repositoryName": "repo-1", "sourceCommitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}`,
+			response:    pullRequestResponse},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.MergePullRequest(ctx, owner, repo1, 42, vcsutils.MergeMethodMerge))
+	assert.NoError(t, client.MergePullRequest(ctx, owner, repo1, 42, vcsutils.MergeMethodSquash))
+	assert.EqualError(t, client.MergePullRequest(ctx, owner, repo1, 42, vcsutils.MergeMethodRebase), "unsupported merge method: 'rebase'")
+}
+
+func TestCodeCommitClient_SetPullRequestAutoMerge(t *testing.T) {
+	client, cleanUp := createCodeCommitServerAndClient(t)
+	defer cleanUp()
+	assert.ErrorIs(t, client.SetPullRequestAutoMerge(context.Background(), owner, repo1, 42, vcsutils.MergeMethodMerge), errCodeCommitAutoMergeNotSupported)
+}
+
+func TestCodeCommitClient_ListPullRequests(t *testing.T) {
+	ctx := context.Background()
+	pullRequestResponse := readCodeCommitTestData(t, "pull_request_response.json")
+	mergedPullRequestResponse := strings.Replace(strings.Replace(pullRequestResponse, `"OPEN"`, `"CLOSED"`, 1), `"isMerged": false`, `"isMerged": true`, 1)
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "ListPullRequests", requestBody: `{"repositoryName": "repo-1", "pullRequestStatus": "OPEN", "maxResults": 100}`,
+			response: `{"pullRequestIds": ["42"]}`},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "ListPullRequests", requestBody: `{"repositoryName": "repo-1", "pullRequestStatus": "CLOSED", "maxResults": 100}`,
+			response: `{"pullRequestIds": ["42"]}`},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: mergedPullRequestResponse},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+	)
+	defer cleanUp()
+
+	pullRequests, err := client.ListOpenPullRequests(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 1)
+	assert.Equal(t, int64(42), pullRequests[0].ID)
+	assert.Empty(t, pullRequests[0].Body)
+
+	pullRequests, err = client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Merged)
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 1)
+	assert.Equal(t, vcsutils.Merged, pullRequests[0].State)
+	assert.Equal(t, "Improve the README", pullRequests[0].Body)
+
+	pullRequest, err := client.GetPullRequestByID(ctx, owner, repo1, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "Update README", pullRequest.Title)
+	assert.Equal(t, "Improve the README", pullRequest.Body)
+}
+
+func TestCodeCommitClient_ListOpenPullRequestsWithOptions(t *testing.T) {
+	pullRequestResponse := readCodeCommitTestData(t, "pull_request_response.json")
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "ListPullRequests", requestBody: `{"repositoryName": "repo-1", "pullRequestStatus": "OPEN", "maxResults": 1}`,
+			response: `{"pullRequestIds": ["43"], "nextToken": "page-2"}`},
+		codeCommitRequest{operation: "ListPullRequests", requestBody: `{"repositoryName": "repo-1", "pullRequestStatus": "OPEN", "maxResults": 1, "nextToken": "page-2"}`,
+			response: `{"pullRequestIds": ["42"], "nextToken": "page-3"}`},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+	)
+	defer cleanUp()
+
+	pullRequests, err := client.ListOpenPullRequestsWithOptions(context.Background(), owner, repo1, ListPullRequestsOptions{Page: 2, PerPage: 1, SourceBranch: branch1, WithBody: true})
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 1)
+	assert.Equal(t, int64(42), pullRequests[0].ID)
+	assert.Equal(t, "Improve the README", pullRequests[0].Body)
+}
+
+func TestCodeCommitClient_AddPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	pullRequestResponse := readCodeCommitTestData(t, "pull_request_response.json")
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "PostCommentForPullRequest",
+			requestBody: `{"pullRequestId": "42", "repositoryName": "repo-1", "content": "Looks good",
+				"beforeCommitId": "9e2b1d3a7c6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d", "afterCommitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}`,
+			response: `{}`},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "PostCommentForPullRequest",
+			requestBody: `{"pullRequestId": "42", "repositoryName": "repo-1", "content": "Fix the typo",
+				"beforeCommitId": "9e2b1d3a7c6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d", "afterCommitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21",
+				"location": {"filePath": "README.md", "filePosition": 7, "relativeFileVersion": "AFTER"}}`,
+			response: `{}`},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.AddPullRequestComment(ctx, owner, repo1, "Looks good", 42))
+	assert.NoError(t, client.AddPullRequestReviewComments(ctx, owner, repo1, 42, PullRequestComment{
+		CommentInfo:     CommentInfo{Content: "Fix the typo"},
+		PullRequestDiff: PullRequestDiff{NewFilePath: "README.md", NewStartLine: 7},
+	}))
+	assert.EqualError(t, client.AddPullRequestReviewComments(ctx, owner, repo1, 42), vcsutils.ErrNoCommentsProvided)
+}
+
+func TestCodeCommitClient_ListPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	commentsResponse := readCodeCommitTestData(t, "comments_response.json")
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetCommentsForPullRequest", requestBody: `{"pullRequestId": "42", "repositoryName": "repo-1", "maxResults": 100}`,
+			response: commentsResponse},
+		codeCommitRequest{operation: "GetCommentsForPullRequest", requestBody: `{"pullRequestId": "42", "repositoryName": "repo-1", "maxResults": 100}`,
+			response: commentsResponse},
+		codeCommitRequest{operation: "DeleteCommentContent", requestBody: `{"commentId": "a1b2c3d4EXAMPLE5e6f7a8b"}`, response: `{}`},
+	)
+	defer cleanUp()
+
+	comments, err := client.ListPullRequestComments(ctx, owner, repo1, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{{
+		NativeID:       "ff30b348EXAMPLEb9aa670f",
+		ThreadID:       "ff30b348EXAMPLEb9aa670f",
+		Content:        "Looks good",
+		Author:         username,
+		AuthorUsername: username,
+		Created:        time.Unix(1710236000, 0).UTC(),
+		Updated:        time.Unix(1710236100, 0).UTC(),
+	}}, comments)
+
+	reviewComments, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{{
+		NativeID:       "a1b2c3d4EXAMPLE5e6f7a8b",
+		ThreadID:       "a1b2c3d4EXAMPLE5e6f7a8b",
+		Content:        "Fix the typo",
+		Author:         "frogbot",
+		AuthorUsername: "frogbot",
+		Created:        time.Unix(1710236400, 0).UTC(),
+		Updated:        time.Unix(1710236400, 0).UTC(),
+		FilePath:       "README.md",
+		Line:           7,
+	}}, reviewComments)
+
+	assert.NoError(t, client.DeletePullRequestReviewComments(ctx, owner, repo1, 42, reviewComments...))
+	assert.ErrorIs(t, client.UpdatePullRequestComment(ctx, owner, repo1, "content", 42, 1), errCodeCommitNumericCommentIDsNotSupported)
+	assert.ErrorIs(t, client.DeletePullRequestComment(ctx, owner, repo1, 42, 1), errCodeCommitNumericCommentIDsNotSupported)
+}
+
+func TestCodeCommitClient_PullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	pullRequestResponse := readCodeCommitTestData(t, "pull_request_response.json")
+	revisionID := "a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2"
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "UpdatePullRequestApprovalState",
+			requestBody: `{"pullRequestId": "42", "revisionId": "` + revisionID + `", "approvalState": "APPROVE"}`,
+			response:    `{}`},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "PostCommentForPullRequest", response: `{}`},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: pullRequestResponse},
+		codeCommitRequest{operation: "GetPullRequestApprovalStates", requestBody: `{"pullRequestId": "42", "revisionId": "` + revisionID + `"}`,
+			response: `{"approvals": [{"userArn": "arn:aws:iam::123456789012:user/frogger", "approvalState": "APPROVE"},
+				{"userArn": "arn:aws:iam::123456789012:user/toad", "approvalState": "REVOKE"}]}`},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.CreatePullRequestReview(ctx, owner, repo1, 42, vcsutils.ReviewVerdictApprove, "LGTM"))
+	assert.ErrorIs(t, client.CreatePullRequestReview(ctx, owner, repo1, 42, vcsutils.ReviewVerdictRequestChanges, "Fix it"), errCodeCommitRequestChangesNotSupported)
+
+	reviews, err := client.ListPullRequestReviews(ctx, owner, repo1, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewInfo{{Reviewer: username, State: vcsutils.ReviewVerdictApprove}}, reviews)
+}
+
+func TestCodeCommitClient_Commits(t *testing.T) {
+	ctx := context.Background()
+	commitResponse := readCodeCommitTestData(t, "commit_response.json")
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetBranch", requestBody: `{"repositoryName": "repo-1", "branchName": "branch-1"}`,
+			response: `{"branch": {"branchName": "branch-1", "commitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}}`},
+		codeCommitRequest{operation: "GetCommit", requestBody: `{"repositoryName": "repo-1", "commitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}`,
+			response: commitResponse},
+	)
+	defer cleanUp()
+
+	commit, err := client.GetLatestCommit(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, CommitInfo{
+		Hash:          "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21",
+		AuthorName:    "Frog Ger",
+		CommitterName: "Frog Ger",
+		Url:           "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo-1/commit/317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21?region=eu-west-1",
+		Timestamp:     1710232900,
+		Message:       "Update README\n",
+		ParentHashes:  []string{"9e2b1d3a7c6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d"},
+		AuthorEmail:   "frogger@jfrog.com",
+	}, commit)
+
+	_, err = client.GetCommits(ctx, owner, repo1, branch1)
+	assert.ErrorIs(t, err, errCodeCommitCommitsHistoryNotSupported)
+}
+
+func TestCodeCommitClient_Files(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetFile", requestBody: `{"repositoryName": "repo-1", "commitSpecifier": "branch-1", "filePath": "README.md"}`,
+			response: `{"fileContent": "SGVsbG8gd29ybGQ=", "filePath": "README.md"}`},
+		codeCommitRequest{operation: "GetFile", statusCode: http.StatusBadRequest,
+			response: `{"__type": "FileDoesNotExistException", "message": "File does not exist"}`},
+		codeCommitRequest{operation: "GetFolder", requestBody: `{"repositoryName": "repo-1", "commitSpecifier": "branch-1", "folderPath": "/"}`,
+			response: `{"files": [{"absolutePath": "README.md", "relativePath": "README.md"}],
+				"subFolders": [{"absolutePath": "src", "relativePath": "src"}]}`},
+		codeCommitRequest{operation: "GetBranch", requestBody: `{"repositoryName": "repo-1", "branchName": "branch-1"}`,
+			response: `{"branch": {"branchName": "branch-1", "commitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}}`},
+		codeCommitRequest{operation: "CreateCommit",
+			requestBody: `{"repositoryName": "repo-1", "branchName": "branch-1", "parentCommitId": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21",
+				"commitMessage": "Update files", "putFiles": [{"filePath": "README.md", "fileContent": "SGVsbG8gd29ybGQ="}],
+				"deleteFiles": [{"filePath": "old.txt"}]}`,
+			response: `{"commitId": "4f5a1c0a52e4c1f2e0e2d6d7e9b1c7f3a2e4b6d8"}`},
+	)
+	defer cleanUp()
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "README.md")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "Hello world", string(content))
+
+	_, statusCode, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "missing.md")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+
+	entries, err := client.ListDirectoryContents(ctx, owner, repo1, branch1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []DirectoryEntry{{Name: "README.md", Path: "README.md"}, {Name: "src", Path: "src", IsDir: true}}, entries)
+
+	assert.NoError(t, client.CommitFiles(ctx, owner, repo1, branch1, "Update files", []FileToCommit{
+		{Path: "README.md", Content: []byte("Hello world"), ChangeType: FileModified},
+		{Path: "old.txt", ChangeType: FileDeleted},
+	}))
+}
+
+func TestCodeCommitClient_Differences(t *testing.T) {
+	ctx := context.Background()
+	differencesResponse := `{"differences": [
+		{"changeType": "A", "afterBlob": {"path": "main.go"}},
+		{"changeType": "M", "beforeBlob": {"path": "README.md"}, "afterBlob": {"path": "README.md"}},
+		{"changeType": "M", "beforeBlob": {"path": "old.go"}, "afterBlob": {"path": "new.go"}},
+		{"changeType": "D", "beforeBlob": {"path": "removed.go"}}]}`
+	client, cleanUp := createCodeCommitServerAndClient(t,
+		codeCommitRequest{operation: "GetDifferences", requestBody: `{"repositoryName": "repo-1", "beforeCommitSpecifier": "main", "afterCommitSpecifier": "branch-1"}`,
+			response: differencesResponse},
+		codeCommitRequest{operation: "GetPullRequest", requestBody: `{"pullRequestId": "42"}`, response: readCodeCommitTestData(t, "pull_request_response.json")},
+		codeCommitRequest{operation: "GetDifferences",
+			requestBody: `{"repositoryName": "repo-1", "beforeCommitSpecifier": "6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e", "afterCommitSpecifier": "317c8c5bcdb7c0b48ee3fa8e0b0a1c4b7b6e3f21"}`,
+			response:    differencesResponse},
+	)
+	defer cleanUp()
+
+	modifiedFiles, err := client.GetModifiedFiles(ctx, owner, repo1, "main", branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "main.go", "new.go", "old.go", "removed.go"}, modifiedFiles)
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "main.go", ChangeType: FileAdded},
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "new.go", PreviousPath: "old.go", ChangeType: FileRenamed},
+		{Path: "removed.go", ChangeType: FileDeleted},
+	}, files)
+}
+
+func TestCodeCommitClient_NotSupported(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewCodeCommitClient(VcsInfo{AWSRegion: codeCommitRegion}, vcsutils.EmptyLogger{})
+	assert.NoError(t, err)
+
+	_, _, err = client.CreateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com")
+	assert.ErrorIs(t, err, errCodeCommitWebhooksNotSupported)
+	assert.ErrorIs(t, client.SetCommitStatus(ctx, Pass, owner, repo1, branch1, "title", "description", ""), errCodeCommitCommitStatusesNotSupported)
+	_, err = client.TriggerPipeline(ctx, owner, repo1, branch1, nil)
+	assert.ErrorIs(t, err, errCodeCommitPipelinesNotSupported)
+	_, err = client.ListEnvironments(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errCodeCommitDeploymentsNotSupported)
+	_, err = client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errCodeCommitRepositorySecretsNotSupported)
+	_, err = client.ListEnvironmentSecrets(ctx, owner, repo1, envName)
+	assert.ErrorIs(t, err, errCodeCommitSecretsNotSupported)
+	assert.ErrorIs(t, client.DownloadRepository(ctx, owner, repo1, branch1, t.TempDir()), errCodeCommitDownloadRepositoryNotSupported)
+	assert.ErrorIs(t, client.DownloadRepositoryPaths(ctx, owner, repo1, branch1, []string{"go.mod"}, t.TempDir()), errCodeCommitDownloadRepositoryNotSupported)
+	_, err = client.ListTags(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errCodeCommitTagsNotSupported)
+	assert.ErrorIs(t, client.CreateLabel(ctx, owner, repo1, LabelInfo{Name: "label"}), errCodeCommitLabelsNotSupported)
+	_, err = client.CreateIssue(ctx, owner, repo1, "title", "body")
+	assert.ErrorIs(t, err, errCodeCommitIssuesNotSupported)
+	_, err = client.CompareCommits(ctx, owner, repo1, "main", branch1)
+	assert.ErrorIs(t, err, errCodeCommitCompareCommitsNotSupported)
+	assert.Equal(t, codeCommitPrContentSizeLimit, client.GetPullRequestCommentSizeLimit())
+}
+
+// createCodeCommitServerAndClient creates a server which expects the given requests in order, and a client of the server
+func createCodeCommitServerAndClient(t *testing.T, requests ...codeCommitRequest) (VcsClient, func()) {
+	var requestIndex int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Less(t, requestIndex, len(requests), "unexpected request") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		expected := requests[requestIndex]
+		requestIndex++
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "CodeCommit_20150413."+expected.operation, r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+username+"/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/"+codeCommitRegion+"/codecommit/aws4_request")
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if expected.requestBody != "" {
+			// The idempotency tokens are generated by the AWS SDK
+			var actualBody map[string]interface{}
+			assert.NoError(t, json.Unmarshal(body, &actualBody))
+			delete(actualBody, "clientRequestToken")
+			body, err = json.Marshal(actualBody)
+			assert.NoError(t, err)
+			assert.JSONEq(t, expected.requestBody, string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if expected.statusCode != 0 {
+			w.WriteHeader(expected.statusCode)
+		}
+		_, err = w.Write([]byte(expected.response))
+		assert.NoError(t, err)
+	}))
+	client, err := NewClientBuilder(vcsutils.CodeCommit).ApiEndpoint(server.URL).AWSRegion(codeCommitRegion).AWSCredentials(username, token, "").Build()
+	assert.NoError(t, err)
+	return client, func() {
+		server.Close()
+		assert.Equal(t, len(requests), requestIndex, "not all the expected requests were sent")
+	}
+}
+
+func readCodeCommitTestData(t *testing.T, filename string) string {
+	content, err := os.ReadFile(filepath.Join("testdata", "codecommit", filename))
+	assert.NoError(t, err)
+	return string(content)
+}
@@ -0,0 +1,159 @@
+package vcsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultCodeScanningToolName = "Code scanning"
+	codeScanningReportKey       = "code-scanning"
+)
+
+// Code scanning severities, ordered from the least severe
+const (
+	codeScanningLowSeverity      = "LOW"
+	codeScanningMediumSeverity   = "MEDIUM"
+	codeScanningHighSeverity     = "HIGH"
+	codeScanningCriticalSeverity = "CRITICAL"
+)
+
+// codeScanningFinding is a single result of a code scanning analysis.
+// FilePath - The path of the file the result was found in, relative to the repository root
+// Line     - The line the result was found in, or 0 if the result isn't located in a specific line
+// Severity - One of the code scanning severities
+type codeScanningFinding struct {
+	RuleID   string
+	Message  string
+	FilePath string
+	Line     int
+	Severity string
+}
+
+// getSummary returns the message of the finding, prefixed by its rule ID
+func (finding codeScanningFinding) getSummary() string {
+	if finding.RuleID == "" {
+		return finding.Message
+	}
+	return fmt.Sprintf("%s: %s", finding.RuleID, finding.Message)
+}
+
+// truncateCodeScanningMessage shortens a message to the length limit of the provider, marking the cut with an ellipsis
+func truncateCodeScanningMessage(message string, limit int) string {
+	runes := []rune(message)
+	if len(runes) <= limit {
+		return message
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// codeScanningAnalysis contains the results of a SARIF code scanning analysis
+type codeScanningAnalysis struct {
+	ToolName string
+	Findings []codeScanningFinding
+}
+
+type sarifLog struct {
+	Runs []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name  string      `json:"name"`
+			Rules []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID         string `json:"id"`
+	Properties struct {
+		SecuritySeverity json.Number `json:"security-severity"`
+	} `json:"properties"`
+}
+
+type sarifResult struct {
+	RuleID  string `json:"ruleId"`
+	Level   string `json:"level"`
+	Message struct {
+		Text string `json:"text"`
+	} `json:"message"`
+	Locations []struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine int `json:"startLine"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	} `json:"locations"`
+}
+
+// parseCodeScanningAnalysis extracts the findings of a SARIF analysis, for providers which don't accept SARIF files
+func parseCodeScanningAnalysis(sarifContent string) (codeScanningAnalysis, error) {
+	var sarif sarifLog
+	if err := json.Unmarshal([]byte(sarifContent), &sarif); err != nil {
+		return codeScanningAnalysis{}, fmt.Errorf("failed to parse the SARIF analysis: %w", err)
+	}
+	analysis := codeScanningAnalysis{ToolName: defaultCodeScanningToolName}
+	for _, run := range sarif.Runs {
+		if run.Tool.Driver.Name != "" {
+			analysis.ToolName = run.Tool.Driver.Name
+		}
+		securitySeverities := make(map[string]json.Number, len(run.Tool.Driver.Rules))
+		for _, rule := range run.Tool.Driver.Rules {
+			securitySeverities[rule.ID] = rule.Properties.SecuritySeverity
+		}
+		for _, result := range run.Results {
+			finding := codeScanningFinding{
+				RuleID:   result.RuleID,
+				Message:  result.Message.Text,
+				Severity: getCodeScanningSeverity(result.Level, securitySeverities[result.RuleID]),
+			}
+			if len(result.Locations) > 0 {
+				location := result.Locations[0].PhysicalLocation
+				finding.FilePath = strings.TrimPrefix(location.ArtifactLocation.URI, "file://")
+				finding.Line = location.Region.StartLine
+			}
+			analysis.Findings = append(analysis.Findings, finding)
+		}
+	}
+	return analysis, nil
+}
+
+// getCodeScanningSeverity maps the security severity score of a rule to a severity, following GitHub's code scanning thresholds.
+// The SARIF level of the result is used if the rule has no security severity.
+func getCodeScanningSeverity(level string, securitySeverity json.Number) string {
+	if score, err := securitySeverity.Float64(); err == nil {
+		switch {
+		case score >= 9:
+			return codeScanningCriticalSeverity
+		case score >= 7:
+			return codeScanningHighSeverity
+		case score >= 4:
+			return codeScanningMediumSeverity
+		default:
+			return codeScanningLowSeverity
+		}
+	}
+	switch level {
+	case "error":
+		return codeScanningHighSeverity
+	case "warning":
+		return codeScanningMediumSeverity
+	default:
+		return codeScanningLowSeverity
+	}
+}
+
+// getDetails returns a short summary of the analysis findings
+func (analysis codeScanningAnalysis) getDetails() string {
+	if len(analysis.Findings) == 0 {
+		return fmt.Sprintf("%s found no issues", analysis.ToolName)
+	}
+	return fmt.Sprintf("%s found %d issues", analysis.ToolName, len(analysis.Findings))
+}
@@ -0,0 +1,84 @@
+package vcsclient
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const codeScanningTestSarif = `{
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "Xray",
+          "rules": [
+            {"id": "XRAY-174176", "properties": {"security-severity": "9.1"}},
+            {"id": "XRAY-174177"}
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "XRAY-174176",
+          "message": {"text": "json 9.0.6. Fixed in Versions: [11.0.0]"},
+          "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file://package.json"}, "region": {"startLine": 12}}}]
+        },
+        {
+          "ruleId": "XRAY-174177",
+          "level": "warning",
+          "message": {"text": "lodash 4.17.0. Fixed in Versions: [4.17.21]"}
+        }
+      ]
+    }
+  ]
+}`
+
+func TestParseCodeScanningAnalysis(t *testing.T) {
+	analysis, err := parseCodeScanningAnalysis(codeScanningTestSarif)
+	assert.NoError(t, err)
+	assert.Equal(t, codeScanningAnalysis{
+		ToolName: "Xray",
+		Findings: []codeScanningFinding{
+			{RuleID: "XRAY-174176", Message: "json 9.0.6. Fixed in Versions: [11.0.0]", FilePath: "package.json", Line: 12, Severity: codeScanningCriticalSeverity},
+			{RuleID: "XRAY-174177", Message: "lodash 4.17.0. Fixed in Versions: [4.17.21]", Severity: codeScanningMediumSeverity},
+		},
+	}, analysis)
+	assert.Equal(t, "Xray found 2 issues", analysis.getDetails())
+	assert.Equal(t, "XRAY-174176: json 9.0.6. Fixed in Versions: [11.0.0]", analysis.Findings[0].getSummary())
+
+	analysis, err = parseCodeScanningAnalysis(`{"runs": []}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Code scanning found no issues", analysis.getDetails())
+
+	_, err = parseCodeScanningAnalysis("1")
+	assert.ErrorContains(t, err, "failed to parse the SARIF analysis")
+}
+
+func TestGetCodeScanningSeverity(t *testing.T) {
+	tests := []struct {
+		level            string
+		securitySeverity json.Number
+		expected         string
+	}{
+		{securitySeverity: "9.0", expected: codeScanningCriticalSeverity},
+		{securitySeverity: "7.5", expected: codeScanningHighSeverity},
+		{securitySeverity: "4", expected: codeScanningMediumSeverity},
+		{level: "error", securitySeverity: "1.2", expected: codeScanningLowSeverity},
+		{level: "error", expected: codeScanningHighSeverity},
+		{level: "warning", expected: codeScanningMediumSeverity},
+		{level: "note", expected: codeScanningLowSeverity},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getCodeScanningSeverity(test.level, test.securitySeverity))
+	}
+}
+
+func TestTruncateCodeScanningMessage(t *testing.T) {
+	assert.Equal(t, "short", truncateCodeScanningMessage("short", 5))
+	assert.Equal(t, "shor…", truncateCodeScanningMessage("shorter", 5))
+	assert.Len(t, []rune(truncateCodeScanningMessage(strings.Repeat("ü", 10), 4)), 4)
+}
@@ -1,9 +1,15 @@
 package vcsclient
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"path"
 	"testing"
 	"time"
 
@@ -100,6 +106,49 @@ func createWaitingServerAndClient(t *testing.T, provider vcsutils.VcsProvider, w
 	return client, server.Close
 }
 
+// readMultipartFile returns the content of a file sent in a parsed multipart form
+func readMultipartFile(t *testing.T, r *http.Request, fieldName string) string {
+	file, _, err := r.FormFile(fieldName)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	return string(content)
+}
+
+// createTarGz returns a tar.gz archive of the given files, under the given base directory
+func createTarGz(t *testing.T, baseDir string, files map[string]string) []byte {
+	archive := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for filePath, content := range files {
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: path.Join(baseDir, filePath), Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+	return archive.Bytes()
+}
+
+// createZip returns a zip archive of the given files
+func createZip(t *testing.T, files map[string]string) []byte {
+	archive := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(archive)
+	for filePath, content := range files {
+		fileWriter, err := zipWriter.Create(filePath)
+		assert.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zipWriter.Close())
+	return archive.Bytes()
+}
+
 func getAllProviders() []vcsutils.VcsProvider {
 	return []vcsutils.VcsProvider{
 		vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketServer, vcsutils.BitbucketCloud,
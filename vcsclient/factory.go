@@ -1,7 +1,14 @@
 package vcsclient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/time/rate"
 )
 
 // ClientBuilder builds VcsClient
@@ -34,6 +41,179 @@ func (builder *ClientBuilder) Token(token string) *ClientBuilder {
 	return builder
 }
 
+// GitHubApp sets the credentials of a GitHub App, to authenticate with instead of a token.
+// Leave the installation ID empty to authenticate as the app itself, for listing its installations.
+func (builder *ClientBuilder) GitHubApp(appID int64, privateKey []byte, installationID int64) *ClientBuilder {
+	builder.vcsInfo.GitHubAppID = appID
+	builder.vcsInfo.GitHubAppPrivateKey = privateKey
+	builder.vcsInfo.GitHubAppInstallationID = installationID
+	return builder
+}
+
+// GitLabJobToken sets a GitLab CI job token, such as the CI_JOB_TOKEN variable of a pipeline, to authenticate with instead of a token
+func (builder *ClientBuilder) GitLabJobToken(jobToken string) *ClientBuilder {
+	builder.vcsInfo.Token = jobToken
+	builder.vcsInfo.GitLabJobToken = true
+	return builder
+}
+
+// GitLabGroup scopes the GitLab repositories listing to the projects of a group and its subgroups, such as org/team.
+// If membershipOnly is set, only the projects which the user is a member of are listed, rather than all the projects of the group which the user can see.
+func (builder *ClientBuilder) GitLabGroup(group string, membershipOnly bool) *ClientBuilder {
+	builder.vcsInfo.GitLabGroup = group
+	builder.vcsInfo.GitLabGroupMembershipOnly = membershipOnly
+	return builder
+}
+
+// AzureADToken sets an Azure AD (Entra ID) access token of a service principal or a managed identity, to authenticate with instead of a personal access token
+func (builder *ClientBuilder) AzureADToken(token string) *ClientBuilder {
+	builder.vcsInfo.Token = token
+	builder.vcsInfo.AzureADToken = true
+	return builder
+}
+
+// AzureADTokenProvider sets a function which returns an Azure AD (Entra ID) access token, to authenticate with instead of a personal access token.
+// Azure AD tokens expire within an hour, so the token provider should refresh them.
+func (builder *ClientBuilder) AzureADTokenProvider(tokenProvider TokenProvider) *ClientBuilder {
+	builder.vcsInfo.TokenProvider = tokenProvider
+	builder.vcsInfo.AzureADToken = true
+	return builder
+}
+
+// AzureDevOpsServer sets the URL of an Azure DevOps Server (on-premises) collection, such as https://server/tfs/DefaultCollection, instead of the API endpoint of an organization
+func (builder *ClientBuilder) AzureDevOpsServer(collectionUrl string) *ClientBuilder {
+	builder.vcsInfo.APIEndpoint = collectionUrl
+	builder.vcsInfo.AzureDevOpsServer = true
+	return builder
+}
+
+// AzureNTLMCredentials sets the Windows credentials of an Azure DevOps Server user, to authenticate with NTLM instead of a personal access token.
+// The username may include the domain, as DOMAIN\user or as user@domain.
+func (builder *ClientBuilder) AzureNTLMCredentials(username, password string) *ClientBuilder {
+	builder.vcsInfo.Username = username
+	builder.vcsInfo.Token = password
+	builder.vcsInfo.AzureNTLMAuth = true
+	return builder
+}
+
+// AzureAPIVersion pins the version of the Azure DevOps REST API - 6.0, 7.0 or 7.1, instead of the latest version which the server supports
+func (builder *ClientBuilder) AzureAPIVersion(apiVersion string) *ClientBuilder {
+	builder.vcsInfo.AzureAPIVersion = apiVersion
+	return builder
+}
+
+// AWSRegion sets the region of the AWS CodeCommit repositories
+func (builder *ClientBuilder) AWSRegion(region string) *ClientBuilder {
+	builder.vcsInfo.AWSRegion = region
+	return builder
+}
+
+// AWSCredentials sets the AWS credentials which sign the AWS CodeCommit requests with Signature Version 4.
+// Leave the session token empty for the long-term credentials of an IAM user.
+func (builder *ClientBuilder) AWSCredentials(accessKeyID, secretAccessKey, sessionToken string) *ClientBuilder {
+	builder.vcsInfo.Username = accessKeyID
+	builder.vcsInfo.Token = secretAccessKey
+	builder.vcsInfo.AWSSessionToken = sessionToken
+	return builder
+}
+
+// AWSCredentialsProvider sets a provider of the AWS credentials which sign the AWS CodeCommit requests, to use instead of static credentials.
+// Use it for credentials which are refreshed, such as the credentials of an assumed IAM role.
+func (builder *ClientBuilder) AWSCredentialsProvider(credentialsProvider aws.CredentialsProvider) *ClientBuilder {
+	builder.vcsInfo.AWSCredentialsProvider = credentialsProvider
+	return builder
+}
+
+// TokenProvider sets a function which returns the access token, to authenticate with instead of a static token.
+// Use it for short-lived tokens, which are refreshed without rebuilding the client.
+func (builder *ClientBuilder) TokenProvider(tokenProvider TokenProvider) *ClientBuilder {
+	builder.vcsInfo.TokenProvider = tokenProvider
+	return builder
+}
+
+// HttpClient sets the HTTP client which sends the requests to the VCS provider, for example to use a proxy, tracing or a custom TLS configuration.
+// The authentication headers are added to the requests by the client.
+func (builder *ClientBuilder) HttpClient(httpClient *http.Client) *ClientBuilder {
+	builder.vcsInfo.HttpClient = httpClient
+	return builder
+}
+
+// RoundTripper sets the transport which sends the requests to the VCS provider
+func (builder *ClientBuilder) RoundTripper(roundTripper http.RoundTripper) *ClientBuilder {
+	builder.vcsInfo.HttpClient = &http.Client{Transport: roundTripper}
+	return builder
+}
+
+// Proxy sets the proxy which the requests to the VCS provider are sent through, instead of the proxy of the HTTP_PROXY and HTTPS_PROXY environment variables
+func (builder *ClientBuilder) Proxy(proxyUrl *url.URL) *ClientBuilder {
+	builder.vcsInfo.Proxy = proxyUrl
+	return builder
+}
+
+// RootCAs sets the certificate authorities which sign the certificates of a self-hosted server, instead of the system's certificate authorities
+func (builder *ClientBuilder) RootCAs(rootCAs *x509.CertPool) *ClientBuilder {
+	builder.vcsInfo.RootCAs = rootCAs
+	return builder
+}
+
+// InsecureSkipVerify disables the verification of the server certificates. Use it only for testing.
+func (builder *ClientBuilder) InsecureSkipVerify(insecureSkipVerify bool) *ClientBuilder {
+	builder.vcsInfo.InsecureSkipVerify = insecureSkipVerify
+	return builder
+}
+
+// ClientCertificate adds a client certificate, which is presented to servers that require mutual TLS authentication.
+// Load the certificate and its private key with tls.LoadX509KeyPair.
+func (builder *ClientBuilder) ClientCertificate(certificate tls.Certificate) *ClientBuilder {
+	builder.vcsInfo.ClientCertificates = append(builder.vcsInfo.ClientCertificates, certificate)
+	return builder
+}
+
+// RateLimit limits the rate of the requests to the VCS provider, using a token bucket which allows bursts of requests.
+// Use it for bulk operations, such as scanning many repositories, to avoid tripping the abuse detection of the provider.
+func (builder *ClientBuilder) RateLimit(requestsPerSecond float64, burst int) *ClientBuilder {
+	builder.vcsInfo.RateLimiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	return builder
+}
+
+// RateLimiter sets the rate limiter of the requests to the VCS provider.
+// Share a rate limiter between several clients to limit the total rate of their requests.
+func (builder *ClientBuilder) RateLimiter(rateLimiter *rate.Limiter) *ClientBuilder {
+	builder.vcsInfo.RateLimiter = rateLimiter
+	return builder
+}
+
+// Retries enables the retries of requests which failed with a transient error, or were rejected by the rate limit of the VCS provider
+func (builder *ClientBuilder) Retries(options RetryOptions) *ClientBuilder {
+	builder.vcsInfo.RetryOptions = &options
+	return builder
+}
+
+// ResponseCache caches the responses of GET requests, which are revalidated with conditional requests.
+// Use it for polling loops, such as polling the repository info or the branches, since the 304 Not Modified responses aren't counted by the rate limit of GitHub.
+func (builder *ClientBuilder) ResponseCache(cache ResponseCache) *ClientBuilder {
+	builder.vcsInfo.ResponseCache = cache
+	return builder
+}
+
+// MaxDownloadSize limits the size in bytes of the repository archives which are downloaded by DownloadRepository
+func (builder *ClientBuilder) MaxDownloadSize(maxDownloadSize int64) *ClientBuilder {
+	builder.vcsInfo.MaxDownloadSize = maxDownloadSize
+	return builder
+}
+
+// ArchiveFormat sets the format of the repository archives which are downloaded by DownloadRepository
+func (builder *ClientBuilder) ArchiveFormat(format vcsutils.ArchiveFormat) *ClientBuilder {
+	builder.vcsInfo.ArchiveFormat = format
+	return builder
+}
+
+// ExtractionLimits sets the limits of the extraction of the downloaded repository archives, which protect from decompression bombs
+func (builder *ClientBuilder) ExtractionLimits(limits vcsutils.ExtractionLimits) *ClientBuilder {
+	builder.vcsInfo.ExtractionLimits = limits
+	return builder
+}
+
 // Logger sets the logger
 func (builder *ClientBuilder) Logger(logger vcsutils.Log) *ClientBuilder {
 	builder.logger = logger
@@ -59,6 +239,14 @@ func (builder *ClientBuilder) Build() (VcsClient, error) {
 		return NewBitbucketCloudClient(builder.vcsInfo, builder.logger)
 	case vcsutils.AzureRepos:
 		return NewAzureReposClient(builder.vcsInfo, builder.logger)
+	case vcsutils.Gitea:
+		return NewGiteaClient(builder.vcsInfo, builder.logger)
+	case vcsutils.CodeCommit:
+		return NewCodeCommitClient(builder.vcsInfo, builder.logger)
+	case vcsutils.Gerrit:
+		return NewGerritClient(builder.vcsInfo, builder.logger)
+	case vcsutils.LocalGit:
+		return NewLocalGitClient(builder.vcsInfo, builder.logger)
 	}
 	return nil, nil
 }
@@ -1,10 +1,27 @@
 package vcsclient
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -12,7 +29,7 @@ const (
 )
 
 func TestClientBuilder(t *testing.T) {
-	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos} {
+	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos, vcsutils.Gitea, vcsutils.CodeCommit, vcsutils.Gerrit, vcsutils.LocalGit} {
 		t.Run(vcsProvider.String(), func(t *testing.T) {
 			clientBuilder := NewClientBuilder(vcsProvider).ApiEndpoint(apiEndpoint).Username(username).Token(token).Project(project)
 			assert.NotNil(t, clientBuilder)
@@ -36,3 +53,290 @@ func TestNegativeBitbucketCloudClient(t *testing.T) {
 	assert.Nil(t, vcsClient)
 	assert.Error(t, err)
 }
+
+func TestClientBuilder_TokenProvider(t *testing.T) {
+	tests := []struct {
+		vcsProvider         vcsutils.VcsProvider
+		username            string
+		expectedAuthHeaders []string
+	}{
+		{vcsProvider: vcsutils.GitHub, expectedAuthHeaders: []string{"Bearer token-1", "Bearer token-2"}},
+		{vcsProvider: vcsutils.GitLab, expectedAuthHeaders: []string{"Bearer token-1", "Bearer token-2"}},
+		{vcsProvider: vcsutils.BitbucketServer, expectedAuthHeaders: []string{"Bearer token-1", "Bearer token-2"}},
+		{vcsProvider: vcsutils.BitbucketCloud, expectedAuthHeaders: []string{"Bearer token-1", "Bearer token-2"}},
+		{vcsProvider: vcsutils.BitbucketCloud, username: "frogger", expectedAuthHeaders: []string{
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("frogger:token-1")),
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("frogger:token-2")),
+		}},
+		{vcsProvider: vcsutils.AzureRepos, expectedAuthHeaders: []string{
+			"Basic " + base64.StdEncoding.EncodeToString([]byte(":token-1")),
+			"Basic " + base64.StdEncoding.EncodeToString([]byte(":token-2")),
+		}},
+		{vcsProvider: vcsutils.Gitea, expectedAuthHeaders: []string{"Bearer token-1", "Bearer token-2"}},
+		{vcsProvider: vcsutils.Gerrit, username: "frogger", expectedAuthHeaders: []string{
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("frogger:token-1")),
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("frogger:token-2")),
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.vcsProvider.String()+" "+test.username, func(t *testing.T) {
+			ctx := context.Background()
+			var authHeaders []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.RequestURI == "/_apis" {
+					// Azure Repos resource locations
+					jsonVal, err := os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+					assert.NoError(t, err)
+					_, err = w.Write(jsonVal)
+					assert.NoError(t, err)
+					return
+				}
+				authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+				response := `{"value": [], "count": 0}`
+				if test.vcsProvider == vcsutils.GitLab {
+					response = "[]"
+				}
+				_, err := w.Write([]byte(response))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+			var tokensProvided int
+			client, err := NewClientBuilder(test.vcsProvider).ApiEndpoint(server.URL).Username(test.username).TokenProvider(func(context.Context) (string, error) {
+				tokensProvided++
+				return fmt.Sprintf("token-%d", tokensProvided), nil
+			}).Build()
+			assert.NoError(t, err)
+
+			assert.NoError(t, client.TestConnection(ctx))
+			assert.NoError(t, client.TestConnection(ctx))
+			assert.Equal(t, test.expectedAuthHeaders, authHeaders)
+		})
+	}
+}
+
+func TestClientBuilder_TokenProviderError(t *testing.T) {
+	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos, vcsutils.Gitea, vcsutils.Gerrit} {
+		t.Run(vcsProvider.String(), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Fail(t, "unexpected request", r.RequestURI)
+			}))
+			defer server.Close()
+			client, err := NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).TokenProvider(func(context.Context) (string, error) {
+				return "", fmt.Errorf("token expired")
+			}).Build()
+			assert.NoError(t, err)
+			assert.ErrorContains(t, client.TestConnection(context.Background()), "token expired")
+		})
+	}
+}
+
+type traceRoundTripper struct {
+	requests int
+}
+
+func (roundTripper *traceRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	roundTripper.requests++
+	request = request.Clone(request.Context())
+	request.Header.Set("X-Trace-Id", "frogger-trace")
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestClientBuilder_RoundTripper(t *testing.T) {
+	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos, vcsutils.Gitea, vcsutils.Gerrit} {
+		t.Run(vcsProvider.String(), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "frogger-trace", r.Header.Get("X-Trace-Id"))
+				assert.NotEmpty(t, r.Header.Get("Authorization")+r.Header.Get("Private-Token"))
+				response := []byte(`{"value": [], "count": 0}`)
+				if r.RequestURI == "/_apis" {
+					var err error
+					response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+					assert.NoError(t, err)
+				} else if vcsProvider == vcsutils.GitLab {
+					response = []byte("[]")
+				}
+				_, err := w.Write(response)
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+
+			roundTripper := &traceRoundTripper{}
+			client, err := NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username("frogger").Token(token).RoundTripper(roundTripper).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.NotZero(t, roundTripper.requests)
+
+			roundTripper = &traceRoundTripper{}
+			client, err = NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username("frogger").TokenProvider(func(context.Context) (string, error) {
+				return token, nil
+			}).HttpClient(&http.Client{Transport: roundTripper}).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.NotZero(t, roundTripper.requests)
+		})
+	}
+}
+
+func TestClientBuilder_Proxy(t *testing.T) {
+	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos, vcsutils.Gitea, vcsutils.Gerrit} {
+		t.Run(vcsProvider.String(), func(t *testing.T) {
+			var proxiedRequests int
+			// The proxy responds to the requests on behalf of the VCS provider
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxiedRequests++
+				assert.Equal(t, "vcs.example.com", r.Host)
+				response := []byte(`{"value": [], "count": 0}`)
+				if r.URL.Path == "/_apis" {
+					var err error
+					response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+					assert.NoError(t, err)
+				} else if vcsProvider == vcsutils.GitLab {
+					response = []byte("[]")
+				}
+				_, err := w.Write(response)
+				assert.NoError(t, err)
+			}))
+			defer proxy.Close()
+			proxyUrl, err := url.Parse(proxy.URL)
+			assert.NoError(t, err)
+
+			client, err := NewClientBuilder(vcsProvider).ApiEndpoint("http://vcs.example.com").Username("frogger").Token(token).Proxy(proxyUrl).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(context.Background()))
+			assert.NotZero(t, proxiedRequests)
+		})
+	}
+}
+
+func TestClientBuilder_TLS(t *testing.T) {
+	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos, vcsutils.Gitea, vcsutils.Gerrit} {
+		t.Run(vcsProvider.String(), func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				response := []byte(`{"value": [], "count": 0}`)
+				if r.RequestURI == "/_apis" {
+					var err error
+					response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+					assert.NoError(t, err)
+				} else if vcsProvider == vcsutils.GitLab {
+					response = []byte("[]")
+				}
+				_, err := w.Write(response)
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+			ctx := context.Background()
+
+			// The certificate of the server is signed by an unknown authority
+			client, err := NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username("frogger").Token(token).Build()
+			assert.NoError(t, err)
+			assert.ErrorContains(t, client.TestConnection(ctx), "certificate")
+
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(server.Certificate())
+			client, err = NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username("frogger").Token(token).RootCAs(rootCAs).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(ctx))
+
+			client, err = NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username("frogger").Token(token).InsecureSkipVerify(true).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(ctx))
+		})
+	}
+}
+
+func TestClientBuilder_ClientCertificate(t *testing.T) {
+	clientCertificate := createClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate.Leaf)
+	for _, vcsProvider := range []vcsutils.VcsProvider{vcsutils.GitHub, vcsutils.GitLab, vcsutils.BitbucketCloud, vcsutils.BitbucketServer, vcsutils.AzureRepos, vcsutils.Gitea, vcsutils.Gerrit} {
+		t.Run(vcsProvider.String(), func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				response := []byte(`{"value": [], "count": 0}`)
+				if r.RequestURI == "/_apis" {
+					var err error
+					response, err = os.ReadFile(filepath.Join("testdata", "azurerepos", "resourcesResponse.json"))
+					assert.NoError(t, err)
+				} else if vcsProvider == vcsutils.GitLab {
+					response = []byte("[]")
+				}
+				_, err := w.Write(response)
+				assert.NoError(t, err)
+			}))
+			server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+			server.StartTLS()
+			defer server.Close()
+			ctx := context.Background()
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(server.Certificate())
+
+			client, err := NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username("frogger").Token(token).RootCAs(rootCAs).Build()
+			assert.NoError(t, err)
+			assert.Error(t, client.TestConnection(ctx))
+
+			client, err = NewClientBuilder(vcsProvider).ApiEndpoint(server.URL).Username("frogger").Token(token).RootCAs(rootCAs).ClientCertificate(clientCertificate).Build()
+			assert.NoError(t, err)
+			assert.NoError(t, client.TestConnection(ctx))
+		})
+	}
+}
+
+// createClientCertificate creates a self-signed client certificate
+func createClientCertificate(t *testing.T) tls.Certificate {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "frogger"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+		// Allows the certificate to sign itself
+		BasicConstraintsValid: true,
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	assert.NoError(t, err)
+	leaf, err := x509.ParseCertificate(certificateBytes)
+	assert.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{certificateBytes}, PrivateKey: privateKey, Leaf: leaf}
+}
+
+func TestClientBuilder_RateLimit(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, err := w.Write([]byte(`[{"name": "master"}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	// A burst of a single request, followed by a request every 50 milliseconds
+	rateLimiter := rate.NewLimiter(rate.Every(50*time.Millisecond), 1)
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).RateLimiter(rateLimiter).Build()
+	assert.NoError(t, err)
+	otherClient, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).RateLimiter(rateLimiter).Build()
+	assert.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		_, err = client.ListBranches(ctx, owner, repo1)
+		assert.NoError(t, err)
+		_, err = otherClient.ListBranches(ctx, owner, repo1)
+		assert.NoError(t, err)
+	}
+	// The clients share the rate limiter
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+	assert.Equal(t, 4, requests)
+
+	// The wait for the rate limiter is canceled with the context
+	client, err = NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).RateLimit(0.001, 1).Build()
+	assert.NoError(t, err)
+	_, err = client.ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = client.ListBranches(timeoutCtx, owner, repo1)
+	assert.ErrorContains(t, err, "rate limiter")
+}
@@ -0,0 +1,1398 @@
+package vcsclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-gerrit"
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	// The default maximal size of the comments on Gerrit
+	gerritPrContentSizeLimit = 16384
+	// The default maximal number of changes returned by a query on Gerrit
+	gerritPageSize = 500
+	// The label which is voted by CI systems on Gerrit
+	gerritVerifiedLabel = "Verified"
+	// The label which is voted by reviewers on Gerrit
+	gerritCodeReviewLabel = "Code-Review"
+	// The messages with an autogenerated tag are posted by bots, and aren't listed as pull request comments
+	gerritAutogeneratedTagPrefix = "autogenerated:"
+	gerritCommitStatusTag        = gerritAutogeneratedTagPrefix + "froggit-go"
+	// The format of the dates of approvals on Gerrit
+	gerritApprovalDateFormat = "2006-01-02 15:04:05.000000000"
+	notSupportedOnGerrit     = "not supported on Gerrit"
+)
+
+var (
+	errGerritTokenScopesNotSupported          = fmt.Errorf("verifying token scopes is %s, permissions are granted by access rights", notSupportedOnGerrit)
+	errGerritAppInstallationsNotSupported     = fmt.Errorf("app installations are %s", notSupportedOnGerrit)
+	errGerritBranchProtectionNotSupported     = fmt.Errorf("branch protection is %s, use access rights and submit requirements instead", notSupportedOnGerrit)
+	errGerritReleasesNotSupported             = fmt.Errorf("releases are %s", notSupportedOnGerrit)
+	errGerritSshKeysNotSupported              = fmt.Errorf("repository SSH keys are %s, SSH keys are added to accounts", notSupportedOnGerrit)
+	errGerritWebhooksNotSupported             = fmt.Errorf("webhooks are %s", notSupportedOnGerrit)
+	errGerritDownloadRepositoryNotSupported   = fmt.Errorf("download repository is %s, clone it instead", notSupportedOnGerrit)
+	errGerritAutoMergeNotSupported            = fmt.Errorf("auto-merge is %s, use the Auto-Submit label of the autosubmit plugin instead", notSupportedOnGerrit)
+	errGerritCreatePullRequestNotSupported    = fmt.Errorf("creating pull requests is %s, push the commit to refs/for/<target branch> instead", notSupportedOnGerrit)
+	errGerritEditCommentsNotSupported         = fmt.Errorf("editing and deleting published comments is %s", notSupportedOnGerrit)
+	errGerritCommitsHistoryNotSupported       = fmt.Errorf("listing the commits history is %s", notSupportedOnGerrit)
+	errGerritCompareCommitsNotSupported       = fmt.Errorf("comparing commits is %s", notSupportedOnGerrit)
+	errGerritDirectoryContentsNotSupported    = fmt.Errorf("listing directory contents is %s", notSupportedOnGerrit)
+	errGerritCommitFilesNotSupported          = fmt.Errorf("committing files is %s, push the commit for review instead", notSupportedOnGerrit)
+	errGerritCollaboratorsNotSupported        = fmt.Errorf("repository collaborators are %s, permissions are granted by access rights", notSupportedOnGerrit)
+	errGerritForkNotSupported                 = fmt.Errorf("forking repositories is %s", notSupportedOnGerrit)
+	errGerritRepositoryLabelsNotSupported     = fmt.Errorf("repository labels are %s, pull requests are labeled by hashtags instead", notSupportedOnGerrit)
+	errGerritIssuesNotSupported               = fmt.Errorf("issues are %s", notSupportedOnGerrit)
+	errGerritCodeScanningNotSupported         = fmt.Errorf("code scanning is %s", notSupportedOnGerrit)
+	errGerritCodeInsightsNotSupported         = fmt.Errorf("code insights reports are %s", notSupportedOnGerrit)
+	errGerritCheckRunsNotSupported            = fmt.Errorf("check runs are %s, use commit statuses instead", notSupportedOnGerrit)
+	errGerritEnvironmentsNotSupported         = fmt.Errorf("get repository environment info is %s", notSupportedOnGerrit)
+	errGerritPipelinesNotSupported            = fmt.Errorf("pipelines are %s", notSupportedOnGerrit)
+	errGerritDeploymentsNotSupported          = fmt.Errorf("deployments and environments are %s", notSupportedOnGerrit)
+	errGerritRepositorySecretsNotSupported    = fmt.Errorf("repository secrets and variables are %s", notSupportedOnGerrit)
+	errGerritSecretsNotSupported              = fmt.Errorf("organization and environment secrets are %s", notSupportedOnGerrit)
+	errGerritCommitStatusNotOnCurrentRevision = errors.New("commit statuses can only be set on the current revision of an open change on Gerrit")
+
+	// The first line of the messages of reviews, such as "Patch Set 2: Code-Review+1"
+	gerritPatchSetLineRegexp = regexp.MustCompile(`^Patch Set \d+:[^\n]*\n*`)
+	// The line which counts the file comments of reviews, such as "(2 comments)"
+	gerritCommentsCountLineRegexp = regexp.MustCompile(`^\(\d+ comments?\)\n*`)
+)
+
+// GerritClient API version 3.
+// Gerrit changes are mapped to pull requests, and the projects are identified by the owner and the repository, joined by a slash.
+type GerritClient struct {
+	vcsInfo      VcsInfo
+	logger       vcsutils.Log
+	gerritClient *gerrit.Client
+}
+
+// NewGerritClient create a new GerritClient.
+// The API endpoint is the URL of the Gerrit server, with or without the "/a" suffix.
+// The requests are authenticated by the username and the HTTP password, taken from the token.
+func NewGerritClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GerritClient, error) {
+	vcsInfo.APIEndpoint = strings.TrimSuffix(strings.TrimSuffix(vcsInfo.APIEndpoint, "/"), "/a")
+	baseURL := vcsInfo.APIEndpoint
+	var httpClient *http.Client
+	if vcsInfo.TokenProvider != nil {
+		httpClient = newTokenProviderHttpClient(vcsInfo, func(request *http.Request, token string) {
+			request.SetBasicAuth(vcsInfo.Username, token)
+		})
+		// The authenticated API is served under the "/a" prefix
+		baseURL += "/a"
+	} else {
+		httpClient = newHttpClient(vcsInfo)
+	}
+	gerritClient, err := gerrit.NewClient(context.Background(), baseURL, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	if vcsInfo.TokenProvider == nil && vcsInfo.Token != "" {
+		gerritClient.Authentication.SetBasicAuth(vcsInfo.Username, vcsInfo.Token)
+	}
+	return &GerritClient{vcsInfo: vcsInfo, logger: logger, gerritClient: gerritClient}, nil
+}
+
+// TestConnection on Gerrit
+func (client *GerritClient) TestConnection(ctx context.Context) error {
+	_, _, err := client.gerritClient.Accounts.GetAccount(ctx, "self")
+	return err
+}
+
+// TestConnectionWithScopes on Gerrit.
+// The permissions of Gerrit accounts are granted by access rights, so an error is returned if any scope is required.
+func (client *GerritClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	if err := client.TestConnection(ctx); err != nil {
+		return err
+	}
+	if len(scopes) > 0 {
+		return errGerritTokenScopesNotSupported
+	}
+	return nil
+}
+
+// GetAuthenticatedUser on Gerrit
+func (client *GerritClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	account, _, err := client.gerritClient.Accounts.GetAccount(ctx, "self")
+	if err != nil {
+		return UserInfo{}, err
+	}
+	return UserInfo{Username: account.Username, DisplayName: account.Name, Email: account.Email}, nil
+}
+
+// ListRepositories on Gerrit.
+// The projects are grouped by their parent folders, and the projects which hold the configuration of the server are skipped.
+func (client *GerritClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	projects, _, err := client.gerritClient.Projects.ListProjects(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for name := range *projects {
+		if name == "All-Projects" || name == "All-Users" {
+			continue
+		}
+		owner, repository := path.Split(name)
+		owner = strings.TrimSuffix(owner, "/")
+		results[owner] = append(results[owner], repository)
+	}
+	for owner := range results {
+		sort.Strings(results[owner])
+	}
+	return results, nil
+}
+
+// ListAppInstallations on Gerrit
+func (client *GerritClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, errGerritAppInstallationsNotSupported
+}
+
+// ListInstallationRepositories on Gerrit
+func (client *GerritClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, errGerritAppInstallationsNotSupported
+}
+
+// ListRepositoriesWithOptions on Gerrit.
+// The projects which hold the configuration of the server are skipped, hence all the repositories are listed and the page is taken from them.
+func (client *GerritClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	repositories, err := client.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getRepositoriesPage(repositories, options), nil
+}
+
+// ListBranches on Gerrit
+func (client *GerritClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	branches, _, err := client.gerritClient.Projects.ListBranches(ctx, getGerritProject(owner, repository), nil)
+	if err != nil {
+		return nil, err
+	}
+	var results []string
+	for _, branch := range *branches {
+		// HEAD and refs/meta/config are listed as branches as well
+		if strings.HasPrefix(branch.Ref, "refs/heads/") {
+			results = append(results, strings.TrimPrefix(branch.Ref, "refs/heads/"))
+		}
+	}
+	return results, nil
+}
+
+// ListBranchesWithOptions on Gerrit.
+// The branches are listed together with refs which aren't branches, hence all the branches are listed and the page is taken from them.
+func (client *GerritClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	branches, err := client.ListBranches(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(branches)
+	return getListPage(branches, options), nil
+}
+
+// CreateBranch on Gerrit.
+// The source reference is either a branch name or a commit ID.
+func (client *GerritClient) CreateBranch(ctx context.Context, owner, repository, sourceRef, newBranch string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "sourceRef": sourceRef, "newBranch": newBranch}); err != nil {
+		return err
+	}
+	_, _, err := client.gerritClient.Projects.CreateBranch(ctx, getGerritProject(owner, repository), newBranch, &gerrit.BranchInput{Revision: sourceRef})
+	return err
+}
+
+// DeleteBranch on Gerrit
+func (client *GerritClient) DeleteBranch(ctx context.Context, owner, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return err
+	}
+	_, err := client.gerritClient.Projects.DeleteBranch(ctx, getGerritProject(owner, repository), branch)
+	return err
+}
+
+// GetDefaultBranch on Gerrit.
+// The default branch is the branch the HEAD of the project points to.
+func (client *GerritClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return "", err
+	}
+	head, _, err := client.gerritClient.Projects.GetHEAD(ctx, getGerritProject(owner, repository))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(head, "refs/heads/"), nil
+}
+
+// SetDefaultBranch on Gerrit
+func (client *GerritClient) SetDefaultBranch(ctx context.Context, owner, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return err
+	}
+	_, _, err := client.gerritClient.Projects.SetHEAD(ctx, getGerritProject(owner, repository), &gerrit.HeadInput{Ref: "refs/heads/" + branch})
+	return err
+}
+
+// GetBranchProtection on Gerrit
+func (client *GerritClient) GetBranchProtection(_ context.Context, _, _, _ string) (BranchProtectionInfo, error) {
+	return BranchProtectionInfo{}, errGerritBranchProtectionNotSupported
+}
+
+// SetBranchProtection on Gerrit
+func (client *GerritClient) SetBranchProtection(_ context.Context, _, _, _ string, _ BranchProtectionInfo) error {
+	return errGerritBranchProtectionNotSupported
+}
+
+// ListTags on Gerrit
+func (client *GerritClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	tags, _, err := client.gerritClient.Projects.ListTags(ctx, getGerritProject(owner, repository), nil)
+	if err != nil {
+		return nil, err
+	}
+	var results []TagInfo
+	for _, tag := range *tags {
+		results = append(results, TagInfo{Name: strings.TrimPrefix(tag.Ref, "refs/tags/"), CommitSha: getGerritTagCommit(tag)})
+	}
+	return results, nil
+}
+
+// CreateTag on Gerrit.
+// An annotated tag is created if a message is provided, and a lightweight tag otherwise.
+func (client *GerritClient) CreateTag(ctx context.Context, owner, repository, tagName, sourceRef, message string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "tagName": tagName, "sourceRef": sourceRef}); err != nil {
+		return err
+	}
+	_, _, err := client.gerritClient.Projects.CreateTag(ctx, getGerritProject(owner, repository), tagName, &gerrit.TagInput{
+		Ref:      tagName,
+		Revision: sourceRef,
+		Message:  message,
+	})
+	return err
+}
+
+// GetTagInfo on Gerrit
+func (client *GerritClient) GetTagInfo(ctx context.Context, owner, repository, tagName string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "tagName": tagName}); err != nil {
+		return CommitInfo{}, err
+	}
+	tag, _, err := client.gerritClient.Projects.GetTag(ctx, getGerritProject(owner, repository), tagName)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return client.GetCommitBySha(ctx, owner, repository, getGerritTagCommit(*tag))
+}
+
+// CreateRelease on Gerrit
+func (client *GerritClient) CreateRelease(_ context.Context, _, _, _, _, _ string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errGerritReleasesNotSupported
+}
+
+// ListReleases on Gerrit
+func (client *GerritClient) ListReleases(_ context.Context, _, _ string) ([]ReleaseInfo, error) {
+	return nil, errGerritReleasesNotSupported
+}
+
+// GetLatestRelease on Gerrit
+func (client *GerritClient) GetLatestRelease(_ context.Context, _, _ string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errGerritReleasesNotSupported
+}
+
+// UploadReleaseAsset on Gerrit
+func (client *GerritClient) UploadReleaseAsset(_ context.Context, _, _, _, _ string, _ io.Reader) (string, error) {
+	return "", errGerritReleasesNotSupported
+}
+
+// AddSshKeyToRepository on Gerrit
+func (client *GerritClient) AddSshKeyToRepository(_ context.Context, _, _, _, _ string, _ Permission) error {
+	return errGerritSshKeysNotSupported
+}
+
+// CreateWebhook on Gerrit
+func (client *GerritClient) CreateWebhook(_ context.Context, _, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", errGerritWebhooksNotSupported
+}
+
+// CreateOrUpdateWebhook on Gerrit
+func (client *GerritClient) CreateOrUpdateWebhook(_ context.Context, _, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", errGerritWebhooksNotSupported
+}
+
+// UpdateWebhook on Gerrit
+func (client *GerritClient) UpdateWebhook(_ context.Context, _, _, _, _, _, _ string, _ ...vcsutils.WebhookEvent) error {
+	return errGerritWebhooksNotSupported
+}
+
+// DeleteWebhook on Gerrit
+func (client *GerritClient) DeleteWebhook(_ context.Context, _, _, _ string) error {
+	return errGerritWebhooksNotSupported
+}
+
+// ListWebhooks on Gerrit
+func (client *GerritClient) ListWebhooks(_ context.Context, _, _ string) ([]WebhookInfo, error) {
+	return nil, errGerritWebhooksNotSupported
+}
+
+// GetWebhook on Gerrit
+func (client *GerritClient) GetWebhook(_ context.Context, _, _, _ string) (WebhookInfo, error) {
+	return WebhookInfo{}, errGerritWebhooksNotSupported
+}
+
+// RotateWebhookSecret on Gerrit
+func (client *GerritClient) RotateWebhookSecret(_ context.Context, _, _, _ string) (string, error) {
+	return "", errGerritWebhooksNotSupported
+}
+
+// SetCommitStatus on Gerrit.
+// The status is set by voting on the Verified label of the change whose current revision is the commit,
+// and the title, the description and the details URL are posted as the message of the vote.
+func (client *GerritClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref, title, description, detailsURL string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref}); err != nil {
+		return err
+	}
+	changes, _, err := client.gerritClient.Changes.QueryChanges(ctx, &gerrit.QueryChangeOptions{
+		QueryOptions:  gerrit.QueryOptions{Query: []string{getGerritProjectQuery(owner, repository, "commit:"+ref, "status:open")}},
+		ChangeOptions: gerrit.ChangeOptions{AdditionalFields: []string{"CURRENT_REVISION"}},
+	})
+	if err != nil {
+		return err
+	}
+	for _, change := range *changes {
+		if change.CurrentRevision != ref {
+			continue
+		}
+		var messageLines []string
+		for _, line := range []string{title, description, detailsURL} {
+			if line != "" {
+				messageLines = append(messageLines, line)
+			}
+		}
+		_, _, err = client.gerritClient.Changes.SetReview(ctx, getGerritChangeID(change.Project, change.Number), ref, &gerrit.ReviewInput{
+			Message: strings.Join(messageLines, "\n"),
+			Tag:     gerritCommitStatusTag,
+			Labels:  map[string]int{gerritVerifiedLabel: mapCommitStatusToGerritVote(commitStatus)},
+		})
+		return err
+	}
+	return errGerritCommitStatusNotOnCurrentRevision
+}
+
+// GetCommitStatuses on Gerrit.
+// The statuses are the votes on the Verified label of the changes which include the commit.
+func (client *GerritClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	if err = validateParametersNotBlank(map[string]string{"repository": repository, "ref": ref}); err != nil {
+		return nil, err
+	}
+	changes, _, err := client.gerritClient.Changes.QueryChanges(ctx, &gerrit.QueryChangeOptions{
+		QueryOptions:  gerrit.QueryOptions{Query: []string{getGerritProjectQuery(owner, repository, "commit:"+ref)}},
+		ChangeOptions: gerrit.ChangeOptions{AdditionalFields: []string{"DETAILED_LABELS"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []CommitStatusInfo
+	for _, change := range *changes {
+		for _, approval := range change.Labels[gerritVerifiedLabel].All {
+			// Accounts which can vote, but haven't voted yet, are listed without a date
+			if approval.Date == "" {
+				continue
+			}
+			results = append(results, CommitStatusInfo{
+				State:         mapGerritVoteToCommitStatus(approval.Value),
+				RawState:      formatGerritVote(approval.Value),
+				Context:       gerritVerifiedLabel,
+				Creator:       approval.Username,
+				LastUpdatedAt: parseGerritApprovalDate(approval.Date),
+			})
+		}
+	}
+	return results, nil
+}
+
+// GetCombinedCommitStatus on Gerrit
+func (client *GerritClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	statuses, err := client.GetCommitStatuses(ctx, owner, repository, ref)
+	if err != nil {
+		return Error, err
+	}
+	states := make([]CommitStatus, 0, len(statuses))
+	for _, status := range statuses {
+		states = append(states, status.State)
+	}
+	return combineCommitStatuses(states...), nil
+}
+
+// TriggerPipeline on Gerrit
+func (client *GerritClient) TriggerPipeline(_ context.Context, _, _, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGerritPipelinesNotSupported
+}
+
+// GetLatestPipelineStatus on Gerrit
+func (client *GerritClient) GetLatestPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGerritPipelinesNotSupported
+}
+
+// GetPipelineStatus on Gerrit
+func (client *GerritClient) GetPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGerritPipelinesNotSupported
+}
+
+// TriggerWorkflow on Gerrit
+func (client *GerritClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return errGerritPipelinesNotSupported
+}
+
+// GetWorkflowRunStatus on Gerrit
+func (client *GerritClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGerritPipelinesNotSupported
+}
+
+// QueueBuild on Gerrit
+func (client *GerritClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGerritPipelinesNotSupported
+}
+
+// GetBuildStatus on Gerrit
+func (client *GerritClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGerritPipelinesNotSupported
+}
+
+// DownloadRepository on Gerrit
+func (client *GerritClient) DownloadRepository(_ context.Context, _, _, _, _ string) error {
+	return errGerritDownloadRepositoryNotSupported
+}
+
+// DownloadRepositoryPaths on Gerrit
+func (client *GerritClient) DownloadRepositoryPaths(_ context.Context, _, _, _ string, _ []string, _ string) error {
+	return errGerritDownloadRepositoryNotSupported
+}
+
+func (client *GerritClient) GetPullRequestCommentSizeLimit() int {
+	return gerritPrContentSizeLimit
+}
+
+func (client *GerritClient) GetPullRequestDetailsSizeLimit() int {
+	return gerritPrContentSizeLimit
+}
+
+// CreatePullRequest on Gerrit.
+// Changes are created by pushing commits to the magic refs/for/<target branch> reference, rather than by the API.
+func (client *GerritClient) CreatePullRequest(_ context.Context, _, _, _, _, _, _ string) (PullRequestInfo, error) {
+	return PullRequestInfo{}, errGerritCreatePullRequestNotSupported
+}
+
+// CreatePullRequestWithOptions on Gerrit
+func (client *GerritClient) CreatePullRequestWithOptions(_ context.Context, _, _, _, _, _, _ string, _ CreatePullRequestOptions) (PullRequestInfo, error) {
+	return PullRequestInfo{}, errGerritCreatePullRequestNotSupported
+}
+
+// UpdatePullRequest on Gerrit.
+// The title and the body are the subject and the body of the commit message of the change, whose footer is kept.
+// Closed changes are abandoned, and reopened changes are restored.
+func (client *GerritClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	changeID := getGerritChangeID(getGerritProject(owner, repository), prId)
+	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
+	if title != "" || body != "" {
+		change, err := client.getChange(ctx, changeID, "CURRENT_REVISION", "CURRENT_COMMIT")
+		if err != nil {
+			return err
+		}
+		currentSubject, currentBody, footer := splitGerritCommitMessage(getGerritCurrentCommit(change).Message)
+		if title == "" {
+			title = currentSubject
+		}
+		if body == "" {
+			body = currentBody
+		}
+		if _, err = client.gerritClient.Changes.SetCommitMessage(ctx, changeID, &gerrit.CommitMessageInput{
+			Message: joinGerritCommitMessage(title, body, footer),
+		}); err != nil {
+			return err
+		}
+	}
+	if targetBranchName != "" {
+		if _, _, err := client.gerritClient.Changes.MoveChange(ctx, changeID, &gerrit.MoveInput{DestinationBranch: targetBranchName}); err != nil {
+			return err
+		}
+	}
+	var err error
+	switch state {
+	case vcsutils.Closed:
+		_, _, err = client.gerritClient.Changes.AbandonChange(ctx, changeID, &gerrit.AbandonInput{})
+	case vcsutils.Open:
+		_, _, err = client.gerritClient.Changes.RestoreChange(ctx, changeID, &gerrit.RestoreInput{})
+	}
+	return err
+}
+
+// MergePullRequest on Gerrit.
+// Changes are submitted by the submit type of their project, hence only the merge method of the project is accepted.
+func (client *GerritClient) MergePullRequest(ctx context.Context, owner, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if mergeMethod != vcsutils.MergeMethodMerge {
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	_, _, err := client.gerritClient.Changes.SubmitChange(ctx, getGerritChangeID(getGerritProject(owner, repository), prId), &gerrit.SubmitInput{})
+	return err
+}
+
+// SetPullRequestAutoMerge on Gerrit
+func (client *GerritClient) SetPullRequestAutoMerge(_ context.Context, _, _ string, _ int, _ vcsutils.MergeMethod) error {
+	return errGerritAutoMergeNotSupported
+}
+
+// ListOpenPullRequestsWithBody on Gerrit
+func (client *GerritClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.listAllPullRequests(ctx, owner, repository, "open", true)
+}
+
+// ListOpenPullRequests on Gerrit
+func (client *GerritClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.listAllPullRequests(ctx, owner, repository, "open", false)
+}
+
+// ListOpenPullRequestsWithOptions on Gerrit
+func (client *GerritClient) ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	changes, _, err := client.gerritClient.Changes.QueryChanges(ctx, &gerrit.QueryChangeOptions{
+		QueryOptions:  gerrit.QueryOptions{Query: []string{getGerritProjectQuery(owner, repository, "status:open")}, Limit: perPage},
+		Start:         (page - 1) * perPage,
+		ChangeOptions: gerrit.ChangeOptions{AdditionalFields: getGerritChangeFields(options.WithBody)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The source branch of a change is its current patch set, hence the changes of the page are filtered here
+	var results []PullRequestInfo
+	for _, change := range *changes {
+		pullRequestInfo := client.mapGerritChangeToPullRequestInfo(change, options.WithBody)
+		if options.matches(pullRequestInfo) {
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
+// ListPullRequestsWithState on Gerrit.
+// Abandoned changes are returned as closed pull requests.
+func (client *GerritClient) ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	if err := validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+	status := string(state)
+	if state == vcsutils.Closed {
+		status = "abandoned"
+	}
+	return client.listAllPullRequests(ctx, owner, repository, status, true)
+}
+
+// listAllPullRequests queries the changes of the project with the given status, page by page
+func (client *GerritClient) listAllPullRequests(ctx context.Context, owner, repository, status string, withBody bool) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	var results []PullRequestInfo
+	for start := 0; ; {
+		changes, _, err := client.gerritClient.Changes.QueryChanges(ctx, &gerrit.QueryChangeOptions{
+			QueryOptions:  gerrit.QueryOptions{Query: []string{getGerritProjectQuery(owner, repository, "status:"+status)}, Limit: gerritPageSize},
+			Start:         start,
+			ChangeOptions: gerrit.ChangeOptions{AdditionalFields: getGerritChangeFields(withBody)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range *changes {
+			results = append(results, client.mapGerritChangeToPullRequestInfo(change, withBody))
+		}
+		// Only the last change of a page reports whether there are more changes
+		if len(*changes) == 0 || !(*changes)[len(*changes)-1].MoreChanges {
+			return results, nil
+		}
+		start += len(*changes)
+	}
+}
+
+// GetPullRequestByID on Gerrit.
+// The pull request ID is the number of the change.
+func (client *GerritClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return PullRequestInfo{}, err
+	}
+	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
+	change, err := client.getChange(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestId), getGerritChangeFields(true)...)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return client.mapGerritChangeToPullRequestInfo(*change, true), nil
+}
+
+func (client *GerritClient) getChange(ctx context.Context, changeID string, additionalFields ...string) (*gerrit.ChangeInfo, error) {
+	change, _, err := client.gerritClient.Changes.GetChange(ctx, changeID, &gerrit.ChangeOptions{AdditionalFields: additionalFields})
+	return change, err
+}
+
+// AddPullRequestComment on Gerrit.
+// The comment is posted as the message of a review of the current patch set.
+func (client *GerritClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "content": content}); err != nil {
+		return err
+	}
+	return client.setReview(ctx, owner, repository, pullRequestID, &gerrit.ReviewInput{Message: content})
+}
+
+// AddPullRequestReviewComments on Gerrit.
+// The comments are posted by a single review of the current patch set, and comments without a file path are posted as its message.
+func (client *GerritClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return errors.New(vcsutils.ErrNoCommentsProvided)
+	}
+	review := &gerrit.ReviewInput{Comments: make(map[string][]gerrit.CommentInput)}
+	var messages []string
+	for _, comment := range comments {
+		if comment.NewFilePath == "" {
+			messages = append(messages, comment.Content)
+			continue
+		}
+		review.Comments[comment.NewFilePath] = append(review.Comments[comment.NewFilePath], gerrit.CommentInput{
+			Line:    comment.NewStartLine,
+			Message: comment.Content,
+		})
+	}
+	review.Message = strings.Join(messages, "\n\n")
+	return client.setReview(ctx, owner, repository, pullRequestID, review)
+}
+
+func (client *GerritClient) setReview(ctx context.Context, owner, repository string, pullRequestID int, review *gerrit.ReviewInput) error {
+	_, _, err := client.gerritClient.Changes.SetReview(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID), "current", review)
+	return err
+}
+
+// ListPullRequestReviewComments on Gerrit.
+// The comment IDs are returned as thread IDs, since they aren't numeric.
+func (client *GerritClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	comments, _, err := client.gerritClient.Changes.ListChangeComments(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID))
+	if err != nil {
+		return nil, err
+	}
+	var results []CommentInfo
+	for filePath, fileComments := range *comments {
+		// Comments on the whole patch set aren't anchored to a file
+		if filePath == "/PATCHSET_LEVEL" {
+			continue
+		}
+		for _, comment := range fileComments {
+			commentInfo := CommentInfo{
+				NativeID:       comment.ID,
+				ThreadID:       comment.ID,
+				ParentID:       comment.InReplyTo,
+				Content:        comment.Message,
+				Author:         comment.Author.Name,
+				AuthorUsername: comment.Author.Username,
+				FilePath:       filePath,
+				Line:           comment.Line,
+			}
+			if comment.Updated != nil {
+				commentInfo.Created = comment.Updated.Time
+				commentInfo.Updated = comment.Updated.Time
+			}
+			results = append(results, commentInfo)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].FilePath != results[j].FilePath {
+			return results[i].FilePath < results[j].FilePath
+		}
+		if results[i].Line != results[j].Line {
+			return results[i].Line < results[j].Line
+		}
+		return results[i].Created.Before(results[j].Created)
+	})
+	return results, nil
+}
+
+// DeletePullRequestReviewComments on Gerrit.
+// Published comments can only be deleted by administrators on Gerrit.
+func (client *GerritClient) DeletePullRequestReviewComments(_ context.Context, _, _ string, _ int, _ ...CommentInfo) error {
+	return errGerritEditCommentsNotSupported
+}
+
+// ListPullRequestComments on Gerrit.
+// The comments are the messages of the reviews, without the votes and the count of file comments.
+// The message IDs are returned as thread IDs, since they aren't numeric.
+func (client *GerritClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	change, err := client.getChange(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID), "MESSAGES")
+	if err != nil {
+		return nil, err
+	}
+	var results []CommentInfo
+	for _, message := range change.Messages {
+		if strings.HasPrefix(message.Tag, gerritAutogeneratedTagPrefix) {
+			continue
+		}
+		content := gerritPatchSetLineRegexp.ReplaceAllString(message.Message, "")
+		content = strings.TrimSpace(gerritCommentsCountLineRegexp.ReplaceAllString(content, ""))
+		if content == "" {
+			continue
+		}
+		results = append(results, CommentInfo{
+			NativeID:       message.ID,
+			ThreadID:       message.ID,
+			Content:        content,
+			Author:         message.Author.Name,
+			AuthorUsername: message.Author.Username,
+			Created:        message.Date.Time,
+			Updated:        message.Date.Time,
+		})
+	}
+	return results, nil
+}
+
+// ListPullRequestCommentsWithOptions on Gerrit.
+// The messages of a change are returned together, hence the page is taken from all the matching comments.
+func (client *GerritClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	comments, err := client.ListPullRequestComments(ctx, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return getPullRequestCommentsPage(comments, options), nil
+}
+
+// UpdatePullRequestComment on Gerrit
+func (client *GerritClient) UpdatePullRequestComment(_ context.Context, _, _, _ string, _, _ int) error {
+	return errGerritEditCommentsNotSupported
+}
+
+// DeletePullRequestComment on Gerrit
+func (client *GerritClient) DeletePullRequestComment(_ context.Context, _, _ string, _, _ int) error {
+	return errGerritEditCommentsNotSupported
+}
+
+// SetPullRequestThreadStatus on Gerrit.
+// The thread is resolved or unresolved by a reply to one of its comments, on the patch set of the comment.
+func (client *GerritClient) SetPullRequestThreadStatus(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "thread ID": threadID}); err != nil {
+		return err
+	}
+	changeID := getGerritChangeID(getGerritProject(owner, repository), pullRequestID)
+	comments, _, err := client.gerritClient.Changes.ListChangeComments(ctx, changeID)
+	if err != nil {
+		return err
+	}
+	for filePath, fileComments := range *comments {
+		for _, comment := range fileComments {
+			if comment.ID != threadID {
+				continue
+			}
+			reply := gerrit.CommentInput{InReplyTo: comment.ID, Line: comment.Line, Message: "Done", Unresolved: vcsutils.PointerOf(!resolved)}
+			if !resolved {
+				reply.Message = "Reopened"
+			}
+			review := &gerrit.ReviewInput{Comments: map[string][]gerrit.CommentInput{filePath: {reply}}}
+			_, _, err = client.gerritClient.Changes.SetReview(ctx, changeID, strconv.Itoa(comment.PatchSet), review)
+			return err
+		}
+	}
+	return fmt.Errorf("comment %s wasn't found in change %d", threadID, pullRequestID)
+}
+
+// CreatePullRequestReview on Gerrit.
+// Approving a change votes +1 on its Code-Review label, and requesting changes votes -1, since +2 and -2 require special access rights.
+func (client *GerritClient) CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	if err := validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	review := &gerrit.ReviewInput{Message: body}
+	switch verdict {
+	case vcsutils.ReviewVerdictApprove:
+		review.Labels = map[string]int{gerritCodeReviewLabel: 1}
+	case vcsutils.ReviewVerdictRequestChanges:
+		review.Labels = map[string]int{gerritCodeReviewLabel: -1}
+	}
+	return client.setReview(ctx, owner, repository, pullRequestID, review)
+}
+
+// ListPullRequestReviews on Gerrit.
+// The reviews are the current votes on the Code-Review label of the change.
+func (client *GerritClient) ListPullRequestReviews(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	change, err := client.getChange(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID), "DETAILED_LABELS")
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestReviewInfo
+	for _, approval := range change.Labels[gerritCodeReviewLabel].All {
+		reviewInfo := PullRequestReviewInfo{Reviewer: approval.Username, Submitted: parseGerritApprovalDate(approval.Date)}
+		switch {
+		case approval.Value > 0:
+			reviewInfo.State = vcsutils.ReviewVerdictApprove
+		case approval.Value < 0:
+			reviewInfo.State = vcsutils.ReviewVerdictRequestChanges
+		default:
+			continue
+		}
+		results = append(results, reviewInfo)
+	}
+	return results, nil
+}
+
+// GetLatestCommit on Gerrit
+func (client *GerritClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch}); err != nil {
+		return CommitInfo{}, err
+	}
+	branchInfo, _, err := client.gerritClient.Projects.GetBranch(ctx, getGerritProject(owner, repository), branch)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return client.GetCommitBySha(ctx, owner, repository, branchInfo.Revision)
+}
+
+// GetCommits on Gerrit
+func (client *GerritClient) GetCommits(_ context.Context, _, _, _ string) ([]CommitInfo, error) {
+	return nil, errGerritCommitsHistoryNotSupported
+}
+
+// ListCommits on Gerrit
+func (client *GerritClient) ListCommits(_ context.Context, _, _ string, _ ListCommitsOptions) ([]CommitInfo, error) {
+	return nil, errGerritCommitsHistoryNotSupported
+}
+
+// GetCommitBySha on Gerrit.
+// The commit URL is the first web link of the commit, which is set if a repository browser, such as Gitiles, is configured.
+func (client *GerritClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "sha": sha}); err != nil {
+		return CommitInfo{}, err
+	}
+	commit, _, err := client.gerritClient.Projects.GetCommit(ctx, getGerritProject(owner, repository), sha)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapGerritCommitToCommitInfo(commit), nil
+}
+
+// GetRepositoryInfo on Gerrit.
+// Gerrit projects are reported as private, since their visibility is controlled by access rights, and read-only projects are reported as archived.
+func (client *GerritClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	project, _, err := client.gerritClient.Projects.GetProject(ctx, getGerritProject(owner, repository))
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	repositoryInfo := client.getRepositoryInfo(project)
+	if repositoryInfo.DefaultBranch, err = client.GetDefaultBranch(ctx, owner, repository); err != nil {
+		return RepositoryInfo{}, err
+	}
+	return repositoryInfo, nil
+}
+
+// CreateRepository on Gerrit.
+// An initial empty commit is created if a README is requested, and the visibility is ignored, since it's controlled by access rights.
+func (client *GerritClient) CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return RepositoryInfo{}, err
+	}
+	input := &gerrit.ProjectInput{Description: options.Description, CreateEmptyCommit: options.InitReadme}
+	if options.DefaultBranch != "" {
+		input.Branches = []string{options.DefaultBranch}
+	}
+	project, _, err := client.gerritClient.Projects.CreateProject(ctx, getGerritProject(owner, repository), input)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return client.getRepositoryInfo(project), nil
+}
+
+// DeleteRepository on Gerrit.
+// The project is deleted by the delete-project plugin, which must be installed on the server.
+func (client *GerritClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	_, err := client.gerritClient.Call(ctx, http.MethodPost, "projects/"+neturl.PathEscape(getGerritProject(owner, repository))+"/delete-project~delete", nil, nil)
+	return err
+}
+
+// ForkRepository on Gerrit
+func (client *GerritClient) ForkRepository(_ context.Context, _, _, _ string) (RepositoryInfo, error) {
+	return RepositoryInfo{}, errGerritForkNotSupported
+}
+
+// ListRepositoryCollaborators on Gerrit
+func (client *GerritClient) ListRepositoryCollaborators(_ context.Context, _, _ string) ([]CollaboratorInfo, error) {
+	return nil, errGerritCollaboratorsNotSupported
+}
+
+// GetRepositoryPermission on Gerrit
+func (client *GerritClient) GetRepositoryPermission(_ context.Context, _, _, _ string) (RepositoryPermission, error) {
+	return NoPermission, errGerritCollaboratorsNotSupported
+}
+
+// CreateLabel on Gerrit
+func (client *GerritClient) CreateLabel(_ context.Context, _, _ string, _ LabelInfo) error {
+	return errGerritRepositoryLabelsNotSupported
+}
+
+// GetLabel on Gerrit
+func (client *GerritClient) GetLabel(_ context.Context, _, _, _ string) (*LabelInfo, error) {
+	return nil, errGerritRepositoryLabelsNotSupported
+}
+
+// ListRepositoryLabels on Gerrit
+func (client *GerritClient) ListRepositoryLabels(_ context.Context, _, _ string) ([]LabelInfo, error) {
+	return nil, errGerritRepositoryLabelsNotSupported
+}
+
+// UpdateLabel on Gerrit
+func (client *GerritClient) UpdateLabel(_ context.Context, _, _, _ string, _ LabelInfo) error {
+	return errGerritRepositoryLabelsNotSupported
+}
+
+// DeleteLabel on Gerrit
+func (client *GerritClient) DeleteLabel(_ context.Context, _, _, _ string) error {
+	return errGerritRepositoryLabelsNotSupported
+}
+
+// ListPullRequestLabels on Gerrit.
+// The labels of a pull request are the hashtags of the change.
+func (client *GerritClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	hashtags, _, err := client.gerritClient.Changes.GetHashtags(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID))
+	return hashtags, err
+}
+
+// LabelPullRequest on Gerrit
+func (client *GerritClient) LabelPullRequest(ctx context.Context, owner, repository string, pullRequestID int, labels []string) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return err
+	}
+	_, _, err := client.gerritClient.Changes.SetHashtags(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID), &gerrit.HashtagsInput{Add: labels})
+	return err
+}
+
+// UnlabelPullRequest on Gerrit
+func (client *GerritClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "name": name}); err != nil {
+		return err
+	}
+	_, _, err := client.gerritClient.Changes.SetHashtags(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID), &gerrit.HashtagsInput{Remove: []string{name}})
+	return err
+}
+
+// CreateIssue on Gerrit
+func (client *GerritClient) CreateIssue(_ context.Context, _, _, _, _ string) (IssueInfo, error) {
+	return IssueInfo{}, errGerritIssuesNotSupported
+}
+
+// ListIssues on Gerrit
+func (client *GerritClient) ListIssues(_ context.Context, _, _ string, _ ListIssuesOptions) ([]IssueInfo, error) {
+	return nil, errGerritIssuesNotSupported
+}
+
+// AddIssueComment on Gerrit
+func (client *GerritClient) AddIssueComment(_ context.Context, _, _, _ string, _ int) error {
+	return errGerritIssuesNotSupported
+}
+
+// CloseIssue on Gerrit
+func (client *GerritClient) CloseIssue(_ context.Context, _, _ string, _ int) error {
+	return errGerritIssuesNotSupported
+}
+
+// UploadCodeScanning on Gerrit
+func (client *GerritClient) UploadCodeScanning(_ context.Context, _, _, _, _ string) (string, error) {
+	return "", errGerritCodeScanningNotSupported
+}
+
+// GetRepositoryEnvironmentInfo on Gerrit
+func (client *GerritClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errGerritEnvironmentsNotSupported
+}
+
+// ListEnvironments on Gerrit
+func (client *GerritClient) ListEnvironments(_ context.Context, _, _ string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errGerritDeploymentsNotSupported
+}
+
+// CreateDeployment on Gerrit
+func (client *GerritClient) CreateDeployment(_ context.Context, _, _, _, _, _ string) (DeploymentInfo, error) {
+	return DeploymentInfo{}, errGerritDeploymentsNotSupported
+}
+
+// SetDeploymentStatus on Gerrit
+func (client *GerritClient) SetDeploymentStatus(_ context.Context, _, _, _ string, _ DeploymentState, _, _ string) error {
+	return errGerritDeploymentsNotSupported
+}
+
+// CreateOrUpdateRepositorySecret on Gerrit
+func (client *GerritClient) CreateOrUpdateRepositorySecret(_ context.Context, _, _, _, _ string) error {
+	return errGerritRepositorySecretsNotSupported
+}
+
+// ListRepositoryVariables on Gerrit
+func (client *GerritClient) ListRepositoryVariables(_ context.Context, _, _ string) ([]RepositoryVariableInfo, error) {
+	return nil, errGerritRepositorySecretsNotSupported
+}
+
+// CreateOrUpdateOrganizationSecret on Gerrit
+func (client *GerritClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return errGerritSecretsNotSupported
+}
+
+// ListOrganizationSecrets on Gerrit
+func (client *GerritClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, errGerritSecretsNotSupported
+}
+
+// ListOrganizationSecretRepositories on Gerrit
+func (client *GerritClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, errGerritSecretsNotSupported
+}
+
+// DeleteOrganizationSecret on Gerrit
+func (client *GerritClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return errGerritSecretsNotSupported
+}
+
+// CreateOrUpdateEnvironmentSecret on Gerrit
+func (client *GerritClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return errGerritSecretsNotSupported
+}
+
+// ListEnvironmentSecrets on Gerrit
+func (client *GerritClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, errGerritSecretsNotSupported
+}
+
+// DeleteEnvironmentSecret on Gerrit
+func (client *GerritClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return errGerritSecretsNotSupported
+}
+
+// CreateCodeInsightsReport on Gerrit
+func (client *GerritClient) CreateCodeInsightsReport(_ context.Context, _, _, _ string, _ CodeInsightsReport) error {
+	return errGerritCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on Gerrit
+func (client *GerritClient) AddCodeInsightsAnnotations(_ context.Context, _, _, _, _ string, _ []CodeInsightsAnnotation) error {
+	return errGerritCodeInsightsNotSupported
+}
+
+// CreateCheckRun on Gerrit
+func (client *GerritClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errGerritCheckRunsNotSupported
+}
+
+// UpdateCheckRun on Gerrit
+func (client *GerritClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errGerritCheckRunsNotSupported
+}
+
+// DownloadFileFromRepo on Gerrit.
+// The content of the file is returned base64 encoded by the API.
+func (client *GerritClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository, "branch": branch, "path": path}); err != nil {
+		return nil, 0, err
+	}
+	urlPath := fmt.Sprintf("projects/%s/branches/%s/files/%s/content",
+		neturl.PathEscape(getGerritProject(owner, repository)), neturl.PathEscape(branch), neturl.PathEscape(path))
+	return client.getBase64Content(ctx, urlPath)
+}
+
+// ListDirectoryContents on Gerrit
+func (client *GerritClient) ListDirectoryContents(_ context.Context, _, _, _, _ string) ([]DirectoryEntry, error) {
+	return nil, errGerritDirectoryContentsNotSupported
+}
+
+// CommitFiles on Gerrit
+func (client *GerritClient) CommitFiles(_ context.Context, _, _, _, _ string, _ []FileToCommit) error {
+	return errGerritCommitFilesNotSupported
+}
+
+// GetModifiedFiles on Gerrit
+func (client *GerritClient) GetModifiedFiles(_ context.Context, _, _, _, _ string) ([]string, error) {
+	return nil, errGerritCompareCommitsNotSupported
+}
+
+// CompareCommits on Gerrit
+func (client *GerritClient) CompareCommits(_ context.Context, _, _, _, _ string) (CommitsComparison, error) {
+	return CommitsComparison{}, errGerritCompareCommitsNotSupported
+}
+
+// ListPullRequestFiles on Gerrit.
+// The files of the current patch set are compared to its parent, and the magic commit message and merge list files are skipped.
+func (client *GerritClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	files, _, err := client.gerritClient.Changes.ListFiles(ctx, getGerritChangeID(getGerritProject(owner, repository), pullRequestID), "current", nil)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestFile
+	for filePath, file := range files {
+		if filePath == "/COMMIT_MSG" || filePath == "/MERGE_LIST" {
+			continue
+		}
+		results = append(results, mapGerritFileToPullRequestFile(filePath, file))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+	return results, nil
+}
+
+// GetPullRequestDiff on Gerrit.
+// The diff is the patch of the current patch set.
+func (client *GerritClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return "", err
+	}
+	patch, _, err := client.getBase64Content(ctx, fmt.Sprintf("changes/%s/revisions/current/patch", getGerritChangeID(getGerritProject(owner, repository), pullRequestID)))
+	return string(patch), err
+}
+
+// getBase64Content fetches an API endpoint which returns base64 encoded content, which the Gerrit client library can't decode.
+func (client *GerritClient) getBase64Content(ctx context.Context, urlPath string) ([]byte, int, error) {
+	req, err := client.gerritClient.NewRequest(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	var body bytes.Buffer
+	resp, err := client.gerritClient.Do(req, &body)
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	if err != nil {
+		return nil, statusCode, err
+	}
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(body.String()))
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return content, statusCode, nil
+}
+
+// getRepositoryInfo returns the info of a project, whose HTTP clone URL is authenticated if the client is
+func (client *GerritClient) getRepositoryInfo(project *gerrit.ProjectInfo) RepositoryInfo {
+	cloneURL := client.vcsInfo.APIEndpoint + "/"
+	if client.vcsInfo.Token != "" || client.vcsInfo.TokenProvider != nil {
+		cloneURL += "a/"
+	}
+	return RepositoryInfo{
+		RepositoryVisibility: Private,
+		CloneInfo:            CloneInfo{HTTP: cloneURL + project.Name},
+		Archived:             project.State == "READ_ONLY",
+		Description:          project.Description,
+	}
+}
+
+// getGerritProject returns the name of the project of a repository, such as "jfrog/repo-1".
+// Repositories without an owner are top level projects.
+func getGerritProject(owner, repository string) string {
+	return path.Join(owner, repository)
+}
+
+// getGerritProjectQuery returns a query of changes, which is restricted to the project of a repository
+func getGerritProjectQuery(owner, repository string, operators ...string) string {
+	return strings.Join(append([]string{"project:" + getGerritProject(owner, repository)}, operators...), " ")
+}
+
+// getGerritChangeID returns the unique identifier of a change, which is the project and the number of the change, such as "jfrog%2Frepo-1~12"
+func getGerritChangeID(project string, number int) string {
+	return neturl.PathEscape(project) + "~" + strconv.Itoa(number)
+}
+
+func getGerritChangeFields(withBody bool) []string {
+	if withBody {
+		return []string{"CURRENT_REVISION", "CURRENT_COMMIT"}
+	}
+	return []string{"CURRENT_REVISION"}
+}
+
+// getGerritTagCommit returns the commit of a tag. The revision of annotated tags is the tag object, which points to the commit.
+func getGerritTagCommit(tag gerrit.TagInfo) string {
+	if tag.Object != "" {
+		return tag.Object
+	}
+	return tag.Revision
+}
+
+func getGerritCurrentCommit(change *gerrit.ChangeInfo) gerrit.CommitInfo {
+	if change == nil {
+		return gerrit.CommitInfo{}
+	}
+	return change.Revisions[change.CurrentRevision].Commit
+}
+
+// splitGerritCommitMessage splits a commit message into its subject, its body and its footer, which holds the Change-Id.
+func splitGerritCommitMessage(message string) (subject, body, footer string) {
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	subject = paragraphs[0]
+	paragraphs = paragraphs[1:]
+	if len(paragraphs) > 0 && strings.Contains(paragraphs[len(paragraphs)-1], "Change-Id:") {
+		footer = paragraphs[len(paragraphs)-1]
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+	return subject, strings.Join(paragraphs, "\n\n"), footer
+}
+
+func joinGerritCommitMessage(subject, body, footer string) string {
+	paragraphs := []string{subject}
+	for _, paragraph := range []string{body, footer} {
+		if paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n") + "\n"
+}
+
+func mapCommitStatusToGerritVote(commitStatus CommitStatus) int {
+	switch commitStatus {
+	case Pass:
+		return 1
+	case Fail, Error:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func mapGerritVoteToCommitStatus(vote int) CommitStatus {
+	switch {
+	case vote > 0:
+		return Pass
+	case vote < 0:
+		return Fail
+	default:
+		return InProgress
+	}
+}
+
+// formatGerritVote formats a vote as Gerrit displays it, such as +1 or -2
+func formatGerritVote(vote int) string {
+	if vote > 0 {
+		return "+" + strconv.Itoa(vote)
+	}
+	return strconv.Itoa(vote)
+}
+
+func parseGerritApprovalDate(date string) time.Time {
+	parsedDate, err := time.Parse(gerritApprovalDateFormat, date)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsedDate
+}
+
+func mapGerritChangeState(status string) vcsutils.PullRequestState {
+	switch status {
+	case "MERGED":
+		return vcsutils.Merged
+	case "ABANDONED":
+		return vcsutils.Closed
+	default:
+		return vcsutils.Open
+	}
+}
+
+func (client *GerritClient) mapGerritChangeToPullRequestInfo(change gerrit.ChangeInfo, withBody bool) PullRequestInfo {
+	owner, repository := path.Split(change.Project)
+	owner = strings.TrimSuffix(owner, "/")
+	pullRequestInfo := PullRequestInfo{
+		ID:        int64(change.Number),
+		Title:     change.Subject,
+		URL:       fmt.Sprintf("%s/c/%s/+/%d", client.vcsInfo.APIEndpoint, change.Project, change.Number),
+		Author:    change.Owner.Username,
+		State:     mapGerritChangeState(change.Status),
+		Mergeable: change.Mergeable,
+		// The source of a change is the reference of its current patch set, such as refs/changes/12/12/1
+		Source:    BranchInfo{Name: change.Revisions[change.CurrentRevision].Ref, Repository: repository, Owner: owner},
+		Target:    BranchInfo{Name: change.Branch, Repository: repository, Owner: owner},
+		CreatedAt: change.Created.Time,
+		UpdatedAt: change.Updated.Time,
+	}
+	if withBody {
+		_, pullRequestInfo.Body, _ = splitGerritCommitMessage(getGerritCurrentCommit(&change).Message)
+	}
+	return pullRequestInfo
+}
+
+func mapGerritCommitToCommitInfo(commit *gerrit.CommitInfo) CommitInfo {
+	if commit == nil {
+		return CommitInfo{}
+	}
+	commitInfo := CommitInfo{
+		Hash:          commit.Commit,
+		AuthorName:    commit.Author.Name,
+		AuthorEmail:   commit.Author.Email,
+		CommitterName: commit.Committer.Name,
+		Timestamp:     commit.Committer.Date.Unix(),
+		Message:       commit.Message,
+	}
+	if len(commit.WebLinks) > 0 {
+		commitInfo.Url = commit.WebLinks[0].URL
+	}
+	for _, parent := range commit.Parents {
+		commitInfo.ParentHashes = append(commitInfo.ParentHashes, parent.Commit)
+	}
+	return commitInfo
+}
+
+func mapGerritFileToPullRequestFile(filePath string, file gerrit.FileInfo) PullRequestFile {
+	switch file.Status {
+	case "A":
+		return PullRequestFile{Path: filePath, ChangeType: FileAdded}
+	case "D":
+		return PullRequestFile{Path: filePath, ChangeType: FileDeleted}
+	case "R":
+		return PullRequestFile{Path: filePath, PreviousPath: file.OldPath, ChangeType: FileRenamed}
+	default:
+		return PullRequestFile{Path: filePath, ChangeType: FileModified}
+	}
+}
@@ -0,0 +1,509 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	gerritChangeID = "jfrog%2Frepo-1~12"
+	gerritRevision = "86a0a9a7b1e5f4ad9c2c1c8bb1bd0e4d2ce9d1d6"
+)
+
+// gerritRequest is an expected request to the Gerrit API, and its response
+type gerritRequest struct {
+	method string
+	uri    string
+	// The request body is checked only if it is set
+	requestBody string
+	response    string
+	// The status code of the response, defaults to http.StatusOK
+	statusCode int
+}
+
+func TestGerritClient_Builder(t *testing.T) {
+	client, err := NewClientBuilder(vcsutils.Gerrit).ApiEndpoint("https://gerrit.example.com/a/").Username(username).Token(token).Build()
+	assert.NoError(t, err)
+	gerritClient, _ := client.(*GerritClient)
+	assert.Equal(t, "https://gerrit.example.com", gerritClient.vcsInfo.APIEndpoint)
+}
+
+func TestGerritClient_Connection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/accounts/self", response: `{"_account_id": 1000096, "name": "Frogger", "email": "frogger@example.com", "username": "frogger"}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/accounts/self", response: `{"_account_id": 1000096, "name": "Frogger", "email": "frogger@example.com", "username": "frogger"}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/accounts/self", statusCode: http.StatusUnauthorized, response: "Unauthorized"},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.TestConnection(ctx))
+	userInfo, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: "frogger", DisplayName: "Frogger", Email: "frogger@example.com"}, userInfo)
+	assert.Error(t, client.TestConnection(ctx))
+
+	_, err = client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "title", "body")
+	assert.ErrorIs(t, err, errGerritCreatePullRequestNotSupported)
+	assert.ErrorIs(t, client.UpdatePullRequestComment(ctx, owner, repo1, "content", 12, 1), errGerritEditCommentsNotSupported)
+	assert.ErrorIs(t, client.DownloadRepository(ctx, owner, repo1, branch1, t.TempDir()), errGerritDownloadRepositoryNotSupported)
+	assert.ErrorIs(t, client.DownloadRepositoryPaths(ctx, owner, repo1, branch1, []string{"go.mod"}, t.TempDir()), errGerritDownloadRepositoryNotSupported)
+	assert.ErrorIs(t, client.CreateLabel(ctx, owner, repo1, LabelInfo{Name: "label"}), errGerritRepositoryLabelsNotSupported)
+	_, _, err = client.CreateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com", vcsutils.Push)
+	assert.ErrorIs(t, err, errGerritWebhooksNotSupported)
+	assert.Equal(t, gerritPrContentSizeLimit, client.GetPullRequestCommentSizeLimit())
+}
+
+func TestGerritClient_ListRepositories(t *testing.T) {
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/", response: `{
+			"All-Projects": {"id": "All-Projects"},
+			"All-Users": {"id": "All-Users"},
+			"jfrog/repo-2": {"id": "jfrog%2Frepo-2"},
+			"jfrog/repo-1": {"id": "jfrog%2Frepo-1"},
+			"standalone": {"id": "standalone"}
+		}`},
+	)
+	defer cleanUp()
+
+	repositories, err := client.ListRepositories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"jfrog": {"repo-1", "repo-2"}, "": {"standalone"}}, repositories)
+}
+
+func TestGerritClient_ListRepositoriesWithOptions(t *testing.T) {
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/", response: `{
+			"All-Projects": {"id": "All-Projects"},
+			"jfrog/repo-2": {"id": "jfrog%2Frepo-2"},
+			"jfrog/repo-1": {"id": "jfrog%2Frepo-1"},
+			"standalone": {"id": "standalone"}
+		}`},
+	)
+	defer cleanUp()
+
+	repositories, err := client.ListRepositoriesWithOptions(context.Background(), ListOptions{Page: 2, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"jfrog": {"repo-2"}}, repositories)
+}
+
+func TestGerritClient_Branches(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/branches/", response: `[
+			{"ref": "HEAD", "revision": "master"},
+			{"ref": "refs/meta/config", "revision": "0a9d54ce9d4e2e4f5a5c1b2d3e4f5a6b7c8d9e0f"},
+			{"ref": "refs/heads/master", "revision": "1d7c2e6a9f3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d"},
+			{"ref": "refs/heads/branch-1", "revision": "86a0a9a7b1e5f4ad9c2c1c8bb1bd0e4d2ce9d1d6"}
+		]`},
+		gerritRequest{method: http.MethodPut, uri: "/a/projects/jfrog%2Frepo-1/branches/branch-2", requestBody: `{"revision": "master"}`,
+			response: `{"ref": "refs/heads/branch-2", "revision": "1d7c2e6a9f3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d"}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/HEAD", response: `"refs/heads/master"`},
+		gerritRequest{method: http.MethodPut, uri: "/a/projects/jfrog%2Frepo-1/HEAD", requestBody: `{"ref": "refs/heads/branch-1"}`, response: `"refs/heads/branch-1"`},
+	)
+	defer cleanUp()
+
+	branches, err := client.ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"master", branch1}, branches)
+	assert.NoError(t, client.CreateBranch(ctx, owner, repo1, "master", branch2))
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "master", defaultBranch)
+	assert.NoError(t, client.SetDefaultBranch(ctx, owner, repo1, branch1))
+}
+
+func TestGerritClient_ListOpenPullRequests(t *testing.T) {
+	ctx := context.Background()
+	change := readGerritTestData(t, "change_response.json")
+	client, serverURL, cleanUp := createGerritServerWithUrlAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?n=500&o=CURRENT_REVISION&q=project:jfrog%2Frepo-1+status:open", response: "[" + change + "]"},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?n=500&o=CURRENT_REVISION&o=CURRENT_COMMIT&q=project:jfrog%2Frepo-1+status:abandoned",
+			response: `[{"project": "jfrog/repo-1", "branch": "master", "_number": 10, "status": "ABANDONED", "_more_changes": true}]`},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?n=500&o=CURRENT_REVISION&o=CURRENT_COMMIT&q=project:jfrog%2Frepo-1+status:abandoned&start=1",
+			response: `[{"project": "jfrog/repo-1", "branch": "master", "_number": 11, "status": "ABANDONED"}]`},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?n=10&o=CURRENT_REVISION&q=project:jfrog%2Frepo-1+status:open&start=10", response: "[" + change + "]"},
+	)
+	defer cleanUp()
+
+	pullRequests, err := client.ListOpenPullRequests(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestInfo{{
+		ID:        12,
+		Title:     "Fix the vulnerable dependencies",
+		URL:       serverURL + "/c/jfrog/repo-1/+/12",
+		Author:    "frogger",
+		State:     vcsutils.Open,
+		Mergeable: true,
+		Source:    BranchInfo{Name: "refs/changes/12/12/1", Repository: repo1, Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		CreatedAt: time.Date(2024, 3, 12, 9, 21, 14, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 3, 13, 10, 2, 31, 0, time.UTC),
+	}}, pullRequests)
+
+	pullRequests, err = client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Closed)
+	assert.NoError(t, err)
+	if assert.Len(t, pullRequests, 2) {
+		assert.Equal(t, int64(10), pullRequests[0].ID)
+		assert.Equal(t, int64(11), pullRequests[1].ID)
+		assert.Equal(t, vcsutils.Closed, pullRequests[1].State)
+	}
+
+	pullRequests, err = client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, ListPullRequestsOptions{Page: 2, PerPage: 10, TargetBranch: branch1})
+	assert.NoError(t, err)
+	assert.Empty(t, pullRequests)
+}
+
+func TestGerritClient_GetPullRequestByID(t *testing.T) {
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "?o=CURRENT_REVISION&o=CURRENT_COMMIT", response: readGerritTestData(t, "change_response.json")},
+	)
+	defer cleanUp()
+
+	pullRequest, err := client.GetPullRequestByID(context.Background(), owner, repo1, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "Fix the vulnerable dependencies", pullRequest.Title)
+	assert.Equal(t, "Upgrade the dependencies which have known vulnerabilities.", pullRequest.Body)
+}
+
+func TestGerritClient_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "?o=CURRENT_REVISION&o=CURRENT_COMMIT", response: readGerritTestData(t, "change_response.json")},
+		gerritRequest{method: http.MethodPut, uri: "/a/changes/" + gerritChangeID + "/message",
+			requestBody: `{"message": "Fix the vulnerable dependencies\n\nThe new body\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n", "notify_details": null}`},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/abandon", requestBody: `{}`, response: `{}`},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.UpdatePullRequest(ctx, owner, repo1, "", "The new body", "", 12, vcsutils.Closed))
+}
+
+func TestGerritClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/submit", requestBody: `{}`, response: `{"status": "MERGED"}`},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.MergePullRequest(ctx, owner, repo1, 12, vcsutils.MergeMethodMerge))
+	assert.EqualError(t, client.MergePullRequest(ctx, owner, repo1, 12, vcsutils.MergeMethodSquash), "unsupported merge method: 'squash'")
+}
+
+func TestGerritClient_SetPullRequestAutoMerge(t *testing.T) {
+	client, cleanUp := createGerritServerAndClient(t)
+	defer cleanUp()
+	assert.ErrorIs(t, client.SetPullRequestAutoMerge(context.Background(), owner, repo1, 12, vcsutils.MergeMethodMerge), errGerritAutoMergeNotSupported)
+}
+
+func TestGerritClient_AddPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/revisions/current/review", requestBody: `{"message": "Scan passed"}`, response: `{}`},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/revisions/current/review",
+			requestBody: `{"message": "Summary", "comments": {"go.mod": [{"line": 12, "message": "Vulnerable dependency"}]}}`, response: `{}`},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.AddPullRequestComment(ctx, owner, repo1, "Scan passed", 12))
+	assert.NoError(t, client.AddPullRequestReviewComments(ctx, owner, repo1, 12,
+		PullRequestComment{CommentInfo: CommentInfo{Content: "Summary"}},
+		PullRequestComment{CommentInfo: CommentInfo{Content: "Vulnerable dependency"}, PullRequestDiff: PullRequestDiff{NewFilePath: "go.mod", NewStartLine: 12}},
+	))
+	assert.EqualError(t, client.AddPullRequestReviewComments(ctx, owner, repo1, 12), vcsutils.ErrNoCommentsProvided)
+}
+
+func TestGerritClient_ListPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "?o=MESSAGES", response: readGerritTestData(t, "change_response.json")},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "/comments", response: readGerritTestData(t, "comments_response.json")},
+	)
+	defer cleanUp()
+
+	comments, err := client.ListPullRequestComments(ctx, owner, repo1, 12)
+	assert.NoError(t, err)
+	reviewDate := time.Date(2024, 3, 13, 10, 2, 31, 0, time.UTC)
+	assert.Equal(t, []CommentInfo{{NativeID: "3e7fae0e806b2d4c", ThreadID: "3e7fae0e806b2d4c", Content: "Looks good to me", Author: "Reviewer", AuthorUsername: "reviewer",
+		Created: reviewDate, Updated: reviewDate}}, comments)
+
+	comments, err = client.ListPullRequestReviewComments(ctx, owner, repo1, 12)
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 3) {
+		assert.Equal(t, CommentInfo{NativeID: "d4e5f6a7_3b4c5d6e", ThreadID: "d4e5f6a7_3b4c5d6e", Content: "Document the change", Author: "Frogger", AuthorUsername: "frogger",
+			Created: time.Date(2024, 3, 13, 10, 0, 0, 0, time.UTC), Updated: time.Date(2024, 3, 13, 10, 0, 0, 0, time.UTC), FilePath: "README.md", Line: 1}, comments[0])
+		assert.Equal(t, "go.mod", comments[1].FilePath)
+		assert.Equal(t, 3, comments[1].Line)
+		assert.Equal(t, "Upgrade to the fixed version", comments[2].Content)
+		assert.Equal(t, "c3d4e5f6_2a3b4c5d", comments[2].ParentID)
+	}
+	assert.ErrorIs(t, client.DeletePullRequestReviewComments(ctx, owner, repo1, 12, comments...), errGerritEditCommentsNotSupported)
+}
+
+func TestGerritClient_SetPullRequestThreadStatus(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "/comments", response: readGerritTestData(t, "comments_response.json")},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/revisions/1/review",
+			requestBody: `{"comments": {"go.mod": [{"in_reply_to": "c3d4e5f6_2a3b4c5d", "line": 3, "message": "Done", "unresolved": false}]}}`, response: `{}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "/comments", response: readGerritTestData(t, "comments_response.json")},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/revisions/1/review",
+			requestBody: `{"comments": {"README.md": [{"in_reply_to": "d4e5f6a7_3b4c5d6e", "line": 1, "message": "Reopened", "unresolved": true}]}}`, response: `{}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "/comments", response: readGerritTestData(t, "comments_response.json")},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 12, "c3d4e5f6_2a3b4c5d", true))
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 12, "d4e5f6a7_3b4c5d6e", false))
+	assert.EqualError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 12, "unknown", true), "comment unknown wasn't found in change 12")
+}
+
+func TestGerritClient_PullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/revisions/current/review",
+			requestBody: `{"message": "Fix the tests", "labels": {"Code-Review": -1}}`, response: `{}`},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/revisions/current/review",
+			requestBody: `{"labels": {"Code-Review": 1}}`, response: `{}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "?o=DETAILED_LABELS", response: readGerritTestData(t, "change_response.json")},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.CreatePullRequestReview(ctx, owner, repo1, 12, vcsutils.ReviewVerdictRequestChanges, "Fix the tests"))
+	assert.NoError(t, client.CreatePullRequestReview(ctx, owner, repo1, 12, vcsutils.ReviewVerdictApprove, ""))
+	reviews, err := client.ListPullRequestReviews(ctx, owner, repo1, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewInfo{
+		{Reviewer: "reviewer", State: vcsutils.ReviewVerdictApprove, Submitted: time.Date(2024, 3, 13, 10, 2, 31, 0, time.UTC)},
+		{Reviewer: "rejecter", State: vcsutils.ReviewVerdictRequestChanges, Submitted: time.Date(2024, 3, 13, 9, 55, 2, 0, time.UTC)},
+	}, reviews)
+}
+
+func TestGerritClient_CommitStatus(t *testing.T) {
+	ctx := context.Background()
+	change := readGerritTestData(t, "change_response.json")
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?o=CURRENT_REVISION&q=project:jfrog%2Frepo-1+commit:" + gerritRevision + "+status:open", response: "[" + change + "]"},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/revisions/" + gerritRevision + "/review",
+			requestBody: `{"message": "Frogbot scan\nNo issues found\nhttps://jfrog.com", "tag": "autogenerated:froggit-go", "labels": {"Verified": 1}}`, response: `{}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?o=CURRENT_REVISION&q=project:jfrog%2Frepo-1+commit:1d7c2e6a+status:open", response: "[" + change + "]"},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?o=DETAILED_LABELS&q=project:jfrog%2Frepo-1+commit:" + gerritRevision, response: "[" + change + "]"},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/?o=DETAILED_LABELS&q=project:jfrog%2Frepo-1+commit:" + gerritRevision, response: "[" + change + "]"},
+	)
+	defer cleanUp()
+
+	assert.NoError(t, client.SetCommitStatus(ctx, Pass, owner, repo1, gerritRevision, "Frogbot scan", "No issues found", "https://jfrog.com"))
+	assert.ErrorIs(t, client.SetCommitStatus(ctx, Fail, owner, repo1, "1d7c2e6a", "Frogbot scan", "", ""), errGerritCommitStatusNotOnCurrentRevision)
+
+	statuses, err := client.GetCommitStatuses(ctx, owner, repo1, gerritRevision)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommitStatusInfo{{State: Pass, RawState: "+1", Context: "Verified", Creator: "ci", LastUpdatedAt: time.Date(2024, 3, 12, 9, 30, 0, 0, time.UTC)}}, statuses)
+	combinedStatus, err := client.GetCombinedCommitStatus(ctx, owner, repo1, gerritRevision)
+	assert.NoError(t, err)
+	assert.Equal(t, Pass, combinedStatus)
+}
+
+func TestGerritClient_Pipelines(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t)
+	defer cleanUp()
+	_, err := client.TriggerPipeline(ctx, owner, repo1, branch1, nil)
+	assert.ErrorIs(t, err, errGerritPipelinesNotSupported)
+	_, err = client.GetLatestPipelineStatus(ctx, owner, repo1, branch1)
+	assert.ErrorIs(t, err, errGerritPipelinesNotSupported)
+	assert.ErrorIs(t, client.TriggerWorkflow(ctx, owner, repo1, "build.yml", branch1, nil), errGerritPipelinesNotSupported)
+	_, err = client.CreateDeployment(ctx, owner, repo1, branch1, envName, "")
+	assert.ErrorIs(t, err, errGerritDeploymentsNotSupported)
+	assert.ErrorIs(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"), errGerritRepositorySecretsNotSupported)
+	assert.ErrorIs(t, client.CreateOrUpdateEnvironmentSecret(ctx, owner, repo1, envName, "JF_TOKEN", "secret-value"), errGerritSecretsNotSupported)
+}
+
+func TestGerritClient_Tags(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/tags/", response: `[
+			{"ref": "refs/tags/v1.0.0", "revision": "1d7c2e6a9f3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d"},
+			{"ref": "refs/tags/v1.1.0", "revision": "5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f", "object": "` + gerritRevision + `", "message": "Release"}
+		]`},
+		gerritRequest{method: http.MethodPut, uri: "/a/projects/jfrog%2Frepo-1/tags/v1.2.0", requestBody: `{"ref": "v1.2.0", "revision": "master", "message": "Release"}`, response: `{}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/tags/v1.1.0",
+			response: `{"ref": "refs/tags/v1.1.0", "revision": "5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f", "object": "` + gerritRevision + `"}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/commits/" + gerritRevision, response: readGerritTestData(t, "commit_response.json")},
+	)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitSha: "1d7c2e6a9f3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d"}, {Name: "v1.1.0", CommitSha: gerritRevision}}, tags)
+	assert.NoError(t, client.CreateTag(ctx, owner, repo1, "v1.2.0", "master", "Release"))
+	commitInfo, err := client.GetTagInfo(ctx, owner, repo1, "v1.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, gerritRevision, commitInfo.Hash)
+}
+
+func TestGerritClient_GetLatestCommit(t *testing.T) {
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/branches/master", response: `{"ref": "refs/heads/master", "revision": "` + gerritRevision + `"}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/commits/" + gerritRevision, response: readGerritTestData(t, "commit_response.json")},
+	)
+	defer cleanUp()
+
+	commitInfo, err := client.GetLatestCommit(context.Background(), owner, repo1, "master")
+	assert.NoError(t, err)
+	assert.Equal(t, CommitInfo{
+		Hash:          gerritRevision,
+		AuthorName:    "Frogger",
+		CommitterName: "Gerrit Code Review",
+		Url:           "https://gerrit.example.com/plugins/gitiles/jfrog/repo-1/+/" + gerritRevision,
+		Timestamp:     time.Date(2024, 3, 14, 8, 0, 0, 0, time.UTC).Unix(),
+		Message:       "Fix the vulnerable dependencies\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n",
+		ParentHashes:  []string{"1d7c2e6a9f3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d"},
+		AuthorEmail:   "frogger@example.com",
+	}, commitInfo)
+}
+
+func TestGerritClient_Repository(t *testing.T) {
+	ctx := context.Background()
+	client, serverURL, cleanUp := createGerritServerWithUrlAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1", response: `{"id": "jfrog%2Frepo-1", "name": "jfrog/repo-1", "description": "Frogs", "state": "READ_ONLY"}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/HEAD", response: `"refs/heads/master"`},
+		gerritRequest{method: http.MethodPut, uri: "/a/projects/jfrog%2Frepo-2/", requestBody: `{"description": "Repository", "create_empty_commit": true, "branches": ["main"], "permissions_only": false}`,
+			response: `{"id": "jfrog%2Frepo-2", "name": "jfrog/repo-2"}`},
+		gerritRequest{method: http.MethodPost, uri: "/a/projects/jfrog%2Frepo-2/delete-project~delete"},
+	)
+	defer cleanUp()
+
+	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryInfo{
+		RepositoryVisibility: Private,
+		CloneInfo:            CloneInfo{HTTP: serverURL + "/a/jfrog/repo-1"},
+		DefaultBranch:        "master",
+		Archived:             true,
+		Description:          "Frogs",
+	}, repositoryInfo)
+	repositoryInfo, err = client.CreateRepository(ctx, owner, repo2, CreateRepositoryOptions{Description: "Repository", DefaultBranch: "main", InitReadme: true})
+	assert.NoError(t, err)
+	assert.Equal(t, serverURL+"/a/jfrog/repo-2", repositoryInfo.CloneInfo.HTTP)
+	assert.NoError(t, client.DeleteRepository(ctx, owner, repo2))
+}
+
+func TestGerritClient_Files(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/branches/master/files/docs%2FREADME.md/content",
+			response: base64.StdEncoding.EncodeToString([]byte("# Repo 1\n"))},
+		gerritRequest{method: http.MethodGet, uri: "/a/projects/jfrog%2Frepo-1/branches/master/files/missing.md/content", statusCode: http.StatusNotFound, response: "Not found: missing.md"},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "/revisions/current/files/", response: `{
+			"/COMMIT_MSG": {"status": "A", "lines_inserted": 7, "size_delta": 551, "size": 551},
+			"go.mod": {"lines_inserted": 1, "lines_deleted": 1, "size_delta": 0, "size": 120},
+			"docs/README.md": {"status": "R", "old_path": "README.md", "size_delta": 0, "size": 10},
+			"old.go": {"status": "D", "size_delta": -20, "size": 0},
+			"new.go": {"status": "A", "size_delta": 20, "size": 20}
+		}`},
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "/revisions/current/patch",
+			response: base64.StdEncoding.EncodeToString([]byte("diff --git a/go.mod b/go.mod\n"))},
+	)
+	defer cleanUp()
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, "master", "docs/README.md")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "# Repo 1\n", string(content))
+	_, statusCode, err = client.DownloadFileFromRepo(ctx, owner, repo1, "master", "missing.md")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "docs/README.md", PreviousPath: "README.md", ChangeType: FileRenamed},
+		{Path: "go.mod", ChangeType: FileModified},
+		{Path: "new.go", ChangeType: FileAdded},
+		{Path: "old.go", ChangeType: FileDeleted},
+	}, files)
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "diff --git a/go.mod b/go.mod\n", diff)
+}
+
+func TestGerritClient_PullRequestLabels(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createGerritServerAndClient(t,
+		gerritRequest{method: http.MethodGet, uri: "/a/changes/" + gerritChangeID + "/hashtags", response: `["security"]`},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/hashtags", requestBody: `{"add": ["frogbot"]}`, response: `["frogbot", "security"]`},
+		gerritRequest{method: http.MethodPost, uri: "/a/changes/" + gerritChangeID + "/hashtags", requestBody: `{"remove": ["security"]}`, response: `["frogbot"]`},
+	)
+	defer cleanUp()
+
+	labels, err := client.ListPullRequestLabels(ctx, owner, repo1, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"security"}, labels)
+	assert.NoError(t, client.LabelPullRequest(ctx, owner, repo1, 12, []string{"frogbot"}))
+	assert.NoError(t, client.UnlabelPullRequest(ctx, owner, repo1, "security", 12))
+}
+
+// createGerritServerAndClient creates a server which expects the given requests in order, and a client of the server
+func createGerritServerAndClient(t *testing.T, requests ...gerritRequest) (VcsClient, func()) {
+	client, _, cleanUp := createGerritServerWithUrlAndClient(t, requests...)
+	return client, cleanUp
+}
+
+func createGerritServerWithUrlAndClient(t *testing.T, requests ...gerritRequest) (VcsClient, string, func()) {
+	var requestIndex int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Less(t, requestIndex, len(requests), "unexpected request") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		expected := requests[requestIndex]
+		requestIndex++
+
+		assert.Equal(t, expected.method, r.Method)
+		assert.Equal(t, expected.uri, r.RequestURI)
+		actualUsername, actualPassword, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, username, actualUsername)
+		assert.Equal(t, token, actualPassword)
+		if expected.requestBody != "" {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, expected.requestBody, string(body))
+		}
+
+		if expected.statusCode != 0 {
+			w.WriteHeader(expected.statusCode)
+		}
+		// The JSON responses of Gerrit are prefixed by a magic line, which prevents cross-site script inclusion
+		response := expected.response
+		if expected.statusCode == 0 && response != "" && strings.ContainsAny(response[:1], `{["`) {
+			response = ")]}'\n" + response
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	client, err := NewClientBuilder(vcsutils.Gerrit).ApiEndpoint(server.URL).Username(username).Token(token).Build()
+	assert.NoError(t, err)
+	return client, server.URL, func() {
+		server.Close()
+		assert.Equal(t, len(requests), requestIndex, "not all the expected requests were sent")
+	}
+}
+
+func readGerritTestData(t *testing.T, filename string) string {
+	content, err := os.ReadFile(filepath.Join("testdata", "gerrit", filename))
+	assert.NoError(t, err)
+	return string(content)
+}
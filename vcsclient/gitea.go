@@ -0,0 +1,2408 @@
+package vcsclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/gofrog/datastructures"
+)
+
+const (
+	// Gitea doesn't limit the size of the pull request details and comments, hence the limit of GitHub, whose API Gitea follows, is used
+	giteaPrContentSizeLimit = 65536
+	// The default maximal page size of the Gitea API
+	giteaPageSize = 50
+)
+
+var (
+	errGiteaTokenScopesNotSupported            = errors.New("verifying token scopes is not supported on Gitea")
+	errGiteaAppInstallationsNotSupported       = errors.New("app installations are not supported on Gitea")
+	errGiteaCodeScanningNotSupported           = errors.New("code scanning is not supported on Gitea")
+	errGiteaCodeInsightsNotSupported           = errors.New("code insights reports are not supported on Gitea")
+	errGiteaCheckRunsNotSupported              = errors.New("check runs are not supported on Gitea, use commit statuses instead")
+	errGiteaGetRepoEnvironmentInfoNotSupported = errors.New("get repository environment info is not supported on Gitea")
+	errGiteaThreadStatusNotSupported           = errors.New("resolving comment threads is not supported by the Gitea API")
+	errGiteaPipelinesNotSupported              = errors.New("pipelines are not supported on Gitea")
+	errGiteaDeploymentsNotSupported            = errors.New("deployments and environments are not supported on Gitea")
+	errGiteaRepositorySecretsNotSupported      = errors.New("repository secrets and variables are not supported on Gitea")
+	errGiteaSecretsNotSupported                = errors.New("organization and environment secrets are not supported on Gitea")
+)
+
+// GiteaClient API version 1, which is supported by Forgejo as well
+type GiteaClient struct {
+	vcsInfo VcsInfo
+	logger  vcsutils.Log
+}
+
+// NewGiteaClient create a new GiteaClient.
+// The API endpoint is the URL of the Gitea server, with or without the "/api/v1" suffix.
+func NewGiteaClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GiteaClient, error) {
+	vcsInfo.APIEndpoint = strings.TrimSuffix(strings.TrimSuffix(vcsInfo.APIEndpoint, "/"), "/api/v1")
+	return &GiteaClient{vcsInfo: vcsInfo, logger: logger}, nil
+}
+
+// buildGiteaClient creates a Gitea client, which sends the requests in the given context.
+// The version of the server isn't queried, since the client library checks it before each request otherwise.
+func (client *GiteaClient) buildGiteaClient(ctx context.Context) (*gitea.Client, error) {
+	options := []gitea.ClientOption{gitea.SetContext(ctx), gitea.SetGiteaVersion(""), gitea.SetHTTPClient(client.buildHTTPClient())}
+	if client.vcsInfo.TokenProvider == nil && client.vcsInfo.Token != "" {
+		options = append(options, gitea.SetToken(client.vcsInfo.Token))
+	}
+	return gitea.NewClient(client.vcsInfo.APIEndpoint, options...)
+}
+
+func (client *GiteaClient) buildHTTPClient() *http.Client {
+	if client.vcsInfo.TokenProvider != nil {
+		return newTokenProviderHttpClient(client.vcsInfo, setBearerAuthorization)
+	}
+	return newHttpClient(client.vcsInfo)
+}
+
+// sendRequest sends a request to an API endpoint which isn't supported by the Gitea client library.
+// The request body is sent only if requestBody isn't nil, and the response body is decoded only if responseBody isn't nil.
+func (client *GiteaClient) sendRequest(ctx context.Context, method, path string, requestBody, responseBody interface{}) (err error) {
+	body := new(bytes.Buffer)
+	if requestBody != nil {
+		if err = json.NewEncoder(body).Encode(requestBody); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, client.vcsInfo.APIEndpoint+"/api/v1"+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client.vcsInfo.TokenProvider == nil && client.vcsInfo.Token != "" {
+		req.Header.Set("Authorization", "token "+client.vcsInfo.Token)
+	}
+
+	response, err := client.buildHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, vcsutils.DiscardResponseBody(response), response.Body.Close())
+	}()
+
+	if response.StatusCode >= 300 {
+		var bodyBytes []byte
+		bodyBytes, err = io.ReadAll(response.Body)
+		if err != nil {
+			return
+		}
+		return fmt.Errorf("status: %v, body: %s", response.Status, bodyBytes)
+	}
+	if responseBody != nil {
+		return json.NewDecoder(response.Body).Decode(responseBody)
+	}
+	return nil
+}
+
+// TestConnection on Gitea
+func (client *GiteaClient) TestConnection(ctx context.Context) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.GetMyUserInfo()
+	return err
+}
+
+// TestConnectionWithScopes on Gitea.
+// The scopes of Gitea tokens can't be queried with the token itself, so an error is returned if any scope is required.
+func (client *GiteaClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	if err := client.TestConnection(ctx); err != nil {
+		return err
+	}
+	if len(scopes) > 0 {
+		return errGiteaTokenScopesNotSupported
+	}
+	return nil
+}
+
+// GetAuthenticatedUser on Gitea
+func (client *GiteaClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return UserInfo{}, err
+	}
+	user, _, err := giteaClient.GetMyUserInfo()
+	if err != nil {
+		return UserInfo{}, err
+	}
+	return UserInfo{Username: user.UserName, DisplayName: user.FullName, Email: user.Email}, nil
+}
+
+// ListRepositories on Gitea
+func (client *GiteaClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for nextPage := 1; nextPage != 0; {
+		repositories, response, err := giteaClient.ListMyRepos(gitea.ListReposOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}})
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range repositories {
+			if repository.Owner == nil {
+				continue
+			}
+			owner := repository.Owner.UserName
+			results[owner] = append(results[owner], repository.Name)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// ListRepositoriesWithOptions on Gitea
+func (client *GiteaClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	repositories, _, err := giteaClient.ListMyRepos(gitea.ListReposOptions{ListOptions: gitea.ListOptions{Page: page, PageSize: perPage}})
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for _, repository := range repositories {
+		if repository.Owner == nil {
+			continue
+		}
+		owner := repository.Owner.UserName
+		results[owner] = append(results[owner], repository.Name)
+	}
+	return results, nil
+}
+
+// ListAppInstallations on Gitea
+func (client *GiteaClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, errGiteaAppInstallationsNotSupported
+}
+
+// ListInstallationRepositories on Gitea
+func (client *GiteaClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, errGiteaAppInstallationsNotSupported
+}
+
+// ListBranches on Gitea
+func (client *GiteaClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []string
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListRepoBranchesOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		branches, response, err := giteaClient.ListRepoBranches(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			results = append(results, branch.Name)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// ListBranchesWithOptions on Gitea
+func (client *GiteaClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	branches, _, err := giteaClient.ListRepoBranches(owner, repository, gitea.ListRepoBranchesOptions{ListOptions: gitea.ListOptions{Page: page, PageSize: perPage}})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		results = append(results, branch.Name)
+	}
+	return results, nil
+}
+
+// CreateBranch on Gitea.
+// The source ref must be a branch, since Gitea creates branches only from other branches.
+func (client *GiteaClient) CreateBranch(ctx context.Context, owner, repository, sourceRef, newBranch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sourceRef": sourceRef, "newBranch": newBranch})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateBranch(owner, repository, gitea.CreateBranchOption{BranchName: newBranch, OldBranchName: sourceRef})
+	return err
+}
+
+// DeleteBranch on Gitea
+func (client *GiteaClient) DeleteBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.DeleteRepoBranch(owner, repository, branch)
+	return err
+}
+
+// GetDefaultBranch on Gitea
+func (client *GiteaClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return "", err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	repo, _, err := giteaClient.GetRepo(owner, repository)
+	if err != nil {
+		return "", err
+	}
+	return repo.DefaultBranch, nil
+}
+
+// SetDefaultBranch on Gitea
+func (client *GiteaClient) SetDefaultBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.EditRepo(owner, repository, gitea.EditRepoOption{DefaultBranch: &branch})
+	return err
+}
+
+// GetBranchProtection on Gitea.
+// The protection rule is looked up by the name of the branch.
+func (client *GiteaClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (BranchProtectionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	protection, response, err := giteaClient.GetBranchProtection(owner, repository, branch)
+	if err != nil {
+		if isGiteaNotFound(response) {
+			return BranchProtectionInfo{}, nil
+		}
+		return BranchProtectionInfo{}, err
+	}
+
+	branchProtection := BranchProtectionInfo{
+		RequiredApprovals: int(protection.RequiredApprovals),
+		RestrictPushes:    !protection.EnablePush,
+	}
+	if protection.EnableStatusCheck {
+		branchProtection.RequiredStatusChecks = protection.StatusCheckContexts
+	}
+	return branchProtection, nil
+}
+
+// SetBranchProtection on Gitea.
+// The protection rule of the branch is created, or replaced if it already exists.
+func (client *GiteaClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtectionInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	enablePush := !protection.RestrictPushes
+	enableStatusCheck := len(protection.RequiredStatusChecks) > 0
+	requiredApprovals := int64(protection.RequiredApprovals)
+
+	_, response, err := giteaClient.GetBranchProtection(owner, repository, branch)
+	if err != nil {
+		if !isGiteaNotFound(response) {
+			return err
+		}
+		_, _, err = giteaClient.CreateBranchProtection(owner, repository, gitea.CreateBranchProtectionOption{
+			BranchName:          branch,
+			EnablePush:          enablePush,
+			EnableStatusCheck:   enableStatusCheck,
+			StatusCheckContexts: protection.RequiredStatusChecks,
+			RequiredApprovals:   requiredApprovals,
+		})
+		return err
+	}
+	_, _, err = giteaClient.EditBranchProtection(owner, repository, branch, gitea.EditBranchProtectionOption{
+		EnablePush:          &enablePush,
+		EnableStatusCheck:   &enableStatusCheck,
+		StatusCheckContexts: protection.RequiredStatusChecks,
+		RequiredApprovals:   &requiredApprovals,
+	})
+	return err
+}
+
+// ListTags on Gitea
+func (client *GiteaClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TagInfo
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListRepoTagsOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		tags, response, err := giteaClient.ListRepoTags(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagInfo := TagInfo{Name: tag.Name}
+			if tag.Commit != nil {
+				tagInfo.CommitSha = tag.Commit.SHA
+			}
+			results = append(results, tagInfo)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// CreateTag on Gitea.
+// An annotated tag is created if a message is given, otherwise a lightweight tag is created.
+func (client *GiteaClient) CreateTag(ctx context.Context, owner, repository, tagName, sourceRef, message string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName, "sourceRef": sourceRef})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateTag(owner, repository, gitea.CreateTagOption{TagName: tagName, Target: sourceRef, Message: message})
+	return err
+}
+
+// GetTagInfo on Gitea
+func (client *GiteaClient) GetTagInfo(ctx context.Context, owner, repository, tagName string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	tag, _, err := giteaClient.GetTag(owner, repository, tagName)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if tag.Commit == nil {
+		return CommitInfo{}, fmt.Errorf("couldn't find the commit of tag %s", tagName)
+	}
+	commit, _, err := giteaClient.GetSingleCommit(owner, repository, tag.Commit.SHA)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapGiteaCommitToCommitInfo(commit), nil
+}
+
+// CreateRelease on Gitea.
+// Gitea requires a release name, hence the tag name is used if no name is given.
+func (client *GiteaClient) CreateRelease(ctx context.Context, owner, repository, tagName, name, releaseNotes string) (ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	if name == "" {
+		name = tagName
+	}
+	release, _, err := giteaClient.CreateRelease(owner, repository, gitea.CreateReleaseOption{TagName: tagName, Title: name, Note: releaseNotes})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	return mapGiteaReleaseToReleaseInfo(release), nil
+}
+
+// ListReleases on Gitea
+func (client *GiteaClient) ListReleases(ctx context.Context, owner, repository string) ([]ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ReleaseInfo
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListReleasesOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		releases, response, err := giteaClient.ListReleases(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			results = append(results, mapGiteaReleaseToReleaseInfo(release))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetLatestRelease on Gitea.
+// Returns the latest published release, excluding drafts and pre-releases.
+func (client *GiteaClient) GetLatestRelease(ctx context.Context, owner, repository string) (ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	releases, _, err := giteaClient.ListReleases(owner, repository, gitea.ListReleasesOptions{
+		ListOptions:  gitea.ListOptions{Page: 1, PageSize: 1},
+		IsDraft:      vcsutils.PointerOf(false),
+		IsPreRelease: vcsutils.PointerOf(false),
+	})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	if len(releases) == 0 {
+		return ReleaseInfo{}, fmt.Errorf("couldn't find any release in repository %s", repository)
+	}
+	return mapGiteaReleaseToReleaseInfo(releases[0]), nil
+}
+
+// UploadReleaseAsset on Gitea
+func (client *GiteaClient) UploadReleaseAsset(ctx context.Context, owner, repository, releaseID, name string, asset io.Reader) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "releaseID": releaseID, "name": name})
+	if err != nil {
+		return "", err
+	}
+	id, err := strconv.ParseInt(releaseID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	attachment, _, err := giteaClient.CreateReleaseAttachment(owner, repository, id, asset, name)
+	if err != nil {
+		return "", err
+	}
+	return attachment.DownloadURL, nil
+}
+
+func mapGiteaReleaseToReleaseInfo(release *gitea.Release) ReleaseInfo {
+	return ReleaseInfo{
+		ID:        strconv.FormatInt(release.ID, 10),
+		Name:      release.Title,
+		TagName:   release.TagName,
+		Body:      release.Note,
+		URL:       release.HTMLURL,
+		CreatedAt: release.CreatedAt,
+	}
+}
+
+// AddSshKeyToRepository on Gitea
+func (client *GiteaClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"key name":   keyName,
+		"public key": publicKey,
+	})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateDeployKey(owner, repository, gitea.CreateKeyOption{
+		Title:    keyName,
+		Key:      publicKey,
+		ReadOnly: permission != ReadWrite,
+	})
+	return err
+}
+
+// CreateWebhook on Gitea.
+// The webhook isn't filtered by the branch, since Gitea would filter out the tag events as well.
+func (client *GiteaClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	token := vcsutils.CreateToken()
+	hook, _, err := giteaClient.CreateRepoHook(owner, repository, gitea.CreateHookOption{
+		Type:   gitea.HookTypeGitea,
+		Config: createGiteaHookConfig(payloadURL, token),
+		Events: getGiteaWebhookEvents(webhookEvents...),
+		Active: true,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return strconv.FormatInt(hook.ID, 10), token, nil
+}
+
+// CreateOrUpdateWebhook on Gitea
+func (client *GiteaClient) CreateOrUpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	return createOrUpdateWebhook(ctx, client, owner, repository, branch, payloadURL, webhookEvents...)
+}
+
+// UpdateWebhook on Gitea
+func (client *GiteaClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	id, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.EditRepoHook(owner, repository, id, gitea.EditHookOption{
+		Config: createGiteaHookConfig(payloadURL, token),
+		Events: getGiteaWebhookEvents(webhookEvents...),
+		Active: vcsutils.PointerOf(true),
+	})
+	return err
+}
+
+// DeleteWebhook on Gitea
+func (client *GiteaClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	id, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteRepoHook(owner, repository, id)
+	return err
+}
+
+// ListWebhooks on Gitea
+func (client *GiteaClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []WebhookInfo
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListHooksOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		hooks, response, err := giteaClient.ListRepoHooks(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			results = append(results, mapGiteaHookToWebhookInfo(hook))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetWebhook on Gitea
+func (client *GiteaClient) GetWebhook(ctx context.Context, owner, repository, webhookID string) (WebhookInfo, error) {
+	id, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	hook, _, err := giteaClient.GetRepoHook(owner, repository, id)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	return mapGiteaHookToWebhookInfo(hook), nil
+}
+
+// RotateWebhookSecret on Gitea
+func (client *GiteaClient) RotateWebhookSecret(ctx context.Context, owner, repository, webhookID string) (string, error) {
+	id, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	hook, _, err := giteaClient.GetRepoHook(owner, repository, id)
+	if err != nil {
+		return "", err
+	}
+	// The omitted settings of the webhook remain unchanged
+	token := vcsutils.CreateToken()
+	if _, err = giteaClient.EditRepoHook(owner, repository, id, gitea.EditHookOption{Config: createGiteaHookConfig(hook.Config["url"], token)}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func createGiteaHookConfig(payloadURL, token string) map[string]string {
+	return map[string]string{
+		"url":          payloadURL,
+		"content_type": "json",
+		"secret":       token,
+	}
+}
+
+// getGiteaWebhookEvents returns the Gitea webhook events, which are named after the GitHub webhook events.
+// Gitea sends push events for created and deleted tags, but only a delete event for deleted branches.
+func getGiteaWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
+	events := datastructures.MakeSet[string]()
+	for _, event := range webhookEvents {
+		switch event {
+		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrRejected, vcsutils.PrMerged, vcsutils.PrClosed, vcsutils.PrReopened:
+			events.Add("pull_request")
+			events.Add("pull_request_sync")
+		case vcsutils.PrCommentCreated, vcsutils.PrCommentEdited:
+			events.Add("pull_request_comment")
+		case vcsutils.IssueCommentCreated:
+			events.Add("issue_comment")
+		case vcsutils.Push, vcsutils.TagPushed, vcsutils.TagRemoved:
+			events.Add("push")
+		case vcsutils.BranchDeleted:
+			events.Add("delete")
+		case vcsutils.ReleaseCreated, vcsutils.ReleasePublished:
+			events.Add("release")
+		}
+	}
+	return events.ToSlice()
+}
+
+func mapGiteaHookToWebhookInfo(hook *gitea.Hook) WebhookInfo {
+	return WebhookInfo{
+		ID:         strconv.FormatInt(hook.ID, 10),
+		PayloadURL: hook.Config["url"],
+		Events:     getSubscribedWebhookEvents(hook.Events, getGiteaWebhookEvents),
+		Active:     hook.Active,
+	}
+}
+
+// SetCommitStatus on Gitea
+func (client *GiteaClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
+	title, description, detailsURL string) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateStatus(owner, repository, ref, gitea.CreateStatusOption{
+		State:       getGiteaCommitState(commitStatus),
+		TargetURL:   detailsURL,
+		Description: description,
+		Context:     title,
+	})
+	return err
+}
+
+// GetCommitStatuses on Gitea
+func (client *GiteaClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	err = validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CommitStatusInfo, 0)
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListStatusesOption{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		statuses, response, err := giteaClient.ListStatuses(owner, repository, ref, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, singleStatus := range statuses {
+			statusInfo := CommitStatusInfo{
+				State:         mapGiteaStatusStateToCommitStatus(singleStatus.State),
+				RawState:      string(singleStatus.State),
+				Context:       singleStatus.Context,
+				Description:   singleStatus.Description,
+				DetailsUrl:    singleStatus.TargetURL,
+				CreatedAt:     singleStatus.Created,
+				LastUpdatedAt: singleStatus.Updated,
+			}
+			if singleStatus.Creator != nil {
+				statusInfo.Creator = singleStatus.Creator.UserName
+			}
+			results = append(results, statusInfo)
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetCombinedCommitStatus on Gitea.
+// Combines the latest status of each context, and passes if the ref has no statuses.
+func (client *GiteaClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return Error, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return Error, err
+	}
+	combinedStatus, _, err := giteaClient.GetCombinedStatus(owner, repository, ref)
+	if err != nil {
+		return Error, err
+	}
+	statuses := make([]CommitStatus, 0, len(combinedStatus.Statuses))
+	for _, singleStatus := range combinedStatus.Statuses {
+		statuses = append(statuses, mapGiteaStatusStateToCommitStatus(singleStatus.State))
+	}
+	return combineCommitStatuses(statuses...), nil
+}
+
+// TriggerPipeline on Gitea
+func (client *GiteaClient) TriggerPipeline(_ context.Context, _, _, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+// GetLatestPipelineStatus on Gitea
+func (client *GiteaClient) GetLatestPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+// GetPipelineStatus on Gitea
+func (client *GiteaClient) GetPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+// TriggerWorkflow on Gitea
+func (client *GiteaClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return errGiteaPipelinesNotSupported
+}
+
+// GetWorkflowRunStatus on Gitea
+func (client *GiteaClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+// QueueBuild on Gitea
+func (client *GiteaClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+// GetBuildStatus on Gitea
+func (client *GiteaClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGiteaPipelinesNotSupported
+}
+
+func getGiteaCommitState(commitState CommitStatus) gitea.StatusState {
+	switch commitState {
+	case Pass:
+		return gitea.StatusSuccess
+	case Fail:
+		return gitea.StatusFailure
+	case Error:
+		return gitea.StatusError
+	case InProgress:
+		return gitea.StatusPending
+	}
+	return ""
+}
+
+// Warnings don't block merging pull requests on Gitea, hence they pass
+func mapGiteaStatusStateToCommitStatus(state gitea.StatusState) CommitStatus {
+	if state == gitea.StatusWarning {
+		return Pass
+	}
+	return commitStatusAsStringToStatus(string(state))
+}
+
+// DownloadRepository on Gitea
+func (client *GiteaClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	return client.downloadRepository(ctx, owner, repository, branch, localPath, nil)
+}
+
+// DownloadRepositoryPaths on Gitea.
+// Gitea doesn't archive selected paths, so the paths are filtered while the archive is extracted.
+func (client *GiteaClient) DownloadRepositoryPaths(ctx context.Context, owner, repository, ref string, paths []string, localPath string) error {
+	paths, err := normalizeDownloadPaths(paths)
+	if err != nil {
+		return err
+	}
+	return client.downloadRepository(ctx, owner, repository, ref, localPath, paths)
+}
+
+func (client *GiteaClient) downloadRepository(ctx context.Context, owner, repository, branch, localPath string, paths []string) (err error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	format, err := getArchiveFormat(client.vcsInfo, vcsutils.TarGzArchive)
+	if err != nil {
+		return err
+	}
+	archiveType := gitea.TarGZArchive
+	if format == vcsutils.ZipArchive {
+		archiveType = gitea.ZipArchive
+	}
+	archive, _, err := giteaClient.GetArchiveReader(owner, repository, branch, archiveType)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, archive.Close()) }()
+	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
+	if err = vcsutils.ExtractArchive(localPath, vcsutils.LimitReader(archive, client.vcsInfo.MaxDownloadSize), format, true, paths, client.vcsInfo.ExtractionLimits); err != nil {
+		return err
+	}
+
+	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
+	if err != nil {
+		return err
+	}
+
+	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	return vcsutils.CreateDotGitFolderWithRemote(localPath, vcsutils.RemoteName, repositoryInfo.CloneInfo.HTTP)
+}
+
+func (client *GiteaClient) GetPullRequestCommentSizeLimit() int {
+	return giteaPrContentSizeLimit
+}
+
+func (client *GiteaClient) GetPullRequestDetailsSizeLimit() int {
+	return giteaPrContentSizeLimit
+}
+
+// CreatePullRequest on Gitea
+func (client *GiteaClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string) (PullRequestInfo, error) {
+	return client.CreatePullRequestWithOptions(ctx, owner, repository, sourceBranch, targetBranch, title, description, CreatePullRequestOptions{})
+}
+
+// CreatePullRequestWithOptions on Gitea.
+// Draft pull requests are created by prefixing their title with "WIP: ", the default work in progress prefix of Gitea.
+// The labels must exist in the repository, and the reviewers are requested after the pull request is created.
+func (client *GiteaClient) CreatePullRequestWithOptions(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string, options CreatePullRequestOptions) (PullRequestInfo, error) {
+	if err := validateCreatePullRequestOptions(options, "not supported on Gitea", deleteSourceBranchPullRequestOption, workItemsPullRequestOption); err != nil {
+		return PullRequestInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	if options.Draft {
+		title = addDraftTitlePrefix(title, "WIP:", "[WIP]")
+	}
+	createOptions := gitea.CreatePullRequestOption{
+		Head:      sourceBranch,
+		Base:      targetBranch,
+		Title:     title,
+		Body:      description,
+		Assignees: options.Assignees,
+		Milestone: int64(options.Milestone),
+	}
+	if len(options.Labels) > 0 {
+		if createOptions.Labels, err = client.getLabelIDs(giteaClient, owner, repository, options.Labels); err != nil {
+			return PullRequestInfo{}, err
+		}
+	}
+	client.logger.Debug(vcsutils.CreatingPullRequest, title)
+	pullRequest, _, err := giteaClient.CreatePullRequest(owner, repository, createOptions)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	if len(options.Reviewers) > 0 {
+		if _, err = giteaClient.CreateReviewRequests(owner, repository, pullRequest.Index, gitea.PullReviewRequestOptions{Reviewers: options.Reviewers}); err != nil {
+			return PullRequestInfo{}, err
+		}
+	}
+	return mapGiteaPullRequestToPullRequestInfo(pullRequest, true), nil
+}
+
+// UpdatePullRequest on Gitea.
+// The client library always sends the body, hence the current body is kept if no body is given.
+func (client *GiteaClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		pullRequest, _, err := giteaClient.GetPullRequest(owner, repository, int64(prId))
+		if err != nil {
+			return err
+		}
+		body = pullRequest.Body
+	}
+	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
+	_, _, err = giteaClient.EditPullRequest(owner, repository, int64(prId), gitea.EditPullRequestOption{
+		Title: title,
+		Body:  body,
+		Base:  targetBranchName,
+		State: mapGiteaPullRequestState(state),
+	})
+	return err
+}
+
+// MergePullRequest on Gitea
+func (client *GiteaClient) MergePullRequest(ctx context.Context, owner, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	var mergeStyle gitea.MergeStyle
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge:
+		mergeStyle = gitea.MergeStyleMerge
+	case vcsutils.MergeMethodSquash:
+		mergeStyle = gitea.MergeStyleSquash
+	case vcsutils.MergeMethodRebase:
+		mergeStyle = gitea.MergeStyleRebase
+	default:
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	merged, response, err := giteaClient.MergePullRequest(owner, repository, int64(prId), gitea.MergePullRequestOption{Style: mergeStyle})
+	if err != nil {
+		return err
+	}
+	if !merged {
+		return fmt.Errorf("failed to merge pull request %d, status: %s", prId, response.Status)
+	}
+	return nil
+}
+
+// SetPullRequestAutoMerge on Gitea.
+// The pull request is scheduled to be merged when its checks succeed, or merged immediately if they already succeeded.
+func (client *GiteaClient) SetPullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod vcsutils.MergeMethod) error {
+	var mergeStyle gitea.MergeStyle
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge:
+		mergeStyle = gitea.MergeStyleMerge
+	case vcsutils.MergeMethodSquash:
+		mergeStyle = gitea.MergeStyleSquash
+	case vcsutils.MergeMethodRebase:
+		mergeStyle = gitea.MergeStyleRebase
+	default:
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	scheduled, response, err := giteaClient.MergePullRequest(owner, repository, int64(pullRequestID), gitea.MergePullRequestOption{Style: mergeStyle, MergeWhenChecksSucceed: true})
+	if err != nil {
+		return err
+	}
+	if !scheduled {
+		return fmt.Errorf("failed to set auto-merge on pull request %d, status: %s", pullRequestID, response.Status)
+	}
+	return nil
+}
+
+// ListOpenPullRequestsWithBody on Gitea
+func (client *GiteaClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.listAllPullRequests(ctx, owner, repository, gitea.StateOpen, true)
+}
+
+// ListOpenPullRequests on Gitea
+func (client *GiteaClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.listAllPullRequests(ctx, owner, repository, gitea.StateOpen, false)
+}
+
+// ListOpenPullRequestsWithOptions on Gitea
+func (client *GiteaClient) ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	pullRequests, _, err := giteaClient.ListRepoPullRequests(owner, repository, gitea.ListPullRequestsOptions{
+		ListOptions: gitea.ListOptions{Page: page, PageSize: perPage},
+		State:       gitea.StateOpen,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The API can't filter the pull requests, hence the pull requests of the page are filtered here
+	var results []PullRequestInfo
+	for _, pullRequest := range pullRequests {
+		if pullRequestInfo := mapGiteaPullRequestToPullRequestInfo(pullRequest, options.WithBody); options.matches(pullRequestInfo) {
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
+// ListPullRequestsWithState on Gitea.
+// Merged pull requests are closed on Gitea, hence the closed pull requests are filtered by their merge status.
+func (client *GiteaClient) ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	if err := validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+	giteaState := gitea.StateClosed
+	if state == vcsutils.Open {
+		giteaState = gitea.StateOpen
+	}
+	pullRequests, err := client.listAllPullRequests(ctx, owner, repository, giteaState, true)
+	if err != nil {
+		return nil, err
+	}
+	var results []PullRequestInfo
+	for _, pullRequest := range pullRequests {
+		if pullRequest.State == state {
+			results = append(results, pullRequest)
+		}
+	}
+	return results, nil
+}
+
+func (client *GiteaClient) listAllPullRequests(ctx context.Context, owner, repository string, state gitea.StateType, withBody bool) ([]PullRequestInfo, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	var results []PullRequestInfo
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListPullRequestsOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}, State: state}
+		pullRequests, response, err := giteaClient.ListRepoPullRequests(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range pullRequests {
+			results = append(results, mapGiteaPullRequestToPullRequestInfo(pullRequest, withBody))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetPullRequestByID on Gitea
+func (client *GiteaClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	pullRequest, _, err := giteaClient.GetPullRequest(owner, repository, int64(pullRequestId))
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapGiteaPullRequestToPullRequestInfo(pullRequest, true), nil
+}
+
+// AddPullRequestComment on Gitea
+func (client *GiteaClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	return client.AddIssueComment(ctx, owner, repository, content, pullRequestID)
+}
+
+// AddPullRequestReviewComments on Gitea.
+// The comments are added by a single review, without a verdict.
+func (client *GiteaClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return errors.New("could not add pull request review comments, no comments provided")
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	reviewComments := make([]gitea.CreatePullReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		reviewComments = append(reviewComments, gitea.CreatePullReviewComment{
+			Path:       comment.NewFilePath,
+			Body:       comment.Content,
+			NewLineNum: int64(comment.NewStartLine),
+		})
+	}
+	_, _, err = giteaClient.CreatePullReview(owner, repository, int64(pullRequestID), gitea.CreatePullReviewOptions{
+		State:    gitea.ReviewStateComment,
+		Comments: reviewComments,
+	})
+	return err
+}
+
+// ListPullRequestReviewComments on Gitea
+func (client *GiteaClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := client.listAllPullReviews(giteaClient, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentsInfo []CommentInfo
+	for _, review := range reviews {
+		if review.CodeCommentsCount == 0 {
+			continue
+		}
+		reviewComments, _, err := giteaClient.ListPullReviewComments(owner, repository, int64(pullRequestID), review.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range reviewComments {
+			line := comment.LineNum
+			if line == 0 {
+				// Comments on removed lines are anchored to the original file only
+				line = comment.OldLineNum
+			}
+			commentInfo := CommentInfo{
+				ID:       comment.ID,
+				NativeID: strconv.FormatInt(comment.ID, 10),
+				Content:  comment.Body,
+				Created:  comment.Created,
+				Updated:  comment.Updated,
+				FilePath: comment.Path,
+				Line:     int(line),
+				DiffHunk: comment.DiffHunk,
+			}
+			if comment.Reviewer != nil {
+				commentInfo.Author, commentInfo.AuthorUsername = comment.Reviewer.FullName, comment.Reviewer.UserName
+			}
+			commentsInfo = append(commentsInfo, commentInfo)
+		}
+	}
+	return commentsInfo, nil
+}
+
+// DeletePullRequestReviewComments on Gitea
+func (client *GiteaClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, _ int, comments ...CommentInfo) error {
+	for _, comment := range comments {
+		if err := client.DeletePullRequestComment(ctx, owner, repository, 0, int(comment.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListPullRequestComments on Gitea
+func (client *GiteaClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []CommentInfo
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListIssueCommentOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		comments, response, err := giteaClient.ListIssueComments(owner, repository, int64(pullRequestID), options)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			results = append(results, mapGiteaCommentToCommentInfo(comment))
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// ListPullRequestCommentsWithOptions on Gitea
+func (client *GiteaClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	listOptions := gitea.ListIssueCommentOptions{ListOptions: gitea.ListOptions{Page: page, PageSize: perPage}, Since: options.UpdatedAfter}
+	comments, _, err := giteaClient.ListIssueComments(owner, repository, int64(pullRequestID), listOptions)
+	if err != nil {
+		return nil, err
+	}
+	var results []CommentInfo
+	for _, comment := range comments {
+		results = append(results, mapGiteaCommentToCommentInfo(comment))
+	}
+	return results, nil
+}
+
+func mapGiteaCommentToCommentInfo(comment *gitea.Comment) CommentInfo {
+	commentInfo := CommentInfo{
+		ID:       comment.ID,
+		NativeID: strconv.FormatInt(comment.ID, 10),
+		Content:  comment.Body,
+		Created:  comment.Created,
+		Updated:  comment.Updated,
+	}
+	if comment.Poster != nil {
+		commentInfo.Author, commentInfo.AuthorUsername = comment.Poster.FullName, comment.Poster.UserName
+	}
+	return commentInfo
+}
+
+// UpdatePullRequestComment on Gitea
+func (client *GiteaClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, _, commentID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	if _, _, err = giteaClient.EditIssueComment(owner, repository, int64(commentID), gitea.EditIssueCommentOption{Body: content}); err != nil {
+		return fmt.Errorf("an error occurred while updating pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// DeletePullRequestComment on Gitea
+func (client *GiteaClient) DeletePullRequestComment(ctx context.Context, owner, repository string, _, commentID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = giteaClient.DeleteIssueComment(owner, repository, int64(commentID)); err != nil {
+		return fmt.Errorf("an error occurred while deleting pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// SetPullRequestThreadStatus on Gitea
+func (client *GiteaClient) SetPullRequestThreadStatus(_ context.Context, _, _ string, _ int, _ string, _ bool) error {
+	return errGiteaThreadStatusNotSupported
+}
+
+// CreatePullRequestReview on Gitea.
+// Gitea requires a body to request changes.
+func (client *GiteaClient) CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	if err := validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreatePullReview(owner, repository, int64(pullRequestID), gitea.CreatePullReviewOptions{
+		State: giteaReviewStates[verdict],
+		Body:  body,
+	})
+	return err
+}
+
+var giteaReviewStates = map[vcsutils.ReviewVerdict]gitea.ReviewStateType{
+	vcsutils.ReviewVerdictApprove:        gitea.ReviewStateApproved,
+	vcsutils.ReviewVerdictRequestChanges: gitea.ReviewStateRequestChanges,
+	vcsutils.ReviewVerdictComment:        gitea.ReviewStateComment,
+}
+
+// ListPullRequestReviews on Gitea.
+// Pending reviews and review requests aren't returned.
+func (client *GiteaClient) ListPullRequestReviews(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := client.listAllPullReviews(giteaClient, owner, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []PullRequestReviewInfo
+	for _, review := range reviews {
+		verdict := mapGiteaReviewStateToReviewVerdict(review.State)
+		if verdict == "" {
+			continue
+		}
+		reviewInfo := PullRequestReviewInfo{State: verdict, Body: review.Body, Submitted: review.Submitted}
+		if review.Reviewer != nil {
+			reviewInfo.Reviewer = review.Reviewer.UserName
+		}
+		results = append(results, reviewInfo)
+	}
+	return results, nil
+}
+
+func (client *GiteaClient) listAllPullReviews(giteaClient *gitea.Client, owner, repository string, pullRequestID int) ([]*gitea.PullReview, error) {
+	var results []*gitea.PullReview
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListPullReviewsOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		reviews, response, err := giteaClient.ListPullReviews(owner, repository, int64(pullRequestID), options)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, reviews...)
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+func mapGiteaReviewStateToReviewVerdict(state gitea.ReviewStateType) vcsutils.ReviewVerdict {
+	for verdict, reviewState := range giteaReviewStates {
+		if reviewState == state {
+			return verdict
+		}
+	}
+	return ""
+}
+
+// GetLatestCommit on Gitea
+func (client *GiteaClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	commits, err := client.ListCommits(ctx, owner, repository, ListCommitsOptions{Branch: branch, PerPage: 1})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	if len(commits) > 0 {
+		return commits[0], nil
+	}
+
+	return CommitInfo{}, fmt.Errorf("no commits were returned for <%s/%s/%s>", owner, repository, branch)
+}
+
+// GetCommits on Gitea
+func (client *GiteaClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	return client.ListCommits(ctx, owner, repository, ListCommitsOptions{Branch: branch})
+}
+
+// ListCommits on Gitea
+func (client *GiteaClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     options.Branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	commits, _, err := giteaClient.ListRepoCommits(owner, repository, gitea.ListCommitOptions{
+		ListOptions: gitea.ListOptions{Page: page, PageSize: perPage},
+		SHA:         options.Branch,
+		Path:        options.Path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The API doesn't support filtering by time or author, hence the commits of the page are filtered here
+	var commitsInfo []CommitInfo
+	for _, commit := range commits {
+		commitInfo := mapGiteaCommitToCommitInfo(commit)
+		if options.matches(commitInfo, time.Unix(commitInfo.Timestamp, 0)) {
+			commitsInfo = append(commitsInfo, commitInfo)
+		}
+	}
+	return commitsInfo, nil
+}
+
+// GetRepositoryInfo on Gitea
+func (client *GiteaClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	repo, _, err := giteaClient.GetRepo(owner, repository)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapGiteaRepositoryToRepositoryInfo(repo), nil
+}
+
+// CreateRepository on Gitea.
+// The repository is created for the authenticated user if it is the owner, otherwise in the organization of the owner.
+func (client *GiteaClient) CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	user, _, err := giteaClient.GetMyUserInfo()
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	createOptions := gitea.CreateRepoOption{
+		Name:          repository,
+		Description:   options.Description,
+		Private:       options.Private,
+		AutoInit:      options.InitReadme,
+		DefaultBranch: options.DefaultBranch,
+	}
+	var repo *gitea.Repository
+	if strings.EqualFold(user.UserName, owner) {
+		repo, _, err = giteaClient.CreateRepo(createOptions)
+	} else {
+		repo, _, err = giteaClient.CreateOrgRepo(owner, createOptions)
+	}
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapGiteaRepositoryToRepositoryInfo(repo), nil
+}
+
+// DeleteRepository on Gitea
+func (client *GiteaClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteRepo(owner, repository)
+	return err
+}
+
+// ForkRepository on Gitea.
+// The target owner must be an organization, otherwise the fork is created for the authenticated user.
+func (client *GiteaClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	fork, _, err := giteaClient.CreateFork(owner, repository, gitea.CreateForkOption{Organization: vcsutils.GetNilIfZeroVal(targetOwner)})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapGiteaRepositoryToRepositoryInfo(fork), nil
+}
+
+// ListRepositoryCollaborators on Gitea.
+// The permission of each collaborator is fetched separately, since Gitea doesn't list it with the collaborators.
+func (client *GiteaClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []CollaboratorInfo
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListCollaboratorsOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		collaborators, response, err := giteaClient.ListCollaborators(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, collaborator := range collaborators {
+			permission, _, err := giteaClient.CollaboratorPermission(owner, repository, collaborator.UserName)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, CollaboratorInfo{Username: collaborator.UserName, Permission: mapGiteaAccessModeToRepositoryPermission(permission.Permission)})
+		}
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+// GetRepositoryPermission on Gitea
+func (client *GiteaClient) GetRepositoryPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return NoPermission, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return NoPermission, err
+	}
+	permission, _, err := giteaClient.CollaboratorPermission(owner, repository, username)
+	if err != nil {
+		return NoPermission, err
+	}
+	return mapGiteaAccessModeToRepositoryPermission(permission.Permission), nil
+}
+
+func mapGiteaAccessModeToRepositoryPermission(accessMode gitea.AccessMode) RepositoryPermission {
+	switch accessMode {
+	case gitea.AccessModeOwner, gitea.AccessModeAdmin:
+		return AdminPermission
+	case gitea.AccessModeWrite:
+		return WritePermission
+	case gitea.AccessModeRead:
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// GetCommitBySha on Gitea
+func (client *GiteaClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commit, _, err := giteaClient.GetSingleCommit(owner, repository, sha)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapGiteaCommitToCommitInfo(commit), nil
+}
+
+// CreateLabel on Gitea
+func (client *GiteaClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateLabel(owner, repository, gitea.CreateLabelOption{
+		Name:        labelInfo.Name,
+		Description: labelInfo.Description,
+		Color:       "#" + labelInfo.Color,
+	})
+	return err
+}
+
+// GetLabel on Gitea
+func (client *GiteaClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := client.listAllRepoLabels(giteaClient, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	label := findGiteaLabel(labels, name)
+	if label == nil {
+		return nil, nil
+	}
+	labelInfo := mapGiteaLabelToLabelInfo(label)
+	return &labelInfo, nil
+}
+
+// ListRepositoryLabels on Gitea
+func (client *GiteaClient) ListRepositoryLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := client.listAllRepoLabels(giteaClient, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	var results []LabelInfo
+	for _, label := range labels {
+		results = append(results, mapGiteaLabelToLabelInfo(label))
+	}
+	return results, nil
+}
+
+// UpdateLabel on Gitea
+func (client *GiteaClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	label, err := client.getLabelByName(giteaClient, owner, repository, name)
+	if err != nil {
+		return err
+	}
+	options := gitea.EditLabelOption{Name: &labelInfo.Name, Description: &labelInfo.Description}
+	if labelInfo.Color != "" {
+		options.Color = vcsutils.PointerOf("#" + labelInfo.Color)
+	}
+	_, _, err = giteaClient.EditLabel(owner, repository, label.ID, options)
+	return err
+}
+
+// DeleteLabel on Gitea
+func (client *GiteaClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	label, err := client.getLabelByName(giteaClient, owner, repository, name)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteLabel(owner, repository, label.ID)
+	return err
+}
+
+// ListPullRequestLabels on Gitea
+func (client *GiteaClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels, _, err := giteaClient.GetIssueLabels(owner, repository, int64(pullRequestID), gitea.ListLabelsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]string, 0, len(labels))
+	for _, label := range labels {
+		results = append(results, label.Name)
+	}
+	return results, nil
+}
+
+// LabelPullRequest on Gitea.
+// Gitea labels pull requests by the label IDs, hence the labels must exist in the repository.
+func (client *GiteaClient) LabelPullRequest(ctx context.Context, owner, repository string, pullRequestID int, labels []string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	labelIDs, err := client.getLabelIDs(giteaClient, owner, repository, labels)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.AddIssueLabels(owner, repository, int64(pullRequestID), gitea.IssueLabelsOption{Labels: labelIDs})
+	return err
+}
+
+// getLabelIDs returns the IDs of the repository labels with the given names
+func (client *GiteaClient) getLabelIDs(giteaClient *gitea.Client, owner, repository string, labels []string) ([]int64, error) {
+	repoLabels, err := client.listAllRepoLabels(giteaClient, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs := make([]int64, 0, len(labels))
+	for _, name := range labels {
+		label := findGiteaLabel(repoLabels, name)
+		if label == nil {
+			return nil, fmt.Errorf("label %s doesn't exist in repository %s", name, repository)
+		}
+		labelIDs = append(labelIDs, label.ID)
+	}
+	return labelIDs, nil
+}
+
+// UnlabelPullRequest on Gitea
+func (client *GiteaClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	label, err := client.getLabelByName(giteaClient, owner, repository, name)
+	if err != nil {
+		return err
+	}
+	_, err = giteaClient.DeleteIssueLabel(owner, repository, int64(pullRequestID), label.ID)
+	return err
+}
+
+func (client *GiteaClient) listAllRepoLabels(giteaClient *gitea.Client, owner, repository string) ([]*gitea.Label, error) {
+	var results []*gitea.Label
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListLabelsOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		labels, response, err := giteaClient.ListRepoLabels(owner, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, labels...)
+		nextPage = response.NextPage
+	}
+	return results, nil
+}
+
+func (client *GiteaClient) getLabelByName(giteaClient *gitea.Client, owner, repository, name string) (*gitea.Label, error) {
+	labels, err := client.listAllRepoLabels(giteaClient, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	label := findGiteaLabel(labels, name)
+	if label == nil {
+		return nil, fmt.Errorf("label %s doesn't exist in repository %s", name, repository)
+	}
+	return label, nil
+}
+
+func findGiteaLabel(labels []*gitea.Label, name string) *gitea.Label {
+	for _, label := range labels {
+		if label.Name == name {
+			return label
+		}
+	}
+	return nil
+}
+
+func mapGiteaLabelToLabelInfo(label *gitea.Label) LabelInfo {
+	return LabelInfo{
+		Name:        label.Name,
+		Description: label.Description,
+		Color:       strings.TrimPrefix(label.Color, "#"),
+	}
+}
+
+// CreateIssue on Gitea
+func (client *GiteaClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	issue, _, err := giteaClient.CreateIssue(owner, repository, gitea.CreateIssueOption{Title: title, Body: body})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	return mapGiteaIssueToIssueInfo(issue), nil
+}
+
+var giteaIssueStates = map[vcsutils.IssueState]gitea.StateType{
+	"":                   gitea.StateAll,
+	vcsutils.IssueOpen:   gitea.StateOpen,
+	vcsutils.IssueClosed: gitea.StateClosed,
+}
+
+// ListIssues on Gitea
+func (client *GiteaClient) ListIssues(ctx context.Context, owner, repository string, options ListIssuesOptions) ([]IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	if err = validateIssueState(options.State); err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	issues, _, err := giteaClient.ListRepoIssues(owner, repository, gitea.ListIssueOption{
+		ListOptions: gitea.ListOptions{Page: page, PageSize: perPage},
+		State:       giteaIssueStates[options.State],
+		Type:        gitea.IssueTypeIssue,
+		Labels:      options.Labels,
+		CreatedBy:   options.Author,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []IssueInfo
+	for _, issue := range issues {
+		results = append(results, mapGiteaIssueToIssueInfo(issue))
+	}
+	return results, nil
+}
+
+// AddIssueComment on Gitea
+func (client *GiteaClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.CreateIssueComment(owner, repository, int64(issueID), gitea.CreateIssueCommentOption{Body: content})
+	return err
+}
+
+// CloseIssue on Gitea
+func (client *GiteaClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = giteaClient.EditIssue(owner, repository, int64(issueID), gitea.EditIssueOption{State: vcsutils.PointerOf(gitea.StateClosed)})
+	return err
+}
+
+func mapGiteaIssueToIssueInfo(issue *gitea.Issue) IssueInfo {
+	issueInfo := IssueInfo{
+		ID:    issue.Index,
+		Title: issue.Title,
+		Body:  issue.Body,
+		URL:   issue.HTMLURL,
+		State: vcsutils.IssueClosed,
+	}
+	if issue.State == gitea.StateOpen {
+		issueInfo.State = vcsutils.IssueOpen
+	}
+	if issue.Poster != nil {
+		issueInfo.Author = issue.Poster.UserName
+	}
+	for _, label := range issue.Labels {
+		issueInfo.Labels = append(issueInfo.Labels, label.Name)
+	}
+	return issueInfo
+}
+
+// UploadCodeScanning on Gitea
+func (client *GiteaClient) UploadCodeScanning(_ context.Context, _, _, _, _ string) (string, error) {
+	return "", errGiteaCodeScanningNotSupported
+}
+
+// GetRepositoryEnvironmentInfo on Gitea
+func (client *GiteaClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errGiteaGetRepoEnvironmentInfoNotSupported
+}
+
+// ListEnvironments on Gitea
+func (client *GiteaClient) ListEnvironments(_ context.Context, _, _ string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errGiteaDeploymentsNotSupported
+}
+
+// CreateDeployment on Gitea
+func (client *GiteaClient) CreateDeployment(_ context.Context, _, _, _, _, _ string) (DeploymentInfo, error) {
+	return DeploymentInfo{}, errGiteaDeploymentsNotSupported
+}
+
+// SetDeploymentStatus on Gitea
+func (client *GiteaClient) SetDeploymentStatus(_ context.Context, _, _, _ string, _ DeploymentState, _, _ string) error {
+	return errGiteaDeploymentsNotSupported
+}
+
+// CreateOrUpdateRepositorySecret on Gitea
+func (client *GiteaClient) CreateOrUpdateRepositorySecret(_ context.Context, _, _, _, _ string) error {
+	return errGiteaRepositorySecretsNotSupported
+}
+
+// ListRepositoryVariables on Gitea
+func (client *GiteaClient) ListRepositoryVariables(_ context.Context, _, _ string) ([]RepositoryVariableInfo, error) {
+	return nil, errGiteaRepositorySecretsNotSupported
+}
+
+// CreateOrUpdateOrganizationSecret on Gitea
+func (client *GiteaClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return errGiteaSecretsNotSupported
+}
+
+// ListOrganizationSecrets on Gitea
+func (client *GiteaClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, errGiteaSecretsNotSupported
+}
+
+// ListOrganizationSecretRepositories on Gitea
+func (client *GiteaClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, errGiteaSecretsNotSupported
+}
+
+// DeleteOrganizationSecret on Gitea
+func (client *GiteaClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return errGiteaSecretsNotSupported
+}
+
+// CreateOrUpdateEnvironmentSecret on Gitea
+func (client *GiteaClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return errGiteaSecretsNotSupported
+}
+
+// ListEnvironmentSecrets on Gitea
+func (client *GiteaClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, errGiteaSecretsNotSupported
+}
+
+// DeleteEnvironmentSecret on Gitea
+func (client *GiteaClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return errGiteaSecretsNotSupported
+}
+
+// CreateCodeInsightsReport on Gitea
+func (client *GiteaClient) CreateCodeInsightsReport(_ context.Context, _, _, _ string, _ CodeInsightsReport) error {
+	return errGiteaCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on Gitea
+func (client *GiteaClient) AddCodeInsightsAnnotations(_ context.Context, _, _, _, _ string, _ []CodeInsightsAnnotation) error {
+	return errGiteaCodeInsightsNotSupported
+}
+
+// CreateCheckRun on Gitea
+func (client *GiteaClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errGiteaCheckRunsNotSupported
+}
+
+// UpdateCheckRun on Gitea
+func (client *GiteaClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errGiteaCheckRunsNotSupported
+}
+
+// DownloadFileFromRepo on Gitea
+func (client *GiteaClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	content, response, err := giteaClient.GetFile(owner, repository, branch, path)
+	var statusCode int
+	if response != nil && response.Response != nil {
+		statusCode = response.StatusCode
+	}
+	if err != nil {
+		return nil, statusCode, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, statusCode, fmt.Errorf("expected %d status code while received %d status code", http.StatusOK, statusCode)
+	}
+	return content, statusCode, nil
+}
+
+// ListDirectoryContents on Gitea
+func (client *GiteaClient) ListDirectoryContents(ctx context.Context, owner, repository, ref, path string) ([]DirectoryEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contents, _, err := giteaClient.ListContents(owner, repository, ref, path)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]DirectoryEntry, 0, len(contents))
+	for _, content := range contents {
+		entries = append(entries, DirectoryEntry{Name: content.Name, Path: content.Path, IsDir: content.Type == "dir"})
+	}
+	return entries, nil
+}
+
+type giteaChangeFilesRequest struct {
+	Branch  string                     `json:"branch"`
+	Message string                     `json:"message"`
+	Files   []giteaChangeFileOperation `json:"files"`
+}
+
+type giteaChangeFileOperation struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   string `json:"content,omitempty"`
+	SHA       string `json:"sha,omitempty"`
+}
+
+// CommitFiles on Gitea.
+// The files are committed by the endpoint which changes multiple files, available since Gitea 1.20.
+// Modified and deleted files are identified by their current blob SHA, which is fetched for each of them.
+func (client *GiteaClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, files []FileToCommit) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "commitMessage": commitMessage})
+	if err != nil {
+		return err
+	}
+	if err = validateFilesToCommit(files); err != nil {
+		return err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	operations := make([]giteaChangeFileOperation, 0, len(files))
+	for _, file := range files {
+		operation := giteaChangeFileOperation{Path: file.Path}
+		switch file.ChangeType {
+		case FileAdded:
+			operation.Operation = "create"
+		case FileDeleted:
+			operation.Operation = "delete"
+		default:
+			operation.Operation = "update"
+		}
+		if file.ChangeType != FileAdded {
+			currentFile, _, err := giteaClient.GetContents(owner, repository, branch, file.Path)
+			if err != nil {
+				return err
+			}
+			operation.SHA = currentFile.SHA
+		}
+		if file.ChangeType != FileDeleted {
+			operation.Content = base64.StdEncoding.EncodeToString(file.Content)
+		}
+		operations = append(operations, operation)
+	}
+	return client.sendRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/contents", owner, repository), giteaChangeFilesRequest{
+		Branch:  branch,
+		Message: commitMessage,
+		Files:   operations,
+	}, nil)
+}
+
+// GetModifiedFiles on Gitea
+func (client *GiteaClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+	if err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"refBefore":  refBefore,
+		"refAfter":   refAfter,
+	}); err != nil {
+		return nil, err
+	}
+
+	comparison, err := client.CompareCommits(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	fileNamesSet := datastructures.MakeSet[string]()
+	for _, file := range comparison.Files {
+		fileNamesSet.Add(file.Path)
+		fileNamesSet.Add(file.PreviousPath)
+	}
+	_ = fileNamesSet.Remove("") // Make sure there are no blank filepath.
+	fileNamesList := fileNamesSet.ToSlice()
+	sort.Strings(fileNamesList)
+	return fileNamesList, nil
+}
+
+type giteaCompare struct {
+	Commits []*giteaCompareCommit `json:"commits"`
+}
+
+type giteaCompareCommit struct {
+	gitea.Commit
+	Files []struct {
+		Filename string `json:"filename"`
+		Status   string `json:"status"`
+	} `json:"files"`
+}
+
+// CompareCommits on Gitea.
+// The comparison endpoint is available since Gitea 1.22. Since it reports only the files changed by each commit,
+// the changes of the compared commits are combined, from the oldest commit to the newest.
+func (client *GiteaClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	if err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	}); err != nil {
+		return CommitsComparison{}, err
+	}
+
+	var compare giteaCompare
+	comparePath := fmt.Sprintf("/repos/%s/%s/compare/%s...%s", owner, repository, neturl.PathEscape(base), neturl.PathEscape(head))
+	if err := client.sendRequest(ctx, http.MethodGet, comparePath, nil, &compare); err != nil {
+		return CommitsComparison{}, err
+	}
+
+	var commitsComparison CommitsComparison
+	changeTypes := make(map[string]FileChangeType)
+	sort.SliceStable(compare.Commits, func(i, j int) bool {
+		return mapGiteaCommitToCommitInfo(&compare.Commits[i].Commit).Timestamp < mapGiteaCommitToCommitInfo(&compare.Commits[j].Commit).Timestamp
+	})
+	for _, commit := range compare.Commits {
+		commitsComparison.Commits = append(commitsComparison.Commits, mapGiteaCommitToCommitInfo(&commit.Commit))
+		for _, file := range commit.Files {
+			combineGiteaFileChange(changeTypes, file.Filename, mapGiteaFileStatusToChangeType(file.Status))
+		}
+	}
+	paths := make([]string, 0, len(changeTypes))
+	for path := range changeTypes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		commitsComparison.Files = append(commitsComparison.Files, PullRequestFile{Path: path, ChangeType: changeTypes[path]})
+	}
+	return commitsComparison, nil
+}
+
+// combineGiteaFileChange combines the change of a file by a commit with its changes by the previous commits
+func combineGiteaFileChange(changeTypes map[string]FileChangeType, path string, changeType FileChangeType) {
+	previousChangeType, exists := changeTypes[path]
+	switch {
+	case !exists:
+		changeTypes[path] = changeType
+	case previousChangeType == FileAdded && changeType == FileDeleted:
+		// The file didn't exist before the compared commits
+		delete(changeTypes, path)
+	case previousChangeType == FileAdded:
+		// The file is still added
+	case previousChangeType == FileDeleted && changeType == FileAdded:
+		changeTypes[path] = FileModified
+	default:
+		changeTypes[path] = changeType
+	}
+}
+
+// ListPullRequestFiles on Gitea
+func (client *GiteaClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []PullRequestFile
+	for nextPage := 1; nextPage != 0; {
+		options := gitea.ListPullRequestFilesOptions{ListOptions: gitea.ListOptions{Page: nextPage, PageSize: giteaPageSize}}
+		changedFiles, response, err := giteaClient.ListPullRequestFiles(owner, repository, int64(pullRequestID), options)
+		if err != nil {
+			return nil, err
+		}
+		for _, changedFile := range changedFiles {
+			file := PullRequestFile{Path: changedFile.Filename, ChangeType: mapGiteaFileStatusToChangeType(changedFile.Status)}
+			if file.ChangeType == FileRenamed {
+				file.PreviousPath = changedFile.PreviousFilename
+			}
+			files = append(files, file)
+		}
+		nextPage = response.NextPage
+	}
+	return files, nil
+}
+
+func mapGiteaFileStatusToChangeType(status string) FileChangeType {
+	switch status {
+	case "added", "copied":
+		return FileAdded
+	case "deleted", "removed":
+		return FileDeleted
+	case "renamed":
+		return FileRenamed
+	default:
+		return FileModified
+	}
+}
+
+// GetPullRequestDiff on Gitea
+func (client *GiteaClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return "", err
+	}
+	giteaClient, err := client.buildGiteaClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	diff, _, err := giteaClient.GetPullRequestDiff(owner, repository, int64(pullRequestID), gitea.PullRequestDiffOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(diff), nil
+}
+
+func isGiteaNotFound(response *gitea.Response) bool {
+	return response != nil && response.Response != nil && response.StatusCode == http.StatusNotFound
+}
+
+func mapGiteaRepositoryToRepositoryInfo(repository *gitea.Repository) RepositoryInfo {
+	visibility := Public
+	if repository.Private {
+		visibility = Private
+	} else if repository.Internal {
+		visibility = Internal
+	}
+	return RepositoryInfo{
+		RepositoryVisibility: visibility,
+		CloneInfo:            CloneInfo{HTTP: repository.CloneURL, SSH: repository.SSHURL},
+		DefaultBranch:        repository.DefaultBranch,
+		Archived:             repository.Archived,
+		Description:          repository.Description,
+	}
+}
+
+func mapGiteaCommitToCommitInfo(commit *gitea.Commit) CommitInfo {
+	commitInfo := CommitInfo{Url: commit.HTMLURL}
+	if commit.CommitMeta != nil {
+		commitInfo.Hash = commit.SHA
+		commitInfo.Timestamp = commit.Created.UTC().Unix()
+	}
+	if commit.RepoCommit != nil {
+		commitInfo.Message = commit.RepoCommit.Message
+		if commit.RepoCommit.Author != nil {
+			commitInfo.AuthorName = commit.RepoCommit.Author.Name
+			commitInfo.AuthorEmail = commit.RepoCommit.Author.Email
+		}
+		if commit.RepoCommit.Committer != nil {
+			commitInfo.CommitterName = commit.RepoCommit.Committer.Name
+		}
+	}
+	for _, parent := range commit.Parents {
+		commitInfo.ParentHashes = append(commitInfo.ParentHashes, parent.SHA)
+	}
+	return commitInfo
+}
+
+func mapGiteaPullRequestState(state vcsutils.PullRequestState) *gitea.StateType {
+	switch state {
+	case vcsutils.Open:
+		return vcsutils.PointerOf(gitea.StateOpen)
+	case vcsutils.Closed:
+		return vcsutils.PointerOf(gitea.StateClosed)
+	default:
+		return nil
+	}
+}
+
+func mapGiteaPullRequestToPullRequestInfo(pullRequest *gitea.PullRequest, withBody bool) PullRequestInfo {
+	pullRequestInfo := PullRequestInfo{
+		ID:        pullRequest.Index,
+		Title:     pullRequest.Title,
+		URL:       pullRequest.HTMLURL,
+		State:     vcsutils.Open,
+		Mergeable: pullRequest.Mergeable,
+		CreatedAt: extractTimeWithFallback(pullRequest.Created),
+		UpdatedAt: extractTimeWithFallback(pullRequest.Updated),
+		Source:    mapGiteaBranchInfo(pullRequest.Head),
+		Target:    mapGiteaBranchInfo(pullRequest.Base),
+	}
+	if withBody {
+		pullRequestInfo.Body = pullRequest.Body
+	}
+	if pullRequest.Poster != nil {
+		pullRequestInfo.Author = pullRequest.Poster.UserName
+	}
+	switch {
+	case pullRequest.HasMerged:
+		pullRequestInfo.State = vcsutils.Merged
+	case pullRequest.State == gitea.StateClosed:
+		pullRequestInfo.State = vcsutils.Closed
+	}
+	return pullRequestInfo
+}
+
+func mapGiteaBranchInfo(branch *gitea.PRBranchInfo) BranchInfo {
+	if branch == nil {
+		return BranchInfo{}
+	}
+	branchInfo := BranchInfo{Name: branch.Ref}
+	if branch.Repository != nil {
+		branchInfo.Repository = branch.Repository.Name
+		if branch.Repository.Owner != nil {
+			branchInfo.Owner = branch.Repository.Owner.UserName
+		}
+	}
+	return branchInfo
+}
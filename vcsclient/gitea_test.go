@@ -0,0 +1,886 @@
+package vcsclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+func TestGiteaClient_Connection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, gitea.User{UserName: username, FullName: "Frog Ger", Email: "frogger@jfrog.com"},
+		"/api/v1/user", createGiteaHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.NoError(t, client.TestConnectionWithScopes(ctx))
+	assert.ErrorIs(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope), errGiteaTokenScopesNotSupported)
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: username, DisplayName: "Frog Ger", Email: "frogger@jfrog.com"}, user)
+
+	assert.Error(t, createBadGiteaClient(t).TestConnection(ctx))
+}
+
+func TestGiteaClient_ApiEndpoint(t *testing.T) {
+	for _, apiEndpoint := range []string{"https://gitea.example.com", "https://gitea.example.com/", "https://gitea.example.com/api/v1", "https://gitea.example.com/api/v1/"} {
+		client, err := NewGiteaClient(VcsInfo{APIEndpoint: apiEndpoint}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://gitea.example.com", client.vcsInfo.APIEndpoint)
+	}
+}
+
+func TestGiteaClient_ListRepositories(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.Repository{
+		{Name: repo1, Owner: &gitea.User{UserName: owner}},
+		{Name: repo2, Owner: &gitea.User{UserName: owner}},
+		{Name: repo1, Owner: &gitea.User{UserName: username}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/user/repos?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListRepositories(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{owner: {repo1, repo2}, username: {repo1}}, actual)
+}
+
+func TestGiteaClient_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.Repository{{Name: repo2, Owner: &gitea.User{UserName: owner}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/user/repos?limit=10&page=2", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListRepositoriesWithOptions(ctx, ListOptions{Page: 2, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{owner: {repo2}}, actual)
+}
+
+func TestGiteaClient_ListBranches(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []gitea.Branch{{Name: branch1}, {Name: branch2}},
+		"/api/v1/repos/jfrog/repo-1/branches?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch1, branch2}, actual)
+
+	_, err = createBadGiteaClient(t).ListBranches(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListBranchesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []gitea.Branch{{Name: branch2}},
+		"/api/v1/repos/jfrog/repo-1/branches?limit=1&page=2", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch2}, actual)
+
+	_, err = createBadGiteaClient(t).ListBranchesWithOptions(ctx, owner, repo1, ListOptions{})
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	response := gitea.BranchProtection{EnableStatusCheck: true, StatusCheckContexts: []string{"ci/build"}, RequiredApprovals: 2}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/branch_protections/branch-1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{RequiredStatusChecks: []string{"ci/build"}, RequiredApprovals: 2, RestrictPushes: true}, actual)
+
+	client, cleanUp = createServerAndClientReturningStatus(t, vcsutils.Gitea, false, []byte(`{"message": "not found"}`),
+		"/api/v1/repos/jfrog/repo-1/branch_protections/branch-1", http.StatusNotFound, createGiteaHandler)
+	defer cleanUp()
+	actual, err = client.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{}, actual)
+}
+
+func TestGiteaClient_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	var created gitea.CreateBranchProtectionOption
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "GET /api/v1/repos/jfrog/repo-1/branch_protections/branch-1":
+				w.WriteHeader(http.StatusNotFound)
+			case "POST /api/v1/repos/jfrog/repo-1/branch_protections":
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte("{}"))
+				assert.NoError(t, err)
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+		}
+	})
+	defer cleanUp()
+
+	err := client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredStatusChecks: []string{"ci/build"}, RequiredApprovals: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, gitea.CreateBranchProtectionOption{
+		BranchName:          branch1,
+		EnablePush:          true,
+		EnableStatusCheck:   true,
+		StatusCheckContexts: []string{"ci/build"},
+		RequiredApprovals:   1,
+	}, created)
+}
+
+func TestGiteaClient_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	var request gitea.CreateHookOption
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST /api/v1/repos/jfrog/repo-1/hooks", r.Method+" "+r.RequestURI)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"id": 7}`))
+			assert.NoError(t, err)
+		}
+	})
+	defer cleanUp()
+
+	id, token, err := client.CreateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com", vcsutils.Push, vcsutils.TagRemoved, vcsutils.PrOpened)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", id)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, gitea.HookTypeGitea, request.Type)
+	assert.True(t, request.Active)
+	assert.Equal(t, map[string]string{"url": "https://jfrog.com", "content_type": "json", "secret": token}, request.Config)
+	assert.ElementsMatch(t, []string{"push", "pull_request", "pull_request_sync"}, request.Events)
+}
+
+func TestGiteaClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.Hook{{ID: 7, Config: map[string]string{"url": "https://jfrog.com"}, Events: []string{"push", "delete"}, Active: true}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/hooks?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListWebhooks(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "7", actual[0].ID)
+	assert.Equal(t, "https://jfrog.com", actual[0].PayloadURL)
+	assert.True(t, actual[0].Active)
+	assert.ElementsMatch(t, []vcsutils.WebhookEvent{vcsutils.Push, vcsutils.TagPushed, vcsutils.TagRemoved, vcsutils.BranchDeleted}, actual[0].Events)
+}
+
+func TestGiteaClient_SetCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreateStatusOption{State: gitea.StatusFailure, TargetURL: "https://httpbin.org/anything", Description: "Commit status description", Context: "Commit status title"})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.Status{}, "/api/v1/repos/jfrog/repo-1/statuses/f62d4a4ab1bb3d6d20a9e83d0be1c0c7a59d5f0a",
+		http.StatusCreated, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.SetCommitStatus(ctx, Fail, owner, repo1, "f62d4a4ab1bb3d6d20a9e83d0be1c0c7a59d5f0a", "Commit status title",
+		"Commit status description", "https://httpbin.org/anything")
+	assert.NoError(t, err)
+}
+
+func TestGiteaClient_GetCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 3, 12, 8, 40, 31, 0, time.UTC)
+	response := []gitea.Status{
+		{State: gitea.StatusSuccess, Context: "ci/build", Description: "Build passed", TargetURL: "https://ci.example.com/1", Creator: &gitea.User{UserName: username}, Created: created, Updated: created},
+		{State: gitea.StatusWarning, Context: "ci/lint"},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/commits/branch-1/statuses?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.GetCommitStatuses(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommitStatusInfo{
+		{State: Pass, RawState: "success", Context: "ci/build", Description: "Build passed", DetailsUrl: "https://ci.example.com/1", Creator: username, CreatedAt: created, LastUpdatedAt: created},
+		{State: Pass, RawState: "warning", Context: "ci/lint"},
+	}, actual)
+}
+
+func TestGiteaClient_GetCombinedCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	response := gitea.CombinedStatus{Statuses: []*gitea.Status{{State: gitea.StatusSuccess}, {State: gitea.StatusPending}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/commits/branch-1/status", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.GetCombinedCommitStatus(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, InProgress, actual)
+}
+
+func TestGiteaClient_Pipelines(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", createGiteaHandler)
+	defer cleanUp()
+	_, err := client.TriggerPipeline(ctx, owner, repo1, branch1, nil)
+	assert.ErrorIs(t, err, errGiteaPipelinesNotSupported)
+	_, err = client.GetLatestPipelineStatus(ctx, owner, repo1, branch1)
+	assert.ErrorIs(t, err, errGiteaPipelinesNotSupported)
+}
+
+func TestGiteaClient_DownloadRepository(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, vcsutils.RemoveTempDir(dir)) }()
+
+	repoFile, err := os.ReadFile(filepath.Join("testdata", "gitlab", "hello-world-main.tar.gz"))
+	assert.NoError(t, err)
+	repositoryResponse, err := os.ReadFile(filepath.Join("testdata", "gitea", "repository_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var response []byte
+			switch r.RequestURI {
+			case "/api/v1/repos/jfrog/repo-1/archive/main.tar.gz":
+				response = repoFile
+			case "/api/v1/repos/jfrog/repo-1":
+				response = repositoryResponse
+			default:
+				assert.Fail(t, "unexpected request", r.RequestURI)
+			}
+			_, err := w.Write(response)
+			assert.NoError(t, err)
+		}
+	})
+	defer cleanUp()
+
+	err = client.DownloadRepository(ctx, owner, repo1, "main", dir)
+	assert.NoError(t, err)
+	fileinfo, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, fileinfo, 2)
+	assert.Equal(t, ".git", fileinfo[0].Name())
+	assert.Equal(t, "README.md", fileinfo[1].Name())
+}
+
+func TestGiteaClient_DownloadRepositoryPaths(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repoFile := createTarGz(t, "repo-1", map[string]string{"README.md": "Hello", "src/go.mod": "module frog", "docs/guide.md": "Guide"})
+	repositoryResponse, err := os.ReadFile(filepath.Join("testdata", "gitea", "repository_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var response []byte
+			switch r.RequestURI {
+			case "/api/v1/repos/jfrog/repo-1/archive/main.tar.gz":
+				response = repoFile
+			case "/api/v1/repos/jfrog/repo-1":
+				response = repositoryResponse
+			default:
+				assert.Fail(t, "unexpected request", r.RequestURI)
+			}
+			_, err := w.Write(response)
+			assert.NoError(t, err)
+		}
+	})
+	defer cleanUp()
+
+	err = client.DownloadRepositoryPaths(ctx, owner, repo1, "main", []string{"src", "README.md"}, dir)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.FileExists(t, filepath.Join(dir, "src", "go.mod"))
+	assert.NoDirExists(t, filepath.Join(dir, "docs"))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+}
+
+func TestGiteaClient_DownloadRepositoryZipArchive(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repoFile := createZip(t, map[string]string{"repo-1/README.md": "Hello", "repo-1/src/go.mod": "module frog"})
+	repositoryResponse, err := os.ReadFile(filepath.Join("testdata", "gitea", "repository_response.json"))
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.RequestURI {
+		case "/api/v1/repos/jfrog/repo-1/archive/main.zip":
+			response = repoFile
+		case "/api/v1/repos/jfrog/repo-1":
+			response = repositoryResponse
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client, err := NewClientBuilder(vcsutils.Gitea).ApiEndpoint(server.URL).Token(token).ArchiveFormat(vcsutils.ZipArchive).Build()
+	assert.NoError(t, err)
+	assert.NoError(t, client.DownloadRepository(ctx, owner, repo1, "main", dir))
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.FileExists(t, filepath.Join(dir, "src", "go.mod"))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+
+	client, err = NewClientBuilder(vcsutils.Gitea).ApiEndpoint(server.URL).Token(token).ArchiveFormat("rar").Build()
+	assert.NoError(t, err)
+	assert.EqualError(t, client.DownloadRepository(ctx, owner, repo1, "main", dir), "unsupported archive format: 'rar'")
+}
+
+func TestGiteaClient_GetRepositoryInfo(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "repository_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.GetRepositoryInfo(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, RepositoryInfo{
+		RepositoryVisibility: Private,
+		CloneInfo:            CloneInfo{HTTP: "https://gitea.example.com/jfrog/repo-1.git", SSH: "git@gitea.example.com:jfrog/repo-1.git"},
+		DefaultBranch:        "main",
+	}, actual)
+
+	_, err = createBadGiteaClient(t).GetRepositoryInfo(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "pull_request_response.json"))
+	assert.NoError(t, err)
+	expectedBody, err := json.Marshal(gitea.CreatePullRequestOption{Head: branch1, Base: "main", Title: "Update README", Body: "Describe the project"})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/pulls",
+		http.StatusCreated, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	actual, err := client.CreatePullRequest(ctx, owner, repo1, branch1, "main", "Update README", "Describe the project")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), actual.ID)
+	assert.Equal(t, "https://gitea.example.com/jfrog/repo-1/pulls/1", actual.URL)
+}
+
+func TestGiteaClient_CreatePullRequestWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "pull_request_response.json"))
+	assert.NoError(t, err)
+	expectedBody, err := json.Marshal(gitea.CreatePullRequestOption{Head: branch1, Base: "main", Title: "WIP: Update README", Body: "Describe the project"})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/pulls",
+		http.StatusCreated, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	actual, err := client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, "main", "Update README", "Describe the project", CreatePullRequestOptions{Draft: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), actual.ID)
+
+	_, err = client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, "main", "Update README", "Describe the project", CreatePullRequestOptions{DeleteSourceBranch: true})
+	assert.EqualError(t, err, "deleting the source branch on merge is not supported on Gitea")
+}
+
+func TestGiteaClient_CreatePullRequestWithLabelsAndReviewers(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "pull_request_response.json"))
+	assert.NoError(t, err)
+	var requestedReviewers bool
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/pulls",
+		func(t *testing.T, expectedURI string, response []byte, _ int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				var responseBody []byte
+				switch r.RequestURI {
+				case "/api/v1/version":
+					responseBody = []byte(`{"version": "1.21.0"}`)
+				case "/api/v1/repos/jfrog/repo-1/labels?limit=50&page=1":
+					responseBody = []byte(`[{"id": 7, "name": "dependencies"}, {"id": 8, "name": "security"}]`)
+				case expectedURI:
+					var createOptions gitea.CreatePullRequestOption
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&createOptions))
+					assert.Equal(t, []int64{8}, createOptions.Labels)
+					assert.Equal(t, []string{username}, createOptions.Assignees)
+					assert.Equal(t, int64(4), createOptions.Milestone)
+					w.WriteHeader(http.StatusCreated)
+					responseBody = response
+				case expectedURI + "/1/requested_reviewers":
+					var reviewRequest gitea.PullReviewRequestOptions
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&reviewRequest))
+					assert.Equal(t, []string{"reviewer1"}, reviewRequest.Reviewers)
+					requestedReviewers = true
+					w.WriteHeader(http.StatusCreated)
+					responseBody = []byte("[]")
+				default:
+					assert.Fail(t, "unexpected request", r.RequestURI)
+				}
+				_, err := w.Write(responseBody)
+				assert.NoError(t, err)
+			}
+		})
+	defer cleanUp()
+
+	options := CreatePullRequestOptions{Labels: []string{"security"}, Assignees: []string{username}, Milestone: 4, Reviewers: []string{"reviewer1"}}
+	actual, err := client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, "main", "Update README", "Describe the project", options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), actual.ID)
+	assert.True(t, requestedReviewers)
+
+	_, err = client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, "main", "Update README", "Describe the project", CreatePullRequestOptions{Labels: []string{"unknown"}})
+	assert.EqualError(t, err, "label unknown doesn't exist in repository repo-1")
+}
+
+func TestGiteaClient_GetPullRequestByID(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "pull_request_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/pulls/1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.GetPullRequestByID(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, PullRequestInfo{
+		ID:        1,
+		Title:     "Update README",
+		Body:      "Describe the project",
+		URL:       "https://gitea.example.com/jfrog/repo-1/pulls/1",
+		Author:    username,
+		State:     vcsutils.Open,
+		Mergeable: true,
+		CreatedAt: time.Date(2024, 3, 12, 9, 10, 45, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 3, 12, 9, 15, 2, 0, time.UTC),
+		Source:    BranchInfo{Name: branch1, Repository: repo1, Owner: owner},
+		Target:    BranchInfo{Name: "main", Repository: repo1, Owner: owner},
+	}, actual)
+
+	_, err = createBadGiteaClient(t).GetPullRequestByID(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGiteaClient_ListPullRequestsWithState(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.PullRequest{
+		{Index: 1, State: gitea.StateClosed, HasMerged: true},
+		{Index: 2, State: gitea.StateClosed},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/pulls?limit=50&page=1&state=closed", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Merged)
+	assert.NoError(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, int64(1), actual[0].ID)
+
+	actual, err = client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Closed)
+	assert.NoError(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, int64(2), actual[0].ID)
+}
+
+func TestGiteaClient_UpdatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.EditPullRequestOption{Title: "New title", Body: "New body", Base: "main", State: vcsutils.PointerOf(gitea.StateClosed)})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.PullRequest{}, "/api/v1/repos/jfrog/repo-1/pulls/1",
+		http.StatusCreated, expectedBody, http.MethodPatch, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.UpdatePullRequest(ctx, owner, repo1, "New title", "New body", "main", 1, vcsutils.Closed))
+}
+
+func TestGiteaClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.MergePullRequestOption{Style: gitea.MergeStyleSquash})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, nil, "/api/v1/repos/jfrog/repo-1/pulls/1/merge",
+		http.StatusOK, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+	assert.NoError(t, client.MergePullRequest(ctx, owner, repo1, 1, vcsutils.MergeMethodSquash))
+
+	client, cleanUp = createServerAndClientReturningStatus(t, vcsutils.Gitea, false, nil, "/api/v1/repos/jfrog/repo-1/pulls/1/merge", http.StatusMethodNotAllowed, createGiteaHandler)
+	defer cleanUp()
+	assert.ErrorContains(t, client.MergePullRequest(ctx, owner, repo1, 1, vcsutils.MergeMethodMerge), "failed to merge pull request 1")
+}
+
+func TestGiteaClient_SetPullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.MergePullRequestOption{Style: gitea.MergeStyleRebase, MergeWhenChecksSucceed: true})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, nil, "/api/v1/repos/jfrog/repo-1/pulls/1/merge",
+		http.StatusOK, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+	assert.NoError(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 1, vcsutils.MergeMethodRebase))
+	assert.Error(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 1, "fast-forward"))
+}
+
+func TestGiteaClient_ListPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 3, 12, 9, 45, 7, 0, time.UTC)
+	response := []gitea.Comment{{ID: 41, Body: "Looks good", Poster: &gitea.User{UserName: username, FullName: "Frog Ger"}, Created: created, Updated: created}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/issues/1/comments?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{{ID: 41, NativeID: "41", Content: "Looks good", Author: "Frog Ger", AuthorUsername: username, Created: created, Updated: created}}, actual)
+}
+
+func TestGiteaClient_ListPullRequestCommentsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 3, 12, 9, 45, 7, 0, time.UTC)
+	response := []gitea.Comment{{ID: 41, Body: "Looks good", Created: created, Updated: created}}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/issues/1/comments?limit=10&page=2&since=2024-03-12T00%3A00%3A00Z", createGiteaHandler)
+	defer cleanUp()
+
+	options := ListPullRequestCommentsOptions{UpdatedAfter: time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), Page: 2, PerPage: 10}
+	actual, err := client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{{ID: 41, NativeID: "41", Content: "Looks good", Created: created, Updated: created}}, actual)
+}
+
+func TestGiteaClient_ListPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var response interface{}
+			switch r.RequestURI {
+			case "/api/v1/repos/jfrog/repo-1/pulls/1/reviews?limit=50&page=1":
+				response = []gitea.PullReview{{ID: 3, CodeCommentsCount: 2}, {ID: 4}}
+			case "/api/v1/repos/jfrog/repo-1/pulls/1/reviews/3/comments":
+				response = []gitea.PullReviewComment{
+					{ID: 51, Body: "Rename", Reviewer: &gitea.User{UserName: username, FullName: "Frog Ger"}, Path: "main.go", LineNum: 12, DiffHunk: "@@ -10,3 +10,4 @@"},
+					{ID: 52, Body: "Why?", Path: "old.go", OldLineNum: 7},
+				}
+			default:
+				assert.Fail(t, "unexpected request", r.RequestURI)
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(response))
+		}
+	})
+	defer cleanUp()
+
+	actual, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CommentInfo{
+		{ID: 51, NativeID: "51", Content: "Rename", Author: "Frog Ger", AuthorUsername: username, FilePath: "main.go", Line: 12, DiffHunk: "@@ -10,3 +10,4 @@"},
+		{ID: 52, NativeID: "52", Content: "Why?", FilePath: "old.go", Line: 7},
+	}, actual)
+}
+
+func TestGiteaClient_AddPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	expectedBody, err := json.Marshal(gitea.CreatePullReviewOptions{
+		State:    gitea.ReviewStateComment,
+		Comments: []gitea.CreatePullReviewComment{{Path: "main.go", Body: "Rename", NewLineNum: 12}},
+	})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.Gitea, false, gitea.PullReview{}, "/api/v1/repos/jfrog/repo-1/pulls/1/reviews",
+		http.StatusOK, expectedBody, http.MethodPost, createGiteaWithBodyHandler)
+	defer cleanUp()
+
+	err = client.AddPullRequestReviewComments(ctx, owner, repo1, 1, PullRequestComment{
+		CommentInfo:     CommentInfo{Content: "Rename"},
+		PullRequestDiff: PullRequestDiff{NewFilePath: "main.go", NewStartLine: 12},
+	})
+	assert.NoError(t, err)
+	assert.Error(t, client.AddPullRequestReviewComments(ctx, owner, repo1, 1))
+}
+
+func TestGiteaClient_ListPullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	submitted := time.Date(2024, 3, 12, 9, 45, 7, 0, time.UTC)
+	response := []gitea.PullReview{
+		{State: gitea.ReviewStateApproved, Reviewer: &gitea.User{UserName: username}, Submitted: submitted},
+		{State: gitea.ReviewStatePending, Reviewer: &gitea.User{UserName: username}},
+		{State: gitea.ReviewStateRequestChanges, Body: "Add tests"},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/pulls/1/reviews?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListPullRequestReviews(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewInfo{
+		{Reviewer: username, State: vcsutils.ReviewVerdictApprove, Submitted: submitted},
+		{State: vcsutils.ReviewVerdictRequestChanges, Body: "Add tests"},
+	}, actual)
+}
+
+func TestGiteaClient_GetCommitBySha(t *testing.T) {
+	ctx := context.Background()
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "commit_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/git/commits/"+sha, createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.GetCommitBySha(ctx, owner, repo1, sha)
+	assert.NoError(t, err)
+	assert.Equal(t, CommitInfo{
+		Hash:          sha,
+		AuthorName:    "Frog Ger",
+		CommitterName: "Frog Ger",
+		Url:           "https://gitea.example.com/jfrog/repo-1/commit/" + sha,
+		Timestamp:     1710232831,
+		Message:       "Fix all the bugs\n",
+		ParentHashes:  []string{"5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b"},
+		AuthorEmail:   "frogger@jfrog.com",
+	}, actual)
+}
+
+func TestGiteaClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	commit, err := os.ReadFile(filepath.Join("testdata", "gitea", "commit_response.json"))
+	assert.NoError(t, err)
+	response := []byte("[" + string(commit) + "]")
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/commits?limit=50&page=1&path=README.md&sha=branch-1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: branch1, Path: "README.md"})
+	assert.NoError(t, err)
+	assert.Len(t, actual, 1)
+
+	// The commit was created before the given time
+	actual, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: branch1, Path: "README.md", Since: time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+}
+
+func TestGiteaClient_GetLatestCommit(t *testing.T) {
+	ctx := context.Background()
+	commit, err := os.ReadFile(filepath.Join("testdata", "gitea", "commit_response.json"))
+	assert.NoError(t, err)
+	response := []byte("[" + string(commit) + "]")
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/commits?limit=1&page=1&sha=branch-1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.GetLatestCommit(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", actual.Hash)
+}
+
+func TestGiteaClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitea", "compare_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/compare/main...branch-1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.CompareCommits(ctx, owner, repo1, "main", branch1)
+	assert.NoError(t, err)
+	assert.Len(t, actual.Commits, 2)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", actual.Commits[0].Hash)
+	assert.Equal(t, "7e3f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f", actual.Commits[1].Hash)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "main.go", ChangeType: FileAdded},
+		{Path: "old.txt", ChangeType: FileModified},
+	}, actual.Files)
+
+	modifiedFiles, err := client.GetModifiedFiles(ctx, owner, repo1, "main", branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "main.go", "old.txt"}, modifiedFiles)
+}
+
+func TestGiteaClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	response := []gitea.ChangedFile{
+		{Filename: "main.go", Status: "added"},
+		{Filename: "README.md", Status: "changed"},
+		{Filename: "docs/guide.md", PreviousFilename: "guide.md", Status: "renamed"},
+		{Filename: "old.txt", Status: "deleted"},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, response, "/api/v1/repos/jfrog/repo-1/pulls/1/files?limit=50&page=1", createGiteaHandler)
+	defer cleanUp()
+
+	actual, err := client.ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "main.go", ChangeType: FileAdded},
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "docs/guide.md", PreviousPath: "guide.md", ChangeType: FileRenamed},
+		{Path: "old.txt", ChangeType: FileDeleted},
+	}, actual)
+}
+
+func TestGiteaClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	var request giteaChangeFilesRequest
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+			switch r.Method + " " + r.RequestURI {
+			case "GET /api/v1/repos/jfrog/repo-1/contents/go.mod?ref=branch-1":
+				_, err := w.Write([]byte(`{"sha": "go-mod-sha"}`))
+				assert.NoError(t, err)
+			case "GET /api/v1/repos/jfrog/repo-1/contents/old.txt?ref=branch-1":
+				_, err := w.Write([]byte(`{"sha": "old-sha"}`))
+				assert.NoError(t, err)
+			case "POST /api/v1/repos/jfrog/repo-1/contents":
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				w.WriteHeader(http.StatusCreated)
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+		}
+	})
+	defer cleanUp()
+
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileToCommit{
+		{Path: "go.mod", Content: []byte("module frogger")},
+		{Path: "go.sum", Content: []byte("checksums"), ChangeType: FileAdded},
+		{Path: "old.txt", ChangeType: FileDeleted},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, giteaChangeFilesRequest{
+		Branch:  branch1,
+		Message: "Update dependencies",
+		Files: []giteaChangeFileOperation{
+			{Operation: "update", Path: "go.mod", Content: base64.StdEncoding.EncodeToString([]byte("module frogger")), SHA: "go-mod-sha"},
+			{Operation: "create", Path: "go.sum", Content: base64.StdEncoding.EncodeToString([]byte("checksums"))},
+			{Operation: "delete", Path: "old.txt", SHA: "old-sha"},
+		},
+	}, request)
+}
+
+func TestGiteaClient_DownloadFileFromRepo(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, []byte("Hello World!"), "/api/v1/repos/jfrog/repo-1/raw/hello-world?ref=branch-1", createGiteaHandler)
+	defer cleanUp()
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "Hello World!", string(content))
+
+	client, cleanUp = createServerAndClientReturningStatus(t, vcsutils.Gitea, false, nil, "/api/v1/repos/jfrog/repo-1/raw/hello-world?ref=branch-1", http.StatusNotFound, createGiteaHandler)
+	defer cleanUp()
+	_, statusCode, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "hello-world")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+func TestGiteaClient_GetRepositoryPermission(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, gitea.CollaboratorPermissionResult{Permission: gitea.AccessModeOwner},
+		"/api/v1/repos/jfrog/repo-1/collaborators/frogger/permission", createGiteaHandler)
+	defer cleanUp()
+
+	permission, err := client.GetRepositoryPermission(ctx, owner, repo1, username)
+	assert.NoError(t, err)
+	assert.Equal(t, AdminPermission, permission)
+}
+
+func TestGiteaClient_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	var request gitea.IssueLabelsOption
+	client, cleanUp := createServerAndClient(t, vcsutils.Gitea, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "GET /api/v1/repos/jfrog/repo-1/labels?limit=50&page=1":
+				assert.NoError(t, json.NewEncoder(w).Encode([]gitea.Label{{ID: 3, Name: labelName, Color: "0075ca"}, {ID: 4, Name: "bug"}}))
+			case "POST /api/v1/repos/jfrog/repo-1/issues/1/labels":
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				_, err := w.Write([]byte("[]"))
+				assert.NoError(t, err)
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+		}
+	})
+	defer cleanUp()
+
+	assert.NoError(t, client.LabelPullRequest(ctx, owner, repo1, 1, []string{labelName}))
+	assert.Equal(t, []int64{3}, request.Labels)
+	assert.ErrorContains(t, client.LabelPullRequest(ctx, owner, repo1, 1, []string{"missing"}), "label missing doesn't exist")
+
+	label, err := client.GetLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+	assert.Equal(t, &LabelInfo{Name: labelName, Color: "0075ca"}, label)
+}
+
+func TestGiteaClient_UnsupportedMethods(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.Gitea).ApiEndpoint("https://gitea.example.com").Token(token).Build()
+	assert.NoError(t, err)
+
+	_, err = client.ListAppInstallations(ctx)
+	assert.ErrorIs(t, err, errGiteaAppInstallationsNotSupported)
+	_, err = client.UploadCodeScanning(ctx, owner, repo1, branch1, "{}")
+	assert.ErrorIs(t, err, errGiteaCodeScanningNotSupported)
+	_, err = client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
+	assert.ErrorIs(t, err, errGiteaGetRepoEnvironmentInfoNotSupported)
+	_, err = client.ListEnvironments(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errGiteaDeploymentsNotSupported)
+	assert.ErrorIs(t, client.SetDeploymentStatus(ctx, owner, repo1, "42", DeploymentSuccess, "", ""), errGiteaDeploymentsNotSupported)
+	_, err = client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errGiteaRepositorySecretsNotSupported)
+	_, err = client.ListOrganizationSecrets(ctx, owner)
+	assert.ErrorIs(t, err, errGiteaSecretsNotSupported)
+	assert.ErrorIs(t, client.CreateCodeInsightsReport(ctx, owner, repo1, "sha", CodeInsightsReport{}), errGiteaCodeInsightsNotSupported)
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{})
+	assert.ErrorIs(t, err, errGiteaCheckRunsNotSupported)
+}
+
+func TestCombineGiteaFileChange(t *testing.T) {
+	tests := []struct {
+		changeTypes []FileChangeType
+		expected    FileChangeType
+		exists      bool
+	}{
+		{changeTypes: []FileChangeType{FileAdded}, expected: FileAdded, exists: true},
+		{changeTypes: []FileChangeType{FileAdded, FileModified}, expected: FileAdded, exists: true},
+		{changeTypes: []FileChangeType{FileAdded, FileDeleted}},
+		{changeTypes: []FileChangeType{FileDeleted, FileAdded}, expected: FileModified, exists: true},
+		{changeTypes: []FileChangeType{FileModified, FileDeleted}, expected: FileDeleted, exists: true},
+	}
+	for _, test := range tests {
+		changeTypes := make(map[string]FileChangeType)
+		for _, changeType := range test.changeTypes {
+			combineGiteaFileChange(changeTypes, "file", changeType)
+		}
+		actual, exists := changeTypes["file"]
+		assert.Equal(t, test.exists, exists)
+		assert.Equal(t, test.expected, actual)
+	}
+}
+
+func createBadGiteaClient(t *testing.T) VcsClient {
+	client, err := NewClientBuilder(vcsutils.Gitea).ApiEndpoint("https://bad^endpoint").Build()
+	assert.NoError(t, err)
+	return client
+}
+
+func createGiteaHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, expectedURI, r.RequestURI)
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+		w.WriteHeader(expectedStatusCode)
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func createGiteaWithBodyHandler(t *testing.T, expectedURI string, response []byte, expectedRequestBody []byte,
+	expectedStatusCode int, expectedHTTPMethod string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, expectedHTTPMethod, r.Method)
+		assert.Equal(t, expectedURI, r.RequestURI)
+		assert.Equal(t, "token "+token, r.Header.Get("Authorization"))
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expectedRequestBody), string(b))
+
+		w.WriteHeader(expectedStatusCode)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
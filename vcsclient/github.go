@@ -1,15 +1,20 @@
 package vcsclient
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	stdbase64 "encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/google/go-github/v56/github"
 	"github.com/grokify/mogo/encoding/base64"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/mitchellh/mapstructure"
+	"golang.org/x/crypto/nacl/box"
 	"golang.org/x/exp/slices"
 	"golang.org/x/oauth2"
 	"io"
@@ -26,10 +31,18 @@ const (
 	retriesIntervalMilliSecs = 60000
 	// https://github.com/orgs/community/discussions/27190
 	githubPrContentSizeLimit = 65536
+	// https://docs.github.com/en/rest/checks/runs#update-a-check-run
+	githubCheckRunAnnotationsLimit = 50
 )
 
 var rateLimitRetryStatuses = []int{http.StatusForbidden, http.StatusTooManyRequests}
 
+var (
+	errGitHubCodeInsightsNotSupported = errors.New("code insights reports are not supported on GitHub, use check runs instead")
+	errGitHubPipelinesNotSupported    = errors.New("triggering pipelines is not supported on GitHub, trigger a workflow instead")
+	errGitHubBuildsNotSupported       = errors.New("queueing builds is not supported on GitHub, trigger a workflow instead")
+)
+
 type GitHubRateLimitExecutionHandler func() (*github.Response, error)
 
 type GitHubRateLimitRetryExecutor struct {
@@ -51,18 +64,27 @@ type GitHubClient struct {
 	rateLimitRetryExecutor GitHubRateLimitRetryExecutor
 	logger                 vcsutils.Log
 	ghClient               *github.Client
+	// appClient is authenticated as the GitHub App itself, and is set only when using GitHub App authentication
+	appClient *github.Client
 }
 
 // NewGitHubClient create a new GitHubClient
 func NewGitHubClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GitHubClient, error) {
-	ghClient, err := buildGithubClient(vcsInfo, logger)
+	var ghClient, appClient *github.Client
+	var err error
+	if vcsInfo.GitHubAppID != 0 {
+		ghClient, appClient, err = buildGitHubAppClients(vcsInfo, logger)
+	} else {
+		ghClient, err = buildGithubClient(vcsInfo, logger)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &GitHubClient{
-			vcsInfo:  vcsInfo,
-			logger:   logger,
-			ghClient: ghClient,
+			vcsInfo:   vcsInfo,
+			logger:    logger,
+			ghClient:  ghClient,
+			appClient: appClient,
 			rateLimitRetryExecutor: GitHubRateLimitRetryExecutor{RetryExecutor: vcsutils.RetryExecutor{
 				Logger:                   logger,
 				MaxRetries:               maxRetries,
@@ -82,14 +104,71 @@ func (client *GitHubClient) TestConnection(ctx context.Context) error {
 	return err
 }
 
+// TestConnectionWithScopes on GitHub.
+// The scopes are read from the X-OAuth-Scopes header, which is returned only for OAuth apps and classic personal access tokens.
+// Other tokens, such as fine-grained personal access tokens and GitHub App tokens, are checked for connectivity only.
+func (client *GitHubClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	var ghResponse *github.Response
+	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var err error
+		_, ghResponse, err = client.ghClient.Users.Get(ctx, "")
+		return ghResponse, err
+	})
+	if err != nil {
+		return err
+	}
+	oauthScopesHeader, exists := ghResponse.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]
+	if !exists {
+		return nil
+	}
+	tokenScopes := datastructures.MakeSet[string]()
+	for _, oauthScopes := range oauthScopesHeader {
+		for _, oauthScope := range strings.Split(oauthScopes, ",") {
+			tokenScopes.Add(strings.TrimSpace(oauthScope))
+		}
+	}
+	return validateTokenScopes(scopes, func(scope TokenScope) bool {
+		return slices.ContainsFunc(gitHubOAuthScopes[scope], tokenScopes.Exists)
+	})
+}
+
+// gitHubOAuthScopes maps each token scope to the GitHub OAuth scopes which grant it
+var gitHubOAuthScopes = map[TokenScope][]string{
+	RepositoryReadScope:  {"repo", "public_repo"},
+	RepositoryWriteScope: {"repo", "public_repo"},
+	WebhookAdminScope:    {"repo", "admin:repo_hook", "write:repo_hook"},
+}
+
+// GetAuthenticatedUser on GitHub.
+// The email is returned only if the user has set a public email address.
+func (client *GitHubClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	var user *github.User
+	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		user, ghResponse, err = client.ghClient.Users.Get(ctx, "")
+		return ghResponse, err
+	})
+	if err != nil {
+		return UserInfo{}, err
+	}
+	return UserInfo{Username: user.GetLogin(), DisplayName: user.GetName(), Email: user.GetEmail()}, nil
+}
+
 func buildGithubClient(vcsInfo VcsInfo, logger vcsutils.Log) (*github.Client, error) {
-	httpClient := &http.Client{}
-	if vcsInfo.Token != "" {
-		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: vcsInfo.Token}))
+	httpClient := newHttpClient(vcsInfo)
+	if vcsInfo.TokenProvider != nil {
+		httpClient = newTokenProviderHttpClient(vcsInfo, setBearerAuthorization)
+	} else if vcsInfo.Token != "" {
+		httpClient = newOAuth2HttpClient(vcsInfo, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: vcsInfo.Token}))
 	}
+	return buildGithubClientWithHttpClient(httpClient, vcsInfo.APIEndpoint, logger)
+}
+
+func buildGithubClientWithHttpClient(httpClient *http.Client, apiEndpoint string, logger vcsutils.Log) (*github.Client, error) {
 	ghClient := github.NewClient(httpClient)
-	if vcsInfo.APIEndpoint != "" {
-		baseURL, err := url.Parse(strings.TrimSuffix(vcsInfo.APIEndpoint, "/") + "/")
+	if apiEndpoint != "" {
+		baseURL, err := url.Parse(strings.TrimSuffix(apiEndpoint, "/") + "/")
 		if err != nil {
 			return nil, err
 		}
@@ -153,18 +232,118 @@ func (client *GitHubClient) executeListRepositoriesInPage(ctx context.Context, p
 	return client.ghClient.Repositories.List(ctx, "", options)
 }
 
+// ListRepositoriesWithOptions on GitHub
+func (client *GitHubClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	page, perPage := options.getPagination()
+	var repositories []*github.Repository
+	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		repositories, ghResponse, err = client.ghClient.Repositories.List(ctx, "", &github.RepositoryListOptions{ListOptions: github.ListOptions{Page: page, PerPage: perPage}})
+		return ghResponse, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for _, repo := range repositories {
+		results[repo.GetOwner().GetLogin()] = append(results[repo.GetOwner().GetLogin()], repo.GetName())
+	}
+	return results, nil
+}
+
+// ListAppInstallations on GitHub
+func (client *GitHubClient) ListAppInstallations(ctx context.Context) ([]AppInstallationInfo, error) {
+	if client.appClient == nil {
+		return nil, errGitHubAppNotConfigured
+	}
+	var results []AppInstallationInfo
+	for nextPage := 1; ; nextPage++ {
+		var installations []*github.Installation
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+			var err error
+			installations, ghResponse, err = client.appClient.Apps.ListInstallations(ctx, &github.ListOptions{Page: nextPage})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, installation := range installations {
+			results = append(results, AppInstallationInfo{
+				ID:                  installation.GetID(),
+				Account:             installation.GetAccount().GetLogin(),
+				RepositorySelection: installation.GetRepositorySelection(),
+			})
+		}
+		if nextPage+1 > ghResponse.LastPage {
+			break
+		}
+	}
+	return results, nil
+}
+
+// ListInstallationRepositories on GitHub
+func (client *GitHubClient) ListInstallationRepositories(ctx context.Context, installationID int64) (map[string][]string, error) {
+	if client.appClient == nil {
+		return nil, errGitHubAppNotConfigured
+	}
+	installationClient, err := buildGitHubInstallationClient(client.vcsInfo, client.appClient, installationID)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for nextPage := 1; ; nextPage++ {
+		var repositories *github.ListRepositories
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			repositories, ghResponse, err = installationClient.Apps.ListRepos(ctx, &github.ListOptions{Page: nextPage})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repositories.Repositories {
+			results[repo.GetOwner().GetLogin()] = append(results[repo.GetOwner().GetLogin()], repo.GetName())
+		}
+		if nextPage+1 > ghResponse.LastPage {
+			break
+		}
+	}
+	return results, nil
+}
+
 // ListBranches on GitHub
 func (client *GitHubClient) ListBranches(ctx context.Context, owner, repository string) (branchList []string, err error) {
+	for nextPage := 1; nextPage != 0; {
+		var branchesInPage []string
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			branchesInPage, ghResponse, err = client.executeListBranch(ctx, owner, repository, github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return
+		}
+		branchList = append(branchList, branchesInPage...)
+		nextPage = ghResponse.NextPage
+	}
+	return
+}
+
+// ListBranchesWithOptions on GitHub
+func (client *GitHubClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) (branchList []string, err error) {
+	page, perPage := options.getPagination()
 	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		branchList, ghResponse, err = client.executeListBranch(ctx, owner, repository)
+		branchList, ghResponse, err = client.executeListBranch(ctx, owner, repository, github.ListOptions{Page: page, PerPage: perPage})
 		return ghResponse, err
 	})
 	return
 }
 
-func (client *GitHubClient) executeListBranch(ctx context.Context, owner, repository string) ([]string, *github.Response, error) {
-	branches, ghResponse, err := client.ghClient.Repositories.ListBranches(ctx, owner, repository, nil)
+func (client *GitHubClient) executeListBranch(ctx context.Context, owner, repository string, listOptions github.ListOptions) ([]string, *github.Response, error) {
+	branches, ghResponse, err := client.ghClient.Repositories.ListBranches(ctx, owner, repository, &github.BranchListOptions{ListOptions: listOptions})
 	if err != nil {
 		return []string{}, ghResponse, err
 	}
@@ -176,354 +355,2221 @@ func (client *GitHubClient) executeListBranch(ctx context.Context, owner, reposi
 	return branchList, ghResponse, nil
 }
 
-// CreateWebhook on GitHub
-func (client *GitHubClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
-	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
-	token := vcsutils.CreateToken()
-	hook := createGitHubHook(token, payloadURL, webhookEvents...)
-	var ghResponseHook *github.Hook
-	var err error
-	if err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+// CreateBranch on GitHub
+func (client *GitHubClient) CreateBranch(ctx context.Context, owner, repository, sourceRef, newBranch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sourceRef": sourceRef, "newBranch": newBranch})
+	if err != nil {
+		return err
+	}
+
+	var sourceSha string
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		ghResponseHook, ghResponse, err = client.ghClient.Repositories.CreateHook(ctx, owner, repository, hook)
+		sourceSha, ghResponse, err = client.ghClient.Repositories.GetCommitSHA1(ctx, owner, repository, sourceRef, "")
 		return ghResponse, err
-	}); err != nil {
-		return "", "", err
+	})
+	if err != nil {
+		return err
 	}
 
-	return strconv.FormatInt(*ghResponseHook.ID, 10), token, nil
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Git.CreateRef(ctx, owner, repository, &github.Reference{
+			Ref:    github.String(vcsutils.AddBranchPrefix(newBranch)),
+			Object: &github.GitObject{SHA: &sourceSha},
+		})
+		return ghResponse, err
+	})
 }
 
-// UpdateWebhook on GitHub
-func (client *GitHubClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
-	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
-	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+// DeleteBranch on GitHub
+func (client *GitHubClient) DeleteBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
 		return err
 	}
-
-	hook := createGitHubHook(token, payloadURL, webhookEvents...)
 	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Git.DeleteRef(ctx, owner, repository, vcsutils.AddBranchPrefix(branch))
+	})
+}
+
+// GetDefaultBranch on GitHub
+func (client *GitHubClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return "", err
+	}
+	var repo *github.Repository
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		_, ghResponse, err = client.ghClient.Repositories.EditHook(ctx, owner, repository, webhookIDInt64, hook)
+		repo, ghResponse, err = client.ghClient.Repositories.Get(ctx, owner, repository)
 		return ghResponse, err
 	})
+	if err != nil {
+		return "", err
+	}
+	return repo.GetDefaultBranch(), nil
 }
 
-// DeleteWebhook on GitHub
-func (client *GitHubClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
-	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+// SetDefaultBranch on GitHub
+func (client *GitHubClient) SetDefaultBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
 	if err != nil {
 		return err
 	}
-
 	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		return client.ghClient.Repositories.DeleteHook(ctx, owner, repository, webhookIDInt64)
+		_, ghResponse, err := client.ghClient.Repositories.Edit(ctx, owner, repository, &github.Repository{DefaultBranch: &branch})
+		return ghResponse, err
 	})
 }
 
-// SetCommitStatus on GitHub
-func (client *GitHubClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
-	title, description, detailsURL string) error {
-	state := getGitHubCommitState(commitStatus)
-	status := &github.RepoStatus{
-		Context:     &title,
-		TargetURL:   &detailsURL,
-		State:       &state,
-		Description: &description,
+// GetBranchProtection on GitHub
+func (client *GitHubClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (BranchProtectionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return BranchProtectionInfo{}, err
 	}
 
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		_, ghResponse, err := client.ghClient.Repositories.CreateStatus(ctx, owner, repository, ref, status)
+	var protection *github.Protection
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		protection, ghResponse, err = client.ghClient.Repositories.GetBranchProtection(ctx, owner, repository, branch)
 		return ghResponse, err
 	})
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return BranchProtectionInfo{}, nil
+	}
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+
+	branchProtection := BranchProtectionInfo{
+		// Requiring pull requests blocks direct pushes to the branch
+		RestrictPushes: protection.RequiredPullRequestReviews != nil || protection.Restrictions != nil,
+	}
+	if protection.RequiredPullRequestReviews != nil {
+		branchProtection.RequiredApprovals = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	}
+	if protection.RequiredStatusChecks != nil {
+		for _, check := range protection.RequiredStatusChecks.Checks {
+			branchProtection.RequiredStatusChecks = append(branchProtection.RequiredStatusChecks, check.Context)
+		}
+	}
+	return branchProtection, nil
 }
 
-// GetCommitStatuses on GitHub
-func (client *GitHubClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (statusInfoList []CommitStatusInfo, err error) {
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		var ghResponse *github.Response
-		statusInfoList, ghResponse, err = client.executeGetCommitStatuses(ctx, owner, repository, ref)
+// SetBranchProtection on GitHub.
+// Pushes are restricted by requiring pull requests, which is also implied by required approvals.
+func (client *GitHubClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtectionInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+
+	protectionRequest := &github.ProtectionRequest{}
+	if protection.RestrictPushes || protection.RequiredApprovals > 0 {
+		protectionRequest.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{RequiredApprovingReviewCount: protection.RequiredApprovals}
+	}
+	if len(protection.RequiredStatusChecks) > 0 {
+		checks := make([]*github.RequiredStatusCheck, 0, len(protection.RequiredStatusChecks))
+		for _, statusContext := range protection.RequiredStatusChecks {
+			checks = append(checks, &github.RequiredStatusCheck{Context: statusContext})
+		}
+		protectionRequest.RequiredStatusChecks = &github.RequiredStatusChecks{Checks: checks}
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Repositories.UpdateBranchProtection(ctx, owner, repository, branch, protectionRequest)
 		return ghResponse, err
 	})
-	return
 }
 
-func (client *GitHubClient) executeGetCommitStatuses(ctx context.Context, owner, repository, ref string) (statusInfoList []CommitStatusInfo, ghResponse *github.Response, err error) {
-	statuses, ghResponse, err := client.ghClient.Repositories.GetCombinedStatus(ctx, owner, repository, ref, nil)
+// ListTags on GitHub
+func (client *GitHubClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	for _, singleStatus := range statuses.Statuses {
-		statusInfoList = append(statusInfoList, CommitStatusInfo{
-			State:         commitStatusAsStringToStatus(*singleStatus.State),
-			Description:   singleStatus.GetDescription(),
-			DetailsUrl:    singleStatus.GetTargetURL(),
-			Creator:       singleStatus.GetCreator().GetName(),
-			LastUpdatedAt: singleStatus.GetUpdatedAt().Time,
-			CreatedAt:     singleStatus.GetCreatedAt().Time,
+	var results []TagInfo
+	for nextPage := 1; nextPage != 0; {
+		var tags []*github.RepositoryTag
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			tags, ghResponse, err = client.ghClient.Repositories.ListTags(ctx, owner, repository, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
 		})
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			results = append(results, TagInfo{Name: tag.GetName(), CommitSha: tag.GetCommit().GetSHA()})
+		}
+		nextPage = ghResponse.NextPage
 	}
-	return
+	return results, nil
 }
 
-// DownloadRepository on GitHub
-func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) (err error) {
-	// Get the archive download link from GitHub
-	var baseURL *url.URL
+// CreateTag on GitHub
+func (client *GitHubClient) CreateTag(ctx context.Context, owner, repository, tagName, sourceRef, message string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName, "sourceRef": sourceRef})
+	if err != nil {
+		return err
+	}
+
+	var targetSha string
 	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		baseURL, ghResponse, err = client.executeGetArchiveLink(ctx, owner, repository, branch)
+		targetSha, ghResponse, err = client.ghClient.Repositories.GetCommitSHA1(ctx, owner, repository, sourceRef, "")
 		return ghResponse, err
 	})
 	if err != nil {
-		return
+		return err
 	}
 
-	// Download the archive
-	httpResponse, err := executeDownloadArchiveFromLink(baseURL.String())
-	if err != nil {
-		return
+	if message != "" {
+		// An annotated tag is a git object, which the tag ref should point to
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			tag, ghResponse, err := client.ghClient.Git.CreateTag(ctx, owner, repository, &github.Tag{
+				Tag:     &tagName,
+				Message: &message,
+				Object:  &github.GitObject{Type: github.String("commit"), SHA: &targetSha},
+			})
+			if err == nil {
+				targetSha = tag.GetSHA()
+			}
+			return ghResponse, err
+		})
+		if err != nil {
+			return err
+		}
 	}
-	defer func() { err = errors.Join(err, httpResponse.Body.Close()) }()
-	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
 
-	// Untar the archive
-	if err = vcsutils.Untar(localPath, httpResponse.Body, true); err != nil {
-		return
-	}
-	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Git.CreateRef(ctx, owner, repository, &github.Reference{
+			Ref:    github.String(vcsutils.TagPrefix + tagName),
+			Object: &github.GitObject{SHA: &targetSha},
+		})
+		return ghResponse, err
+	})
+}
 
-	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
+// GetTagInfo on GitHub
+func (client *GitHubClient) GetTagInfo(ctx context.Context, owner, repository, tagName string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
 	if err != nil {
-		return
+		return CommitInfo{}, err
 	}
-	// Create a .git folder in the archive with the remote repository HTTP clone url
-	err = vcsutils.CreateDotGitFolderWithRemote(localPath, vcsutils.RemoteName, repositoryInfo.CloneInfo.HTTP)
-	return
-}
 
-func (client *GitHubClient) executeGetArchiveLink(ctx context.Context, owner, repository, branch string) (baseURL *url.URL, ghResponse *github.Response, err error) {
-	client.logger.Debug("Getting GitHub archive link to download")
-	return client.ghClient.Repositories.GetArchiveLink(ctx, owner, repository, github.Tarball,
-		&github.RepositoryContentGetOptions{Ref: branch}, 5)
+	var commit *github.RepositoryCommit
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		commit, ghResponse, err = client.ghClient.Repositories.GetCommit(ctx, owner, repository, "tags/"+tagName, nil)
+		return ghResponse, err
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapGitHubCommitToCommitInfo(commit), nil
 }
 
-func executeDownloadArchiveFromLink(baseURL string) (*http.Response, error) {
-	httpClient := &http.Client{}
-	req, err := http.NewRequest(http.MethodGet, baseURL, nil)
+// CreateRelease on GitHub
+func (client *GitHubClient) CreateRelease(ctx context.Context, owner, repository, tagName, name, releaseNotes string) (ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
 	if err != nil {
-		return nil, err
+		return ReleaseInfo{}, err
 	}
-	httpResponse, err := httpClient.Do(req)
+
+	var release *github.RepositoryRelease
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		release, ghResponse, err = client.ghClient.Repositories.CreateRelease(ctx, owner, repository, &github.RepositoryRelease{
+			TagName: &tagName,
+			Name:    &name,
+			Body:    &releaseNotes,
+		})
+		return ghResponse, err
+	})
 	if err != nil {
-		return httpResponse, err
+		return ReleaseInfo{}, err
 	}
-	return httpResponse, vcsutils.CheckResponseStatusWithBody(httpResponse, http.StatusOK)
+	return mapGitHubReleaseToReleaseInfo(release), nil
 }
 
-func (client *GitHubClient) GetPullRequestCommentSizeLimit() int {
-	return githubPrContentSizeLimit
-}
+// ListReleases on GitHub
+func (client *GitHubClient) ListReleases(ctx context.Context, owner, repository string) ([]ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ReleaseInfo
+	for nextPage := 1; nextPage != 0; {
+		var releases []*github.RepositoryRelease
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			releases, ghResponse, err = client.ghClient.Repositories.ListReleases(ctx, owner, repository, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			results = append(results, mapGitHubReleaseToReleaseInfo(release))
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return results, nil
+}
+
+// GetLatestRelease on GitHub
+func (client *GitHubClient) GetLatestRelease(ctx context.Context, owner, repository string) (ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+
+	var release *github.RepositoryRelease
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		release, ghResponse, err = client.ghClient.Repositories.GetLatestRelease(ctx, owner, repository)
+		return ghResponse, err
+	})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	return mapGitHubReleaseToReleaseInfo(release), nil
+}
+
+// UploadReleaseAsset on GitHub
+func (client *GitHubClient) UploadReleaseAsset(ctx context.Context, owner, repository, releaseID, name string, asset io.Reader) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "releaseID": releaseID, "name": name})
+	if err != nil {
+		return "", err
+	}
+	id, err := strconv.ParseInt(releaseID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid GitHub release ID %s: %w", releaseID, err)
+	}
+	content, err := io.ReadAll(asset)
+	if err != nil {
+		return "", err
+	}
+
+	// The upload URL of the release is used, as it points to the uploads server of the GitHub instance
+	var release *github.RepositoryRelease
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		release, ghResponse, err = client.ghClient.Repositories.GetRelease(ctx, owner, repository, id)
+		return ghResponse, err
+	})
+	if err != nil {
+		return "", err
+	}
+	uploadURL := strings.Split(release.GetUploadURL(), "{")[0] + "?name=" + url.QueryEscape(name)
+
+	releaseAsset := &github.ReleaseAsset{}
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		request, err := client.ghClient.NewUploadRequest(uploadURL, bytes.NewReader(content), int64(len(content)), "application/octet-stream")
+		if err != nil {
+			return nil, err
+		}
+		return client.ghClient.Do(ctx, request, releaseAsset)
+	})
+	if err != nil {
+		return "", err
+	}
+	return releaseAsset.GetBrowserDownloadURL(), nil
+}
+
+func mapGitHubReleaseToReleaseInfo(release *github.RepositoryRelease) ReleaseInfo {
+	return ReleaseInfo{
+		ID:        strconv.FormatInt(release.GetID(), 10),
+		Name:      release.GetName(),
+		TagName:   release.GetTagName(),
+		Body:      release.GetBody(),
+		URL:       release.GetHTMLURL(),
+		CreatedAt: release.GetCreatedAt().Time,
+	}
+}
+
+// CreateWebhook on GitHub
+func (client *GitHubClient) CreateWebhook(ctx context.Context, owner, repository, _, payloadURL string,
+	webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	token := vcsutils.CreateToken()
+	hook := createGitHubHook(token, payloadURL, webhookEvents...)
+	var ghResponseHook *github.Hook
+	var err error
+	if err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		ghResponseHook, ghResponse, err = client.ghClient.Repositories.CreateHook(ctx, owner, repository, hook)
+		return ghResponse, err
+	}); err != nil {
+		return "", "", err
+	}
+
+	return strconv.FormatInt(*ghResponseHook.ID, 10), token, nil
+}
+
+// CreateOrUpdateWebhook on GitHub
+func (client *GitHubClient) CreateOrUpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	return createOrUpdateWebhook(ctx, client, owner, repository, branch, payloadURL, webhookEvents...)
+}
+
+// UpdateWebhook on GitHub
+func (client *GitHubClient) UpdateWebhook(ctx context.Context, owner, repository, _, payloadURL, token,
+	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	hook := createGitHubHook(token, payloadURL, webhookEvents...)
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		_, ghResponse, err = client.ghClient.Repositories.EditHook(ctx, owner, repository, webhookIDInt64, hook)
+		return ghResponse, err
+	})
+}
+
+// DeleteWebhook on GitHub
+func (client *GitHubClient) DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error {
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Repositories.DeleteHook(ctx, owner, repository, webhookIDInt64)
+	})
+}
+
+// ListWebhooks on GitHub
+func (client *GitHubClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []WebhookInfo
+	for nextPage := 1; nextPage != 0; {
+		var hooks []*github.Hook
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			hooks, ghResponse, err = client.ghClient.Repositories.ListHooks(ctx, owner, repository, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			results = append(results, mapGitHubHookToWebhookInfo(hook))
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return results, nil
+}
+
+// GetWebhook on GitHub
+func (client *GitHubClient) GetWebhook(ctx context.Context, owner, repository, webhookID string) (WebhookInfo, error) {
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+
+	var hook *github.Hook
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		hook, ghResponse, err = client.ghClient.Repositories.GetHook(ctx, owner, repository, webhookIDInt64)
+		return ghResponse, err
+	})
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	return mapGitHubHookToWebhookInfo(hook), nil
+}
+
+// RotateWebhookSecret on GitHub
+func (client *GitHubClient) RotateWebhookSecret(ctx context.Context, owner, repository, webhookID string) (string, error) {
+	webhookIDInt64, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	token := vcsutils.CreateToken()
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		_, ghResponse, err = client.ghClient.Repositories.EditHookConfiguration(ctx, owner, repository, webhookIDInt64, &github.HookConfig{Secret: &token})
+		return ghResponse, err
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// SetCommitStatus on GitHub
+func (client *GitHubClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
+	title, description, detailsURL string) error {
+	state := getGitHubCommitState(commitStatus)
+	status := &github.RepoStatus{
+		Context:     &title,
+		TargetURL:   &detailsURL,
+		State:       &state,
+		Description: &description,
+	}
+
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Repositories.CreateStatus(ctx, owner, repository, ref, status)
+		return ghResponse, err
+	})
+}
+
+// GetCommitStatuses on GitHub.
+// The statuses are listed rather than combined, since the combined status doesn't include their creators.
+// Only the latest status of each context is returned, as in the combined status.
+func (client *GitHubClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (statusInfoList []CommitStatusInfo, err error) {
+	var statuses []*github.RepoStatus
+	for nextPage := 1; nextPage != 0; {
+		var statusesInPage []*github.RepoStatus
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			statusesInPage, ghResponse, err = client.ghClient.Repositories.ListStatuses(ctx, owner, repository, ref, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, statusesInPage...)
+		nextPage = ghResponse.NextPage
+	}
+
+	// The statuses are listed from the newest, hence the first status of each context is its latest status
+	listedContexts := map[string]bool{}
+	for _, singleStatus := range statuses {
+		if listedContexts[singleStatus.GetContext()] {
+			continue
+		}
+		listedContexts[singleStatus.GetContext()] = true
+		statusInfoList = append(statusInfoList, CommitStatusInfo{
+			State:         commitStatusAsStringToStatus(singleStatus.GetState()),
+			RawState:      singleStatus.GetState(),
+			Context:       singleStatus.GetContext(),
+			Description:   singleStatus.GetDescription(),
+			DetailsUrl:    singleStatus.GetTargetURL(),
+			Creator:       singleStatus.GetCreator().GetLogin(),
+			LastUpdatedAt: singleStatus.GetUpdatedAt().Time,
+			CreatedAt:     singleStatus.GetCreatedAt().Time,
+		})
+	}
+	return statusInfoList, nil
+}
+
+// GetCombinedCommitStatus on GitHub.
+// Combines the commit statuses with the check runs of the ref.
+func (client *GitHubClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return Error, err
+	}
+
+	var combinedStatus *github.CombinedStatus
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		combinedStatus, ghResponse, err = client.ghClient.Repositories.GetCombinedStatus(ctx, owner, repository, ref, nil)
+		return ghResponse, err
+	})
+	if err != nil {
+		return Error, err
+	}
+	var statuses []CommitStatus
+	// GitHub reports a pending state for refs without statuses
+	if combinedStatus.GetTotalCount() > 0 {
+		statuses = append(statuses, commitStatusAsStringToStatus(combinedStatus.GetState()))
+	}
+
+	for nextPage := 1; nextPage != 0; {
+		var checkRuns *github.ListCheckRunsResults
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			checkRuns, ghResponse, err = client.ghClient.Checks.ListCheckRunsForRef(ctx, owner, repository, ref, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+			return ghResponse, err
+		})
+		if err != nil {
+			return Error, err
+		}
+		for _, checkRun := range checkRuns.CheckRuns {
+			statuses = append(statuses, mapGitHubCheckRunToCommitStatus(checkRun))
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return combineCommitStatuses(statuses...), nil
+}
+
+// TriggerPipeline on GitHub
+func (client *GitHubClient) TriggerPipeline(_ context.Context, _, _, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGitHubPipelinesNotSupported
+}
+
+// GetLatestPipelineStatus on GitHub.
+// Returns the latest workflow run of the ref, of any workflow.
+func (client *GitHubClient) GetLatestPipelineStatus(ctx context.Context, owner, repository, ref string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return client.getLatestWorkflowRun(ctx, owner, repository, "", ref)
+}
+
+// GetPipelineStatus on GitHub.
+// The pipeline ID is the ID of a workflow run.
+func (client *GitHubClient) GetPipelineStatus(ctx context.Context, owner, repository, pipelineID string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pipelineID": pipelineID})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	runID, err := strconv.ParseInt(pipelineID, 10, 64)
+	if err != nil {
+		return PipelineInfo{}, fmt.Errorf("invalid workflow run ID '%s': %w", pipelineID, err)
+	}
+	var workflowRun *github.WorkflowRun
+	err = client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		workflowRun, ghResponse, err = client.ghClient.Actions.GetWorkflowRunByID(ctx, owner, repository, runID)
+		return ghResponse, err
+	})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapGitHubWorkflowRunToPipelineInfo(workflowRun), nil
+}
+
+// TriggerWorkflow on GitHub.
+// The workflow must have a workflow_dispatch trigger. GitHub doesn't return the triggered run, use GetWorkflowRunStatus to follow it.
+func (client *GitHubClient) TriggerWorkflow(ctx context.Context, owner, repository, workflowFile, ref string, inputs map[string]string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "workflowFile": workflowFile, "ref": ref})
+	if err != nil {
+		return err
+	}
+	event := github.CreateWorkflowDispatchEventRequest{Ref: ref}
+	if len(inputs) > 0 {
+		event.Inputs = make(map[string]interface{}, len(inputs))
+		for key, value := range inputs {
+			event.Inputs[key] = value
+		}
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repository, workflowFile, event)
+	})
+}
+
+// GetWorkflowRunStatus on GitHub
+func (client *GitHubClient) GetWorkflowRunStatus(ctx context.Context, owner, repository, workflowFile, ref string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "workflowFile": workflowFile, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return client.getLatestWorkflowRun(ctx, owner, repository, workflowFile, ref)
+}
+
+// QueueBuild on GitHub
+func (client *GitHubClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGitHubBuildsNotSupported
+}
+
+// GetBuildStatus on GitHub
+func (client *GitHubClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGitHubBuildsNotSupported
+}
+
+// getLatestWorkflowRun returns the latest run of a workflow on a commit SHA, a branch or a tag.
+// Without a workflow file, the runs of all the workflows of the repository are considered.
+func (client *GitHubClient) getLatestWorkflowRun(ctx context.Context, owner, repository, workflowFile, ref string) (PipelineInfo, error) {
+	options := &github.ListWorkflowRunsOptions{ListOptions: github.ListOptions{PerPage: 1}}
+	if plumbing.IsHash(ref) {
+		options.HeadSHA = ref
+	} else {
+		options.Branch = ref
+	}
+	var workflowRuns *github.WorkflowRuns
+	err := client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		if workflowFile == "" {
+			workflowRuns, ghResponse, err = client.ghClient.Actions.ListRepositoryWorkflowRuns(ctx, owner, repository, options)
+		} else {
+			workflowRuns, ghResponse, err = client.ghClient.Actions.ListWorkflowRunsByFileName(ctx, owner, repository, workflowFile, options)
+		}
+		return ghResponse, err
+	})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	if len(workflowRuns.WorkflowRuns) == 0 {
+		return PipelineInfo{}, fmt.Errorf("no workflow run was found for ref '%s'", ref)
+	}
+	return mapGitHubWorkflowRunToPipelineInfo(workflowRuns.WorkflowRuns[0]), nil
+}
+
+func mapGitHubWorkflowRunToPipelineInfo(workflowRun *github.WorkflowRun) PipelineInfo {
+	rawStatus := workflowRun.GetStatus()
+	if rawStatus == "completed" {
+		rawStatus = workflowRun.GetConclusion()
+	}
+	return PipelineInfo{
+		ID:            strconv.FormatInt(workflowRun.GetID(), 10),
+		Status:        mapGitHubRunStatusToCommitStatus(workflowRun.GetStatus(), workflowRun.GetConclusion()),
+		RawStatus:     rawStatus,
+		Ref:           workflowRun.GetHeadBranch(),
+		CommitSHA:     workflowRun.GetHeadSHA(),
+		WebURL:        workflowRun.GetHTMLURL(),
+		CreatedAt:     workflowRun.GetCreatedAt().Time,
+		LastUpdatedAt: workflowRun.GetUpdatedAt().Time,
+	}
+}
+
+func mapGitHubCheckRunToCommitStatus(checkRun *github.CheckRun) CommitStatus {
+	return mapGitHubRunStatusToCommitStatus(checkRun.GetStatus(), checkRun.GetConclusion())
+}
+
+// mapGitHubRunStatusToCommitStatus maps the status and the conclusion of a check run or a workflow run
+func mapGitHubRunStatusToCommitStatus(status, conclusion string) CommitStatus {
+	if status != "completed" {
+		return InProgress
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return Pass
+	default:
+		return Fail
+	}
+}
+
+// DownloadRepository on GitHub
+func (client *GitHubClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	return client.downloadRepository(ctx, owner, repository, branch, localPath, nil)
+}
+
+// DownloadRepositoryPaths on GitHub.
+// GitHub doesn't archive selected paths, so the paths are filtered while the tarball is extracted.
+func (client *GitHubClient) DownloadRepositoryPaths(ctx context.Context, owner, repository, ref string, paths []string, localPath string) error {
+	paths, err := normalizeDownloadPaths(paths)
+	if err != nil {
+		return err
+	}
+	return client.downloadRepository(ctx, owner, repository, ref, localPath, paths)
+}
+
+func (client *GitHubClient) downloadRepository(ctx context.Context, owner, repository, branch, localPath string, paths []string) (err error) {
+	format, err := getArchiveFormat(client.vcsInfo, vcsutils.TarGzArchive)
+	if err != nil {
+		return
+	}
+	archiveFormat := github.Tarball
+	if format == vcsutils.ZipArchive {
+		archiveFormat = github.Zipball
+	}
+
+	// Get the archive download link from GitHub
+	var baseURL *url.URL
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		baseURL, ghResponse, err = client.executeGetArchiveLink(ctx, owner, repository, branch, archiveFormat)
+		return ghResponse, err
+	})
+	if err != nil {
+		return
+	}
+
+	// Download the archive
+	httpResponse, err := executeDownloadArchiveFromLink(newHttpClient(client.vcsInfo), baseURL.String())
+	if err != nil {
+		return
+	}
+	defer func() { err = errors.Join(err, httpResponse.Body.Close()) }()
+	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
+
+	// Extract the archive
+	if err = vcsutils.ExtractArchive(localPath, vcsutils.LimitReader(httpResponse.Body, client.vcsInfo.MaxDownloadSize), format, true, paths, client.vcsInfo.ExtractionLimits); err != nil {
+		return
+	}
+	client.logger.Info(vcsutils.SuccessfulRepoExtraction)
+
+	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
+	if err != nil {
+		return
+	}
+	// Create a .git folder in the archive with the remote repository HTTP clone url
+	err = vcsutils.CreateDotGitFolderWithRemote(localPath, vcsutils.RemoteName, repositoryInfo.CloneInfo.HTTP)
+	return
+}
+
+func (client *GitHubClient) executeGetArchiveLink(ctx context.Context, owner, repository, branch string, archiveFormat github.ArchiveFormat) (baseURL *url.URL, ghResponse *github.Response, err error) {
+	client.logger.Debug("Getting GitHub archive link to download")
+	return client.ghClient.Repositories.GetArchiveLink(ctx, owner, repository, archiveFormat,
+		&github.RepositoryContentGetOptions{Ref: branch}, 5)
+}
+
+func executeDownloadArchiveFromLink(httpClient *http.Client, baseURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, baseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := httpClient.Do(req)
+	if err != nil {
+		return httpResponse, err
+	}
+	return httpResponse, vcsutils.CheckResponseStatusWithBody(httpResponse, http.StatusOK)
+}
+
+func (client *GitHubClient) GetPullRequestCommentSizeLimit() int {
+	return githubPrContentSizeLimit
+}
 
 func (client *GitHubClient) GetPullRequestDetailsSizeLimit() int {
 	return githubPrContentSizeLimit
 }
 
-// CreatePullRequest on GitHub
-func (client *GitHubClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error {
+// CreatePullRequest on GitHub
+func (client *GitHubClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) (PullRequestInfo, error) {
+	return client.CreatePullRequestWithOptions(ctx, owner, repository, sourceBranch, targetBranch, title, description, CreatePullRequestOptions{})
+}
+
+// CreatePullRequestWithOptions on GitHub.
+// The labels, the assignees, the milestone and the reviewers are set after the pull request is created.
+func (client *GitHubClient) CreatePullRequestWithOptions(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string, options CreatePullRequestOptions) (PullRequestInfo, error) {
+	if err := validateCreatePullRequestOptions(options, "not supported on GitHub", deleteSourceBranchPullRequestOption, workItemsPullRequestOption); err != nil {
+		return PullRequestInfo{}, err
+	}
+	var pullRequest *github.PullRequest
+	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		pullRequest, ghResponse, err = client.executeCreatePullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description, options)
+		return ghResponse, err
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	if err = client.setPullRequestCreationOptions(ctx, owner, repository, pullRequest.GetNumber(), options); err != nil {
+		return PullRequestInfo{}, err
+	}
+	return mapGitHubPullRequestToPullRequestInfo(pullRequest, true)
+}
+
+// setPullRequestCreationOptions sets the options which can't be set when a pull request is created
+func (client *GitHubClient) setPullRequestCreationOptions(ctx context.Context, owner, repository string, pullRequestNumber int, options CreatePullRequestOptions) error {
+	if len(options.Labels) > 0 || len(options.Assignees) > 0 || options.Milestone != 0 {
+		issueRequest := &github.IssueRequest{Milestone: vcsutils.GetNilIfZeroVal(options.Milestone)}
+		if len(options.Labels) > 0 {
+			issueRequest.Labels = &options.Labels
+		}
+		if len(options.Assignees) > 0 {
+			issueRequest.Assignees = &options.Assignees
+		}
+		err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+			_, ghResponse, err := client.ghClient.Issues.Edit(ctx, owner, repository, pullRequestNumber, issueRequest)
+			return ghResponse, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(options.Reviewers) == 0 {
+		return nil
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.RequestReviewers(ctx, owner, repository, pullRequestNumber, github.ReviewersRequest{Reviewers: options.Reviewers})
+		return ghResponse, err
+	})
+}
+
+func (client *GitHubClient) executeCreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string, options CreatePullRequestOptions) (*github.PullRequest, *github.Response, error) {
+	head := owner + ":" + sourceBranch
+	client.logger.Debug(vcsutils.CreatingPullRequest, title)
+
+	return client.ghClient.PullRequests.Create(ctx, owner, repository, &github.NewPullRequest{
+		Title: &title,
+		Body:  &description,
+		Head:  &head,
+		Base:  &targetBranch,
+		Draft: vcsutils.GetNilIfZeroVal(options.Draft),
+	})
+}
+
+// UpdatePullRequest on GitHub
+func (client *GitHubClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, id int, state vcsutils.PullRequestState) error {
+	client.logger.Debug(vcsutils.UpdatingPullRequest, id)
+	var baseRef *github.PullRequestBranch
+	if targetBranchName != "" {
+		baseRef = &github.PullRequestBranch{Ref: &targetBranchName}
+	}
+	pullRequest := &github.PullRequest{
+		Body:  vcsutils.GetNilIfZeroVal(body),
+		Title: vcsutils.GetNilIfZeroVal(title),
+		State: vcsutils.MapPullRequestState(&state),
+		Base:  baseRef,
+	}
+
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.Edit(ctx, owner, repository, id, pullRequest)
+		return ghResponse, err
+	})
+}
+
+// MergePullRequest on GitHub
+func (client *GitHubClient) MergePullRequest(ctx context.Context, owner, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge, vcsutils.MergeMethodSquash, vcsutils.MergeMethodRebase:
+	default:
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.Merge(ctx, owner, repository, prId, "", &github.PullRequestOptions{MergeMethod: string(mergeMethod)})
+		return ghResponse, err
+	})
+}
+
+// SetPullRequestAutoMerge on GitHub.
+// Auto-merge is enabled by the GraphQL API, and must be allowed in the repository settings.
+func (client *GitHubClient) SetPullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod vcsutils.MergeMethod) error {
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge, vcsutils.MergeMethodSquash, vcsutils.MergeMethodRebase:
+	default:
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	var pullRequest *github.PullRequest
+	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var err error
+		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestID)
+		return ghResponse, err
+	})
+	if err != nil {
+		return err
+	}
+	query := `mutation($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) { clientMutationId }
+}`
+	variables := map[string]interface{}{"pullRequestId": pullRequest.GetNodeID(), "mergeMethod": strings.ToUpper(string(mergeMethod))}
+	return client.sendGraphQLRequest(ctx, query, variables, nil)
+}
+
+// ListOpenPullRequestsWithBody on GitHub
+func (client *GitHubClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.getOpenPullRequests(ctx, owner, repository, true)
+}
+
+// ListOpenPullRequests on GitHub
+func (client *GitHubClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
+	return client.getOpenPullRequests(ctx, owner, repository, false)
+}
+
+// ListOpenPullRequestsWithOptions on GitHub
+func (client *GitHubClient) ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	listOptions := &github.PullRequestListOptions{
+		State:       "open",
+		Base:        options.TargetBranch,
+		ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+	}
+	var pullRequests []*github.PullRequest
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		pullRequests, ghResponse, err = client.ghClient.PullRequests.List(ctx, owner, repository, listOptions)
+		return ghResponse, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	pullRequestsInfo, err := mapGitHubPullRequestToPullRequestInfoList(pullRequests, options.WithBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API can filter only by the target branch, hence the pull requests of the page are filtered here
+	var results []PullRequestInfo
+	for _, pullRequestInfo := range pullRequestsInfo {
+		if options.matches(pullRequestInfo) {
+			results = append(results, pullRequestInfo)
+		}
+	}
+	return results, nil
+}
+
+// ListPullRequestsWithState on GitHub.
+// Merged pull requests are closed on GitHub, hence the closed pull requests are filtered by their merge status.
+func (client *GitHubClient) ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	if err = validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+	githubState := "closed"
+	if state == vcsutils.Open {
+		githubState = "open"
+	}
+
+	var results []PullRequestInfo
+	for nextPage := 1; nextPage != 0; {
+		var pullRequests []*github.PullRequest
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			pullRequests, ghResponse, err = client.ghClient.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{
+				State:       githubState,
+				ListOptions: github.ListOptions{Page: nextPage, PerPage: 100},
+			})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		pullRequestsInfo, err := mapGitHubPullRequestToPullRequestInfoList(pullRequests, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequestInfo := range pullRequestsInfo {
+			if pullRequestInfo.State == state {
+				results = append(results, pullRequestInfo)
+			}
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return results, nil
+}
+
+func (client *GitHubClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
+	var pullRequests []*github.PullRequest
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	for nextPage := 1; nextPage != 0; {
+		var pullRequestsInPage []*github.PullRequest
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+			var err error
+			pullRequestsInPage, ghResponse, err = client.ghClient.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{
+				State:       "open",
+				ListOptions: github.ListOptions{Page: nextPage, PerPage: 100},
+			})
+			return ghResponse, err
+		})
+		if err != nil {
+			return []PullRequestInfo{}, err
+		}
+		pullRequests = append(pullRequests, pullRequestsInPage...)
+		nextPage = ghResponse.NextPage
+	}
+
+	return mapGitHubPullRequestToPullRequestInfoList(pullRequests, withBody)
+}
+
+// GetPullRequestByID on GitHub
+func (client *GitHubClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (PullRequestInfo, error) {
+	var pullRequest *github.PullRequest
+	var ghResponse *github.Response
+	var err error
+	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestId)
+		return ghResponse, err
+	})
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+
+	if err = vcsutils.CheckResponseStatusWithBody(ghResponse.Response, http.StatusOK); err != nil {
+		return PullRequestInfo{}, err
+	}
+
+	return mapGitHubPullRequestToPullRequestInfo(pullRequest, true)
+}
+
+func mapGitHubPullRequestToPullRequestInfo(ghPullRequest *github.PullRequest, withBody bool) (PullRequestInfo, error) {
+	var sourceBranch, targetBranch string
+	var err1, err2 error
+	if ghPullRequest != nil && ghPullRequest.Head != nil && ghPullRequest.Base != nil {
+		sourceBranch, err1 = extractBranchFromLabel(vcsutils.DefaultIfNotNil(ghPullRequest.Head.Label))
+		targetBranch, err2 = extractBranchFromLabel(vcsutils.DefaultIfNotNil(ghPullRequest.Base.Label))
+		err := errors.Join(err1, err2)
+		if err != nil {
+			return PullRequestInfo{}, err
+		}
+	}
+
+	var sourceRepoName, sourceRepoOwner string
+	if ghPullRequest.Head.Repo == nil {
+		return PullRequestInfo{}, errors.New("the source repository information is missing when fetching the pull request details")
+	}
+	if ghPullRequest.Head.Repo.Owner == nil {
+		return PullRequestInfo{}, errors.New("the source repository owner name is missing when fetching the pull request details")
+	}
+	sourceRepoName = vcsutils.DefaultIfNotNil(ghPullRequest.Head.Repo.Name)
+	sourceRepoOwner = vcsutils.DefaultIfNotNil(ghPullRequest.Head.Repo.Owner.Login)
+
+	var targetRepoName, targetRepoOwner string
+	if ghPullRequest.Base.Repo == nil {
+		return PullRequestInfo{}, errors.New("the target repository information is missing when fetching the pull request details")
+	}
+	if ghPullRequest.Base.Repo.Owner == nil {
+		return PullRequestInfo{}, errors.New("the target repository owner name is missing when fetching the pull request details")
+	}
+	targetRepoName = vcsutils.DefaultIfNotNil(ghPullRequest.Base.Repo.Name)
+	targetRepoOwner = vcsutils.DefaultIfNotNil(ghPullRequest.Base.Repo.Owner.Login)
+
+	var body string
+	if withBody {
+		body = vcsutils.DefaultIfNotNil(ghPullRequest.Body)
+	}
+
+	return PullRequestInfo{
+		ID:        int64(vcsutils.DefaultIfNotNil(ghPullRequest.Number)),
+		Title:     vcsutils.DefaultIfNotNil(ghPullRequest.Title),
+		URL:       vcsutils.DefaultIfNotNil(ghPullRequest.HTMLURL),
+		Body:      body,
+		Author:    ghPullRequest.GetUser().GetLogin(),
+		State:     mapGitHubPullRequestState(ghPullRequest),
+		Mergeable: ghPullRequest.GetMergeable(),
+		CreatedAt: ghPullRequest.GetCreatedAt().Time,
+		UpdatedAt: ghPullRequest.GetUpdatedAt().Time,
+		Source: BranchInfo{
+			Name:       sourceBranch,
+			Repository: sourceRepoName,
+			Owner:      sourceRepoOwner,
+		},
+		Target: BranchInfo{
+			Name:       targetBranch,
+			Repository: targetRepoName,
+			Owner:      targetRepoOwner,
+		},
+	}, nil
+}
+
+// Extracts branch name from the following expected label format repo:branch
+func extractBranchFromLabel(label string) (string, error) {
+	split := strings.Split(label, ":")
+	if len(split) <= 1 {
+		return "", fmt.Errorf("bad label format %s", label)
+	}
+	return split[1], nil
+}
+
+// AddPullRequestComment on GitHub
+func (client *GitHubClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		// We use the Issues API to add a regular comment. The PullRequests API adds a code review comment.
+		_, ghResponse, err = client.ghClient.Issues.CreateComment(ctx, owner, repository, pullRequestID, &github.IssueComment{Body: &content})
+		return ghResponse, err
+	})
+}
+
+// AddPullRequestReviewComments on GitHub
+func (client *GitHubClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
+	prID := strconv.Itoa(pullRequestID)
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": prID})
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return errors.New(vcsutils.ErrNoCommentsProvided)
+	}
+
+	var commits []*github.RepositoryCommit
+	var ghResponse *github.Response
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		commits, ghResponse, err = client.ghClient.PullRequests.ListCommits(ctx, owner, repository, pullRequestID, nil)
+		return ghResponse, err
+	})
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return errors.New("could not fetch the commits list for pull request " + prID)
+	}
+
+	latestCommitSHA := commits[len(commits)-1].GetSHA()
+
+	for _, comment := range comments {
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			ghResponse, err = client.executeCreatePullRequestReviewComment(ctx, owner, repository, latestCommitSHA, pullRequestID, comment)
+			return ghResponse, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *GitHubClient) executeCreatePullRequestReviewComment(ctx context.Context, owner, repository, latestCommitSHA string, pullRequestID int, comment PullRequestComment) (*github.Response, error) {
+	filePath := filepath.Clean(comment.NewFilePath)
+	startLine := &comment.NewStartLine
+	// GitHub API won't accept 'start_line' if it equals the end line
+	if *startLine == comment.NewEndLine {
+		startLine = nil
+	}
+	_, ghResponse, err := client.ghClient.PullRequests.CreateComment(ctx, owner, repository, pullRequestID, &github.PullRequestComment{
+		CommitID:  &latestCommitSHA,
+		Body:      &comment.Content,
+		StartLine: startLine,
+		Line:      &comment.NewEndLine,
+		Path:      &filePath,
+	})
+	if err != nil {
+		err = fmt.Errorf("could not create a code review comment for <%s/%s> in pull request %d. error received: %w",
+			owner, repository, pullRequestID, err)
+	}
+	return ghResponse, err
+}
+
+// ListPullRequestReviewComments on GitHub
+func (client *GitHubClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	commentsInfoList := []CommentInfo{}
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		commentsInfoList, ghResponse, err = client.executeListPullRequestReviewComments(ctx, owner, repository, pullRequestID)
+		return ghResponse, err
+	})
+	return commentsInfoList, err
+}
+
+func (client *GitHubClient) executeListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, *github.Response, error) {
+	commentsList, ghResponse, err := client.ghClient.PullRequests.ListComments(ctx, owner, repository, pullRequestID, nil)
+	if err != nil {
+		return []CommentInfo{}, ghResponse, err
+	}
+	commentsInfoList := []CommentInfo{}
+	for _, comment := range commentsList {
+		line := comment.GetLine()
+		if line == 0 {
+			// Comments on outdated diffs are reported on their original line only
+			line = comment.GetOriginalLine()
+		}
+		commentInfo := CommentInfo{
+			ID:             comment.GetID(),
+			NativeID:       strconv.FormatInt(comment.GetID(), 10),
+			Content:        comment.GetBody(),
+			Author:         comment.GetUser().GetName(),
+			AuthorUsername: comment.GetUser().GetLogin(),
+			Created:        comment.GetCreatedAt().Time,
+			Updated:        comment.GetUpdatedAt().Time,
+			FilePath:       comment.GetPath(),
+			Line:           line,
+			DiffHunk:       comment.GetDiffHunk(),
+		}
+		if comment.GetInReplyTo() != 0 {
+			commentInfo.ParentID = strconv.FormatInt(comment.GetInReplyTo(), 10)
+		}
+		commentsInfoList = append(commentsInfoList, commentInfo)
+	}
+	return commentsInfoList, ghResponse, nil
+}
+
+// ListPullRequestComments on GitHub
+func (client *GitHubClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return []CommentInfo{}, err
+	}
+
+	var commentsList []*github.IssueComment
+	for nextPage := 1; nextPage != 0; {
+		var commentsInPage []*github.IssueComment
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			commentsInPage, ghResponse, err = client.ghClient.Issues.ListComments(ctx, owner, repository, pullRequestID, &github.IssueListCommentsOptions{
+				ListOptions: github.ListOptions{Page: nextPage, PerPage: 100},
+			})
+			return ghResponse, err
+		})
+		if err != nil {
+			return []CommentInfo{}, err
+		}
+		commentsList = append(commentsList, commentsInPage...)
+		nextPage = ghResponse.NextPage
+	}
+
+	return mapGitHubIssuesCommentToCommentInfoList(commentsList)
+}
+
+// ListPullRequestCommentsWithOptions on GitHub
+func (client *GitHubClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	listOptions := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{Page: page, PerPage: perPage}}
+	if !options.UpdatedAfter.IsZero() {
+		listOptions.Since = &options.UpdatedAfter
+	}
+	var comments []*github.IssueComment
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		comments, ghResponse, err = client.ghClient.Issues.ListComments(ctx, owner, repository, pullRequestID, listOptions)
+		return ghResponse, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapGitHubIssuesCommentToCommentInfoList(comments)
+}
+
+// DeletePullRequestReviewComments on GitHub
+func (client *GitHubClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, _ int, comments ...CommentInfo) error {
+	for _, comment := range comments {
+		commentID := comment.ID
+		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "commentID": strconv.FormatInt(commentID, 10)})
+		if err != nil {
+			return err
+		}
+
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			return client.executeDeletePullRequestReviewComment(ctx, owner, repository, commentID)
+		})
+		if err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+func (client *GitHubClient) executeDeletePullRequestReviewComment(ctx context.Context, owner, repository string, commentID int64) (*github.Response, error) {
+	ghResponse, err := client.ghClient.PullRequests.DeleteComment(ctx, owner, repository, commentID)
+	if err != nil {
+		err = fmt.Errorf("could not delete pull request review comment: %w", err)
+	}
+	return ghResponse, err
+}
+
+// UpdatePullRequestComment on GitHub
+func (client *GitHubClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, _, commentID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Issues.EditComment(ctx, owner, repository, int64(commentID), &github.IssueComment{Body: &content})
+		return ghResponse, err
+	})
+}
+
+// DeletePullRequestComment on GitHub
+func (client *GitHubClient) DeletePullRequestComment(ctx context.Context, owner, repository string, _, commentID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.executeDeletePullRequestComment(ctx, owner, repository, commentID)
+	})
+}
+
+// SetPullRequestThreadStatus on GitHub.
+// Review threads are resolved by the GraphQL API, hence the thread is looked up by the ID of one of its comments.
+// The GraphQL node ID of the thread is accepted as well.
+func (client *GitHubClient) SetPullRequestThreadStatus(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "thread ID": threadID}); err != nil {
+		return err
+	}
+	nodeID := threadID
+	if commentID, err := strconv.ParseInt(threadID, 10, 64); err == nil {
+		if nodeID, err = client.getReviewThreadNodeID(ctx, owner, repository, pullRequestID, commentID); err != nil {
+			return err
+		}
+	}
+	mutation := "resolveReviewThread"
+	if !resolved {
+		mutation = "unresolveReviewThread"
+	}
+	query := fmt.Sprintf("mutation($threadId: ID!) { %s(input: {threadId: $threadId}) { thread { id } } }", mutation)
+	return client.sendGraphQLRequest(ctx, query, map[string]interface{}{"threadId": nodeID}, nil)
+}
+
+const gitHubReviewThreadsQuery = `query($owner: String!, $repository: String!, $pullRequestID: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repository) {
+    pullRequest(number: $pullRequestID) {
+      reviewThreads(first: 100, after: $cursor) {
+        nodes { id comments(first: 100) { nodes { databaseId } } }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+type gitHubReviewThreadsResponse struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes []struct {
+					ID       string `json:"id"`
+					Comments struct {
+						Nodes []struct {
+							DatabaseID int64 `json:"databaseId"`
+						} `json:"nodes"`
+					} `json:"comments"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// getReviewThreadNodeID returns the GraphQL node ID of the review thread which contains the comment
+func (client *GitHubClient) getReviewThreadNodeID(ctx context.Context, owner, repository string, pullRequestID int, commentID int64) (string, error) {
+	variables := map[string]interface{}{"owner": owner, "repository": repository, "pullRequestID": pullRequestID, "cursor": nil}
+	for {
+		var response gitHubReviewThreadsResponse
+		if err := client.sendGraphQLRequest(ctx, gitHubReviewThreadsQuery, variables, &response); err != nil {
+			return "", err
+		}
+		reviewThreads := response.Repository.PullRequest.ReviewThreads
+		for _, thread := range reviewThreads.Nodes {
+			for _, comment := range thread.Comments.Nodes {
+				if comment.DatabaseID == commentID {
+					return thread.ID, nil
+				}
+			}
+		}
+		if !reviewThreads.PageInfo.HasNextPage {
+			return "", fmt.Errorf("no review thread of pull request %d contains comment %d", pullRequestID, commentID)
+		}
+		variables["cursor"] = reviewThreads.PageInfo.EndCursor
+	}
+}
+
+// sendGraphQLRequest sends a query to the GraphQL API, and decodes its data into data if it isn't nil.
+// The GraphQL endpoint is relative to the parent of the REST API endpoint, which is /api/v3 on GitHub Enterprise Server.
+func (client *GitHubClient) sendGraphQLRequest(ctx context.Context, query string, variables map[string]interface{}, data interface{}) error {
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+		request, err := client.ghClient.NewRequest(http.MethodPost, "../graphql", map[string]interface{}{"query": query, "variables": variables})
+		if err != nil {
+			return nil, err
+		}
+		return client.ghClient.Do(ctx, request, &response)
+	})
+	if err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		messages := make([]string, len(response.Errors))
+		for i, graphQLError := range response.Errors {
+			messages[i] = graphQLError.Message
+		}
+		return fmt.Errorf("GitHub GraphQL request failed: %s", strings.Join(messages, "; "))
+	}
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(response.Data, data)
+}
+
+func (client *GitHubClient) executeDeletePullRequestComment(ctx context.Context, owner, repository string, commentID int) (*github.Response, error) {
+	ghResponse, err := client.ghClient.Issues.DeleteComment(ctx, owner, repository, int64(commentID))
+	if err != nil {
+		return ghResponse, err
+	}
+
+	var statusCode int
+	if ghResponse.Response != nil {
+		statusCode = ghResponse.Response.StatusCode
+	}
+	if statusCode != http.StatusNoContent && statusCode != http.StatusOK {
+		return ghResponse, fmt.Errorf("expected %d status code while received %d status code", http.StatusNoContent, ghResponse.Response.StatusCode)
+	}
+
+	return ghResponse, nil
+}
+
+var githubReviewEvents = map[vcsutils.ReviewVerdict]string{
+	vcsutils.ReviewVerdictApprove:        "APPROVE",
+	vcsutils.ReviewVerdictRequestChanges: "REQUEST_CHANGES",
+	vcsutils.ReviewVerdictComment:        "COMMENT",
+}
+
+// CreatePullRequestReview on GitHub
+func (client *GitHubClient) CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	if err = validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	review := &github.PullRequestReviewRequest{Event: vcsutils.PointerOf(githubReviewEvents[verdict])}
+	if body != "" {
+		review.Body = &body
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.PullRequests.CreateReview(ctx, owner, repository, pullRequestID, review)
+		return ghResponse, err
+	})
+}
+
+var githubReviewStates = map[string]vcsutils.ReviewVerdict{
+	"APPROVED":          vcsutils.ReviewVerdictApprove,
+	"CHANGES_REQUESTED": vcsutils.ReviewVerdictRequestChanges,
+	"COMMENTED":         vcsutils.ReviewVerdictComment,
+}
+
+// ListPullRequestReviews on GitHub.
+// Pending and dismissed reviews aren't returned.
+func (client *GitHubClient) ListPullRequestReviews(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var reviews []PullRequestReviewInfo
+	listOptions := &github.ListOptions{PerPage: 100}
+	for {
+		var pageReviews []*github.PullRequestReview
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			pageReviews, ghResponse, err = client.ghClient.PullRequests.ListReviews(ctx, owner, repository, pullRequestID, listOptions)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range pageReviews {
+			state, ok := githubReviewStates[review.GetState()]
+			if !ok {
+				continue
+			}
+			reviews = append(reviews, PullRequestReviewInfo{
+				Reviewer:  review.GetUser().GetLogin(),
+				State:     state,
+				Body:      review.GetBody(),
+				Submitted: review.GetSubmittedAt().Time,
+			})
+		}
+		if ghResponse.NextPage == 0 {
+			return reviews, nil
+		}
+		listOptions.Page = ghResponse.NextPage
+	}
+}
+
+// GetLatestCommit on GitHub
+func (client *GitHubClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	commits, err := client.ListCommits(ctx, owner, repository, ListCommitsOptions{Branch: branch, PerPage: 1})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	latestCommit := CommitInfo{}
+	if len(commits) > 0 {
+		latestCommit = commits[0]
+	}
+	return latestCommit, nil
+}
+
+// GetCommits on GitHub
+func (client *GitHubClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var commitsInfo []CommitInfo
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		commitsInfo, ghResponse, err = client.executeGetCommits(ctx, owner, repository, branch)
+		return ghResponse, err
+	})
+	return commitsInfo, err
+}
+
+func (client *GitHubClient) executeGetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, *github.Response, error) {
+	listOptions := &github.CommitsListOptions{
+		SHA: branch,
+		ListOptions: github.ListOptions{
+			Page:    1,
+			PerPage: vcsutils.NumberOfCommitsToFetch,
+		},
+	}
+
+	commits, ghResponse, err := client.ghClient.Repositories.ListCommits(ctx, owner, repository, listOptions)
+	if err != nil {
+		return nil, ghResponse, err
+	}
+
+	var commitsInfo []CommitInfo
+	for _, commit := range commits {
+		commitInfo := mapGitHubCommitToCommitInfo(commit)
+		commitsInfo = append(commitsInfo, commitInfo)
+	}
+	return commitsInfo, ghResponse, nil
+}
+
+// ListCommits on GitHub
+func (client *GitHubClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     options.Branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	listOptions := &github.CommitsListOptions{
+		SHA:         options.Branch,
+		Path:        options.Path,
+		Author:      options.Author,
+		Since:       options.Since,
+		Until:       options.Until,
+		ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+	}
+	var commits []*github.RepositoryCommit
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		commits, ghResponse, err = client.ghClient.Repositories.ListCommits(ctx, owner, repository, listOptions)
+		return ghResponse, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var commitsInfo []CommitInfo
+	for _, commit := range commits {
+		commitsInfo = append(commitsInfo, mapGitHubCommitToCommitInfo(commit))
+	}
+	return commitsInfo, nil
+}
+
+// GetRepositoryInfo on GitHub
+func (client *GitHubClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	var repo *github.Repository
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		repo, ghResponse, err = client.ghClient.Repositories.Get(ctx, owner, repository)
+		return ghResponse, err
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	return mapGitHubRepositoryToRepositoryInfo(repo), nil
+}
+
+// CreateRepository on GitHub.
+// The default branch is applied only if the repository is initialized with a README, since an empty repository has no branches.
+func (client *GitHubClient) CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	org, err := client.getOrganizationToCreateRepositoryIn(ctx, owner)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	var repo *github.Repository
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		repo, ghResponse, err = client.ghClient.Repositories.Create(ctx, org, &github.Repository{
+			Name:        &repository,
+			Description: &options.Description,
+			Private:     &options.Private,
+			AutoInit:    &options.InitReadme,
+		})
+		return ghResponse, err
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	if options.InitReadme && options.DefaultBranch != "" && options.DefaultBranch != repo.GetDefaultBranch() {
+		// The initial branch can't be named on creation. Renaming it also makes the new name the default branch.
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			_, ghResponse, renameErr := client.ghClient.Repositories.RenameBranch(ctx, owner, repository, repo.GetDefaultBranch(), options.DefaultBranch)
+			return ghResponse, renameErr
+		})
+		if err != nil {
+			return RepositoryInfo{}, err
+		}
+	}
+	return mapGitHubRepositoryToRepositoryInfo(repo), nil
+}
+
+// getOrganizationToCreateRepositoryIn returns the organization to create a repository or a fork in,
+// which is empty if the owner is the authenticated user.
+func (client *GitHubClient) getOrganizationToCreateRepositoryIn(ctx context.Context, owner string) (string, error) {
+	user, err := client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(user.Username, owner) {
+		return "", nil
+	}
+	return owner, nil
+}
+
+// DeleteRepository on GitHub
+func (client *GitHubClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
 	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		return client.executeCreatePullRequest(ctx, owner, repository, sourceBranch, targetBranch, title, description)
+		return client.ghClient.Repositories.Delete(ctx, owner, repository)
 	})
 }
 
-func (client *GitHubClient) executeCreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) (*github.Response, error) {
-	head := owner + ":" + sourceBranch
-	client.logger.Debug(vcsutils.CreatingPullRequest, title)
+// ForkRepository on GitHub.
+// The fork is created asynchronously, hence its content may be unavailable for a short while after it is returned.
+func (client *GitHubClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	var org string
+	if targetOwner != "" {
+		if org, err = client.getOrganizationToCreateRepositoryIn(ctx, targetOwner); err != nil {
+			return RepositoryInfo{}, err
+		}
+	}
 
-	_, ghResponse, err := client.ghClient.PullRequests.Create(ctx, owner, repository, &github.NewPullRequest{
-		Title: &title,
-		Body:  &description,
-		Head:  &head,
-		Base:  &targetBranch,
+	var fork *github.Repository
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		var forkErr error
+		fork, ghResponse, forkErr = client.ghClient.Repositories.CreateFork(ctx, owner, repository, &github.RepositoryCreateForkOptions{Organization: org})
+		// According to go-github API - a fork in progress returns a 202 status code, and the fork details are decoded despite the error
+		var ghAcceptedError *github.AcceptedError
+		if errors.As(forkErr, &ghAcceptedError) {
+			forkErr = nil
+		}
+		return ghResponse, forkErr
+	})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapGitHubRepositoryToRepositoryInfo(fork), nil
+}
+
+// ListRepositoryCollaborators on GitHub
+func (client *GitHubClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var results []CollaboratorInfo
+	for nextPage := 1; nextPage != 0; {
+		var users []*github.User
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			users, ghResponse, err = client.ghClient.Repositories.ListCollaborators(ctx, owner, repository, &github.ListCollaboratorsOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			results = append(results, CollaboratorInfo{Username: user.GetLogin(), Permission: mapGitHubPermissionsToRepositoryPermission(user.Permissions)})
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return results, nil
+}
+
+func mapGitHubPermissionsToRepositoryPermission(permissions map[string]bool) RepositoryPermission {
+	switch {
+	case permissions["admin"]:
+		return AdminPermission
+	case permissions["maintain"], permissions["push"]:
+		return WritePermission
+	case permissions["triage"], permissions["pull"]:
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// GetRepositoryPermission on GitHub
+func (client *GitHubClient) GetRepositoryPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return NoPermission, err
+	}
+	var permissionLevel *github.RepositoryPermissionLevel
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		permissionLevel, ghResponse, err = client.ghClient.Repositories.GetPermissionLevel(ctx, owner, repository, username)
+		return ghResponse, err
+	})
+	if err != nil {
+		return NoPermission, err
+	}
+	switch permissionLevel.GetPermission() {
+	case "admin":
+		return AdminPermission, nil
+	case "write":
+		return WritePermission, nil
+	case "read":
+		return ReadPermission, nil
+	default:
+		return NoPermission, nil
+	}
+}
+
+// GetCommitBySha on GitHub
+func (client *GitHubClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	var commit *github.RepositoryCommit
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		commit, ghResponse, err = client.ghClient.Repositories.GetCommit(ctx, owner, repository, sha, nil)
+		return ghResponse, err
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	return mapGitHubCommitToCommitInfo(commit), nil
+}
+
+// CreateLabel on GitHub
+func (client *GitHubClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		_, ghResponse, err = client.ghClient.Issues.CreateLabel(ctx, owner, repository, &github.Label{
+			Name:        &labelInfo.Name,
+			Description: &labelInfo.Description,
+			Color:       &labelInfo.Color,
+		})
+		return ghResponse, err
+	})
+}
+
+// GetLabel on GitHub
+func (client *GitHubClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	var labelInfo *LabelInfo
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		labelInfo, ghResponse, err = client.executeGetLabel(ctx, owner, repository, name)
+		return ghResponse, err
+	})
+	return labelInfo, err
+}
+
+func (client *GitHubClient) executeGetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, *github.Response, error) {
+	label, ghResponse, err := client.ghClient.Issues.GetLabel(ctx, owner, repository, name)
+	if err != nil {
+		if ghResponse != nil && ghResponse.Response != nil && ghResponse.Response.StatusCode == http.StatusNotFound {
+			return nil, ghResponse, nil
+		}
+		return nil, ghResponse, err
+	}
+
+	labelInfo := &LabelInfo{
+		Name:        *label.Name,
+		Description: *label.Description,
+		Color:       *label.Color,
+	}
+	return labelInfo, ghResponse, nil
+}
+
+// ListRepositoryLabels on GitHub
+func (client *GitHubClient) ListRepositoryLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []LabelInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &github.ListOptions{Page: nextPage, PerPage: 100}
+		var labels []*github.Label
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			labels, ghResponse, err = client.ghClient.Issues.ListLabels(ctx, owner, repository, options)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			results = append(results, LabelInfo{
+				Name:        label.GetName(),
+				Description: label.GetDescription(),
+				Color:       label.GetColor(),
+			})
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return results, nil
+}
+
+// UpdateLabel on GitHub
+func (client *GitHubClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		_, ghResponse, err = client.ghClient.Issues.EditLabel(ctx, owner, repository, name, &github.Label{
+			Name:        &labelInfo.Name,
+			Description: &labelInfo.Description,
+			Color:       &labelInfo.Color,
+		})
+		return ghResponse, err
+	})
+}
+
+// DeleteLabel on GitHub
+func (client *GitHubClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Issues.DeleteLabel(ctx, owner, repository, name)
 	})
-	return ghResponse, err
 }
 
-// UpdatePullRequest on GitHub
-func (client *GitHubClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, id int, state vcsutils.PullRequestState) error {
-	client.logger.Debug(vcsutils.UpdatingPullRequest, id)
-	var baseRef *github.PullRequestBranch
-	if targetBranchName != "" {
-		baseRef = &github.PullRequestBranch{Ref: &targetBranchName}
+// ListPullRequestLabels on GitHub
+func (client *GitHubClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
 	}
-	pullRequest := &github.PullRequest{
-		Body:  &body,
-		Title: &title,
-		State: vcsutils.MapPullRequestState(&state),
-		Base:  baseRef,
+
+	results := []string{}
+	for nextPage := 0; ; nextPage++ {
+		options := &github.ListOptions{Page: nextPage}
+		var labels []*github.Label
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			labels, ghResponse, err = client.ghClient.Issues.ListLabelsByIssue(ctx, owner, repository, pullRequestID, options)
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			results = append(results, *label.Name)
+		}
+		if nextPage+1 >= ghResponse.LastPage {
+			break
+		}
+	}
+	return results, nil
+}
+
+// LabelPullRequest on GitHub
+func (client *GitHubClient) LabelPullRequest(ctx context.Context, owner, repository string, pullRequestID int, labels []string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
 	}
 
 	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		_, ghResponse, err := client.ghClient.PullRequests.Edit(ctx, owner, repository, id, pullRequest)
+		_, ghResponse, err := client.ghClient.Issues.AddLabelsToIssue(ctx, owner, repository, pullRequestID, labels)
 		return ghResponse, err
 	})
 }
 
-// ListOpenPullRequestsWithBody on GitHub
-func (client *GitHubClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, true)
-}
+// UnlabelPullRequest on GitHub
+func (client *GitHubClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
 
-// ListOpenPullRequests on GitHub
-func (client *GitHubClient) ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
-	return client.getOpenPullRequests(ctx, owner, repository, false)
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Issues.RemoveLabelForIssue(ctx, owner, repository, pullRequestID, name)
+	})
 }
 
-func (client *GitHubClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
-	var pullRequests []*github.PullRequest
-	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
-	err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+// CreateIssue on GitHub
+func (client *GitHubClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	var issue *github.Issue
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		var err error
-		pullRequests, ghResponse, err = client.ghClient.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{State: "open"})
+		issue, ghResponse, err = client.ghClient.Issues.Create(ctx, owner, repository, &github.IssueRequest{Title: &title, Body: &body})
 		return ghResponse, err
 	})
 	if err != nil {
-		return []PullRequestInfo{}, err
+		return IssueInfo{}, err
 	}
-
-	return mapGitHubPullRequestToPullRequestInfoList(pullRequests, withBody)
+	return mapGitHubIssueToIssueInfo(issue), nil
 }
 
-func (client *GitHubClient) GetPullRequestByID(ctx context.Context, owner, repository string, pullRequestId int) (PullRequestInfo, error) {
-	var pullRequest *github.PullRequest
-	var ghResponse *github.Response
-	var err error
-	client.logger.Debug(vcsutils.FetchingPullRequestById, repository)
+// ListIssues on GitHub.
+// GitHub lists pull requests as issues, hence they are filtered out and a page may contain fewer issues than requested.
+func (client *GitHubClient) ListIssues(ctx context.Context, owner, repository string, options ListIssuesOptions) ([]IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	if err = validateIssueState(options.State); err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	listOptions := &github.IssueListByRepoOptions{
+		State:       "all",
+		Creator:     options.Author,
+		Labels:      options.Labels,
+		ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+	}
+	if options.State != "" {
+		listOptions.State = string(options.State)
+	}
+	var issues []*github.Issue
 	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		pullRequest, ghResponse, err = client.ghClient.PullRequests.Get(ctx, owner, repository, pullRequestId)
+		var ghResponse *github.Response
+		issues, ghResponse, err = client.ghClient.Issues.ListByRepo(ctx, owner, repository, listOptions)
 		return ghResponse, err
 	})
 	if err != nil {
-		return PullRequestInfo{}, err
+		return nil, err
 	}
-
-	if err = vcsutils.CheckResponseStatusWithBody(ghResponse.Response, http.StatusOK); err != nil {
-		return PullRequestInfo{}, err
+	var results []IssueInfo
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			results = append(results, mapGitHubIssueToIssueInfo(issue))
+		}
 	}
+	return results, nil
+}
 
-	return mapGitHubPullRequestToPullRequestInfo(pullRequest, false)
+// AddIssueComment on GitHub
+func (client *GitHubClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Issues.CreateComment(ctx, owner, repository, issueID, &github.IssueComment{Body: &content})
+		return ghResponse, err
+	})
 }
 
-func mapGitHubPullRequestToPullRequestInfo(ghPullRequest *github.PullRequest, withBody bool) (PullRequestInfo, error) {
-	var sourceBranch, targetBranch string
-	var err1, err2 error
-	if ghPullRequest != nil && ghPullRequest.Head != nil && ghPullRequest.Base != nil {
-		sourceBranch, err1 = extractBranchFromLabel(vcsutils.DefaultIfNotNil(ghPullRequest.Head.Label))
-		targetBranch, err2 = extractBranchFromLabel(vcsutils.DefaultIfNotNil(ghPullRequest.Base.Label))
-		err := errors.Join(err1, err2)
-		if err != nil {
-			return PullRequestInfo{}, err
-		}
+// CloseIssue on GitHub
+func (client *GitHubClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
 	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		_, ghResponse, err := client.ghClient.Issues.Edit(ctx, owner, repository, issueID, &github.IssueRequest{State: github.String(string(vcsutils.IssueClosed))})
+		return ghResponse, err
+	})
+}
 
-	var sourceRepoName, sourceRepoOwner string
-	if ghPullRequest.Head.Repo == nil {
-		return PullRequestInfo{}, errors.New("the source repository information is missing when fetching the pull request details")
+func mapGitHubIssueToIssueInfo(issue *github.Issue) IssueInfo {
+	var labels []string
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
 	}
-	if ghPullRequest.Head.Repo.Owner == nil {
-		return PullRequestInfo{}, errors.New("the source repository owner name is missing when fetching the pull request details")
+	return IssueInfo{
+		ID:     int64(issue.GetNumber()),
+		Title:  issue.GetTitle(),
+		Body:   issue.GetBody(),
+		URL:    issue.GetHTMLURL(),
+		Author: issue.GetUser().GetLogin(),
+		State:  vcsutils.IssueState(issue.GetState()),
+		Labels: labels,
 	}
-	sourceRepoName = vcsutils.DefaultIfNotNil(ghPullRequest.Head.Repo.Name)
-	sourceRepoOwner = vcsutils.DefaultIfNotNil(ghPullRequest.Head.Repo.Owner.Login)
+}
 
-	var targetRepoName, targetRepoOwner string
-	if ghPullRequest.Base.Repo == nil {
-		return PullRequestInfo{}, errors.New("the target repository information is missing when fetching the pull request details")
+// UploadCodeScanning to GitHub Security tab
+func (client *GitHubClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, sarifContent string) (id string, err error) {
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return
 	}
-	if ghPullRequest.Base.Repo.Owner == nil {
-		return PullRequestInfo{}, errors.New("the target repository owner name is missing when fetching the pull request details")
+
+	commitSHA := commit.Hash
+	branch = vcsutils.AddBranchPrefix(branch)
+	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
+
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		id, ghResponse, err = client.executeUploadCodeScanning(ctx, owner, repository, branch, commitSHA, sarifContent)
+		return ghResponse, err
+	})
+	return
+}
+
+func (client *GitHubClient) executeUploadCodeScanning(ctx context.Context, owner, repository, branch, commitSHA, sarifContent string) (id string, ghResponse *github.Response, err error) {
+	encodedSarif, err := encodeScanningResult(sarifContent)
+	if err != nil {
+		return
 	}
-	targetRepoName = vcsutils.DefaultIfNotNil(ghPullRequest.Base.Repo.Name)
-	targetRepoOwner = vcsutils.DefaultIfNotNil(ghPullRequest.Base.Repo.Owner.Login)
 
-	var body string
-	if withBody {
-		body = vcsutils.DefaultIfNotNil(ghPullRequest.Body)
+	sarifID, ghResponse, err := client.ghClient.CodeScanning.UploadSarif(ctx, owner, repository, &github.SarifAnalysis{
+		CommitSHA: &commitSHA,
+		Ref:       &branch,
+		Sarif:     &encodedSarif,
+	})
+
+	// According to go-github API - successful ghResponse will return 202 status code
+	// The body of the ghResponse will appear in the error, and the Sarif struct will be empty.
+	if err != nil && ghResponse.Response.StatusCode != http.StatusAccepted {
+		return
 	}
 
-	return PullRequestInfo{
-		ID:   int64(vcsutils.DefaultIfNotNil(ghPullRequest.Number)),
-		URL:  vcsutils.DefaultIfNotNil(ghPullRequest.HTMLURL),
-		Body: body,
-		Source: BranchInfo{
-			Name:       sourceBranch,
-			Repository: sourceRepoName,
-			Owner:      sourceRepoOwner,
-		},
-		Target: BranchInfo{
-			Name:       targetBranch,
-			Repository: targetRepoName,
-			Owner:      targetRepoOwner,
-		},
-	}, nil
+	id, err = handleGitHubUploadSarifID(sarifID, err)
+	return
 }
 
-// Extracts branch name from the following expected label format repo:branch
-func extractBranchFromLabel(label string) (string, error) {
-	split := strings.Split(label, ":")
-	if len(split) <= 1 {
-		return "", fmt.Errorf("bad label format %s", label)
+func handleGitHubUploadSarifID(sarifID *github.SarifID, uploadSarifErr error) (id string, err error) {
+	if sarifID != nil && *sarifID.ID != "" {
+		id = *sarifID.ID
+		return
 	}
-	return split[1], nil
+	var result map[string]string
+	var ghAcceptedError *github.AcceptedError
+	if errors.As(uploadSarifErr, &ghAcceptedError) {
+		if err = json.Unmarshal(ghAcceptedError.Raw, &result); err != nil {
+			return
+		}
+		id = result["id"]
+	}
+	return
 }
 
-// AddPullRequestComment on GitHub
-func (client *GitHubClient) AddPullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+// CreateCodeInsightsReport on GitHub
+func (client *GitHubClient) CreateCodeInsightsReport(_ context.Context, _, _, _ string, _ CodeInsightsReport) error {
+	return errGitHubCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on GitHub
+func (client *GitHubClient) AddCodeInsightsAnnotations(_ context.Context, _, _, _, _ string, _ []CodeInsightsAnnotation) error {
+	return errGitHubCodeInsightsNotSupported
+}
+
+// CreateCheckRun on GitHub.
+// The API accepts a limited number of annotations per request, so the rest of the annotations are added by updating the check run.
+// Notice - creating check runs requires authenticating as a GitHub App.
+func (client *GitHubClient) CreateCheckRun(ctx context.Context, owner, repository string, checkRun CheckRunInfo) (int64, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": checkRun.Name, "head SHA": checkRun.HeadSHA})
 	if err != nil {
-		return err
+		return 0, err
 	}
-
-	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+	if err = validateCheckRunOutput(checkRun); err != nil {
+		return 0, err
+	}
+	annotations, remainingAnnotations := splitGitHubCheckRunAnnotations(checkRun.Annotations)
+	var createdCheckRun *github.CheckRun
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		// We use the Issues API to add a regular comment. The PullRequests API adds a code review comment.
-		_, ghResponse, err = client.ghClient.Issues.CreateComment(ctx, owner, repository, pullRequestID, &github.IssueComment{Body: &content})
+		createdCheckRun, ghResponse, err = client.ghClient.Checks.CreateCheckRun(ctx, owner, repository, github.CreateCheckRunOptions{
+			Name:       checkRun.Name,
+			HeadSHA:    checkRun.HeadSHA,
+			DetailsURL: nilIfEmpty(checkRun.DetailsURL),
+			Status:     nilIfEmpty(checkRun.Status),
+			Conclusion: nilIfEmpty(checkRun.Conclusion),
+			Output:     getGitHubCheckRunOutput(checkRun, annotations),
+		})
 		return ghResponse, err
 	})
+	if err != nil {
+		return 0, err
+	}
+	return createdCheckRun.GetID(), client.addCheckRunAnnotations(ctx, owner, repository, createdCheckRun.GetID(), checkRun, remainingAnnotations)
 }
 
-// AddPullRequestReviewComments on GitHub
-func (client *GitHubClient) AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error {
-	prID := strconv.Itoa(pullRequestID)
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": prID})
+// UpdateCheckRun on GitHub.
+// The API accepts a limited number of annotations per request, so the annotations are added in batches.
+func (client *GitHubClient) UpdateCheckRun(ctx context.Context, owner, repository string, checkRunID int64, checkRun CheckRunInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": checkRun.Name})
 	if err != nil {
 		return err
 	}
-	if len(comments) == 0 {
-		return errors.New(vcsutils.ErrNoCommentsProvided)
+	if err = validateCheckRunOutput(checkRun); err != nil {
+		return err
 	}
-
-	var commits []*github.RepositoryCommit
-	var ghResponse *github.Response
+	annotations, remainingAnnotations := splitGitHubCheckRunAnnotations(checkRun.Annotations)
 	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		commits, ghResponse, err = client.ghClient.PullRequests.ListCommits(ctx, owner, repository, pullRequestID, nil)
+		_, ghResponse, err := client.ghClient.Checks.UpdateCheckRun(ctx, owner, repository, checkRunID, github.UpdateCheckRunOptions{
+			Name:       checkRun.Name,
+			DetailsURL: nilIfEmpty(checkRun.DetailsURL),
+			Status:     nilIfEmpty(checkRun.Status),
+			Conclusion: nilIfEmpty(checkRun.Conclusion),
+			Output:     getGitHubCheckRunOutput(checkRun, annotations),
+		})
 		return ghResponse, err
 	})
 	if err != nil {
 		return err
 	}
-	if len(commits) == 0 {
-		return errors.New("could not fetch the commits list for pull request " + prID)
-	}
-
-	latestCommitSHA := commits[len(commits)-1].GetSHA()
+	return client.addCheckRunAnnotations(ctx, owner, repository, checkRunID, checkRun, remainingAnnotations)
+}
 
-	for _, comment := range comments {
-		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-			ghResponse, err = client.executeCreatePullRequestReviewComment(ctx, owner, repository, latestCommitSHA, pullRequestID, comment)
+// addCheckRunAnnotations adds annotations to a check run in batches, keeping the rest of its output
+func (client *GitHubClient) addCheckRunAnnotations(ctx context.Context, owner, repository string, checkRunID int64, checkRun CheckRunInfo, annotations []CheckRunAnnotation) error {
+	for len(annotations) > 0 {
+		var batch []CheckRunAnnotation
+		batch, annotations = splitGitHubCheckRunAnnotations(annotations)
+		err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+			_, ghResponse, err := client.ghClient.Checks.UpdateCheckRun(ctx, owner, repository, checkRunID, github.UpdateCheckRunOptions{
+				Name:   checkRun.Name,
+				Output: getGitHubCheckRunOutput(checkRun, batch),
+			})
 			return ghResponse, err
 		})
 		if err != nil {
@@ -533,456 +2579,606 @@ func (client *GitHubClient) AddPullRequestReviewComments(ctx context.Context, ow
 	return nil
 }
 
-func (client *GitHubClient) executeCreatePullRequestReviewComment(ctx context.Context, owner, repository, latestCommitSHA string, pullRequestID int, comment PullRequestComment) (*github.Response, error) {
-	filePath := filepath.Clean(comment.NewFilePath)
-	startLine := &comment.NewStartLine
-	// GitHub API won't accept 'start_line' if it equals the end line
-	if *startLine == comment.NewEndLine {
-		startLine = nil
+func validateCheckRunOutput(checkRun CheckRunInfo) error {
+	hasOutput := checkRun.Title != "" || checkRun.Summary != "" || checkRun.Text != "" || len(checkRun.Annotations) > 0
+	if hasOutput && (checkRun.Title == "" || checkRun.Summary == "") {
+		return errors.New("the title and summary of the check run output are required when adding output to a check run")
 	}
-	_, ghResponse, err := client.ghClient.PullRequests.CreateComment(ctx, owner, repository, pullRequestID, &github.PullRequestComment{
-		CommitID:  &latestCommitSHA,
-		Body:      &comment.Content,
-		StartLine: startLine,
-		Line:      &comment.NewEndLine,
-		Path:      &filePath,
-	})
-	if err != nil {
-		err = fmt.Errorf("could not create a code review comment for <%s/%s> in pull request %d. error received: %w",
-			owner, repository, pullRequestID, err)
+	return nil
+}
+
+func splitGitHubCheckRunAnnotations(annotations []CheckRunAnnotation) (batch, remaining []CheckRunAnnotation) {
+	if len(annotations) <= githubCheckRunAnnotationsLimit {
+		return annotations, nil
 	}
-	return ghResponse, err
+	return annotations[:githubCheckRunAnnotationsLimit], annotations[githubCheckRunAnnotationsLimit:]
 }
 
-// ListPullRequestReviewComments on GitHub
-func (client *GitHubClient) ListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
-	if err != nil {
-		return nil, err
+func getGitHubCheckRunOutput(checkRun CheckRunInfo, annotations []CheckRunAnnotation) *github.CheckRunOutput {
+	if checkRun.Title == "" {
+		return nil
+	}
+	output := &github.CheckRunOutput{
+		Title:   &checkRun.Title,
+		Summary: &checkRun.Summary,
+		Text:    nilIfEmpty(checkRun.Text),
+	}
+	for _, annotation := range annotations {
+		endLine := annotation.EndLine
+		if endLine == 0 {
+			endLine = annotation.StartLine
+		}
+		output.Annotations = append(output.Annotations, &github.CheckRunAnnotation{
+			Path:            vcsutils.PointerOf(annotation.Path),
+			StartLine:       vcsutils.PointerOf(annotation.StartLine),
+			EndLine:         vcsutils.PointerOf(endLine),
+			AnnotationLevel: vcsutils.PointerOf(string(annotation.Level)),
+			Title:           nilIfEmpty(annotation.Title),
+			Message:         vcsutils.PointerOf(annotation.Message),
+		})
 	}
+	return output
+}
 
-	commentsInfoList := []CommentInfo{}
+// nilIfEmpty returns a pointer to the value, or nil if the value is empty so it's omitted from the request
+func nilIfEmpty[T ~string](value T) *string {
+	if value == "" {
+		return nil
+	}
+	return vcsutils.PointerOf(string(value))
+}
+
+// DownloadFileFromRepo on GitHub
+func (client *GitHubClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) (content []byte, statusCode int, err error) {
 	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		commentsInfoList, ghResponse, err = client.executeListPullRequestReviewComments(ctx, owner, repository, pullRequestID)
+		content, statusCode, ghResponse, err = client.executeDownloadFileFromRepo(ctx, owner, repository, branch, path)
 		return ghResponse, err
 	})
-	return commentsInfoList, err
+	return
 }
 
-func (client *GitHubClient) executeListPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, *github.Response, error) {
-	commentsList, ghResponse, err := client.ghClient.PullRequests.ListComments(ctx, owner, repository, pullRequestID, nil)
-	if err != nil {
-		return []CommentInfo{}, ghResponse, err
+func (client *GitHubClient) executeDownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) (content []byte, statusCode int, ghResponse *github.Response, err error) {
+	body, ghResponse, err := client.ghClient.Repositories.DownloadContents(ctx, owner, repository, path, &github.RepositoryContentGetOptions{Ref: branch})
+	defer func() {
+		if body != nil {
+			err = errors.Join(err, body.Close())
+		}
+	}()
+
+	if ghResponse == nil || ghResponse.Response == nil {
+		return
 	}
-	commentsInfoList := []CommentInfo{}
-	for _, comment := range commentsList {
-		commentsInfoList = append(commentsInfoList, CommentInfo{
-			ID:      comment.GetID(),
-			Content: comment.GetBody(),
-			Created: comment.GetCreatedAt().Time,
-		})
+
+	statusCode = ghResponse.StatusCode
+	if err != nil && statusCode != http.StatusOK {
+		err = fmt.Errorf("expected %d status code while received %d status code with error:\n%s", http.StatusOK, ghResponse.StatusCode, err)
+		return
 	}
-	return commentsInfoList, ghResponse, nil
+
+	if body != nil {
+		content, err = io.ReadAll(body)
+	}
+	return
 }
 
-// ListPullRequestComments on GitHub
-func (client *GitHubClient) ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// ListDirectoryContents on GitHub
+func (client *GitHubClient) ListDirectoryContents(ctx context.Context, owner, repository, ref, path string) ([]DirectoryEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
 	if err != nil {
-		return []CommentInfo{}, err
+		return nil, err
 	}
 
-	var commentsList []*github.IssueComment
+	var fileContent *github.RepositoryContent
+	var directoryContent []*github.RepositoryContent
 	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		commentsList, ghResponse, err = client.ghClient.Issues.ListComments(ctx, owner, repository, pullRequestID, &github.IssueListCommentsOptions{})
+		fileContent, directoryContent, ghResponse, err = client.ghClient.Repositories.GetContents(ctx, owner, repository, path, &github.RepositoryContentGetOptions{Ref: ref})
 		return ghResponse, err
 	})
-
 	if err != nil {
-		return []CommentInfo{}, err
+		return nil, err
 	}
-
-	return mapGitHubIssuesCommentToCommentInfoList(commentsList)
-}
-
-// DeletePullRequestReviewComments on GitHub
-func (client *GitHubClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, _ int, comments ...CommentInfo) error {
-	for _, comment := range comments {
-		commentID := comment.ID
-		err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "commentID": strconv.FormatInt(commentID, 10)})
-		if err != nil {
-			return err
-		}
-
-		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-			return client.executeDeletePullRequestReviewComment(ctx, owner, repository, commentID)
-		})
-		if err != nil {
-			return err
-		}
-
+	if fileContent != nil {
+		return nil, fmt.Errorf("path '%s' is not a directory", path)
 	}
-	return nil
-}
 
-func (client *GitHubClient) executeDeletePullRequestReviewComment(ctx context.Context, owner, repository string, commentID int64) (*github.Response, error) {
-	ghResponse, err := client.ghClient.PullRequests.DeleteComment(ctx, owner, repository, commentID)
-	if err != nil {
-		err = fmt.Errorf("could not delete pull request review comment: %w", err)
+	entries := make([]DirectoryEntry, 0, len(directoryContent))
+	for _, content := range directoryContent {
+		entries = append(entries, DirectoryEntry{Name: content.GetName(), Path: content.GetPath(), IsDir: content.GetType() == "dir"})
 	}
-	return ghResponse, err
+	return entries, nil
 }
 
-// DeletePullRequestComment on GitHub
-func (client *GitHubClient) DeletePullRequestComment(ctx context.Context, owner, repository string, _, commentID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// CommitFiles on GitHub
+func (client *GitHubClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, files []FileToCommit) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "commitMessage": commitMessage})
 	if err != nil {
 		return err
 	}
+	if err = validateFilesToCommit(files); err != nil {
+		return err
+	}
 	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		return client.executeDeletePullRequestComment(ctx, owner, repository, commentID)
+		return client.executeCommitFiles(ctx, owner, repository, branch, commitMessage, files)
 	})
 }
 
-func (client *GitHubClient) executeDeletePullRequestComment(ctx context.Context, owner, repository string, commentID int) (*github.Response, error) {
-	ghResponse, err := client.ghClient.Issues.DeleteComment(ctx, owner, repository, int64(commentID))
+func (client *GitHubClient) executeCommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, files []FileToCommit) (*github.Response, error) {
+	branchRef, ghResponse, err := client.ghClient.Git.GetRef(ctx, owner, repository, vcsutils.AddBranchPrefix(branch))
+	if err != nil {
+		return ghResponse, err
+	}
+	parentCommit, ghResponse, err := client.ghClient.Git.GetCommit(ctx, owner, repository, branchRef.GetObject().GetSHA())
 	if err != nil {
 		return ghResponse, err
 	}
 
-	var statusCode int
-	if ghResponse.Response != nil {
-		statusCode = ghResponse.Response.StatusCode
+	treeEntries := make([]*github.TreeEntry, 0, len(files))
+	for _, file := range files {
+		treeEntry := &github.TreeEntry{Path: github.String(file.Path), Mode: github.String("100644"), Type: github.String("blob")}
+		// A tree entry without a SHA deletes the file
+		if file.ChangeType != FileDeleted {
+			blob, ghResponse, err := client.ghClient.Git.CreateBlob(ctx, owner, repository, &github.Blob{
+				Content:  github.String(stdbase64.StdEncoding.EncodeToString(file.Content)),
+				Encoding: github.String("base64"),
+			})
+			if err != nil {
+				return ghResponse, err
+			}
+			treeEntry.SHA = blob.SHA
+		}
+		treeEntries = append(treeEntries, treeEntry)
 	}
-	if statusCode != http.StatusNoContent && statusCode != http.StatusOK {
-		return ghResponse, fmt.Errorf("expected %d status code while received %d status code", http.StatusNoContent, ghResponse.Response.StatusCode)
+	tree, ghResponse, err := client.ghClient.Git.CreateTree(ctx, owner, repository, parentCommit.GetTree().GetSHA(), treeEntries)
+	if err != nil {
+		return ghResponse, err
 	}
 
-	return ghResponse, nil
-}
-
-// GetLatestCommit on GitHub
-func (client *GitHubClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
-	commits, err := client.GetCommits(ctx, owner, repository, branch)
+	commit, ghResponse, err := client.ghClient.Git.CreateCommit(ctx, owner, repository, &github.Commit{
+		Message: &commitMessage,
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: parentCommit.SHA}},
+	}, nil)
 	if err != nil {
-		return CommitInfo{}, err
-	}
-	latestCommit := CommitInfo{}
-	if len(commits) > 0 {
-		latestCommit = commits[0]
+		return ghResponse, err
 	}
-	return latestCommit, nil
+
+	branchRef.Object.SHA = commit.SHA
+	_, ghResponse, err = client.ghClient.Git.UpdateRef(ctx, owner, repository, branchRef, false)
+	return ghResponse, err
 }
 
-// GetCommits on GitHub
-func (client *GitHubClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"branch":     branch,
-	})
+// GetRepositoryEnvironmentInfo on GitHub
+func (client *GitHubClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
 	if err != nil {
-		return nil, err
+		return RepositoryEnvironmentInfo{}, err
 	}
 
-	var commitsInfo []CommitInfo
+	var repositoryEnvInfo *RepositoryEnvironmentInfo
 	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
 		var ghResponse *github.Response
-		commitsInfo, ghResponse, err = client.executeGetCommits(ctx, owner, repository, branch)
+		repositoryEnvInfo, ghResponse, err = client.executeGetRepositoryEnvironmentInfo(ctx, owner, repository, name)
 		return ghResponse, err
 	})
-	return commitsInfo, err
+	return *repositoryEnvInfo, err
 }
 
-func (client *GitHubClient) executeGetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, *github.Response, error) {
-	listOptions := &github.CommitsListOptions{
-		SHA: branch,
-		ListOptions: github.ListOptions{
-			Page:    1,
-			PerPage: vcsutils.NumberOfCommitsToFetch,
-		},
+func (client *GitHubClient) executeGetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (*RepositoryEnvironmentInfo, *github.Response, error) {
+	environment, ghResponse, err := client.ghClient.Repositories.GetEnvironment(ctx, owner, repository, name)
+	if err != nil {
+		return &RepositoryEnvironmentInfo{}, ghResponse, err
 	}
 
-	commits, ghResponse, err := client.ghClient.Repositories.ListCommits(ctx, owner, repository, listOptions)
-	if err != nil {
-		return nil, ghResponse, err
+	if err = vcsutils.CheckResponseStatusWithBody(ghResponse.Response, http.StatusOK); err != nil {
+		return &RepositoryEnvironmentInfo{}, ghResponse, err
 	}
 
-	var commitsInfo []CommitInfo
-	for _, commit := range commits {
-		commitInfo := mapGitHubCommitToCommitInfo(commit)
-		commitsInfo = append(commitsInfo, commitInfo)
+	reviewers, err := extractGitHubEnvironmentReviewers(environment)
+	if err != nil {
+		return &RepositoryEnvironmentInfo{}, ghResponse, err
 	}
-	return commitsInfo, ghResponse, nil
+
+	return &RepositoryEnvironmentInfo{
+			Name:      environment.GetName(),
+			Url:       environment.GetURL(),
+			Reviewers: reviewers,
+		},
+		ghResponse,
+		nil
 }
 
-// GetRepositoryInfo on GitHub
-func (client *GitHubClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+// ListEnvironments on GitHub
+func (client *GitHubClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
 	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return RepositoryInfo{}, err
+		return nil, err
 	}
-
-	var repo *github.Repository
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+	var environments []RepositoryEnvironmentInfo
+	for nextPage := 1; nextPage != 0; {
+		var envResponse *github.EnvResponse
 		var ghResponse *github.Response
-		repo, ghResponse, err = client.ghClient.Repositories.Get(ctx, owner, repository)
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			envResponse, ghResponse, err = client.ghClient.Repositories.ListEnvironments(ctx, owner, repository,
+				&github.EnvironmentListOptions{ListOptions: github.ListOptions{Page: nextPage, PerPage: 100}})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range envResponse.Environments {
+			reviewers, err := extractGitHubEnvironmentReviewers(environment)
+			if err != nil {
+				return nil, err
+			}
+			environments = append(environments, RepositoryEnvironmentInfo{
+				Name:      environment.GetName(),
+				Url:       environment.GetURL(),
+				Reviewers: reviewers,
+			})
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return environments, nil
+}
+
+// CreateDeployment on GitHub.
+// The deployment only records the ref, so the default branch isn't merged into it and its commit statuses aren't checked.
+func (client *GitHubClient) CreateDeployment(ctx context.Context, owner, repository, ref, environment, description string) (DeploymentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref, "environment": environment})
+	if err != nil {
+		return DeploymentInfo{}, err
+	}
+	request := &github.DeploymentRequest{
+		Ref:              &ref,
+		Environment:      &environment,
+		AutoMerge:        github.Bool(false),
+		RequiredContexts: &[]string{},
+	}
+	if description != "" {
+		request.Description = &description
+	}
+	var deployment *github.Deployment
+	err = client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		deployment, ghResponse, err = client.ghClient.Repositories.CreateDeployment(ctx, owner, repository, request)
 		return ghResponse, err
 	})
 	if err != nil {
-		return RepositoryInfo{}, err
+		return DeploymentInfo{}, err
+	}
+	return DeploymentInfo{
+		ID:          strconv.FormatInt(deployment.GetID(), 10),
+		Ref:         deployment.GetRef(),
+		CommitSHA:   deployment.GetSHA(),
+		Environment: deployment.GetEnvironment(),
+		Description: deployment.GetDescription(),
+		CreatedAt:   deployment.GetCreatedAt().Time,
+	}, nil
+}
+
+// SetDeploymentStatus on GitHub
+func (client *GitHubClient) SetDeploymentStatus(ctx context.Context, owner, repository, deploymentID string, state DeploymentState, description, environmentURL string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "deploymentID": deploymentID})
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseInt(deploymentID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deployment ID '%s': %w", deploymentID, err)
 	}
+	request := &github.DeploymentStatusRequest{State: github.String(getGitHubDeploymentState(state))}
+	if description != "" {
+		request.Description = &description
+	}
+	if environmentURL != "" {
+		request.EnvironmentURL = &environmentURL
+	}
+	return client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		_, ghResponse, err = client.ghClient.Repositories.CreateDeploymentStatus(ctx, owner, repository, id, request)
+		return ghResponse, err
+	})
+}
 
-	return RepositoryInfo{RepositoryVisibility: getGitHubRepositoryVisibility(repo), CloneInfo: CloneInfo{HTTP: repo.GetCloneURL(), SSH: repo.GetSSHURL()}}, nil
+func getGitHubDeploymentState(state DeploymentState) string {
+	switch state {
+	case DeploymentInProgress:
+		return "in_progress"
+	case DeploymentSuccess:
+		return "success"
+	case DeploymentFailure:
+		return "failure"
+	}
+	return "pending"
 }
 
-// GetCommitBySha on GitHub
-func (client *GitHubClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"sha":        sha,
+// CreateOrUpdateRepositorySecret on GitHub.
+// The value is encrypted with the public key of the repository before it is sent, as required by GitHub Actions.
+func (client *GitHubClient) CreateOrUpdateRepositorySecret(ctx context.Context, owner, repository, name, value string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name, "value": value})
+	if err != nil {
+		return err
+	}
+	var publicKey *github.PublicKey
+	err = client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		publicKey, ghResponse, err = client.ghClient.Actions.GetRepoPublicKey(ctx, owner, repository)
+		return ghResponse, err
 	})
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
+	encryptedSecret, err := encryptGitHubSecret(name, value, publicKey)
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Actions.CreateOrUpdateRepoSecret(ctx, owner, repository, encryptedSecret)
+	})
+}
 
-	var commit *github.RepositoryCommit
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+// ListRepositoryVariables on GitHub.
+// Returns the GitHub Actions variables of the repository, followed by its secrets.
+func (client *GitHubClient) ListRepositoryVariables(ctx context.Context, owner, repository string) ([]RepositoryVariableInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	var variables []RepositoryVariableInfo
+	for nextPage := 1; nextPage != 0; {
+		var actionsVariables *github.ActionsVariables
 		var ghResponse *github.Response
-		commit, ghResponse, err = client.ghClient.Repositories.GetCommit(ctx, owner, repository, sha, nil)
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			actionsVariables, ghResponse, err = client.ghClient.Actions.ListRepoVariables(ctx, owner, repository, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, variable := range actionsVariables.Variables {
+			variables = append(variables, RepositoryVariableInfo{Name: variable.Name, Value: variable.Value})
+		}
+		nextPage = ghResponse.NextPage
+	}
+	for nextPage := 1; nextPage != 0; {
+		var secrets *github.Secrets
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			secrets, ghResponse, err = client.ghClient.Actions.ListRepoSecrets(ctx, owner, repository, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets.Secrets {
+			variables = append(variables, RepositoryVariableInfo{Name: secret.Name, IsSecret: true})
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return variables, nil
+}
+
+// CreateOrUpdateOrganizationSecret on GitHub.
+// The value is encrypted with the public key of the organization before it is sent.
+func (client *GitHubClient) CreateOrUpdateOrganizationSecret(ctx context.Context, owner, name, value string, visibility SecretVisibility, repositories []string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name, "value": value})
+	if err != nil {
+		return err
+	}
+	// GitHub grants access to the selected repositories by their IDs
+	var selectedRepositoryIDs github.SelectedRepoIDs
+	if visibility == VisibleToSelectedRepositories {
+		for _, repository := range repositories {
+			repositoryID, err := client.getRepositoryID(ctx, owner, repository)
+			if err != nil {
+				return err
+			}
+			selectedRepositoryIDs = append(selectedRepositoryIDs, repositoryID)
+		}
+	}
+	var publicKey *github.PublicKey
+	err = client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		publicKey, ghResponse, err = client.ghClient.Actions.GetOrgPublicKey(ctx, owner)
 		return ghResponse, err
 	})
 	if err != nil {
-		return CommitInfo{}, err
+		return err
 	}
-
-	return mapGitHubCommitToCommitInfo(commit), nil
-}
-
-// CreateLabel on GitHub
-func (client *GitHubClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+	encryptedSecret, err := encryptGitHubSecret(name, value, publicKey)
 	if err != nil {
 		return err
 	}
-
+	encryptedSecret.Visibility = getGitHubSecretVisibility(visibility)
+	encryptedSecret.SelectedRepositoryIDs = selectedRepositoryIDs
 	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		var ghResponse *github.Response
-		_, ghResponse, err = client.ghClient.Issues.CreateLabel(ctx, owner, repository, &github.Label{
-			Name:        &labelInfo.Name,
-			Description: &labelInfo.Description,
-			Color:       &labelInfo.Color,
-		})
-		return ghResponse, err
+		return client.ghClient.Actions.CreateOrUpdateOrgSecret(ctx, owner, encryptedSecret)
 	})
 }
 
-// GetLabel on GitHub
-func (client *GitHubClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+// ListOrganizationSecrets on GitHub
+func (client *GitHubClient) ListOrganizationSecrets(ctx context.Context, owner string) ([]SecretInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner})
 	if err != nil {
 		return nil, err
 	}
-
-	var labelInfo *LabelInfo
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		var ghResponse *github.Response
-		labelInfo, ghResponse, err = client.executeGetLabel(ctx, owner, repository, name)
-		return ghResponse, err
+	return client.listSecrets(func(listOptions *github.ListOptions) (*github.Secrets, *github.Response, error) {
+		return client.ghClient.Actions.ListOrgSecrets(ctx, owner, listOptions)
 	})
-	return labelInfo, err
-}
-
-func (client *GitHubClient) executeGetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, *github.Response, error) {
-	label, ghResponse, err := client.ghClient.Issues.GetLabel(ctx, owner, repository, name)
-	if err != nil {
-		if ghResponse != nil && ghResponse.Response != nil && ghResponse.Response.StatusCode == http.StatusNotFound {
-			return nil, ghResponse, nil
-		}
-		return nil, ghResponse, err
-	}
-
-	labelInfo := &LabelInfo{
-		Name:        *label.Name,
-		Description: *label.Description,
-		Color:       *label.Color,
-	}
-	return labelInfo, ghResponse, nil
 }
 
-// ListPullRequestLabels on GitHub
-func (client *GitHubClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// ListOrganizationSecretRepositories on GitHub
+func (client *GitHubClient) ListOrganizationSecretRepositories(ctx context.Context, owner, name string) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name})
 	if err != nil {
 		return nil, err
 	}
-
-	results := []string{}
-	for nextPage := 0; ; nextPage++ {
-		options := &github.ListOptions{Page: nextPage}
-		var labels []*github.Label
+	var repositories []string
+	for nextPage := 1; nextPage != 0; {
+		var selectedRepositories *github.SelectedReposList
 		var ghResponse *github.Response
 		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-			labels, ghResponse, err = client.ghClient.Issues.ListLabelsByIssue(ctx, owner, repository, pullRequestID, options)
+			selectedRepositories, ghResponse, err = client.ghClient.Actions.ListSelectedReposForOrgSecret(ctx, owner, name, &github.ListOptions{Page: nextPage, PerPage: 100})
 			return ghResponse, err
 		})
 		if err != nil {
 			return nil, err
 		}
-		for _, label := range labels {
-			results = append(results, *label.Name)
-		}
-		if nextPage+1 >= ghResponse.LastPage {
-			break
+		for _, repository := range selectedRepositories.Repositories {
+			repositories = append(repositories, repository.GetName())
 		}
+		nextPage = ghResponse.NextPage
 	}
-	return results, nil
+	return repositories, nil
 }
 
-// UnlabelPullRequest on GitHub
-func (client *GitHubClient) UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// DeleteOrganizationSecret on GitHub
+func (client *GitHubClient) DeleteOrganizationSecret(ctx context.Context, owner, name string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "name": name})
 	if err != nil {
 		return err
 	}
-
 	return client.runWithRateLimitRetries(func() (*github.Response, error) {
-		return client.ghClient.Issues.RemoveLabelForIssue(ctx, owner, repository, pullRequestID, name)
+		return client.ghClient.Actions.DeleteOrgSecret(ctx, owner, name)
 	})
 }
 
-// UploadCodeScanning to GitHub Security tab
-func (client *GitHubClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, sarifContent string) (id string, err error) {
-	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+// CreateOrUpdateEnvironmentSecret on GitHub.
+// The value is encrypted with the public key of the environment before it is sent.
+func (client *GitHubClient) CreateOrUpdateEnvironmentSecret(ctx context.Context, owner, repository, environment, name, value string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "environment": environment, "name": name, "value": value})
 	if err != nil {
-		return
+		return err
 	}
-
-	commitSHA := commit.Hash
-	branch = vcsutils.AddBranchPrefix(branch)
-	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
-
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		var ghResponse *github.Response
-		id, ghResponse, err = client.executeUploadCodeScanning(ctx, owner, repository, branch, commitSHA, sarifContent)
+	repositoryID, err := client.getRepositoryID(ctx, owner, repository)
+	if err != nil {
+		return err
+	}
+	var publicKey *github.PublicKey
+	err = client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		publicKey, ghResponse, err = client.ghClient.Actions.GetEnvPublicKey(ctx, int(repositoryID), environment)
 		return ghResponse, err
 	})
-	return
-}
-
-func (client *GitHubClient) executeUploadCodeScanning(ctx context.Context, owner, repository, branch, commitSHA, sarifContent string) (id string, ghResponse *github.Response, err error) {
-	encodedSarif, err := encodeScanningResult(sarifContent)
 	if err != nil {
-		return
+		return err
 	}
-
-	sarifID, ghResponse, err := client.ghClient.CodeScanning.UploadSarif(ctx, owner, repository, &github.SarifAnalysis{
-		CommitSHA: &commitSHA,
-		Ref:       &branch,
-		Sarif:     &encodedSarif,
-	})
-
-	// According to go-github API - successful ghResponse will return 202 status code
-	// The body of the ghResponse will appear in the error, and the Sarif struct will be empty.
-	if err != nil && ghResponse.Response.StatusCode != http.StatusAccepted {
-		return
+	encryptedSecret, err := encryptGitHubSecret(name, value, publicKey)
+	if err != nil {
+		return err
 	}
-
-	id, err = handleGitHubUploadSarifID(sarifID, err)
-	return
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Actions.CreateOrUpdateEnvSecret(ctx, int(repositoryID), environment, encryptedSecret)
+	})
 }
 
-func handleGitHubUploadSarifID(sarifID *github.SarifID, uploadSarifErr error) (id string, err error) {
-	if sarifID != nil && *sarifID.ID != "" {
-		id = *sarifID.ID
-		return
+// ListEnvironmentSecrets on GitHub
+func (client *GitHubClient) ListEnvironmentSecrets(ctx context.Context, owner, repository, environment string) ([]SecretInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "environment": environment})
+	if err != nil {
+		return nil, err
 	}
-	var result map[string]string
-	var ghAcceptedError *github.AcceptedError
-	if errors.As(uploadSarifErr, &ghAcceptedError) {
-		if err = json.Unmarshal(ghAcceptedError.Raw, &result); err != nil {
-			return
-		}
-		id = result["id"]
+	repositoryID, err := client.getRepositoryID(ctx, owner, repository)
+	if err != nil {
+		return nil, err
 	}
-	return
+	return client.listSecrets(func(listOptions *github.ListOptions) (*github.Secrets, *github.Response, error) {
+		return client.ghClient.Actions.ListEnvSecrets(ctx, int(repositoryID), environment, listOptions)
+	})
 }
 
-// DownloadFileFromRepo on GitHub
-func (client *GitHubClient) DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) (content []byte, statusCode int, err error) {
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		var ghResponse *github.Response
-		content, statusCode, ghResponse, err = client.executeDownloadFileFromRepo(ctx, owner, repository, branch, path)
-		return ghResponse, err
+// DeleteEnvironmentSecret on GitHub
+func (client *GitHubClient) DeleteEnvironmentSecret(ctx context.Context, owner, repository, environment, name string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "environment": environment, "name": name})
+	if err != nil {
+		return err
+	}
+	repositoryID, err := client.getRepositoryID(ctx, owner, repository)
+	if err != nil {
+		return err
+	}
+	return client.runWithRateLimitRetries(func() (*github.Response, error) {
+		return client.ghClient.Actions.DeleteEnvSecret(ctx, int(repositoryID), environment, name)
 	})
-	return
 }
 
-func (client *GitHubClient) executeDownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) (content []byte, statusCode int, ghResponse *github.Response, err error) {
-	body, ghResponse, err := client.ghClient.Repositories.DownloadContents(ctx, owner, repository, path, &github.RepositoryContentGetOptions{Ref: branch})
-	defer func() {
-		if body != nil {
-			err = errors.Join(err, body.Close())
+// listSecrets returns all the pages of the secrets listed by the list function
+func (client *GitHubClient) listSecrets(list func(listOptions *github.ListOptions) (*github.Secrets, *github.Response, error)) ([]SecretInfo, error) {
+	var secretsInfo []SecretInfo
+	for nextPage := 1; nextPage != 0; {
+		var secrets *github.Secrets
+		var ghResponse *github.Response
+		err := client.runWithRateLimitRetries(func() (*github.Response, error) {
+			var err error
+			secrets, ghResponse, err = list(&github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
 		}
-	}()
-
-	if ghResponse == nil || ghResponse.Response == nil {
-		return
+		for _, secret := range secrets.Secrets {
+			secretsInfo = append(secretsInfo, SecretInfo{
+				Name:          secret.Name,
+				Visibility:    mapGitHubSecretVisibility(secret.Visibility),
+				CreatedAt:     secret.CreatedAt.Time,
+				LastUpdatedAt: secret.UpdatedAt.Time,
+			})
+		}
+		nextPage = ghResponse.NextPage
 	}
+	return secretsInfo, nil
+}
 
-	statusCode = ghResponse.StatusCode
-	if err != nil && statusCode != http.StatusOK {
-		err = fmt.Errorf("expected %d status code while received %d status code with error:\n%s", http.StatusOK, ghResponse.StatusCode, err)
-		return
+// getRepositoryID returns the ID of a repository, which the GitHub API requires instead of the repository name in some of the secrets APIs
+func (client *GitHubClient) getRepositoryID(ctx context.Context, owner, repository string) (int64, error) {
+	var repo *github.Repository
+	err := client.runWithRateLimitRetries(func() (ghResponse *github.Response, err error) {
+		repo, ghResponse, err = client.ghClient.Repositories.Get(ctx, owner, repository)
+		return ghResponse, err
+	})
+	if err != nil {
+		return 0, err
 	}
+	return repo.GetID(), nil
+}
 
-	if body != nil {
-		content, err = io.ReadAll(body)
+func getGitHubSecretVisibility(visibility SecretVisibility) string {
+	switch visibility {
+	case VisibleToPrivateRepositories:
+		return "private"
+	case VisibleToSelectedRepositories:
+		return "selected"
 	}
-	return
+	return "all"
 }
 
-// GetRepositoryEnvironmentInfo on GitHub
-func (client *GitHubClient) GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
-	if err != nil {
-		return RepositoryEnvironmentInfo{}, err
+func mapGitHubSecretVisibility(visibility string) SecretVisibility {
+	switch visibility {
+	case "private":
+		return VisibleToPrivateRepositories
+	case "selected":
+		return VisibleToSelectedRepositories
 	}
-
-	var repositoryEnvInfo *RepositoryEnvironmentInfo
-	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
-		var ghResponse *github.Response
-		repositoryEnvInfo, ghResponse, err = client.executeGetRepositoryEnvironmentInfo(ctx, owner, repository, name)
-		return ghResponse, err
-	})
-	return *repositoryEnvInfo, err
+	return VisibleToAllRepositories
 }
 
-func (client *GitHubClient) executeGetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (*RepositoryEnvironmentInfo, *github.Response, error) {
-	environment, ghResponse, err := client.ghClient.Repositories.GetEnvironment(ctx, owner, repository, name)
+// encryptGitHubSecret encrypts a secret value in a libsodium sealed box with the public key of a repository, an organization or an environment
+func encryptGitHubSecret(name, value string, publicKey *github.PublicKey) (*github.EncryptedSecret, error) {
+	decodedKey, err := stdbase64.StdEncoding.DecodeString(publicKey.GetKey())
 	if err != nil {
-		return &RepositoryEnvironmentInfo{}, ghResponse, err
+		return nil, fmt.Errorf("failed to decode the public key of the secrets: %w", err)
 	}
-
-	if err = vcsutils.CheckResponseStatusWithBody(ghResponse.Response, http.StatusOK); err != nil {
-		return &RepositoryEnvironmentInfo{}, ghResponse, err
+	var recipientKey [32]byte
+	if len(decodedKey) != len(recipientKey) {
+		return nil, fmt.Errorf("unexpected public key length of %d bytes, expected %d bytes", len(decodedKey), len(recipientKey))
 	}
-
-	reviewers, err := extractGitHubEnvironmentReviewers(environment)
+	copy(recipientKey[:], decodedKey)
+	encryptedValue, err := box.SealAnonymous(nil, []byte(value), &recipientKey, rand.Reader)
 	if err != nil {
-		return &RepositoryEnvironmentInfo{}, ghResponse, err
+		return nil, err
 	}
-
-	return &RepositoryEnvironmentInfo{
-			Name:      environment.GetName(),
-			Url:       environment.GetURL(),
-			Reviewers: reviewers,
-		},
-		ghResponse,
-		nil
+	return &github.EncryptedSecret{
+		Name:           name,
+		KeyID:          publicKey.GetKeyID(),
+		EncryptedValue: stdbase64.StdEncoding.EncodeToString(encryptedValue),
+	}, nil
 }
 
 func (client *GitHubClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
@@ -1034,6 +3230,101 @@ func (client *GitHubClient) executeGetModifiedFiles(ctx context.Context, owner,
 	return fileNamesList, ghResponse, nil
 }
 
+// CompareCommits on GitHub
+func (client *GitHubClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	})
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+
+	var commitsComparison CommitsComparison
+	for nextPage := 1; nextPage != 0; {
+		var comparison *github.CommitsComparison
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			comparison, ghResponse, err = client.ghClient.Repositories.CompareCommits(ctx, owner, repository, base, head, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return CommitsComparison{}, err
+		}
+		for _, commit := range comparison.Commits {
+			commitsComparison.Commits = append(commitsComparison.Commits, mapGitHubCommitToCommitInfo(commit))
+		}
+		// The changed files of the entire comparison are returned with the first page only
+		if nextPage == 1 {
+			for _, commitFile := range comparison.Files {
+				commitsComparison.Files = append(commitsComparison.Files, mapGitHubCommitFileToPullRequestFile(commitFile))
+			}
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return commitsComparison, nil
+}
+
+// ListPullRequestFiles on GitHub
+func (client *GitHubClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []PullRequestFile
+	for nextPage := 1; nextPage != 0; {
+		var commitFiles []*github.CommitFile
+		var ghResponse *github.Response
+		err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+			commitFiles, ghResponse, err = client.ghClient.PullRequests.ListFiles(ctx, owner, repository, pullRequestID, &github.ListOptions{Page: nextPage, PerPage: 100})
+			return ghResponse, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, commitFile := range commitFiles {
+			files = append(files, mapGitHubCommitFileToPullRequestFile(commitFile))
+		}
+		nextPage = ghResponse.NextPage
+	}
+	return files, nil
+}
+
+// GetPullRequestDiff on GitHub
+func (client *GitHubClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return "", err
+	}
+
+	var diff string
+	err = client.runWithRateLimitRetries(func() (*github.Response, error) {
+		var ghResponse *github.Response
+		diff, ghResponse, err = client.ghClient.PullRequests.GetRaw(ctx, owner, repository, pullRequestID, github.RawOptions{Type: github.Diff})
+		return ghResponse, err
+	})
+	return diff, err
+}
+
+func mapGitHubCommitFileToPullRequestFile(commitFile *github.CommitFile) PullRequestFile {
+	file := PullRequestFile{Path: commitFile.GetFilename()}
+	switch commitFile.GetStatus() {
+	case "added", "copied":
+		file.ChangeType = FileAdded
+	case "removed":
+		file.ChangeType = FileDeleted
+	case "renamed":
+		file.ChangeType = FileRenamed
+		file.PreviousPath = commitFile.GetPreviousFilename()
+	default:
+		file.ChangeType = FileModified
+	}
+	return file
+}
+
 // Extract code reviewers from environment
 func extractGitHubEnvironmentReviewers(environment *github.Environment) ([]string, error) {
 	var reviewers []string
@@ -1064,20 +3355,47 @@ func createGitHubHook(token, payloadURL string, webhookEvents ...vcsutils.Webhoo
 	}
 }
 
+func mapGitHubHookToWebhookInfo(hook *github.Hook) WebhookInfo {
+	payloadURL, _ := hook.Config["url"].(string)
+	return WebhookInfo{
+		ID:         strconv.FormatInt(hook.GetID(), 10),
+		PayloadURL: payloadURL,
+		Events:     getSubscribedWebhookEvents(hook.Events, getGitHubWebhookEvents),
+		Active:     hook.GetActive(),
+	}
+}
+
 // Get varargs of webhook events and return a slice of GitHub webhook events
 func getGitHubWebhookEvents(webhookEvents ...vcsutils.WebhookEvent) []string {
 	events := datastructures.MakeSet[string]()
 	for _, event := range webhookEvents {
 		switch event {
-		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected:
+		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.PrClosed, vcsutils.PrReopened:
 			events.Add("pull_request")
-		case vcsutils.Push, vcsutils.TagPushed, vcsutils.TagRemoved:
+		case vcsutils.PrCommentCreated, vcsutils.PrCommentEdited:
+			events.Add("issue_comment")
+			events.Add("pull_request_review_comment")
+		case vcsutils.IssueCommentCreated:
+			events.Add("issue_comment")
+		case vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved:
 			events.Add("push")
+		case vcsutils.ReleaseCreated, vcsutils.ReleasePublished:
+			events.Add("release")
 		}
 	}
 	return events.ToSlice()
 }
 
+func mapGitHubRepositoryToRepositoryInfo(repo *github.Repository) RepositoryInfo {
+	return RepositoryInfo{
+		RepositoryVisibility: getGitHubRepositoryVisibility(repo),
+		CloneInfo:            CloneInfo{HTTP: repo.GetCloneURL(), SSH: repo.GetSSHURL()},
+		DefaultBranch:        repo.GetDefaultBranch(),
+		Archived:             repo.GetArchived(),
+		Description:          repo.GetDescription(),
+	}
+}
+
 func getGitHubRepositoryVisibility(repo *github.Repository) RepositoryVisibility {
 	switch *repo.Visibility {
 	case "public":
@@ -1124,9 +3442,13 @@ func mapGitHubCommitToCommitInfo(commit *github.RepositoryCommit) CommitInfo {
 func mapGitHubIssuesCommentToCommentInfoList(commentsList []*github.IssueComment) (res []CommentInfo, err error) {
 	for _, comment := range commentsList {
 		res = append(res, CommentInfo{
-			ID:      comment.GetID(),
-			Content: comment.GetBody(),
-			Created: comment.GetCreatedAt().Time,
+			ID:             comment.GetID(),
+			NativeID:       strconv.FormatInt(comment.GetID(), 10),
+			Content:        comment.GetBody(),
+			Author:         comment.GetUser().GetName(),
+			AuthorUsername: comment.GetUser().GetLogin(),
+			Created:        comment.GetCreatedAt().Time,
+			Updated:        comment.GetUpdatedAt().Time,
 		})
 	}
 	return
@@ -1184,3 +3506,11 @@ func isRateLimitAbuseError(requestError error) bool {
 	var rateLimitError *github.RateLimitError
 	return errors.As(requestError, &abuseRateLimitError) || errors.As(requestError, &rateLimitError)
 }
+
+func mapGitHubPullRequestState(ghPullRequest *github.PullRequest) vcsutils.PullRequestState {
+	// Pull requests listing doesn't report the merged field, hence the merge time is checked as well
+	if ghPullRequest.GetState() == "closed" && (ghPullRequest.GetMerged() || ghPullRequest.MergedAt != nil) {
+		return vcsutils.Merged
+	}
+	return vcsutils.PullRequestState(ghPullRequest.GetState())
+}
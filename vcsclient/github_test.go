@@ -3,6 +3,8 @@ package vcsclient
 import (
 	"bytes"
 	"context"
+	cryptorand "crypto/rand"
+	stdbase64 "encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +12,7 @@ import (
 	"math"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -21,6 +24,7 @@ import (
 	"github.com/google/go-github/v56/github"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/nacl/box"
 )
 
 func TestGitHubClient_Connection(t *testing.T) {
@@ -57,6 +61,39 @@ func TestGitHubClient_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestGitHubClient_TestConnectionWithScopes(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "/user", createOAuthScopesGitHubHandler("repo, admin:org"))
+	defer cleanUp()
+	assert.NoError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope, RepositoryWriteScope, WebhookAdminScope))
+
+	client, cleanUp = createServerAndClient(t, vcsutils.GitHub, false, nil, "/user", createOAuthScopesGitHubHandler("public_repo"))
+	defer cleanUp()
+	assert.NoError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope, RepositoryWriteScope))
+	assert.EqualError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope, WebhookAdminScope), "the token is missing the following scopes: webhook admin")
+
+	// Fine-grained tokens don't return the X-OAuth-Scopes header
+	client, cleanUp = createServerAndClient(t, vcsutils.GitHub, false, github.User{}, "/user", createGitHubHandler)
+	defer cleanUp()
+	assert.NoError(t, client.TestConnectionWithScopes(ctx, WebhookAdminScope))
+
+	assert.Error(t, createBadGitHubClient(t).TestConnectionWithScopes(ctx))
+}
+
+func TestGitHubClient_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	response := github.User{Login: github.String("frogger"), Name: github.String("Frog Ger"), Email: github.String("frogger@jfrog.com")}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/user", createGitHubHandler)
+	defer cleanUp()
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: "frogger", DisplayName: "Frog Ger", Email: "frogger@jfrog.com"}, user)
+
+	_, err = createBadGitHubClient(t).GetAuthenticatedUser(ctx)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	expectedRepo1 := github.Repository{Name: &repo1, Owner: &github.User{Login: &username}}
@@ -72,6 +109,20 @@ func TestGitHubClient_ListRepositories(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	expectedRepo := github.Repository{Name: &repo2, Owner: &github.User{Login: &username}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []github.Repository{expectedRepo}, "/user/repos?page=2&per_page=50", createGitHubHandler)
+	defer cleanUp()
+
+	actualRepositories, err := client.ListRepositoriesWithOptions(ctx, ListOptions{Page: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{username: {repo2}}, actualRepositories)
+
+	_, err = createBadGitHubClient(t).ListRepositoriesWithOptions(ctx, ListOptions{})
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListRepositoriesWithPagination(t *testing.T) {
 	ctx := context.Background()
 	const repo = "repo"
@@ -108,7 +159,7 @@ func TestGitHubClient_ListRepositoriesWithPagination(t *testing.T) {
 
 func TestGitHubClient_ListBranches(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []github.Branch{{Name: &branch1}, {Name: &branch2}}, fmt.Sprintf("/repos/jfrog/%s/branches", repo1), createGitHubHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []github.Branch{{Name: &branch1}, {Name: &branch2}}, fmt.Sprintf("/repos/jfrog/%s/branches?page=1&per_page=100", repo1), createGitHubHandler)
 	defer cleanUp()
 
 	actualBranches, err := client.ListBranches(ctx, owner, repo1)
@@ -119,6 +170,257 @@ func TestGitHubClient_ListBranches(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListBranchesAllPages(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/branches?page=1&per_page=100":
+			w.Header().Set("Link", `<https://api.github.com/repos/jfrog/repo-1/branches?page=2&per_page=100>; rel="next"`)
+			_, err := w.Write([]byte(`[{"name": "branch-1"}]`))
+			assert.NoError(t, err)
+		case "/repos/jfrog/repo-1/branches?page=2&per_page=100":
+			_, err := w.Write([]byte(`[{"name": "branch-2"}]`))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	actualBranches, err := buildClient(t, vcsutils.GitHub, false, server).ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch1, branch2}, actualBranches)
+}
+
+func TestGitHubClient_ListBranchesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []github.Branch{{Name: &branch2}}, "/repos/jfrog/repo-1/branches?page=2&per_page=1", createGitHubHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch2}, actualBranches)
+
+	_, err = createBadGitHubClient(t).ListBranchesWithOptions(ctx, owner, repo1, ListOptions{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.Reference{Ref: github.String("refs/heads/new-branch")}, "/repos/jfrog/repo-1/git/refs", createCreateBranchGitHubHandler)
+	defer cleanUp()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "new-branch")
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).CreateBranch(ctx, owner, repo1, branch1, "new-branch")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "/repos/jfrog/repo-1/git/refs/heads/branch-1", createGitHubHandler)
+	defer cleanUp()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteBranch(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "repository_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1", createGitHubHandler)
+	defer cleanUp()
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "master", defaultBranch)
+
+	_, err = createBadGitHubClient(t).GetDefaultBranch(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.Repository{}, "/repos/jfrog/repo-1",
+		http.StatusOK, []byte(`{"default_branch":"develop"}`+"\n"), http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetDefaultBranch(ctx, owner, repo1, "develop")
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).SetDefaultBranch(ctx, owner, repo1, "develop")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{
+		"required_pull_request_reviews": {"required_approving_review_count": 2},
+		"required_status_checks": {"strict": false, "checks": [{"context": "frogbot"}]}
+	}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/branches/%s/protection", repo1, branch1), createGitHubHandler)
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{RequiredApprovals: 2, RequiredStatusChecks: []string{"frogbot"}, RestrictPushes: true}, protection)
+
+	unprotectedClient, unprotectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, []byte(`{"message": "Branch not protected"}`),
+		fmt.Sprintf("/repos/jfrog/%s/branches/%s/protection", repo1, branch1), http.StatusNotFound, createGitHubHandler)
+	defer unprotectedCleanUp()
+	protection, err = unprotectedClient.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{}, protection)
+
+	_, err = createBadGitHubClient(t).GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"required_status_checks":{"strict":false,"checks":[{"context":"frogbot"}]},"required_pull_request_reviews":{"dismiss_stale_reviews":false,"require_code_owner_reviews":false,"required_approving_review_count":1},"enforce_admins":false,"restrictions":null}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.Protection{},
+		fmt.Sprintf("/repos/jfrog/%s/branches/%s/protection", repo1, branch1), http.StatusOK, expectedBody, http.MethodPut, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredApprovals: 1, RequiredStatusChecks: []string{"frogbot"}})
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RestrictPushes: true})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListTags(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false,
+		[]github.RepositoryTag{{Name: github.String("v1.0.0"), Commit: &github.Commit{SHA: github.String("86d6919952702f9ab03bc95b45687f145a663de0")}}},
+		"/repos/jfrog/repo-1/tags?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitSha: "86d6919952702f9ab03bc95b45687f145a663de0"}}, tags)
+
+	_, err = createBadGitHubClient(t).ListTags(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	t.Run("lightweight", func(t *testing.T) {
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createCreateTagGitHubHandler)
+		defer cleanUp()
+		assert.NoError(t, client.CreateTag(ctx, owner, repo1, "v1.0.0", branch1, ""))
+	})
+
+	t.Run("annotated", func(t *testing.T) {
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createCreateTagGitHubHandler)
+		defer cleanUp()
+		assert.NoError(t, client.CreateTag(ctx, owner, repo1, "v1.0.0", branch1, "Release 1.0.0"))
+	})
+
+	err := createBadGitHubClient(t).CreateTag(ctx, owner, repo1, "v1.0.0", branch1, "")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetTagInfo(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_single_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/commits/tags/v1.0.0", createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.GetTagInfo(ctx, owner, repo1, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", result.Hash)
+	assert.Equal(t, "Fix all the bugs", result.Message)
+
+	_, err = createBadGitHubClient(t).GetTagInfo(ctx, owner, repo1, "v1.0.0")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	response := github.RepositoryRelease{
+		ID:        github.Int64(1),
+		TagName:   github.String("v1.0.0"),
+		Name:      github.String("Release 1.0.0"),
+		Body:      github.String("Release notes"),
+		HTMLURL:   github.String("https://github.com/jfrog/repo-1/releases/tag/v1.0.0"),
+		CreatedAt: &github.Timestamp{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/releases", http.StatusCreated,
+		[]byte(`{"tag_name":"v1.0.0","name":"Release 1.0.0","body":"Release notes"}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	release, err := client.CreateRelease(ctx, owner, repo1, "v1.0.0", "Release 1.0.0", "Release notes")
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseInfo{
+		ID:        "1",
+		Name:      "Release 1.0.0",
+		TagName:   "v1.0.0",
+		Body:      "Release notes",
+		URL:       "https://github.com/jfrog/repo-1/releases/tag/v1.0.0",
+		CreatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, release)
+
+	_, err = createBadGitHubClient(t).CreateRelease(ctx, owner, repo1, "v1.0.0", "Release 1.0.0", "Release notes")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListReleases(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false,
+		[]github.RepositoryRelease{{ID: github.Int64(2), TagName: github.String("v1.0.1")}, {ID: github.Int64(1), TagName: github.String("v1.0.0")}},
+		"/repos/jfrog/repo-1/releases?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	releases, err := client.ListReleases(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReleaseInfo{{ID: "2", TagName: "v1.0.1"}, {ID: "1", TagName: "v1.0.0"}}, releases)
+
+	_, err = createBadGitHubClient(t).ListReleases(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetLatestRelease(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false,
+		github.RepositoryRelease{ID: github.Int64(2), TagName: github.String("v1.0.1"), Body: github.String("Release notes")},
+		"/repos/jfrog/repo-1/releases/latest", createGitHubHandler)
+	defer cleanUp()
+
+	release, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseInfo{ID: "2", TagName: "v1.0.1", Body: "Release notes"}, release)
+
+	_, err = createBadGitHubClient(t).GetLatestRelease(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createUploadReleaseAssetGitHubHandler)
+	defer cleanUp()
+
+	downloadURL, err := client.UploadReleaseAsset(ctx, owner, repo1, "1", "app 1.0.0.zip", strings.NewReader("asset content"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/jfrog/repo-1/releases/download/v1.0.0/app.1.0.0.zip", downloadURL)
+
+	_, err = client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", "app.zip", strings.NewReader("asset content"))
+	assert.ErrorContains(t, err, "invalid GitHub release ID v1.0.0")
+
+	_, err = createBadGitHubClient(t).UploadReleaseAsset(ctx, owner, repo1, "1", "app.zip", strings.NewReader("asset content"))
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int63()
@@ -134,6 +436,47 @@ func TestGitHubClient_CreateWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_CreateOrUpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	existingID, createdID := rand.Int63(), rand.Int63()
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		var response interface{}
+		switch r.Method + " " + r.RequestURI {
+		case fmt.Sprintf("GET /repos/jfrog/%s/hooks?page=1&per_page=100", repo1):
+			response = []*github.Hook{{ID: &existingID, Config: map[string]interface{}{"url": "https://jfrog.com/existing"}}}
+		case fmt.Sprintf("PATCH /repos/jfrog/%s/hooks/%d", repo1, existingID):
+			response = github.Hook{ID: &existingID}
+		case fmt.Sprintf("POST /repos/jfrog/%s/hooks", repo1):
+			response = github.Hook{ID: &createdID}
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	// An existing webhook with the payload URL is updated
+	actualID, token, err := client.CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com/existing", vcsutils.Push)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, strconv.FormatInt(existingID, 10), actualID)
+	assert.Equal(t, fmt.Sprintf("PATCH /repos/jfrog/%s/hooks/%d", repo1, existingID), requests[len(requests)-1])
+
+	// A new webhook is created otherwise
+	actualID, token, err = client.CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com/new", vcsutils.Push)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, strconv.FormatInt(createdID, 10), actualID)
+	assert.Equal(t, fmt.Sprintf("POST /repos/jfrog/%s/hooks", repo1), requests[len(requests)-1])
+
+	_, _, err = createBadGitHubClient(t).CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com", vcsutils.Push)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_UpdateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int63()
@@ -162,6 +505,80 @@ func TestGitHubClient_DeleteWebhook(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_RotateWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int63()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.HookConfig{}, fmt.Sprintf("/repos/jfrog/%s/hooks/%s/config", repo1, strconv.FormatInt(id, 10)), createGitHubHandler)
+	defer cleanUp()
+
+	newToken, err := client.RotateWebhookSecret(ctx, owner, repo1, strconv.FormatInt(id, 10))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, newToken)
+
+	_, err = client.RotateWebhookSecret(ctx, owner, repo1, "invalid-id")
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).RotateWebhookSecret(ctx, owner, repo1, strconv.FormatInt(id, 10))
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int63()
+	response := []*github.Hook{{
+		ID:     &id,
+		Active: github.Bool(true),
+		Events: []string{"pull_request", "push"},
+		Config: map[string]interface{}{"url": "https://httpbin.org/anything", "content_type": "json"},
+	}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, fmt.Sprintf("/repos/jfrog/%s/hooks?page=1&per_page=100", repo1), createGitHubHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []WebhookInfo{{
+		ID:         strconv.FormatInt(id, 10),
+		PayloadURL: "https://httpbin.org/anything",
+		Events:     []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.PrClosed, vcsutils.PrReopened, vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved},
+		Active:     true,
+	}}, webhooks)
+
+	_, err = createBadGitHubClient(t).ListWebhooks(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetWebhook(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int63()
+	response := github.Hook{
+		ID:     &id,
+		Active: github.Bool(false),
+		Events: []string{"push"},
+		Config: map[string]interface{}{"url": "https://httpbin.org/anything"},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, fmt.Sprintf("/repos/jfrog/%s/hooks/%d", repo1, id), createGitHubHandler)
+	defer cleanUp()
+
+	webhook, err := client.GetWebhook(ctx, owner, repo1, strconv.FormatInt(id, 10))
+	assert.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(id, 10), webhook.ID)
+	assert.Equal(t, "https://httpbin.org/anything", webhook.PayloadURL)
+	assert.Equal(t, []vcsutils.WebhookEvent{vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved}, webhook.Events)
+	assert.False(t, webhook.Active)
+
+	_, err = client.GetWebhook(ctx, owner, repo1, "invalid-id")
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).GetWebhook(ctx, owner, repo1, strconv.FormatInt(id, 10))
+	assert.Error(t, err)
+}
+
+func TestGetGitHubWebhookEvents(t *testing.T) {
+	assert.ElementsMatch(t, []string{"push", "release"},
+		getGitHubWebhookEvents(vcsutils.Push, vcsutils.ReleaseCreated, vcsutils.ReleasePublished))
+	assert.Empty(t, getGitHubWebhookEvents(vcsutils.Ping))
+}
+
 func TestGitHubClient_CreateCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "39e5418"
@@ -236,77 +653,219 @@ func TestGitHubClient_DownloadFileFromRepository(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_CreatePullRequest(t *testing.T) {
+func TestGitHubClient_ListDirectoryContents(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{}, "/repos/jfrog/repo-1/pulls", createGitHubHandler)
+	response := []*github.RepositoryContent{
+		{Name: github.String("go.mod"), Path: github.String("src/go.mod"), Type: github.String("file")},
+		{Name: github.String("pkg"), Path: github.String("src/pkg"), Type: github.String("dir")},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/contents/src?ref=master", createGitHubHandler)
 	defer cleanUp()
 
-	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	entries, err := client.ListDirectoryContents(ctx, owner, repo1, "master", "src")
 	assert.NoError(t, err)
+	assert.Equal(t, []DirectoryEntry{
+		{Name: "go.mod", Path: "src/go.mod", IsDir: false},
+		{Name: "pkg", Path: "src/pkg", IsDir: true},
+	}, entries)
 
-	err = createBadGitHubClient(t).CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	fileClient, fileCleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.RepositoryContent{Type: github.String("file")}, "/repos/jfrog/repo-1/contents/go.mod?ref=master", createGitHubHandler)
+	defer fileCleanUp()
+	_, err = fileClient.ListDirectoryContents(ctx, owner, repo1, "master", "go.mod")
+	assert.EqualError(t, err, "path 'go.mod' is not a directory")
+
+	_, err = createBadGitHubClient(t).ListDirectoryContents(ctx, owner, repo1, "master", "src")
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_UpdatePullRequest(t *testing.T) {
-	pullRequestId := 3
+func TestGitHubClient_CommitFiles(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{}, fmt.Sprintf("/repos/jfrog/repo-1/pulls/%v", pullRequestId), createGitHubHandler)
+	files := []FileToCommit{
+		{Path: "go.mod", Content: []byte("module example"), ChangeType: FileModified},
+		{Path: "go.sum", ChangeType: FileDeleted},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createCommitFilesGitHubHandler)
 	defer cleanUp()
 
-	err := client.UpdatePullRequest(ctx, owner, repo1, "title", "body", "", pullRequestId, vcsutils.Open)
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", files)
 	assert.NoError(t, err)
 
-	err = client.UpdatePullRequest(ctx, owner, repo1, "title", "body", "master", pullRequestId, vcsutils.Open)
-	assert.NoError(t, err)
+	err = client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", nil)
+	assert.EqualError(t, err, "validation failed: no files to commit")
 
-	err = createBadGitHubClient(t).UpdatePullRequest(ctx, owner, repo1, "title", "body", "master", pullRequestId, vcsutils.Open)
+	err = createBadGitHubClient(t).CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", files)
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_AddPullRequestComment(t *testing.T) {
+func TestGitHubClient_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.IssueComment{}, "/repos/jfrog/repo-1/issues/1/comments", createGitHubHandler)
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_info_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/pulls", createGitHubHandler)
 	defer cleanUp()
 
-	err := client.AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
+	pullRequestInfo, err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1347), pullRequestInfo.ID)
+	assert.Equal(t, "https://github.com/octocat/Hello-World/pull/1347", pullRequestInfo.URL)
 
-	err = createBadGitHubClient(t).AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
+	_, err = createBadGitHubClient(t).CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_AddPullRequestReviewComments(t *testing.T) {
+func TestGitHubClient_CreatePullRequestWithOptions(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequestReview{}, "/repos/jfrog/repo-1/pulls/1/comments", createAddPullRequestReviewCommentHandler)
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_info_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/pulls", http.StatusCreated,
+		[]byte(`{"title":"PR title","head":"jfrog:branch-1","base":"branch-2","body":"PR body","draft":true}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
 	defer cleanUp()
 
-	err := client.AddPullRequestReviewComments(ctx, owner, repo1, 1, []PullRequestComment{
-		{
-			CommentInfo: CommentInfo{Content: "test1"},
-			PullRequestDiff: PullRequestDiff{
-				NewFilePath:  "requirements.txt",
-				NewStartLine: 3,
-			},
-		},
-		{
-			CommentInfo: CommentInfo{Content: "test2"},
-			PullRequestDiff: PullRequestDiff{
-				NewFilePath:  "requirements.txt",
-				NewStartLine: 1,
-			},
-		},
-	}...)
+	pullRequestInfo, err := client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{Draft: true})
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1347), pullRequestInfo.ID)
 
-	err = createBadGitHubClient(t).AddPullRequestReviewComments(ctx, owner, repo1, 1, PullRequestComment{
-		CommentInfo: CommentInfo{Content: "test1"},
-		PullRequestDiff: PullRequestDiff{
-			NewFilePath:  "requirements.txt",
-			NewStartLine: 3,
-		},
-	})
-	assert.Error(t, err)
+	_, err = client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{WorkItems: []int{1}})
+	assert.EqualError(t, err, "linking work items to pull requests is not supported on GitHub")
+}
+
+func TestGitHubClient_CreatePullRequestWithOptionsSetAfterCreation(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_info_response.json"))
+	assert.NoError(t, err)
+	expectedRequests := map[string]string{
+		"POST /repos/jfrog/repo-1/pulls":                          `{"title":"PR title","head":"jfrog:branch-1","base":"branch-2","body":"PR body"}`,
+		"PATCH /repos/jfrog/repo-1/issues/1347":                   `{"labels":["dependencies"],"assignees":["frogger"],"milestone":2}`,
+		"POST /repos/jfrog/repo-1/pulls/1347/requested_reviewers": `{"reviewers":["reviewer1"]}`,
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "",
+		func(t *testing.T, _ string, response []byte, _ int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				request := r.Method + " " + r.RequestURI
+				expectedBody, ok := expectedRequests[request]
+				if !assert.True(t, ok, "unexpected request: "+request) {
+					return
+				}
+				delete(expectedRequests, request)
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, expectedBody, string(body))
+				_, err = w.Write(response)
+				assert.NoError(t, err)
+			}
+		})
+	defer cleanUp()
+
+	_, err = client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body",
+		CreatePullRequestOptions{Reviewers: []string{"reviewer1"}, Labels: []string{"dependencies"}, Assignees: []string{username}, Milestone: 2})
+	assert.NoError(t, err)
+	assert.Empty(t, expectedRequests)
+}
+
+func TestGitHubClient_UpdatePullRequest(t *testing.T) {
+	pullRequestId := 3
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequest{}, fmt.Sprintf("/repos/jfrog/repo-1/pulls/%v", pullRequestId), createGitHubHandler)
+	defer cleanUp()
+
+	err := client.UpdatePullRequest(ctx, owner, repo1, "title", "body", "", pullRequestId, vcsutils.Open)
+	assert.NoError(t, err)
+
+	err = client.UpdatePullRequest(ctx, owner, repo1, "title", "body", "master", pullRequestId, vcsutils.Open)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).UpdatePullRequest(ctx, owner, repo1, "title", "body", "master", pullRequestId, vcsutils.Open)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_MergePullRequest(t *testing.T) {
+	pullRequestId := 3
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequestMergeResult{}, fmt.Sprintf("/repos/jfrog/repo-1/pulls/%v/merge", pullRequestId), createGitHubHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, pullRequestId, vcsutils.MergeMethodSquash)
+	assert.NoError(t, err)
+
+	err = client.MergePullRequest(ctx, owner, repo1, pullRequestId, "fast-forward")
+	assert.Error(t, err)
+
+	err = createBadGitHubClient(t).MergePullRequest(ctx, owner, repo1, pullRequestId, vcsutils.MergeMethodMerge)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_SetPullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "/graphql",
+		func(t *testing.T, expectedURI string, _ []byte, _ int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if r.RequestURI == "/repos/jfrog/repo-1/pulls/3" {
+					_, err := w.Write([]byte(`{"number": 3, "node_id": "PR_kwDOA"}`))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, expectedURI, r.RequestURI)
+				var request struct {
+					Query     string                 `json:"query"`
+					Variables map[string]interface{} `json:"variables"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				assert.Contains(t, request.Query, "enablePullRequestAutoMerge")
+				assert.Equal(t, map[string]interface{}{"pullRequestId": "PR_kwDOA", "mergeMethod": "SQUASH"}, request.Variables)
+				_, err := w.Write([]byte(`{"data": {}}`))
+				assert.NoError(t, err)
+			}
+		})
+	defer cleanUp()
+
+	assert.NoError(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 3, vcsutils.MergeMethodSquash))
+	assert.EqualError(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 3, "fast-forward"), "unsupported merge method: 'fast-forward'")
+	assert.Error(t, createBadGitHubClient(t).SetPullRequestAutoMerge(ctx, owner, repo1, 3, vcsutils.MergeMethodMerge))
+}
+
+func TestGitHubClient_AddPullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.IssueComment{}, "/repos/jfrog/repo-1/issues/1/comments", createGitHubHandler)
+	defer cleanUp()
+
+	err := client.AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).AddPullRequestComment(ctx, owner, repo1, "Comment content", 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_AddPullRequestReviewComments(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.PullRequestReview{}, "/repos/jfrog/repo-1/pulls/1/comments", createAddPullRequestReviewCommentHandler)
+	defer cleanUp()
+
+	err := client.AddPullRequestReviewComments(ctx, owner, repo1, 1, []PullRequestComment{
+		{
+			CommentInfo: CommentInfo{Content: "test1"},
+			PullRequestDiff: PullRequestDiff{
+				NewFilePath:  "requirements.txt",
+				NewStartLine: 3,
+			},
+		},
+		{
+			CommentInfo: CommentInfo{Content: "test2"},
+			PullRequestDiff: PullRequestDiff{
+				NewFilePath:  "requirements.txt",
+				NewStartLine: 1,
+			},
+		},
+	}...)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).AddPullRequestReviewComments(ctx, owner, repo1, 1, PullRequestComment{
+		CommentInfo: CommentInfo{Content: "test1"},
+		PullRequestDiff: PullRequestDiff{
+			NewFilePath:  "requirements.txt",
+			NewStartLine: 3,
+		},
+	})
+	assert.Error(t, err)
 }
 
 func TestGitHubClient_ListPullRequestReviewComments(t *testing.T) {
@@ -314,15 +873,29 @@ func TestGitHubClient_ListPullRequestReviewComments(t *testing.T) {
 	id := int64(1)
 	body := "test"
 	created := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []*github.PullRequestComment{{ID: &id, Body: &body, CreatedAt: &github.Timestamp{Time: created}}}, "/repos/jfrog/repo-1/pulls/1/comments", createGitHubHandler)
+	path := "path/to/file.go"
+	diffHunk := "@@ -1,3 +1,4 @@\n package main\n+import \"fmt\""
+	outdatedID := int64(2)
+	comments := []*github.PullRequestComment{
+		{ID: &id, Body: &body, CreatedAt: &github.Timestamp{Time: created}, Path: &path, Line: github.Int(2), DiffHunk: &diffHunk, User: &github.User{Login: github.String("frogger")}},
+		{ID: &outdatedID, InReplyTo: &id, Body: &body, CreatedAt: &github.Timestamp{Time: created}, Path: &path, OriginalLine: github.Int(5)},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, comments, "/repos/jfrog/repo-1/pulls/1/comments", createGitHubHandler)
 	defer cleanUp()
 
 	commentInfo, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
 	assert.NoError(t, err)
-	assert.Len(t, commentInfo, 1)
+	assert.Len(t, commentInfo, 2)
 	assert.Equal(t, id, commentInfo[0].ID)
 	assert.Equal(t, body, commentInfo[0].Content)
 	assert.Equal(t, created, commentInfo[0].Created)
+	assert.Equal(t, path, commentInfo[0].FilePath)
+	assert.Equal(t, 2, commentInfo[0].Line)
+	assert.Equal(t, diffHunk, commentInfo[0].DiffHunk)
+	assert.Equal(t, username, commentInfo[0].AuthorUsername)
+	assert.Empty(t, commentInfo[0].ParentID)
+	assert.Equal(t, 5, commentInfo[1].Line)
+	assert.Equal(t, "1", commentInfo[1].ParentID)
 
 	commentInfo, err = createBadGitHubClient(t).ListPullRequestReviewComments(ctx, owner, repo1, 1)
 	assert.Empty(t, commentInfo)
@@ -335,7 +908,7 @@ func TestGitHubClient_GetLatestCommit(t *testing.T) {
 	assert.NoError(t, err)
 
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=50&sha=master", owner, repo1), createGitHubHandler)
+		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=1&sha=master", owner, repo1), createGitHubHandler)
 	defer cleanUp()
 
 	result, err := client.GetLatestCommit(ctx, owner, repo1, "master")
@@ -393,6 +966,34 @@ func TestGitHubClient_GetCommits(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/commits?author=octocat&page=2&path=README.md&per_page=10&sha=master&since=2011-04-14T00%%3A00%%3A00Z", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.ListCommits(ctx, owner, repo1, ListCommitsOptions{
+		Branch:  "master",
+		Path:    "README.md",
+		Author:  "octocat",
+		Since:   time.Date(2011, 4, 14, 0, 0, 0, 0, time.UTC),
+		PerPage: 10,
+		Page:    2,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", result[0].Hash)
+
+	_, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{})
+	assert.EqualError(t, err, "validation failed: required parameter 'branch' is missing")
+
+	_, err = createBadGitHubClient(t).ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: "master"})
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetLatestCommitNotFound(t *testing.T) {
 	ctx := context.Background()
 	response := []byte(`{
@@ -401,7 +1002,7 @@ func TestGitHubClient_GetLatestCommitNotFound(t *testing.T) {
 	}`)
 
 	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, response,
-		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=50&sha=master", owner, "unknown"), http.StatusNotFound,
+		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=1&sha=master", owner, "unknown"), http.StatusNotFound,
 		createGitHubHandler)
 	defer cleanUp()
 
@@ -420,7 +1021,7 @@ func TestGitHubClient_GetLatestCommitUnknownBranch(t *testing.T) {
 	}`)
 
 	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitHub, false, response,
-		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=50&sha=unknown", owner, repo1), http.StatusNotFound,
+		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=1&sha=unknown", owner, repo1), http.StatusNotFound,
 		createGitHubHandler)
 	defer cleanUp()
 
@@ -541,6 +1142,8 @@ func TestGitHubClient_GetRepositoryInfo(t *testing.T) {
 		RepositoryInfo{
 			RepositoryVisibility: Public,
 			CloneInfo:            CloneInfo{HTTP: "https://github.com/octocat/Hello-World.git", SSH: "git@github.com:octocat/Hello-World.git"},
+			DefaultBranch:        "master",
+			Description:          "This your first repo!",
 		},
 		info,
 	)
@@ -549,6 +1152,111 @@ func TestGitHubClient_GetRepositoryInfo(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	expectedRepositoryInfo := RepositoryInfo{
+		RepositoryVisibility: Private,
+		CloneInfo:            CloneInfo{HTTP: "https://github.com/jfrog/repo-1.git", SSH: "git@github.com:jfrog/repo-1.git"},
+	}
+	t.Run("organization", func(t *testing.T) {
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createCreateRepositoryGitHubHandler("/orgs/jfrog/repos"))
+		defer cleanUp()
+		info, err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Private: true, Description: "Frogs", DefaultBranch: "develop", InitReadme: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedRepositoryInfo, RepositoryInfo{RepositoryVisibility: info.RepositoryVisibility, CloneInfo: info.CloneInfo})
+		assert.Equal(t, "master", info.DefaultBranch)
+	})
+
+	t.Run("authenticated user", func(t *testing.T) {
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createCreateRepositoryGitHubHandler("/user/repos"))
+		defer cleanUp()
+		// The default branch of an empty repository isn't renamed
+		info, err := client.CreateRepository(ctx, "Frogger", repo1, CreateRepositoryOptions{Private: true, Description: "Frogs", DefaultBranch: "develop"})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedRepositoryInfo, RepositoryInfo{RepositoryVisibility: info.RepositoryVisibility, CloneInfo: info.CloneInfo})
+		assert.Equal(t, "main", info.DefaultBranch)
+	})
+
+	_, err := createBadGitHubClient(t).CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "/repos/jfrog/repo-1", createGitHubHandler)
+	defer cleanUp()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteRepository(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "repository_response.json"))
+	assert.NoError(t, err)
+	expectedRepositoryInfo := RepositoryInfo{
+		RepositoryVisibility: Public,
+		CloneInfo:            CloneInfo{HTTP: "https://github.com/octocat/Hello-World.git", SSH: "git@github.com:octocat/Hello-World.git"},
+		DefaultBranch:        "master",
+		Description:          "This your first repo!",
+	}
+
+	t.Run("authenticated user", func(t *testing.T) {
+		// A fork in progress is returned with a 202 status code
+		client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/forks",
+			http.StatusAccepted, []byte("{}\n"), http.MethodPost, createGitHubWithBodyHandler)
+		defer cleanUp()
+		info, err := client.ForkRepository(ctx, owner, repo1, "")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedRepositoryInfo, info)
+	})
+
+	t.Run("organization", func(t *testing.T) {
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "", createForkRepositoryGitHubHandler)
+		defer cleanUp()
+		info, err := client.ForkRepository(ctx, owner, repo1, "frogs")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedRepositoryInfo, info)
+	})
+
+	_, err = createBadGitHubClient(t).ForkRepository(ctx, owner, repo1, "")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListRepositoryCollaborators(t *testing.T) {
+	ctx := context.Background()
+	response := []github.User{
+		{Login: github.String("frogger"), Permissions: map[string]bool{"admin": false, "maintain": false, "push": true, "triage": true, "pull": true}},
+		{Login: github.String("toad"), Permissions: map[string]bool{"pull": true}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/collaborators?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: WritePermission}, {Username: "toad", Permission: ReadPermission}}, collaborators)
+
+	_, err = createBadGitHubClient(t).ListRepositoryCollaborators(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetRepositoryPermission(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.RepositoryPermissionLevel{Permission: github.String("admin")},
+		"/repos/jfrog/repo-1/collaborators/frogger/permission", createGitHubHandler)
+	defer cleanUp()
+
+	permission, err := client.GetRepositoryPermission(ctx, owner, repo1, "frogger")
+	assert.NoError(t, err)
+	assert.Equal(t, AdminPermission, permission)
+
+	_, err = createBadGitHubClient(t).GetRepositoryPermission(ctx, owner, repo1, "frogger")
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_CreateLabel(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, github.Label{}, fmt.Sprintf("/repos/jfrog/%s/labels", repo1), createGitHubHandler)
@@ -598,6 +1306,49 @@ func TestGitGubClient_GetLabelNotExisted(t *testing.T) {
 	assert.Nil(t, actualLabel)
 }
 
+func TestGitHubClient_ListRepositoryLabels(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false,
+		[]*github.Label{{Name: &labelName, Description: vcsutils.PointerOf("label-description"), Color: vcsutils.PointerOf("001122")}},
+		fmt.Sprintf("/repos/jfrog/%s/labels?page=1&per_page=100", repo1), createGitHubHandler)
+	defer cleanUp()
+
+	labels, err := client.ListRepositoryLabels(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []LabelInfo{{Name: labelName, Description: "label-description", Color: "001122"}}, labels)
+
+	_, err = createBadGitHubClient(t).ListRepositoryLabels(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UpdateLabel(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.Label{},
+		fmt.Sprintf("/repos/jfrog/%s/labels/%s", repo1, url.PathEscape(labelName)), http.StatusOK,
+		[]byte(`{"name":"new-label","color":"001122","description":"label-description"}`+"\n"), http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	labelInfo := LabelInfo{Name: "new-label", Description: "label-description", Color: "001122"}
+	err := client.UpdateLabel(ctx, owner, repo1, labelName, labelInfo)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).UpdateLabel(ctx, owner, repo1, labelName, labelInfo)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_DeleteLabel(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, nil,
+		fmt.Sprintf("/repos/jfrog/%s/labels/%s", repo1, url.PathEscape(labelName)), http.StatusOK, []byte{}, http.MethodDelete, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).DeleteLabel(ctx, owner, repo1, labelName)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []*github.Label{{Name: &labelName}}, "/repos/jfrog/repo-1/issues/1/labels", createGitHubHandler)
@@ -617,7 +1368,7 @@ func TestGitHubClient_ListOpenPullRequests(t *testing.T) {
 	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_requests_list_response.json"))
 	assert.NoError(t, err)
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-		fmt.Sprintf("/repos/%s/%s/pulls?state=open", owner, repo1), createGitHubHandler)
+		fmt.Sprintf("/repos/%s/%s/pulls?page=1&per_page=100&state=open", owner, repo1), createGitHubHandler)
 	defer cleanUp()
 
 	result, err := client.ListOpenPullRequests(ctx, owner, repo1)
@@ -625,10 +1376,15 @@ func TestGitHubClient_ListOpenPullRequests(t *testing.T) {
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     1347,
-		Source: BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
-		URL:    "https://github.com/octocat/Hello-World/pull/1347",
+		ID:        1347,
+		Title:     "Amazing new feature",
+		Author:    "octocat",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
+		URL:       "https://github.com/octocat/Hello-World/pull/1347",
+		CreatedAt: time.Date(2011, 1, 26, 19, 1, 12, 0, time.UTC),
+		UpdatedAt: time.Date(2011, 1, 26, 19, 1, 12, 0, time.UTC),
 	}, result[0])
 
 	_, err = createBadGitHubClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
@@ -640,17 +1396,76 @@ func TestGitHubClient_ListOpenPullRequests(t *testing.T) {
 	assert.Len(t, result, 1)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     1347,
-		Body:   "hello world",
-		Source: BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
-		URL:    "https://github.com/octocat/Hello-World/pull/1347",
+		ID:        1347,
+		Title:     "Amazing new feature",
+		Body:      "hello world",
+		Author:    "octocat",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: "Hello-World", Owner: owner},
+		URL:       "https://github.com/octocat/Hello-World/pull/1347",
+		CreatedAt: time.Date(2011, 1, 26, 19, 1, 12, 0, time.UTC),
+		UpdatedAt: time.Date(2011, 1, 26, 19, 1, 12, 0, time.UTC),
 	}, result[0])
 
 	_, err = createBadGitHubClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_ListOpenPullRequestsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/pulls?base=master&page=2&per_page=10&state=open", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	options := ListPullRequestsOptions{TargetBranch: "master", Author: "octocat", WithBody: true, Page: 2, PerPage: 10}
+	result, err := client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(1347), result[0].ID)
+	assert.Equal(t, "hello world", result[0].Body)
+
+	// Filtered by the source branch
+	options.SourceBranch = "other-topic"
+	result, err = client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, options)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = createBadGitHubClient(t).ListOpenPullRequestsWithOptions(ctx, owner, repo1, ListPullRequestsOptions{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListPullRequestsWithState(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/pulls?page=1&per_page=100&state=open", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Open)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(1347), result[0].ID)
+	assert.Equal(t, "hello world", result[0].Body)
+
+	// The open pull request of the response isn't merged
+	closedClient, closedCleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/pulls?page=1&per_page=100&state=closed", owner, repo1), createGitHubHandler)
+	defer closedCleanUp()
+	result, err = closedClient.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Merged)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = client.ListPullRequestsWithState(ctx, owner, repo1, "draft")
+	assert.EqualError(t, err, "unsupported pull request state: 'draft'")
+
+	_, err = createBadGitHubClient(t).ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Open)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_GetPullRequestByID(t *testing.T) {
 	ctx := context.Background()
 	pullRequestId := 1347
@@ -667,103 +1482,539 @@ func TestGitHubClient_GetPullRequestByID(t *testing.T) {
 	result, err := client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     int64(pullRequestId),
-		Source: BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: "Hello-World", Owner: forkedOwner},
-		URL:    "https://github.com/octocat/Hello-World/pull/1347",
+		ID:        int64(pullRequestId),
+		Title:     "Amazing new feature",
+		Body:      "Please pull these awesome changes in!",
+		Author:    "octocat",
+		State:     vcsutils.Open,
+		Mergeable: true,
+		Source:    BranchInfo{Name: "new-topic", Repository: "Hello-World", Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: "Hello-World", Owner: forkedOwner},
+		URL:       "https://github.com/octocat/Hello-World/pull/1347",
+		CreatedAt: time.Date(2011, 1, 26, 19, 1, 12, 0, time.UTC),
+		UpdatedAt: time.Date(2011, 1, 26, 19, 1, 12, 0, time.UTC),
 	}, result)
 
-	// Bad Labels
-	badLabels, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_info_response_bad_labels.json"))
+	// Bad Labels
+	badLabels, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_info_response_bad_labels.json"))
+	assert.NoError(t, err)
+	badLabelsClient, badLabelClientCleanUp := createServerAndClient(t, vcsutils.GitHub, false, badLabels,
+		fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repoName, pullRequestId), createGitHubHandler)
+	defer badLabelClientCleanUp()
+	_, err = badLabelsClient.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
+	assert.Error(t, err)
+
+	// Bad client
+	_, err = createBadGitHubClient(t).GetPullRequestByID(ctx, owner, repoName, pullRequestId)
+	assert.Error(t, err)
+
+	// Bad Response
+	badResponseClient, badResponseCleanUp := createServerAndClient(t, vcsutils.GitHub, false, "{",
+		fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repoName, pullRequestId), createGitHubHandler)
+	defer badResponseCleanUp()
+	_, err = badResponseClient.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
+	assert.Error(t, err)
+
+}
+
+func TestGitHubClient_ListPullRequestComments(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/issues/1/comments?page=1&per_page=100", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	expectedCreated, err := time.Parse(time.RFC3339, "2011-04-14T16:00:49Z")
+	assert.NoError(t, err)
+	assert.Equal(t, CommentInfo{
+		ID:             10,
+		NativeID:       "10",
+		Content:        "Great stuff!",
+		AuthorUsername: "octocat",
+		Created:        expectedCreated,
+		Updated:        expectedCreated,
+	}, result[0])
+
+	_, err = createBadGitHubClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListPullRequestCommentsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/issues/1/comments?page=2&per_page=10&since=2011-04-14T16%%3A00%%3A00Z", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	options := ListPullRequestCommentsOptions{UpdatedAfter: time.Date(2011, 4, 14, 16, 0, 0, 0, time.UTC), Page: 2, PerPage: 10}
+	result, err := client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, options)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	_, err = createBadGitHubClient(t).ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, options)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []*github.Label{{Name: &labelName}},
+		"/repos/jfrog/repo-1/issues/1/labels", http.StatusOK, []byte(`["`+labelName+`"]`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.LabelPullRequest(ctx, owner, repo1, 1, []string{labelName})
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).LabelPullRequest(ctx, owner, repo1, 1, []string{labelName})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UnlabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, &github.PullRequest{}, fmt.Sprintf("/repos/jfrog/repo-1/issues/1/labels/%s", url.PathEscape(labelName)), createGitHubHandler)
+	defer cleanUp()
+
+	err := client.UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateIssue(t *testing.T) {
+	ctx := context.Background()
+	response := github.Issue{Number: github.Int(1), Title: github.String("Vulnerable dependency"), Body: github.String("Upgrade it"),
+		HTMLURL: github.String("https://github.com/jfrog/repo-1/issues/1"), User: &github.User{Login: github.String("frogger")}, State: github.String("open")}
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/issues",
+		http.StatusCreated, []byte(`{"title":"Vulnerable dependency","body":"Upgrade it"}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade it")
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{ID: 1, Title: "Vulnerable dependency", Body: "Upgrade it", URL: "https://github.com/jfrog/repo-1/issues/1",
+		Author: "frogger", State: vcsutils.IssueOpen}, issue)
+
+	_, err = createBadGitHubClient(t).CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade it")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListIssues(t *testing.T) {
+	ctx := context.Background()
+	response := []github.Issue{
+		{Number: github.Int(1), Title: github.String("Vulnerable dependency"), User: &github.User{Login: github.String("frogger")},
+			State: github.String("open"), Labels: []*github.Label{{Name: github.String("security")}}},
+		{Number: github.Int(2), Title: github.String("Pull request"), State: github.String("open"), PullRequestLinks: &github.PullRequestLinks{}},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		"/repos/jfrog/repo-1/issues?creator=frogger&labels=security&page=2&per_page=10&state=open", createGitHubHandler)
+	defer cleanUp()
+
+	issues, err := client.ListIssues(ctx, owner, repo1, ListIssuesOptions{State: vcsutils.IssueOpen, Labels: []string{"security"}, Author: "frogger", Page: 2, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []IssueInfo{{ID: 1, Title: "Vulnerable dependency", Author: "frogger", State: vcsutils.IssueOpen, Labels: []string{"security"}}}, issues)
+
+	_, err = client.ListIssues(ctx, owner, repo1, ListIssuesOptions{State: "merged"})
+	assert.Error(t, err)
+
+	_, err = createBadGitHubClient(t).ListIssues(ctx, owner, repo1, ListIssuesOptions{})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_AddIssueComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.IssueComment{}, "/repos/jfrog/repo-1/issues/1/comments",
+		http.StatusCreated, []byte(`{"body":"Fixed"}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddIssueComment(ctx, owner, repo1, "Fixed", 1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).AddIssueComment(ctx, owner, repo1, "Fixed", 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CloseIssue(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.Issue{}, "/repos/jfrog/repo-1/issues/1",
+		http.StatusOK, []byte(`{"state":"closed"}`+"\n"), http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CloseIssue(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+
+	err = createBadGitHubClient(t).CloseIssue(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_UploadScanningAnalysis(t *testing.T) {
+	ctx := context.Background()
+	scan := "{\n    \"version\": \"2.1.0\",\n    \"$schema\": \"https://json.schemastore.org/sarif-2.1.0-rtm.5.json\",\n    \"runs\": [\n      {\n        \"tool\": {\n          \"driver\": {\n            \"informationUri\": \"https://jfrog.com/xray/\",\n            \"name\": \"Xray\",\n            \"rules\": [\n              {\n                \"id\": \"XRAY-174176\",\n                \"shortDescription\": null,\n                \"fullDescription\": {\n                  \"text\": \"json Package for Node.js lib/json.js _parseString() Function -d Argument Handling Local Code Execution Weakness\"\n                },\n                \"properties\": {\n                  \"security-severity\": \"8\"\n                }\n              }\n            ]\n          }\n        },\n        \"results\": [\n          {\n            \"ruleId\": \"XRAY-174176\",\n            \"ruleIndex\": 1,\n            \"message\": {\n              \"text\": \"json 9.0.6. Fixed in Versions: [11.0.0]\"\n            },\n            \"locations\": [\n              {\n                \"physicalLocation\": {\n                  \"artifactLocation\": {\n                    \"uri\": \"package.json\"\n                  }\n                }\n              }\n            ]\n          }\n        ]\n      }\n    ]\n  }"
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_list_response.json"))
+	assert.NoError(t, err)
+	expectedUploadSarifID := "b16b0368-01b9-11ed-90a3-cabff0b8ad31"
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=1&sha=master", owner, repo1), createGitHubSarifUploadHandler)
+	defer cleanUp()
+
+	sarifID, err := client.UploadCodeScanning(ctx, owner, repo1, "master", scan)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedUploadSarifID, sarifID)
+
+	_, err = createBadGitHubClient(t).UploadCodeScanning(ctx, owner, repo1, "master", scan)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CodeInsights(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.GitHub).Build()
+	assert.NoError(t, err)
+	err = client.CreateCodeInsightsReport(ctx, owner, repo1, "abcdef", CodeInsightsReport{Key: "xray-scan", Title: "Xray"})
+	assert.ErrorIs(t, err, errGitHubCodeInsightsNotSupported)
+	err = client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", nil)
+	assert.ErrorIs(t, err, errGitHubCodeInsightsNotSupported)
+}
+
+func TestGitHubClient_CreateCheckRun(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"name":"Xray","head_sha":"abcdef","status":"completed","conclusion":"failure",` +
+		`"output":{"title":"Xray found 1 issue","summary":"Vulnerable dependencies","annotations":[` +
+		`{"path":"package.json","start_line":12,"end_line":12,"annotation_level":"failure","message":"lodash 4.17.0 is vulnerable"}]}}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.CheckRun{ID: github.Int64(4)}, "/repos/jfrog/repo-1/check-runs",
+		http.StatusCreated, expectedBody, http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	checkRunID, err := client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{
+		Name:        "Xray",
+		HeadSHA:     "abcdef",
+		Status:      CheckRunCompleted,
+		Conclusion:  CheckRunFailure,
+		Title:       "Xray found 1 issue",
+		Summary:     "Vulnerable dependencies",
+		Annotations: []CheckRunAnnotation{{Path: "package.json", StartLine: 12, Level: CheckRunFailureLevel, Message: "lodash 4.17.0 is vulnerable"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), checkRunID)
+
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "Xray", HeadSHA: "abcdef", Title: "Xray found 1 issue"})
+	assert.Error(t, err)
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "Xray"})
+	assert.Error(t, err)
+	_, err = createBadGitHubClient(t).CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "Xray", HeadSHA: "abcdef"})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateCheckRunWithManyAnnotations(t *testing.T) {
+	ctx := context.Background()
+	var annotations []CheckRunAnnotation
+	for i := 1; i <= 60; i++ {
+		annotations = append(annotations, CheckRunAnnotation{Path: "main.go", StartLine: i, EndLine: i + 1, Level: CheckRunWarningLevel, Title: "Unused", Message: "Unused variable"})
+	}
+	var annotationsPerRequest []int
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createCheckRunGitHubHandler(&annotationsPerRequest))
+	defer cleanUp()
+
+	checkRunID, err := client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "Linter", HeadSHA: "abcdef", Title: "Linter", Summary: "60 warnings", Annotations: annotations})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), checkRunID)
+	assert.Equal(t, []int{50, 10}, annotationsPerRequest)
+}
+
+func TestGitHubClient_UpdateCheckRun(t *testing.T) {
+	ctx := context.Background()
+	expectedBody := []byte(`{"name":"Xray","details_url":"https://jfrog.com","status":"in_progress"}` + "\n")
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, github.CheckRun{ID: github.Int64(4)}, "/repos/jfrog/repo-1/check-runs/4",
+		http.StatusOK, expectedBody, http.MethodPatch, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{Name: "Xray", Status: CheckRunInProgress, DetailsURL: "https://jfrog.com"})
+	assert.NoError(t, err)
+
+	err = client.UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{})
+	assert.Error(t, err)
+	err = createBadGitHubClient(t).UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{Name: "Xray"})
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetRepositoryEnvironmentInfo(t *testing.T) {
+	ctx := context.Background()
+
+	response, err := os.ReadFile(filepath.Join("testdata", "github", "repository_environment_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, fmt.Sprintf("/repos/jfrog/repo-1/environments/%s", envName), createGitHubHandler)
+	defer cleanUp()
+
+	repositoryEnvironmentInfo, err := client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
+	assert.NoError(t, err)
+	assert.Equal(t, envName, repositoryEnvironmentInfo.Name)
+	assert.Equal(t, "https://api.github.com/repos/superfrog/test-repo/environments/frogbot", repositoryEnvironmentInfo.Url)
+	assert.Equal(t, []string{"superfrog"}, repositoryEnvironmentInfo.Reviewers)
+
+	_, err = createBadGitHubClient(t).GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListEnvironments(t *testing.T) {
+	ctx := context.Background()
+	response := github.EnvResponse{TotalCount: github.Int(1), Environments: []*github.Environment{{
+		Name: github.String(envName),
+		URL:  github.String("https://api.github.com/repos/jfrog/repo-1/environments/frogbot"),
+		ProtectionRules: []*github.ProtectionRule{{
+			Type:      github.String("required_reviewers"),
+			Reviewers: []*github.RequiredReviewer{{Type: github.String("User"), Reviewer: &github.User{Login: github.String("superfrog")}}},
+		}},
+	}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/environments?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	environments, err := client.ListEnvironments(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryEnvironmentInfo{{
+		Name:      envName,
+		Url:       "https://api.github.com/repos/jfrog/repo-1/environments/frogbot",
+		Reviewers: []string{"superfrog"},
+	}}, environments)
+
+	_, err = createBadGitHubClient(t).ListEnvironments(ctx, owner, repo1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CreateDeployment(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	response, err := json.Marshal(github.Deployment{
+		ID:          github.Int64(42),
+		Ref:         github.String(branch1),
+		SHA:         github.String("5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"),
+		Environment: github.String(envName),
+		Description: github.String("Release 1.0"),
+		CreatedAt:   &github.Timestamp{Time: createdAt},
+	})
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, response, "/repos/jfrog/repo-1/deployments", http.StatusCreated,
+		[]byte(`{"ref":"branch-1","auto_merge":false,"required_contexts":[],"environment":"frogbot","description":"Release 1.0"}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	deployment, err := client.CreateDeployment(ctx, owner, repo1, branch1, envName, "Release 1.0")
 	assert.NoError(t, err)
-	badLabelsClient, badLabelClientCleanUp := createServerAndClient(t, vcsutils.GitHub, false, badLabels,
-		fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repoName, pullRequestId), createGitHubHandler)
-	defer badLabelClientCleanUp()
-	_, err = badLabelsClient.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
+	assert.Equal(t, DeploymentInfo{
+		ID:          "42",
+		Ref:         branch1,
+		CommitSHA:   "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69",
+		Environment: envName,
+		Description: "Release 1.0",
+		CreatedAt:   createdAt,
+	}, deployment)
+
+	_, err = client.CreateDeployment(ctx, owner, repo1, branch1, "", "")
+	assert.ErrorContains(t, err, "required parameter 'environment' is missing")
+	_, err = createBadGitHubClient(t).CreateDeployment(ctx, owner, repo1, branch1, envName, "")
 	assert.Error(t, err)
+}
 
-	// Bad client
-	_, err = createBadGitHubClient(t).GetPullRequestByID(ctx, owner, repoName, pullRequestId)
-	assert.Error(t, err)
+func TestGitHubClient_SetDeploymentStatus(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte("{}"), "/repos/jfrog/repo-1/deployments/42/statuses", http.StatusCreated,
+		[]byte(`{"state":"success","description":"Deployed","environment_url":"https://frogbot.jfrog.io"}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
 
-	// Bad Response
-	badResponseClient, badResponseCleanUp := createServerAndClient(t, vcsutils.GitHub, false, "{",
-		fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repoName, pullRequestId), createGitHubHandler)
-	defer badResponseCleanUp()
-	_, err = badResponseClient.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
-	assert.Error(t, err)
+	assert.NoError(t, client.SetDeploymentStatus(ctx, owner, repo1, "42", DeploymentSuccess, "Deployed", "https://frogbot.jfrog.io"))
+	assert.ErrorContains(t, client.SetDeploymentStatus(ctx, owner, repo1, "deployment", DeploymentSuccess, "", ""), "invalid deployment ID 'deployment'")
+	assert.Error(t, createBadGitHubClient(t).SetDeploymentStatus(ctx, owner, repo1, "42", DeploymentFailure, "", ""))
+}
 
+func TestGetGitHubDeploymentState(t *testing.T) {
+	assert.Equal(t, "pending", getGitHubDeploymentState(DeploymentPending))
+	assert.Equal(t, "in_progress", getGitHubDeploymentState(DeploymentInProgress))
+	assert.Equal(t, "success", getGitHubDeploymentState(DeploymentSuccess))
+	assert.Equal(t, "failure", getGitHubDeploymentState(DeploymentFailure))
 }
 
-func TestGitHubClient_ListPullRequestComments(t *testing.T) {
+func TestGitHubClient_CreateOrUpdateRepositorySecret(t *testing.T) {
 	ctx := context.Background()
-	response, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_comments_list_response.json"))
+	publicKey, privateKey, err := box.GenerateKey(cryptorand.Reader)
 	assert.NoError(t, err)
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-		fmt.Sprintf("/repos/%s/%s/issues/1/comments", owner, repo1), createGitHubHandler)
-	defer cleanUp()
+	var encryptedSecret github.EncryptedSecret
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/actions/secrets/public-key":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.PublicKey{KeyID: github.String("key-1"), Key: github.String(stdbase64.StdEncoding.EncodeToString(publicKey[:]))}))
+		case "PUT /repos/jfrog/repo-1/actions/secrets/JF_TOKEN":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&encryptedSecret))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
 
-	result, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
-	assert.NoError(t, err)
-	assert.Len(t, result, 2)
-	expectedCreated, err := time.Parse(time.RFC3339, "2011-04-14T16:00:49Z")
+	assert.NoError(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"))
+	assert.Equal(t, "key-1", encryptedSecret.KeyID)
+	sealedValue, err := stdbase64.StdEncoding.DecodeString(encryptedSecret.EncryptedValue)
 	assert.NoError(t, err)
-	assert.Equal(t, CommentInfo{
-		ID:      10,
-		Content: "Great stuff!",
-		Created: expectedCreated,
-	}, result[0])
+	decryptedValue, ok := box.OpenAnonymous(nil, sealedValue, publicKey, privateKey)
+	assert.True(t, ok)
+	assert.Equal(t, "secret-value", string(decryptedValue))
 
-	_, err = createBadGitHubClient(t).ListPullRequestComments(ctx, owner, repo1, 1)
-	assert.Error(t, err)
+	assert.ErrorContains(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", ""), "required parameter 'value' is missing")
+	assert.Error(t, createBadGitHubClient(t).CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"))
 }
 
-func TestGitHubClient_UnlabelPullRequest(t *testing.T) {
+func TestEncryptGitHubSecret(t *testing.T) {
+	_, err := encryptGitHubSecret("JF_TOKEN", "secret-value", &github.PublicKey{Key: github.String("not base64")})
+	assert.ErrorContains(t, err, "failed to decode the public key of the secrets")
+	_, err = encryptGitHubSecret("JF_TOKEN", "secret-value", &github.PublicKey{Key: github.String(stdbase64.StdEncoding.EncodeToString([]byte("short")))})
+	assert.EqualError(t, err, "unexpected public key length of 5 bytes, expected 32 bytes")
+}
+
+func TestGitHubClient_ListRepositoryVariables(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, &github.PullRequest{}, fmt.Sprintf("/repos/jfrog/repo-1/issues/1/labels/%s", url.PathEscape(labelName)), createGitHubHandler)
-	defer cleanUp()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/repos/jfrog/repo-1/actions/variables?page=1&per_page=100":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.ActionsVariables{TotalCount: 1, Variables: []*github.ActionsVariable{{Name: "JF_URL", Value: "https://jfrog.io"}}}))
+		case "/repos/jfrog/repo-1/actions/secrets?page=1&per_page=100":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.Secrets{TotalCount: 1, Secrets: []*github.Secret{{Name: "JF_TOKEN"}}}))
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+	}))
+	defer server.Close()
 
-	err := client.UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
+	variables, err := buildClient(t, vcsutils.GitHub, false, server).ListRepositoryVariables(ctx, owner, repo1)
 	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryVariableInfo{{Name: "JF_URL", Value: "https://jfrog.io"}, {Name: "JF_TOKEN", IsSecret: true}}, variables)
 
-	err = createBadGitHubClient(t).UnlabelPullRequest(ctx, owner, repo1, labelName, 1)
+	_, err = createBadGitHubClient(t).ListRepositoryVariables(ctx, owner, repo1)
 	assert.Error(t, err)
 }
 
-func TestGitHubClient_UploadScanningAnalysis(t *testing.T) {
+func TestGitHubClient_OrganizationSecrets(t *testing.T) {
 	ctx := context.Background()
-	scan := "{\n    \"version\": \"2.1.0\",\n    \"$schema\": \"https://json.schemastore.org/sarif-2.1.0-rtm.5.json\",\n    \"runs\": [\n      {\n        \"tool\": {\n          \"driver\": {\n            \"informationUri\": \"https://jfrog.com/xray/\",\n            \"name\": \"Xray\",\n            \"rules\": [\n              {\n                \"id\": \"XRAY-174176\",\n                \"shortDescription\": null,\n                \"fullDescription\": {\n                  \"text\": \"json Package for Node.js lib/json.js _parseString() Function -d Argument Handling Local Code Execution Weakness\"\n                },\n                \"properties\": {\n                  \"security-severity\": \"8\"\n                }\n              }\n            ]\n          }\n        },\n        \"results\": [\n          {\n            \"ruleId\": \"XRAY-174176\",\n            \"ruleIndex\": 1,\n            \"message\": {\n              \"text\": \"json 9.0.6. Fixed in Versions: [11.0.0]\"\n            },\n            \"locations\": [\n              {\n                \"physicalLocation\": {\n                  \"artifactLocation\": {\n                    \"uri\": \"package.json\"\n                  }\n                }\n              }\n            ]\n          }\n        ]\n      }\n    ]\n  }"
-	response, err := os.ReadFile(filepath.Join("testdata", "github", "commit_list_response.json"))
+	publicKey, privateKey, err := box.GenerateKey(cryptorand.Reader)
 	assert.NoError(t, err)
-	expectedUploadSarifID := "b16b0368-01b9-11ed-90a3-cabff0b8ad31"
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-		fmt.Sprintf("/repos/%s/%s/commits?page=1&per_page=50&sha=master", owner, repo1), createGitHubSarifUploadHandler)
-	defer cleanUp()
+	updatedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var encryptedSecret github.EncryptedSecret
+	var deletedSecrets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.Repository{ID: github.Int64(101), Name: github.String(repo1)}))
+		case "GET /repos/jfrog/repo-2":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.Repository{ID: github.Int64(102), Name: github.String(repo2)}))
+		case "GET /orgs/jfrog/actions/secrets/public-key":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.PublicKey{KeyID: github.String("org-key"), Key: github.String(stdbase64.StdEncoding.EncodeToString(publicKey[:]))}))
+		case "PUT /orgs/jfrog/actions/secrets/JF_TOKEN":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&encryptedSecret))
+			w.WriteHeader(http.StatusCreated)
+		case "GET /orgs/jfrog/actions/secrets?page=1&per_page=100":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.Secrets{TotalCount: 2, Secrets: []*github.Secret{
+				{Name: "JF_TOKEN", Visibility: "selected", UpdatedAt: github.Timestamp{Time: updatedAt}},
+				{Name: "JF_URL", Visibility: "all"},
+			}}))
+		case "GET /orgs/jfrog/actions/secrets/JF_TOKEN/repositories?page=1&per_page=100":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.SelectedReposList{TotalCount: github.Int(2), Repositories: []*github.Repository{
+				{Name: github.String(repo1)}, {Name: github.String(repo2)},
+			}}))
+		case "DELETE /orgs/jfrog/actions/secrets/JF_TOKEN":
+			deletedSecrets = append(deletedSecrets, "JF_TOKEN")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	assert.NoError(t, client.CreateOrUpdateOrganizationSecret(ctx, owner, "JF_TOKEN", "secret-value", VisibleToSelectedRepositories, []string{repo1, repo2}))
+	assert.Equal(t, "org-key", encryptedSecret.KeyID)
+	assert.Equal(t, "selected", encryptedSecret.Visibility)
+	assert.Equal(t, github.SelectedRepoIDs{101, 102}, encryptedSecret.SelectedRepositoryIDs)
+	sealedValue, err := stdbase64.StdEncoding.DecodeString(encryptedSecret.EncryptedValue)
+	assert.NoError(t, err)
+	decryptedValue, ok := box.OpenAnonymous(nil, sealedValue, publicKey, privateKey)
+	assert.True(t, ok)
+	assert.Equal(t, "secret-value", string(decryptedValue))
 
-	sarifID, err := client.UploadCodeScanning(ctx, owner, repo1, "master", scan)
+	// The repositories are ignored unless the secret is visible to selected repositories
+	encryptedSecret = github.EncryptedSecret{}
+	assert.NoError(t, client.CreateOrUpdateOrganizationSecret(ctx, owner, "JF_TOKEN", "secret-value", VisibleToPrivateRepositories, []string{repo1}))
+	assert.Equal(t, "private", encryptedSecret.Visibility)
+	assert.Empty(t, encryptedSecret.SelectedRepositoryIDs)
+
+	secrets, err := client.ListOrganizationSecrets(ctx, owner)
 	assert.NoError(t, err)
-	assert.Equal(t, expectedUploadSarifID, sarifID)
+	assert.Equal(t, []SecretInfo{{Name: "JF_TOKEN", Visibility: VisibleToSelectedRepositories, LastUpdatedAt: updatedAt}, {Name: "JF_URL", Visibility: VisibleToAllRepositories}}, secrets)
 
-	_, err = createBadGitHubClient(t).UploadCodeScanning(ctx, owner, repo1, "master", scan)
+	repositories, err := client.ListOrganizationSecretRepositories(ctx, owner, "JF_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{repo1, repo2}, repositories)
+
+	assert.NoError(t, client.DeleteOrganizationSecret(ctx, owner, "JF_TOKEN"))
+	assert.Equal(t, []string{"JF_TOKEN"}, deletedSecrets)
+
+	assert.ErrorContains(t, client.CreateOrUpdateOrganizationSecret(ctx, owner, "", "secret-value", VisibleToAllRepositories, nil), "required parameter 'name' is missing")
+	badClient := createBadGitHubClient(t)
+	assert.Error(t, badClient.CreateOrUpdateOrganizationSecret(ctx, owner, "JF_TOKEN", "secret-value", VisibleToAllRepositories, nil))
+	_, err = badClient.ListOrganizationSecrets(ctx, owner)
 	assert.Error(t, err)
+	_, err = badClient.ListOrganizationSecretRepositories(ctx, owner, "JF_TOKEN")
+	assert.Error(t, err)
+	assert.Error(t, badClient.DeleteOrganizationSecret(ctx, owner, "JF_TOKEN"))
 }
 
-func TestGitHubClient_GetRepositoryEnvironmentInfo(t *testing.T) {
+func TestGitHubClient_EnvironmentSecrets(t *testing.T) {
 	ctx := context.Background()
+	publicKey, privateKey, err := box.GenerateKey(cryptorand.Reader)
+	assert.NoError(t, err)
+	var encryptedSecret github.EncryptedSecret
+	var deletedSecrets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.Repository{ID: github.Int64(101), Name: github.String(repo1)}))
+		case "GET /repositories/101/environments/frogbot/secrets/public-key":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.PublicKey{KeyID: github.String("env-key"), Key: github.String(stdbase64.StdEncoding.EncodeToString(publicKey[:]))}))
+		case "PUT /repositories/101/environments/frogbot/secrets/JF_TOKEN":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&encryptedSecret))
+			w.WriteHeader(http.StatusCreated)
+		case "GET /repositories/101/environments/frogbot/secrets?page=1&per_page=100":
+			assert.NoError(t, json.NewEncoder(w).Encode(github.Secrets{TotalCount: 1, Secrets: []*github.Secret{{Name: "JF_TOKEN"}}}))
+		case "DELETE /repositories/101/environments/frogbot/secrets/JF_TOKEN":
+			deletedSecrets = append(deletedSecrets, "JF_TOKEN")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
 
-	response, err := os.ReadFile(filepath.Join("testdata", "github", "repository_environment_response.json"))
+	assert.NoError(t, client.CreateOrUpdateEnvironmentSecret(ctx, owner, repo1, envName, "JF_TOKEN", "secret-value"))
+	assert.Equal(t, "env-key", encryptedSecret.KeyID)
+	sealedValue, err := stdbase64.StdEncoding.DecodeString(encryptedSecret.EncryptedValue)
 	assert.NoError(t, err)
-	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, fmt.Sprintf("/repos/jfrog/repo-1/environments/%s", envName), createGitHubHandler)
-	defer cleanUp()
+	decryptedValue, ok := box.OpenAnonymous(nil, sealedValue, publicKey, privateKey)
+	assert.True(t, ok)
+	assert.Equal(t, "secret-value", string(decryptedValue))
 
-	repositoryEnvironmentInfo, err := client.GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
+	secrets, err := client.ListEnvironmentSecrets(ctx, owner, repo1, envName)
 	assert.NoError(t, err)
-	assert.Equal(t, envName, repositoryEnvironmentInfo.Name)
-	assert.Equal(t, "https://api.github.com/repos/superfrog/test-repo/environments/frogbot", repositoryEnvironmentInfo.Url)
-	assert.Equal(t, []string{"superfrog"}, repositoryEnvironmentInfo.Reviewers)
+	assert.Equal(t, []SecretInfo{{Name: "JF_TOKEN"}}, secrets)
 
-	_, err = createBadGitHubClient(t).GetRepositoryEnvironmentInfo(ctx, owner, repo1, envName)
+	assert.NoError(t, client.DeleteEnvironmentSecret(ctx, owner, repo1, envName, "JF_TOKEN"))
+	assert.Equal(t, []string{"JF_TOKEN"}, deletedSecrets)
+
+	assert.ErrorContains(t, client.CreateOrUpdateEnvironmentSecret(ctx, owner, repo1, "", "JF_TOKEN", "secret-value"), "required parameter 'environment' is missing")
+	badClient := createBadGitHubClient(t)
+	assert.Error(t, badClient.CreateOrUpdateEnvironmentSecret(ctx, owner, repo1, envName, "JF_TOKEN", "secret-value"))
+	_, err = badClient.ListEnvironmentSecrets(ctx, owner, repo1, envName)
 	assert.Error(t, err)
+	assert.Error(t, badClient.DeleteEnvironmentSecret(ctx, owner, repo1, envName, "JF_TOKEN"))
 }
 
 func TestGitHubClient_ExtractGitHubEnvironmentReviewers(t *testing.T) {
@@ -853,11 +2104,102 @@ func TestGitHubClient_GetModifiedFiles(t *testing.T) {
 	})
 }
 
+func TestGitHubClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	commitFiles := []*github.CommitFile{
+		{Filename: github.String("README.md"), Status: github.String("modified")},
+		{Filename: github.String("new.go"), Status: github.String("added")},
+		{Filename: github.String("old.go"), Status: github.String("removed")},
+		{Filename: github.String("renamed.go"), PreviousFilename: github.String("original.go"), Status: github.String("renamed")},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, commitFiles, "/repos/jfrog/repo-1/pulls/1/files?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "new.go", ChangeType: FileAdded},
+		{Path: "old.go", ChangeType: FileDeleted},
+		{Path: "renamed.go", PreviousPath: "original.go", ChangeType: FileRenamed},
+	}, files)
+
+	_, err = client.ListPullRequestFiles(ctx, "", repo1, 1)
+	assert.EqualError(t, err, "validation failed: required parameter 'owner' is missing")
+
+	_, err = createBadGitHubClient(t).ListPullRequestFiles(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	expectedDiff := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n hello\n+world\n"
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, []byte(expectedDiff), "/repos/jfrog/repo-1/pulls/1", createGitHubHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDiff, diff)
+
+	_, err = client.GetPullRequestDiff(ctx, "", repo1, 1)
+	assert.EqualError(t, err, "validation failed: required parameter 'owner' is missing")
+
+	_, err = createBadGitHubClient(t).GetPullRequestDiff(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	comparison := github.CommitsComparison{
+		Commits: []*github.RepositoryCommit{{
+			SHA: github.String("6dcb09b5b57875f334f61aebed695e2e4193db5e"),
+			URL: github.String("https://api.github.com/repos/jfrog/repo-1/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e"),
+			Commit: &github.Commit{
+				Message:   github.String("Fix all the bugs"),
+				Author:    &github.CommitAuthor{Name: github.String("frogger"), Email: github.String("frogger@jfrog.com")},
+				Committer: &github.CommitAuthor{Name: github.String("frogger"), Date: &github.Timestamp{Time: time.Unix(1667812601, 0)}},
+			},
+			Parents: []*github.Commit{{SHA: github.String("7638417db6d59f3c431d3e1f261cc637155684cd")}},
+		}},
+		Files: []*github.CommitFile{
+			{Filename: github.String("README.md"), Status: github.String("modified")},
+			{Filename: github.String("renamed.go"), PreviousFilename: github.String("original.go"), Status: github.String("renamed")},
+		},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, comparison, "/repos/jfrog/repo-1/compare/main...feature?page=1&per_page=100", createGitHubHandler)
+	defer cleanUp()
+
+	result, err := client.CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.NoError(t, err)
+	assert.Equal(t, CommitsComparison{
+		Commits: []CommitInfo{{
+			Hash:          "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			AuthorName:    "frogger",
+			CommitterName: "frogger",
+			Url:           "https://api.github.com/repos/jfrog/repo-1/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			Timestamp:     1667812601,
+			Message:       "Fix all the bugs",
+			ParentHashes:  []string{"7638417db6d59f3c431d3e1f261cc637155684cd"},
+			AuthorEmail:   "frogger@jfrog.com",
+		}},
+		Files: []PullRequestFile{
+			{Path: "README.md", ChangeType: FileModified},
+			{Path: "renamed.go", PreviousPath: "original.go", ChangeType: FileRenamed},
+		},
+	}, result)
+
+	_, err = client.CompareCommits(ctx, owner, repo1, "", "feature")
+	assert.EqualError(t, err, "validation failed: required parameter 'base' is missing")
+
+	_, err = createBadGitHubClient(t).CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_TestGetCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
 	t.Run("Empty response", func(t *testing.T) {
-		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, fmt.Sprintf("/repos/jfrog/%s/commits/%s/status", repo1, ref), createGitHubHandler)
+		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, fmt.Sprintf("/repos/jfrog/%s/commits/%s/statuses?page=1&per_page=100", repo1, ref), createGitHubHandler)
 		defer cleanUp()
 		_, err := client.GetCommitStatuses(ctx, owner, repo1, ref)
 		assert.NoError(t, err)
@@ -866,22 +2208,34 @@ func TestGitHubClient_TestGetCommitStatus(t *testing.T) {
 		response, err := os.ReadFile(filepath.Join("testdata", "github", "commits_statuses.json"))
 		assert.NoError(t, err)
 		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-			fmt.Sprintf("/repos/jfrog/%s/commits/%s/status", repo1, ref),
+			fmt.Sprintf("/repos/jfrog/%s/commits/%s/statuses?page=1&per_page=100", repo1, ref),
 			createGitHubHandler)
 		defer cleanUp()
 		commitStatuses, err := client.GetCommitStatuses(ctx, owner, repo1, ref)
 		assert.NoError(t, err)
+		// The pending status of the jenkins context is replaced by its latest status
 		assert.Len(t, commitStatuses, 4)
-		assert.Equal(t, Pass, commitStatuses[0].State)
+		assert.Equal(t, CommitStatusInfo{
+			State:         Pass,
+			RawState:      "success",
+			Context:       "continuous-integration/jenkins",
+			Description:   "Build has completed successfully",
+			DetailsUrl:    "https://ci.example.com/1000/output",
+			Creator:       "octocat",
+			CreatedAt:     time.Date(2012, 7, 20, 1, 19, 13, 0, time.UTC),
+			LastUpdatedAt: time.Date(2012, 7, 20, 1, 20, 13, 0, time.UTC),
+		}, commitStatuses[0])
 		assert.Equal(t, InProgress, commitStatuses[1].State)
+		assert.Equal(t, "frogbot", commitStatuses[1].Creator)
 		assert.Equal(t, Fail, commitStatuses[2].State)
 		assert.Equal(t, Error, commitStatuses[3].State)
+		assert.Equal(t, "someNewState", commitStatuses[3].RawState)
 	})
 	t.Run("Bad response format", func(t *testing.T) {
 		response, err := os.ReadFile(filepath.Join("testdata", "github", "commits_statuses_bad_json.json"))
 		assert.NoError(t, err)
 		client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
-			fmt.Sprintf("/repos/jfrog/%s/commits/%s/status", repo1, ref),
+			fmt.Sprintf("/repos/jfrog/%s/commits/%s/statuses?page=1&per_page=100", repo1, ref),
 			createGitHubHandler)
 		defer cleanUp()
 		_, err = client.GetCommitStatuses(ctx, owner, repo1, ref)
@@ -896,6 +2250,109 @@ func TestGitHubClient_TestGetCommitStatus(t *testing.T) {
 	})
 }
 
+func TestGitHubClient_GetCombinedCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
+	testCases := []struct {
+		name           string
+		statusResponse string
+		checkRuns      string
+		expectedStatus CommitStatus
+	}{
+		{name: "No statuses", statusResponse: `{"state": "pending", "total_count": 0}`, checkRuns: `{"total_count": 0}`, expectedStatus: Pass},
+		{name: "Passed", statusResponse: `{"state": "success", "total_count": 1}`, checkRuns: `{"total_count": 1, "check_runs": [{"status": "completed", "conclusion": "skipped"}]}`, expectedStatus: Pass},
+		{name: "Check run in progress", statusResponse: `{"state": "success", "total_count": 1}`, checkRuns: `{"total_count": 1, "check_runs": [{"status": "in_progress"}]}`, expectedStatus: InProgress},
+		{name: "Failed status", statusResponse: `{"state": "failure", "total_count": 1}`, checkRuns: `{"total_count": 1, "check_runs": [{"status": "queued"}]}`, expectedStatus: Fail},
+		{name: "Failed check run", statusResponse: `{"state": "pending", "total_count": 1}`, checkRuns: `{"total_count": 1, "check_runs": [{"status": "completed", "conclusion": "timed_out"}]}`, expectedStatus: Fail},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createGetCombinedCommitStatusGitHubHandler(ref, tc.statusResponse, tc.checkRuns))
+			defer cleanUp()
+			status, err := client.GetCombinedCommitStatus(ctx, owner, repo1, ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+
+	_, err := createBadGitHubClient(t).GetCombinedCommitStatus(ctx, owner, repo1, ref)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_TriggerWorkflow(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, nil, fmt.Sprintf("/repos/jfrog/%s/actions/workflows/build.yml/dispatches", repo1),
+		http.StatusOK, []byte(`{"ref":"branch-1","inputs":{"environment":"staging"}}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.TriggerWorkflow(ctx, owner, repo1, "build.yml", branch1, map[string]string{"environment": "staging"}))
+	assert.ErrorContains(t, client.TriggerWorkflow(ctx, owner, repo1, "", branch1, nil), "required parameter 'workflowFile' is missing")
+
+	_, err := client.TriggerPipeline(ctx, owner, repo1, branch1, nil)
+	assert.ErrorIs(t, err, errGitHubPipelinesNotSupported)
+	assert.Error(t, createBadGitHubClient(t).TriggerWorkflow(ctx, owner, repo1, "build.yml", branch1, nil))
+}
+
+func TestGitHubClient_GetWorkflowRunStatus(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	response := github.WorkflowRuns{TotalCount: github.Int(1), WorkflowRuns: []*github.WorkflowRun{{
+		ID:         github.Int64(42),
+		HeadBranch: github.String(branch1),
+		HeadSHA:    github.String("5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"),
+		Status:     github.String("completed"),
+		Conclusion: github.String("failure"),
+		HTMLURL:    github.String("https://github.com/jfrog/repo-1/actions/runs/42"),
+		CreatedAt:  &github.Timestamp{Time: createdAt},
+	}}}
+	expected := PipelineInfo{
+		ID:        "42",
+		Status:    Fail,
+		RawStatus: "failure",
+		Ref:       branch1,
+		CommitSHA: "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69",
+		WebURL:    "https://github.com/jfrog/repo-1/actions/runs/42",
+		CreatedAt: createdAt,
+	}
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/actions/workflows/build.yml/runs?branch=%s&per_page=1", repo1, branch1), createGitHubHandler)
+	defer cleanUp()
+	workflowRun, err := client.GetWorkflowRunStatus(ctx, owner, repo1, "build.yml", branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, workflowRun)
+
+	client, cleanUp = createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/jfrog/%s/actions/runs?head_sha=%s&per_page=1", repo1, expected.CommitSHA), createGitHubHandler)
+	defer cleanUp()
+	workflowRun, err = client.GetLatestPipelineStatus(ctx, owner, repo1, expected.CommitSHA)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, workflowRun)
+
+	client, cleanUp = createServerAndClient(t, vcsutils.GitHub, false, github.WorkflowRuns{TotalCount: github.Int(0)},
+		fmt.Sprintf("/repos/jfrog/%s/actions/workflows/build.yml/runs?branch=%s&per_page=1", repo1, branch1), createGitHubHandler)
+	defer cleanUp()
+	_, err = client.GetWorkflowRunStatus(ctx, owner, repo1, "build.yml", branch1)
+	assert.EqualError(t, err, "no workflow run was found for ref 'branch-1'")
+
+	_, err = createBadGitHubClient(t).GetWorkflowRunStatus(ctx, owner, repo1, "build.yml", branch1)
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_GetPipelineStatus(t *testing.T) {
+	ctx := context.Background()
+	response := github.WorkflowRun{ID: github.Int64(42), HeadBranch: github.String(branch1), Status: github.String("in_progress")}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response, fmt.Sprintf("/repos/jfrog/%s/actions/runs/42", repo1), createGitHubHandler)
+	defer cleanUp()
+
+	workflowRun, err := client.GetPipelineStatus(ctx, owner, repo1, "42")
+	assert.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "42", Status: InProgress, RawStatus: "in_progress", Ref: branch1}, workflowRun)
+
+	_, err = createBadGitHubClient(t).GetPipelineStatus(ctx, owner, repo1, "42")
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_DeletePullRequestReviewComments(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "", createGitHubHandlerWithoutExpectedURI)
@@ -907,6 +2364,19 @@ func TestGitHubClient_DeletePullRequestReviewComments(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, fmt.Sprintf("/repos/%v/%v/issues/comments/1", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, "Updated content", 1, 1)
+	assert.NoError(t, err)
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 1)
+	assert.Error(t, err)
+	client = createBadGitHubClient(t)
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "Updated content", 1, 1)
+	assert.Error(t, err)
+}
+
 func TestGitHubClient_DeletePullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, fmt.Sprintf("/repos/%v/%v/issues/comments/1", owner, repo1), createGitHubHandler)
@@ -918,6 +2388,84 @@ func TestGitHubClient_DeletePullRequestComment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubClient_SetPullRequestThreadStatus(t *testing.T) {
+	ctx := context.Background()
+	var mutations []string
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, nil, "/graphql",
+		func(t *testing.T, expectedURI string, _ []byte, _ int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, expectedURI, r.RequestURI)
+				var request struct {
+					Query     string                 `json:"query"`
+					Variables map[string]interface{} `json:"variables"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				var response string
+				if strings.HasPrefix(request.Query, "mutation") {
+					assert.Equal(t, "PRRT_1", request.Variables["threadId"])
+					mutations = append(mutations, strings.Fields(request.Query)[3])
+					response = `{"data": {}}`
+				} else {
+					assert.Equal(t, float64(1), request.Variables["pullRequestID"])
+					response = `{"data": {"repository": {"pullRequest": {"reviewThreads": {
+						"nodes": [{"id": "PRRT_1", "comments": {"nodes": [{"databaseId": 10}, {"databaseId": 11}]}}],
+						"pageInfo": {"hasNextPage": false}}}}}}`
+				}
+				_, err := w.Write([]byte(response))
+				assert.NoError(t, err)
+			}
+		})
+	defer cleanUp()
+
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "11", true))
+	assert.NoError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "PRRT_1", false))
+	assert.Equal(t, []string{"resolveReviewThread(input:", "unresolveReviewThread(input:"}, mutations)
+	assert.EqualError(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "12", true), "no review thread of pull request 1 contains comment 12")
+}
+
+func TestGitHubClient_CreatePullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitHub, false, []byte("{}"),
+		fmt.Sprintf("/repos/%s/%s/pulls/1/reviews", owner, repo1), http.StatusOK,
+		[]byte(`{"body":"Looks good","event":"APPROVE"}`+"\n"), http.MethodPost, createGitHubWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictApprove, "Looks good")
+	assert.NoError(t, err)
+
+	err = client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictComment, "")
+	assert.Error(t, err)
+
+	err = client.CreatePullRequestReview(ctx, owner, repo1, 1, "unknown", "")
+	assert.EqualError(t, err, "unsupported review verdict: 'unknown'")
+
+	err = createBadGitHubClient(t).CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictApprove, "")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient_ListPullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[
+		{"id": 1, "user": {"login": "reviewer1"}, "body": "Looks good", "state": "APPROVED", "submitted_at": "2023-06-01T10:00:00Z"},
+		{"id": 2, "user": {"login": "reviewer2"}, "body": "Please fix", "state": "CHANGES_REQUESTED", "submitted_at": "2023-06-02T10:00:00Z"},
+		{"id": 3, "user": {"login": "reviewer3"}, "state": "DISMISSED", "submitted_at": "2023-06-03T10:00:00Z"}
+	]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitHub, false, response,
+		fmt.Sprintf("/repos/%s/%s/pulls/1/reviews?per_page=100", owner, repo1), createGitHubHandler)
+	defer cleanUp()
+
+	reviews, err := client.ListPullRequestReviews(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewInfo{
+		{Reviewer: "reviewer1", State: vcsutils.ReviewVerdictApprove, Body: "Looks good", Submitted: time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)},
+		{Reviewer: "reviewer2", State: vcsutils.ReviewVerdictRequestChanges, Body: "Please fix", Submitted: time.Date(2023, 6, 2, 10, 0, 0, 0, time.UTC)},
+	}, reviews)
+
+	_, err = createBadGitHubClient(t).ListPullRequestReviews(ctx, owner, repo1, 1)
+	assert.Error(t, err)
+}
+
 func createBadGitHubClient(t *testing.T) VcsClient {
 	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint("https://badendpoint").Build()
 	assert.NoError(t, err)
@@ -1009,6 +2557,18 @@ func createGitHubHandler(t *testing.T, expectedURI string, response []byte, expe
 	}
 }
 
+func createOAuthScopesGitHubHandler(oauthScopes string) createHandlerFunc {
+	return func(t *testing.T, expectedURI string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, expectedURI, r.RequestURI)
+			assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+			w.Header().Set("X-OAuth-Scopes", oauthScopes)
+			_, err := w.Write([]byte(`{"login": "frogger"}`))
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func createGitHubHandlerWithoutExpectedURI(t *testing.T, _ string, response []byte, expectedStatusCode int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
@@ -1039,6 +2599,175 @@ func createAddPullRequestReviewCommentHandler(t *testing.T, expectedURI string,
 	}
 }
 
+func createCreateBranchGitHubHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/repos/jfrog/repo-1/commits/"+branch1 {
+			_, err := w.Write([]byte("86d6919952702f9ab03bc95b45687f145a663de0"))
+			assert.NoError(t, err)
+			return
+		}
+		assert.Equal(t, expectedURI, r.RequestURI)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"ref":"refs/heads/new-branch","sha":"86d6919952702f9ab03bc95b45687f145a663de0"}`, string(body))
+		w.WriteHeader(expectedStatusCode)
+		_, err = w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func createCreateTagGitHubHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/commits/" + branch1:
+			response = "86d6919952702f9ab03bc95b45687f145a663de0"
+		case "POST /repos/jfrog/repo-1/git/tags":
+			assert.JSONEq(t, `{"tag":"v1.0.0","message":"Release 1.0.0","object":"86d6919952702f9ab03bc95b45687f145a663de0","type":"commit"}`, string(body))
+			response = `{"sha": "940bd336248efae0f9ee5bc7b2d5c985887b16ac"}`
+		case "POST /repos/jfrog/repo-1/git/refs":
+			// An annotated tag ref points to the tag object rather than to the commit
+			var ref map[string]string
+			assert.NoError(t, json.Unmarshal(body, &ref))
+			assert.Equal(t, "refs/tags/v1.0.0", ref["ref"])
+			assert.Contains(t, []string{"86d6919952702f9ab03bc95b45687f145a663de0", "940bd336248efae0f9ee5bc7b2d5c985887b16ac"}, ref["sha"])
+			response = `{"ref": "refs/tags/v1.0.0"}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createCreateRepositoryGitHubHandler(expectedCreateURI string) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var response string
+			switch r.Method + " " + r.RequestURI {
+			case "GET /user":
+				response = `{"login": "frogger"}`
+			case "POST " + expectedCreateURI:
+				var repository map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &repository))
+				assert.Equal(t, repo1, repository["name"])
+				assert.Equal(t, "Frogs", repository["description"])
+				assert.Equal(t, true, repository["private"])
+				initReadme := repository["auto_init"] == true
+				defaultBranch := "main"
+				if initReadme {
+					defaultBranch = "master"
+				}
+				response = `{"name": "repo-1", "visibility": "private", "default_branch": "` + defaultBranch + `",
+					"clone_url": "https://github.com/jfrog/repo-1.git", "ssh_url": "git@github.com:jfrog/repo-1.git"}`
+			case "POST /repos/jfrog/repo-1/branches/master/rename":
+				assert.JSONEq(t, `{"new_name": "develop"}`, string(body))
+				response = `{"name": "develop"}`
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+			_, err = w.Write([]byte(response))
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func createForkRepositoryGitHubHandler(t *testing.T, _ string, forkResponse []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var response []byte
+		switch r.Method + " " + r.RequestURI {
+		case "GET /user":
+			response = []byte(`{"login": "frogger"}`)
+		case "POST /repos/jfrog/repo-1/forks":
+			response = forkResponse
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"organization": "frogs"}`, string(body))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write(response)
+		assert.NoError(t, err)
+	}
+}
+
+func createUploadReleaseAssetGitHubHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/releases/1":
+			response = `{"id": 1, "upload_url": "http://` + r.Host + `/repos/jfrog/repo-1/releases/1/assets{?name,label}"}`
+		case "POST /repos/jfrog/repo-1/releases/1/assets?name=app+1.0.0.zip":
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "asset content", string(body))
+			response = `{"id": 2, "browser_download_url": "https://github.com/jfrog/repo-1/releases/download/v1.0.0/app.1.0.0.zip"}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createGetCombinedCommitStatusGitHubHandler(ref, statusResponse, checkRunsResponse string) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var response string
+			switch r.RequestURI {
+			case "/repos/jfrog/repo-1/commits/" + ref + "/status":
+				response = statusResponse
+			case "/repos/jfrog/repo-1/commits/" + ref + "/check-runs?page=1&per_page=100":
+				response = checkRunsResponse
+			default:
+				assert.Fail(t, "unexpected request", r.RequestURI)
+			}
+			_, err := w.Write([]byte(response))
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func createCommitFilesGitHubHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /repos/jfrog/repo-1/git/ref/heads/branch-1":
+			response = `{"ref": "refs/heads/branch-1", "object": {"sha": "parent-sha"}}`
+		case "GET /repos/jfrog/repo-1/git/commits/parent-sha":
+			response = `{"sha": "parent-sha", "tree": {"sha": "base-tree-sha"}}`
+		case "POST /repos/jfrog/repo-1/git/blobs":
+			assert.JSONEq(t, `{"content": "bW9kdWxlIGV4YW1wbGU=", "encoding": "base64"}`, string(body))
+			response = `{"sha": "blob-sha"}`
+		case "POST /repos/jfrog/repo-1/git/trees":
+			assert.JSONEq(t, `{"base_tree": "base-tree-sha", "tree": [
+				{"path": "go.mod", "mode": "100644", "type": "blob", "sha": "blob-sha"},
+				{"path": "go.sum", "mode": "100644", "type": "blob", "sha": null}
+			]}`, string(body))
+			response = `{"sha": "tree-sha"}`
+		case "POST /repos/jfrog/repo-1/git/commits":
+			assert.JSONEq(t, `{"message": "Update dependencies", "tree": "tree-sha", "parents": ["parent-sha"]}`, string(body))
+			response = `{"sha": "commit-sha"}`
+		case "PATCH /repos/jfrog/repo-1/git/refs/heads/branch-1":
+			assert.JSONEq(t, `{"sha": "commit-sha", "force": false}`, string(body))
+			response = `{"ref": "refs/heads/branch-1", "object": {"sha": "commit-sha"}}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
 func createDownloadRepositoryGitHubHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.RequestURI == "/repos/jfrog/Hello-World" {
@@ -1066,7 +2795,7 @@ func createGitHubSarifUploadHandler(t *testing.T, _ string, _ []byte, _ int) htt
 	return func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
 		switch r.RequestURI {
-		case "/repos/jfrog/repo-1/commits?page=1&per_page=50&sha=master":
+		case "/repos/jfrog/repo-1/commits?page=1&per_page=1&sha=master":
 			w.WriteHeader(http.StatusOK)
 			repositoryCommits := []*github.RepositoryCommit{
 				{
@@ -1131,3 +2860,30 @@ func TestIsRateLimitAbuseError(t *testing.T) {
 	isRateLimitAbuseErr = isRateLimitAbuseError(&github.AbuseRateLimitError{})
 	assert.True(t, isRateLimitAbuseErr)
 }
+
+func createCheckRunGitHubHandler(annotationsPerRequest *[]int) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+			var options struct {
+				Name   string                 `json:"name"`
+				Output *github.CheckRunOutput `json:"output"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+			assert.Equal(t, "Linter", options.Name)
+			if assert.NotNil(t, options.Output) {
+				assert.Equal(t, "60 warnings", options.Output.GetSummary())
+				*annotationsPerRequest = append(*annotationsPerRequest, len(options.Output.Annotations))
+			}
+			switch r.Method + " " + r.RequestURI {
+			case "POST /repos/jfrog/repo-1/check-runs":
+				w.WriteHeader(http.StatusCreated)
+			case "PATCH /repos/jfrog/repo-1/check-runs/4":
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+			_, err := w.Write([]byte(`{"id": 4}`))
+			assert.NoError(t, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package vcsclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// GitHub accepts JWTs which expire in up to 10 minutes
+	githubAppJwtExpiration = 9 * time.Minute
+	// The JWT is issued in the past, to allow a clock drift between the machine and GitHub
+	githubAppJwtClockDrift = time.Minute
+)
+
+var errGitHubAppNotConfigured = errors.New("the GitHub App credentials are required, set them with ClientBuilder.GitHubApp")
+
+// buildGitHubAppClients returns a client authenticated as the installation of the GitHub App, and a client authenticated as the app itself.
+// Without an installation ID, both clients are authenticated as the app.
+func buildGitHubAppClients(vcsInfo VcsInfo, logger vcsutils.Log) (ghClient *github.Client, appClient *github.Client, err error) {
+	privateKey, err := parseGitHubAppPrivateKey(vcsInfo.GitHubAppPrivateKey)
+	if err != nil {
+		return
+	}
+	appTokenSource := oauth2.ReuseTokenSource(nil, &githubAppTokenSource{appID: vcsInfo.GitHubAppID, privateKey: privateKey})
+	appClient, err = buildGithubClientWithHttpClient(newOAuth2HttpClient(vcsInfo, appTokenSource), vcsInfo.APIEndpoint, logger)
+	if err != nil || vcsInfo.GitHubAppInstallationID == 0 {
+		return appClient, appClient, err
+	}
+	ghClient, err = buildGitHubInstallationClient(vcsInfo, appClient, vcsInfo.GitHubAppInstallationID)
+	return
+}
+
+// buildGitHubInstallationClient returns a client authenticated as an installation of a GitHub App.
+// The installation access token is refreshed when it expires.
+func buildGitHubInstallationClient(vcsInfo VcsInfo, appClient *github.Client, installationID int64) (*github.Client, error) {
+	installationTokenSource := oauth2.ReuseTokenSource(nil, &githubInstallationTokenSource{appClient: appClient, installationID: installationID})
+	return buildGithubClientWithHttpClient(newOAuth2HttpClient(vcsInfo, installationTokenSource), appClient.BaseURL.String(), vcsutils.EmptyLogger{})
+}
+
+// parseGitHubAppPrivateKey parses the PEM encoded RSA private key, which is generated in the settings of the GitHub App
+func parseGitHubAppPrivateKey(privateKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, errors.New("failed to decode the GitHub App private key, a PEM encoded key is expected")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the GitHub App private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the GitHub App private key must be an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// githubAppTokenSource creates JWTs which authenticate as the GitHub App itself
+type githubAppTokenSource struct {
+	appID      int64
+	privateKey *rsa.PrivateKey
+}
+
+type githubAppJwtClaims struct {
+	IssuedAt  int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp"`
+	Issuer    int64 `json:"iss"`
+}
+
+func (source *githubAppTokenSource) Token() (*oauth2.Token, error) {
+	now := time.Now()
+	expiresAt := now.Add(githubAppJwtExpiration)
+	claims, err := json.Marshal(githubAppJwtClaims{
+		IssuedAt:  now.Add(-githubAppJwtClockDrift).Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		Issuer:    source.appID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	unsignedToken := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hashed := sha256.Sum256([]byte(unsignedToken))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, source.privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the GitHub App JWT: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: unsignedToken + "." + base64.RawURLEncoding.EncodeToString(signature),
+		Expiry:      expiresAt,
+	}, nil
+}
+
+// githubInstallationTokenSource creates access tokens which authenticate as an installation of the GitHub App
+type githubInstallationTokenSource struct {
+	appClient      *github.Client
+	installationID int64
+}
+
+func (source *githubInstallationTokenSource) Token() (*oauth2.Token, error) {
+	installationToken, _, err := source.appClient.Apps.CreateInstallationToken(context.Background(), source.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an access token for the GitHub App installation %d: %w", source.installationID, err)
+	}
+	return &oauth2.Token{
+		AccessToken: installationToken.GetToken(),
+		Expiry:      installationToken.GetExpiresAt().Time,
+	}, nil
+}
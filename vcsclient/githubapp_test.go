@@ -0,0 +1,174 @@
+package vcsclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	githubAppID             = int64(1234)
+	githubAppInstallationID = int64(5)
+	githubInstallationToken = "ghs_installation-token"
+)
+
+func TestParseGitHubAppPrivateKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	parsedKey, err := parseGitHubAppPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}))
+	assert.NoError(t, err)
+	assert.True(t, privateKey.Equal(parsedKey))
+
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	parsedKey, err = parseGitHubAppPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Key}))
+	assert.NoError(t, err)
+	assert.True(t, privateKey.Equal(parsedKey))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	pkcs8Key, err = x509.MarshalPKCS8PrivateKey(ecKey)
+	assert.NoError(t, err)
+	_, err = parseGitHubAppPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Key}))
+	assert.EqualError(t, err, "the GitHub App private key must be an RSA key")
+
+	_, err = parseGitHubAppPrivateKey([]byte("not a key"))
+	assert.Error(t, err)
+	_, err = NewClientBuilder(vcsutils.GitHub).GitHubApp(githubAppID, []byte("not a key"), githubAppInstallationID).Build()
+	assert.Error(t, err)
+}
+
+func TestGitHubAppTokenSource(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	token, err := (&githubAppTokenSource{appID: githubAppID, privateKey: privateKey}).Token()
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(githubAppJwtExpiration), token.Expiry, time.Minute)
+
+	claims := assertGitHubAppJwt(t, token.AccessToken, &privateKey.PublicKey)
+	assert.Equal(t, githubAppID, claims.Issuer)
+	assert.Equal(t, int64((githubAppJwtExpiration + githubAppJwtClockDrift).Seconds()), claims.ExpiresAt-claims.IssuedAt)
+}
+
+func TestGitHubClient_GitHubAppInstallation(t *testing.T) {
+	ctx := context.Background()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	var accessTokensCreated int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "POST /app/installations/5/access_tokens":
+			assertGitHubAppJwt(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &privateKey.PublicKey)
+			accessTokensCreated++
+			w.WriteHeader(http.StatusCreated)
+			response = `{"token": "` + githubInstallationToken + `", "expires_at": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`
+		case "GET /repos/jfrog/repo-1/branches?page=1&per_page=100":
+			assert.Equal(t, "Bearer "+githubInstallationToken, r.Header.Get("Authorization"))
+			response = `[{"name": "master"}]`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).GitHubApp(githubAppID, encodeGitHubAppPrivateKey(privateKey), githubAppInstallationID).Build()
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		branches, err := client.ListBranches(ctx, owner, repo1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"master"}, branches)
+	}
+	// The installation access token is reused until it expires
+	assert.Equal(t, 1, accessTokensCreated)
+}
+
+func TestGitHubClient_ListAppInstallations(t *testing.T) {
+	ctx := context.Background()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /app/installations?page=1":
+			assertGitHubAppJwt(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &privateKey.PublicKey)
+			response = `[{"id": 5, "account": {"login": "jfrog"}, "repository_selection": "selected"}, {"id": 6, "account": {"login": "frogger"}, "repository_selection": "all"}]`
+		case "POST /app/installations/5/access_tokens":
+			assertGitHubAppJwt(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &privateKey.PublicKey)
+			w.WriteHeader(http.StatusCreated)
+			response = `{"token": "` + githubInstallationToken + `", "expires_at": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`
+		case "GET /installation/repositories?page=1":
+			assert.Equal(t, "Bearer "+githubInstallationToken, r.Header.Get("Authorization"))
+			response = `{"total_count": 2, "repositories": [{"name": "repo-1", "owner": {"login": "jfrog"}}, {"name": "repo-2", "owner": {"login": "jfrog"}}]}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).GitHubApp(githubAppID, encodeGitHubAppPrivateKey(privateKey), 0).Build()
+	assert.NoError(t, err)
+
+	installations, err := client.ListAppInstallations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []AppInstallationInfo{
+		{ID: 5, Account: "jfrog", RepositorySelection: "selected"},
+		{ID: 6, Account: "frogger", RepositorySelection: "all"},
+	}, installations)
+
+	repositories, err := client.ListInstallationRepositories(ctx, githubAppInstallationID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"jfrog": {"repo-1", "repo-2"}}, repositories)
+
+	client, err = NewClientBuilder(vcsutils.GitHub).Token(token).Build()
+	assert.NoError(t, err)
+	_, err = client.ListAppInstallations(ctx)
+	assert.ErrorIs(t, err, errGitHubAppNotConfigured)
+	_, err = client.ListInstallationRepositories(ctx, githubAppInstallationID)
+	assert.ErrorIs(t, err, errGitHubAppNotConfigured)
+}
+
+func encodeGitHubAppPrivateKey(privateKey *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+}
+
+// assertGitHubAppJwt verifies the signature of a GitHub App JWT, and returns its claims
+func assertGitHubAppJwt(t *testing.T, jwt string, publicKey *rsa.PublicKey) (claims githubAppJwtClaims) {
+	parts := strings.Split(jwt, ".")
+	if !assert.Len(t, parts, 3) {
+		return
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"alg":"RS256","typ":"JWT"}`, string(header))
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.NoError(t, err)
+	hashed := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signature))
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(rawClaims, &claims))
+	return
+}
@@ -1,14 +1,17 @@
 package vcsclient
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/jfrog/froggit-go/vcsutils"
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/xanzy/go-gitlab"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"io"
 	"net/http"
 	"sort"
 	"strconv"
@@ -24,13 +27,22 @@ type GitLabClient struct {
 
 // NewGitLabClient create a new GitLabClient
 func NewGitLabClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GitLabClient, error) {
-	var client *gitlab.Client
-	var err error
+	var options []gitlab.ClientOptionFunc
 	if vcsInfo.APIEndpoint != "" {
-		client, err = gitlab.NewClient(vcsInfo.Token, gitlab.WithBaseURL(vcsInfo.APIEndpoint))
-	} else {
-		client, err = gitlab.NewClient(vcsInfo.Token)
+		options = append(options, gitlab.WithBaseURL(vcsInfo.APIEndpoint))
+	}
+	newClient := gitlab.NewClient
+	if vcsInfo.GitLabJobToken {
+		newClient = gitlab.NewJobClient
+	}
+	if vcsInfo.TokenProvider != nil {
+		// The authentication header which go-gitlab sets with the empty token is replaced by the transport
+		newClient = gitlab.NewOAuthClient
+		options = append(options, gitlab.WithHTTPClient(newTokenProviderHttpClient(vcsInfo, setGitLabAuthorization(vcsInfo.GitLabJobToken))))
+	} else if !isDefaultHttpClient(vcsInfo) {
+		options = append(options, gitlab.WithHTTPClient(newHttpClient(vcsInfo)))
 	}
+	client, err := newClient(vcsInfo.Token, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -42,27 +54,89 @@ func NewGitLabClient(vcsInfo VcsInfo, logger vcsutils.Log) (*GitLabClient, error
 	}, nil
 }
 
+// setGitLabAuthorization returns a function which authenticates a request with a job token or an access token
+func setGitLabAuthorization(jobToken bool) func(request *http.Request, token string) {
+	return func(request *http.Request, token string) {
+		request.Header.Del("Authorization")
+		if jobToken {
+			request.Header.Set("JOB-TOKEN", token)
+			return
+		}
+		setBearerAuthorization(request, token)
+	}
+}
+
 // TestConnection on GitLab
 func (client *GitLabClient) TestConnection(ctx context.Context) error {
+	if client.vcsInfo.GitLabJobToken {
+		// Job tokens can't list projects
+		_, _, err := client.glClient.Jobs.GetJobTokensJob(nil, gitlab.WithContext(ctx))
+		return err
+	}
 	_, _, err := client.glClient.Projects.ListProjects(nil, gitlab.WithContext(ctx))
 	return err
 }
 
-// ListRepositories on GitLab
+// TestConnectionWithScopes on GitLab.
+// The scopes are read from the token details, so the token must be a personal, group or project access token.
+// A CI job token grants only the repository read scope.
+func (client *GitLabClient) TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error {
+	if client.vcsInfo.GitLabJobToken {
+		if err := client.TestConnection(ctx); err != nil {
+			return err
+		}
+		return validateTokenScopes(scopes, func(scope TokenScope) bool {
+			return scope == RepositoryReadScope
+		})
+	}
+	accessToken, _, err := client.glClient.PersonalAccessTokens.GetSinglePersonalAccessToken(gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return validateTokenScopes(scopes, func(scope TokenScope) bool {
+		return slices.ContainsFunc(gitLabTokenScopes[scope], func(gitLabScope string) bool {
+			return slices.Contains(accessToken.Scopes, gitLabScope)
+		})
+	})
+}
+
+// gitLabTokenScopes maps each token scope to the GitLab token scopes which grant it
+var gitLabTokenScopes = map[TokenScope][]string{
+	RepositoryReadScope:  {"api", "read_api"},
+	RepositoryWriteScope: {"api"},
+	WebhookAdminScope:    {"api"},
+}
+
+// GetAuthenticatedUser on GitLab.
+// With a CI job token, the user is the one who triggered the job.
+func (client *GitLabClient) GetAuthenticatedUser(ctx context.Context) (UserInfo, error) {
+	if client.vcsInfo.GitLabJobToken {
+		job, _, err := client.glClient.Jobs.GetJobTokensJob(nil, gitlab.WithContext(ctx))
+		if err != nil {
+			return UserInfo{}, err
+		}
+		if job.User == nil {
+			return UserInfo{}, errors.New("the user who triggered the job is missing from the job details")
+		}
+		return UserInfo{Username: job.User.Username, DisplayName: job.User.Name, Email: job.User.Email}, nil
+	}
+	user, _, err := client.glClient.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return UserInfo{}, err
+	}
+	return UserInfo{Username: user.Username, DisplayName: user.Name, Email: user.Email}, nil
+}
+
+// ListRepositories on GitLab.
+// If the client is configured with a group, the projects of the group and its subgroups are listed, keyed by the full paths of their groups.
 func (client *GitLabClient) ListRepositories(ctx context.Context) (map[string][]string, error) {
-	simple := true
 	results := make(map[string][]string)
-	membership := true
 	for pageID := 1; ; pageID++ {
-		options := &gitlab.ListProjectsOptions{ListOptions: gitlab.ListOptions{Page: pageID}, Simple: &simple, Membership: &membership}
-		projects, response, err := client.glClient.Projects.ListProjects(options, gitlab.WithContext(ctx))
+		projects, response, err := client.listProjects(ctx, gitlab.ListOptions{Page: pageID})
 		if err != nil {
 			return nil, err
 		}
-		for _, project := range projects {
-			owner := project.Namespace.Path
-			results[owner] = append(results[owner], project.Path)
-		}
+		addGitLabProjects(results, projects)
 		if pageID >= response.TotalPages {
 			break
 		}
@@ -70,10 +144,72 @@ func (client *GitLabClient) ListRepositories(ctx context.Context) (map[string][]
 	return results, nil
 }
 
+// ListRepositoriesWithOptions on GitLab
+func (client *GitLabClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	page, perPage := options.getPagination()
+	projects, _, err := client.listProjects(ctx, gitlab.ListOptions{Page: page, PerPage: perPage})
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	addGitLabProjects(results, projects)
+	return results, nil
+}
+
+// listProjects lists a page of the projects which the user is a member of, or of the projects of the client's group and its subgroups
+func (client *GitLabClient) listProjects(ctx context.Context, listOptions gitlab.ListOptions) ([]*gitlab.Project, *gitlab.Response, error) {
+	if client.vcsInfo.GitLabGroup == "" {
+		options := &gitlab.ListProjectsOptions{ListOptions: listOptions, Simple: gitlab.Bool(true), Membership: gitlab.Bool(true)}
+		return client.glClient.Projects.ListProjects(options, gitlab.WithContext(ctx))
+	}
+	options := &gitlab.ListGroupProjectsOptions{ListOptions: listOptions, Simple: gitlab.Bool(true), IncludeSubGroups: gitlab.Bool(true)}
+	if client.vcsInfo.GitLabGroupMembershipOnly {
+		// Members of a group or a project have at least the guest access level
+		options.MinAccessLevel = gitlab.AccessLevel(gitlab.GuestPermissions)
+	}
+	return client.glClient.Groups.ListGroupProjects(client.vcsInfo.GitLabGroup, options, gitlab.WithContext(ctx))
+}
+
+// addGitLabProjects adds the projects to the repositories, keyed by the full paths of their namespaces, such as org/team/sub
+func addGitLabProjects(repositories map[string][]string, projects []*gitlab.Project) {
+	for _, project := range projects {
+		owner := project.Namespace.FullPath
+		repositories[owner] = append(repositories[owner], project.Path)
+	}
+}
+
+// ListAppInstallations on GitLab
+func (client *GitLabClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, errGitLabAppInstallationsNotSupported
+}
+
+// ListInstallationRepositories on GitLab
+func (client *GitLabClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, errGitLabAppInstallationsNotSupported
+}
+
 // ListBranches on GitLab
 func (client *GitLabClient) ListBranches(ctx context.Context, owner, repository string) ([]string, error) {
-	branches, _, err := client.glClient.Branches.ListBranches(getProjectID(owner, repository), nil,
-		gitlab.WithContext(ctx))
+	var results []string
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListBranchesOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		branches, glResponse, err := client.glClient.Branches.ListBranches(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			results = append(results, branch.Name)
+		}
+		nextPage = glResponse.NextPage
+	}
+	return results, nil
+}
+
+// ListBranchesWithOptions on GitLab
+func (client *GitLabClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	page, perPage := options.getPagination()
+	listOptions := &gitlab.ListBranchesOptions{ListOptions: gitlab.ListOptions{Page: page, PerPage: perPage}}
+	branches, _, err := client.glClient.Branches.ListBranches(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +221,305 @@ func (client *GitLabClient) ListBranches(ctx context.Context, owner, repository
 	return results, nil
 }
 
+// CreateBranch on GitLab
+func (client *GitLabClient) CreateBranch(ctx context.Context, owner, repository, sourceRef, newBranch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "sourceRef": sourceRef, "newBranch": newBranch})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Branches.CreateBranch(getProjectID(owner, repository), &gitlab.CreateBranchOptions{
+		Branch: &newBranch,
+		Ref:    &sourceRef,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// DeleteBranch on GitLab
+func (client *GitLabClient) DeleteBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Branches.DeleteBranch(getProjectID(owner, repository), branch, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetDefaultBranch on GitLab
+func (client *GitLabClient) GetDefaultBranch(ctx context.Context, owner, repository string) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return "", err
+	}
+	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return project.DefaultBranch, nil
+}
+
+// SetDefaultBranch on GitLab
+func (client *GitLabClient) SetDefaultBranch(ctx context.Context, owner, repository, branch string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Projects.EditProject(getProjectID(owner, repository), &gitlab.EditProjectOptions{DefaultBranch: &branch}, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetBranchProtection on GitLab.
+// Required approvals are read from the approval rules of the protected branch.
+func (client *GitLabClient) GetBranchProtection(ctx context.Context, owner, repository, branch string) (BranchProtectionInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	projectID := getProjectID(owner, repository)
+	protectedBranch, glResponse, err := client.glClient.ProtectedBranches.GetProtectedBranch(projectID, branch, gitlab.WithContext(ctx))
+	if err != nil {
+		if glResponse != nil && glResponse.StatusCode == http.StatusNotFound {
+			return BranchProtectionInfo{}, nil
+		}
+		return BranchProtectionInfo{}, err
+	}
+
+	branchProtection := BranchProtectionInfo{RestrictPushes: len(protectedBranch.PushAccessLevels) > 0}
+	for _, pushAccessLevel := range protectedBranch.PushAccessLevels {
+		if pushAccessLevel.AccessLevel != gitlab.NoPermissions {
+			branchProtection.RestrictPushes = false
+		}
+	}
+	approvalRules, _, err := client.glClient.Projects.GetProjectApprovalRules(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return BranchProtectionInfo{}, err
+	}
+	for _, approvalRule := range approvalRules {
+		if approvalRule.ApprovalsRequired > branchProtection.RequiredApprovals && isGitLabApprovalRuleApplied(approvalRule, protectedBranch.ID) {
+			branchProtection.RequiredApprovals = approvalRule.ApprovalsRequired
+		}
+	}
+	return branchProtection, nil
+}
+
+// SetBranchProtection on GitLab.
+// The branch is protected again with the new rules, and the required approvals are set by an approval rule of the protected branch.
+// Required status checks aren't supported.
+func (client *GitLabClient) SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtectionInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch})
+	if err != nil {
+		return err
+	}
+	if len(protection.RequiredStatusChecks) > 0 {
+		return errors.New("required status checks are not supported on GitLab")
+	}
+	projectID := getProjectID(owner, repository)
+
+	// The approval rule is removed before unprotecting the branch, because a rule without protected branches applies to all branches
+	approvalRuleName := fmt.Sprintf("%s approvals", branch)
+	approvalRules, _, err := client.glClient.Projects.GetProjectApprovalRules(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	for _, approvalRule := range approvalRules {
+		if approvalRule.Name == approvalRuleName {
+			if _, err = client.glClient.Projects.DeleteProjectApprovalRule(projectID, approvalRule.ID, gitlab.WithContext(ctx)); err != nil {
+				return err
+			}
+		}
+	}
+
+	glResponse, err := client.glClient.ProtectedBranches.UnprotectRepositoryBranches(projectID, branch, gitlab.WithContext(ctx))
+	if err != nil && (glResponse == nil || glResponse.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	pushAccessLevel := gitlab.MaintainerPermissions
+	if protection.RestrictPushes {
+		pushAccessLevel = gitlab.NoPermissions
+	}
+	protectedBranch, _, err := client.glClient.ProtectedBranches.ProtectRepositoryBranches(projectID, &gitlab.ProtectRepositoryBranchesOptions{
+		Name:             &branch,
+		PushAccessLevel:  &pushAccessLevel,
+		MergeAccessLevel: vcsutils.PointerOf(gitlab.MaintainerPermissions),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if protection.RequiredApprovals == 0 {
+		return nil
+	}
+	_, _, err = client.glClient.Projects.CreateProjectApprovalRule(projectID, &gitlab.CreateProjectLevelRuleOptions{
+		Name:               &approvalRuleName,
+		ApprovalsRequired:  &protection.RequiredApprovals,
+		ProtectedBranchIDs: &[]int{protectedBranch.ID},
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+func isGitLabApprovalRuleApplied(approvalRule *gitlab.ProjectApprovalRule, protectedBranchID int) bool {
+	if approvalRule.AppliesToAllProtectedBranches {
+		return true
+	}
+	for _, protectedBranch := range approvalRule.ProtectedBranches {
+		if protectedBranch.ID == protectedBranchID {
+			return true
+		}
+	}
+	return false
+}
+
+// ListTags on GitLab
+func (client *GitLabClient) ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TagInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListTagsOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		tags, glResponse, err := client.glClient.Tags.ListTags(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagInfo := TagInfo{Name: tag.Name}
+			if tag.Commit != nil {
+				tagInfo.CommitSha = tag.Commit.ID
+			}
+			results = append(results, tagInfo)
+		}
+		nextPage = glResponse.NextPage
+	}
+	return results, nil
+}
+
+// CreateTag on GitLab
+func (client *GitLabClient) CreateTag(ctx context.Context, owner, repository, tagName, sourceRef, message string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName, "sourceRef": sourceRef})
+	if err != nil {
+		return err
+	}
+	options := &gitlab.CreateTagOptions{TagName: &tagName, Ref: &sourceRef}
+	if message != "" {
+		options.Message = &message
+	}
+	_, _, err = client.glClient.Tags.CreateTag(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetTagInfo on GitLab
+func (client *GitLabClient) GetTagInfo(ctx context.Context, owner, repository, tagName string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	tag, _, err := client.glClient.Tags.GetTag(getProjectID(owner, repository), tagName, gitlab.WithContext(ctx))
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	if tag.Commit == nil {
+		return CommitInfo{}, fmt.Errorf("couldn't find the commit of tag %s", tagName)
+	}
+	return mapGitLabCommitToCommitInfo(tag.Commit), nil
+}
+
+// CreateRelease on GitLab
+func (client *GitLabClient) CreateRelease(ctx context.Context, owner, repository, tagName, name, releaseNotes string) (ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "tagName": tagName})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	release, _, err := client.glClient.Releases.CreateRelease(getProjectID(owner, repository), &gitlab.CreateReleaseOptions{
+		Name:        &name,
+		TagName:     &tagName,
+		Description: &releaseNotes,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	return mapGitLabReleaseToReleaseInfo(release), nil
+}
+
+// ListReleases on GitLab
+func (client *GitLabClient) ListReleases(ctx context.Context, owner, repository string) ([]ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ReleaseInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListReleasesOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		releases, glResponse, err := client.glClient.Releases.ListReleases(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			results = append(results, mapGitLabReleaseToReleaseInfo(release))
+		}
+		nextPage = glResponse.NextPage
+	}
+	return results, nil
+}
+
+// GetLatestRelease on GitLab
+func (client *GitLabClient) GetLatestRelease(ctx context.Context, owner, repository string) (ReleaseInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	releases, _, err := client.glClient.Releases.ListReleases(getProjectID(owner, repository), &gitlab.ListReleasesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 1},
+		OrderBy:     vcsutils.PointerOf("released_at"),
+		Sort:        vcsutils.PointerOf("desc"),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return ReleaseInfo{}, err
+	}
+	if len(releases) == 0 {
+		return ReleaseInfo{}, fmt.Errorf("couldn't find any release in repository %s", repository)
+	}
+	return mapGitLabReleaseToReleaseInfo(releases[0]), nil
+}
+
+// UploadReleaseAsset on GitLab.
+// The file is uploaded to the project and linked to the release, which is identified by its tag name.
+func (client *GitLabClient) UploadReleaseAsset(ctx context.Context, owner, repository, releaseID, name string, asset io.Reader) (string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "releaseID": releaseID, "name": name})
+	if err != nil {
+		return "", err
+	}
+	projectID := getProjectID(owner, repository)
+	project, _, err := client.glClient.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	projectFile, _, err := client.glClient.Projects.UploadFile(projectID, asset, name, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	releaseLink, _, err := client.glClient.ReleaseLinks.CreateReleaseLink(projectID, releaseID, &gitlab.CreateReleaseLinkOptions{
+		Name: &name,
+		URL:  vcsutils.PointerOf(project.WebURL + projectFile.URL),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return releaseLink.URL, nil
+}
+
+func mapGitLabReleaseToReleaseInfo(release *gitlab.Release) ReleaseInfo {
+	return ReleaseInfo{
+		ID:        release.TagName,
+		Name:      release.Name,
+		TagName:   release.TagName,
+		Body:      release.Description,
+		URL:       release.Links.Self,
+		CreatedAt: extractTimeWithFallback(release.CreatedAt),
+	}
+}
+
 // AddSshKeyToRepository on GitLab
 func (client *GitLabClient) AddSshKeyToRepository(ctx context.Context, owner, repository, keyName, publicKey string, permission Permission) error {
 	err := validateParametersNotBlank(map[string]string{
@@ -122,6 +557,8 @@ func (client *GitLabClient) CreateWebhook(ctx context.Context, owner, repository
 		PushEvents:             &projectHook.PushEvents,
 		PushEventsBranchFilter: &projectHook.PushEventsBranchFilter,
 		TagPushEvents:          &projectHook.TagPushEvents,
+		NoteEvents:             &projectHook.NoteEvents,
+		ReleasesEvents:         &projectHook.ReleasesEvents,
 	}
 	response, _, err := client.glClient.Projects.AddProjectHook(getProjectID(owner, repository), options,
 		gitlab.WithContext(ctx))
@@ -131,6 +568,11 @@ func (client *GitLabClient) CreateWebhook(ctx context.Context, owner, repository
 	return strconv.Itoa(response.ID), token, nil
 }
 
+// CreateOrUpdateWebhook on GitLab
+func (client *GitLabClient) CreateOrUpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	return createOrUpdateWebhook(ctx, client, owner, repository, branch, payloadURL, webhookEvents...)
+}
+
 // UpdateWebhook on GitLab
 func (client *GitLabClient) UpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL, token,
 	webhookID string, webhookEvents ...vcsutils.WebhookEvent) error {
@@ -142,6 +584,8 @@ func (client *GitLabClient) UpdateWebhook(ctx context.Context, owner, repository
 		PushEvents:             &projectHook.PushEvents,
 		PushEventsBranchFilter: &projectHook.PushEventsBranchFilter,
 		TagPushEvents:          &projectHook.TagPushEvents,
+		NoteEvents:             &projectHook.NoteEvents,
+		ReleasesEvents:         &projectHook.ReleasesEvents,
 	}
 	intWebhook, err := strconv.Atoi(webhookID)
 	if err != nil {
@@ -163,6 +607,64 @@ func (client *GitLabClient) DeleteWebhook(ctx context.Context, owner, repository
 	return err
 }
 
+// ListWebhooks on GitLab
+func (client *GitLabClient) ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []WebhookInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListProjectHooksOptions{Page: nextPage, PerPage: 100}
+		hooks, glResponse, err := client.glClient.Projects.ListProjectHooks(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			results = append(results, mapGitLabProjectHookToWebhookInfo(hook))
+		}
+		nextPage = glResponse.NextPage
+	}
+	return results, nil
+}
+
+// GetWebhook on GitLab
+func (client *GitLabClient) GetWebhook(ctx context.Context, owner, repository, webhookID string) (WebhookInfo, error) {
+	intWebhook, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	hook, _, err := client.glClient.Projects.GetProjectHook(getProjectID(owner, repository), intWebhook, gitlab.WithContext(ctx))
+	if err != nil {
+		return WebhookInfo{}, err
+	}
+	return mapGitLabProjectHookToWebhookInfo(hook), nil
+}
+
+// RotateWebhookSecret on GitLab
+func (client *GitLabClient) RotateWebhookSecret(ctx context.Context, owner, repository, webhookID string) (string, error) {
+	intWebhook, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return "", err
+	}
+	projectID := getProjectID(owner, repository)
+	projectHook, _, err := client.glClient.Projects.GetProjectHook(projectID, intWebhook, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	// The URL is required, while the omitted settings of the webhook remain unchanged
+	token := vcsutils.CreateToken()
+	options := &gitlab.EditProjectHookOptions{
+		URL:   &projectHook.URL,
+		Token: &token,
+	}
+	if _, _, err = client.glClient.Projects.EditProjectHook(projectID, intWebhook, options, gitlab.WithContext(ctx)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
 // SetCommitStatus on GitLab
 func (client *GitLabClient) SetCommitStatus(ctx context.Context, commitStatus CommitStatus, owner, repository, ref,
 	title, description, detailsURL string) error {
@@ -179,8 +681,8 @@ func (client *GitLabClient) SetCommitStatus(ctx context.Context, commitStatus Co
 }
 
 // GetCommitStatuses on GitLab
-func (client *GitLabClient) GetCommitStatuses(ctx context.Context, _, repository, ref string) (status []CommitStatusInfo, err error) {
-	statuses, _, err := client.glClient.Commits.GetCommitStatuses(repository, ref, nil, gitlab.WithContext(ctx))
+func (client *GitLabClient) GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error) {
+	statuses, _, err := client.glClient.Commits.GetCommitStatuses(getProjectID(owner, repository), ref, nil, gitlab.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -188,6 +690,8 @@ func (client *GitLabClient) GetCommitStatuses(ctx context.Context, _, repository
 	for _, singleStatus := range statuses {
 		results = append(results, CommitStatusInfo{
 			State:         commitStatusAsStringToStatus(singleStatus.Status),
+			RawState:      singleStatus.Status,
+			Context:       singleStatus.Name,
 			Description:   singleStatus.Description,
 			DetailsUrl:    singleStatus.TargetURL,
 			Creator:       singleStatus.Author.Name,
@@ -198,24 +702,209 @@ func (client *GitLabClient) GetCommitStatuses(ctx context.Context, _, repository
 	return results, nil
 }
 
-// DownloadRepository on GitLab
-func (client *GitLabClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
-	format := "tar.gz"
-	options := &gitlab.ArchiveOptions{
-		Format: &format,
-		SHA:    &branch,
+// GetCombinedCommitStatus on GitLab.
+// Returns the status of the latest pipeline of the ref.
+func (client *GitLabClient) GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return Error, err
 	}
-	response, _, err := client.glClient.Repositories.Archive(getProjectID(owner, repository), options,
-		gitlab.WithContext(ctx))
+	pipeline, err := client.getLatestPipeline(ctx, owner, repository, ref)
 	if err != nil {
-		return err
+		return Error, err
 	}
-	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
-	err = vcsutils.Untar(localPath, bytes.NewReader(response), true)
+	if pipeline == nil {
+		return Pass, nil
+	}
+	return mapGitLabPipelineStatusToCommitStatus(pipeline.Status), nil
+}
+
+// TriggerPipeline on GitLab
+func (client *GitLabClient) TriggerPipeline(ctx context.Context, owner, repository, ref string, variables map[string]string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	options := &gitlab.CreatePipelineOptions{Ref: &ref}
+	if len(variables) > 0 {
+		pipelineVariables := make([]*gitlab.PipelineVariableOptions, 0, len(variables))
+		keys := maps.Keys(variables)
+		slices.Sort(keys)
+		for _, key := range keys {
+			pipelineVariables = append(pipelineVariables, &gitlab.PipelineVariableOptions{Key: gitlab.String(key), Value: gitlab.String(variables[key])})
+		}
+		options.Variables = &pipelineVariables
+	}
+	pipeline, _, err := client.glClient.Pipelines.CreatePipeline(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapGitLabPipelineToPipelineInfo(toGitLabPipelineInfo(pipeline)), nil
+}
+
+// GetLatestPipelineStatus on GitLab
+func (client *GitLabClient) GetLatestPipelineStatus(ctx context.Context, owner, repository, ref string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	pipeline, err := client.getLatestPipeline(ctx, owner, repository, ref)
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	if pipeline == nil {
+		return PipelineInfo{}, fmt.Errorf("no pipeline was found for ref '%s'", ref)
+	}
+	return mapGitLabPipelineToPipelineInfo(*pipeline), nil
+}
+
+// GetPipelineStatus on GitLab
+func (client *GitLabClient) GetPipelineStatus(ctx context.Context, owner, repository, pipelineID string) (PipelineInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pipelineID": pipelineID})
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	id, err := strconv.Atoi(pipelineID)
+	if err != nil {
+		return PipelineInfo{}, fmt.Errorf("invalid pipeline ID '%s': %w", pipelineID, err)
+	}
+	pipeline, _, err := client.glClient.Pipelines.GetPipeline(getProjectID(owner, repository), id, gitlab.WithContext(ctx))
+	if err != nil {
+		return PipelineInfo{}, err
+	}
+	return mapGitLabPipelineToPipelineInfo(toGitLabPipelineInfo(pipeline)), nil
+}
+
+// TriggerWorkflow on GitLab
+func (client *GitLabClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return errGitLabWorkflowsNotSupported
+}
+
+// GetWorkflowRunStatus on GitLab
+func (client *GitLabClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGitLabWorkflowsNotSupported
+}
+
+// QueueBuild on GitLab
+func (client *GitLabClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGitLabBuildsNotSupported
+}
+
+// GetBuildStatus on GitLab
+func (client *GitLabClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errGitLabBuildsNotSupported
+}
+
+// getLatestPipeline returns the latest pipeline of a commit SHA, a branch or a tag, or nil if the ref has no pipelines
+func (client *GitLabClient) getLatestPipeline(ctx context.Context, owner, repository, ref string) (*gitlab.PipelineInfo, error) {
+	options := &gitlab.ListProjectPipelinesOptions{ListOptions: gitlab.ListOptions{PerPage: 1}}
+	if plumbing.IsHash(ref) {
+		options.SHA = &ref
+	} else {
+		options.Ref = &ref
+	}
+	pipelines, _, err := client.glClient.Pipelines.ListProjectPipelines(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	if err != nil || len(pipelines) == 0 {
+		return nil, err
+	}
+	return pipelines[0], nil
+}
+
+// toGitLabPipelineInfo returns the details of a pipeline which are returned by the pipelines listing
+func toGitLabPipelineInfo(pipeline *gitlab.Pipeline) gitlab.PipelineInfo {
+	return gitlab.PipelineInfo{
+		ID:        pipeline.ID,
+		Status:    pipeline.Status,
+		Ref:       pipeline.Ref,
+		SHA:       pipeline.SHA,
+		WebURL:    pipeline.WebURL,
+		CreatedAt: pipeline.CreatedAt,
+		UpdatedAt: pipeline.UpdatedAt,
+	}
+}
+
+func mapGitLabPipelineToPipelineInfo(pipeline gitlab.PipelineInfo) PipelineInfo {
+	return PipelineInfo{
+		ID:            strconv.Itoa(pipeline.ID),
+		Status:        mapGitLabPipelineStatusToCommitStatus(pipeline.Status),
+		RawStatus:     pipeline.Status,
+		Ref:           pipeline.Ref,
+		CommitSHA:     pipeline.SHA,
+		WebURL:        pipeline.WebURL,
+		CreatedAt:     extractTimeWithFallback(pipeline.CreatedAt),
+		LastUpdatedAt: extractTimeWithFallback(pipeline.UpdatedAt),
+	}
+}
+
+func mapGitLabPipelineStatusToCommitStatus(pipelineStatus string) CommitStatus {
+	switch pipelineStatus {
+	case "success", "skipped":
+		return Pass
+	case "failed", "canceled":
+		return Fail
+	default:
+		return InProgress
+	}
+}
+
+// DownloadRepository on GitLab
+func (client *GitLabClient) DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error {
+	if err := client.extractArchive(ctx, owner, repository, branch, "", localPath, nil); err != nil {
+		return err
+	}
+	return client.createDotGitFolder(ctx, owner, repository, localPath)
+}
+
+// DownloadRepositoryPaths on GitLab.
+// GitLab archives a single path, so an archive is downloaded for each of the paths.
+func (client *GitLabClient) DownloadRepositoryPaths(ctx context.Context, owner, repository, ref string, paths []string, localPath string) error {
+	paths, err := normalizeDownloadPaths(paths)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(paths, "") {
+		// The root of the repository contains all the other paths
+		paths = []string{""}
+	}
+	for _, archivePath := range paths {
+		if err = client.extractArchive(ctx, owner, repository, ref, archivePath, localPath, paths); err != nil {
+			return err
+		}
+	}
+	return client.createDotGitFolder(ctx, owner, repository, localPath)
+}
+
+// extractArchive downloads the archive of archivePath, or of the whole repository if it's empty, and extracts the given paths of it
+func (client *GitLabClient) extractArchive(ctx context.Context, owner, repository, branch, archivePath, localPath string, paths []string) error {
+	format, err := getArchiveFormat(client.vcsInfo, vcsutils.TarGzArchive)
+	if err != nil {
+		return err
+	}
+	options := &gitlab.ArchiveOptions{
+		Format: gitlab.String(string(format)),
+		SHA:    &branch,
+	}
+	if archivePath != "" {
+		options.Path = &archivePath
+	}
+	// Stream the archive into the extraction, rather than loading it into memory
+	archiveReader, archiveWriter := io.Pipe()
+	go func() {
+		_, streamErr := client.glClient.Repositories.StreamArchive(getProjectID(owner, repository), archiveWriter, options,
+			gitlab.WithContext(ctx))
+		archiveWriter.CloseWithError(streamErr)
+	}()
+	// Closing the reader stops the stream if the extraction ended early
+	defer archiveReader.Close()
+	err = vcsutils.ExtractArchive(localPath, vcsutils.LimitReader(archiveReader, client.vcsInfo.MaxDownloadSize), format, true, paths, client.vcsInfo.ExtractionLimits)
 	if err != nil {
 		return err
 	}
+	client.logger.Info(repository, vcsutils.SuccessfulRepoDownload)
+	return nil
+}
 
+func (client *GitLabClient) createDotGitFolder(ctx context.Context, owner, repository, localPath string) error {
 	repositoryInfo, err := client.GetRepositoryInfo(ctx, owner, repository)
 	if err != nil {
 		return err
@@ -235,32 +924,146 @@ func (client *GitLabClient) GetPullRequestDetailsSizeLimit() int {
 
 // CreatePullRequest on GitLab
 func (client *GitLabClient) CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch,
-	title, description string) error {
+	title, description string) (PullRequestInfo, error) {
+	return client.CreatePullRequestWithOptions(ctx, owner, repository, sourceBranch, targetBranch, title, description, CreatePullRequestOptions{})
+}
+
+// CreatePullRequestWithOptions on GitLab.
+// Draft merge requests are created by prefixing their title with "Draft: ".
+// The reviewers and the assignees are looked up by their usernames, since merge requests reference users by their IDs.
+func (client *GitLabClient) CreatePullRequestWithOptions(ctx context.Context, owner, repository, sourceBranch, targetBranch,
+	title, description string, createOptions CreatePullRequestOptions) (PullRequestInfo, error) {
+	if err := validateCreatePullRequestOptions(createOptions, "not supported on GitLab", workItemsPullRequestOption); err != nil {
+		return PullRequestInfo{}, err
+	}
+	if createOptions.Draft {
+		title = addDraftTitlePrefix(title, "Draft:", "[Draft]", "(Draft)")
+	}
 	options := &gitlab.CreateMergeRequestOptions{
-		Title:        &title,
-		Description:  &description,
-		SourceBranch: &sourceBranch,
-		TargetBranch: &targetBranch,
+		Title:              &title,
+		Description:        &description,
+		SourceBranch:       &sourceBranch,
+		TargetBranch:       &targetBranch,
+		MilestoneID:        vcsutils.GetNilIfZeroVal(createOptions.Milestone),
+		RemoveSourceBranch: vcsutils.GetNilIfZeroVal(createOptions.DeleteSourceBranch),
+	}
+	if len(createOptions.Labels) > 0 {
+		options.Labels = (*gitlab.LabelOptions)(&createOptions.Labels)
+	}
+	var err error
+	if options.ReviewerIDs, err = client.getUserIDs(ctx, createOptions.Reviewers); err != nil {
+		return PullRequestInfo{}, err
+	}
+	if options.AssigneeIDs, err = client.getUserIDs(ctx, createOptions.Assignees); err != nil {
+		return PullRequestInfo{}, err
 	}
 	client.logger.Debug("creating new merge request:", title)
-	_, _, err := client.glClient.MergeRequests.CreateMergeRequest(getProjectID(owner, repository), options,
+	mergeRequest, _, err := client.glClient.MergeRequests.CreateMergeRequest(getProjectID(owner, repository), options,
 		gitlab.WithContext(ctx))
-	return err
+	if err != nil {
+		return PullRequestInfo{}, err
+	}
+	return client.mapGitLabMergeRequestToPullRequestInfo(mergeRequest, true, owner, repository)
+}
+
+// getUserIDs returns the IDs of the users with the given usernames, or nil if no usernames are given
+func (client *GitLabClient) getUserIDs(ctx context.Context, usernames []string) (*[]int, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	userIDs := make([]int, 0, len(usernames))
+	for _, username := range usernames {
+		users, _, err := client.glClient.Users.ListUsers(&gitlab.ListUsersOptions{Username: &username}, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("GitLab user '%s' wasn't found", username)
+		}
+		userIDs = append(userIDs, users[0].ID)
+	}
+	return &userIDs, nil
 }
 
 // UpdatePullRequest on GitLab
 func (client *GitLabClient) UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error {
 	options := &gitlab.UpdateMergeRequestOptions{
-		Title:        &title,
-		Description:  &body,
-		TargetBranch: &targetBranchName,
+		Title:        vcsutils.GetNilIfZeroVal(title),
+		Description:  vcsutils.GetNilIfZeroVal(body),
+		TargetBranch: vcsutils.GetNilIfZeroVal(targetBranchName),
 		StateEvent:   mapGitLabPullRequestState(&state),
 	}
-	client.logger.Debug("updating details of merge request ID:", prId)
+	client.logger.Debug(vcsutils.UpdatingPullRequest, prId)
 	_, _, err := client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), prId, options, gitlab.WithContext(ctx))
 	return err
 }
 
+// MergePullRequest on GitLab
+// The rebase merge method rebases the merge request onto the target branch before accepting it.
+// Whether a merge commit is created is determined by the merge method configured for the project.
+func (client *GitLabClient) MergePullRequest(ctx context.Context, owner, repository string, prId int, mergeMethod vcsutils.MergeMethod) error {
+	options := &gitlab.AcceptMergeRequestOptions{}
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge:
+		options.Squash = vcsutils.PointerOf(false)
+	case vcsutils.MergeMethodSquash:
+		options.Squash = vcsutils.PointerOf(true)
+	case vcsutils.MergeMethodRebase:
+		if err := client.rebaseMergeRequest(ctx, owner, repository, prId); err != nil {
+			return err
+		}
+	default:
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	client.logger.Debug(vcsutils.MergingPullRequest, prId)
+	_, _, err := client.glClient.MergeRequests.AcceptMergeRequest(getProjectID(owner, repository), prId, options, gitlab.WithContext(ctx))
+	return err
+}
+
+// SetPullRequestAutoMerge on GitLab.
+// The merge request is set to be merged when its pipeline succeeds. The rebase merge method isn't supported, since the merge request can't be rebased in advance.
+func (client *GitLabClient) SetPullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod vcsutils.MergeMethod) error {
+	options := &gitlab.AcceptMergeRequestOptions{MergeWhenPipelineSucceeds: vcsutils.PointerOf(true)}
+	switch mergeMethod {
+	case vcsutils.MergeMethodMerge:
+		options.Squash = vcsutils.PointerOf(false)
+	case vcsutils.MergeMethodSquash:
+		options.Squash = vcsutils.PointerOf(true)
+	default:
+		return getUnsupportedMergeMethodError(mergeMethod)
+	}
+	_, _, err := client.glClient.MergeRequests.AcceptMergeRequest(getProjectID(owner, repository), pullRequestID, options, gitlab.WithContext(ctx))
+	return err
+}
+
+// Rebase the merge request onto its target branch and wait for the asynchronous rebase to complete
+func (client *GitLabClient) rebaseMergeRequest(ctx context.Context, owner, repository string, prId int) error {
+	projectID := getProjectID(owner, repository)
+	if _, err := client.glClient.MergeRequests.RebaseMergeRequest(projectID, prId, gitlab.WithContext(ctx)); err != nil {
+		return err
+	}
+	executor := vcsutils.RetryExecutor{
+		Context:                  ctx,
+		MaxRetries:               gitlabRebaseMaxRetries,
+		RetriesIntervalMilliSecs: gitlabRebaseRetriesIntervalMilliSecs,
+		ErrorMessage:             "Waiting for the merge request rebase to complete",
+		LogMsgPrefix:             "GitLab:",
+		Logger:                   client.logger,
+		ExecutionHandler: func() (bool, error) {
+			mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(projectID, prId,
+				&gitlab.GetMergeRequestsOptions{IncludeRebaseInProgress: vcsutils.PointerOf(true)}, gitlab.WithContext(ctx))
+			if err != nil {
+				return false, err
+			}
+			if mergeRequest.MergeError != "" {
+				return false, fmt.Errorf("failed to rebase merge request %d: %s", prId, mergeRequest.MergeError)
+			}
+			return mergeRequest.RebaseInProgress, nil
+		},
+	}
+	return executor.Execute()
+}
+
 // ListOpenPullRequestsWithBody on GitLab
 func (client *GitLabClient) ListOpenPullRequestsWithBody(ctx context.Context, owner, repository string) ([]PullRequestInfo, error) {
 	return client.getOpenPullRequests(ctx, owner, repository, true)
@@ -271,21 +1074,91 @@ func (client *GitLabClient) ListOpenPullRequests(ctx context.Context, owner, rep
 	return client.getOpenPullRequests(ctx, owner, repository, false)
 }
 
+// ListOpenPullRequestsWithOptions on GitLab
+func (client *GitLabClient) ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	page, perPage := options.getPagination()
+	listOptions := &gitlab.ListProjectMergeRequestsOptions{
+		ListOptions: gitlab.ListOptions{Page: page, PerPage: perPage},
+		State:       vcsutils.PointerOf("opened"),
+		Scope:       vcsutils.PointerOf("all"),
+	}
+	if options.SourceBranch != "" {
+		listOptions.SourceBranch = &options.SourceBranch
+	}
+	if options.TargetBranch != "" {
+		listOptions.TargetBranch = &options.TargetBranch
+	}
+	if options.Author != "" {
+		listOptions.AuthorUsername = &options.Author
+	}
+	if !options.UpdatedSince.IsZero() {
+		listOptions.UpdatedAfter = &options.UpdatedSince
+	}
+	client.logger.Debug(vcsutils.FetchingOpenPullRequests, repository)
+	mergeRequests, _, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return client.mapGitLabMergeRequestToPullRequestInfoList(mergeRequests, owner, repository, options.WithBody)
+}
+
+var gitlabMergeRequestStates = map[vcsutils.PullRequestState]string{
+	vcsutils.Open:   "opened",
+	vcsutils.Closed: "closed",
+	vcsutils.Merged: "merged",
+}
+
+// ListPullRequestsWithState on GitLab
+func (client *GitLabClient) ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	if err := validatePullRequestState(state); err != nil {
+		return nil, err
+	}
+
+	var results []PullRequestInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListProjectMergeRequestsOptions{
+			ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100},
+			State:       vcsutils.PointerOf(gitlabMergeRequestStates[state]),
+			Scope:       vcsutils.PointerOf("all"),
+		}
+		mergeRequests, glResponse, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		pullRequestsInfo, err := client.mapGitLabMergeRequestToPullRequestInfoList(mergeRequests, owner, repository, true)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pullRequestsInfo...)
+		nextPage = glResponse.NextPage
+	}
+	return results, nil
+}
+
 func (client *GitLabClient) getOpenPullRequests(ctx context.Context, owner, repository string, withBody bool) ([]PullRequestInfo, error) {
 	openState := "opened"
 	allScope := "all"
-	options := &gitlab.ListProjectMergeRequestsOptions{
-		State: &openState,
-		Scope: &allScope,
-	}
-	mergeRequests, _, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
-	if err != nil {
-		return []PullRequestInfo{}, err
+	var mergeRequests []*gitlab.MergeRequest
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListProjectMergeRequestsOptions{
+			ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100},
+			State:       &openState,
+			Scope:       &allScope,
+		}
+		mergeRequestsInPage, glResponse, err := client.glClient.MergeRequests.ListProjectMergeRequests(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return []PullRequestInfo{}, err
+		}
+		mergeRequests = append(mergeRequests, mergeRequestsInPage...)
+		nextPage = glResponse.NextPage
 	}
 	return client.mapGitLabMergeRequestToPullRequestInfoList(mergeRequests, owner, repository, withBody)
 }
 
-// GetPullRequestInfoById on GitLab
+// GetPullRequestByID on GitLab
 func (client *GitLabClient) GetPullRequestByID(_ context.Context, owner, repository string, pullRequestId int) (pullRequestInfo PullRequestInfo, err error) {
 	client.logger.Debug("fetching merge requests by ID in", repository)
 	mergeRequest, glResponse, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestId, nil)
@@ -297,7 +1170,7 @@ func (client *GitLabClient) GetPullRequestByID(_ context.Context, owner, reposit
 			return PullRequestInfo{}, err
 		}
 	}
-	pullRequestInfo, err = client.mapGitLabMergeRequestToPullRequestInfo(mergeRequest, false, owner, repository)
+	pullRequestInfo, err = client.mapGitLabMergeRequestToPullRequestInfo(mergeRequest, true, owner, repository)
 	return
 }
 
@@ -453,6 +1326,10 @@ func (client *GitLabClient) ListPullRequestReviewComments(ctx context.Context, o
 
 	var commentsInfo []CommentInfo
 	for _, discussion := range discussions {
+		// Individual notes are general comments, which aren't part of a review discussion
+		if discussion.IndividualNote {
+			continue
+		}
 		commentsInfo = append(commentsInfo, mapGitLabNotesToCommentInfoList(discussion.Notes, discussion.ID)...)
 	}
 
@@ -464,14 +1341,41 @@ func (client *GitLabClient) ListPullRequestComments(ctx context.Context, owner,
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": strconv.Itoa(pullRequestID)}); err != nil {
 		return nil, err
 	}
-	commentsList, _, err := client.glClient.Notes.ListMergeRequestNotes(getProjectID(owner, repository), pullRequestID, &gitlab.ListMergeRequestNotesOptions{},
-		gitlab.WithContext(ctx))
-	if err != nil {
-		return []CommentInfo{}, err
+	var commentsList []*gitlab.Note
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListMergeRequestNotesOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		commentsInPage, glResponse, err := client.glClient.Notes.ListMergeRequestNotes(getProjectID(owner, repository), pullRequestID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return []CommentInfo{}, err
+		}
+		commentsList = append(commentsList, commentsInPage...)
+		nextPage = glResponse.NextPage
 	}
 	return mapGitLabNotesToCommentInfoList(commentsList, ""), nil
 }
 
+// ListPullRequestCommentsWithOptions on GitLab
+func (client *GitLabClient) ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": strconv.Itoa(pullRequestID)}); err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	listOptions := &gitlab.ListMergeRequestNotesOptions{ListOptions: gitlab.ListOptions{Page: page, PerPage: perPage}}
+	notes, _, err := client.glClient.Notes.ListMergeRequestNotes(getProjectID(owner, repository), pullRequestID, listOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// The API doesn't support filtering by the update time, hence the comments of the page are filtered here
+	var results []CommentInfo
+	for _, comment := range mapGitLabNotesToCommentInfoList(notes, "") {
+		if options.matches(comment) {
+			results = append(results, comment)
+		}
+	}
+	return results, nil
+}
+
 // DeletePullRequestReviewComment on GitLab
 func (client *GitLabClient) DeletePullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...CommentInfo) error {
 	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "pullRequestID": strconv.Itoa(pullRequestID)}); err != nil {
@@ -482,180 +1386,839 @@ func (client *GitLabClient) DeletePullRequestReviewComments(ctx context.Context,
 		if err := validateParametersNotBlank(map[string]string{"commentID": strconv.FormatInt(commentID, 10), "discussionID": comment.ThreadID}); err != nil {
 			return err
 		}
-		if _, err := client.glClient.Discussions.DeleteMergeRequestDiscussionNote(getProjectID(owner, repository), pullRequestID, comment.ThreadID, int(commentID), gitlab.WithContext(ctx)); err != nil {
-			return fmt.Errorf("an error occurred while deleting pull request review comment: %w", err)
+		if _, err := client.glClient.Discussions.DeleteMergeRequestDiscussionNote(getProjectID(owner, repository), pullRequestID, comment.ThreadID, int(commentID), gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("an error occurred while deleting pull request review comment: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpdatePullRequestComment on GitLab
+func (client *GitLabClient) UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID, commentID int) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content}); err != nil {
+		return err
+	}
+	options := &gitlab.UpdateMergeRequestNoteOptions{Body: &content}
+	if _, _, err := client.glClient.Notes.UpdateMergeRequestNote(getProjectID(owner, repository), pullRequestID, commentID, options, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("an error occurred while updating pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// DeletePullRequestComment on GitLab
+func (client *GitLabClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	if _, err := client.glClient.Notes.DeleteMergeRequestNote(getProjectID(owner, repository), pullRequestID, commentID, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("an error occurred while deleting pull request comment:\n%s", err.Error())
+	}
+	return nil
+}
+
+// SetPullRequestThreadStatus on GitLab.
+// The thread is a discussion of the merge request.
+func (client *GitLabClient) SetPullRequestThreadStatus(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "thread ID": threadID}); err != nil {
+		return err
+	}
+	_, _, err := client.glClient.Discussions.ResolveMergeRequestDiscussion(getProjectID(owner, repository), pullRequestID, threadID,
+		&gitlab.ResolveMergeRequestDiscussionOptions{Resolved: &resolved}, gitlab.WithContext(ctx))
+	return err
+}
+
+// CreatePullRequestReview on GitLab.
+// Approving approves the merge request, and requesting changes revokes a previous approval.
+func (client *GitLabClient) CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return err
+	}
+	if err := validatePullRequestReview(verdict, body); err != nil {
+		return err
+	}
+	projectID := getProjectID(owner, repository)
+	switch verdict {
+	case vcsutils.ReviewVerdictApprove:
+		if _, _, err := client.glClient.MergeRequestApprovals.ApproveMergeRequest(projectID, pullRequestID, &gitlab.ApproveMergeRequestOptions{}, gitlab.WithContext(ctx)); err != nil {
+			return fmt.Errorf("an error occurred while approving merge request:\n%s", err.Error())
+		}
+	case vcsutils.ReviewVerdictRequestChanges:
+		// GitLab responds with 404 when the merge request wasn't approved by the user
+		if glResponse, err := client.glClient.MergeRequestApprovals.UnapproveMergeRequest(projectID, pullRequestID, gitlab.WithContext(ctx)); err != nil &&
+			(glResponse == nil || glResponse.StatusCode != http.StatusNotFound) {
+			return fmt.Errorf("an error occurred while revoking merge request approval:\n%s", err.Error())
+		}
+	}
+	if body == "" {
+		return nil
+	}
+	return client.AddPullRequestComment(ctx, owner, repository, body, pullRequestID)
+}
+
+// ListPullRequestReviews on GitLab.
+// Only approvals are returned, since GitLab doesn't report when a merge request was approved.
+func (client *GitLabClient) ListPullRequestReviews(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+	approvals, _, err := client.glClient.MergeRequestApprovals.GetConfiguration(getProjectID(owner, repository), pullRequestID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var reviews []PullRequestReviewInfo
+	for _, approver := range approvals.ApprovedBy {
+		if approver.User == nil {
+			continue
+		}
+		reviews = append(reviews, PullRequestReviewInfo{Reviewer: approver.User.Username, State: vcsutils.ReviewVerdictApprove})
+	}
+	return reviews, nil
+}
+
+// GetLatestCommit on GitLab
+func (client *GitLabClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
+	commits, err := client.ListCommits(ctx, owner, repository, ListCommitsOptions{Branch: branch, PerPage: 1})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	if len(commits) > 0 {
+		return commits[0], nil
+	}
+
+	return CommitInfo{}, fmt.Errorf("no commits were returned for <%s/%s/%s>", owner, repository, branch)
+}
+
+// GetCommits on GitLab
+func (client *GitLabClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	listOptions := &gitlab.ListCommitsOptions{
+		RefName: &branch,
+		ListOptions: gitlab.ListOptions{
+			Page:    1,
+			PerPage: vcsutils.NumberOfCommitsToFetch,
+		},
+	}
+
+	commits, _, err := client.glClient.Commits.ListCommits(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var commitsInfo []CommitInfo
+	for _, commit := range commits {
+		commitInfo := mapGitLabCommitToCommitInfo(commit)
+		commitsInfo = append(commitsInfo, commitInfo)
+	}
+	return commitsInfo, nil
+}
+
+// ListCommits on GitLab
+func (client *GitLabClient) ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"branch":     options.Branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page, perPage := options.getPagination()
+	listOptions := &gitlab.ListCommitsOptions{
+		RefName:     &options.Branch,
+		ListOptions: gitlab.ListOptions{Page: page, PerPage: perPage},
+	}
+	if options.Path != "" {
+		listOptions.Path = &options.Path
+	}
+	if options.Author != "" {
+		listOptions.Author = &options.Author
+	}
+	if !options.Since.IsZero() {
+		listOptions.Since = &options.Since
+	}
+	if !options.Until.IsZero() {
+		listOptions.Until = &options.Until
+	}
+
+	commits, _, err := client.glClient.Commits.ListCommits(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var commitsInfo []CommitInfo
+	for _, commit := range commits {
+		commitsInfo = append(commitsInfo, mapGitLabCommitToCommitInfo(commit))
+	}
+	return commitsInfo, nil
+}
+
+// GetRepositoryInfo on GitLab
+func (client *GitLabClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	return mapGitLabProjectToRepositoryInfo(project), nil
+}
+
+// CreateRepository on GitLab.
+// The project is created in the namespace of the owner, which is either a user or a group.
+func (client *GitLabClient) CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	namespace, _, err := client.glClient.Namespaces.GetNamespace(owner, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	visibility := gitlab.PublicVisibility
+	if options.Private {
+		visibility = gitlab.PrivateVisibility
+	}
+	createOptions := &gitlab.CreateProjectOptions{
+		Name:                 &repository,
+		Path:                 &repository,
+		NamespaceID:          &namespace.ID,
+		Description:          &options.Description,
+		Visibility:           &visibility,
+		InitializeWithReadme: &options.InitReadme,
+	}
+	if options.DefaultBranch != "" {
+		createOptions.DefaultBranch = &options.DefaultBranch
+	}
+	project, _, err := client.glClient.Projects.CreateProject(createOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapGitLabProjectToRepositoryInfo(project), nil
+}
+
+// DeleteRepository on GitLab
+func (client *GitLabClient) DeleteRepository(ctx context.Context, owner, repository string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, err = client.glClient.Projects.DeleteProject(getProjectID(owner, repository), gitlab.WithContext(ctx))
+	return err
+}
+
+// ForkRepository on GitLab
+func (client *GitLabClient) ForkRepository(ctx context.Context, owner, repository, targetOwner string) (RepositoryInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	forkOptions := &gitlab.ForkProjectOptions{}
+	if targetOwner != "" {
+		forkOptions.NamespacePath = &targetOwner
+	}
+	fork, _, err := client.glClient.Projects.ForkProject(getProjectID(owner, repository), forkOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	return mapGitLabProjectToRepositoryInfo(fork), nil
+}
+
+// ListRepositoryCollaborators on GitLab.
+// The members of the project include the members inherited from its groups.
+func (client *GitLabClient) ListRepositoryCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	members, err := client.listAllProjectMembers(ctx, owner, repository, nil)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CollaboratorInfo, 0, len(members))
+	for _, member := range members {
+		results = append(results, CollaboratorInfo{Username: member.Username, Permission: mapGitLabAccessLevelToRepositoryPermission(member.AccessLevel)})
+	}
+	return results, nil
+}
+
+// GetRepositoryPermission on GitLab
+func (client *GitLabClient) GetRepositoryPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "username": username})
+	if err != nil {
+		return NoPermission, err
+	}
+	// The query matches the username partially, hence the member is looked up among the results
+	members, err := client.listAllProjectMembers(ctx, owner, repository, &username)
+	if err != nil {
+		return NoPermission, err
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.Username, username) {
+			return mapGitLabAccessLevelToRepositoryPermission(member.AccessLevel), nil
+		}
+	}
+	return NoPermission, nil
+}
+
+func (client *GitLabClient) listAllProjectMembers(ctx context.Context, owner, repository string, query *string) ([]*gitlab.ProjectMember, error) {
+	var results []*gitlab.ProjectMember
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListProjectMembersOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}, Query: query}
+		members, glResponse, err := client.glClient.ProjectMembers.ListAllProjectMembers(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, members...)
+		nextPage = glResponse.NextPage
+	}
+	return results, nil
+}
+
+// Guests can't read the code of private projects, hence they have no permission
+func mapGitLabAccessLevelToRepositoryPermission(accessLevel gitlab.AccessLevelValue) RepositoryPermission {
+	switch {
+	case accessLevel >= gitlab.MaintainerPermissions:
+		return AdminPermission
+	case accessLevel >= gitlab.DeveloperPermissions:
+		return WritePermission
+	case accessLevel >= gitlab.ReporterPermissions:
+		return ReadPermission
+	default:
+		return NoPermission
+	}
+}
+
+// GetCommitBySha on GitLab
+func (client *GitLabClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
+	err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"sha":        sha,
+	})
+	if err != nil {
+		return CommitInfo{}, err
+	}
+
+	commit, _, err := client.glClient.Commits.GetCommit(getProjectID(owner, repository), sha, gitlab.WithContext(ctx))
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapGitLabCommitToCommitInfo(commit), nil
+}
+
+// CreateLabel on GitLab
+func (client *GitLabClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.glClient.Labels.CreateLabel(getProjectID(owner, repository), &gitlab.CreateLabelOptions{
+		Name:        &labelInfo.Name,
+		Description: &labelInfo.Description,
+		Color:       &labelInfo.Color,
+	}, gitlab.WithContext(ctx))
+
+	return err
+}
+
+// GetLabel on GitLub
+func (client *GitLabClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	labels, _, err := client.glClient.Labels.ListLabels(getProjectID(owner, repository), &gitlab.ListLabelsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, label := range labels {
+		if label.Name == name {
+			return &LabelInfo{
+				Name:        label.Name,
+				Description: label.Description,
+				Color:       strings.TrimPrefix(label.Color, "#"),
+			}, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ListRepositoryLabels on GitLab
+func (client *GitLabClient) ListRepositoryLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []LabelInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListLabelsOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		labels, glResponse, err := client.glClient.Labels.ListLabels(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			results = append(results, LabelInfo{
+				Name:        label.Name,
+				Description: label.Description,
+				Color:       strings.TrimPrefix(label.Color, "#"),
+			})
+		}
+		nextPage = glResponse.NextPage
+	}
+	return results, nil
+}
+
+// UpdateLabel on GitLab
+func (client *GitLabClient) UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name, "LabelInfo.name": labelInfo.Name})
+	if err != nil {
+		return err
+	}
+
+	options := &gitlab.UpdateLabelOptions{
+		Name:        &name,
+		Description: &labelInfo.Description,
+		Color:       &labelInfo.Color,
+	}
+	if labelInfo.Name != name {
+		options.NewName = &labelInfo.Name
+	}
+	_, _, err = client.glClient.Labels.UpdateLabel(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+	return err
+}
+
+// DeleteLabel on GitLab
+func (client *GitLabClient) DeleteLabel(ctx context.Context, owner, repository, name string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.glClient.Labels.DeleteLabel(getProjectID(owner, repository), name, nil, gitlab.WithContext(ctx))
+	return err
+}
+
+// ListPullRequestLabels on GitLab
+func (client *GitLabClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return []string{}, err
+	}
+	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID,
+		&gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return []string{}, err
+	}
+
+	return mergeRequest.Labels, nil
+}
+
+// LabelPullRequest on GitLab
+func (client *GitLabClient) LabelPullRequest(ctx context.Context, owner, repository string, pullRequestID int, labels []string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	labelOptions := gitlab.LabelOptions(labels)
+	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, &gitlab.UpdateMergeRequestOptions{
+		AddLabels: &labelOptions,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// UnlabelPullRequest on GitLab
+func (client *GitLabClient) UnlabelPullRequest(ctx context.Context, owner, repository, label string, pullRequestID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	labels := gitlab.LabelOptions{label}
+	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, &gitlab.UpdateMergeRequestOptions{
+		RemoveLabels: &labels,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// CreateIssue on GitLab
+func (client *GitLabClient) CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "title": title})
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	issue, _, err := client.glClient.Issues.CreateIssue(getProjectID(owner, repository), &gitlab.CreateIssueOptions{
+		Title:       &title,
+		Description: &body,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return IssueInfo{}, err
+	}
+	return mapGitLabIssueToIssueInfo(issue), nil
+}
+
+var gitlabIssueStates = map[vcsutils.IssueState]string{
+	vcsutils.IssueOpen:   "opened",
+	vcsutils.IssueClosed: "closed",
+}
+
+// ListIssues on GitLab
+func (client *GitLabClient) ListIssues(ctx context.Context, owner, repository string, options ListIssuesOptions) ([]IssueInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return nil, err
+	}
+	if err = validateIssueState(options.State); err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	listOptions := &gitlab.ListProjectIssuesOptions{ListOptions: gitlab.ListOptions{Page: page, PerPage: perPage}}
+	if options.State != "" {
+		listOptions.State = vcsutils.PointerOf(gitlabIssueStates[options.State])
+	}
+	if len(options.Labels) > 0 {
+		listOptions.Labels = vcsutils.PointerOf(gitlab.LabelOptions(options.Labels))
+	}
+	if options.Author != "" {
+		listOptions.AuthorUsername = &options.Author
+	}
+	issues, _, err := client.glClient.Issues.ListProjectIssues(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var results []IssueInfo
+	for _, issue := range issues {
+		results = append(results, mapGitLabIssueToIssueInfo(issue))
+	}
+	return results, nil
+}
+
+// AddIssueComment on GitLab
+func (client *GitLabClient) AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "content": content})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Notes.CreateIssueNote(getProjectID(owner, repository), issueID, &gitlab.CreateIssueNoteOptions{
+		Body: &content,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+// CloseIssue on GitLab
+func (client *GitLabClient) CloseIssue(ctx context.Context, owner, repository string, issueID int) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+	if err != nil {
+		return err
+	}
+	_, _, err = client.glClient.Issues.UpdateIssue(getProjectID(owner, repository), issueID, &gitlab.UpdateIssueOptions{
+		StateEvent: vcsutils.PointerOf("close"),
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
+func mapGitLabIssueToIssueInfo(issue *gitlab.Issue) IssueInfo {
+	issueInfo := IssueInfo{
+		ID:     int64(issue.IID),
+		Title:  issue.Title,
+		Body:   issue.Description,
+		URL:    issue.WebURL,
+		State:  vcsutils.IssueClosed,
+		Labels: issue.Labels,
+	}
+	if issue.State == gitlabIssueStates[vcsutils.IssueOpen] {
+		issueInfo.State = vcsutils.IssueOpen
+	}
+	if issue.Author != nil {
+		issueInfo.Author = issue.Author.Username
+	}
+	return issueInfo
+}
+
+// UploadCodeScanning on GitLab.
+// GitLab ingests SAST reports only as artifacts of CI jobs, so the analysis result is set as a status of the latest commit of the branch,
+// and each finding is added as a discussion to the open merge requests from the branch.
+// The returned ID is the hash of the commit.
+func (client *GitLabClient) UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error) {
+	analysis, err := parseCodeScanningAnalysis(scanResults)
+	if err != nil {
+		return "", err
+	}
+	commit, err := client.GetLatestCommit(ctx, owner, repository, branch)
+	if err != nil {
+		return "", err
+	}
+	client.logger.Debug(vcsutils.UploadingCodeScanning, repository, "/", branch)
+
+	status := Pass
+	if len(analysis.Findings) > 0 {
+		status = Fail
+	}
+	if err = client.SetCommitStatus(ctx, status, owner, repository, commit.Hash, analysis.ToolName, analysis.getDetails(), ""); err != nil {
+		return "", err
+	}
+	if len(analysis.Findings) == 0 {
+		return commit.Hash, nil
+	}
+
+	mergeRequests, err := client.ListOpenPullRequestsWithOptions(ctx, owner, repository, ListPullRequestsOptions{SourceBranch: branch})
+	if err != nil {
+		return "", err
+	}
+	for _, mergeRequest := range mergeRequests {
+		if err = client.addCodeScanningFindingComments(ctx, owner, repository, int(mergeRequest.ID), analysis.Findings); err != nil {
+			return "", err
+		}
+	}
+	return commit.Hash, nil
+}
+
+// addCodeScanningFindingComments adds a discussion on the line of each finding located in a file changed by the merge request,
+// and a general comment for the rest of the findings, since GitLab can't position discussions outside the merge request diff.
+func (client *GitLabClient) addCodeScanningFindingComments(ctx context.Context, owner, repository string, mergeRequestID int, findings []codeScanningFinding) error {
+	projectID := getProjectID(owner, repository)
+	versions, err := client.getMergeRequestDiffVersions(ctx, projectID, mergeRequestID)
+	if err != nil {
+		return fmt.Errorf("could not get merge request diff versions: %w", err)
+	}
+	mergeRequestChanges, err := client.getMergeRequestDiff(ctx, projectID, mergeRequestID)
+	if err != nil {
+		return fmt.Errorf("could not get merge request changes: %w", err)
+	}
+	changedFiles := datastructures.MakeSet[string]()
+	for _, diff := range mergeRequestChanges {
+		changedFiles.Add(diff.NewPath)
+	}
+
+	for _, finding := range findings {
+		content := fmt.Sprintf("**%s** %s", finding.Severity, finding.getSummary())
+		if len(versions) == 0 || finding.Line == 0 || !changedFiles.Exists(finding.FilePath) {
+			err = client.AddPullRequestComment(ctx, owner, repository, content, mergeRequestID)
+		} else {
+			err = client.addPullRequestReviewComment(ctx, projectID, mergeRequestID, PullRequestComment{
+				CommentInfo:     CommentInfo{Content: content},
+				PullRequestDiff: PullRequestDiff{NewFilePath: finding.FilePath, NewStartLine: finding.Line},
+			}, versions, mergeRequestChanges)
+		}
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-// DeletePullRequestComment on GitLab
-func (client *GitLabClient) DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error {
-	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
-		return err
-	}
-	if _, err := client.glClient.Notes.DeleteMergeRequestNote(getProjectID(owner, repository), pullRequestID, commentID, gitlab.WithContext(ctx)); err != nil {
-		return fmt.Errorf("an error occurred while deleting pull request comment:\n%s", err.Error())
-	}
-	return nil
+// GetRepositoryEnvironmentInfo on GitLab
+func (client *GitLabClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errGitLabGetRepoEnvironmentInfoNotSupported
 }
 
-// GetLatestCommit on GitLab
-func (client *GitLabClient) GetLatestCommit(ctx context.Context, owner, repository, branch string) (CommitInfo, error) {
-	commits, err := client.GetCommits(ctx, owner, repository, branch)
+// ListEnvironments on GitLab.
+// Environment reviewers are not available on GitLab.
+func (client *GitLabClient) ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
-		return CommitInfo{}, err
+		return nil, err
 	}
-
-	if len(commits) > 0 {
-		return commits[0], nil
+	var environments []RepositoryEnvironmentInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListEnvironmentsOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		glEnvironments, glResponse, err := client.glClient.Environments.ListEnvironments(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range glEnvironments {
+			environments = append(environments, RepositoryEnvironmentInfo{Name: environment.Name, Url: environment.ExternalURL})
+		}
+		nextPage = glResponse.NextPage
 	}
-
-	return CommitInfo{}, fmt.Errorf("no commits were returned for <%s/%s/%s>", owner, repository, branch)
+	return environments, nil
 }
 
-// GetCommits on GitLab
-func (client *GitLabClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"branch":     branch,
-	})
+// CreateDeployment on GitLab.
+// GitLab deployments are created on a commit, so the ref is resolved to its latest commit first.
+func (client *GitLabClient) CreateDeployment(ctx context.Context, owner, repository, ref, environment, _ string) (DeploymentInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref, "environment": environment})
 	if err != nil {
-		return nil, err
+		return DeploymentInfo{}, err
 	}
-
-	listOptions := &gitlab.ListCommitsOptions{
-		RefName: &branch,
-		ListOptions: gitlab.ListOptions{
-			Page:    1,
-			PerPage: vcsutils.NumberOfCommitsToFetch,
-		},
+	projectID := getProjectID(owner, repository)
+	commit, _, err := client.glClient.Commits.GetCommit(projectID, ref, gitlab.WithContext(ctx))
+	if err != nil {
+		return DeploymentInfo{}, err
 	}
-
-	commits, _, err := client.glClient.Commits.ListCommits(getProjectID(owner, repository), listOptions, gitlab.WithContext(ctx))
+	deployment, _, err := client.glClient.Deployments.CreateProjectDeployment(projectID, &gitlab.CreateProjectDeploymentOptions{
+		Environment: &environment,
+		Ref:         &ref,
+		SHA:         &commit.ID,
+		Status:      gitlab.DeploymentStatus(gitlab.DeploymentStatusCreated),
+	}, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, err
+		return DeploymentInfo{}, err
 	}
-
-	var commitsInfo []CommitInfo
-	for _, commit := range commits {
-		commitInfo := mapGitLabCommitToCommitInfo(commit)
-		commitsInfo = append(commitsInfo, commitInfo)
+	deploymentInfo := DeploymentInfo{
+		ID:        strconv.Itoa(deployment.ID),
+		Ref:       deployment.Ref,
+		CommitSHA: deployment.SHA,
+		CreatedAt: extractTimeWithFallback(deployment.CreatedAt),
 	}
-	return commitsInfo, nil
+	if deployment.Environment != nil {
+		deploymentInfo.Environment = deployment.Environment.Name
+	}
+	return deploymentInfo, nil
 }
 
-// GetRepositoryInfo on GitLab
-func (client *GitLabClient) GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
+// SetDeploymentStatus on GitLab.
+// The environment URL is set as the external URL of the deployment's environment.
+func (client *GitLabClient) SetDeploymentStatus(ctx context.Context, owner, repository, deploymentID string, state DeploymentState, _, environmentURL string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "deploymentID": deploymentID})
 	if err != nil {
-		return RepositoryInfo{}, err
+		return err
 	}
-
-	project, _, err := client.glClient.Projects.GetProject(getProjectID(owner, repository), nil, gitlab.WithContext(ctx))
+	id, err := strconv.Atoi(deploymentID)
 	if err != nil {
-		return RepositoryInfo{}, err
+		return fmt.Errorf("invalid deployment ID '%s': %w", deploymentID, err)
 	}
-
-	return RepositoryInfo{RepositoryVisibility: getGitLabProjectVisibility(project), CloneInfo: CloneInfo{HTTP: project.HTTPURLToRepo, SSH: project.SSHURLToRepo}}, nil
-}
-
-// GetCommitBySha on GitLab
-func (client *GitLabClient) GetCommitBySha(ctx context.Context, owner, repository, sha string) (CommitInfo, error) {
-	err := validateParametersNotBlank(map[string]string{
-		"owner":      owner,
-		"repository": repository,
-		"sha":        sha,
-	})
-	if err != nil {
-		return CommitInfo{}, err
+	projectID := getProjectID(owner, repository)
+	deployment, _, err := client.glClient.Deployments.UpdateProjectDeployment(projectID, id,
+		&gitlab.UpdateProjectDeploymentOptions{Status: gitlab.DeploymentStatus(getGitLabDeploymentStatus(state))}, gitlab.WithContext(ctx))
+	if err != nil || environmentURL == "" {
+		return err
+	}
+	if deployment.Environment == nil {
+		return fmt.Errorf("deployment %s has no environment to set the URL of", deploymentID)
 	}
+	_, _, err = client.glClient.Environments.EditEnvironment(projectID, deployment.Environment.ID,
+		&gitlab.EditEnvironmentOptions{ExternalURL: &environmentURL}, gitlab.WithContext(ctx))
+	return err
+}
 
-	commit, _, err := client.glClient.Commits.GetCommit(getProjectID(owner, repository), sha, gitlab.WithContext(ctx))
-	if err != nil {
-		return CommitInfo{}, err
+func getGitLabDeploymentStatus(state DeploymentState) gitlab.DeploymentStatusValue {
+	switch state {
+	case DeploymentInProgress:
+		return gitlab.DeploymentStatusRunning
+	case DeploymentSuccess:
+		return gitlab.DeploymentStatusSuccess
+	case DeploymentFailure:
+		return gitlab.DeploymentStatusFailed
 	}
-	return mapGitLabCommitToCommitInfo(commit), nil
+	return gitlab.DeploymentStatusCreated
 }
 
-// CreateLabel on GitLab
-func (client *GitLabClient) CreateLabel(ctx context.Context, owner, repository string, labelInfo LabelInfo) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "LabelInfo.name": labelInfo.Name})
+// CreateOrUpdateRepositorySecret on GitLab.
+// The secret is a masked CI/CD variable of the project, hence its value must meet the masking requirements of GitLab.
+func (client *GitLabClient) CreateOrUpdateRepositorySecret(ctx context.Context, owner, repository, name, value string) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name, "value": value})
 	if err != nil {
 		return err
 	}
-
-	_, _, err = client.glClient.Labels.CreateLabel(getProjectID(owner, repository), &gitlab.CreateLabelOptions{
-		Name:        &labelInfo.Name,
-		Description: &labelInfo.Description,
-		Color:       &labelInfo.Color,
-	}, gitlab.WithContext(ctx))
-
+	projectID := getProjectID(owner, repository)
+	_, glResponse, err := client.glClient.ProjectVariables.UpdateVariable(projectID, name,
+		&gitlab.UpdateProjectVariableOptions{Value: &value, Masked: gitlab.Bool(true)}, gitlab.WithContext(ctx))
+	if err == nil || glResponse == nil || glResponse.StatusCode != http.StatusNotFound {
+		return err
+	}
+	_, _, err = client.glClient.ProjectVariables.CreateVariable(projectID,
+		&gitlab.CreateProjectVariableOptions{Key: &name, Value: &value, Masked: gitlab.Bool(true)}, gitlab.WithContext(ctx))
 	return err
 }
 
-// GetLabel on GitLub
-func (client *GitLabClient) GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "name": name})
-	if err != nil {
-		return nil, err
-	}
-
-	labels, _, err := client.glClient.Labels.ListLabels(getProjectID(owner, repository), &gitlab.ListLabelsOptions{}, gitlab.WithContext(ctx))
+// ListRepositoryVariables on GitLab.
+// Masked variables are returned as secrets.
+func (client *GitLabClient) ListRepositoryVariables(ctx context.Context, owner, repository string) ([]RepositoryVariableInfo, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
 	if err != nil {
 		return nil, err
 	}
-
-	for _, label := range labels {
-		if label.Name == name {
-			return &LabelInfo{
-				Name:        label.Name,
-				Description: label.Description,
-				Color:       strings.TrimPrefix(label.Color, "#"),
-			}, err
+	var variables []RepositoryVariableInfo
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListProjectVariablesOptions{Page: nextPage, PerPage: 100}
+		projectVariables, glResponse, err := client.glClient.ProjectVariables.ListVariables(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
 		}
+		for _, projectVariable := range projectVariables {
+			variable := RepositoryVariableInfo{Name: projectVariable.Key, IsSecret: projectVariable.Masked}
+			if !projectVariable.Masked {
+				variable.Value = projectVariable.Value
+			}
+			variables = append(variables, variable)
+		}
+		nextPage = glResponse.NextPage
 	}
+	return variables, nil
+}
 
-	return nil, nil
+// CreateOrUpdateOrganizationSecret on GitLab
+func (client *GitLabClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return errGitLabSecretsNotSupported
 }
 
-// ListPullRequestLabels on GitLab
-func (client *GitLabClient) ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error) {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
-	if err != nil {
-		return []string{}, err
-	}
-	mergeRequest, _, err := client.glClient.MergeRequests.GetMergeRequest(getProjectID(owner, repository), pullRequestID,
-		&gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
-	if err != nil {
-		return []string{}, err
-	}
+// ListOrganizationSecrets on GitLab
+func (client *GitLabClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, errGitLabSecretsNotSupported
+}
 
-	return mergeRequest.Labels, nil
+// ListOrganizationSecretRepositories on GitLab
+func (client *GitLabClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, errGitLabSecretsNotSupported
 }
 
-// UnlabelPullRequest on GitLab
-func (client *GitLabClient) UnlabelPullRequest(ctx context.Context, owner, repository, label string, pullRequestID int) error {
-	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository})
-	if err != nil {
-		return err
-	}
-	labels := gitlab.LabelOptions{label}
-	_, _, err = client.glClient.MergeRequests.UpdateMergeRequest(getProjectID(owner, repository), pullRequestID, &gitlab.UpdateMergeRequestOptions{
-		RemoveLabels: &labels,
-	}, gitlab.WithContext(ctx))
-	return err
+// DeleteOrganizationSecret on GitLab
+func (client *GitLabClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return errGitLabSecretsNotSupported
 }
 
-// UploadCodeScanning on GitLab
-func (client *GitLabClient) UploadCodeScanning(_ context.Context, _ string, _ string, _ string, _ string) (string, error) {
-	return "", errGitLabCodeScanningNotSupported
+// CreateOrUpdateEnvironmentSecret on GitLab
+func (client *GitLabClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return errGitLabSecretsNotSupported
 }
 
-// GetRepositoryEnvironmentInfo on GitLab
-func (client *GitLabClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
-	return RepositoryEnvironmentInfo{}, errGitLabGetRepoEnvironmentInfoNotSupported
+// ListEnvironmentSecrets on GitLab
+func (client *GitLabClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, errGitLabSecretsNotSupported
+}
+
+// DeleteEnvironmentSecret on GitLab
+func (client *GitLabClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return errGitLabSecretsNotSupported
+}
+
+// CreateCodeInsightsReport on GitLab
+func (client *GitLabClient) CreateCodeInsightsReport(_ context.Context, _, _, _ string, _ CodeInsightsReport) error {
+	return errGitLabCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on GitLab
+func (client *GitLabClient) AddCodeInsightsAnnotations(_ context.Context, _, _, _, _ string, _ []CodeInsightsAnnotation) error {
+	return errGitLabCodeInsightsNotSupported
+}
+
+// CreateCheckRun on GitLab
+func (client *GitLabClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errGitLabCheckRunsNotSupported
+}
+
+// UpdateCheckRun on GitLab
+func (client *GitLabClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errGitLabCheckRunsNotSupported
 }
 
 // DownloadFileFromRepo on GitLab
@@ -678,6 +2241,63 @@ func (client *GitLabClient) DownloadFileFromRepo(_ context.Context, owner, repos
 	return content, statusCode, err
 }
 
+// ListDirectoryContents on GitLab
+func (client *GitLabClient) ListDirectoryContents(ctx context.Context, owner, repository, ref, path string) ([]DirectoryEntry, error) {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "ref": ref})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []DirectoryEntry
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListTreeOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}, Path: &path, Ref: &ref}
+		treeNodes, glResponse, err := client.glClient.Repositories.ListTree(getProjectID(owner, repository), options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, treeNode := range treeNodes {
+			entries = append(entries, DirectoryEntry{Name: treeNode.Name, Path: treeNode.Path, IsDir: treeNode.Type == "tree"})
+		}
+		nextPage = glResponse.NextPage
+	}
+	return entries, nil
+}
+
+// CommitFiles on GitLab
+func (client *GitLabClient) CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, files []FileToCommit) error {
+	err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository, "branch": branch, "commitMessage": commitMessage})
+	if err != nil {
+		return err
+	}
+	if err = validateFilesToCommit(files); err != nil {
+		return err
+	}
+
+	actions := make([]*gitlab.CommitActionOptions, 0, len(files))
+	for _, file := range files {
+		action := &gitlab.CommitActionOptions{FilePath: gitlab.String(file.Path)}
+		switch file.ChangeType {
+		case FileAdded:
+			action.Action = gitlab.FileAction(gitlab.FileCreate)
+		case FileDeleted:
+			action.Action = gitlab.FileAction(gitlab.FileDelete)
+		default:
+			action.Action = gitlab.FileAction(gitlab.FileUpdate)
+		}
+		if file.ChangeType != FileDeleted {
+			action.Content = gitlab.String(base64.StdEncoding.EncodeToString(file.Content))
+			action.Encoding = gitlab.String("base64")
+		}
+		actions = append(actions, action)
+	}
+	_, _, err = client.glClient.Commits.CreateCommit(getProjectID(owner, repository), &gitlab.CreateCommitOptions{
+		Branch:        &branch,
+		CommitMessage: &commitMessage,
+		Actions:       actions,
+	}, gitlab.WithContext(ctx))
+	return err
+}
+
 func (client *GitLabClient) GetModifiedFiles(_ context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
 	if err := validateParametersNotBlank(map[string]string{
 		"owner":      owner,
@@ -709,6 +2329,121 @@ func (client *GitLabClient) GetModifiedFiles(_ context.Context, owner, repositor
 	return fileNamesList, nil
 }
 
+// CompareCommits on GitLab
+func (client *GitLabClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	if err := validateParametersNotBlank(map[string]string{
+		"owner":      owner,
+		"repository": repository,
+		"base":       base,
+		"head":       head,
+	}); err != nil {
+		return CommitsComparison{}, err
+	}
+
+	compare, _, err := client.glClient.Repositories.Compare(
+		getProjectID(owner, repository),
+		&gitlab.CompareOptions{From: &base, To: &head},
+		gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+
+	var commitsComparison CommitsComparison
+	for _, commit := range compare.Commits {
+		commitsComparison.Commits = append(commitsComparison.Commits, mapGitLabCommitToCommitInfo(commit))
+	}
+	for _, diff := range compare.Diffs {
+		commitsComparison.Files = append(commitsComparison.Files, mapGitLabDiffToPullRequestFile(&gitlab.MergeRequestDiff{
+			OldPath:     diff.OldPath,
+			NewPath:     diff.NewPath,
+			NewFile:     diff.NewFile,
+			RenamedFile: diff.RenamedFile,
+			DeletedFile: diff.DeletedFile,
+		}))
+	}
+	return commitsComparison, nil
+}
+
+// ListPullRequestFiles on GitLab
+func (client *GitLabClient) ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return nil, err
+	}
+
+	var files []PullRequestFile
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListMergeRequestDiffsOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		diffs, glResponse, err := client.glClient.MergeRequests.ListMergeRequestDiffs(getProjectID(owner, repository), pullRequestID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, diff := range diffs {
+			files = append(files, mapGitLabDiffToPullRequestFile(diff))
+		}
+		nextPage = glResponse.NextPage
+	}
+	return files, nil
+}
+
+func mapGitLabDiffToPullRequestFile(diff *gitlab.MergeRequestDiff) PullRequestFile {
+	switch {
+	case diff.NewFile:
+		return PullRequestFile{Path: diff.NewPath, ChangeType: FileAdded}
+	case diff.DeletedFile:
+		return PullRequestFile{Path: diff.OldPath, ChangeType: FileDeleted}
+	case diff.RenamedFile:
+		return PullRequestFile{Path: diff.NewPath, PreviousPath: diff.OldPath, ChangeType: FileRenamed}
+	default:
+		return PullRequestFile{Path: diff.NewPath, ChangeType: FileModified}
+	}
+}
+
+// GetPullRequestDiff on GitLab
+func (client *GitLabClient) GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error) {
+	if err := validateParametersNotBlank(map[string]string{"owner": owner, "repository": repository}); err != nil {
+		return "", err
+	}
+
+	var diff strings.Builder
+	for nextPage := 1; nextPage != 0; {
+		options := &gitlab.ListMergeRequestDiffsOptions{ListOptions: gitlab.ListOptions{Page: nextPage, PerPage: 100}}
+		diffs, glResponse, err := client.glClient.MergeRequests.ListMergeRequestDiffs(getProjectID(owner, repository), pullRequestID, options, gitlab.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+		for _, fileDiff := range diffs {
+			writeGitLabFileDiff(&diff, fileDiff)
+		}
+		nextPage = glResponse.NextPage
+	}
+	return diff.String(), nil
+}
+
+// GitLab returns only the hunks of each file, so the git headers are added to produce a unified diff
+func writeGitLabFileDiff(diff *strings.Builder, fileDiff *gitlab.MergeRequestDiff) {
+	fromPath, toPath := "a/"+fileDiff.OldPath, "b/"+fileDiff.NewPath
+	diff.WriteString(fmt.Sprintf("diff --git %s %s\n", fromPath, toPath))
+	switch {
+	case fileDiff.NewFile:
+		diff.WriteString(fmt.Sprintf("new file mode %s\n", fileDiff.BMode))
+		fromPath = "/dev/null"
+	case fileDiff.DeletedFile:
+		diff.WriteString(fmt.Sprintf("deleted file mode %s\n", fileDiff.AMode))
+		toPath = "/dev/null"
+	case fileDiff.RenamedFile:
+		diff.WriteString(fmt.Sprintf("rename from %s\nrename to %s\n", fileDiff.OldPath, fileDiff.NewPath))
+	}
+	if fileDiff.Diff == "" {
+		return
+	}
+	diff.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", fromPath, toPath))
+	diff.WriteString(fileDiff.Diff)
+	if !strings.HasSuffix(fileDiff.Diff, "\n") {
+		diff.WriteString("\n")
+	}
+}
+
 func getProjectID(owner, project string) string {
 	return fmt.Sprintf("%s/%s", owner, project)
 }
@@ -717,18 +2452,63 @@ func createProjectHook(branch string, payloadURL string, webhookEvents ...vcsuti
 	options := &gitlab.ProjectHook{URL: payloadURL}
 	for _, webhookEvent := range webhookEvents {
 		switch webhookEvent {
-		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrRejected, vcsutils.PrMerged:
+		case vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrRejected, vcsutils.PrMerged, vcsutils.PrClosed, vcsutils.PrReopened:
 			options.MergeRequestsEvents = true
 		case vcsutils.Push:
 			options.PushEvents = true
 			options.PushEventsBranchFilter = branch
+		case vcsutils.BranchDeleted:
+			options.PushEvents = true
 		case vcsutils.TagPushed, vcsutils.TagRemoved:
 			options.TagPushEvents = true
+		case vcsutils.PrCommentCreated, vcsutils.PrCommentEdited, vcsutils.IssueCommentCreated:
+			options.NoteEvents = true
+		case vcsutils.ReleaseCreated, vcsutils.ReleasePublished:
+			options.ReleasesEvents = true
 		}
 	}
 	return options
 }
 
+// GitLab project hooks are always active, since GitLab doesn't support disabling them
+func mapGitLabProjectHookToWebhookInfo(hook *gitlab.ProjectHook) WebhookInfo {
+	return WebhookInfo{
+		ID:         strconv.Itoa(hook.ID),
+		PayloadURL: hook.URL,
+		Events: getSubscribedWebhookEvents(getGitLabProjectHookEvents(hook), func(webhookEvents ...vcsutils.WebhookEvent) []string {
+			return getGitLabProjectHookEvents(createProjectHook("", "", webhookEvents...))
+		}),
+		Active: true,
+	}
+}
+
+// getGitLabProjectHookEvents returns the events the project hook is triggered by, as named in the GitLab API
+func getGitLabProjectHookEvents(hook *gitlab.ProjectHook) []string {
+	var events []string
+	for event, enabled := range map[string]bool{
+		"push_events":           hook.PushEvents,
+		"tag_push_events":       hook.TagPushEvents,
+		"merge_requests_events": hook.MergeRequestsEvents,
+		"note_events":           hook.NoteEvents,
+		"releases_events":       hook.ReleasesEvents,
+	} {
+		if enabled {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func mapGitLabProjectToRepositoryInfo(project *gitlab.Project) RepositoryInfo {
+	return RepositoryInfo{
+		RepositoryVisibility: getGitLabProjectVisibility(project),
+		CloneInfo:            CloneInfo{HTTP: project.HTTPURLToRepo, SSH: project.SSHURLToRepo},
+		DefaultBranch:        project.DefaultBranch,
+		Archived:             project.Archived,
+		Description:          project.Description,
+	}
+}
+
 func getGitLabProjectVisibility(project *gitlab.Project) RepositoryVisibility {
 	switch project.Visibility {
 	case gitlab.PublicVisibility:
@@ -767,14 +2547,32 @@ func mapGitLabCommitToCommitInfo(commit *gitlab.Commit) CommitInfo {
 	}
 }
 
+// mapGitLabNotesToCommentInfoList maps the notes of a discussion, whose first note is replied to by the rest of the notes
 func mapGitLabNotesToCommentInfoList(notes []*gitlab.Note, discussionId string) (res []CommentInfo) {
-	for _, note := range notes {
-		res = append(res, CommentInfo{
-			ID:       int64(note.ID),
-			ThreadID: discussionId,
-			Content:  note.Body,
-			Created:  *note.CreatedAt,
-		})
+	for i, note := range notes {
+		commentInfo := CommentInfo{
+			ID:             int64(note.ID),
+			NativeID:       strconv.Itoa(note.ID),
+			ThreadID:       discussionId,
+			Content:        note.Body,
+			Author:         note.Author.Name,
+			AuthorUsername: note.Author.Username,
+			Created:        *note.CreatedAt,
+		}
+		if discussionId != "" && i > 0 {
+			commentInfo.ParentID = strconv.Itoa(notes[0].ID)
+		}
+		if note.UpdatedAt != nil {
+			commentInfo.Updated = *note.UpdatedAt
+		}
+		if note.Position != nil {
+			commentInfo.FilePath, commentInfo.Line = note.Position.NewPath, note.Position.NewLine
+			if commentInfo.Line == 0 {
+				// Comments on removed lines are anchored to the original file only
+				commentInfo.FilePath, commentInfo.Line = note.Position.OldPath, note.Position.OldLine
+			}
+		}
+		res = append(res, commentInfo)
 	}
 	return
 }
@@ -803,9 +2601,20 @@ func (client *GitLabClient) mapGitLabMergeRequestToPullRequestInfo(mergeRequest
 		}
 	}
 
+	var author string
+	if mergeRequest.Author != nil {
+		author = mergeRequest.Author.Username
+	}
+
 	return PullRequestInfo{
-		ID:   int64(mergeRequest.IID),
-		Body: body,
+		ID:        int64(mergeRequest.IID),
+		Title:     mergeRequest.Title,
+		Body:      body,
+		Author:    author,
+		State:     mapGitLabMergeRequestState(mergeRequest.State),
+		Mergeable: isGitLabMergeRequestMergeable(mergeRequest),
+		CreatedAt: extractTimeWithFallback(mergeRequest.CreatedAt),
+		UpdatedAt: extractTimeWithFallback(mergeRequest.UpdatedAt),
 		Source: BranchInfo{
 			Name:       mergeRequest.SourceBranch,
 			Repository: repository,
@@ -848,3 +2657,24 @@ func mapGitLabPullRequestState(state *vcsutils.PullRequestState) *string {
 	}
 	return &stateStringValue
 }
+
+func mapGitLabMergeRequestState(state string) vcsutils.PullRequestState {
+	switch state {
+	case "opened":
+		return vcsutils.Open
+	case "merged":
+		return vcsutils.Merged
+	case "closed", "locked":
+		return vcsutils.Closed
+	default:
+		return ""
+	}
+}
+
+func isGitLabMergeRequestMergeable(mergeRequest *gitlab.MergeRequest) bool {
+	// The detailed merge status was introduced in GitLab 15.6, older versions report the deprecated merge status
+	if mergeRequest.DetailedMergeStatus != "" {
+		return mergeRequest.DetailedMergeStatus == "mergeable"
+	}
+	return mergeRequest.MergeStatus == "can_be_merged"
+}
@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -53,6 +54,49 @@ func TestGitLabClient_ConnectionWhenContextTimesOut(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
+func TestGitLabClient_TestConnectionWithScopes(t *testing.T) {
+	ctx := context.Background()
+	response := gitlab.PersonalAccessToken{Scopes: []string{"read_api", "read_repository"}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, "/api/v4/personal_access_tokens/self", createGitLabHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope))
+	assert.EqualError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope, RepositoryWriteScope, WebhookAdminScope),
+		"the token is missing the following scopes: repository write, webhook admin")
+}
+
+func TestGitLabClient_GetAuthenticatedUser(t *testing.T) {
+	ctx := context.Background()
+	response := gitlab.User{Username: "frogger", Name: "Frog Ger", Email: "frogger@jfrog.com"}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, "/api/v4/user", createGitLabHandler)
+	defer cleanUp()
+
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: "frogger", DisplayName: "Frog Ger", Email: "frogger@jfrog.com"}, user)
+}
+
+func TestGitLabClient_JobToken(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET /api/v4/job", r.Method+" "+r.RequestURI)
+		assert.Equal(t, "job-token", r.Header.Get("JOB-TOKEN"))
+		assert.Empty(t, r.Header.Get("PRIVATE-TOKEN"))
+		_, err := w.Write([]byte(`{"id": 1, "user": {"username": "frogger", "name": "Frog Ger"}}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client, err := NewClientBuilder(vcsutils.GitLab).ApiEndpoint(server.URL).GitLabJobToken("job-token").Build()
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.TestConnection(ctx))
+	assert.NoError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope))
+	assert.EqualError(t, client.TestConnectionWithScopes(ctx, RepositoryReadScope, WebhookAdminScope), "the token is missing the following scopes: webhook admin")
+	user, err := client.GetAuthenticatedUser(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, UserInfo{Username: "frogger", DisplayName: "Frog Ger"}, user)
+}
+
 func TestGitLabClient_ListRepositories(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "projects_response.json"))
@@ -71,9 +115,50 @@ func TestGitLabClient_ListRepositories(t *testing.T) {
 	}, actualRepositories)
 }
 
+func TestGitLabClient_ListRepositoriesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	projects := []gitlab.Project{{Path: repo1, Namespace: &gitlab.ProjectNamespace{Path: owner, FullPath: owner}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, projects, "/api/v4/projects?membership=true&page=3&per_page=10&simple=true", createGitLabHandler)
+	defer cleanUp()
+
+	actualRepositories, err := client.ListRepositoriesWithOptions(ctx, ListOptions{Page: 3, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{owner: {repo1}}, actualRepositories)
+}
+
+func TestGitLabClient_ListGroupRepositories(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		membershipOnly bool
+		expectedURI    string
+	}{
+		{name: "allProjects", expectedURI: "/api/v4/groups/org%2Fteam/projects?include_subgroups=true&page=1&simple=true"},
+		{name: "membershipOnly", membershipOnly: true, expectedURI: "/api/v4/groups/org%2Fteam/projects?include_subgroups=true&min_access_level=10&page=1&simple=true"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedURI, r.RequestURI)
+				_, err := w.Write([]byte(`[{"path": "repo-1", "namespace": {"path": "team", "full_path": "org/team"}},` +
+					`{"path": "repo-2", "namespace": {"path": "sub", "full_path": "org/team/sub"}},` +
+					`{"path": "repo-3", "namespace": {"path": "sub", "full_path": "org/team/sub"}}]`))
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+			client, err := NewClientBuilder(vcsutils.GitLab).ApiEndpoint(server.URL).Token(token).GitLabGroup("org/team", test.membershipOnly).Build()
+			assert.NoError(t, err)
+
+			actualRepositories, err := client.ListRepositories(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string][]string{"org/team": {"repo-1"}, "org/team/sub": {"repo-2", "repo-3"}}, actualRepositories)
+		})
+	}
+}
+
 func TestGitLabClient_ListBranches(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []gitlab.Branch{{Name: branch1}, {Name: branch2}}, fmt.Sprintf("/api/v4/projects/%s/repository/branches", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []gitlab.Branch{{Name: branch1}, {Name: branch2}}, fmt.Sprintf("/api/v4/projects/%s/repository/branches?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
 	defer cleanUp()
 
 	actualRepositories, err := client.ListBranches(ctx, owner, repo1)
@@ -81,6 +166,207 @@ func TestGitLabClient_ListBranches(t *testing.T) {
 	assert.ElementsMatch(t, actualRepositories, []string{branch1, branch2})
 }
 
+func TestGitLabClient_ListBranchesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []gitlab.Branch{{Name: branch2}}, fmt.Sprintf("/api/v4/projects/%s/repository/branches?page=2&per_page=1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	actualBranches, err := client.ListBranchesWithOptions(ctx, owner, repo1, ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch2}, actualBranches)
+}
+
+func TestGitLabClient_CreateBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, gitlab.Branch{Name: "new-branch"}, fmt.Sprintf("/api/v4/projects/%s/repository/branches", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	err := client.CreateBranch(ctx, owner, repo1, branch1, "new-branch")
+	assert.NoError(t, err)
+
+	err = client.CreateBranch(ctx, owner, repo1, "", "new-branch")
+	assert.EqualError(t, err, "validation failed: required parameter 'sourceRef' is missing")
+}
+
+func TestGitLabClient_DeleteBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, fmt.Sprintf("/api/v4/projects/%s/repository/branches/%s", url.PathEscape(owner+"/"+repo1), branch1), createGitLabHandler)
+	defer cleanUp()
+
+	err := client.DeleteBranch(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+
+	err = client.DeleteBranch(ctx, owner, repo1, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'branch' is missing")
+}
+
+func TestGitLabClient_GetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "repository_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, "/api/v4/projects/"+url.PathEscape(owner+"/"+repo1), createGitLabHandler)
+	defer cleanUp()
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, "master", defaultBranch)
+
+	_, err = client.GetDefaultBranch(ctx, owner, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'repository' is missing")
+}
+
+func TestGitLabClient_SetDefaultBranch(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Project{}, "/api/v4/projects/"+url.PathEscape(owner+"/"+repo1),
+		http.StatusOK, []byte(`{"default_branch":"develop"}`), http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetDefaultBranch(ctx, owner, repo1, "develop")
+	assert.NoError(t, err)
+
+	err = client.SetDefaultBranch(ctx, owner, repo1, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'branch' is missing")
+}
+
+func TestGitLabClient_GetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "", createBranchProtectionGitLabHandler)
+	defer cleanUp()
+
+	protection, err := client.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{RequiredApprovals: 2, RestrictPushes: true}, protection)
+
+	unprotectedClient, unprotectedCleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, []byte(`{"message": "404 Not found"}`),
+		fmt.Sprintf("/api/v4/projects/%s/protected_branches/%s", url.PathEscape(owner+"/"+repo1), branch1), http.StatusNotFound, createGitLabHandler)
+	defer unprotectedCleanUp()
+	protection, err = unprotectedClient.GetBranchProtection(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, BranchProtectionInfo{}, protection)
+}
+
+func TestGitLabClient_SetBranchProtection(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "", createBranchProtectionGitLabHandler)
+	defer cleanUp()
+
+	err := client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredApprovals: 2, RestrictPushes: true})
+	assert.NoError(t, err)
+
+	err = client.SetBranchProtection(ctx, owner, repo1, branch1, BranchProtectionInfo{RequiredStatusChecks: []string{"frogbot"}})
+	assert.EqualError(t, err, "required status checks are not supported on GitLab")
+}
+
+func TestGitLabClient_ListTags(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false,
+		[]gitlab.Tag{{Name: "v1.0.0", Commit: &gitlab.Commit{ID: "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a"}}},
+		fmt.Sprintf("/api/v4/projects/%s/repository/tags?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []TagInfo{{Name: "v1.0.0", CommitSha: "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a"}}, tags)
+}
+
+func TestGitLabClient_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Tag{Name: "v1.0.0"},
+		fmt.Sprintf("/api/v4/projects/%s/repository/tags", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		[]byte(`{"tag_name":"v1.0.0","ref":"`+branch1+`","message":"Release 1.0.0"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CreateTag(ctx, owner, repo1, "v1.0.0", branch1, "Release 1.0.0")
+	assert.NoError(t, err)
+
+	err = client.CreateTag(ctx, owner, repo1, "", branch1, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'tagName' is missing")
+}
+
+func TestGitLabClient_GetTagInfo(t *testing.T) {
+	ctx := context.Background()
+	commitResponse, err := os.ReadFile(filepath.Join("testdata", "gitlab", "commit_single_response.json"))
+	assert.NoError(t, err)
+	response := []byte(`{"name": "v1.0.0", "commit": ` + string(commitResponse) + `}`)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/tags/v1.0.0", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.GetTagInfo(ctx, owner, repo1, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "ff4a54b88fbd387ac4d9e8cdeb54b049978e450a", result.Hash)
+	assert.Equal(t, "Initial commit", result.Message)
+}
+
+func TestGitLabClient_CreateRelease(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	response := gitlab.Release{TagName: "v1.0.0", Name: "Release 1.0.0", Description: "Release notes", CreatedAt: &createdAt}
+	response.Links.Self = "https://gitlab.com/jfrog/repo-1/-/releases/v1.0.0"
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/releases", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		[]byte(`{"name":"Release 1.0.0","tag_name":"v1.0.0","description":"Release notes"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	release, err := client.CreateRelease(ctx, owner, repo1, "v1.0.0", "Release 1.0.0", "Release notes")
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseInfo{
+		ID:        "v1.0.0",
+		Name:      "Release 1.0.0",
+		TagName:   "v1.0.0",
+		Body:      "Release notes",
+		URL:       "https://gitlab.com/jfrog/repo-1/-/releases/v1.0.0",
+		CreatedAt: createdAt,
+	}, release)
+}
+
+func TestGitLabClient_ListReleases(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false,
+		[]gitlab.Release{{TagName: "v1.0.1", Name: "Release 1.0.1"}, {TagName: "v1.0.0", Name: "Release 1.0.0"}},
+		fmt.Sprintf("/api/v4/projects/%s/releases?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	releases, err := client.ListReleases(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReleaseInfo{
+		{ID: "v1.0.1", Name: "Release 1.0.1", TagName: "v1.0.1"},
+		{ID: "v1.0.0", Name: "Release 1.0.0", TagName: "v1.0.0"},
+	}, releases)
+}
+
+func TestGitLabClient_GetLatestRelease(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false,
+		[]gitlab.Release{{TagName: "v1.0.1", Name: "Release 1.0.1"}},
+		fmt.Sprintf("/api/v4/projects/%s/releases?order_by=released_at&per_page=1&sort=desc", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	release, err := client.GetLatestRelease(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, ReleaseInfo{ID: "v1.0.1", Name: "Release 1.0.1", TagName: "v1.0.1"}, release)
+
+	emptyClient, emptyCleanUp := createServerAndClient(t, vcsutils.GitLab, false, []gitlab.Release{},
+		fmt.Sprintf("/api/v4/projects/%s/releases?order_by=released_at&per_page=1&sort=desc", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer emptyCleanUp()
+	_, err = emptyClient.GetLatestRelease(ctx, owner, repo1)
+	assert.EqualError(t, err, "couldn't find any release in repository repo-1")
+}
+
+func TestGitLabClient_UploadReleaseAsset(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "", createUploadReleaseAssetGitLabHandler)
+	defer cleanUp()
+
+	downloadURL, err := client.UploadReleaseAsset(ctx, owner, repo1, "v1.0.0", "app.zip", strings.NewReader("asset content"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/jfrog/repo-1/uploads/66dbcd21ec5d24ed6ea225176098d52b/app.zip", downloadURL)
+
+	_, err = client.UploadReleaseAsset(ctx, owner, repo1, "", "app.zip", strings.NewReader("asset content"))
+	assert.EqualError(t, err, "validation failed: required parameter 'releaseID' is missing")
+}
+
 func TestGitLabClient_CreateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int()
@@ -94,6 +380,45 @@ func TestGitLabClient_CreateWebhook(t *testing.T) {
 	assert.Equal(t, actualID, strconv.Itoa(id))
 }
 
+func TestGitLabClient_CreateOrUpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	existingID, createdID := rand.Int(), rand.Int()
+	hooksPath := fmt.Sprintf("/api/v4/projects/%s/hooks", url.PathEscape(owner+"/"+repo1))
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		var response interface{}
+		switch r.Method + " " + r.RequestURI {
+		case "GET " + hooksPath + "?page=1&per_page=100":
+			response = []*gitlab.ProjectHook{{ID: existingID, URL: "https://jfrog.com/existing"}}
+		case fmt.Sprintf("PUT %s/%d", hooksPath, existingID):
+			response = gitlab.ProjectHook{ID: existingID}
+		case "POST " + hooksPath:
+			response = gitlab.ProjectHook{ID: createdID}
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	// An existing webhook with the payload URL is updated
+	actualID, token, err := client.CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com/existing", vcsutils.Push)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, strconv.Itoa(existingID), actualID)
+	assert.Equal(t, fmt.Sprintf("PUT %s/%d", hooksPath, existingID), requests[len(requests)-1])
+
+	// A new webhook is created otherwise
+	actualID, token, err = client.CreateOrUpdateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com/new", vcsutils.Push)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, strconv.Itoa(createdID), actualID)
+	assert.Equal(t, "POST "+hooksPath, requests[len(requests)-1])
+}
+
 func TestGitLabClient_UpdateWebhook(t *testing.T) {
 	ctx := context.Background()
 	id := rand.Int()
@@ -115,6 +440,61 @@ func TestGitLabClient_DeleteWebhook(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitLabClient_RotateWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, gitlab.ProjectHook{ID: id, URL: "https://jfrog.com"}, fmt.Sprintf("/api/v4/projects/%s/hooks/%d", url.PathEscape(owner+"/"+repo1), id), createGitLabHandler)
+	defer cleanUp()
+
+	newToken, err := client.RotateWebhookSecret(ctx, owner, repo1, strconv.Itoa(id))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, newToken)
+
+	_, err = client.RotateWebhookSecret(ctx, owner, repo1, "invalid-id")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_ListWebhooks(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int()
+	response := []*gitlab.ProjectHook{{ID: id, URL: "https://jfrog.com", PushEvents: true, MergeRequestsEvents: true}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, fmt.Sprintf("/api/v4/projects/%s/hooks?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	webhooks, err := client.ListWebhooks(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.Equal(t, strconv.Itoa(id), webhooks[0].ID)
+	assert.Equal(t, "https://jfrog.com", webhooks[0].PayloadURL)
+	assert.Equal(t, []vcsutils.WebhookEvent{vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.PrClosed, vcsutils.PrReopened, vcsutils.Push, vcsutils.BranchDeleted}, webhooks[0].Events)
+	assert.True(t, webhooks[0].Active)
+}
+
+func TestGitLabClient_GetWebhook(t *testing.T) {
+	ctx := context.Background()
+	id := rand.Int()
+	response := gitlab.ProjectHook{ID: id, URL: "https://jfrog.com", TagPushEvents: true}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, fmt.Sprintf("/api/v4/projects/%s/hooks/%d", url.PathEscape(owner+"/"+repo1), id), createGitLabHandler)
+	defer cleanUp()
+
+	webhook, err := client.GetWebhook(ctx, owner, repo1, strconv.Itoa(id))
+	assert.NoError(t, err)
+	assert.Equal(t, WebhookInfo{ID: strconv.Itoa(id), PayloadURL: "https://jfrog.com", Events: []vcsutils.WebhookEvent{vcsutils.TagPushed, vcsutils.TagRemoved}, Active: true}, webhook)
+
+	_, err = client.GetWebhook(ctx, owner, repo1, "invalid-id")
+	assert.Error(t, err)
+}
+
+func TestCreateProjectHook(t *testing.T) {
+	projectHook := createProjectHook(branch1, "https://jfrog.com", vcsutils.Push, vcsutils.ReleasePublished)
+	assert.Equal(t, &gitlab.ProjectHook{
+		URL:                    "https://jfrog.com",
+		PushEvents:             true,
+		PushEventsBranchFilter: branch1,
+		ReleasesEvents:         true,
+	}, projectHook)
+}
+
 func TestGitLabClient_CreateCommitStatus(t *testing.T) {
 	ctx := context.Background()
 	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
@@ -148,6 +528,44 @@ func TestGitLabClient_DownloadRepository(t *testing.T) {
 	assert.Equal(t, "README.md", fileinfo[1].Name())
 }
 
+func TestGitLabClient_DownloadRepositoryPaths(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
+	archives := map[string][]byte{
+		"src":       createTarGz(t, "repo-1-main-src", map[string]string{"src/go.mod": "module frog"}),
+		"README.md": createTarGz(t, "repo-1-main-README.md", map[string]string{"README.md": "Hello"}),
+	}
+	var archivePaths []string
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "", func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v4/projects/jfrog/repo-1/repository/archive.tar.gz":
+				assert.Equal(t, ref, r.URL.Query().Get("sha"))
+				archivePath := r.URL.Query().Get("path")
+				archivePaths = append(archivePaths, archivePath)
+				_, err := w.Write(archives[archivePath])
+				assert.NoError(t, err)
+			case "/api/v4/projects/jfrog/repo-1":
+				repositoryResponse, err := os.ReadFile(filepath.Join("testdata", "gitlab", "repository_response.json"))
+				assert.NoError(t, err)
+				_, err = w.Write(repositoryResponse)
+				assert.NoError(t, err)
+			default:
+				assert.Fail(t, "unexpected request", r.RequestURI)
+			}
+		}
+	})
+	defer cleanUp()
+
+	err := client.DownloadRepositoryPaths(ctx, owner, repo1, ref, []string{"src/", "README.md"}, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src", "README.md"}, archivePaths)
+	assert.FileExists(t, filepath.Join(dir, "README.md"))
+	assert.FileExists(t, filepath.Join(dir, "src", "go.mod"))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+}
+
 func TestGitLabClient_DownloadFileFromRepo(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, gitlab.File{Content: "SGVsbG8gV29ybGQh"}, fmt.Sprintf("/api/v4/projects/%s/repository/files/hello-world?ref=branch-1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
@@ -160,13 +578,124 @@ func TestGitLabClient_DownloadFileFromRepo(t *testing.T) {
 	assert.Equal(t, expected, string(content))
 }
 
+func TestGitLabClient_ListDirectoryContents(t *testing.T) {
+	ctx := context.Background()
+	response := []*gitlab.TreeNode{
+		{Name: "go.mod", Path: "src/go.mod", Type: "blob"},
+		{Name: "pkg", Path: "src/pkg", Type: "tree"},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/tree?page=1&path=src&per_page=100&ref=master", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	entries, err := client.ListDirectoryContents(ctx, owner, repo1, "master", "src")
+	assert.NoError(t, err)
+	assert.Equal(t, []DirectoryEntry{
+		{Name: "go.mod", Path: "src/go.mod", IsDir: false},
+		{Name: "pkg", Path: "src/pkg", IsDir: true},
+	}, entries)
+
+	_, err = client.ListDirectoryContents(ctx, owner, repo1, "", "src")
+	assert.EqualError(t, err, "validation failed: required parameter 'ref' is missing")
+}
+
+func TestGitLabClient_CommitFiles(t *testing.T) {
+	ctx := context.Background()
+	files := []FileToCommit{
+		{Path: "go.mod", Content: []byte("module example"), ChangeType: FileModified},
+		{Path: "new.go", Content: []byte("package main"), ChangeType: FileAdded},
+		{Path: "go.sum", ChangeType: FileDeleted},
+	}
+	expectedBody := []byte(`{"branch":"branch-1","commit_message":"Update dependencies","actions":[` +
+		`{"action":"update","file_path":"go.mod","content":"bW9kdWxlIGV4YW1wbGU=","encoding":"base64"},` +
+		`{"action":"create","file_path":"new.go","content":"cGFja2FnZSBtYWlu","encoding":"base64"},` +
+		`{"action":"delete","file_path":"go.sum"}]}`)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.Commit{},
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		expectedBody, http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", files)
+	assert.NoError(t, err)
+
+	err = client.CommitFiles(ctx, owner, repo1, branch1, "Update dependencies", []FileToCommit{{Path: "new.go", ChangeType: FileRenamed}})
+	assert.EqualError(t, err, "validation failed: renaming files isn't supported, delete 'new.go' and add it under its new path instead")
+}
+
 func TestGitLabClient_CreatePullRequest(t *testing.T) {
 	ctx := context.Background()
-	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, fmt.Sprintf("/api/v4/projects/%s/merge_requests", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "get_merge_request_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, fmt.Sprintf("/api/v4/projects/%s/merge_requests", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	pullRequestInfo, err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(133), pullRequestInfo.ID)
+	assert.Equal(t, "https://gitlab.com/marcel.amirault/test-project/-/merge_requests/133", pullRequestInfo.URL)
+}
+
+func TestGitLabClient_CreatePullRequestWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "get_merge_request_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		[]byte(`{"title":"Draft: PR title","description":"PR body","source_branch":"branch-1","target_branch":"branch-2"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	pullRequestInfo, err := client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{Draft: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(133), pullRequestInfo.ID)
+
+	// The title isn't prefixed twice
+	_, err = client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "Draft: PR title", "PR body", CreatePullRequestOptions{Draft: true})
+	assert.NoError(t, err)
+
+	_, err = client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{WorkItems: []int{1}})
+	assert.EqualError(t, err, "linking work items to pull requests is not supported on GitLab")
+}
+
+func TestGitLabClient_CreatePullRequestWithReviewersAndAssignees(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "get_merge_request_response.json"))
+	assert.NoError(t, err)
+	mergeRequestsURI := fmt.Sprintf("/api/v4/projects/%s/merge_requests", url.PathEscape(owner+"/"+repo1))
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response, mergeRequestsURI,
+		func(t *testing.T, expectedURI string, response []byte, _ int) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				var responseBody []byte
+				switch r.RequestURI {
+				case "/api/v4/":
+				case "/api/v4/users?username=reviewer1":
+					responseBody = []byte(`[{"id": 11, "username": "reviewer1"}]`)
+				case "/api/v4/users?username=frogger":
+					responseBody = []byte(`[{"id": 12, "username": "frogger"}]`)
+				case "/api/v4/users?username=unknown":
+					responseBody = []byte(`[]`)
+				case expectedURI:
+					body, err := io.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"title": "PR title", "description": "PR body", "source_branch": "branch-1", "target_branch": "branch-2",
+						"labels": "dependencies,security", "assignee_ids": [12], "reviewer_ids": [11], "milestone_id": 3, "remove_source_branch": true}`, string(body))
+					responseBody = response
+				default:
+					assert.Fail(t, "unexpected request", r.RequestURI)
+				}
+				_, err := w.Write(responseBody)
+				assert.NoError(t, err)
+			}
+		})
 	defer cleanUp()
 
-	err := client.CreatePullRequest(ctx, owner, repo1, branch1, branch2, "PR title", "PR body")
+	options := CreatePullRequestOptions{Reviewers: []string{"reviewer1"}, Assignees: []string{username}, Labels: []string{"dependencies", "security"},
+		Milestone: 3, DeleteSourceBranch: true}
+	pullRequestInfo, err := client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body", options)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(133), pullRequestInfo.ID)
+
+	_, err = client.CreatePullRequestWithOptions(ctx, owner, repo1, branch1, branch2, "PR title", "PR body", CreatePullRequestOptions{Reviewers: []string{"unknown"}})
+	assert.EqualError(t, err, "GitLab user 'unknown' wasn't found")
 }
 
 func TestGitLabClient_UpdatePullRequest(t *testing.T) {
@@ -185,6 +714,41 @@ func TestGitLabClient_UpdatePullRequest(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitLabClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+	prId := 5
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, fmt.Sprintf("/api/v4/projects/%s/merge_requests/%v/merge", url.PathEscape(owner+"/"+repo1), prId), createGitLabHandler)
+	defer cleanUp()
+
+	err := client.MergePullRequest(ctx, owner, repo1, prId, vcsutils.MergeMethodMerge)
+	assert.NoError(t, err)
+	err = client.MergePullRequest(ctx, owner, repo1, prId, vcsutils.MergeMethodSquash)
+	assert.NoError(t, err)
+	err = client.MergePullRequest(ctx, owner, repo1, prId, "fast-forward")
+	assert.Error(t, err)
+
+	rebaseClient, rebaseCleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, "", createGitLabHandlerWithoutExpectedURI)
+	defer rebaseCleanUp()
+	err = rebaseClient.MergePullRequest(ctx, owner, repo1, prId, vcsutils.MergeMethodRebase)
+	assert.NoError(t, err)
+
+	failedRebaseClient, failedRebaseCleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{MergeError: "conflict"}, "", createGitLabHandlerWithoutExpectedURI)
+	defer failedRebaseCleanUp()
+	err = failedRebaseClient.MergePullRequest(ctx, owner, repo1, prId, vcsutils.MergeMethodRebase)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_SetPullRequestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, []byte("{}"),
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/5/merge", url.PathEscape(owner+"/"+repo1)), http.StatusOK,
+		[]byte(`{"squash":true,"merge_when_pipeline_succeeds":true}`), http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	assert.NoError(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 5, vcsutils.MergeMethodSquash))
+	assert.EqualError(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 5, vcsutils.MergeMethodRebase), "unsupported merge method: 'rebase'")
+}
+
 func TestGitLabClient_AddPullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{}, fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
@@ -240,16 +804,21 @@ func TestGitLabClient_ListPullRequestReviewComments(t *testing.T) {
 	result, err := client.ListPullRequestReviewComments(ctx, owner, repo1, 1)
 	assert.NoError(t, err)
 	assert.NoError(t, err)
+	// The individual note is a general comment and isn't returned
 	assert.Len(t, result, 3)
 	assert.Equal(t, int64(1126), result[0].ID)
 	assert.Equal(t, "discussion text", result[0].Content)
 	assert.Equal(t, "2018-03-03 21:54:39.668 +0000 UTC", result[0].Created.String())
+	assert.Empty(t, result[0].FilePath)
 	assert.Equal(t, int64(1129), result[1].ID)
 	assert.Equal(t, "reply to the discussion", result[1].Content)
+	assert.Equal(t, "1126", result[1].ParentID)
 	assert.Equal(t, "2018-03-04 13:38:02.127 +0000 UTC", result[1].Created.String())
-	assert.Equal(t, int64(1128), result[2].ID)
-	assert.Equal(t, "a single comment", result[2].Content)
-	assert.Equal(t, "2018-03-04 09:17:22.52 +0000 UTC", result[2].Created.String())
+	assert.Equal(t, int64(1130), result[2].ID)
+	assert.Equal(t, "87805b7c09016a7058e91bdbe7b29d1f284a39e7", result[2].ThreadID)
+	assert.Equal(t, "diff comment", result[2].Content)
+	assert.Equal(t, "path/to/file.go", result[2].FilePath)
+	assert.Equal(t, 18, result[2].Line)
 }
 
 func TestGitLabClient_ListPullRequestComments(t *testing.T) {
@@ -258,7 +827,7 @@ func TestGitLabClient_ListPullRequestComments(t *testing.T) {
 	assert.NoError(t, err)
 
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
-		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
 	defer cleanUp()
 
 	result, err := client.ListPullRequestComments(ctx, owner, repo1, 1)
@@ -267,29 +836,57 @@ func TestGitLabClient_ListPullRequestComments(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 	assert.Equal(t, CommentInfo{
-		ID:      305,
-		Content: "Text of the comment\r\n",
-		Created: expectedCreated,
+		ID:             305,
+		NativeID:       "305",
+		Content:        "Text of the comment\r\n",
+		Author:         "Pip",
+		AuthorUsername: "pipin",
+		Created:        expectedCreated,
+		Updated:        expectedCreated,
 	}, result[1])
 }
 
+func TestGitLabClient_ListPullRequestCommentsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pull_request_comments_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes?page=2&per_page=10", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	// The comments of the page are filtered by the client
+	options := ListPullRequestCommentsOptions{UpdatedAfter: time.Date(2013, 10, 2, 10, 0, 0, 0, time.UTC), Page: 2, PerPage: 10}
+	result, err := client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, options)
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, int64(302), result[0].ID)
+	}
+}
+
 func TestGitLabClient_ListOpenPullRequests(t *testing.T) {
 	ctx := context.Background()
 	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pull_requests_list_response.json"))
 	assert.NoError(t, err)
 
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
-		"/api/v4/projects/jfrog%2Frepo-1/merge_requests?scope=all&state=opened", createGitLabHandler)
+		"/api/v4/projects/jfrog%2Frepo-1/merge_requests?page=1&per_page=100&scope=all&state=opened", createGitLabHandler)
 	defer cleanUp()
 
 	result, err := client.ListOpenPullRequests(ctx, owner, repo1)
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     302,
-		Source: BranchInfo{Name: "test1", Repository: repo1, Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: repo1, Owner: owner},
-		URL:    "https://gitlab.example.com/my-group/my-project/merge_requests/1",
+		ID:        302,
+		Title:     "test1",
+		Author:    "admin",
+		State:     vcsutils.Open,
+		Mergeable: true,
+		Source:    BranchInfo{Name: "test1", Repository: repo1, Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		URL:       "https://gitlab.example.com/my-group/my-project/merge_requests/1",
+		CreatedAt: time.Date(2017, 4, 29, 8, 46, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2017, 4, 29, 8, 46, 0, 0, time.UTC),
 	}, result[0])
 
 	// With body
@@ -297,14 +894,60 @@ func TestGitLabClient_ListOpenPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     302,
-		Body:   "hello world",
-		Source: BranchInfo{Name: "test1", Repository: repo1, Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: repo1, Owner: owner},
-		URL:    "https://gitlab.example.com/my-group/my-project/merge_requests/1",
+		ID:        302,
+		Title:     "test1",
+		Body:      "hello world",
+		Author:    "admin",
+		State:     vcsutils.Open,
+		Mergeable: true,
+		Source:    BranchInfo{Name: "test1", Repository: repo1, Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: repo1, Owner: owner},
+		URL:       "https://gitlab.example.com/my-group/my-project/merge_requests/1",
+		CreatedAt: time.Date(2017, 4, 29, 8, 46, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2017, 4, 29, 8, 46, 0, 0, time.UTC),
 	}, result[0])
 }
 
+func TestGitLabClient_ListOpenPullRequestsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		"/api/v4/projects/jfrog%2Frepo-1/merge_requests?author_username=admin&page=1&per_page=50&scope=all&source_branch=test1&state=opened&target_branch=master&updated_after=2017-04-29T08%3A00%3A00Z", createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.ListOpenPullRequestsWithOptions(ctx, owner, repo1, ListPullRequestsOptions{
+		SourceBranch: "test1",
+		TargetBranch: "master",
+		Author:       "admin",
+		UpdatedSince: time.Date(2017, 4, 29, 8, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(302), result[0].ID)
+	assert.Empty(t, result[0].Body)
+}
+
+func TestGitLabClient_ListPullRequestsWithState(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pull_requests_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		"/api/v4/projects/jfrog%2Frepo-1/merge_requests?page=1&per_page=100&scope=all&state=merged", createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.ListPullRequestsWithState(ctx, owner, repo1, vcsutils.Merged)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(302), result[0].ID)
+	assert.Equal(t, "hello world", result[0].Body)
+
+	_, err = client.ListPullRequestsWithState(ctx, owner, repo1, "draft")
+	assert.EqualError(t, err, "unsupported pull request state: 'draft'")
+}
+
 func TestGitLabClient_GetPullRequestByID(t *testing.T) {
 	ctx := context.Background()
 	repoName := "repo"
@@ -319,10 +962,15 @@ func TestGitLabClient_GetPullRequestByID(t *testing.T) {
 	result, err := client.GetPullRequestByID(ctx, owner, repoName, pullRequestId)
 	assert.NoError(t, err)
 	assert.EqualValues(t, PullRequestInfo{
-		ID:     133,
-		Source: BranchInfo{Name: "manual-job-rules", Repository: repoName, Owner: owner},
-		Target: BranchInfo{Name: "master", Repository: repoName, Owner: owner},
-		URL:    "https://gitlab.com/marcel.amirault/test-project/-/merge_requests/133",
+		ID:        133,
+		Title:     "Manual job rules",
+		Author:    "marcel.amirault",
+		State:     vcsutils.Open,
+		Source:    BranchInfo{Name: "manual-job-rules", Repository: repoName, Owner: owner},
+		Target:    BranchInfo{Name: "master", Repository: repoName, Owner: owner},
+		URL:       "https://gitlab.com/marcel.amirault/test-project/-/merge_requests/133",
+		CreatedAt: time.Date(2022, 5, 13, 7, 26, 38, 402000000, time.UTC),
+		UpdatedAt: time.Date(2022, 5, 14, 3, 38, 31, 354000000, time.UTC),
 	}, result)
 
 	// Bad client
@@ -340,7 +988,7 @@ func TestGitLabClient_GetLatestCommit(t *testing.T) {
 	assert.NoError(t, err)
 
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
-		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=50&ref_name=master",
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=1&ref_name=master",
 			url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
 	defer cleanUp()
 
@@ -394,6 +1042,30 @@ func TestGitLabClient_GetCommits(t *testing.T) {
 	}, result[1])
 }
 
+func TestGitLabClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "commit_list_response.json"))
+	assert.NoError(t, err)
+
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits?author=user%%40example.com&page=1&path=README.md&per_page=50&ref_name=master&until=2012-09-21T00%%3A00%%3A00Z",
+			url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.ListCommits(ctx, owner, repo1, ListCommitsOptions{
+		Branch: "master",
+		Path:   "README.md",
+		Author: "user@example.com",
+		Until:  time.Date(2012, 9, 21, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "ed899a2f4b50b4370feeea94676502b42383c746", result[0].Hash)
+
+	_, err = client.ListCommits(ctx, owner, "", ListCommitsOptions{Branch: "master"})
+	assert.EqualError(t, err, "validation failed: required parameter 'repository' is missing")
+}
+
 func TestGitLabClient_GetLatestCommitNotFound(t *testing.T) {
 	ctx := context.Background()
 	response := []byte(`{
@@ -401,7 +1073,7 @@ func TestGitLabClient_GetLatestCommitNotFound(t *testing.T) {
 	}`)
 
 	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, response,
-		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=50&ref_name=master",
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=1&ref_name=master",
 			url.PathEscape(owner+"/"+repo1)), http.StatusNotFound, createGitLabHandler)
 	defer cleanUp()
 
@@ -415,7 +1087,7 @@ func TestGitLabClient_GetLatestCommitNotFound(t *testing.T) {
 func TestGitLabClient_GetLatestCommitUnknownBranch(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, []byte("[]"),
-		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=50&ref_name=unknown",
+		fmt.Sprintf("/api/v4/projects/%s/repository/commits?page=1&per_page=1&ref_name=unknown",
 			url.PathEscape(owner+"/"+repo1)), http.StatusOK, createGitLabHandler)
 	defer cleanUp()
 
@@ -474,21 +1146,106 @@ func TestGitLabClient_GetRepositoryInfo(t *testing.T) {
 	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "repository_response.json"))
 	assert.NoError(t, err)
 
-	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, response,
-		"/api/v4/projects/diaspora%2Fdiaspora-project-site", http.StatusOK, createGitLabHandler)
+	client, cleanUp := createServerAndClientReturningStatus(t, vcsutils.GitLab, false, response,
+		"/api/v4/projects/diaspora%2Fdiaspora-project-site", http.StatusOK, createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.GetRepositoryInfo(ctx, "diaspora", "diaspora-project-site")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo: CloneInfo{
+				HTTP: "https://example.com/diaspora/diaspora-project-site.git",
+				SSH:  "git@example.com:diaspora/diaspora-project-site.git"},
+			DefaultBranch: "master",
+		},
+		result,
+	)
+}
+
+func TestGitLabClient_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "", createCreateRepositoryGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.CreateRepository(ctx, owner, repo1, CreateRepositoryOptions{Private: true, Description: "Frogs", DefaultBranch: "develop", InitReadme: true})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo:            CloneInfo{HTTP: "https://gitlab.com/jfrog/repo-1.git", SSH: "git@gitlab.com:jfrog/repo-1.git"},
+		},
+		result,
+	)
+
+	_, err = client.CreateRepository(ctx, owner, "", CreateRepositoryOptions{})
+	assert.EqualError(t, err, "validation failed: required parameter 'repository' is missing")
+}
+
+func TestGitLabClient_DeleteRepository(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "/api/v4/projects/"+url.PathEscape(owner+"/"+repo1), createGitLabHandler)
+	defer cleanUp()
+
+	err := client.DeleteRepository(ctx, owner, repo1)
+	assert.NoError(t, err)
+
+	err = client.DeleteRepository(ctx, owner, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'repository' is missing")
+}
+
+func TestGitLabClient_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "repository_response.json"))
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response, "/api/v4/projects/"+url.PathEscape(owner+"/"+repo1)+"/fork",
+		http.StatusCreated, []byte(`{"namespace_path":"frogs"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	result, err := client.ForkRepository(ctx, owner, repo1, "frogs")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		RepositoryInfo{
+			RepositoryVisibility: Private,
+			CloneInfo: CloneInfo{
+				HTTP: "https://example.com/diaspora/diaspora-project-site.git",
+				SSH:  "git@example.com:diaspora/diaspora-project-site.git"},
+			DefaultBranch: "master",
+		},
+		result,
+	)
+
+	_, err = client.ForkRepository(ctx, owner, "", "frogs")
+	assert.EqualError(t, err, "validation failed: required parameter 'repository' is missing")
+}
+
+func TestGitLabClient_ListRepositoryCollaborators(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`[{"username": "frogger", "access_level": 30}, {"username": "toad", "access_level": 10}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		"/api/v4/projects/"+url.PathEscape(owner+"/"+repo1)+"/members/all?page=1&per_page=100", createGitLabHandler)
+	defer cleanUp()
+
+	collaborators, err := client.ListRepositoryCollaborators(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []CollaboratorInfo{{Username: "frogger", Permission: WritePermission}, {Username: "toad", Permission: NoPermission}}, collaborators)
+}
+
+func TestGitLabClient_GetRepositoryPermission(t *testing.T) {
+	ctx := context.Background()
+	// The query matches usernames partially
+	response := []byte(`[{"username": "frogger2", "access_level": 50}, {"username": "frogger", "access_level": 20}]`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		"/api/v4/projects/"+url.PathEscape(owner+"/"+repo1)+"/members/all?page=1&per_page=100&query=frogger", createGitLabHandler)
 	defer cleanUp()
 
-	result, err := client.GetRepositoryInfo(ctx, "diaspora", "diaspora-project-site")
+	permission, err := client.GetRepositoryPermission(ctx, owner, repo1, "frogger")
 	assert.NoError(t, err)
-	assert.Equal(t,
-		RepositoryInfo{
-			RepositoryVisibility: Private,
-			CloneInfo: CloneInfo{
-				HTTP: "https://example.com/diaspora/diaspora-project-site.git",
-				SSH:  "git@example.com:diaspora/diaspora-project-site.git"},
-		},
-		result,
-	)
+	assert.Equal(t, ReadPermission, permission)
+
+	_, err = client.GetRepositoryPermission(ctx, owner, repo1, "")
+	assert.EqualError(t, err, "validation failed: required parameter 'username' is missing")
 }
 
 func TestGitLabClient_GetCommitBySha(t *testing.T) {
@@ -582,6 +1339,40 @@ func TestGitlabClient_GetLabel(t *testing.T) {
 	assert.Nil(t, labelInfo)
 }
 
+func TestGitlabClient_ListRepositoryLabels(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false,
+		[]gitlab.Label{{Name: labelName, Description: "label-description", Color: "#001122"}},
+		fmt.Sprintf("/api/v4/projects/%s/labels?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	labels, err := client.ListRepositoryLabels(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []LabelInfo{{Name: labelName, Description: "label-description", Color: "001122"}}, labels)
+}
+
+func TestGitlabClient_UpdateLabel(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Label{},
+		fmt.Sprintf("/api/v4/projects/%s/labels", url.PathEscape(owner+"/"+repo1)), http.StatusOK,
+		[]byte(`{"name":"`+labelName+`","new_name":"new-label","color":"001122","description":"label-description"}`), http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.UpdateLabel(ctx, owner, repo1, labelName, LabelInfo{Name: "new-label", Description: "label-description", Color: "001122"})
+	assert.NoError(t, err)
+}
+
+func TestGitlabClient_DeleteLabel(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, nil,
+		fmt.Sprintf("/api/v4/projects/%s/labels/%s", url.PathEscape(owner+"/"+repo1), url.PathEscape(labelName)), http.StatusOK,
+		[]byte{}, http.MethodDelete, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.DeleteLabel(ctx, owner, repo1, labelName)
+	assert.NoError(t, err)
+}
+
 func TestGitlabClient_ListPullRequestLabels(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{Labels: gitlab.Labels{labelName}},
@@ -594,6 +1385,17 @@ func TestGitlabClient_ListPullRequestLabels(t *testing.T) {
 	assert.Equal(t, labelName, labels[0])
 }
 
+func TestGitlabClient_LabelPullRequest(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, &gitlab.MergeRequest{},
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1", url.PathEscape(owner+"/"+repo1)), http.StatusOK,
+		[]byte(`{"add_labels":"`+labelName+`"}`), http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.LabelPullRequest(ctx, owner, repo1, 1, []string{labelName})
+	assert.NoError(t, err)
+}
+
 func TestGitlabClient_UnlabelPullRequest(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil,
@@ -604,12 +1406,104 @@ func TestGitlabClient_UnlabelPullRequest(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitlabClient_CreateIssue(t *testing.T) {
+	ctx := context.Background()
+	response := gitlab.Issue{IID: 1, Title: "Vulnerable dependency", Description: "Upgrade it", WebURL: "https://gitlab.com/jfrog/repo-1/-/issues/1",
+		Author: &gitlab.IssueAuthor{Username: "frogger"}, State: "opened"}
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, response, "/api/v4/projects/"+url.PathEscape(owner+"/"+repo1)+"/issues",
+		http.StatusCreated, []byte(`{"title":"Vulnerable dependency","description":"Upgrade it"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	issue, err := client.CreateIssue(ctx, owner, repo1, "Vulnerable dependency", "Upgrade it")
+	assert.NoError(t, err)
+	assert.Equal(t, IssueInfo{ID: 1, Title: "Vulnerable dependency", Body: "Upgrade it", URL: "https://gitlab.com/jfrog/repo-1/-/issues/1",
+		Author: "frogger", State: vcsutils.IssueOpen}, issue)
+}
+
+func TestGitlabClient_ListIssues(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.Issue{{IID: 1, Title: "Vulnerable dependency", Author: &gitlab.IssueAuthor{Username: "frogger"}, State: "closed", Labels: gitlab.Labels{"security"}}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		"/api/v4/projects/"+url.PathEscape(owner+"/"+repo1)+"/issues?author_username=frogger&labels=security&page=1&per_page=50&state=closed", createGitLabHandler)
+	defer cleanUp()
+
+	issues, err := client.ListIssues(ctx, owner, repo1, ListIssuesOptions{State: vcsutils.IssueClosed, Labels: []string{"security"}, Author: "frogger"})
+	assert.NoError(t, err)
+	assert.Equal(t, []IssueInfo{{ID: 1, Title: "Vulnerable dependency", Author: "frogger", State: vcsutils.IssueClosed, Labels: []string{"security"}}}, issues)
+}
+
+func TestGitlabClient_AddIssueComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Note{}, "/api/v4/projects/"+url.PathEscape(owner+"/"+repo1)+"/issues/1/notes",
+		http.StatusCreated, []byte(`{"body":"Fixed"}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.AddIssueComment(ctx, owner, repo1, "Fixed", 1)
+	assert.NoError(t, err)
+}
+
+func TestGitlabClient_CloseIssue(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, gitlab.Issue{}, "/api/v4/projects/"+url.PathEscape(owner+"/"+repo1)+"/issues/1",
+		http.StatusOK, []byte(`{"state_event":"close"}`), http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.CloseIssue(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+}
+
 func TestGitlabClient_UploadCodeScanning(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, true, "", "unsupportedTest", createGitLabHandler)
 	defer cleanUp()
 	_, err := client.UploadCodeScanning(ctx, owner, repo1, "", "1")
 	assert.Error(t, err)
+
+	var discussions, notes []string
+	client, cleanUp = createServerAndClient(t, vcsutils.GitLab, false, nil, "", createUploadCodeScanningGitLabHandler(&discussions, &notes))
+	defer cleanUp()
+	// The first finding is located in a file changed by the merge request
+	sarif := strings.Replace(codeScanningTestSarif, "file://package.json", "VERSION", 1)
+	commitHash, err := client.UploadCodeScanning(ctx, owner, repo1, "master", sarif)
+	assert.NoError(t, err)
+	assert.Equal(t, "ed899a2f4b50b4370feeea94676502b42383c746", commitHash)
+	if assert.Len(t, discussions, 1) {
+		assert.Contains(t, discussions[0], `"body":"**CRITICAL** XRAY-174176: json 9.0.6. Fixed in Versions: [11.0.0]"`)
+		assert.Contains(t, discussions[0], `"new_path":"VERSION"`)
+		assert.Contains(t, discussions[0], `"new_line":12`)
+	}
+	if assert.Len(t, notes, 1) {
+		assert.Contains(t, notes[0], `"body":"**MEDIUM** XRAY-174177: lodash 4.17.0. Fixed in Versions: [4.17.21]"`)
+	}
+}
+
+func TestGitlabClient_CodeInsights(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.GitLab).Build()
+	assert.NoError(t, err)
+	err = client.CreateCodeInsightsReport(ctx, owner, repo1, "abcdef", CodeInsightsReport{Key: "xray-scan", Title: "Xray"})
+	assert.ErrorIs(t, err, errGitLabCodeInsightsNotSupported)
+	err = client.AddCodeInsightsAnnotations(ctx, owner, repo1, "abcdef", "xray-scan", nil)
+	assert.ErrorIs(t, err, errGitLabCodeInsightsNotSupported)
+}
+
+func TestGitlabClient_CheckRuns(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.GitLab).Build()
+	assert.NoError(t, err)
+	_, err = client.CreateCheckRun(ctx, owner, repo1, CheckRunInfo{Name: "Xray", HeadSHA: "abcdef"})
+	assert.ErrorIs(t, err, errGitLabCheckRunsNotSupported)
+	assert.ErrorIs(t, client.UpdateCheckRun(ctx, owner, repo1, 4, CheckRunInfo{Name: "Xray"}), errGitLabCheckRunsNotSupported)
+}
+
+func TestGitlabClient_AppInstallations(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClientBuilder(vcsutils.GitLab).Build()
+	assert.NoError(t, err)
+	_, err = client.ListAppInstallations(ctx)
+	assert.ErrorIs(t, err, errGitLabAppInstallationsNotSupported)
+	_, err = client.ListInstallationRepositories(ctx, 5)
+	assert.ErrorIs(t, err, errGitLabAppInstallationsNotSupported)
 }
 
 func TestGitlabClient_GetRepositoryEnvironmentInfo(t *testing.T) {
@@ -621,6 +1515,128 @@ func TestGitlabClient_GetRepositoryEnvironmentInfo(t *testing.T) {
 	assert.ErrorIs(t, err, errGitLabGetRepoEnvironmentInfoNotSupported)
 }
 
+func TestGitLabClient_ListEnvironments(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.Environment{{ID: 3, Name: "production", ExternalURL: "https://frogbot.jfrog.io"}, {ID: 4, Name: "staging"}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/environments?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	environments, err := client.ListEnvironments(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryEnvironmentInfo{{Name: "production", Url: "https://frogbot.jfrog.io"}, {Name: "staging"}}, environments)
+}
+
+func TestGitLabClient_Deployments(t *testing.T) {
+	ctx := context.Background()
+	sha := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	projectPath := fmt.Sprintf("/api/v4/projects/%s", url.PathEscape(owner+"/"+repo1))
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, r.Method+" "+r.RequestURI+" "+string(body))
+		var response interface{}
+		switch r.Method + " " + r.RequestURI {
+		case "GET " + projectPath + "/repository/commits/" + branch1:
+			response = gitlab.Commit{ID: sha}
+		case "POST " + projectPath + "/deployments", "PUT " + projectPath + "/deployments/42":
+			response = gitlab.Deployment{ID: 42, Ref: branch1, SHA: sha, CreatedAt: &createdAt, Environment: &gitlab.Environment{ID: 3, Name: "production"}}
+		case "PUT " + projectPath + "/environments/3":
+			response = gitlab.Environment{ID: 3, Name: "production"}
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	deployment, err := client.CreateDeployment(ctx, owner, repo1, branch1, "production", "ignored")
+	assert.NoError(t, err)
+	assert.Equal(t, DeploymentInfo{ID: "42", Ref: branch1, CommitSHA: sha, Environment: "production", CreatedAt: createdAt}, deployment)
+	assert.Equal(t, "POST "+projectPath+`/deployments {"environment":"production","ref":"branch-1","sha":"`+sha+`","status":"created"}`, requests[len(requests)-1])
+
+	// Without an environment URL, only the deployment is updated
+	requests = nil
+	assert.NoError(t, client.SetDeploymentStatus(ctx, owner, repo1, "42", DeploymentInProgress, "", ""))
+	assert.Equal(t, []string{"PUT " + projectPath + `/deployments/42 {"status":"running"}`}, requests)
+
+	requests = nil
+	assert.NoError(t, client.SetDeploymentStatus(ctx, owner, repo1, "42", DeploymentSuccess, "", "https://frogbot.jfrog.io"))
+	assert.Equal(t, []string{
+		"PUT " + projectPath + `/deployments/42 {"status":"success"}`,
+		"PUT " + projectPath + `/environments/3 {"external_url":"https://frogbot.jfrog.io"}`,
+	}, requests)
+
+	assert.ErrorContains(t, client.SetDeploymentStatus(ctx, owner, repo1, "deployment", DeploymentFailure, "", ""), "invalid deployment ID 'deployment'")
+}
+
+func TestGitLabClient_CreateOrUpdateRepositorySecret(t *testing.T) {
+	ctx := context.Background()
+	variablesPath := fmt.Sprintf("/api/v4/projects/%s/variables", url.PathEscape(owner+"/"+repo1))
+	variableExists := true
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, r.Method+" "+r.RequestURI+" "+string(body))
+		switch r.Method + " " + r.RequestURI {
+		case "PUT " + variablesPath + "/JF_TOKEN":
+			if !variableExists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		case "POST " + variablesPath:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(gitlab.ProjectVariable{Key: "JF_TOKEN", Masked: true}))
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitLab, false, server)
+
+	// An existing variable is updated
+	assert.NoError(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"))
+	assert.Equal(t, []string{"PUT " + variablesPath + `/JF_TOKEN {"value":"secret-value","masked":true}`}, requests)
+
+	// A new variable is created otherwise
+	variableExists, requests = false, nil
+	assert.NoError(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"))
+	assert.Equal(t, []string{
+		"PUT " + variablesPath + `/JF_TOKEN {"value":"secret-value","masked":true}`,
+		"POST " + variablesPath + ` {"key":"JF_TOKEN","value":"secret-value","masked":true}`,
+	}, requests)
+
+	assert.ErrorContains(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "", "secret-value"), "required parameter 'name' is missing")
+}
+
+func TestGitLabClient_ListRepositoryVariables(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.ProjectVariable{{Key: "JF_URL", Value: "https://jfrog.io"}, {Key: "JF_TOKEN", Value: "secret-value", Masked: true}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/variables?page=1&per_page=100", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	variables, err := client.ListRepositoryVariables(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryVariableInfo{{Name: "JF_URL", Value: "https://jfrog.io"}, {Name: "JF_TOKEN", IsSecret: true}}, variables)
+}
+
+func TestGitLabClient_OrganizationAndEnvironmentSecrets(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, "", "unsupportedTest", createGitLabHandler)
+	defer cleanUp()
+
+	assert.ErrorIs(t, client.CreateOrUpdateOrganizationSecret(ctx, owner, "JF_TOKEN", "secret-value", VisibleToAllRepositories, nil), errGitLabSecretsNotSupported)
+	_, err := client.ListEnvironmentSecrets(ctx, owner, repo1, envName)
+	assert.ErrorIs(t, err, errGitLabSecretsNotSupported)
+}
+
 func TestGitLabClient_DeletePullRequestReviewComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, "",
@@ -634,6 +1650,45 @@ func TestGitLabClient_DeletePullRequestReviewComment(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitLabClient_UpdatePullRequestComment(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []byte("{}"),
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/notes/1", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+	err := client.UpdatePullRequestComment(ctx, owner, repo1, "Updated content", 1, 1)
+	assert.NoError(t, err)
+	err = client.UpdatePullRequestComment(ctx, owner, repo1, "", 1, 1)
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_CreatePullRequestReview(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "", createPullRequestReviewGitLabHandler)
+	defer cleanUp()
+
+	err := client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictApprove, "")
+	assert.NoError(t, err)
+
+	// The merge request wasn't approved, so there is no approval to revoke
+	err = client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictRequestChanges, "Please fix")
+	assert.NoError(t, err)
+
+	err = client.CreatePullRequestReview(ctx, owner, repo1, 1, vcsutils.ReviewVerdictComment, "")
+	assert.Error(t, err)
+}
+
+func TestGitLabClient_ListPullRequestReviews(t *testing.T) {
+	ctx := context.Background()
+	response := []byte(`{"approved": true, "approved_by": [{"user": {"id": 1, "username": "reviewer1"}}]}`)
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/approvals", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	reviews, err := client.ListPullRequestReviews(ctx, owner, repo1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestReviewInfo{{Reviewer: "reviewer1", State: vcsutils.ReviewVerdictApprove}}, reviews)
+}
+
 func TestGitLabClient_DeletePullRequestComment(t *testing.T) {
 	ctx := context.Background()
 	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, "",
@@ -643,6 +1698,20 @@ func TestGitLabClient_DeletePullRequestComment(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGitLabClient_SetPullRequestThreadStatus(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, []byte("{}"),
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/1/discussions/6a9c1750b37d513a43987b574953fceb50b03ce7", url.PathEscape(owner+"/"+repo1)),
+		http.StatusOK, []byte(`{"resolved":true}`), http.MethodPut, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	err := client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "6a9c1750b37d513a43987b574953fceb50b03ce7", true)
+	assert.NoError(t, err)
+
+	err = client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "", true)
+	assert.Error(t, err)
+}
+
 func TestGitLabClient_GetModifiedFiles(t *testing.T) {
 	ctx := context.Background()
 	t.Run("ok", func(t *testing.T) {
@@ -698,6 +1767,120 @@ func TestGitLabClient_GetModifiedFiles(t *testing.T) {
 	})
 }
 
+func TestGitLabClient_ListPullRequestFiles(t *testing.T) {
+	ctx := context.Background()
+	prId := 5
+	diffs := []*gitlab.MergeRequestDiff{
+		{OldPath: "README.md", NewPath: "README.md"},
+		{OldPath: "new.go", NewPath: "new.go", NewFile: true},
+		{OldPath: "old.go", NewPath: "old.go", DeletedFile: true},
+		{OldPath: "original.go", NewPath: "renamed.go", RenamedFile: true},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, diffs,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/%d/diffs?page=1&per_page=100", url.PathEscape(owner+"/"+repo1), prId), createGitLabHandler)
+	defer cleanUp()
+
+	files, err := client.ListPullRequestFiles(ctx, owner, repo1, prId)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullRequestFile{
+		{Path: "README.md", ChangeType: FileModified},
+		{Path: "new.go", ChangeType: FileAdded},
+		{Path: "old.go", ChangeType: FileDeleted},
+		{Path: "renamed.go", PreviousPath: "original.go", ChangeType: FileRenamed},
+	}, files)
+
+	_, err = client.ListPullRequestFiles(ctx, owner, "", prId)
+	assert.EqualError(t, err, "validation failed: required parameter 'repository' is missing")
+}
+
+func TestGitLabClient_GetPullRequestDiff(t *testing.T) {
+	ctx := context.Background()
+	prId := 5
+	diffs := []*gitlab.MergeRequestDiff{
+		{OldPath: "README.md", NewPath: "README.md", AMode: "100644", BMode: "100644", Diff: "@@ -1 +1,2 @@\n hello\n+world\n"},
+		{OldPath: "new.go", NewPath: "new.go", BMode: "100644", NewFile: true, Diff: "@@ -0,0 +1 @@\n+package main"},
+		{OldPath: "old.go", NewPath: "old.go", AMode: "100644", DeletedFile: true, Diff: "@@ -1 +0,0 @@\n-package main\n"},
+		{OldPath: "original.go", NewPath: "renamed.go", AMode: "100644", BMode: "100644", RenamedFile: true},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, diffs,
+		fmt.Sprintf("/api/v4/projects/%s/merge_requests/%d/diffs?page=1&per_page=100", url.PathEscape(owner+"/"+repo1), prId), createGitLabHandler)
+	defer cleanUp()
+
+	diff, err := client.GetPullRequestDiff(ctx, owner, repo1, prId)
+	assert.NoError(t, err)
+	assert.Equal(t, `diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1 +1,2 @@
+ hello
++world
+diff --git a/new.go b/new.go
+new file mode 100644
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1 @@
++package main
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package main
+diff --git a/original.go b/renamed.go
+rename from original.go
+rename to renamed.go
+`, diff)
+
+	_, err = client.GetPullRequestDiff(ctx, owner, "", prId)
+	assert.EqualError(t, err, "validation failed: required parameter 'repository' is missing")
+}
+
+func TestGitLabClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	committedDate := time.Unix(1667812601, 0)
+	compare := gitlab.Compare{
+		Commits: []*gitlab.Commit{{
+			ID:            "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			Message:       "Fix all the bugs",
+			AuthorName:    "frogger",
+			AuthorEmail:   "frogger@jfrog.com",
+			CommitterName: "frogger",
+			CommittedDate: &committedDate,
+			WebURL:        "https://gitlab.com/jfrog/repo-1/-/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			ParentIDs:     []string{"7638417db6d59f3c431d3e1f261cc637155684cd"},
+		}},
+		Diffs: []*gitlab.Diff{
+			{OldPath: "README.md", NewPath: "README.md"},
+			{OldPath: "old.go", NewPath: "old.go", DeletedFile: true},
+		},
+	}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, compare,
+		fmt.Sprintf("/api/v4/projects/%s/repository/compare?from=main&to=feature", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	result, err := client.CompareCommits(ctx, owner, repo1, "main", "feature")
+	assert.NoError(t, err)
+	assert.Equal(t, CommitsComparison{
+		Commits: []CommitInfo{{
+			Hash:          "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			AuthorName:    "frogger",
+			CommitterName: "frogger",
+			Url:           "https://gitlab.com/jfrog/repo-1/-/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			Timestamp:     1667812601,
+			Message:       "Fix all the bugs",
+			ParentHashes:  []string{"7638417db6d59f3c431d3e1f261cc637155684cd"},
+			AuthorEmail:   "frogger@jfrog.com",
+		}},
+		Files: []PullRequestFile{
+			{Path: "README.md", ChangeType: FileModified},
+			{Path: "old.go", ChangeType: FileDeleted},
+		},
+	}, result)
+
+	_, err = client.CompareCommits(ctx, owner, repo1, "main", "")
+	assert.EqualError(t, err, "validation failed: required parameter 'head' is missing")
+}
+
 func createGitLabHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.RequestURI == "/api/v4/" {
@@ -725,6 +1908,32 @@ func createGitLabHandlerWithoutExpectedURI(t *testing.T, _ string, response []by
 	}
 }
 
+func createPullRequestReviewGitLabHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, token, r.Header.Get("Private-Token"))
+		switch r.RequestURI {
+		case "/api/v4/":
+			w.WriteHeader(http.StatusOK)
+		case "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1/approve":
+			assert.Equal(t, http.MethodPost, r.Method)
+			_, err := w.Write([]byte("{}"))
+			assert.NoError(t, err)
+		case "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1/unapprove":
+			assert.Equal(t, http.MethodPost, r.Method)
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v4/projects/jfrog%2Frepo-1/merge_requests/1/notes":
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), "Please fix")
+			_, err = w.Write([]byte("{}"))
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "unexpected request", r.RequestURI)
+		}
+	}
+}
+
 func createAddPullRequestReviewCommentGitLabHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.RequestURI {
@@ -755,6 +1964,54 @@ func createAddPullRequestReviewCommentGitLabHandler(t *testing.T, _ string, _ []
 	}
 }
 
+func createUploadCodeScanningGitLabHandler(discussions, notes *[]string) createHandlerFunc {
+	return func(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, token, r.Header.Get("Private-Token"))
+			var response []byte
+			var err error
+			switch {
+			case r.RequestURI == "/api/v4/":
+			case strings.HasPrefix(r.RequestURI, "/api/v4/projects/jfrog%2Frepo-1/repository/commits?"):
+				assert.Contains(t, r.RequestURI, "ref_name=master")
+				response, err = os.ReadFile(filepath.Join("testdata", "gitlab", "commit_list_response.json"))
+				assert.NoError(t, err)
+			case r.RequestURI == "/api/v4/projects/jfrog%2Frepo-1/statuses/ed899a2f4b50b4370feeea94676502b42383c746":
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Contains(t, string(body), `"state":"failed"`)
+				assert.Contains(t, string(body), `"name":"Xray"`)
+				assert.Contains(t, string(body), `"description":"Xray found 2 issues"`)
+				response = []byte("{}")
+			case strings.HasPrefix(r.RequestURI, "/api/v4/projects/jfrog%2Frepo-1/merge_requests?"):
+				assert.Contains(t, r.RequestURI, "source_branch=master")
+				response = []byte(`[{"iid": 7, "source_branch": "master", "target_branch": "main"}]`)
+			case r.RequestURI == "/api/v4/projects/jfrog%2Frepo-1/merge_requests/7/versions":
+				response, err = os.ReadFile(filepath.Join("testdata", "gitlab", "merge_request_diff_versions.json"))
+				assert.NoError(t, err)
+			case r.RequestURI == "/api/v4/projects/jfrog%2Frepo-1/merge_requests/7/diffs":
+				response, err = os.ReadFile(filepath.Join("testdata", "gitlab", "merge_request_changes.json"))
+				assert.NoError(t, err)
+			case r.RequestURI == "/api/v4/projects/jfrog%2Frepo-1/merge_requests/7/discussions":
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				*discussions = append(*discussions, string(body))
+				response, err = os.ReadFile(filepath.Join("testdata", "gitlab", "new_merge_request_thread.json"))
+				assert.NoError(t, err)
+			case r.RequestURI == "/api/v4/projects/jfrog%2Frepo-1/merge_requests/7/notes":
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				*notes = append(*notes, string(body))
+				response = []byte("{}")
+			default:
+				assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+			}
+			_, err = w.Write(response)
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func createDownloadRepositoryGitLabHandler(t *testing.T, expectedURI string, response []byte, expectedStatusCode int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.RequestURI == "/api/v4/" {
@@ -867,7 +2124,7 @@ func TestGitLabClient_TestGetCommitStatus(t *testing.T) {
 	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
 	t.Run("Empty response", func(t *testing.T) {
 		client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, []CommitStatusInfo{},
-			fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s/statuses", repo1, ref),
+			fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s/statuses", url.PathEscape(owner+"/"+repo1), ref),
 			createGitLabHandler)
 		defer cleanUp()
 		_, err := client.GetCommitStatuses(ctx, owner, repo1, ref)
@@ -877,12 +2134,14 @@ func TestGitLabClient_TestGetCommitStatus(t *testing.T) {
 		response, err := os.ReadFile(filepath.Join("testdata", "gitlab", "commits_statuses.json"))
 		assert.NoError(t, err)
 		client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
-			fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s/statuses", repo1, ref),
+			fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s/statuses", url.PathEscape(owner+"/"+repo1), ref),
 			createGitLabHandler)
 		defer cleanUp()
 		commitStatuses, err := client.GetCommitStatuses(ctx, owner, repo1, ref)
 		assert.Len(t, commitStatuses, 3)
 		assert.Equal(t, Pass, commitStatuses[0].State)
+		assert.Equal(t, "success", commitStatuses[0].RawState)
+		assert.Equal(t, "bundler:audit", commitStatuses[0].Context)
 		assert.Equal(t, InProgress, commitStatuses[1].State)
 		assert.Equal(t, Fail, commitStatuses[2].State)
 		assert.NoError(t, err)
@@ -891,7 +2150,7 @@ func TestGitLabClient_TestGetCommitStatus(t *testing.T) {
 		response, err := os.ReadFile(filepath.Join("testdata", "github", "commits_statuses_bad_json.json"))
 		assert.NoError(t, err)
 		client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
-			fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s/statuses", repo1, ref),
+			fmt.Sprintf("/api/v4/projects/%s/repository/commits/%s/statuses", url.PathEscape(owner+"/"+repo1), ref),
 			createGitLabHandler)
 		defer cleanUp()
 		_, err = client.GetCommitStatuses(ctx, owner, repo1, ref)
@@ -904,6 +2163,103 @@ func TestGitLabClient_TestGetCommitStatus(t *testing.T) {
 	})
 }
 
+func TestGitLabClient_GetCombinedCommitStatus(t *testing.T) {
+	ctx := context.Background()
+	ref := "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69"
+	testCases := []struct {
+		name           string
+		ref            string
+		query          string
+		response       []gitlab.PipelineInfo
+		expectedStatus CommitStatus
+	}{
+		{name: "No pipelines", ref: branch1, query: "ref=" + branch1, response: []gitlab.PipelineInfo{}, expectedStatus: Pass},
+		{name: "Successful pipeline", ref: ref, query: "sha=" + ref, response: []gitlab.PipelineInfo{{Status: "success"}}, expectedStatus: Pass},
+		{name: "Running pipeline", ref: branch1, query: "ref=" + branch1, response: []gitlab.PipelineInfo{{Status: "running"}}, expectedStatus: InProgress},
+		{name: "Failed pipeline", ref: ref, query: "sha=" + ref, response: []gitlab.PipelineInfo{{Status: "failed"}}, expectedStatus: Fail},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, tc.response,
+				fmt.Sprintf("/api/v4/projects/%s/pipelines?per_page=1&%s", url.PathEscape(owner+"/"+repo1), tc.query), createGitLabHandler)
+			defer cleanUp()
+			status, err := client.GetCombinedCommitStatus(ctx, owner, repo1, tc.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
+func TestGitLabClient_TriggerPipeline(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	response := gitlab.Pipeline{ID: 42, Status: "created", Ref: branch1, SHA: "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69", WebURL: "https://gitlab.com/jfrog/repo-1/-/pipelines/42", CreatedAt: &createdAt}
+	responseBody, err := json.Marshal(response)
+	assert.NoError(t, err)
+	client, cleanUp := createBodyHandlingServerAndClient(t, vcsutils.GitLab, false, responseBody, fmt.Sprintf("/api/v4/projects/%s/pipeline", url.PathEscape(owner+"/"+repo1)), http.StatusCreated,
+		[]byte(`{"ref":"branch-1","variables":[{"key":"DEPLOY","value":"false"},{"key":"ENV","value":"staging"}]}`), http.MethodPost, createGitLabWithBodyHandler)
+	defer cleanUp()
+
+	pipeline, err := client.TriggerPipeline(ctx, owner, repo1, branch1, map[string]string{"ENV": "staging", "DEPLOY": "false"})
+	assert.NoError(t, err)
+	assert.Equal(t, PipelineInfo{
+		ID:        "42",
+		Status:    InProgress,
+		RawStatus: "created",
+		Ref:       branch1,
+		CommitSHA: "5fbf81b31ff7a3b06bd362d1891e2f01bdb2be69",
+		WebURL:    "https://gitlab.com/jfrog/repo-1/-/pipelines/42",
+		CreatedAt: createdAt,
+	}, pipeline)
+
+	_, err = client.TriggerPipeline(ctx, owner, repo1, "", nil)
+	assert.ErrorContains(t, err, "required parameter 'ref' is missing")
+}
+
+func TestGitLabClient_GetLatestPipelineStatus(t *testing.T) {
+	ctx := context.Background()
+	response := []gitlab.PipelineInfo{{ID: 42, Status: "failed", Ref: branch1}}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/pipelines?per_page=1&ref=%s", url.PathEscape(owner+"/"+repo1), branch1), createGitLabHandler)
+	defer cleanUp()
+
+	pipeline, err := client.GetLatestPipelineStatus(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "42", Status: Fail, RawStatus: "failed", Ref: branch1}, pipeline)
+
+	client, cleanUp = createServerAndClient(t, vcsutils.GitLab, false, []gitlab.PipelineInfo{},
+		fmt.Sprintf("/api/v4/projects/%s/pipelines?per_page=1&ref=%s", url.PathEscape(owner+"/"+repo1), branch1), createGitLabHandler)
+	defer cleanUp()
+	_, err = client.GetLatestPipelineStatus(ctx, owner, repo1, branch1)
+	assert.EqualError(t, err, "no pipeline was found for ref 'branch-1'")
+}
+
+func TestGitLabClient_GetPipelineStatus(t *testing.T) {
+	ctx := context.Background()
+	response := gitlab.Pipeline{ID: 42, Status: "success", Ref: branch1}
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, response,
+		fmt.Sprintf("/api/v4/projects/%s/pipelines/42", url.PathEscape(owner+"/"+repo1)), createGitLabHandler)
+	defer cleanUp()
+
+	pipeline, err := client.GetPipelineStatus(ctx, owner, repo1, "42")
+	assert.NoError(t, err)
+	assert.Equal(t, PipelineInfo{ID: "42", Status: Pass, RawStatus: "success", Ref: branch1}, pipeline)
+
+	_, err = client.GetPipelineStatus(ctx, owner, repo1, "latest")
+	assert.ErrorContains(t, err, "invalid pipeline ID 'latest'")
+}
+
+func TestGitLabClient_Workflows(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := createServerAndClient(t, vcsutils.GitLab, false, nil, "", createGitLabHandler)
+	defer cleanUp()
+	assert.ErrorIs(t, client.TriggerWorkflow(ctx, owner, repo1, "build.yml", branch1, nil), errGitLabWorkflowsNotSupported)
+	_, err := client.GetWorkflowRunStatus(ctx, owner, repo1, "build.yml", branch1)
+	assert.ErrorIs(t, err, errGitLabWorkflowsNotSupported)
+	_, err = client.QueueBuild(ctx, owner, repo1, 7, branch1, nil)
+	assert.ErrorIs(t, err, errGitLabBuildsNotSupported)
+}
+
 func TestGitLabClient_getProjectOwnerByID(t *testing.T) {
 	projectID := 47457684
 
@@ -929,3 +2285,83 @@ func TestGitLabClient_getProjectOwnerByID(t *testing.T) {
 	assert.Error(t, err)
 	assert.NotEqual(t, "test", projectOwner)
 }
+
+func createUploadReleaseAssetGitLabHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projectPath := "/api/v4/projects/" + url.PathEscape(owner+"/"+repo1)
+		var response string
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET " + projectPath:
+			response = `{"id": 1, "web_url": "https://gitlab.com/jfrog/repo-1"}`
+		case "POST " + projectPath + "/uploads":
+			file, header, err := r.FormFile("file")
+			assert.NoError(t, err)
+			assert.Equal(t, "app.zip", header.Filename)
+			content, err := io.ReadAll(file)
+			assert.NoError(t, err)
+			assert.Equal(t, "asset content", string(content))
+			response = `{"alt": "app.zip", "url": "/uploads/66dbcd21ec5d24ed6ea225176098d52b/app.zip"}`
+		case "POST " + projectPath + "/releases/v1%2E0%2E0/assets/links":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"name":"app.zip","url":"https://gitlab.com/jfrog/repo-1/uploads/66dbcd21ec5d24ed6ea225176098d52b/app.zip"}`, string(body))
+			response = `{"id": 1, "name": "app.zip", "url": "https://gitlab.com/jfrog/repo-1/uploads/66dbcd21ec5d24ed6ea225176098d52b/app.zip"}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createCreateRepositoryGitLabHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/namespaces/jfrog":
+			response = `{"id": 7, "path": "jfrog", "kind": "group"}`
+		case "POST /api/v4/projects":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"name":"repo-1","path":"repo-1","namespace_id":7,"description":"Frogs","visibility":"private","initialize_with_readme":true,"default_branch":"develop"}`, string(body))
+			response = `{"id": 1, "visibility": "private", "http_url_to_repo": "https://gitlab.com/jfrog/repo-1.git", "ssh_url_to_repo": "git@gitlab.com:jfrog/repo-1.git"}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
+
+func createBranchProtectionGitLabHandler(t *testing.T, _ string, _ []byte, _ int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, token, r.Header.Get("Private-Token"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		projectPath := "/api/v4/projects/" + url.PathEscape(owner+"/"+repo1)
+		var response string
+		switch r.Method + " " + r.RequestURI {
+		case "GET /api/v4/":
+		case "GET " + projectPath + "/protected_branches/" + branch1:
+			response = `{"id": 5, "name": "` + branch1 + `", "push_access_levels": [{"access_level": 0}]}`
+		case "GET " + projectPath + "/approval_rules":
+			response = `[
+				{"id": 1, "name": "` + branch1 + ` approvals", "approvals_required": 2, "protected_branches": [{"id": 5}]},
+				{"id": 2, "name": "` + branch2 + ` approvals", "approvals_required": 3, "protected_branches": [{"id": 6}]}
+			]`
+		case "DELETE " + projectPath + "/approval_rules/1", "DELETE " + projectPath + "/protected_branches/" + branch1:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case "POST " + projectPath + "/protected_branches":
+			assert.JSONEq(t, `{"name": "`+branch1+`", "push_access_level": 0, "merge_access_level": 40}`, string(body))
+			response = `{"id": 7, "name": "` + branch1 + `"}`
+		case "POST " + projectPath + "/approval_rules":
+			assert.JSONEq(t, `{"name": "`+branch1+` approvals", "approvals_required": 2, "protected_branch_ids": [7]}`, string(body))
+			response = `{"id": 3}`
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.RequestURI)
+		}
+		_, err = w.Write([]byte(response))
+		assert.NoError(t, err)
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"errors"
 )
 
-var errGitLabCodeScanningNotSupported = errors.New("code scanning is not supported on Gitlab")
+var errGitLabCodeInsightsNotSupported = errors.New("code insights reports are not supported on GitLab")
+var errGitLabCheckRunsNotSupported = errors.New("check runs are not supported on GitLab")
+var errGitLabAppInstallationsNotSupported = errors.New("app installations are not supported on GitLab")
+var errGitLabWorkflowsNotSupported = errors.New("workflows are not supported on GitLab, use pipelines instead")
+var errGitLabBuildsNotSupported = errors.New("queueing builds is not supported on GitLab, trigger a pipeline instead")
+var errGitLabSecretsNotSupported = errors.New("organization and environment secrets are not supported on GitLab")
 var errGitLabGetRepoEnvironmentInfoNotSupported = errors.New("get repository environment info is currently not supported on Bitbucket")
 
 const (
@@ -12,4 +17,7 @@ const (
 	gitlabMergeRequestDetailsSizeLimit = 1048576
 	// https://docs.gitlab.com/ee/api/notes.html#create-new-merge-request-note
 	gitlabMergeRequestCommentSizeLimit = 1000000
+	// Rebasing a merge request is asynchronous, so its status is polled until the rebase is completed
+	gitlabRebaseMaxRetries               = 30
+	gitlabRebaseRetriesIntervalMilliSecs = 1000
 )
@@ -0,0 +1,174 @@
+package vcsclient
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// pageIterator lazily lists the pages of a listing and iterates over their items.
+// The listing ends at the first empty page, since some providers return pages which are shorter than requested.
+// listPage - Lists the items of a page, before they are filtered
+// matches  - Filters the listed items, used for filters which some providers apply only to the fetched page
+type pageIterator[T any] struct {
+	listPage func(ctx context.Context, page int) ([]T, error)
+	matches  func(item T) bool
+	nextPage int
+	items    []T
+	current  T
+	done     bool
+	err      error
+}
+
+func newPageIterator[T any](firstPage int, listPage func(ctx context.Context, page int) ([]T, error), matches func(item T) bool) pageIterator[T] {
+	if firstPage < 1 {
+		firstPage = 1
+	}
+	return pageIterator[T]{listPage: listPage, matches: matches, nextPage: firstPage}
+}
+
+func (iterator *pageIterator[T]) next(ctx context.Context) bool {
+	for len(iterator.items) == 0 {
+		if iterator.done || iterator.err != nil {
+			return false
+		}
+		var items []T
+		if items, iterator.err = iterator.listPage(ctx, iterator.nextPage); iterator.err != nil {
+			return false
+		}
+		iterator.done = len(items) == 0
+		iterator.nextPage++
+		for _, item := range items {
+			if iterator.matches == nil || iterator.matches(item) {
+				iterator.items = append(iterator.items, item)
+			}
+		}
+	}
+	iterator.current, iterator.items = iterator.items[0], iterator.items[1:]
+	return true
+}
+
+// RepositoriesIterator lazily lists the accessible repositories, a page at a time.
+// Usage:
+//
+//	iterator := NewRepositoriesIterator(client, ListOptions{})
+//	for iterator.Next(ctx) {
+//		owner, repository := iterator.Repository()
+//	}
+//	err := iterator.Err()
+type RepositoriesIterator struct {
+	pageIterator[repositoryName]
+}
+
+type repositoryName struct {
+	owner      string
+	repository string
+}
+
+// NewRepositoriesIterator creates an iterator over the repositories listed by ListRepositoriesWithOptions.
+// options - The first page and the page size of the listing
+func NewRepositoriesIterator(client VcsClient, options ListOptions) *RepositoriesIterator {
+	_, perPage := options.getPagination()
+	listPage := func(ctx context.Context, page int) ([]repositoryName, error) {
+		repositories, err := client.ListRepositoriesWithOptions(ctx, ListOptions{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, err
+		}
+		var results []repositoryName
+		for owner, ownerRepositories := range repositories {
+			for _, repository := range ownerRepositories {
+				results = append(results, repositoryName{owner: owner, repository: repository})
+			}
+		}
+		// The order of the owners in the page isn't kept by the map
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].owner < results[j].owner
+		})
+		return results, nil
+	}
+	return &RepositoriesIterator{newPageIterator(options.Page, listPage, nil)}
+}
+
+// Next advances the iterator to the next repository, and returns false when there are no more repositories or an error occurred
+func (iterator *RepositoriesIterator) Next(ctx context.Context) bool {
+	return iterator.next(ctx)
+}
+
+// Repository returns the owner and the name of the current repository
+func (iterator *RepositoriesIterator) Repository() (owner, repository string) {
+	return iterator.current.owner, iterator.current.repository
+}
+
+// Err returns the error which stopped the iteration, if any
+func (iterator *RepositoriesIterator) Err() error {
+	return iterator.err
+}
+
+// CommitsIterator lazily lists the commits of a branch, a page at a time
+type CommitsIterator struct {
+	pageIterator[CommitInfo]
+}
+
+// NewCommitsIterator creates an iterator over the commits listed by ListCommits.
+// The time and the author filters of the options are applied by the iterator, since some providers apply them only to the fetched page.
+// owner      - User or organization
+// repository - VCS repository name
+// options    - The branch, the filters, the first page and the page size of the listing
+func NewCommitsIterator(client VcsClient, owner, repository string, options ListCommitsOptions) *CommitsIterator {
+	_, perPage := options.getPagination()
+	listPage := func(ctx context.Context, page int) ([]CommitInfo, error) {
+		return client.ListCommits(ctx, owner, repository, ListCommitsOptions{Branch: options.Branch, Path: options.Path, Page: page, PerPage: perPage})
+	}
+	matches := func(commit CommitInfo) bool {
+		return options.matches(commit, time.Unix(commit.Timestamp, 0))
+	}
+	return &CommitsIterator{newPageIterator(options.Page, listPage, matches)}
+}
+
+// Next advances the iterator to the next commit, and returns false when there are no more commits or an error occurred
+func (iterator *CommitsIterator) Next(ctx context.Context) bool {
+	return iterator.next(ctx)
+}
+
+// Commit returns the current commit
+func (iterator *CommitsIterator) Commit() CommitInfo {
+	return iterator.current
+}
+
+// Err returns the error which stopped the iteration, if any
+func (iterator *CommitsIterator) Err() error {
+	return iterator.err
+}
+
+// PullRequestsIterator lazily lists the open pull requests of a repository, a page at a time
+type PullRequestsIterator struct {
+	pageIterator[PullRequestInfo]
+}
+
+// NewOpenPullRequestsIterator creates an iterator over the pull requests listed by ListOpenPullRequestsWithOptions.
+// The filters of the options are applied by the iterator, since some providers apply them only to the fetched page.
+// owner      - User or organization
+// repository - VCS repository name
+// options    - The filters, the first page and the page size of the listing
+func NewOpenPullRequestsIterator(client VcsClient, owner, repository string, options ListPullRequestsOptions) *PullRequestsIterator {
+	_, perPage := options.getPagination()
+	listPage := func(ctx context.Context, page int) ([]PullRequestInfo, error) {
+		return client.ListOpenPullRequestsWithOptions(ctx, owner, repository, ListPullRequestsOptions{WithBody: options.WithBody, Page: page, PerPage: perPage})
+	}
+	return &PullRequestsIterator{newPageIterator(options.Page, listPage, options.matches)}
+}
+
+// Next advances the iterator to the next pull request, and returns false when there are no more pull requests or an error occurred
+func (iterator *PullRequestsIterator) Next(ctx context.Context) bool {
+	return iterator.next(ctx)
+}
+
+// PullRequest returns the current pull request
+func (iterator *PullRequestsIterator) PullRequest() PullRequestInfo {
+	return iterator.current
+}
+
+// Err returns the error which stopped the iteration, if any
+func (iterator *PullRequestsIterator) Err() error {
+	return iterator.err
+}
@@ -0,0 +1,109 @@
+package vcsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoriesIterator(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	localGitClient, ok := testRepository.client.(*LocalGitClient)
+	assert.True(t, ok)
+	for _, repositoryPath := range []string{localGitClient.getRepositoryPath(owner, repo2), localGitClient.getRepositoryPath(username, repo1)} {
+		_, err := git.PlainInit(repositoryPath, false)
+		assert.NoError(t, err)
+	}
+
+	iterator := NewRepositoriesIterator(testRepository.client, ListOptions{PerPage: 2})
+	var actual []string
+	for iterator.Next(ctx) {
+		repositoryOwner, repository := iterator.Repository()
+		actual = append(actual, repositoryOwner+"/"+repository)
+	}
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{username + "/" + repo1, owner + "/" + repo1, owner + "/" + repo2}, actual)
+	assert.False(t, iterator.Next(ctx))
+}
+
+func TestCommitsIterator(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+
+	iterator := NewCommitsIterator(testRepository.client, owner, repo1, ListCommitsOptions{Branch: branch2, PerPage: 1})
+	var actual []string
+	for iterator.Next(ctx) {
+		actual = append(actual, iterator.Commit().Hash)
+	}
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{testRepository.commits[2].String(), testRepository.commits[0].String()}, actual)
+
+	// The filters are applied by the iterator, hence pages without matching commits don't stop the iteration
+	until := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	iterator = NewCommitsIterator(testRepository.client, owner, repo1, ListCommitsOptions{Branch: branch2, Until: until, PerPage: 1})
+	actual = nil
+	for iterator.Next(ctx) {
+		actual = append(actual, iterator.Commit().Hash)
+	}
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{testRepository.commits[0].String()}, actual)
+
+	iterator = NewCommitsIterator(testRepository.client, owner, repo2, ListCommitsOptions{Branch: branch1})
+	assert.False(t, iterator.Next(ctx))
+	assert.Error(t, iterator.Err())
+}
+
+func TestPullRequestsIterator(t *testing.T) {
+	ctx := context.Background()
+	pullRequest := func(number int, author string) string {
+		branch := fmt.Sprintf(`{"label": "jfrog:branch-%d", "repo": {"name": "repo-1", "owner": {"login": "jfrog"}}}`, number)
+		return fmt.Sprintf(`{"number": %d, "state": "open", "user": {"login": %q}, "head": %s, "base": %s}`, number, author, branch, branch)
+	}
+	pages := map[string]string{
+		"1": "[" + pullRequest(1, "frogger") + "," + pullRequest(2, "toad") + "]",
+		"2": "[" + pullRequest(3, "toad") + "]",
+		"3": "[" + pullRequest(4, "frogger") + "]",
+		"4": "[]",
+	}
+	var requestedPages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/repos/%s/%s/pulls", owner, repo1), r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+		_, err := w.Write([]byte(pages[page]))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := buildClient(t, vcsutils.GitHub, false, server)
+
+	iterator := NewOpenPullRequestsIterator(client, owner, repo1, ListPullRequestsOptions{Author: "frogger", PerPage: 2})
+	var actual []int64
+	for iterator.Next(ctx) {
+		actual = append(actual, iterator.PullRequest().ID)
+	}
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []int64{1, 4}, actual)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, requestedPages)
+
+	// Starts from the page of the options
+	requestedPages = nil
+	iterator = NewOpenPullRequestsIterator(client, owner, repo1, ListPullRequestsOptions{Page: 3, PerPage: 2})
+	assert.True(t, iterator.Next(ctx))
+	assert.Equal(t, int64(4), iterator.PullRequest().ID)
+	assert.False(t, iterator.Next(ctx))
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{"3", "4"}, requestedPages)
+
+	iterator = NewOpenPullRequestsIterator(createBadGitHubClient(t), owner, repo1, ListPullRequestsOptions{})
+	assert.False(t, iterator.Next(ctx))
+	assert.Error(t, iterator.Err())
+}
@@ -0,0 +1,1109 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/jfrog/gofrog/datastructures"
+)
+
+const (
+	// The name of the tagger of annotated tags, when the username isn't set
+	localGitDefaultTaggerName = "froggit-go"
+	notSupportedOnLocalGit    = "not supported on local Git repositories"
+)
+
+var (
+	errLocalGitAuthenticatedUserNotSupported    = fmt.Errorf("getting the authenticated user is %s", notSupportedOnLocalGit)
+	errLocalGitAppInstallationsNotSupported     = fmt.Errorf("app installations are %s", notSupportedOnLocalGit)
+	errLocalGitBranchProtectionNotSupported     = fmt.Errorf("branch protection is %s", notSupportedOnLocalGit)
+	errLocalGitReleasesNotSupported             = fmt.Errorf("releases are %s", notSupportedOnLocalGit)
+	errLocalGitSshKeysNotSupported              = fmt.Errorf("repository SSH keys are %s", notSupportedOnLocalGit)
+	errLocalGitWebhooksNotSupported             = fmt.Errorf("webhooks are %s", notSupportedOnLocalGit)
+	errLocalGitCommitStatusesNotSupported       = fmt.Errorf("commit statuses are %s", notSupportedOnLocalGit)
+	errLocalGitPullRequestsNotSupported         = fmt.Errorf("pull requests are %s", notSupportedOnLocalGit)
+	errLocalGitRepositoryManagementNotSupported = fmt.Errorf("creating, deleting and forking repositories is %s, use git init and git clone instead",
+		notSupportedOnLocalGit)
+	errLocalGitCollaboratorsNotSupported = fmt.Errorf("repository collaborators are %s", notSupportedOnLocalGit)
+	errLocalGitLabelsNotSupported        = fmt.Errorf("labels are %s", notSupportedOnLocalGit)
+	errLocalGitIssuesNotSupported        = fmt.Errorf("issues are %s", notSupportedOnLocalGit)
+	errLocalGitCodeScanningNotSupported  = fmt.Errorf("code scanning is %s", notSupportedOnLocalGit)
+	errLocalGitCodeInsightsNotSupported  = fmt.Errorf("code insights reports are %s", notSupportedOnLocalGit)
+	errLocalGitCheckRunsNotSupported     = fmt.Errorf("check runs are %s", notSupportedOnLocalGit)
+	errLocalGitEnvironmentsNotSupported  = fmt.Errorf("get repository environment info is %s", notSupportedOnLocalGit)
+	errLocalGitCommitFilesNotSupported   = fmt.Errorf("committing files is %s, commit in a working tree instead", notSupportedOnLocalGit)
+	errLocalGitPipelinesNotSupported     = fmt.Errorf("pipelines are %s", notSupportedOnLocalGit)
+	errLocalGitDeploymentsNotSupported   = fmt.Errorf("deployments and environments are %s", notSupportedOnLocalGit)
+	errLocalGitSecretsNotSupported       = fmt.Errorf("repository secrets and variables are %s", notSupportedOnLocalGit)
+	errLocalGitOrgSecretsNotSupported    = fmt.Errorf("organization and environment secrets are %s", notSupportedOnLocalGit)
+	errLocalGitSetHeadOfWorktree         = errors.New("the default branch of a repository with a working tree is the checked out branch, check out the branch instead")
+)
+
+// LocalGitClient works with Git repositories on the local filesystem through go-git, without the API of a VCS provider.
+// The API endpoint is the directory of the repositories, and each repository is found at <API endpoint>/<owner>/<repository>.
+// Branches, tags, commits, file contents and diffs are supported, while pull requests and the other features of VCS providers aren't.
+type LocalGitClient struct {
+	vcsInfo VcsInfo
+	logger  vcsutils.Log
+}
+
+// NewLocalGitClient create a new LocalGitClient
+func NewLocalGitClient(vcsInfo VcsInfo, logger vcsutils.Log) (*LocalGitClient, error) {
+	if err := validateParametersNotBlank(map[string]string{"apiEndpoint": vcsInfo.APIEndpoint}); err != nil {
+		return nil, err
+	}
+	return &LocalGitClient{vcsInfo: vcsInfo, logger: logger}, nil
+}
+
+// TestConnection on local Git repositories checks that the directory of the repositories exists
+func (client *LocalGitClient) TestConnection(_ context.Context) error {
+	info, err := os.Stat(client.vcsInfo.APIEndpoint)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", client.vcsInfo.APIEndpoint)
+	}
+	return nil
+}
+
+// TestConnectionWithScopes on local Git repositories.
+// The repositories are accessed with the filesystem permissions of the current user, so the scopes aren't verified.
+func (client *LocalGitClient) TestConnectionWithScopes(ctx context.Context, _ ...TokenScope) error {
+	return client.TestConnection(ctx)
+}
+
+// GetAuthenticatedUser on local Git repositories
+func (client *LocalGitClient) GetAuthenticatedUser(_ context.Context) (UserInfo, error) {
+	return UserInfo{}, errLocalGitAuthenticatedUserNotSupported
+}
+
+// ListRepositories on local Git repositories.
+// The subdirectories of each owner directory which aren't Git repositories are skipped.
+func (client *LocalGitClient) ListRepositories(_ context.Context) (map[string][]string, error) {
+	ownerEntries, err := os.ReadDir(client.vcsInfo.APIEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string][]string)
+	for _, ownerEntry := range ownerEntries {
+		if !ownerEntry.IsDir() {
+			continue
+		}
+		repositoryEntries, err := os.ReadDir(filepath.Join(client.vcsInfo.APIEndpoint, ownerEntry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, repositoryEntry := range repositoryEntries {
+			if !repositoryEntry.IsDir() {
+				continue
+			}
+			if _, err = git.PlainOpen(client.getRepositoryPath(ownerEntry.Name(), repositoryEntry.Name())); err == nil {
+				results[ownerEntry.Name()] = append(results[ownerEntry.Name()], repositoryEntry.Name())
+			}
+		}
+	}
+	return results, nil
+}
+
+// ListAppInstallations on local Git repositories
+func (client *LocalGitClient) ListAppInstallations(_ context.Context) ([]AppInstallationInfo, error) {
+	return nil, errLocalGitAppInstallationsNotSupported
+}
+
+// ListInstallationRepositories on local Git repositories
+func (client *LocalGitClient) ListInstallationRepositories(_ context.Context, _ int64) (map[string][]string, error) {
+	return nil, errLocalGitAppInstallationsNotSupported
+}
+
+// ListRepositoriesWithOptions on local Git repositories.
+// The repositories are read from the directories of all the owners, hence all the repositories are listed and the page is taken from them.
+func (client *LocalGitClient) ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error) {
+	repositories, err := client.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getRepositoriesPage(repositories, options), nil
+}
+
+// ListBranches on local Git repositories
+func (client *LocalGitClient) ListBranches(_ context.Context, owner, repository string) ([]string, error) {
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := repo.Branches()
+	if err != nil {
+		return nil, err
+	}
+	var results []string
+	err = branches.ForEach(func(branch *plumbing.Reference) error {
+		results = append(results, branch.Name().Short())
+		return nil
+	})
+	return results, err
+}
+
+// ListBranchesWithOptions on local Git repositories.
+// The references of a repository have no order, hence all the branches are listed and the page is taken from them.
+func (client *LocalGitClient) ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error) {
+	branches, err := client.ListBranches(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(branches)
+	return getListPage(branches, options), nil
+}
+
+// CreateBranch on local Git repositories.
+// The source reference is either a branch name, a tag name or a commit SHA.
+func (client *LocalGitClient) CreateBranch(_ context.Context, owner, repository, sourceRef, newBranch string) error {
+	if err := validateParametersNotBlank(map[string]string{"sourceRef": sourceRef, "newBranch": newBranch}); err != nil {
+		return err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return err
+	}
+	sourceCommit, err := resolveLocalGitCommit(repo, sourceRef)
+	if err != nil {
+		return err
+	}
+	branchName := plumbing.NewBranchReferenceName(newBranch)
+	if _, err = repo.Reference(branchName, false); err == nil {
+		return fmt.Errorf("branch %s already exists", newBranch)
+	}
+	return repo.Storer.SetReference(plumbing.NewHashReference(branchName, sourceCommit.Hash))
+}
+
+// DeleteBranch on local Git repositories
+func (client *LocalGitClient) DeleteBranch(_ context.Context, owner, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"branch": branch}); err != nil {
+		return err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return err
+	}
+	branchName := plumbing.NewBranchReferenceName(branch)
+	if _, err = repo.Reference(branchName, false); err != nil {
+		return fmt.Errorf("failed to find branch %s: %w", branch, err)
+	}
+	return repo.Storer.RemoveReference(branchName)
+}
+
+// GetDefaultBranch on local Git repositories returns the branch which HEAD points to
+func (client *LocalGitClient) GetDefaultBranch(_ context.Context, owner, repository string) (string, error) {
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", err
+	}
+	if head.Type() != plumbing.SymbolicReference {
+		return "", errors.New("HEAD is detached, and doesn't point to a branch")
+	}
+	return head.Target().Short(), nil
+}
+
+// SetDefaultBranch on local Git repositories points HEAD to the branch.
+// It's supported on bare repositories only, since HEAD is the checked out branch of a repository with a working tree.
+func (client *LocalGitClient) SetDefaultBranch(_ context.Context, owner, repository, branch string) error {
+	if err := validateParametersNotBlank(map[string]string{"branch": branch}); err != nil {
+		return err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return err
+	}
+	if _, err = repo.Worktree(); err == nil {
+		return errLocalGitSetHeadOfWorktree
+	}
+	branchName := plumbing.NewBranchReferenceName(branch)
+	if _, err = repo.Reference(branchName, false); err != nil {
+		return fmt.Errorf("failed to find branch %s: %w", branch, err)
+	}
+	return repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branchName))
+}
+
+// GetBranchProtection on local Git repositories
+func (client *LocalGitClient) GetBranchProtection(_ context.Context, _, _, _ string) (BranchProtectionInfo, error) {
+	return BranchProtectionInfo{}, errLocalGitBranchProtectionNotSupported
+}
+
+// SetBranchProtection on local Git repositories
+func (client *LocalGitClient) SetBranchProtection(_ context.Context, _, _, _ string, _ BranchProtectionInfo) error {
+	return errLocalGitBranchProtectionNotSupported
+}
+
+// ListTags on local Git repositories
+func (client *LocalGitClient) ListTags(_ context.Context, owner, repository string) ([]TagInfo, error) {
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var results []TagInfo
+	err = tags.ForEach(func(tag *plumbing.Reference) error {
+		commit, err := resolveLocalGitCommit(repo, tag.Name().String())
+		if err != nil {
+			return err
+		}
+		results = append(results, TagInfo{Name: tag.Name().Short(), CommitSha: commit.Hash.String()})
+		return nil
+	})
+	return results, err
+}
+
+// CreateTag on local Git repositories.
+// A lightweight tag is created if the message is empty, and an annotated tag by the username is created otherwise.
+func (client *LocalGitClient) CreateTag(_ context.Context, owner, repository, tagName, sourceRef, message string) error {
+	if err := validateParametersNotBlank(map[string]string{"tagName": tagName, "sourceRef": sourceRef}); err != nil {
+		return err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return err
+	}
+	sourceCommit, err := resolveLocalGitCommit(repo, sourceRef)
+	if err != nil {
+		return err
+	}
+	var options *git.CreateTagOptions
+	if message != "" {
+		tagger := &object.Signature{Name: client.vcsInfo.Username, When: time.Now()}
+		if tagger.Name == "" {
+			tagger.Name = localGitDefaultTaggerName
+		}
+		options = &git.CreateTagOptions{Tagger: tagger, Message: message}
+	}
+	_, err = repo.CreateTag(tagName, sourceCommit.Hash, options)
+	return err
+}
+
+// GetTagInfo on local Git repositories
+func (client *LocalGitClient) GetTagInfo(_ context.Context, owner, repository, tagName string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"tagName": tagName}); err != nil {
+		return CommitInfo{}, err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commit, err := resolveLocalGitCommit(repo, plumbing.NewTagReferenceName(tagName).String())
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapLocalGitCommitToCommitInfo(commit), nil
+}
+
+// CreateRelease on local Git repositories
+func (client *LocalGitClient) CreateRelease(_ context.Context, _, _, _, _, _ string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errLocalGitReleasesNotSupported
+}
+
+// ListReleases on local Git repositories
+func (client *LocalGitClient) ListReleases(_ context.Context, _, _ string) ([]ReleaseInfo, error) {
+	return nil, errLocalGitReleasesNotSupported
+}
+
+// GetLatestRelease on local Git repositories
+func (client *LocalGitClient) GetLatestRelease(_ context.Context, _, _ string) (ReleaseInfo, error) {
+	return ReleaseInfo{}, errLocalGitReleasesNotSupported
+}
+
+// UploadReleaseAsset on local Git repositories
+func (client *LocalGitClient) UploadReleaseAsset(_ context.Context, _, _, _, _ string, _ io.Reader) (string, error) {
+	return "", errLocalGitReleasesNotSupported
+}
+
+// AddSshKeyToRepository on local Git repositories
+func (client *LocalGitClient) AddSshKeyToRepository(_ context.Context, _, _, _, _ string, _ Permission) error {
+	return errLocalGitSshKeysNotSupported
+}
+
+// CreateWebhook on local Git repositories
+func (client *LocalGitClient) CreateWebhook(_ context.Context, _, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", errLocalGitWebhooksNotSupported
+}
+
+// CreateOrUpdateWebhook on local Git repositories
+func (client *LocalGitClient) CreateOrUpdateWebhook(_ context.Context, _, _, _, _ string, _ ...vcsutils.WebhookEvent) (string, string, error) {
+	return "", "", errLocalGitWebhooksNotSupported
+}
+
+// UpdateWebhook on local Git repositories
+func (client *LocalGitClient) UpdateWebhook(_ context.Context, _, _, _, _, _, _ string, _ ...vcsutils.WebhookEvent) error {
+	return errLocalGitWebhooksNotSupported
+}
+
+// DeleteWebhook on local Git repositories
+func (client *LocalGitClient) DeleteWebhook(_ context.Context, _, _, _ string) error {
+	return errLocalGitWebhooksNotSupported
+}
+
+// ListWebhooks on local Git repositories
+func (client *LocalGitClient) ListWebhooks(_ context.Context, _, _ string) ([]WebhookInfo, error) {
+	return nil, errLocalGitWebhooksNotSupported
+}
+
+// GetWebhook on local Git repositories
+func (client *LocalGitClient) GetWebhook(_ context.Context, _, _, _ string) (WebhookInfo, error) {
+	return WebhookInfo{}, errLocalGitWebhooksNotSupported
+}
+
+// RotateWebhookSecret on local Git repositories
+func (client *LocalGitClient) RotateWebhookSecret(_ context.Context, _, _, _ string) (string, error) {
+	return "", errLocalGitWebhooksNotSupported
+}
+
+// SetCommitStatus on local Git repositories
+func (client *LocalGitClient) SetCommitStatus(_ context.Context, _ CommitStatus, _, _, _, _, _, _ string) error {
+	return errLocalGitCommitStatusesNotSupported
+}
+
+// GetCommitStatuses on local Git repositories
+func (client *LocalGitClient) GetCommitStatuses(_ context.Context, _, _, _ string) (status []CommitStatusInfo, err error) {
+	return nil, errLocalGitCommitStatusesNotSupported
+}
+
+// GetCombinedCommitStatus on local Git repositories
+func (client *LocalGitClient) GetCombinedCommitStatus(_ context.Context, _, _, _ string) (CommitStatus, error) {
+	return Error, errLocalGitCommitStatusesNotSupported
+}
+
+// TriggerPipeline on local Git repositories
+func (client *LocalGitClient) TriggerPipeline(_ context.Context, _, _, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errLocalGitPipelinesNotSupported
+}
+
+// GetLatestPipelineStatus on local Git repositories
+func (client *LocalGitClient) GetLatestPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errLocalGitPipelinesNotSupported
+}
+
+// GetPipelineStatus on local Git repositories
+func (client *LocalGitClient) GetPipelineStatus(_ context.Context, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errLocalGitPipelinesNotSupported
+}
+
+// TriggerWorkflow on local Git repositories
+func (client *LocalGitClient) TriggerWorkflow(_ context.Context, _, _, _, _ string, _ map[string]string) error {
+	return errLocalGitPipelinesNotSupported
+}
+
+// GetWorkflowRunStatus on local Git repositories
+func (client *LocalGitClient) GetWorkflowRunStatus(_ context.Context, _, _, _, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errLocalGitPipelinesNotSupported
+}
+
+// QueueBuild on local Git repositories
+func (client *LocalGitClient) QueueBuild(_ context.Context, _, _ string, _ int, _ string, _ map[string]string) (PipelineInfo, error) {
+	return PipelineInfo{}, errLocalGitPipelinesNotSupported
+}
+
+// GetBuildStatus on local Git repositories
+func (client *LocalGitClient) GetBuildStatus(_ context.Context, _, _ string, _ int, _ string) (PipelineInfo, error) {
+	return PipelineInfo{}, errLocalGitPipelinesNotSupported
+}
+
+// DownloadRepository on local Git repositories.
+// The files of the branch are written to the local path, with a .git folder whose remote is the repository.
+func (client *LocalGitClient) DownloadRepository(_ context.Context, owner, repository, branch, localPath string) error {
+	return client.downloadRepository(owner, repository, branch, localPath, nil)
+}
+
+// DownloadRepositoryPaths on local Git
+func (client *LocalGitClient) DownloadRepositoryPaths(_ context.Context, owner, repository, ref string, paths []string, localPath string) error {
+	paths, err := normalizeDownloadPaths(paths)
+	if err != nil {
+		return err
+	}
+	return client.downloadRepository(owner, repository, ref, localPath, paths)
+}
+
+func (client *LocalGitClient) downloadRepository(owner, repository, branch, localPath string, paths []string) error {
+	if err := validateParametersNotBlank(map[string]string{"branch": branch, "localPath": localPath}); err != nil {
+		return err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return err
+	}
+	commit, err := resolveLocalGitCommit(repo, branch)
+	if err != nil {
+		return err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return err
+	}
+	if err = files.ForEach(func(file *object.File) error {
+		if !vcsutils.IsInPaths(file.Name, paths) {
+			return nil
+		}
+		return writeLocalGitFile(file, localPath)
+	}); err != nil {
+		return err
+	}
+	client.logger.Info(vcsutils.SuccessfulRepoDownload)
+	return vcsutils.CreateDotGitFolderWithRemote(localPath, vcsutils.RemoteName, client.getRepositoryPath(owner, repository))
+}
+
+func writeLocalGitFile(file *object.File, localPath string) error {
+	if !filepath.IsLocal(file.Name) {
+		return fmt.Errorf("the file path %s is outside of the repository", file.Name)
+	}
+	filePath := filepath.Join(localPath, file.Name)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return err
+	}
+	switch file.Mode {
+	case filemode.Symlink:
+		return os.Symlink(content, filePath)
+	case filemode.Executable:
+		return os.WriteFile(filePath, []byte(content), 0755)
+	default:
+		return os.WriteFile(filePath, []byte(content), 0644)
+	}
+}
+
+// GetPullRequestCommentSizeLimit on local Git repositories returns 0, since pull requests aren't supported
+func (client *LocalGitClient) GetPullRequestCommentSizeLimit() int {
+	return 0
+}
+
+// GetPullRequestDetailsSizeLimit on local Git repositories returns 0, since pull requests aren't supported
+func (client *LocalGitClient) GetPullRequestDetailsSizeLimit() int {
+	return 0
+}
+
+// CreatePullRequest on local Git repositories
+func (client *LocalGitClient) CreatePullRequest(_ context.Context, _, _, _, _, _, _ string) (PullRequestInfo, error) {
+	return PullRequestInfo{}, errLocalGitPullRequestsNotSupported
+}
+
+// CreatePullRequestWithOptions on local Git repositories
+func (client *LocalGitClient) CreatePullRequestWithOptions(_ context.Context, _, _, _, _, _, _ string, _ CreatePullRequestOptions) (PullRequestInfo, error) {
+	return PullRequestInfo{}, errLocalGitPullRequestsNotSupported
+}
+
+// UpdatePullRequest on local Git repositories
+func (client *LocalGitClient) UpdatePullRequest(_ context.Context, _, _, _, _, _ string, _ int, _ vcsutils.PullRequestState) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// MergePullRequest on local Git repositories
+func (client *LocalGitClient) MergePullRequest(_ context.Context, _, _ string, _ int, _ vcsutils.MergeMethod) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// SetPullRequestAutoMerge on local Git repositories
+func (client *LocalGitClient) SetPullRequestAutoMerge(_ context.Context, _, _ string, _ int, _ vcsutils.MergeMethod) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// ListOpenPullRequestsWithBody on local Git repositories
+func (client *LocalGitClient) ListOpenPullRequestsWithBody(_ context.Context, _, _ string) ([]PullRequestInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// ListOpenPullRequests on local Git repositories
+func (client *LocalGitClient) ListOpenPullRequests(_ context.Context, _, _ string) ([]PullRequestInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// ListOpenPullRequestsWithOptions on local Git repositories
+func (client *LocalGitClient) ListOpenPullRequestsWithOptions(_ context.Context, _, _ string, _ ListPullRequestsOptions) ([]PullRequestInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// ListPullRequestsWithState on local Git repositories
+func (client *LocalGitClient) ListPullRequestsWithState(_ context.Context, _, _ string, _ vcsutils.PullRequestState) ([]PullRequestInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// GetPullRequestByID on local Git repositories
+func (client *LocalGitClient) GetPullRequestByID(_ context.Context, _, _ string, _ int) (PullRequestInfo, error) {
+	return PullRequestInfo{}, errLocalGitPullRequestsNotSupported
+}
+
+// AddPullRequestComment on local Git repositories
+func (client *LocalGitClient) AddPullRequestComment(_ context.Context, _, _, _ string, _ int) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// AddPullRequestReviewComments on local Git repositories
+func (client *LocalGitClient) AddPullRequestReviewComments(_ context.Context, _, _ string, _ int, _ ...PullRequestComment) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// ListPullRequestReviewComments on local Git repositories
+func (client *LocalGitClient) ListPullRequestReviewComments(_ context.Context, _, _ string, _ int) ([]CommentInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// DeletePullRequestReviewComments on local Git repositories
+func (client *LocalGitClient) DeletePullRequestReviewComments(_ context.Context, _, _ string, _ int, _ ...CommentInfo) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// ListPullRequestComments on local Git repositories
+func (client *LocalGitClient) ListPullRequestComments(_ context.Context, _, _ string, _ int) ([]CommentInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// ListPullRequestCommentsWithOptions on local Git repositories
+func (client *LocalGitClient) ListPullRequestCommentsWithOptions(_ context.Context, _, _ string, _ int, _ ListPullRequestCommentsOptions) ([]CommentInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// UpdatePullRequestComment on local Git repositories
+func (client *LocalGitClient) UpdatePullRequestComment(_ context.Context, _, _, _ string, _, _ int) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// DeletePullRequestComment on local Git repositories
+func (client *LocalGitClient) DeletePullRequestComment(_ context.Context, _, _ string, _, _ int) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// SetPullRequestThreadStatus on local Git repositories
+func (client *LocalGitClient) SetPullRequestThreadStatus(_ context.Context, _, _ string, _ int, _ string, _ bool) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// CreatePullRequestReview on local Git repositories
+func (client *LocalGitClient) CreatePullRequestReview(_ context.Context, _, _ string, _ int, _ vcsutils.ReviewVerdict, _ string) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// ListPullRequestReviews on local Git repositories
+func (client *LocalGitClient) ListPullRequestReviews(_ context.Context, _, _ string, _ int) ([]PullRequestReviewInfo, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// GetLatestCommit on local Git repositories
+func (client *LocalGitClient) GetLatestCommit(_ context.Context, owner, repository, branch string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"branch": branch}); err != nil {
+		return CommitInfo{}, err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commit, err := resolveLocalGitCommit(repo, branch)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapLocalGitCommitToCommitInfo(commit), nil
+}
+
+// GetCommits on local Git repositories
+func (client *LocalGitClient) GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error) {
+	return client.ListCommits(ctx, owner, repository, ListCommitsOptions{Branch: branch})
+}
+
+// ListCommits on local Git repositories.
+// The commits are ordered by their commit time, starting from the latest.
+func (client *LocalGitClient) ListCommits(_ context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"branch": options.Branch}); err != nil {
+		return nil, err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	branchCommit, err := resolveLocalGitCommit(repo, options.Branch)
+	if err != nil {
+		return nil, err
+	}
+	logOptions := &git.LogOptions{From: branchCommit.Hash, Order: git.LogOrderCommitterTime}
+	if options.Path != "" {
+		logOptions.PathFilter = func(filePath string) bool {
+			return filePath == options.Path || strings.HasPrefix(filePath, strings.TrimSuffix(options.Path, "/")+"/")
+		}
+	}
+	commits, err := repo.Log(logOptions)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage := options.getPagination()
+	toSkip := (page - 1) * perPage
+	var results []CommitInfo
+	err = commits.ForEach(func(commit *object.Commit) error {
+		commitInfo := mapLocalGitCommitToCommitInfo(commit)
+		if !options.matches(commitInfo, commit.Committer.When) {
+			return nil
+		}
+		if toSkip > 0 {
+			toSkip--
+			return nil
+		}
+		results = append(results, commitInfo)
+		if len(results) == perPage {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return results, err
+}
+
+// GetCommitBySha on local Git repositories
+func (client *LocalGitClient) GetCommitBySha(_ context.Context, owner, repository, sha string) (CommitInfo, error) {
+	if err := validateParametersNotBlank(map[string]string{"sha": sha}); err != nil {
+		return CommitInfo{}, err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	commit, err := resolveLocalGitCommit(repo, sha)
+	if err != nil {
+		return CommitInfo{}, err
+	}
+	return mapLocalGitCommitToCommitInfo(commit), nil
+}
+
+// GetRepositoryInfo on local Git repositories.
+// The repository is cloned from its path, and is private to the users of the filesystem.
+// The default branch is the branch which HEAD points to, and is empty if HEAD is detached.
+func (client *LocalGitClient) GetRepositoryInfo(_ context.Context, owner, repository string) (RepositoryInfo, error) {
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+	repositoryInfo := RepositoryInfo{RepositoryVisibility: Private, CloneInfo: CloneInfo{HTTP: client.getRepositoryPath(owner, repository)}}
+	if head, err := repo.Storer.Reference(plumbing.HEAD); err == nil && head.Type() == plumbing.SymbolicReference {
+		repositoryInfo.DefaultBranch = head.Target().Short()
+	}
+	return repositoryInfo, nil
+}
+
+// CreateRepository on local Git repositories
+func (client *LocalGitClient) CreateRepository(_ context.Context, _, _ string, _ CreateRepositoryOptions) (RepositoryInfo, error) {
+	return RepositoryInfo{}, errLocalGitRepositoryManagementNotSupported
+}
+
+// DeleteRepository on local Git repositories
+func (client *LocalGitClient) DeleteRepository(_ context.Context, _, _ string) error {
+	return errLocalGitRepositoryManagementNotSupported
+}
+
+// ForkRepository on local Git repositories
+func (client *LocalGitClient) ForkRepository(_ context.Context, _, _, _ string) (RepositoryInfo, error) {
+	return RepositoryInfo{}, errLocalGitRepositoryManagementNotSupported
+}
+
+// ListRepositoryCollaborators on local Git repositories
+func (client *LocalGitClient) ListRepositoryCollaborators(_ context.Context, _, _ string) ([]CollaboratorInfo, error) {
+	return nil, errLocalGitCollaboratorsNotSupported
+}
+
+// GetRepositoryPermission on local Git repositories
+func (client *LocalGitClient) GetRepositoryPermission(_ context.Context, _, _, _ string) (RepositoryPermission, error) {
+	return NoPermission, errLocalGitCollaboratorsNotSupported
+}
+
+// CreateLabel on local Git repositories
+func (client *LocalGitClient) CreateLabel(_ context.Context, _, _ string, _ LabelInfo) error {
+	return errLocalGitLabelsNotSupported
+}
+
+// GetLabel on local Git repositories
+func (client *LocalGitClient) GetLabel(_ context.Context, _, _, _ string) (*LabelInfo, error) {
+	return nil, errLocalGitLabelsNotSupported
+}
+
+// ListRepositoryLabels on local Git repositories
+func (client *LocalGitClient) ListRepositoryLabels(_ context.Context, _, _ string) ([]LabelInfo, error) {
+	return nil, errLocalGitLabelsNotSupported
+}
+
+// UpdateLabel on local Git repositories
+func (client *LocalGitClient) UpdateLabel(_ context.Context, _, _, _ string, _ LabelInfo) error {
+	return errLocalGitLabelsNotSupported
+}
+
+// DeleteLabel on local Git repositories
+func (client *LocalGitClient) DeleteLabel(_ context.Context, _, _, _ string) error {
+	return errLocalGitLabelsNotSupported
+}
+
+// ListPullRequestLabels on local Git repositories
+func (client *LocalGitClient) ListPullRequestLabels(_ context.Context, _, _ string, _ int) ([]string, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// LabelPullRequest on local Git repositories
+func (client *LocalGitClient) LabelPullRequest(_ context.Context, _, _ string, _ int, _ []string) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// UnlabelPullRequest on local Git repositories
+func (client *LocalGitClient) UnlabelPullRequest(_ context.Context, _, _, _ string, _ int) error {
+	return errLocalGitPullRequestsNotSupported
+}
+
+// CreateIssue on local Git repositories
+func (client *LocalGitClient) CreateIssue(_ context.Context, _, _, _, _ string) (IssueInfo, error) {
+	return IssueInfo{}, errLocalGitIssuesNotSupported
+}
+
+// ListIssues on local Git repositories
+func (client *LocalGitClient) ListIssues(_ context.Context, _, _ string, _ ListIssuesOptions) ([]IssueInfo, error) {
+	return nil, errLocalGitIssuesNotSupported
+}
+
+// AddIssueComment on local Git repositories
+func (client *LocalGitClient) AddIssueComment(_ context.Context, _, _, _ string, _ int) error {
+	return errLocalGitIssuesNotSupported
+}
+
+// CloseIssue on local Git repositories
+func (client *LocalGitClient) CloseIssue(_ context.Context, _, _ string, _ int) error {
+	return errLocalGitIssuesNotSupported
+}
+
+// UploadCodeScanning on local Git repositories
+func (client *LocalGitClient) UploadCodeScanning(_ context.Context, _, _, _, _ string) (string, error) {
+	return "", errLocalGitCodeScanningNotSupported
+}
+
+// GetRepositoryEnvironmentInfo on local Git repositories
+func (client *LocalGitClient) GetRepositoryEnvironmentInfo(_ context.Context, _, _, _ string) (RepositoryEnvironmentInfo, error) {
+	return RepositoryEnvironmentInfo{}, errLocalGitEnvironmentsNotSupported
+}
+
+// ListEnvironments on local Git repositories
+func (client *LocalGitClient) ListEnvironments(_ context.Context, _, _ string) ([]RepositoryEnvironmentInfo, error) {
+	return nil, errLocalGitDeploymentsNotSupported
+}
+
+// CreateDeployment on local Git repositories
+func (client *LocalGitClient) CreateDeployment(_ context.Context, _, _, _, _, _ string) (DeploymentInfo, error) {
+	return DeploymentInfo{}, errLocalGitDeploymentsNotSupported
+}
+
+// SetDeploymentStatus on local Git repositories
+func (client *LocalGitClient) SetDeploymentStatus(_ context.Context, _, _, _ string, _ DeploymentState, _, _ string) error {
+	return errLocalGitDeploymentsNotSupported
+}
+
+// CreateOrUpdateRepositorySecret on local Git repositories
+func (client *LocalGitClient) CreateOrUpdateRepositorySecret(_ context.Context, _, _, _, _ string) error {
+	return errLocalGitSecretsNotSupported
+}
+
+// ListRepositoryVariables on local Git repositories
+func (client *LocalGitClient) ListRepositoryVariables(_ context.Context, _, _ string) ([]RepositoryVariableInfo, error) {
+	return nil, errLocalGitSecretsNotSupported
+}
+
+// CreateOrUpdateOrganizationSecret on local Git repositories
+func (client *LocalGitClient) CreateOrUpdateOrganizationSecret(_ context.Context, _, _, _ string, _ SecretVisibility, _ []string) error {
+	return errLocalGitOrgSecretsNotSupported
+}
+
+// ListOrganizationSecrets on local Git repositories
+func (client *LocalGitClient) ListOrganizationSecrets(_ context.Context, _ string) ([]SecretInfo, error) {
+	return nil, errLocalGitOrgSecretsNotSupported
+}
+
+// ListOrganizationSecretRepositories on local Git repositories
+func (client *LocalGitClient) ListOrganizationSecretRepositories(_ context.Context, _, _ string) ([]string, error) {
+	return nil, errLocalGitOrgSecretsNotSupported
+}
+
+// DeleteOrganizationSecret on local Git repositories
+func (client *LocalGitClient) DeleteOrganizationSecret(_ context.Context, _, _ string) error {
+	return errLocalGitOrgSecretsNotSupported
+}
+
+// CreateOrUpdateEnvironmentSecret on local Git repositories
+func (client *LocalGitClient) CreateOrUpdateEnvironmentSecret(_ context.Context, _, _, _, _, _ string) error {
+	return errLocalGitOrgSecretsNotSupported
+}
+
+// ListEnvironmentSecrets on local Git repositories
+func (client *LocalGitClient) ListEnvironmentSecrets(_ context.Context, _, _, _ string) ([]SecretInfo, error) {
+	return nil, errLocalGitOrgSecretsNotSupported
+}
+
+// DeleteEnvironmentSecret on local Git repositories
+func (client *LocalGitClient) DeleteEnvironmentSecret(_ context.Context, _, _, _, _ string) error {
+	return errLocalGitOrgSecretsNotSupported
+}
+
+// CreateCodeInsightsReport on local Git repositories
+func (client *LocalGitClient) CreateCodeInsightsReport(_ context.Context, _, _, _ string, _ CodeInsightsReport) error {
+	return errLocalGitCodeInsightsNotSupported
+}
+
+// AddCodeInsightsAnnotations on local Git repositories
+func (client *LocalGitClient) AddCodeInsightsAnnotations(_ context.Context, _, _, _, _ string, _ []CodeInsightsAnnotation) error {
+	return errLocalGitCodeInsightsNotSupported
+}
+
+// CreateCheckRun on local Git repositories
+func (client *LocalGitClient) CreateCheckRun(_ context.Context, _, _ string, _ CheckRunInfo) (int64, error) {
+	return 0, errLocalGitCheckRunsNotSupported
+}
+
+// UpdateCheckRun on local Git repositories
+func (client *LocalGitClient) UpdateCheckRun(_ context.Context, _, _ string, _ int64, _ CheckRunInfo) error {
+	return errLocalGitCheckRunsNotSupported
+}
+
+// DownloadFileFromRepo on local Git repositories.
+// The returned status code is 404 if the file doesn't exist in the branch, to be consistent with the VCS providers.
+func (client *LocalGitClient) DownloadFileFromRepo(_ context.Context, owner, repository, branch, path string) ([]byte, int, error) {
+	if err := validateParametersNotBlank(map[string]string{"branch": branch, "path": path}); err != nil {
+		return nil, 0, err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return nil, 0, err
+	}
+	commit, err := resolveLocalGitCommit(repo, branch)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+	file, err := commit.File(path)
+	if err != nil {
+		return nil, http.StatusNotFound, fmt.Errorf("failed to find %s in %s: %w", path, branch, err)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, 0, err
+	}
+	return []byte(content), http.StatusOK, nil
+}
+
+// ListDirectoryContents on local Git repositories
+func (client *LocalGitClient) ListDirectoryContents(_ context.Context, owner, repository, ref, dirPath string) ([]DirectoryEntry, error) {
+	if err := validateParametersNotBlank(map[string]string{"ref": ref}); err != nil {
+		return nil, err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := resolveLocalGitCommit(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	dirPath = strings.Trim(dirPath, "/")
+	if dirPath != "" {
+		if tree, err = tree.Tree(dirPath); err != nil {
+			return nil, fmt.Errorf("path '%s' is not a directory: %w", dirPath, err)
+		}
+	}
+	entries := make([]DirectoryEntry, 0, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		entries = append(entries, DirectoryEntry{Name: entry.Name, Path: path.Join(dirPath, entry.Name), IsDir: entry.Mode == filemode.Dir})
+	}
+	return entries, nil
+}
+
+// CommitFiles on local Git repositories
+func (client *LocalGitClient) CommitFiles(_ context.Context, _, _, _, _ string, _ []FileToCommit) error {
+	return errLocalGitCommitFilesNotSupported
+}
+
+// GetModifiedFiles on local Git repositories.
+// The files are compared between the common ancestor of the references and the after reference, like the VCS providers do.
+func (client *LocalGitClient) GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error) {
+	comparison, err := client.CompareCommits(ctx, owner, repository, refBefore, refAfter)
+	if err != nil {
+		return nil, err
+	}
+	fileNamesSet := datastructures.MakeSet[string]()
+	for _, file := range comparison.Files {
+		fileNamesSet.Add(file.Path)
+		fileNamesSet.Add(file.PreviousPath)
+	}
+	_ = fileNamesSet.Remove("")
+	fileNamesList := fileNamesSet.ToSlice()
+	sort.Strings(fileNamesList)
+	return fileNamesList, nil
+}
+
+// CompareCommits on local Git repositories.
+// The commits are ordered from the oldest to the latest.
+func (client *LocalGitClient) CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error) {
+	if err := validateParametersNotBlank(map[string]string{"base": base, "head": head}); err != nil {
+		return CommitsComparison{}, err
+	}
+	repo, err := client.openRepository(owner, repository)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	baseCommit, err := resolveLocalGitCommit(repo, base)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	headCommit, err := resolveLocalGitCommit(repo, head)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	mergeBases, err := baseCommit.MergeBase(headCommit)
+	if err != nil {
+		return CommitsComparison{}, err
+	}
+	if len(mergeBases) == 0 {
+		return CommitsComparison{}, fmt.Errorf("%s and %s have no common ancestor", base, head)
+	}
+
+	var comparison CommitsComparison
+	if comparison.Commits, err = getLocalGitCommitsNotReachableFrom(headCommit, baseCommit); err != nil {
+		return CommitsComparison{}, err
+	}
+	if comparison.Files, err = getLocalGitChangedFiles(ctx, mergeBases[0], headCommit); err != nil {
+		return CommitsComparison{}, err
+	}
+	return comparison, nil
+}
+
+// getLocalGitCommitsNotReachableFrom returns the commits which are reachable from the head commit and not from the base commit, from the oldest to the latest
+func getLocalGitCommitsNotReachableFrom(headCommit, baseCommit *object.Commit) ([]CommitInfo, error) {
+	baseAncestors := datastructures.MakeSet[plumbing.Hash]()
+	if err := object.NewCommitPreorderIter(baseCommit, nil, nil).ForEach(func(commit *object.Commit) error {
+		baseAncestors.Add(commit.Hash)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	var commits []*object.Commit
+	if err := object.NewCommitPreorderIter(headCommit, nil, nil).ForEach(func(commit *object.Commit) error {
+		if !baseAncestors.Exists(commit.Hash) {
+			commits = append(commits, commit)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Committer.When.Before(commits[j].Committer.When)
+	})
+	results := make([]CommitInfo, 0, len(commits))
+	for _, commit := range commits {
+		results = append(results, mapLocalGitCommitToCommitInfo(commit))
+	}
+	return results, nil
+}
+
+// getLocalGitChangedFiles returns the files changed between two commits, detecting the renamed files
+func getLocalGitChangedFiles(ctx context.Context, fromCommit, toCommit *object.Commit) ([]PullRequestFile, error) {
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := toCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTreeWithOptions(ctx, fromTree, toTree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]PullRequestFile, 0, len(changes))
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case action == merkletrie.Insert:
+			files = append(files, PullRequestFile{Path: change.To.Name, ChangeType: FileAdded})
+		case action == merkletrie.Delete:
+			files = append(files, PullRequestFile{Path: change.From.Name, ChangeType: FileDeleted})
+		case change.From.Name != change.To.Name:
+			files = append(files, PullRequestFile{Path: change.To.Name, PreviousPath: change.From.Name, ChangeType: FileRenamed})
+		default:
+			files = append(files, PullRequestFile{Path: change.To.Name, ChangeType: FileModified})
+		}
+	}
+	return files, nil
+}
+
+// ListPullRequestFiles on local Git repositories
+func (client *LocalGitClient) ListPullRequestFiles(_ context.Context, _, _ string, _ int) ([]PullRequestFile, error) {
+	return nil, errLocalGitPullRequestsNotSupported
+}
+
+// GetPullRequestDiff on local Git repositories
+func (client *LocalGitClient) GetPullRequestDiff(_ context.Context, _, _ string, _ int) (string, error) {
+	return "", errLocalGitPullRequestsNotSupported
+}
+
+func (client *LocalGitClient) getRepositoryPath(owner, repository string) string {
+	return filepath.Join(client.vcsInfo.APIEndpoint, owner, repository)
+}
+
+// openRepository opens a bare repository, or a repository with a working tree
+func (client *LocalGitClient) openRepository(owner, repository string) (*git.Repository, error) {
+	if err := validateParametersNotBlank(map[string]string{"repository": repository}); err != nil {
+		return nil, err
+	}
+	repositoryPath := client.getRepositoryPath(owner, repository)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the Git repository at %s: %w", repositoryPath, err)
+	}
+	return repo, nil
+}
+
+// resolveLocalGitCommit returns the commit of a branch, a tag, a commit SHA or any other Git revision
+func resolveLocalGitCommit(repo *git.Repository, ref string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return repo.CommitObject(*hash)
+}
+
+func mapLocalGitCommitToCommitInfo(commit *object.Commit) CommitInfo {
+	parents := make([]string, 0, len(commit.ParentHashes))
+	for _, parentHash := range commit.ParentHashes {
+		parents = append(parents, parentHash.String())
+	}
+	return CommitInfo{
+		Hash:          commit.Hash.String(),
+		AuthorName:    commit.Author.Name,
+		CommitterName: commit.Committer.Name,
+		Timestamp:     commit.Committer.When.UTC().Unix(),
+		Message:       commit.Message,
+		ParentHashes:  parents,
+		AuthorEmail:   commit.Author.Email,
+	}
+}
@@ -0,0 +1,283 @@
+package vcsclient
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// localGitTestRepository is a repository with two commits on branch-1, and a third commit on branch-2 which is based on the first commit
+type localGitTestRepository struct {
+	client  VcsClient
+	commits []plumbing.Hash
+}
+
+func createLocalGitTestRepository(t *testing.T) localGitTestRepository {
+	rootDir := t.TempDir()
+	repo, err := git.PlainInit(filepath.Join(rootDir, owner, repo1), false)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch1))))
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	testRepository := localGitTestRepository{}
+	commit := func(message string, when time.Time, files map[string]string, removedFiles ...string) {
+		for filePath, content := range files {
+			fullPath := filepath.Join(worktree.Filesystem.Root(), filePath)
+			assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+			assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+			_, err = worktree.Add(filePath)
+			assert.NoError(t, err)
+		}
+		for _, filePath := range removedFiles {
+			_, err = worktree.Remove(filePath)
+			assert.NoError(t, err)
+		}
+		hash, err := worktree.Commit(message, &git.CommitOptions{Author: &object.Signature{Name: "Frogger", Email: "frogger@jfrog.com", When: when}})
+		assert.NoError(t, err)
+		testRepository.commits = append(testRepository.commits, hash)
+	}
+	firstCommitTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	commit("First commit", firstCommitTime, map[string]string{"README.md": "Hello", "docs/guide.md": "The guide of the frog, which is long enough to be detected as renamed"})
+	commit("Second commit", firstCommitTime.Add(time.Hour), map[string]string{"README.md": "Hello frog"})
+	assert.NoError(t, worktree.Checkout(&git.CheckoutOptions{Hash: testRepository.commits[0], Branch: plumbing.NewBranchReferenceName(branch2), Create: true}))
+	commit("Third commit", firstCommitTime.Add(2*time.Hour),
+		map[string]string{"docs/frog-guide.md": "The guide of the frog, which is long enough to be detected as renamed", "main.go": "package main"}, "docs/guide.md")
+	assert.NoError(t, worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch1)}))
+
+	testRepository.client, err = NewClientBuilder(vcsutils.LocalGit).ApiEndpoint(rootDir).Build()
+	assert.NoError(t, err)
+	return testRepository
+}
+
+func TestLocalGitClient_Connection(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	assert.NoError(t, testRepository.client.TestConnection(ctx))
+	assert.NoError(t, testRepository.client.TestConnectionWithScopes(ctx, RepositoryReadScope, RepositoryWriteScope))
+
+	client, err := NewClientBuilder(vcsutils.LocalGit).ApiEndpoint(filepath.Join(t.TempDir(), "not-exist")).Build()
+	assert.NoError(t, err)
+	assert.Error(t, client.TestConnection(ctx))
+
+	_, err = NewClientBuilder(vcsutils.LocalGit).Build()
+	assert.Error(t, err)
+}
+
+func TestLocalGitClient_ListRepositories(t *testing.T) {
+	testRepository := createLocalGitTestRepository(t)
+	localGitClient, ok := testRepository.client.(*LocalGitClient)
+	assert.True(t, ok)
+	assert.NoError(t, os.MkdirAll(filepath.Join(localGitClient.vcsInfo.APIEndpoint, owner, "not-a-repository"), 0755))
+
+	repositories, err := testRepository.client.ListRepositories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{owner: {repo1}}, repositories)
+
+	repositories, err = testRepository.client.ListRepositoriesWithOptions(context.Background(), ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, repositories)
+}
+
+func TestLocalGitClient_Branches(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	client := testRepository.client
+
+	branches, err := client.ListBranches(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{branch1, branch2}, branches)
+	branches, err = client.ListBranchesWithOptions(ctx, owner, repo1, ListOptions{Page: 2, PerPage: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{branch2}, branches)
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.Equal(t, branch1, defaultBranch)
+	assert.ErrorIs(t, client.SetDefaultBranch(ctx, owner, repo1, branch2), errLocalGitSetHeadOfWorktree)
+
+	assert.NoError(t, client.CreateBranch(ctx, owner, repo1, branch2, "branch-3"))
+	commit, err := client.GetLatestCommit(ctx, owner, repo1, "branch-3")
+	assert.NoError(t, err)
+	assert.Equal(t, testRepository.commits[2].String(), commit.Hash)
+	assert.EqualError(t, client.CreateBranch(ctx, owner, repo1, branch1, "branch-3"), "branch branch-3 already exists")
+
+	assert.NoError(t, client.DeleteBranch(ctx, owner, repo1, "branch-3"))
+	assert.Error(t, client.DeleteBranch(ctx, owner, repo1, "branch-3"))
+	_, err = client.ListBranches(ctx, owner, repo2)
+	assert.Error(t, err)
+}
+
+func TestLocalGitClient_Tags(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	client := testRepository.client
+
+	assert.NoError(t, client.CreateTag(ctx, owner, repo1, "v1.0.0", testRepository.commits[0].String(), ""))
+	assert.NoError(t, client.CreateTag(ctx, owner, repo1, "v2.0.0", branch2, "Version 2"))
+	tags, err := client.ListTags(ctx, owner, repo1)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []TagInfo{
+		{Name: "v1.0.0", CommitSha: testRepository.commits[0].String()},
+		{Name: "v2.0.0", CommitSha: testRepository.commits[2].String()},
+	}, tags)
+
+	commit, err := client.GetTagInfo(ctx, owner, repo1, "v2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "Third commit", commit.Message)
+	_, err = client.GetTagInfo(ctx, owner, repo1, "v3.0.0")
+	assert.Error(t, err)
+}
+
+func TestLocalGitClient_Commits(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	client := testRepository.client
+
+	commit, err := client.GetLatestCommit(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	assert.Equal(t, CommitInfo{
+		Hash:          testRepository.commits[1].String(),
+		AuthorName:    "Frogger",
+		CommitterName: "Frogger",
+		Timestamp:     time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC).Unix(),
+		Message:       "Second commit",
+		ParentHashes:  []string{testRepository.commits[0].String()},
+		AuthorEmail:   "frogger@jfrog.com",
+	}, commit)
+
+	commits, err := client.GetCommits(ctx, owner, repo1, branch1)
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 2) {
+		assert.Equal(t, testRepository.commits[1].String(), commits[0].Hash)
+		assert.Equal(t, testRepository.commits[0].String(), commits[1].Hash)
+	}
+
+	commits, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: branch2, Path: "docs"})
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 2) {
+		assert.Equal(t, "Third commit", commits[0].Message)
+	}
+	commits, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: branch1, PerPage: 1, Page: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 1) {
+		assert.Equal(t, "First commit", commits[0].Message)
+	}
+	commits, err = client.ListCommits(ctx, owner, repo1, ListCommitsOptions{Branch: branch2, Since: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 1) {
+		assert.Equal(t, "Third commit", commits[0].Message)
+	}
+
+	commit, err = client.GetCommitBySha(ctx, owner, repo1, testRepository.commits[0].String())
+	assert.NoError(t, err)
+	assert.Equal(t, "First commit", commit.Message)
+	assert.Empty(t, commit.ParentHashes)
+}
+
+func TestLocalGitClient_FileContents(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	client := testRepository.client
+
+	content, statusCode, err := client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "README.md")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "Hello frog", string(content))
+	_, statusCode, err = client.DownloadFileFromRepo(ctx, owner, repo1, branch1, "main.go")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+
+	entries, err := client.ListDirectoryContents(ctx, owner, repo1, branch2, "")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []DirectoryEntry{
+		{Name: "README.md", Path: "README.md"},
+		{Name: "docs", Path: "docs", IsDir: true},
+		{Name: "main.go", Path: "main.go"},
+	}, entries)
+	entries, err = client.ListDirectoryContents(ctx, owner, repo1, branch2, "docs")
+	assert.NoError(t, err)
+	assert.Equal(t, []DirectoryEntry{{Name: "frog-guide.md", Path: "docs/frog-guide.md"}}, entries)
+	_, err = client.ListDirectoryContents(ctx, owner, repo1, branch2, "main.go")
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	assert.NoError(t, client.DownloadRepository(ctx, owner, repo1, branch2, dir))
+	downloadedContent, err := os.ReadFile(filepath.Join(dir, "docs", "frog-guide.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "The guide of the frog, which is long enough to be detected as renamed", string(downloadedContent))
+	assert.FileExists(t, filepath.Join(dir, "main.go"))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+
+	dir = t.TempDir()
+	assert.NoError(t, client.DownloadRepositoryPaths(ctx, owner, repo1, branch2, []string{"/docs/"}, dir))
+	assert.FileExists(t, filepath.Join(dir, "docs", "frog-guide.md"))
+	assert.NoFileExists(t, filepath.Join(dir, "main.go"))
+	assert.NoFileExists(t, filepath.Join(dir, "README.md"))
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+	assert.Error(t, client.DownloadRepositoryPaths(ctx, owner, repo1, branch2, nil, dir))
+}
+
+func TestLocalGitClient_CompareCommits(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	client := testRepository.client
+
+	comparison, err := client.CompareCommits(ctx, owner, repo1, branch1, branch2)
+	assert.NoError(t, err)
+	if assert.Len(t, comparison.Commits, 1) {
+		assert.Equal(t, testRepository.commits[2].String(), comparison.Commits[0].Hash)
+	}
+	// The changes of branch-1 since the common ancestor aren't included
+	assert.ElementsMatch(t, []PullRequestFile{
+		{Path: "docs/frog-guide.md", PreviousPath: "docs/guide.md", ChangeType: FileRenamed},
+		{Path: "main.go", ChangeType: FileAdded},
+	}, comparison.Files)
+
+	modifiedFiles, err := client.GetModifiedFiles(ctx, owner, repo1, branch1, branch2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/frog-guide.md", "docs/guide.md", "main.go"}, modifiedFiles)
+
+	comparison, err = client.CompareCommits(ctx, owner, repo1, branch2, branch1)
+	assert.NoError(t, err)
+	if assert.Len(t, comparison.Commits, 1) {
+		assert.Equal(t, testRepository.commits[1].String(), comparison.Commits[0].Hash)
+	}
+	assert.Equal(t, []PullRequestFile{{Path: "README.md", ChangeType: FileModified}}, comparison.Files)
+}
+
+func TestLocalGitClient_NotSupported(t *testing.T) {
+	ctx := context.Background()
+	testRepository := createLocalGitTestRepository(t)
+	client := testRepository.client
+
+	_, err := client.ListOpenPullRequests(ctx, owner, repo1)
+	assert.ErrorIs(t, err, errLocalGitPullRequestsNotSupported)
+	_, err = client.ListPullRequestCommentsWithOptions(ctx, owner, repo1, 1, ListPullRequestCommentsOptions{})
+	assert.ErrorIs(t, err, errLocalGitPullRequestsNotSupported)
+	assert.ErrorIs(t, client.SetPullRequestThreadStatus(ctx, owner, repo1, 1, "1", true), errLocalGitPullRequestsNotSupported)
+	assert.ErrorIs(t, client.SetPullRequestAutoMerge(ctx, owner, repo1, 1, vcsutils.MergeMethodMerge), errLocalGitPullRequestsNotSupported)
+	_, _, err = client.CreateWebhook(ctx, owner, repo1, branch1, "https://jfrog.com")
+	assert.ErrorIs(t, err, errLocalGitWebhooksNotSupported)
+	assert.ErrorIs(t, client.SetCommitStatus(ctx, Pass, owner, repo1, branch1, "", "", ""), errLocalGitCommitStatusesNotSupported)
+	_, err = client.TriggerPipeline(ctx, owner, repo1, branch1, nil)
+	assert.ErrorIs(t, err, errLocalGitPipelinesNotSupported)
+	assert.ErrorIs(t, client.TriggerWorkflow(ctx, owner, repo1, "build.yml", branch1, nil), errLocalGitPipelinesNotSupported)
+	_, err = client.QueueBuild(ctx, owner, repo1, 7, branch1, nil)
+	assert.ErrorIs(t, err, errLocalGitPipelinesNotSupported)
+	_, err = client.CreateDeployment(ctx, owner, repo1, branch1, envName, "")
+	assert.ErrorIs(t, err, errLocalGitDeploymentsNotSupported)
+	assert.ErrorIs(t, client.CreateOrUpdateRepositorySecret(ctx, owner, repo1, "JF_TOKEN", "secret-value"), errLocalGitSecretsNotSupported)
+	assert.ErrorIs(t, client.DeleteOrganizationSecret(ctx, owner, "JF_TOKEN"), errLocalGitOrgSecretsNotSupported)
+	assert.ErrorIs(t, client.CommitFiles(ctx, owner, repo1, branch1, "message", nil), errLocalGitCommitFilesNotSupported)
+}
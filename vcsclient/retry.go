@@ -0,0 +1,254 @@
+package vcsclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMaxWait     = time.Minute
+	defaultRetryBackoffBase = time.Second
+	// maxRetryBackoff caps the exponential backoff, so it doesn't overflow once the jitter is added
+	maxRetryBackoff = time.Duration(math.MaxInt64 / 2)
+	// maxRateLimitBodySize limits the body of a 403 response which is read to detect GitHub's secondary rate limit
+	maxRateLimitBodySize = 4 << 10
+)
+
+// The statuses of transient server errors, which are retried by default
+var defaultRetryableStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryOptions configures the retries of requests which failed with a transient error, or were rejected by the rate limit of the VCS provider.
+// MaxAttempts          - The maximal number of attempts of each request, including the first one. Defaults to 3
+// MaxWait              - The maximal time to wait before retrying a request. Requests which can be retried only later fail immediately. Defaults to a minute
+// BackoffBase          - The wait before the first retry when the response doesn't specify one, which is doubled on each retry. Defaults to a second
+// RetryableStatusCodes - The statuses of responses to retry, in addition to the rate limit responses. Replaces the default statuses - 502, 503 and 504
+// AttemptTimeout       - The timeout of each attempt, including reading the response body, after which the request is retried. Defaults to no timeout
+type RetryOptions struct {
+	MaxAttempts          int
+	MaxWait              time.Duration
+	BackoffBase          time.Duration
+	RetryableStatusCodes []int
+	AttemptTimeout       time.Duration
+}
+
+// retryTransport retries requests which failed with a transient error, or were rejected by the rate limit of the VCS provider.
+// The wait before each retry is read from the rate limit headers of the response, or calculated by an exponential backoff with jitter.
+type retryTransport struct {
+	base    http.RoundTripper
+	options RetryOptions
+}
+
+func newRetryTransport(base http.RoundTripper, options RetryOptions) *retryTransport {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if options.MaxWait <= 0 {
+		options.MaxWait = defaultRetryMaxWait
+	}
+	if options.BackoffBase <= 0 {
+		options.BackoffBase = defaultRetryBackoffBase
+	}
+	if options.RetryableStatusCodes == nil {
+		options.RetryableStatusCodes = defaultRetryableStatusCodes
+	}
+	return &retryTransport{base: base, options: options}
+}
+
+func (transport *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := transport.roundTripAttempt(request)
+		// A request with a body can be retried only if the body can be read again
+		if attempt >= transport.options.MaxAttempts || request.Context().Err() != nil || (request.Body != nil && request.Body != http.NoBody && request.GetBody == nil) {
+			return response, err
+		}
+		wait, shouldRetry := transport.getRetryWait(response, err, attempt)
+		if !shouldRetry || wait > transport.options.MaxWait {
+			return response, err
+		}
+		if response != nil {
+			if err = errors.Join(vcsutils.DiscardResponseBody(response), response.Body.Close()); err != nil {
+				return nil, err
+			}
+		}
+		if request, err = cloneRequestForRetry(request); err != nil {
+			return nil, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// roundTripAttempt sends a single attempt of the request, with the attempt timeout.
+// The timeout includes reading the response body, so the attempt is canceled only when the body is closed.
+func (transport *retryTransport) roundTripAttempt(request *http.Request) (*http.Response, error) {
+	if transport.options.AttemptTimeout <= 0 {
+		return transport.base.RoundTrip(request)
+	}
+	ctx, cancel := context.WithTimeout(request.Context(), transport.options.AttemptTimeout)
+	response, err := transport.base.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &cancelOnCloseBody{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// cancelOnCloseBody cancels the context of the attempt when the response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelOnCloseBody) Close() error {
+	defer body.cancel()
+	return body.ReadCloser.Close()
+}
+
+// getRetryWait returns the time to wait before retrying a request which failed with a transient error, or was rejected by the rate limit
+func (transport *retryTransport) getRetryWait(response *http.Response, requestError error, attempt int) (time.Duration, bool) {
+	if requestError != nil {
+		return getRetryBackoff(transport.options.BackoffBase, attempt), isRetryableRequestError(requestError)
+	}
+	if wait, shouldRetry := getRateLimitRetryWait(response, transport.options.BackoffBase, attempt); shouldRetry {
+		return wait, true
+	}
+	if !slices.Contains(transport.options.RetryableStatusCodes, response.StatusCode) {
+		return 0, false
+	}
+	if wait, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+		return wait, true
+	}
+	return getRetryBackoff(transport.options.BackoffBase, attempt), true
+}
+
+// isRetryableRequestError returns true if the request failed with a transient network error, such as a timeout or a reset connection
+func isRetryableRequestError(requestError error) bool {
+	var netError net.Error
+	return errors.As(requestError, &netError) && netError.Timeout() ||
+		errors.Is(requestError, context.DeadlineExceeded) ||
+		errors.Is(requestError, syscall.ECONNRESET) ||
+		errors.Is(requestError, syscall.ECONNREFUSED) ||
+		errors.Is(requestError, io.ErrUnexpectedEOF)
+}
+
+// cloneRequestForRetry returns a copy of the request with a new body, since the body of the previous attempt was read
+func cloneRequestForRetry(request *http.Request) (*http.Request, error) {
+	retryRequest := request.Clone(request.Context())
+	if request.GetBody == nil {
+		return retryRequest, nil
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retryRequest.Body = body
+	return retryRequest, nil
+}
+
+// getRateLimitRetryWait returns the time to wait before retrying a request which was rejected by the rate limit of the VCS provider.
+// The rate limit headers are:
+// Retry-After                                 - Sent by all providers, usually with the 429 status. Azure Repos sends it with 503 as well
+// X-RateLimit-Remaining and X-RateLimit-Reset - Sent by GitHub, which rejects requests exceeding the rate limit with 403 or 429
+// RateLimit-Remaining and RateLimit-Reset     - Sent by GitLab
+// 429 responses without these headers, such as Bitbucket's, are retried with an exponential backoff.
+// 403 responses are retried only when their headers report a rate limit, since the GitHub client already retries the other rate limit responses.
+func getRateLimitRetryWait(response *http.Response, backoffBase time.Duration, attempt int) (time.Duration, bool) {
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusServiceUnavailable:
+		if response.Header.Get("Retry-After") == "" {
+			return 0, false
+		}
+	case http.StatusForbidden:
+		if !isGitHubRateLimitResponse(response) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if wait, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+		return wait, true
+	}
+	for _, headerPrefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if response.Header.Get(headerPrefix+"Remaining") != "0" {
+			continue
+		}
+		if reset, err := strconv.ParseInt(response.Header.Get(headerPrefix+"Reset"), 10, 64); err == nil {
+			wait := time.Until(time.Unix(reset, 0))
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+	return getRetryBackoff(backoffBase, attempt), true
+}
+
+// isGitHubRateLimitResponse returns true if a 403 response was returned by GitHub because of its primary rate limit,
+// or because of its secondary rate limit with a Retry-After header.
+func isGitHubRateLimitResponse(response *http.Response) bool {
+	if response.Header.Get("X-RateLimit-Remaining") == "0" {
+		return true
+	}
+	if response.Header.Get("Retry-After") == "" || response.Body == nil {
+		return false
+	}
+	// The secondary rate limit is told from other 403 responses by the body, of which only a small prefix is read.
+	// The read bytes are restored in front of the rest of the body, since the response may be returned
+	readBytes := new(bytes.Buffer)
+	body, err := io.ReadAll(vcsutils.LimitReader(io.TeeReader(response.Body, readBytes), maxRateLimitBodySize))
+	response.Body = &multiReadCloser{Reader: io.MultiReader(readBytes, response.Body), Closer: response.Body}
+	return err == nil && strings.Contains(string(body), "secondary rate limit")
+}
+
+// parseRetryAfter parses the Retry-After header, which contains either the number of seconds to wait or an HTTP date
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// getRetryBackoff returns an exponential backoff for the attempt, with a random jitter of up to half of the backoff.
+// The backoff is capped, so it doesn't overflow when the number of attempts is large.
+func getRetryBackoff(backoffBase time.Duration, attempt int) time.Duration {
+	backoff := backoffBase
+	for doubling := 1; doubling < attempt && backoff <= maxRetryBackoff/2; doubling++ {
+		backoff <<= 1
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	// #nosec G404 -- The jitter doesn't require a secure random number
+	return backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+}
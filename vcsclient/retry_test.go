@@ -0,0 +1,213 @@
+package vcsclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRateLimitRetryWait(t *testing.T) {
+	resetIn := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+	tests := []struct {
+		name          string
+		status        int
+		headers       map[string]string
+		body          string
+		expectedRetry bool
+		minWait       time.Duration
+		maxWait       time.Duration
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound},
+		{name: "retry after", status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "7"}, expectedRetry: true, minWait: 7 * time.Second, maxWait: 7 * time.Second},
+		{name: "azure unavailable", status: http.StatusServiceUnavailable, headers: map[string]string{"Retry-After": "2"}, expectedRetry: true, minWait: 2 * time.Second, maxWait: 2 * time.Second},
+		{name: "unavailable", status: http.StatusServiceUnavailable},
+		{name: "github rate limit", status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": resetIn}, expectedRetry: true, minWait: 28 * time.Second, maxWait: 30 * time.Second},
+		{name: "github secondary rate limit", status: http.StatusForbidden, headers: map[string]string{"Retry-After": "3"}, body: `{"message": "You have exceeded a secondary rate limit."}`, expectedRetry: true, minWait: 3 * time.Second, maxWait: 3 * time.Second},
+		// Left to the rate limit handling of the GitHub client, so the request isn't retried twice
+		{name: "github secondary rate limit without retry after", status: http.StatusForbidden, body: `{"message": "You have exceeded a secondary rate limit."}`},
+		{name: "github forbidden with retry after", status: http.StatusForbidden, headers: map[string]string{"Retry-After": "3"}, body: `{"message": "Resource not accessible by integration"}`},
+		{name: "github large forbidden body", status: http.StatusForbidden, headers: map[string]string{"Retry-After": "3"}, body: strings.Repeat("a", maxRateLimitBodySize) + "secondary rate limit"},
+		{name: "github forbidden", status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "4999"}, body: `{"message": "Resource not accessible by integration"}`},
+		{name: "gitlab rate limit", status: http.StatusTooManyRequests, headers: map[string]string{"RateLimit-Remaining": "0", "RateLimit-Reset": resetIn}, expectedRetry: true, minWait: 28 * time.Second, maxWait: 30 * time.Second},
+		{name: "bitbucket rate limit", status: http.StatusTooManyRequests, expectedRetry: true, minWait: time.Second, maxWait: 1500 * time.Millisecond},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := &http.Response{StatusCode: test.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(test.body))}
+			for key, value := range test.headers {
+				response.Header.Set(key, value)
+			}
+			wait, shouldRetry := getRateLimitRetryWait(response, defaultRetryBackoffBase, 1)
+			assert.Equal(t, test.expectedRetry, shouldRetry)
+			assert.GreaterOrEqual(t, wait, test.minWait)
+			assert.LessOrEqual(t, wait, test.maxWait)
+			// The body is restored for the caller
+			body, err := io.ReadAll(response.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, test.body, string(body))
+		})
+	}
+}
+
+func TestGetRetryBackoff(t *testing.T) {
+	for attempt := 1; attempt <= 4; attempt++ {
+		backoff := getRetryBackoff(defaultRetryBackoffBase, attempt)
+		expected := defaultRetryBackoffBase << (attempt - 1)
+		assert.GreaterOrEqual(t, backoff, expected)
+		assert.LessOrEqual(t, backoff, expected+expected/2)
+	}
+
+	// The backoff of many attempts is capped rather than overflowed
+	assert.NotPanics(t, func() {
+		backoff := getRetryBackoff(time.Second, 64)
+		assert.GreaterOrEqual(t, backoff, maxRetryBackoff)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, time.Hour, wait, float64(2*time.Second))
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestRetryTransport(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"frogger"}`+"\n", string(body))
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	vcsInfo := VcsInfo{RetryOptions: &RetryOptions{MaxAttempts: 3}}
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, strings.NewReader(`{"name":"frogger"}`+"\n"))
+	assert.NoError(t, err)
+	response, err := newHttpClient(vcsInfo).Do(request)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, 3, requests)
+
+	// The last rejected response is returned when the attempts are exhausted
+	requests = 0
+	vcsInfo.RetryOptions.MaxAttempts = 2
+	request, err = http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, strings.NewReader(`{"name":"frogger"}`+"\n"))
+	assert.NoError(t, err)
+	response, err = newHttpClient(vcsInfo).Do(request)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, 2, requests)
+}
+
+func TestClientBuilder_RetriesMaxWait(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	// Requests which can be retried only after the maximal wait fail immediately
+	client, err := NewClientBuilder(vcsutils.GitHub).ApiEndpoint(server.URL).Token(token).Retries(RetryOptions{MaxAttempts: 3}).Build()
+	assert.NoError(t, err)
+	assert.Error(t, client.TestConnection(context.Background()))
+	assert.Equal(t, 1, requests)
+}
+
+func TestRetryTransport_RetryableStatusCodes(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// 502 is retried by default, unlike 500
+	httpClient := newHttpClient(VcsInfo{RetryOptions: &RetryOptions{MaxAttempts: 3, BackoffBase: time.Millisecond}})
+	response, err := httpClient.Get(server.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, 2, requests)
+
+	// The retryable statuses replace the default ones
+	requests = 0
+	httpClient = newHttpClient(VcsInfo{RetryOptions: &RetryOptions{MaxAttempts: 3, BackoffBase: time.Millisecond, RetryableStatusCodes: []int{http.StatusInternalServerError}}})
+	response, err = httpClient.Get(server.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRetryTransport_AttemptTimeout(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			// The first attempt times out
+			<-r.Context().Done()
+			return
+		}
+		_, err := w.Write([]byte("frogger"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	httpClient := newHttpClient(VcsInfo{RetryOptions: &RetryOptions{MaxAttempts: 2, BackoffBase: time.Millisecond, AttemptTimeout: 100 * time.Millisecond}})
+	response, err := httpClient.Get(server.URL)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, "frogger", string(body))
+	assert.Equal(t, 2, requests)
+
+	// The request fails once the attempts are exhausted
+	requests = 0
+	httpClient = newHttpClient(VcsInfo{RetryOptions: &RetryOptions{MaxAttempts: 1, AttemptTimeout: 100 * time.Millisecond}})
+	_, err = httpClient.Get(server.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestIsRetryableRequestError(t *testing.T) {
+	assert.True(t, isRetryableRequestError(context.DeadlineExceeded))
+	assert.True(t, isRetryableRequestError(fmt.Errorf("read: %w", syscall.ECONNRESET)))
+	assert.True(t, isRetryableRequestError(io.ErrUnexpectedEOF))
+	assert.False(t, isRetryableRequestError(context.Canceled))
+	assert.False(t, isRetryableRequestError(errors.New("x509: certificate signed by unknown authority")))
+}
@@ -188,6 +188,82 @@ func TestRequiredParams_GetLabel(t *testing.T) {
 	}
 }
 
+func TestRequiredParams_ListRepositoryLabels(t *testing.T) {
+	tests := []struct {
+		name          string
+		owner         string
+		repo          string
+		missingParams []string
+	}{
+		{name: "all empty", missingParams: []string{"owner", "repository"}},
+		{name: "empty owner", repo: "repo", missingParams: []string{"owner"}},
+		{name: "empty repo", owner: "owner", missingParams: []string{"repository"}},
+	}
+
+	for _, p := range getNonBitbucketProviders() {
+		for _, tt := range tests {
+			t.Run(p.String()+" "+tt.name, func(t *testing.T) {
+				ctx, client := createClientAndContext(t, p)
+				result, err := client.ListRepositoryLabels(ctx, tt.owner, tt.repo)
+				assertMissingParam(t, err, tt.missingParams...)
+				assert.Empty(t, result)
+			})
+		}
+	}
+}
+
+func TestRequiredParams_UpdateLabel(t *testing.T) {
+	tests := []struct {
+		name          string
+		owner         string
+		repo          string
+		labelName     string
+		labelInfo     LabelInfo
+		missingParams []string
+	}{
+		{name: "all empty", missingParams: []string{"owner", "repository", "name", "LabelInfo.name"}},
+		{name: "empty owner", repo: "repo", labelName: "name", labelInfo: LabelInfo{Name: "name"}, missingParams: []string{"owner"}},
+		{name: "empty repo", owner: "owner", labelName: "name", labelInfo: LabelInfo{Name: "name"}, missingParams: []string{"repository"}},
+		{name: "empty name", owner: "owner", repo: "repo", labelInfo: LabelInfo{Name: "name"}, missingParams: []string{"name"}},
+		{name: "empty LabelInfo.name", owner: "owner", repo: "repo", labelName: "name", missingParams: []string{"LabelInfo.name"}},
+	}
+
+	for _, p := range getNonBitbucketProviders() {
+		for _, tt := range tests {
+			t.Run(p.String()+" "+tt.name, func(t *testing.T) {
+				ctx, client := createClientAndContext(t, p)
+				err := client.UpdateLabel(ctx, tt.owner, tt.repo, tt.labelName, tt.labelInfo)
+				assertMissingParam(t, err, tt.missingParams...)
+			})
+		}
+	}
+}
+
+func TestRequiredParams_DeleteLabel(t *testing.T) {
+	tests := []struct {
+		name          string
+		owner         string
+		repo          string
+		labelName     string
+		missingParams []string
+	}{
+		{name: "all empty", missingParams: []string{"owner", "repository", "name"}},
+		{name: "empty owner", repo: "repo", labelName: "name", missingParams: []string{"owner"}},
+		{name: "empty repo", owner: "owner", labelName: "name", missingParams: []string{"repository"}},
+		{name: "empty name", owner: "owner", repo: "repo", missingParams: []string{"name"}},
+	}
+
+	for _, p := range getNonBitbucketProviders() {
+		for _, tt := range tests {
+			t.Run(p.String()+" "+tt.name, func(t *testing.T) {
+				ctx, client := createClientAndContext(t, p)
+				err := client.DeleteLabel(ctx, tt.owner, tt.repo, tt.labelName)
+				assertMissingParam(t, err, tt.missingParams...)
+			})
+		}
+	}
+}
+
 func TestRequiredParams_ListPullRequestLabels(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -212,6 +288,29 @@ func TestRequiredParams_ListPullRequestLabels(t *testing.T) {
 	}
 }
 
+func TestRequiredParams_LabelPullRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		owner         string
+		repo          string
+		missingParams []string
+	}{
+		{name: "all empty", missingParams: []string{"owner", "repository"}},
+		{name: "empty owner", repo: "repo", missingParams: []string{"owner"}},
+		{name: "empty repo", owner: "owner", missingParams: []string{"repository"}},
+	}
+
+	for _, p := range getNonBitbucketProviders() {
+		for _, tt := range tests {
+			t.Run(p.String()+" "+tt.name, func(t *testing.T) {
+				ctx, client := createClientAndContext(t, p)
+				err := client.LabelPullRequest(ctx, tt.owner, tt.repo, 0, []string{"label"})
+				assertMissingParam(t, err, tt.missingParams...)
+			})
+		}
+	}
+}
+
 func TestRequiredParams_UnlabelPullRequest(t *testing.T) {
 	tests := []struct {
 		name          string
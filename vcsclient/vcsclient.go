@@ -2,11 +2,24 @@ package vcsclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/jfrog/froggit-go/vcsutils"
+	"golang.org/x/exp/slices"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
 )
 
 // CommitStatus the status of the commit in the VCS
@@ -33,6 +46,94 @@ const (
 	ReadWrite
 )
 
+// RepositoryPermission the access level of a user to a VCS repository.
+// The levels are ordered, so a level grants every lower level.
+type RepositoryPermission int
+
+const (
+	// NoPermission means the user has no access to the repository
+	NoPermission RepositoryPermission = iota
+	// ReadPermission allows cloning the repository
+	ReadPermission
+	// WritePermission allows pushing branches to the repository
+	WritePermission
+	// AdminPermission allows managing the repository settings, such as webhooks
+	AdminPermission
+)
+
+// TokenScope a group of operations the client's token is expected to be allowed to perform
+type TokenScope string
+
+const (
+	// RepositoryReadScope allows reading repositories, their pull requests and commits
+	RepositoryReadScope TokenScope = "repository read"
+	// RepositoryWriteScope allows pushing commits, opening pull requests and commenting on them
+	RepositoryWriteScope TokenScope = "repository write"
+	// WebhookAdminScope allows creating, updating and deleting repository webhooks
+	WebhookAdminScope TokenScope = "webhook admin"
+)
+
+// CodeInsightsResult the overall result of a Code Insights report
+type CodeInsightsResult string
+
+const (
+	// CodeInsightsPass means that the analysis passed
+	CodeInsightsPass CodeInsightsResult = "PASS"
+	// CodeInsightsFail means that the analysis found issues
+	CodeInsightsFail CodeInsightsResult = "FAIL"
+)
+
+// CodeInsightsSeverity the severity of a Code Insights annotation
+type CodeInsightsSeverity string
+
+const (
+	CodeInsightsLowSeverity    CodeInsightsSeverity = "LOW"
+	CodeInsightsMediumSeverity CodeInsightsSeverity = "MEDIUM"
+	CodeInsightsHighSeverity   CodeInsightsSeverity = "HIGH"
+	// CodeInsightsCriticalSeverity is reported as high on Bitbucket server, which doesn't have a critical severity
+	CodeInsightsCriticalSeverity CodeInsightsSeverity = "CRITICAL"
+)
+
+// CodeInsightsAnnotationType the kind of issue a Code Insights annotation describes
+type CodeInsightsAnnotationType string
+
+const (
+	CodeInsightsVulnerability CodeInsightsAnnotationType = "VULNERABILITY"
+	CodeInsightsCodeSmell     CodeInsightsAnnotationType = "CODE_SMELL"
+	CodeInsightsBug           CodeInsightsAnnotationType = "BUG"
+)
+
+// CheckRunStatus the status of a check run
+type CheckRunStatus string
+
+const (
+	CheckRunQueued     CheckRunStatus = "queued"
+	CheckRunInProgress CheckRunStatus = "in_progress"
+	CheckRunCompleted  CheckRunStatus = "completed"
+)
+
+// CheckRunConclusion the final result of a completed check run
+type CheckRunConclusion string
+
+const (
+	CheckRunSuccess        CheckRunConclusion = "success"
+	CheckRunFailure        CheckRunConclusion = "failure"
+	CheckRunNeutral        CheckRunConclusion = "neutral"
+	CheckRunCancelled      CheckRunConclusion = "cancelled"
+	CheckRunSkipped        CheckRunConclusion = "skipped"
+	CheckRunTimedOut       CheckRunConclusion = "timed_out"
+	CheckRunActionRequired CheckRunConclusion = "action_required"
+)
+
+// CheckRunAnnotationLevel the severity of a check run annotation
+type CheckRunAnnotationLevel string
+
+const (
+	CheckRunNoticeLevel  CheckRunAnnotationLevel = "notice"
+	CheckRunWarningLevel CheckRunAnnotationLevel = "warning"
+	CheckRunFailureLevel CheckRunAnnotationLevel = "failure"
+)
+
 // RepositoryVisibility the visibility level of the repository
 type RepositoryVisibility int
 
@@ -45,13 +146,96 @@ const (
 	Private
 )
 
+// FileChangeType the type of change made to a file in a pull request
+type FileChangeType int
+
+const (
+	// FileModified means that the file content was modified
+	FileModified FileChangeType = iota
+	// FileAdded means that the file was added
+	FileAdded
+	// FileDeleted means that the file was deleted
+	FileDeleted
+	// FileRenamed means that the file was renamed or moved, and may also be modified
+	FileRenamed
+)
+
+// TokenProvider returns the access token to authenticate with.
+// It's called before each request, so it should cache the token until it's about to expire.
+type TokenProvider func(ctx context.Context) (string, error)
+
 // VcsInfo is the connection details of the VcsClient to communicate with the server
 type VcsInfo struct {
 	APIEndpoint string
 	Username    string
 	Token       string
+	// TokenProvider is optional, and is used instead of the token to support short-lived tokens
+	TokenProvider TokenProvider
+	// HttpClient is optional, and is used to send the requests to the VCS provider, for example through a proxy or with a custom TLS configuration
+	HttpClient *http.Client
+	// Proxy is optional, and is used to send the requests through a proxy, instead of the proxy of the environment variables.
+	// It's ignored if a custom HTTP client is set.
+	Proxy *url.URL
+	// RootCAs is optional, and is used to verify the certificates of self-hosted servers which are signed by a private CA.
+	// It's ignored if a custom HTTP client is set.
+	RootCAs *x509.CertPool
+	// InsecureSkipVerify disables the verification of the server certificates, and should be used only for testing.
+	// It's ignored if a custom HTTP client is set.
+	InsecureSkipVerify bool
+	// ClientCertificates is optional, and is presented to servers which require mutual TLS authentication.
+	// It's ignored if a custom HTTP client is set.
+	ClientCertificates []tls.Certificate
+	// RateLimiter is optional, and limits the rate of the requests to the VCS provider.
+	// It can be shared by several clients, to limit the total rate of their requests.
+	RateLimiter *rate.Limiter
+	// RetryOptions is optional, and enables the retries of requests which failed with a transient error, or were rejected by the rate limit of the VCS provider
+	RetryOptions *RetryOptions
+	// ResponseCache is optional, and caches the responses of GET requests, which are revalidated with conditional requests.
+	// The cached response is returned when the VCS provider responds with 304 Not Modified.
+	ResponseCache ResponseCache
+	// MaxDownloadSize is optional, and limits the size in bytes of the repository archives which are downloaded by DownloadRepository.
+	// The download fails with vcsutils.ErrDownloadSizeLimitExceeded once the limit is exceeded. By default, the size isn't limited.
+	MaxDownloadSize int64
+	// ArchiveFormat is optional, and sets the format of the repository archives which are downloaded by DownloadRepository - tar.gz or zip.
+	// By default, tar.gz archives are downloaded, except for Azure Repos, which supports only zip archives.
+	ArchiveFormat vcsutils.ArchiveFormat
+	// ExtractionLimits is optional, and limits the total size and the number of the files which are extracted from the downloaded repository archives.
+	// By default, vcsutils.DefaultMaxExtractedSize and vcsutils.DefaultMaxExtractedEntries are used.
+	ExtractionLimits vcsutils.ExtractionLimits
 	// Project name is relevant for Azure Repos
 	Project string
+	// The GitHub App credentials are relevant for GitHub, and are used instead of the token.
+	// Without an installation ID, the client is authenticated as the app itself.
+	GitHubAppID             int64
+	GitHubAppPrivateKey     []byte
+	GitHubAppInstallationID int64
+	// GitLabJobToken marks the token as a GitLab CI job token, which has access to a limited set of the API
+	GitLabJobToken bool
+	// GitLabGroup is optional, and scopes the GitLab repositories listing to the projects of a group and its subgroups, such as org/team.
+	// By default, the projects which the user is a member of are listed.
+	GitLabGroup string
+	// GitLabGroupMembershipOnly lists only the projects of the GitLab group which the user is a member of, directly or through a parent group.
+	// By default, all the projects of the group which the user can see are listed.
+	GitLabGroupMembershipOnly bool
+	// AzureADToken marks the token as an Azure AD (Entra ID) access token, which is relevant for Azure Repos.
+	// Azure AD tokens are sent as bearer tokens, rather than as personal access tokens.
+	AzureADToken bool
+	// AzureDevOpsServer marks the API endpoint as the URL of an Azure DevOps Server (on-premises) collection, rather than of an Azure DevOps Services organization
+	AzureDevOpsServer bool
+	// AzureNTLMAuth marks the username and the token as the Windows credentials of an Azure DevOps Server user.
+	// They are sent with NTLM authentication, rather than as a personal access token.
+	AzureNTLMAuth bool
+	// AzureAPIVersion is optional, and pins the version of the Azure DevOps REST API which the Azure Repos requests are sent with - 6.0, 7.0 or 7.1.
+	// By default, the latest version which the server supports is used.
+	AzureAPIVersion string
+	// AWSRegion is the region of the repositories, and is relevant for AWS CodeCommit
+	AWSRegion string
+	// AWSSessionToken is the session token of temporary AWS credentials, and is relevant for AWS CodeCommit.
+	// The access key ID and the secret access key are taken from the username and the token.
+	AWSSessionToken string
+	// AWSCredentialsProvider is optional, and is used instead of static credentials to sign the AWS CodeCommit requests,
+	// for example to assume an IAM role or to use the credentials of the environment
+	AWSCredentialsProvider aws.CredentialsProvider
 }
 
 // RepositoryEnvironmentInfo is the environment details configured for a repository
@@ -61,8 +245,74 @@ type RepositoryEnvironmentInfo struct {
 	Reviewers []string
 }
 
+// DeploymentState the state of a deployment of a repository environment
+type DeploymentState int
+
+const (
+	// DeploymentPending means that the deployment was created and hasn't started yet
+	DeploymentPending DeploymentState = iota
+	// DeploymentInProgress means that the deployment is running
+	DeploymentInProgress
+	// DeploymentSuccess means that the deployment completed successfully
+	DeploymentSuccess
+	// DeploymentFailure means that the deployment failed
+	DeploymentFailure
+)
+
+// DeploymentInfo is a deployment of a ref to a repository environment
+// ID          - The ID of the deployment
+// Ref         - The branch, tag or commit SHA which was deployed
+// CommitSHA   - The deployed commit
+// Environment - The name of the environment
+// Description - The description of the deployment, not available on GitLab
+// CreatedAt   - Date of the deployment creation
+type DeploymentInfo struct {
+	ID          string
+	Ref         string
+	CommitSHA   string
+	Environment string
+	Description string
+	CreatedAt   time.Time
+}
+
+// RepositoryVariableInfo is a CI variable or secret of a repository
+// Name     - The variable name
+// Value    - The variable value, empty for secrets since their values can't be read back
+// IsSecret - Whether the variable is a secret, such as a GitHub Actions secret or a masked GitLab CI variable
+type RepositoryVariableInfo struct {
+	Name     string
+	Value    string
+	IsSecret bool
+}
+
+// SecretVisibility the repositories of an organization which can access an organization secret
+type SecretVisibility int
+
+const (
+	// VisibleToAllRepositories grants access to all the repositories of the organization
+	VisibleToAllRepositories SecretVisibility = iota
+	// VisibleToPrivateRepositories grants access to the private and internal repositories of the organization
+	VisibleToPrivateRepositories
+	// VisibleToSelectedRepositories grants access to the selected repositories of the organization only
+	VisibleToSelectedRepositories
+)
+
+// SecretInfo is a CI secret of an organization or an environment, whose value can't be read back
+// Name          - The secret name
+// Visibility    - The repositories which can access the secret, relevant for organization secrets only
+// CreatedAt     - Date of the secret creation
+// LastUpdatedAt - Date of the secret last update
+type SecretInfo struct {
+	Name          string
+	Visibility    SecretVisibility
+	CreatedAt     time.Time
+	LastUpdatedAt time.Time
+}
+
 // CommitStatusInfo status which is then reflected in pull requests involving those commits
 // State         - One of success, pending, failure, or error
+// RawState      - The state as reported by the VCS provider, such as "success" on GitHub or "SUCCESSFUL" on Bitbucket
+// Context       - The label of the status, which is the title it was set with
 // Description   - Description of the commit status
 // DetailsUrl    - The URL for component status link
 // Creator       - The creator of the status
@@ -70,6 +320,8 @@ type RepositoryEnvironmentInfo struct {
 // LastUpdatedAt - Date of status last update time.
 type CommitStatusInfo struct {
 	State         CommitStatus
+	RawState      string
+	Context       string
 	Description   string
 	DetailsUrl    string
 	Creator       string
@@ -77,19 +329,145 @@ type CommitStatusInfo struct {
 	LastUpdatedAt time.Time
 }
 
+// PipelineInfo is a run of a CI pipeline or workflow
+// ID            - The ID of the pipeline run
+// Status        - One of Pass, Fail or InProgress
+// RawStatus     - The status as reported by the VCS provider, such as "running" on GitLab, or the conclusion of a completed GitHub workflow run
+// Ref           - The branch or the tag which the pipeline runs on
+// CommitSHA     - The commit which the pipeline runs on
+// WebURL        - The URL of the pipeline run
+// CreatedAt     - Date of the pipeline run creation
+// LastUpdatedAt - Date of the pipeline run last update
+type PipelineInfo struct {
+	ID            string
+	Status        CommitStatus
+	RawStatus     string
+	Ref           string
+	CommitSHA     string
+	WebURL        string
+	CreatedAt     time.Time
+	LastUpdatedAt time.Time
+}
+
 // VcsClient is a base class of all Vcs clients - GitHub, GitLab, Bitbucket server and cloud clients
 type VcsClient interface {
 	// TestConnection Returns nil if connection and authorization established successfully
 	TestConnection(ctx context.Context) error
 
+	// GetAuthenticatedUser Returns the details of the user identified by the client's token
+	GetAuthenticatedUser(ctx context.Context) (UserInfo, error)
+
+	// TestConnectionWithScopes Returns nil if connection and authorization established successfully, and the token has all the input scopes
+	// scopes - The scopes required for the operations the caller intends to perform
+	TestConnectionWithScopes(ctx context.Context, scopes ...TokenScope) error
+
 	// ListRepositories Returns a map between all accessible owners to their list of repositories
 	ListRepositories(ctx context.Context) (map[string][]string, error)
 
+	// ListRepositoriesWithOptions Returns a map between the accessible owners to their repositories in a page of the accessible repositories
+	// options - The pagination of the listed repositories
+	ListRepositoriesWithOptions(ctx context.Context, options ListOptions) (map[string][]string, error)
+
+	// ListAppInstallations Lists the installations of the GitHub App the client is authenticated as
+	ListAppInstallations(ctx context.Context) ([]AppInstallationInfo, error)
+
+	// ListInstallationRepositories Returns a map between the owners to their list of repositories accessible to an installation of the GitHub App
+	// installationID - The ID of the installation
+	ListInstallationRepositories(ctx context.Context, installationID int64) (map[string][]string, error)
+
 	// ListBranches Lists all branches under the input repository
 	// owner      - User or organization
 	// repository - VCS repository name
 	ListBranches(ctx context.Context, owner, repository string) ([]string, error)
 
+	// ListBranchesWithOptions Lists a page of the branches under the input repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// options    - The pagination of the listed branches
+	ListBranchesWithOptions(ctx context.Context, owner, repository string, options ListOptions) ([]string, error)
+
+	// CreateBranch Creates a new branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// sourceRef  - The branch name or commit SHA to create the new branch from
+	// newBranch  - The name of the new branch
+	CreateBranch(ctx context.Context, owner, repository, sourceRef, newBranch string) error
+
+	// DeleteBranch Deletes a branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the branch to delete
+	DeleteBranch(ctx context.Context, owner, repository, branch string) error
+
+	// GetDefaultBranch Returns the name of the default branch of a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	GetDefaultBranch(ctx context.Context, owner, repository string) (string, error)
+
+	// SetDefaultBranch Sets the default branch of a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of an existing branch
+	SetDefaultBranch(ctx context.Context, owner, repository, branch string) error
+
+	// GetBranchProtection Returns the protection rules of a branch. An unprotected branch has no rules.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the branch
+	GetBranchProtection(ctx context.Context, owner, repository, branch string) (BranchProtectionInfo, error)
+
+	// SetBranchProtection Replaces the protection rules of a branch
+	// owner      - User or organization
+	// repository - VCS repository name
+	// branch     - The name of the branch
+	// protection - The protection rules to set
+	SetBranchProtection(ctx context.Context, owner, repository, branch string, protection BranchProtectionInfo) error
+
+	// ListTags Lists all tags under the input repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListTags(ctx context.Context, owner, repository string) ([]TagInfo, error)
+
+	// CreateTag Creates a new tag
+	// owner      - User or organization
+	// repository - VCS repository name
+	// tagName    - The name of the new tag
+	// sourceRef  - The branch name or commit SHA to create the tag on
+	// message    - The tag message. An annotated tag is created if not empty, and a lightweight tag otherwise
+	CreateTag(ctx context.Context, owner, repository, tagName, sourceRef, message string) error
+
+	// GetTagInfo Returns the commit a tag points to
+	// owner      - User or organization
+	// repository - VCS repository name
+	// tagName    - The name of the tag
+	GetTagInfo(ctx context.Context, owner, repository, tagName string) (CommitInfo, error)
+
+	// CreateRelease Creates a release of a tag, and returns the created release
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// tagName      - The name of the tag to release
+	// name         - The release name
+	// releaseNotes - The release notes
+	CreateRelease(ctx context.Context, owner, repository, tagName, name, releaseNotes string) (ReleaseInfo, error)
+
+	// ListReleases Lists all releases of a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListReleases(ctx context.Context, owner, repository string) ([]ReleaseInfo, error)
+
+	// GetLatestRelease Returns the most recent release of a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	GetLatestRelease(ctx context.Context, owner, repository string) (ReleaseInfo, error)
+
+	// UploadReleaseAsset Attaches a file to a release, and returns the download URL of the uploaded asset
+	// owner      - User or organization
+	// repository - VCS repository name
+	// releaseID  - The ID of the release, as returned in ReleaseInfo
+	// name       - The file name of the asset
+	// asset      - The content of the asset
+	UploadReleaseAsset(ctx context.Context, owner, repository, releaseID, name string, asset io.Reader) (string, error)
+
 	// CreateWebhook Creates a webhook
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -99,6 +477,15 @@ type VcsClient interface {
 	// Return the webhook ID, token and an error, if occurred
 	CreateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error)
 
+	// CreateOrUpdateWebhook Updates the webhook with the payload URL, or creates a new webhook if none exists
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// branch        - VCS branch name
+	// payloadURL    - URL to send the payload when a webhook event occurs
+	// webhookEvents - The event type
+	// Return the webhook ID, a new token and an error, if occurred
+	CreateOrUpdateWebhook(ctx context.Context, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error)
+
 	// UpdateWebhook Updates a webhook
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -115,6 +502,26 @@ type VcsClient interface {
 	// webhookID    - The webhook ID returned from a previous CreateWebhook command
 	DeleteWebhook(ctx context.Context, owner, repository, webhookID string) error
 
+	// RotateWebhookSecret Replaces the token of a webhook with a new token, keeping the rest of the webhook settings
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// webhookID    - The webhook ID returned from a previous CreateWebhook command
+	// Return the new token and an error, if occurred.
+	// Until all the incoming webhooks are signed by the new token, authenticate them by both the new and the old tokens,
+	// using webhookparser.WebhookOrigin.Tokens.
+	RotateWebhookSecret(ctx context.Context, owner, repository, webhookID string) (string, error)
+
+	// ListWebhooks Returns the webhooks of a repository
+	// owner        - User or organization
+	// repository   - VCS repository name
+	ListWebhooks(ctx context.Context, owner, repository string) ([]WebhookInfo, error)
+
+	// GetWebhook Returns a webhook of a repository
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// webhookID    - The webhook ID returned from a previous CreateWebhook command
+	GetWebhook(ctx context.Context, owner, repository, webhookID string) (WebhookInfo, error)
+
 	// SetCommitStatus Sets commit status
 	// commitStatus - One of Pass, Fail, Error, or InProgress
 	// owner        - User or organization
@@ -131,6 +538,62 @@ type VcsClient interface {
 	// ref          - SHA, a branch name, or a tag name.
 	GetCommitStatuses(ctx context.Context, owner, repository, ref string) (status []CommitStatusInfo, err error)
 
+	// GetCombinedCommitStatus Returns a single verdict of all statuses and checks of a ref - Pass, InProgress or Fail.
+	// A ref without any status or check is considered as passed.
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// ref          - SHA, a branch name, or a tag name.
+	GetCombinedCommitStatus(ctx context.Context, owner, repository, ref string) (CommitStatus, error)
+
+	// TriggerPipeline Triggers a CI pipeline run of a branch or a tag
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// ref          - A branch name or a tag name
+	// variables    - Optional variables which the pipeline runs with
+	TriggerPipeline(ctx context.Context, owner, repository, ref string, variables map[string]string) (PipelineInfo, error)
+
+	// GetLatestPipelineStatus Returns the latest CI pipeline run of a ref
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// ref          - SHA, a branch name, or a tag name.
+	GetLatestPipelineStatus(ctx context.Context, owner, repository, ref string) (PipelineInfo, error)
+
+	// GetPipelineStatus Returns a CI pipeline run by its ID, to poll the status of a triggered pipeline
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// pipelineID   - The ID of the pipeline run, as returned by TriggerPipeline
+	GetPipelineStatus(ctx context.Context, owner, repository, pipelineID string) (PipelineInfo, error)
+
+	// TriggerWorkflow Triggers a run of a CI workflow on a branch or a tag
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// workflowFile - The file name of the workflow, such as build.yml
+	// ref          - A branch name or a tag name
+	// inputs       - Optional inputs which the workflow runs with
+	TriggerWorkflow(ctx context.Context, owner, repository, workflowFile, ref string, inputs map[string]string) error
+
+	// GetWorkflowRunStatus Returns the latest run of a CI workflow on a ref
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// workflowFile - The file name of the workflow, such as build.yml
+	// ref          - SHA, a branch name, or a tag name.
+	GetWorkflowRunStatus(ctx context.Context, owner, repository, workflowFile, ref string) (PipelineInfo, error)
+
+	// QueueBuild Queues a build of a CI pipeline definition on a branch
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// definitionID - The ID of the pipeline definition
+	// branch       - The branch to build
+	// variables    - Optional variables which the build runs with
+	QueueBuild(ctx context.Context, owner, repository string, definitionID int, branch string, variables map[string]string) (PipelineInfo, error)
+
+	// GetBuildStatus Returns the latest build of a CI pipeline definition on a branch
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// definitionID - The ID of the pipeline definition
+	// branch       - The built branch
+	GetBuildStatus(ctx context.Context, owner, repository string, definitionID int, branch string) (PipelineInfo, error)
+
 	// DownloadRepository Downloads and extracts a VCS repository
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -138,6 +601,15 @@ type VcsClient interface {
 	// localPath  - Local file system path
 	DownloadRepository(ctx context.Context, owner, repository, branch, localPath string) error
 
+	// DownloadRepositoryPaths Downloads and extracts only the given paths of a VCS repository, such as the manifest files which are needed for a scan.
+	// Providers which can't download selected paths download the whole archive, and extract only the given paths.
+	// owner      - User or organization
+	// repository - VCS repository name
+	// ref        - SHA, a branch name, or a tag name
+	// paths      - Files or directories, relative to the root of the repository
+	// localPath  - Local file system path
+	DownloadRepositoryPaths(ctx context.Context, owner, repository, ref string, paths []string, localPath string) error
+
 	// CreatePullRequest Creates a pull request between 2 different branches in the same repository
 	// owner        - User or organization
 	// repository   - VCS repository name
@@ -145,18 +617,44 @@ type VcsClient interface {
 	// targetBranch - Target branch
 	// title        - Pull request title
 	// description  - Pull request description
-	CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) error
-
-	// UpdatePullRequest Updates pull requests metadata
-	// owner        		    - User or organization
-	// repository    		    - VCS repository name
-	// title         	        - Pull request title
-	// body                     - Pull request body or description
-	// targetBranchName         - Name of the pull request target branch name,For non-change, pass an empty string.
-	// prId				        - Pull request ID
-	// state				    - Pull request state
+	// Returns the created pull request
+	CreatePullRequest(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string) (PullRequestInfo, error)
+
+	// CreatePullRequestWithOptions Creates a pull request between 2 different branches in the same repository, with additional settings
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// sourceBranch - Source branch
+	// targetBranch - Target branch
+	// title        - Pull request title
+	// description  - Pull request description
+	// options      - The additional settings of the pull request
+	// Returns the created pull request
+	CreatePullRequestWithOptions(ctx context.Context, owner, repository, sourceBranch, targetBranch, title, description string, options CreatePullRequestOptions) (PullRequestInfo, error)
+
+	// UpdatePullRequest Updates pull requests metadata. Empty title, body and target branch are left unchanged.
+	// owner            - User or organization
+	// repository       - VCS repository name
+	// title            - Pull request title
+	// body             - Pull request body or description
+	// targetBranchName - Name of the pull request target branch
+	// prId             - Pull request ID
+	// state            - Pull request state, vcsutils.Closed closes the pull request and vcsutils.Open reopens it
 	UpdatePullRequest(ctx context.Context, owner, repository, title, body, targetBranchName string, prId int, state vcsutils.PullRequestState) error
 
+	// MergePullRequest Merges a pull request into its target branch
+	// owner        - User or organization
+	// repository   - VCS repository name
+	// prId         - Pull request ID
+	// mergeMethod  - The merge strategy: merge, squash or rebase
+	MergePullRequest(ctx context.Context, owner, repository string, prId int, mergeMethod vcsutils.MergeMethod) error
+
+	// SetPullRequestAutoMerge Sets a pull request to be merged automatically once its required checks and approvals pass
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// mergeMethod    - The method to merge the pull request by
+	SetPullRequestAutoMerge(ctx context.Context, owner, repository string, pullRequestID int, mergeMethod vcsutils.MergeMethod) error
+
 	// AddPullRequestComment Adds a new comment on the requested pull request
 	// owner          - User or organization
 	// repository     - VCS repository name
@@ -171,7 +669,7 @@ type VcsClient interface {
 	// comment        - The new comment details defined in PullRequestComment
 	AddPullRequestReviewComments(ctx context.Context, owner, repository string, pullRequestID int, comments ...PullRequestComment) error
 
-	// ListPullRequestReviewComments Gets all pull request review comments
+	// ListPullRequestReviewComments Gets all pull request review comments, which are anchored to a file in the pull request diff
 	// owner          - User or organization
 	// repository     - VCS repository name
 	// pullRequestID  - Pull request ID
@@ -190,6 +688,21 @@ type VcsClient interface {
 	// pullRequestID  - Pull request ID
 	ListPullRequestComments(ctx context.Context, owner, repository string, pullRequestID int) ([]CommentInfo, error)
 
+	// ListPullRequestCommentsWithOptions Gets a page of the comments assigned to a pull request, optionally only the comments updated after a given time.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// options        - The filter and the pagination of the listed comments
+	ListPullRequestCommentsWithOptions(ctx context.Context, owner, repository string, pullRequestID int, options ListPullRequestCommentsOptions) ([]CommentInfo, error)
+
+	// UpdatePullRequestComment replaces the content of a specific comment in a pull request.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// content        - The new comment content
+	// pullRequestID  - Pull request ID
+	// commentID 	  - The ID of the comment
+	UpdatePullRequestComment(ctx context.Context, owner, repository, content string, pullRequestID, commentID int) error
+
 	// DeletePullRequestComment deleted a specific comment in a pull request.
 	// owner          - User or organization
 	// repository     - VCS repository name
@@ -197,6 +710,28 @@ type VcsClient interface {
 	// commentID 	  - The ID of the comment
 	DeletePullRequestComment(ctx context.Context, owner, repository string, pullRequestID, commentID int) error
 
+	// SetPullRequestThreadStatus resolves or unresolves a comment thread in a pull request.
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// threadID       - The ThreadID of the thread's comments on GitLab and Azure Repos, and the NativeID of one of the thread's comments on the rest of the VCS providers
+	// resolved       - True to resolve the thread, and false to unresolve it
+	SetPullRequestThreadStatus(ctx context.Context, owner, repository string, pullRequestID int, threadID string, resolved bool) error
+
+	// CreatePullRequestReview Submits a review on a pull request
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	// verdict        - Approve, request changes or comment
+	// body           - The review comment, required when the verdict is vcsutils.ReviewVerdictComment
+	CreatePullRequestReview(ctx context.Context, owner, repository string, pullRequestID int, verdict vcsutils.ReviewVerdict, body string) error
+
+	// ListPullRequestReviews Gets the current review of each reviewer of a pull request
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// pullRequestID  - Pull request ID
+	ListPullRequestReviews(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestReviewInfo, error)
+
 	// ListOpenPullRequestsWithBody Gets all open pull requests ids and the pull request body.
 	// owner          - User or organization
 	// repository     - VCS repository name
@@ -207,7 +742,19 @@ type VcsClient interface {
 	// repository     - VCS repository name
 	ListOpenPullRequests(ctx context.Context, owner, repository string) ([]PullRequestInfo, error)
 
-	// GetPullRequestByID Gets pull request info by ID.
+	// ListOpenPullRequestsWithOptions Gets a page of the open pull requests matching the filters of the options
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// options        - Filters and pagination
+	ListOpenPullRequestsWithOptions(ctx context.Context, owner, repository string, options ListPullRequestsOptions) ([]PullRequestInfo, error)
+
+	// ListPullRequestsWithState Gets all the pull requests in a state, including their body
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// state          - Open, closed or merged
+	ListPullRequestsWithState(ctx context.Context, owner, repository string, state vcsutils.PullRequestState) ([]PullRequestInfo, error)
+
+	// GetPullRequestByID Gets pull request info by ID, including its title, body, author, state and mergeability.
 	// owner          - User or organization
 	// repository     - VCS repository name
 	// pullRequestId  - ID of the pull request
@@ -225,6 +772,12 @@ type VcsClient interface {
 	// branch     - The name of the branch
 	GetCommits(ctx context.Context, owner, repository, branch string) ([]CommitInfo, error)
 
+	// ListCommits Gets a page of the commits of a branch, filtered by the given options
+	// owner      - User or organization
+	// repository - VCS repository name
+	// options    - The branch, the filters and the page of the commits to list
+	ListCommits(ctx context.Context, owner, repository string, options ListCommitsOptions) ([]CommitInfo, error)
+
 	// AddSshKeyToRepository Adds a public ssh key to a repository
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -238,6 +791,34 @@ type VcsClient interface {
 	// repository - VCS repository name
 	GetRepositoryInfo(ctx context.Context, owner, repository string) (RepositoryInfo, error)
 
+	// CreateRepository Creates a new repository and returns its information
+	// owner      - User or organization
+	// repository - VCS repository name
+	// options    - The visibility, description and initial content of the repository
+	CreateRepository(ctx context.Context, owner, repository string, options CreateRepositoryOptions) (RepositoryInfo, error)
+
+	// DeleteRepository Deletes a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	DeleteRepository(ctx context.Context, owner, repository string) error
+
+	// ForkRepository Forks a repository and returns the information of the fork
+	// owner       - User or organization
+	// repository  - VCS repository name
+	// targetOwner - The user or organization to fork the repository to. Leave empty to fork it to the authenticated user
+	ForkRepository(ctx context.Context, owner, repository, targetOwner string) (RepositoryInfo, error)
+
+	// ListRepositoryCollaborators Lists the users with access to a repository and their permissions
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListRepositoryCollaborators(ctx context.Context, owner, repository string) ([]CollaboratorInfo, error)
+
+	// GetRepositoryPermission Returns the permission of a user on a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// username   - The username to get the permission of
+	GetRepositoryPermission(ctx context.Context, owner, repository, username string) (RepositoryPermission, error)
+
 	// GetCommitBySha Gets the commit by its SHA
 	// owner      - User or organization
 	// repository - VCS repository name
@@ -256,12 +837,37 @@ type VcsClient interface {
 	// name       - Label name
 	GetLabel(ctx context.Context, owner, repository, name string) (*LabelInfo, error)
 
+	// ListRepositoryLabels Gets all labels defined in a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	ListRepositoryLabels(ctx context.Context, owner, repository string) ([]LabelInfo, error)
+
+	// UpdateLabel Updates a label in a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// name       - The current label name
+	// labelInfo  - The new label info
+	UpdateLabel(ctx context.Context, owner, repository, name string, labelInfo LabelInfo) error
+
+	// DeleteLabel Deletes a label from a repository
+	// owner      - User or organization
+	// repository - VCS repository name
+	// name       - Label name
+	DeleteLabel(ctx context.Context, owner, repository, name string) error
+
 	// ListPullRequestLabels Gets all labels assigned to a pull request.
 	// owner         - User or organization
 	// repository    - VCS repository name
 	// pullRequestID - Pull request ID
 	ListPullRequestLabels(ctx context.Context, owner, repository string, pullRequestID int) ([]string, error)
 
+	// LabelPullRequest Adds labels to a pull request
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	// labels        - Label names
+	LabelPullRequest(ctx context.Context, owner, repository string, pullRequestID int, labels []string) error
+
 	// UnlabelPullRequest Removes a label from a pull request
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -269,6 +875,32 @@ type VcsClient interface {
 	// pullRequestID - Pull request ID
 	UnlabelPullRequest(ctx context.Context, owner, repository, name string, pullRequestID int) error
 
+	// CreateIssue Creates an issue in a repository
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// title         - Issue title
+	// body          - Issue description
+	CreateIssue(ctx context.Context, owner, repository, title, body string) (IssueInfo, error)
+
+	// ListIssues Gets a page of the repository issues matching the options
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// options       - The filters and the pagination of the issues
+	ListIssues(ctx context.Context, owner, repository string, options ListIssuesOptions) ([]IssueInfo, error)
+
+	// AddIssueComment Adds a comment to an issue
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// content       - The comment content
+	// issueID       - Issue ID
+	AddIssueComment(ctx context.Context, owner, repository, content string, issueID int) error
+
+	// CloseIssue Closes an issue
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// issueID       - Issue ID
+	CloseIssue(ctx context.Context, owner, repository string, issueID int) error
+
 	// UploadCodeScanning Upload Scanning Analysis uploads a scanning analysis file to the relevant git provider
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -276,6 +908,34 @@ type VcsClient interface {
 	// scan          - Code scanning analysis
 	UploadCodeScanning(ctx context.Context, owner, repository, branch, scanResults string) (string, error)
 
+	// CreateCodeInsightsReport Creates a Code Insights report on a commit, or replaces the report with the same key
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// commitHash    - The hash of the commit
+	// report        - The report details
+	CreateCodeInsightsReport(ctx context.Context, owner, repository, commitHash string, report CodeInsightsReport) error
+
+	// AddCodeInsightsAnnotations Adds annotations to an existing Code Insights report
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// commitHash    - The hash of the commit
+	// reportKey     - The key of the report
+	// annotations   - The annotations to add
+	AddCodeInsightsAnnotations(ctx context.Context, owner, repository, commitHash, reportKey string, annotations []CodeInsightsAnnotation) error
+
+	// CreateCheckRun Creates a check run on a commit, and returns its ID
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// checkRun      - The check run details
+	CreateCheckRun(ctx context.Context, owner, repository string, checkRun CheckRunInfo) (int64, error)
+
+	// UpdateCheckRun Updates a check run, and adds its annotations to the existing ones
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// checkRunID    - The ID of the check run
+	// checkRun      - The check run details
+	UpdateCheckRun(ctx context.Context, owner, repository string, checkRunID int64, checkRun CheckRunInfo) error
+
 	// DownloadFileFromRepo Downloads a file from path in a repository
 	// owner         - User or organization
 	// repository    - VCS repository name
@@ -283,19 +943,130 @@ type VcsClient interface {
 	// path          - The path to the requested file
 	DownloadFileFromRepo(ctx context.Context, owner, repository, branch, path string) ([]byte, int, error)
 
-	// GetRepositoryEnvironmentInfo Gets the environment info configured for a repository
+	// ListDirectoryContents Lists the files and directories directly under a path in a repository
 	// owner         - User or organization
 	// repository    - VCS repository name
-	// name          - The environment name
-	GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error)
+	// ref           - A VCS reference: commit SHA, branch name, tag name
+	// path          - The path of the directory, leave empty for the repository root
+	ListDirectoryContents(ctx context.Context, owner, repository, ref, path string) ([]DirectoryEntry, error)
 
-	// GetModifiedFiles returns list of file names modified between two VCS references
+	// CommitFiles Creates a commit with the given file changes on top of a branch
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// branch        - The name of the branch
+	// commitMessage - The commit message
+	// files         - The files to add, modify or delete
+	CommitFiles(ctx context.Context, owner, repository, branch, commitMessage string, files []FileToCommit) error
+
+	// GetRepositoryEnvironmentInfo Gets the environment info configured for a repository
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// name          - The environment name
+	GetRepositoryEnvironmentInfo(ctx context.Context, owner, repository, name string) (RepositoryEnvironmentInfo, error)
+
+	// ListEnvironments Returns the environments configured for a repository
+	// owner         - User or organization
+	// repository    - VCS repository name
+	ListEnvironments(ctx context.Context, owner, repository string) ([]RepositoryEnvironmentInfo, error)
+
+	// CreateDeployment Records a deployment of a ref to a repository environment
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// ref           - The branch, tag or commit SHA to deploy
+	// environment   - The environment name
+	// description   - Optional description of the deployment, ignored on GitLab
+	CreateDeployment(ctx context.Context, owner, repository, ref, environment, description string) (DeploymentInfo, error)
+
+	// SetDeploymentStatus Updates the state of a deployment
+	// owner          - User or organization
+	// repository     - VCS repository name
+	// deploymentID   - The ID of the deployment, as returned by CreateDeployment
+	// state          - The new state of the deployment
+	// description    - Optional description of the state, ignored on GitLab
+	// environmentURL - Optional URL of the deployed environment
+	SetDeploymentStatus(ctx context.Context, owner, repository, deploymentID string, state DeploymentState, description, environmentURL string) error
+
+	// CreateOrUpdateRepositorySecret Creates a CI secret of a repository, or replaces the value of an existing secret
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// name          - The secret name
+	// value         - The secret value
+	CreateOrUpdateRepositorySecret(ctx context.Context, owner, repository, name, value string) error
+
+	// ListRepositoryVariables Returns the CI variables and secrets of a repository, without the values of the secrets
+	// owner         - User or organization
+	// repository    - VCS repository name
+	ListRepositoryVariables(ctx context.Context, owner, repository string) ([]RepositoryVariableInfo, error)
+
+	// CreateOrUpdateOrganizationSecret Creates a CI secret of an organization, or replaces an existing secret
+	// owner         - Organization
+	// name          - The secret name
+	// value         - The secret value
+	// visibility    - The repositories of the organization which can access the secret
+	// repositories  - The names of the repositories which can access the secret, relevant for VisibleToSelectedRepositories only
+	CreateOrUpdateOrganizationSecret(ctx context.Context, owner, name, value string, visibility SecretVisibility, repositories []string) error
+
+	// ListOrganizationSecrets Returns the CI secrets of an organization, without their values
+	// owner         - Organization
+	ListOrganizationSecrets(ctx context.Context, owner string) ([]SecretInfo, error)
+
+	// ListOrganizationSecretRepositories Returns the names of the repositories which can access an organization secret visible to selected repositories
+	// owner         - Organization
+	// name          - The secret name
+	ListOrganizationSecretRepositories(ctx context.Context, owner, name string) ([]string, error)
+
+	// DeleteOrganizationSecret Deletes a CI secret of an organization
+	// owner         - Organization
+	// name          - The secret name
+	DeleteOrganizationSecret(ctx context.Context, owner, name string) error
+
+	// CreateOrUpdateEnvironmentSecret Creates a CI secret of a repository environment, or replaces an existing secret
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// environment   - The environment name
+	// name          - The secret name
+	// value         - The secret value
+	CreateOrUpdateEnvironmentSecret(ctx context.Context, owner, repository, environment, name, value string) error
+
+	// ListEnvironmentSecrets Returns the CI secrets of a repository environment, without their values
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// environment   - The environment name
+	ListEnvironmentSecrets(ctx context.Context, owner, repository, environment string) ([]SecretInfo, error)
+
+	// DeleteEnvironmentSecret Deletes a CI secret of a repository environment
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// environment   - The environment name
+	// name          - The secret name
+	DeleteEnvironmentSecret(ctx context.Context, owner, repository, environment, name string) error
+
+	// GetModifiedFiles returns list of file names modified between two VCS references
 	// owner         - User or organization
 	// repository    - VCS repository name
 	// refBefore     - A VCS reference: commit SHA, branch name, tag name
 	// refAfter      - A VCS reference: commit SHA, branch name, tag name
 	GetModifiedFiles(ctx context.Context, owner, repository, refBefore, refAfter string) ([]string, error)
 
+	// CompareCommits returns the commits and the files changed between two VCS references
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// base          - A VCS reference to compare from: commit SHA, branch name, tag name
+	// head          - A VCS reference to compare to: commit SHA, branch name, tag name
+	CompareCommits(ctx context.Context, owner, repository, base, head string) (CommitsComparison, error)
+
+	// ListPullRequestFiles returns the files changed in a pull request
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	ListPullRequestFiles(ctx context.Context, owner, repository string, pullRequestID int) ([]PullRequestFile, error)
+
+	// GetPullRequestDiff returns the changes of a pull request in the unified diff format
+	// owner         - User or organization
+	// repository    - VCS repository name
+	// pullRequestID - Pull request ID
+	GetPullRequestDiff(ctx context.Context, owner, repository string, pullRequestID int) (string, error)
+
 	// GetPullRequestCommentSizeLimit returns the maximum size of a pull request comment
 	GetPullRequestCommentSizeLimit() int
 
@@ -323,20 +1094,87 @@ type CommitInfo struct {
 	AuthorEmail string
 }
 
+// BranchProtectionInfo contains the protection rules of a branch
+// RequiredApprovals    - The number of approvals required to merge a pull request into the branch
+// RequiredStatusChecks - The contexts of the statuses which must pass to merge a pull request into the branch
+// RestrictPushes       - Whether direct pushes to the branch are blocked, so changes are merged through pull requests
+type BranchProtectionInfo struct {
+	RequiredApprovals    int
+	RequiredStatusChecks []string
+	RestrictPushes       bool
+}
+
+// TagInfo contains a tag information
+type TagInfo struct {
+	Name string
+	// The SHA-1 hash of the commit the tag points to
+	CommitSha string
+}
+
+// ReleaseInfo contains a release information
+type ReleaseInfo struct {
+	// The release ID. Releases are identified by their tag name on GitLab, and by the download file name on Bitbucket cloud
+	ID      string
+	Name    string
+	TagName string
+	// The release notes
+	Body      string
+	URL       string
+	CreatedAt time.Time
+}
+
 type CommentInfo struct {
-	ID       int64
+	// ID is the ID of the comment. On Azure Repos, whose comment IDs are only unique within their thread, it is the thread ID.
+	ID int64
+	// NativeID is the ID of the comment as reported by the VCS provider.
+	// Unlike ID, it is also set on AWS CodeCommit and Gerrit, whose comment IDs aren't numeric.
+	NativeID string
+	// ThreadID is the ID of the discussion (GitLab) or thread (Azure Repos) the comment belongs to.
+	// On AWS CodeCommit and Gerrit, whose comment IDs aren't numeric, it is the ID of the comment itself.
 	ThreadID string
+	// ParentID is the native ID of the comment this comment replies to, and is empty if the comment isn't a reply
+	ParentID string
 	Content  string
-	Created  time.Time
-	Version  int
+	// Author is the display name of the comment author, and AuthorUsername is its username, when reported by the VCS provider
+	Author         string
+	AuthorUsername string
+	Created        time.Time
+	Updated        time.Time
+	Version        int
+	// FilePath and Line locate review comments, which are anchored to a line in the pull request diff
+	FilePath string
+	Line     int
+	// DiffHunk is the diff context of a review comment, when reported by the VCS provider
+	DiffHunk string
+}
+
+// PullRequestReviewInfo contains the details of a pull request review
+type PullRequestReviewInfo struct {
+	// Reviewer is the username of the reviewer, or the display name on Bitbucket cloud
+	Reviewer string
+	State    vcsutils.ReviewVerdict
+	Body     string
+	// Submitted is the time the review was submitted, when reported by the VCS provider
+	Submitted time.Time
 }
 
 type PullRequestInfo struct {
-	ID     int64
-	Body   string
-	URL    string
-	Source BranchInfo
-	Target BranchInfo
+	ID    int64
+	Title string
+	Body  string
+	URL   string
+	// Author is the username of the pull request author. It is the nickname on Bitbucket cloud, and the unique name on Azure Repos.
+	Author string
+	State  vcsutils.PullRequestState
+	// Mergeable is true when the VCS provider reports that the pull request can be merged without conflicts.
+	// It is always false on Bitbucket cloud, which doesn't report the pull request mergeability.
+	Mergeable bool
+	Source    BranchInfo
+	Target    BranchInfo
+	CreatedAt time.Time
+	// UpdatedAt is the time of the last pull request update.
+	// It is always empty on Azure Repos, which doesn't report it.
+	UpdatedAt time.Time
 }
 
 type BranchInfo struct {
@@ -377,10 +1215,415 @@ type PullRequestDiff struct {
 	NewEndColumn        int
 }
 
+// PullRequestFile contains the details of a file changed in a pull request
+type PullRequestFile struct {
+	// The path of the file after the change, or the path of the deleted file
+	Path string
+	// The path of the file before the change, set when the file was renamed
+	PreviousPath string
+	ChangeType   FileChangeType
+}
+
+// ListOptions contains the pagination of listings which have no filters
+type ListOptions struct {
+	// The number of items per page, defaults to vcsutils.NumberOfItemsToFetch
+	PerPage int
+	// The page number, starting from 1
+	Page int
+}
+
+func (options ListOptions) getPagination() (page, perPage int) {
+	return getPagination(options.Page, options.PerPage, vcsutils.NumberOfItemsToFetch)
+}
+
+// Used by providers which can't paginate a listing, hence the page is taken from all the listed items
+func getListPage[T any](items []T, options ListOptions) []T {
+	page, perPage := options.getPagination()
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return nil
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// Used by providers which can't paginate the repositories listing.
+// The repositories are ordered by their owners and names, so that consecutive pages don't overlap.
+func getRepositoriesPage(repositories map[string][]string, options ListOptions) map[string][]string {
+	type ownerRepository struct {
+		owner      string
+		repository string
+	}
+	var allRepositories []ownerRepository
+	for owner, ownerRepositories := range repositories {
+		for _, repository := range ownerRepositories {
+			allRepositories = append(allRepositories, ownerRepository{owner: owner, repository: repository})
+		}
+	}
+	sort.Slice(allRepositories, func(i, j int) bool {
+		if allRepositories[i].owner != allRepositories[j].owner {
+			return allRepositories[i].owner < allRepositories[j].owner
+		}
+		return allRepositories[i].repository < allRepositories[j].repository
+	})
+	results := make(map[string][]string)
+	for _, repository := range getListPage(allRepositories, options) {
+		results[repository.owner] = append(results[repository.owner], repository.repository)
+	}
+	return results
+}
+
+// ListCommitsOptions contains the branch, the filters and the pagination of listed commits
+type ListCommitsOptions struct {
+	// The name of the branch
+	Branch string
+	// If set, only commits that changed this file path are listed
+	Path string
+	// If set, only commits committed at or after this time are listed
+	Since time.Time
+	// If set, only commits committed at or before this time are listed
+	Until time.Time
+	// If set, only commits authored by this user name or email are listed
+	Author string
+	// The number of commits per page, defaults to vcsutils.NumberOfCommitsToFetch
+	PerPage int
+	// The page number, starting from 1
+	Page int
+}
+
+func (options ListCommitsOptions) getPagination() (page, perPage int) {
+	return getPagination(options.Page, options.PerPage, vcsutils.NumberOfCommitsToFetch)
+}
+
+// Used by providers which can't filter the commits by the time or the author
+func (options ListCommitsOptions) matches(commit CommitInfo, commitTime time.Time) bool {
+	if !options.Since.IsZero() && commitTime.Before(options.Since) {
+		return false
+	}
+	if !options.Until.IsZero() && commitTime.After(options.Until) {
+		return false
+	}
+	return options.Author == "" || options.Author == commit.AuthorName || strings.EqualFold(options.Author, commit.AuthorEmail)
+}
+
+// ListPullRequestsOptions contains the filters and the pagination of listed pull requests
+type ListPullRequestsOptions struct {
+	// If set, only pull requests from this source branch are listed
+	SourceBranch string
+	// If set, only pull requests into this target branch are listed
+	TargetBranch string
+	// If set, only pull requests whose author, as reported in PullRequestInfo.Author, equals this value are listed
+	Author string
+	// If set, only pull requests updated at or after this time are listed. Not supported on Azure Repos.
+	UpdatedSince time.Time
+	// If true, the pull requests body is included
+	WithBody bool
+	// The number of pull requests per page, defaults to vcsutils.NumberOfPullRequestsToFetch
+	PerPage int
+	// The page number, starting from 1
+	Page int
+}
+
+func (options ListPullRequestsOptions) getPagination() (page, perPage int) {
+	return getPagination(options.Page, options.PerPage, vcsutils.NumberOfPullRequestsToFetch)
+}
+
+// Used by providers which can't filter the pull requests by all the options
+func (options ListPullRequestsOptions) matches(pullRequest PullRequestInfo) bool {
+	if options.SourceBranch != "" && options.SourceBranch != pullRequest.Source.Name {
+		return false
+	}
+	if options.TargetBranch != "" && options.TargetBranch != pullRequest.Target.Name {
+		return false
+	}
+	if options.Author != "" && options.Author != pullRequest.Author {
+		return false
+	}
+	return options.UpdatedSince.IsZero() || !pullRequest.UpdatedAt.Before(options.UpdatedSince)
+}
+
+// ListPullRequestCommentsOptions contains the filter and the pagination of listed pull request comments
+type ListPullRequestCommentsOptions struct {
+	// If set, only comments created or updated after this time are listed
+	UpdatedAfter time.Time
+	// The number of comments per page, defaults to vcsutils.NumberOfItemsToFetch
+	PerPage int
+	// The page number, starting from 1
+	Page int
+}
+
+func (options ListPullRequestCommentsOptions) getPagination() (page, perPage int) {
+	return getPagination(options.Page, options.PerPage, vcsutils.NumberOfItemsToFetch)
+}
+
+// Used by providers which can't filter the comments by their update time.
+// Comments without an update time are filtered by their creation time.
+func (options ListPullRequestCommentsOptions) matches(comment CommentInfo) bool {
+	if options.UpdatedAfter.IsZero() {
+		return true
+	}
+	updated := comment.Updated
+	if updated.IsZero() {
+		updated = comment.Created
+	}
+	return updated.After(options.UpdatedAfter)
+}
+
+// Used by providers which can't paginate the comments, hence the page is taken from all the matching comments
+func getPullRequestCommentsPage(comments []CommentInfo, options ListPullRequestCommentsOptions) []CommentInfo {
+	var matchingComments []CommentInfo
+	for _, comment := range comments {
+		if options.matches(comment) {
+			matchingComments = append(matchingComments, comment)
+		}
+	}
+	return getListPage(matchingComments, ListOptions{Page: options.Page, PerPage: options.PerPage})
+}
+
+// IssueInfo contains the details of a repository issue.
+// ID     - The issue number within the repository
+// Author - The username of the issue author, or the display name on Bitbucket cloud
+// URL    - The web URL of the issue
+type IssueInfo struct {
+	ID     int64
+	Title  string
+	Body   string
+	URL    string
+	Author string
+	State  vcsutils.IssueState
+	Labels []string
+}
+
+// ListIssuesOptions contains the filters and the pagination of listed issues
+type ListIssuesOptions struct {
+	// If set, only issues in this state are listed, otherwise issues in all states are listed
+	State vcsutils.IssueState
+	// If set, only issues with all these labels are listed. Not supported on Bitbucket cloud.
+	Labels []string
+	// If set, only issues whose author, as reported in IssueInfo.Author, equals this value are listed
+	Author string
+	// The number of issues per page, defaults to vcsutils.NumberOfIssuesToFetch
+	PerPage int
+	// The page number, starting from 1
+	Page int
+}
+
+func (options ListIssuesOptions) getPagination() (page, perPage int) {
+	return getPagination(options.Page, options.PerPage, vcsutils.NumberOfIssuesToFetch)
+}
+
+// CodeInsightsReport contains the summary of an analysis of a commit, displayed in the Code Insights panel of its pull requests.
+// Key      - A key which identifies the report in the commit. Creating a report with an existing key replaces the report and deletes its annotations
+// Reporter - [Optional] The name of the tool which created the report
+// Link     - [Optional] A link to the full results of the analysis
+type CodeInsightsReport struct {
+	Key      string
+	Title    string
+	Details  string
+	Result   CodeInsightsResult
+	Reporter string
+	Link     string
+}
+
+// CodeInsightsAnnotation is a single finding of a Code Insights report.
+// Path - [Optional] The path of the file, relative to the repository root. Leave empty for a finding of the whole commit
+// Line - [Optional] The line in the file, or 0 for a finding of the whole file
+// Link - [Optional] A link to the details of the finding
+type CodeInsightsAnnotation struct {
+	Path     string
+	Line     int
+	Message  string
+	Severity CodeInsightsSeverity
+	Type     CodeInsightsAnnotationType
+	Link     string
+}
+
+// CheckRunInfo contains the details of a check run, displayed in the Checks tab of the commit's pull requests.
+// Name        - The name of the check
+// HeadSHA     - The hash of the commit. Required on creation and can't be updated
+// Status      - [Optional] The status of the check run, queued by default
+// Conclusion  - [Optional] The result of the check run. Setting a conclusion completes the check run
+// DetailsURL  - [Optional] A link to the full details of the check
+// Title       - [Optional] The title of the check run output. Required with a summary or annotations
+// Summary     - [Optional] The summary of the check run output, in Markdown. Required with a title or annotations
+// Text        - [Optional] The details of the check run output, in Markdown
+// Annotations - [Optional] Annotations of specific lines. On update, the annotations are added to the existing ones
+type CheckRunInfo struct {
+	Name        string
+	HeadSHA     string
+	Status      CheckRunStatus
+	Conclusion  CheckRunConclusion
+	DetailsURL  string
+	Title       string
+	Summary     string
+	Text        string
+	Annotations []CheckRunAnnotation
+}
+
+// CheckRunAnnotation marks lines of a file in a check run.
+// EndLine - The last line of the annotation, the same as StartLine for a single line
+// Title   - [Optional] The title of the annotation
+type CheckRunAnnotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	Level     CheckRunAnnotationLevel
+	Title     string
+	Message   string
+}
+
+func getPagination(page, perPage, defaultPerPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	return page, perPage
+}
+
+// CommitsComparison contains the changes between two VCS references
+type CommitsComparison struct {
+	// The commits reachable from the head reference and not from the base reference
+	Commits []CommitInfo
+	// The files changed between the common ancestor of the references and the head reference
+	Files []PullRequestFile
+}
+
+// DirectoryEntry contains the details of a file or a directory in a repository
+type DirectoryEntry struct {
+	// The name of the file or the directory
+	Name string
+	// The path of the file or the directory from the repository root
+	Path  string
+	IsDir bool
+}
+
+// FileToCommit contains the details of a file change committed by CommitFiles
+type FileToCommit struct {
+	// The path of the file in the repository
+	Path string
+	// The content of the file after the change, ignored when the file is deleted
+	Content []byte
+	// FileAdded, FileModified or FileDeleted
+	ChangeType FileChangeType
+}
+
 // RepositoryInfo contains general information about the repository.
 type RepositoryInfo struct {
 	CloneInfo            CloneInfo
 	RepositoryVisibility RepositoryVisibility
+	// DefaultBranch is the name of the default branch, which is empty if the repository has no branches yet
+	DefaultBranch string
+	// Archived is true if the repository is read-only
+	Archived    bool
+	Description string
+}
+
+// UserInfo contains the details of a VCS user.
+// Username    - The username used to log in to the VCS provider
+// DisplayName - The full name of the user
+// Email       - The email address of the user, empty if the provider doesn't expose it
+type UserInfo struct {
+	Username    string
+	DisplayName string
+	Email       string
+}
+
+// AppInstallationInfo contains the details of an installation of a GitHub App.
+// Account             - The user or organization the app is installed on
+// RepositorySelection - Either "all" or "selected", according to the repositories the installation can access
+type AppInstallationInfo struct {
+	ID                  int64
+	Account             string
+	RepositorySelection string
+}
+
+// CollaboratorInfo contains a user with access to a repository.
+// Username   - The username of the collaborator
+// Permission - The access level of the collaborator to the repository
+type CollaboratorInfo struct {
+	Username   string
+	Permission RepositoryPermission
+}
+
+// CreateRepositoryOptions contains the settings of a new repository.
+// Private       - Whether the repository is visible only to its owner and members
+// Description   - The description of the repository
+// DefaultBranch - The name of the default branch. Leave empty to use the provider's default
+// InitReadme    - Whether to create the repository with an initial commit containing a README file
+type CreateRepositoryOptions struct {
+	Private       bool
+	Description   string
+	DefaultBranch string
+	InitReadme    bool
+}
+
+// CreatePullRequestOptions contains the additional settings of a new pull request.
+// Setting an option which isn't supported by the VCS provider fails the pull request creation.
+type CreatePullRequestOptions struct {
+	// Draft creates the pull request as a draft, which can't be merged until it is marked as ready for review.
+	// On GitLab and Gitea, the title is prefixed by the draft prefix of the provider.
+	Draft bool
+	// The usernames of the requested reviewers.
+	// On Azure Repos, the identity IDs of the reviewers, and on Bitbucket cloud, their account UUIDs.
+	Reviewers []string
+	// The names of the pull request labels
+	Labels []string
+	// The usernames of the pull request assignees
+	Assignees []string
+	// The ID of the pull request milestone. On GitHub, the milestone number.
+	Milestone int
+	// DeleteSourceBranch deletes the source branch once the pull request is merged
+	DeleteSourceBranch bool
+	// The IDs of the work items linked to the pull request
+	WorkItems []int
+}
+
+type createPullRequestOption string
+
+const (
+	draftPullRequestOption              createPullRequestOption = "creating draft pull requests"
+	reviewersPullRequestOption          createPullRequestOption = "requesting reviewers on pull request creation"
+	labelsPullRequestOption             createPullRequestOption = "labeling pull requests on creation"
+	assigneesPullRequestOption          createPullRequestOption = "assigning pull requests"
+	milestonePullRequestOption          createPullRequestOption = "setting pull request milestones"
+	deleteSourceBranchPullRequestOption createPullRequestOption = "deleting the source branch on merge"
+	workItemsPullRequestOption          createPullRequestOption = "linking work items to pull requests"
+)
+
+func (options CreatePullRequestOptions) isSet(option createPullRequestOption) bool {
+	switch option {
+	case draftPullRequestOption:
+		return options.Draft
+	case reviewersPullRequestOption:
+		return len(options.Reviewers) > 0
+	case labelsPullRequestOption:
+		return len(options.Labels) > 0
+	case assigneesPullRequestOption:
+		return len(options.Assignees) > 0
+	case milestonePullRequestOption:
+		return options.Milestone != 0
+	case deleteSourceBranchPullRequestOption:
+		return options.DeleteSourceBranch
+	case workItemsPullRequestOption:
+		return len(options.WorkItems) > 0
+	default:
+		return false
+	}
+}
+
+// validateCreatePullRequestOptions returns an error if one of the options which aren't supported by the VCS provider is set
+func validateCreatePullRequestOptions(options CreatePullRequestOptions, notSupportedMessage string, unsupportedOptions ...createPullRequestOption) error {
+	for _, option := range unsupportedOptions {
+		if options.isSet(option) {
+			return fmt.Errorf("%s is %s", option, notSupportedMessage)
+		}
+	}
+	return nil
 }
 
 // CloneInfo contains URLs that can be used to clone the repository.
@@ -391,6 +1634,17 @@ type CloneInfo struct {
 	SSH string
 }
 
+// WebhookInfo contains a webhook information
+type WebhookInfo struct {
+	// The webhook ID, as returned from CreateWebhook
+	ID string
+	// The URL the payloads are sent to
+	PayloadURL string
+	// The webhook events delivered by the webhook. Events that can't be passed to CreateWebhook are omitted.
+	Events []vcsutils.WebhookEvent
+	Active bool
+}
+
 // LabelInfo contains a label information
 type LabelInfo struct {
 	Name        string
@@ -399,6 +1653,183 @@ type LabelInfo struct {
 	Color string
 }
 
+// tokenProviderTransport authenticates each request with the current token of the token provider
+type tokenProviderTransport struct {
+	tokenProvider TokenProvider
+	authenticate  func(request *http.Request, token string)
+	base          http.RoundTripper
+}
+
+func (transport *tokenProviderTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	token, err := transport.tokenProvider(request.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an access token from the token provider: %w", err)
+	}
+	// A RoundTripper mustn't modify the original request
+	request = request.Clone(request.Context())
+	transport.authenticate(request, token)
+	return transport.base.RoundTrip(request)
+}
+
+// newHttpClient returns a copy of the custom HTTP client, or an HTTP client with the connection settings of the VcsInfo if none is set.
+// The requests are rate limited by the rate limiter, and retried according to the retry options.
+func newHttpClient(vcsInfo VcsInfo) *http.Client {
+	var httpClient *http.Client
+	if vcsInfo.HttpClient != nil {
+		customHttpClient := *vcsInfo.HttpClient
+		httpClient = &customHttpClient
+	} else {
+		httpClient = &http.Client{Transport: newHttpTransport(vcsInfo)}
+	}
+	if vcsInfo.RateLimiter == nil && vcsInfo.RetryOptions == nil && vcsInfo.ResponseCache == nil {
+		return httpClient
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	// Each retry is rate limited as well
+	if vcsInfo.RateLimiter != nil {
+		transport = &rateLimitTransport{base: transport, rateLimiter: vcsInfo.RateLimiter}
+	}
+	if vcsInfo.RetryOptions != nil {
+		transport = newRetryTransport(transport, *vcsInfo.RetryOptions)
+	}
+	// The conditional requests are retried as well
+	if vcsInfo.ResponseCache != nil {
+		transport = &cacheTransport{base: transport, cache: vcsInfo.ResponseCache}
+	}
+	httpClient.Transport = transport
+	return httpClient
+}
+
+// rateLimitTransport waits until the rate limiter allows sending each request
+type rateLimitTransport struct {
+	base        http.RoundTripper
+	rateLimiter *rate.Limiter
+}
+
+func (transport *rateLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := transport.rateLimiter.Wait(request.Context()); err != nil {
+		return nil, fmt.Errorf("failed to wait for the rate limiter: %w", err)
+	}
+	return transport.base.RoundTrip(request)
+}
+
+// newHttpTransport returns a transport with the connection settings of the VcsInfo, or nil to use the default transport
+func newHttpTransport(vcsInfo VcsInfo) http.RoundTripper {
+	if vcsInfo.Proxy == nil && vcsInfo.RootCAs == nil && !vcsInfo.InsecureSkipVerify && len(vcsInfo.ClientCertificates) == 0 {
+		return nil
+	}
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+	if vcsInfo.Proxy != nil {
+		transport.Proxy = http.ProxyURL(vcsInfo.Proxy)
+	}
+	if vcsInfo.RootCAs != nil || vcsInfo.InsecureSkipVerify || len(vcsInfo.ClientCertificates) > 0 {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:      vcsInfo.RootCAs,
+			Certificates: vcsInfo.ClientCertificates,
+			// #nosec G402 -- The verification is skipped only if explicitly requested
+			InsecureSkipVerify: vcsInfo.InsecureSkipVerify,
+		}
+	}
+	return transport
+}
+
+// isDefaultHttpClient returns true if the requests are sent by a default HTTP client, without a custom HTTP client, connection settings, rate limit or retries
+func isDefaultHttpClient(vcsInfo VcsInfo) bool {
+	return vcsInfo.HttpClient == nil && newHttpTransport(vcsInfo) == nil && vcsInfo.RateLimiter == nil && vcsInfo.RetryOptions == nil && vcsInfo.ResponseCache == nil
+}
+
+// newTokenProviderHttpClient returns an HTTP client which authenticates each request with the token provider
+func newTokenProviderHttpClient(vcsInfo VcsInfo, authenticate func(request *http.Request, token string)) *http.Client {
+	httpClient := newHttpClient(vcsInfo)
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.Transport = &tokenProviderTransport{tokenProvider: vcsInfo.TokenProvider, authenticate: authenticate, base: base}
+	return httpClient
+}
+
+// newOAuth2HttpClient returns an HTTP client which authenticates each request with the token source
+func newOAuth2HttpClient(vcsInfo VcsInfo, tokenSource oauth2.TokenSource) *http.Client {
+	ctx := context.Background()
+	if !isDefaultHttpClient(vcsInfo) {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, newHttpClient(vcsInfo))
+	}
+	return oauth2.NewClient(ctx, tokenSource)
+}
+
+func setBearerAuthorization(request *http.Request, token string) {
+	request.Header.Set("Authorization", "Bearer "+token)
+}
+
+// webhookEvents are the webhook events that can be passed to CreateWebhook
+var webhookEvents = []vcsutils.WebhookEvent{
+	vcsutils.PrOpened, vcsutils.PrEdited, vcsutils.PrMerged, vcsutils.PrRejected, vcsutils.PrClosed, vcsutils.PrReopened,
+	vcsutils.PrCommentCreated, vcsutils.PrCommentEdited, vcsutils.IssueCommentCreated,
+	vcsutils.Push, vcsutils.BranchDeleted, vcsutils.TagPushed, vcsutils.TagRemoved,
+	vcsutils.ReleaseCreated, vcsutils.ReleasePublished,
+}
+
+// findWebhookByPayloadURL returns the first webhook of the repository with the payload URL, or nil if none exists
+func findWebhookByPayloadURL(ctx context.Context, client VcsClient, owner, repository, payloadURL string) (*WebhookInfo, error) {
+	webhooks, err := client.ListWebhooks(ctx, owner, repository)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		if webhooks[i].PayloadURL == payloadURL {
+			return &webhooks[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// createOrUpdateWebhook updates the events and the token of the webhook with the payload URL, or creates a new webhook if none exists.
+// A new token is generated in both cases, since the token of an existing webhook can't be retrieved.
+func createOrUpdateWebhook(ctx context.Context, client VcsClient, owner, repository, branch, payloadURL string, webhookEvents ...vcsutils.WebhookEvent) (string, string, error) {
+	webhook, err := findWebhookByPayloadURL(ctx, client, owner, repository, payloadURL)
+	if err != nil {
+		return "", "", err
+	}
+	if webhook == nil {
+		return client.CreateWebhook(ctx, owner, repository, branch, payloadURL, webhookEvents...)
+	}
+	token := vcsutils.CreateToken()
+	if err = client.UpdateWebhook(ctx, owner, repository, branch, payloadURL, token, webhook.ID, webhookEvents...); err != nil {
+		return "", "", err
+	}
+	return webhook.ID, token, nil
+}
+
+// getSubscribedWebhookEvents returns the webhook events delivered by a webhook subscribed to the provider events.
+// toProviderEvents maps webhook events to provider events, as done by CreateWebhook.
+func getSubscribedWebhookEvents(providerEvents []string, toProviderEvents func(...vcsutils.WebhookEvent) []string) []vcsutils.WebhookEvent {
+	var events []vcsutils.WebhookEvent
+	for _, event := range webhookEvents {
+		required := toProviderEvents(event)
+		if len(required) == 0 {
+			continue
+		}
+		subscribed := true
+		for _, providerEvent := range required {
+			if !slices.Contains(providerEvents, providerEvent) {
+				subscribed = false
+				break
+			}
+		}
+		if subscribed {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
 func validateParametersNotBlank(paramNameValueMap map[string]string) error {
 	var errorMessages []string
 	for k, v := range paramNameValueMap {
@@ -412,6 +1843,117 @@ func validateParametersNotBlank(paramNameValueMap map[string]string) error {
 	return nil
 }
 
+// validateTokenScopes returns an error listing the required scopes which the token doesn't have
+func validateTokenScopes(requiredScopes []TokenScope, hasScope func(TokenScope) bool) error {
+	var missingScopes []string
+	for _, scope := range requiredScopes {
+		if !hasScope(scope) {
+			missingScopes = append(missingScopes, string(scope))
+		}
+	}
+	if len(missingScopes) > 0 {
+		return fmt.Errorf("the token is missing the following scopes: %s", strings.Join(missingScopes, ", "))
+	}
+	return nil
+}
+
+// filterReviewComments returns the comments which are anchored to a file in the pull request diff
+func filterReviewComments(comments []CommentInfo) (reviewComments []CommentInfo) {
+	for _, comment := range comments {
+		if comment.FilePath != "" {
+			reviewComments = append(reviewComments, comment)
+		}
+	}
+	return
+}
+
+func validateFilesToCommit(files []FileToCommit) error {
+	if len(files) == 0 {
+		return errors.New("validation failed: no files to commit")
+	}
+	for _, file := range files {
+		if strings.TrimSpace(file.Path) == "" {
+			return errors.New("validation failed: required parameter 'path' is missing")
+		}
+		if file.ChangeType == FileRenamed {
+			return fmt.Errorf("validation failed: renaming files isn't supported, delete '%s' and add it under its new path instead", file.Path)
+		}
+	}
+	return nil
+}
+
+// normalizeDownloadPaths returns the paths to download relative to the root of the repository, with forward slashes
+func normalizeDownloadPaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("validation failed: no paths to download")
+	}
+	normalizedPaths := make([]string, 0, len(paths))
+	for _, downloadPath := range paths {
+		if strings.TrimSpace(downloadPath) == "" {
+			return nil, errors.New("validation failed: required parameter 'path' is missing")
+		}
+		normalizedPaths = append(normalizedPaths, strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(downloadPath)), "/"))
+	}
+	return normalizedPaths, nil
+}
+
+// getArchiveFormat returns the configured format of the downloaded repository archives, or defaultFormat if it isn't configured
+func getArchiveFormat(vcsInfo VcsInfo, defaultFormat vcsutils.ArchiveFormat) (vcsutils.ArchiveFormat, error) {
+	switch vcsInfo.ArchiveFormat {
+	case "":
+		return defaultFormat, nil
+	case vcsutils.TarGzArchive, vcsutils.ZipArchive:
+		return vcsInfo.ArchiveFormat, nil
+	default:
+		return "", fmt.Errorf("unsupported archive format: '%s'", vcsInfo.ArchiveFormat)
+	}
+}
+
+// addDraftTitlePrefix prefixes the title of a draft pull request by draftPrefix, unless it already starts with one of the draft prefixes of the provider
+func addDraftTitlePrefix(title, draftPrefix string, recognizedPrefixes ...string) string {
+	for _, prefix := range append(recognizedPrefixes, draftPrefix) {
+		if strings.HasPrefix(strings.ToLower(title), strings.ToLower(prefix)) {
+			return title
+		}
+	}
+	return draftPrefix + " " + title
+}
+
+func getUnsupportedMergeMethodError(mergeMethod vcsutils.MergeMethod) error {
+	return fmt.Errorf("unsupported merge method: '%s'", mergeMethod)
+}
+
+// validatePullRequestReview checks that the review verdict is supported, and that a comment review has a body
+func validatePullRequestReview(verdict vcsutils.ReviewVerdict, body string) error {
+	switch verdict {
+	case vcsutils.ReviewVerdictApprove, vcsutils.ReviewVerdictRequestChanges:
+		return nil
+	case vcsutils.ReviewVerdictComment:
+		return validateParametersNotBlank(map[string]string{"body": body})
+	default:
+		return fmt.Errorf("unsupported review verdict: '%s'", verdict)
+	}
+}
+
+// validatePullRequestState checks that the pull request state is supported
+func validatePullRequestState(state vcsutils.PullRequestState) error {
+	switch state {
+	case vcsutils.Open, vcsutils.Closed, vcsutils.Merged:
+		return nil
+	default:
+		return fmt.Errorf("unsupported pull request state: '%s'", state)
+	}
+}
+
+func validateIssueState(state vcsutils.IssueState) error {
+	switch state {
+	case "", vcsutils.IssueOpen, vcsutils.IssueClosed:
+		return nil
+	default:
+		return fmt.Errorf("unsupported issue state: '%s'", state)
+	}
+}
+
 // commitStatusAsStringToStatus maps status as string to CommitStatus
 // Handles all the different statuses for every VCS provider
 func commitStatusAsStringToStatus(rawStatus string) CommitStatus {
@@ -427,6 +1969,29 @@ func commitStatusAsStringToStatus(rawStatus string) CommitStatus {
 	}
 }
 
+// Combines commit statuses into a single verdict.
+// Any failed or errored status fails the verdict, otherwise any status in progress keeps it in progress.
+func combineCommitStatuses(statuses ...CommitStatus) CommitStatus {
+	combinedStatus := Pass
+	for _, status := range statuses {
+		switch status {
+		case Fail, Error:
+			return Fail
+		case InProgress:
+			combinedStatus = InProgress
+		}
+	}
+	return combinedStatus
+}
+
+func combineCommitStatusInfos(statusInfos []CommitStatusInfo) CommitStatus {
+	statuses := make([]CommitStatus, 0, len(statusInfos))
+	for _, statusInfo := range statusInfos {
+		statuses = append(statuses, statusInfo.State)
+	}
+	return combineCommitStatuses(statuses...)
+}
+
 func extractTimeWithFallback(timeObject *time.Time) time.Time {
 	if timeObject == nil {
 		return time.Time{}
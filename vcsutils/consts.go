@@ -1,10 +1,13 @@
 package vcsutils
 
 const (
-	branchPrefix           = "refs/heads/"
-	TagPrefix              = "refs/tags/"
-	NumberOfCommitsToFetch = 50
-	ErrNoCommentsProvided  = "could not add a pull request review comment, no comments were provided"
+	branchPrefix                = "refs/heads/"
+	TagPrefix                   = "refs/tags/"
+	NumberOfCommitsToFetch      = 50
+	NumberOfPullRequestsToFetch = 50
+	NumberOfIssuesToFetch       = 50
+	NumberOfItemsToFetch        = 50
+	ErrNoCommentsProvided       = "could not add a pull request review comment, no comments were provided"
 )
 
 // VcsProvider is an enum represents the VCS provider type
@@ -21,6 +24,14 @@ const (
 	BitbucketCloud
 	// AzureRepos VCS provider
 	AzureRepos
+	// Gitea VCS provider, compatible with Forgejo as well
+	Gitea
+	// CodeCommit is the AWS CodeCommit VCS provider
+	CodeCommit
+	// Gerrit VCS provider
+	Gerrit
+	// LocalGit works with Git repositories on the local filesystem, without a VCS provider
+	LocalGit
 )
 
 // String representation of the VcsProvider
@@ -36,6 +47,14 @@ func (v VcsProvider) String() string {
 		return "Bitbucket Cloud"
 	case AzureRepos:
 		return "Azure Repos"
+	case Gitea:
+		return "Gitea"
+	case CodeCommit:
+		return "AWS CodeCommit"
+	case Gerrit:
+		return "Gerrit"
+	case LocalGit:
+		return "Local Git"
 	default:
 		return ""
 	}
@@ -45,20 +64,39 @@ func (v VcsProvider) String() string {
 type WebhookEvent string
 
 const (
-	// PrRejected the pull request is rejected
+	// PrRejected the pull request is declined (Bitbucket)
 	PrRejected WebhookEvent = "PrRejected"
+	// PrClosed the pull request is closed without being merged
+	PrClosed WebhookEvent = "PrClosed"
+	// PrReopened a closed pull request is reopened
+	PrReopened WebhookEvent = "PrReopened"
 	// PrEdited the pull request is edited
 	PrEdited WebhookEvent = "PrEdited"
 	// PrMerged the pull request is merged
 	PrMerged WebhookEvent = "PrMerged"
 	// PrOpened a pull request is opened
 	PrOpened WebhookEvent = "PrOpened"
+	// PrCommentCreated a comment is added to a pull request
+	PrCommentCreated WebhookEvent = "PrCommentCreated"
+	// PrCommentEdited a comment of a pull request is edited
+	PrCommentEdited WebhookEvent = "PrCommentEdited"
+	// IssueCommentCreated a comment is added to an issue
+	IssueCommentCreated WebhookEvent = "IssueCommentCreated"
 	// Push a commit is pushed to the source branch
 	Push WebhookEvent = "Push"
 	// TagPushed a new tag is pushed
 	TagPushed WebhookEvent = "TagPushed"
 	// TagRemoved a tag is removed
 	TagRemoved WebhookEvent = "TagRemoved"
+	// BranchDeleted a branch is deleted
+	BranchDeleted WebhookEvent = "BranchDeleted"
+	// ReleaseCreated a release is created, including draft releases (GitHub only)
+	ReleaseCreated WebhookEvent = "ReleaseCreated"
+	// ReleasePublished a release is published. In GitLab, releases are published once created.
+	ReleasePublished WebhookEvent = "ReleasePublished"
+	// Ping a test event sent by the VCS provider to check the webhook, for example when the webhook is created.
+	// The VCS providers send ping events regardless of the webhook events, hence it can't be passed to CreateWebhook.
+	Ping WebhookEvent = "Ping"
 )
 
 type PullRequestState string
@@ -66,4 +104,49 @@ type PullRequestState string
 const (
 	Open   PullRequestState = "open"
 	Closed PullRequestState = "closed"
+	Merged PullRequestState = "merged"
+)
+
+// IssueState is the state of a repository issue
+type IssueState string
+
+const (
+	// IssueOpen an issue which wasn't resolved yet
+	IssueOpen IssueState = "open"
+	// IssueClosed an issue which was closed or resolved
+	IssueClosed IssueState = "closed"
+)
+
+// MergeMethod is the strategy used to merge a pull request into its target branch
+type MergeMethod string
+
+const (
+	// MergeMethodMerge merges the pull request with a merge commit
+	MergeMethodMerge MergeMethod = "merge"
+	// MergeMethodSquash squashes the pull request commits into a single commit on the target branch
+	MergeMethodSquash MergeMethod = "squash"
+	// MergeMethodRebase rebases the pull request commits onto the target branch
+	MergeMethodRebase MergeMethod = "rebase"
+)
+
+// ArchiveFormat is the format of the repository archives which are downloaded
+type ArchiveFormat string
+
+const (
+	// TarGzArchive is a gzip compressed tar archive
+	TarGzArchive ArchiveFormat = "tar.gz"
+	// ZipArchive is a zip archive
+	ZipArchive ArchiveFormat = "zip"
+)
+
+// ReviewVerdict is the outcome of a pull request review
+type ReviewVerdict string
+
+const (
+	// ReviewVerdictApprove approves the pull request
+	ReviewVerdictApprove ReviewVerdict = "approve"
+	// ReviewVerdictRequestChanges requests changes before the pull request can be merged
+	ReviewVerdictRequestChanges ReviewVerdict = "request_changes"
+	// ReviewVerdictComment submits feedback without approving or requesting changes
+	ReviewVerdictComment ReviewVerdict = "comment"
 )
@@ -6,6 +6,7 @@ const (
 	CreatingPullRequest      = "Creating new pull request:"
 
 	UpdatingPullRequest      = "Updating details of pull request ID:"
+	MergingPullRequest       = "Merging pull request ID:"
 	FetchingOpenPullRequests = "Fetching open pull requests in"
 	FetchingPullRequestById  = "Fetching pull requests by id in"
 	UploadingCodeScanning    = "Uploading code scanning for:"
@@ -25,6 +25,22 @@ const (
 	RemoteName = "origin"
 )
 
+const (
+	// DefaultMaxExtractedSize is the default limit of the total size of the files which are extracted from an archive - 10 GiB
+	DefaultMaxExtractedSize int64 = 10 << 30
+	// DefaultMaxExtractedEntries is the default limit of the number of entries which are extracted from an archive
+	DefaultMaxExtractedEntries = 1_000_000
+	// maxSymlinkTargetLength limits the target of a symbolic link which is read from an archive
+	maxSymlinkTargetLength = 4096
+)
+
+var (
+	// ErrDownloadSizeLimitExceeded is returned when a downloaded repository archive is larger than the configured size limit
+	ErrDownloadSizeLimitExceeded = errors.New("the repository archive exceeds the download size limit")
+	// ErrExtractionLimitExceeded is returned when an archive is extracted to more entries, or to larger files, than the extraction limits
+	ErrExtractionLimitExceeded = errors.New("the archive exceeds the extraction limits")
+)
+
 // CreateToken create a random UUID
 func CreateToken() string {
 	return uuid.New().String()
@@ -35,6 +51,17 @@ func CreateToken() string {
 // reader              - Reader for the tar.gz file
 // shouldRemoveBaseDir - True if should remove the base directory
 func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err error) {
+	return untar(destDir, reader, shouldRemoveBaseDir, nil, ExtractionLimits{})
+}
+
+// UntarPaths extracts only the entries of the tar.gz archive which are in the given paths, or under them.
+// The paths are relative to the root of the extracted archive, with forward slashes. If no paths are given, all the entries are extracted.
+func UntarPaths(destDir string, reader io.Reader, shouldRemoveBaseDir bool, paths []string) (err error) {
+	return untar(destDir, reader, shouldRemoveBaseDir, paths, ExtractionLimits{})
+}
+
+func untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool, paths []string, limits ExtractionLimits) (err error) {
+	extractor := newArchiveExtractor(limits)
 	gzr, err := gzip.NewReader(reader)
 	if err != nil {
 		return
@@ -49,6 +76,7 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 	var readerErr error
 	for tarEntryReader := tar.NewReader(gzr); readerErr != io.EOF; header, readerErr = tarEntryReader.Next() {
 		if readerErr != nil {
+			err = readerErr
 			return
 		}
 
@@ -62,7 +90,7 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 		if shouldRemoveBaseDir {
 			filePath = removeBaseDir(filePath)
 		}
-		if filePath == "" {
+		if filePath == "" || !IsInPaths(filePath, paths) {
 			continue
 		}
 
@@ -78,6 +106,9 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 
 		// If it's a dir, and it doesn't exist create it
 		case tar.TypeDir:
+			if err = extractor.countEntry(); err != nil {
+				return
+			}
 			err = makeDirIfMissing(target)
 			if err != nil {
 				return
@@ -85,20 +116,19 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 
 		// If it's a file create it
 		case tar.TypeReg:
-			var targetFile *os.File
-			targetFile, err = os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
-			if err != nil {
+			if err = extractor.countEntry(); err != nil {
 				return
 			}
-
-			// Copy file contents
-			if err = safeCopy(targetFile, tarEntryReader); err != nil {
+			if err = extractor.writeFile(target, tarEntryReader, os.FileMode(header.Mode)); err != nil {
 				return
 			}
 
-			// Manually close here after each file operation; deferring would cause each file close
-			// to wait until all operations have completed.
-			if err = targetFile.Close(); err != nil {
+		// If it's a symbolic link, create it only if it points inside the destination
+		case tar.TypeSymlink:
+			if err = extractor.countEntry(); err != nil {
+				return
+			}
+			if err = createSafeSymlink(target, header.Linkname, destDir); err != nil {
 				return
 			}
 		}
@@ -106,6 +136,21 @@ func Untar(destDir string, reader io.Reader, shouldRemoveBaseDir bool) (err erro
 	return
 }
 
+// IsInPaths checks whether the relative file path is one of the given paths, or is under one of them.
+// The paths are relative, with forward slashes. An empty path stands for the root, and an empty list of paths contains every file path.
+func IsInPaths(filePath string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+	filePath = strings.Trim(filepath.ToSlash(filePath), "/")
+	for _, path := range paths {
+		if path == "" || filePath == path || strings.HasPrefix(filePath, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 func makeDirIfMissing(destDir string) error {
 	var err error
 	if _, err = os.Stat(destDir); os.IsNotExist(err) {
@@ -128,9 +173,36 @@ func sanitizeExtractionPath(filePath string, destination string) (string, error)
 	if !strings.HasPrefix(target, filepath.Clean(destination)+string(os.PathSeparator)) {
 		return "", fmt.Errorf("%s: illegal file path", filePath)
 	}
+	if err := checkNoSymlinkInPath(target, destination); err != nil {
+		return "", fmt.Errorf("%s: %w", filePath, err)
+	}
 	return target, nil
 }
 
+// checkNoSymlinkInPath fails if one of the parent directories of the target, under the destination, is a symbolic link.
+// Entries are never written through symbolic links, because a chain of links could lead them outside of the destination.
+func checkNoSymlinkInPath(target, destination string) error {
+	relativeParent, err := filepath.Rel(filepath.Clean(destination), filepath.Dir(target))
+	if err != nil || relativeParent == "." {
+		return err
+	}
+	currentPath := filepath.Clean(destination)
+	for _, component := range strings.Split(relativeParent, string(os.PathSeparator)) {
+		currentPath = filepath.Join(currentPath, component)
+		fileInfo, err := os.Lstat(currentPath)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fileInfo.Mode()&os.ModeSymlink != 0 {
+			return errors.New("illegal file path through a symbolic link")
+		}
+	}
+	return nil
+}
+
 func safeCopy(targetFile *os.File, reader io.Reader) error {
 	for {
 		_, err := io.CopyN(targetFile, reader, 1024)
@@ -143,6 +215,36 @@ func safeCopy(targetFile *os.File, reader io.Reader) error {
 	}
 }
 
+// LimitReader returns a reader which fails with ErrDownloadSizeLimitExceeded once more than limit bytes are read from reader.
+// A non-positive limit means no limit.
+func LimitReader(reader io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return reader
+	}
+	return &sizeLimitedReader{reader: reader, remaining: limit}
+}
+
+type sizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (lr *sizeLimitedReader) Read(p []byte) (n int, err error) {
+	if lr.remaining < 0 {
+		return 0, ErrDownloadSizeLimitExceeded
+	}
+	// Read up to one byte past the limit, to tell a reader of exactly the limit size from a larger one
+	if int64(len(p)) > lr.remaining+1 {
+		p = p[:lr.remaining+1]
+	}
+	n, err = lr.reader.Read(p)
+	lr.remaining -= int64(n)
+	if lr.remaining < 0 {
+		return n - 1, ErrDownloadSizeLimitExceeded
+	}
+	return n, err
+}
+
 // DiscardResponseBody prepare http response body for closing
 func DiscardResponseBody(resp *http.Response) error {
 	if resp != nil {
@@ -198,6 +300,55 @@ func Unzip(zipFileContent []byte, destinationToUnzip string) (err error) {
 	if err != nil {
 		return err
 	}
+	return unzipArchive(zf, destinationToUnzip, false, nil, ExtractionLimits{})
+}
+
+// UnzipFile extracts the zip archive in zipFilePath to destinationToUnzip, without loading the whole archive into memory
+func UnzipFile(zipFilePath string, destinationToUnzip string) error {
+	return unzipFilePaths(zipFilePath, destinationToUnzip, false, nil, ExtractionLimits{})
+}
+
+// ExtractArchive extracts the entries of an archive in the given format which are in the given paths, or all of them if no paths are given.
+// Zip archives can't be extracted from a stream, so they're saved to a temporary file rather than loaded into memory.
+// destDir             - Destination folder
+// reader              - Reader for the archive
+// format              - The format of the archive
+// shouldRemoveBaseDir - True if should remove the base directory
+// paths               - Paths relative to the root of the extracted archive, with forward slashes
+// limits              - Limits of the extraction, which protect from decompression bombs
+func ExtractArchive(destDir string, reader io.Reader, format ArchiveFormat, shouldRemoveBaseDir bool, paths []string, limits ExtractionLimits) (err error) {
+	switch format {
+	case TarGzArchive:
+		return untar(destDir, reader, shouldRemoveBaseDir, paths, limits)
+	case ZipArchive:
+		var zipFile *os.File
+		zipFile, err = os.CreateTemp("", "froggit-archive-*.zip")
+		if err != nil {
+			return
+		}
+		defer func() {
+			err = errors.Join(err, zipFile.Close(), os.Remove(zipFile.Name()))
+		}()
+		if _, err = io.Copy(zipFile, reader); err != nil {
+			return
+		}
+		return unzipFilePaths(zipFile.Name(), destDir, shouldRemoveBaseDir, paths, limits)
+	default:
+		return fmt.Errorf("unsupported archive format: '%s'", format)
+	}
+}
+
+func unzipFilePaths(zipFilePath string, destinationToUnzip string, shouldRemoveBaseDir bool, paths []string, limits ExtractionLimits) (err error) {
+	zf, err := zip.OpenReader(zipFilePath)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, zf.Close()) }()
+	return unzipArchive(&zf.Reader, destinationToUnzip, shouldRemoveBaseDir, paths, limits)
+}
+
+func unzipArchive(zf *zip.Reader, destinationToUnzip string, shouldRemoveBaseDir bool, paths []string, limits ExtractionLimits) (err error) {
+	extractor := newArchiveExtractor(limits)
 	// Get the absolute destination path
 	destinationToUnzip, err = filepath.Abs(destinationToUnzip)
 	if err != nil {
@@ -206,7 +357,18 @@ func Unzip(zipFileContent []byte, destinationToUnzip string) (err error) {
 
 	// Iterate over zip files inside the archive and unzip each of them
 	for _, f := range zf.File {
-		err = unzipFile(f, destinationToUnzip)
+		// Remove the root directory of the repository if needed
+		filePath := f.Name
+		if shouldRemoveBaseDir {
+			filePath = removeBaseDir(filePath)
+		}
+		if filePath == "" || !IsInPaths(filePath, paths) {
+			continue
+		}
+		if err = extractor.countEntry(); err != nil {
+			return err
+		}
+		err = extractor.unzipFile(f, filePath, destinationToUnzip)
 		if err != nil {
 			return err
 		}
@@ -215,39 +377,136 @@ func Unzip(zipFileContent []byte, destinationToUnzip string) (err error) {
 	return nil
 }
 
-func unzipFile(f *zip.File, destination string) (err error) {
+func (extractor *archiveExtractor) unzipFile(f *zip.File, filePath, destination string) (err error) {
 	// Check if file paths are not vulnerable to Zip Slip
-	fullFilePath, err := sanitizeExtractionPath(f.Name, destination)
+	fullFilePath, err := sanitizeExtractionPath(filePath, destination)
 	if err != nil {
 		return err
 	}
 	// Create directory tree
 	if f.FileInfo().IsDir() {
-		if e := os.MkdirAll(fullFilePath, 0700); err == nil {
-			return e
-		}
-		return nil
-	} else if err = os.MkdirAll(filepath.Dir(fullFilePath), 0700); err != nil {
-		return err
+		return os.MkdirAll(fullFilePath, 0700)
 	}
 
-	// Create a destination file for unzipped content
-	destinationFile, err := os.OpenFile(filepath.Clean(fullFilePath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+	// Unzip the content of a file and copy it to the destination file
+	zippedFile, err := f.Open()
 	if err != nil {
 		return err
 	}
 	defer func() {
-		err = errors.Join(err, destinationFile.Close())
+		err = errors.Join(err, zippedFile.Close())
 	}()
-	// Unzip the content of a file and copy it to the destination file
-	zippedFile, err := f.Open()
+	if f.Mode()&os.ModeSymlink != 0 {
+		// The content of a symbolic link is its target
+		var linkTarget []byte
+		if linkTarget, err = io.ReadAll(io.LimitReader(zippedFile, maxSymlinkTargetLength)); err != nil {
+			return err
+		}
+		return createSafeSymlink(fullFilePath, string(linkTarget), destination)
+	}
+	return extractor.writeFile(fullFilePath, zippedFile, f.Mode().Perm())
+}
+
+// ExtractionLimits protects the extraction of archives, which are downloaded from remote services, from decompression bombs.
+// Zero values stand for the default limits, and negative values disable the limits.
+type ExtractionLimits struct {
+	// MaxExtractedSize is the maximal total size in bytes of the extracted files
+	MaxExtractedSize int64
+	// MaxExtractedEntries is the maximal number of extracted files, directories and symbolic links
+	MaxExtractedEntries int
+}
+
+// archiveExtractor enforces the extraction limits of a single archive
+type archiveExtractor struct {
+	limits           ExtractionLimits
+	extractedSize    int64
+	extractedEntries int
+}
+
+func newArchiveExtractor(limits ExtractionLimits) *archiveExtractor {
+	if limits.MaxExtractedSize == 0 {
+		limits.MaxExtractedSize = DefaultMaxExtractedSize
+	}
+	if limits.MaxExtractedEntries == 0 {
+		limits.MaxExtractedEntries = DefaultMaxExtractedEntries
+	}
+	return &archiveExtractor{limits: limits}
+}
+
+// countEntry counts an extracted entry, and fails if there are too many entries
+func (extractor *archiveExtractor) countEntry() error {
+	extractor.extractedEntries++
+	if extractor.limits.MaxExtractedEntries > 0 && extractor.extractedEntries > extractor.limits.MaxExtractedEntries {
+		return fmt.Errorf("%w: the archive has more than %d entries", ErrExtractionLimitExceeded, extractor.limits.MaxExtractedEntries)
+	}
+	return nil
+}
+
+// writeFile writes the content of an extracted file, and fails if the extracted files are too large
+func (extractor *archiveExtractor) writeFile(target string, reader io.Reader, mode os.FileMode) (err error) {
+	// The directory entries of the parents may have been filtered out
+	if err = makeDirIfMissing(filepath.Dir(target)); err != nil {
+		return
+	}
+	targetFile, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
 	if err != nil {
-		return err
+		return
 	}
+	// Close after each file rather than when the extraction completes, so the files aren't kept open
 	defer func() {
-		err = errors.Join(err, zippedFile.Close())
+		err = errors.Join(err, targetFile.Close())
 	}()
-	return safeCopy(destinationFile, zippedFile)
+	if extractor.limits.MaxExtractedSize < 0 {
+		return safeCopy(targetFile, reader)
+	}
+	// Copy one byte past the remaining size, to tell a file which fills the limit from a larger one
+	remainingSize := extractor.limits.MaxExtractedSize - extractor.extractedSize
+	written, err := io.Copy(targetFile, io.LimitReader(reader, remainingSize+1))
+	extractor.extractedSize += written
+	if err != nil {
+		return
+	}
+	if written > remainingSize {
+		return fmt.Errorf("%w: the extracted files are larger than %d bytes", ErrExtractionLimitExceeded, extractor.limits.MaxExtractedSize)
+	}
+	return
+}
+
+// createSafeSymlink creates a symbolic link, unless its target may be outside of the destination.
+// Such links are skipped, because the files which are extracted after them could be written through them outside of the destination.
+// Targets with '..' segments are skipped too, since they can't be checked as text once they go through other links.
+func createSafeSymlink(target, linkTarget, destination string) error {
+	if filepath.IsAbs(linkTarget) || hasParentDirSegment(linkTarget) {
+		return nil
+	}
+	linkDir := filepath.Dir(target)
+	if err := makeDirIfMissing(linkDir); err != nil {
+		return err
+	}
+	resolvedDestination, err := filepath.EvalSymlinks(destination)
+	if err != nil {
+		return err
+	}
+	resolvedLinkDir, err := filepath.EvalSymlinks(linkDir)
+	if err != nil {
+		return err
+	}
+	if resolvedLinkDir != resolvedDestination && !strings.HasPrefix(resolvedLinkDir, resolvedDestination+string(os.PathSeparator)) {
+		return nil
+	}
+	if err = os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(linkTarget, target)
+}
+
+func hasParentDirSegment(linkTarget string) bool {
+	for _, segment := range strings.Split(filepath.ToSlash(linkTarget), "/") {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
 }
 
 func CheckResponseStatusWithBody(resp *http.Response, expectedStatusCodes ...int) error {
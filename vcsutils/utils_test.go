@@ -1,12 +1,17 @@
 package vcsutils
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"github.com/go-git/go-git/v5"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,6 +53,64 @@ func TestUntarError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestUntarPaths(t *testing.T) {
+	destDir, tarball := openTarball(t)
+	defer func() {
+		assert.NoError(t, tarball.Close())
+		assert.NoError(t, RemoveTempDir(destDir))
+	}()
+
+	err := UntarPaths(destDir, tarball, true, []string{"b/c/file", "missing"})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(destDir, "b", "c", "file"))
+	assert.NoDirExists(t, filepath.Join(destDir, "missing"))
+}
+
+func TestIsInPaths(t *testing.T) {
+	tests := []struct {
+		filePath string
+		paths    []string
+		expected bool
+	}{
+		{filePath: "src/main.go", paths: nil, expected: true},
+		{filePath: "src/main.go", paths: []string{""}, expected: true},
+		{filePath: "src/main.go", paths: []string{"src"}, expected: true},
+		{filePath: "src/", paths: []string{"src"}, expected: true},
+		{filePath: "src/main.go", paths: []string{"docs", "src/main.go"}, expected: true},
+		{filePath: "srcs/main.go", paths: []string{"src"}, expected: false},
+		{filePath: "src", paths: []string{"src/main.go"}, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.filePath, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsInPaths(test.filePath, test.paths))
+		})
+	}
+}
+
+func TestUntarSizeLimit(t *testing.T) {
+	destDir, tarball := openTarball(t)
+	defer func() {
+		assert.NoError(t, tarball.Close())
+	}()
+
+	err := Untar(destDir, LimitReader(tarball, 10), false)
+	assert.ErrorIs(t, err, ErrDownloadSizeLimitExceeded)
+}
+
+func TestLimitReader(t *testing.T) {
+	content, err := io.ReadAll(LimitReader(strings.NewReader("froggit"), 7))
+	assert.NoError(t, err)
+	assert.Equal(t, "froggit", string(content))
+
+	content, err = io.ReadAll(LimitReader(strings.NewReader("froggit"), 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "froggit", string(content))
+
+	content, err = io.ReadAll(LimitReader(strings.NewReader("froggit"), 6))
+	assert.ErrorIs(t, err, ErrDownloadSizeLimitExceeded)
+	assert.Equal(t, "froggi", string(content))
+}
+
 func TestCreateToken(t *testing.T) {
 	assert.NotEmpty(t, CreateToken())
 }
@@ -144,6 +207,220 @@ func TestUnzip(t *testing.T) {
 	assert.Equal(t, "README.md", fileinfo[0].Name())
 }
 
+func TestUnzipFile(t *testing.T) {
+	destDir, err := os.MkdirTemp("", "")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, RemoveTempDir(destDir)) }()
+	err = UnzipFile(filepath.Join("testdata", "hello_world.zip"), destDir)
+	assert.NoError(t, err)
+
+	fileinfo, err := os.ReadDir(destDir)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, fileinfo)
+	assert.Equal(t, "README.md", fileinfo[0].Name())
+
+	assert.Error(t, UnzipFile(filepath.Join("testdata", "missing.zip"), destDir))
+}
+
+func TestExtractArchive(t *testing.T) {
+	zipContent := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(zipContent)
+	for _, filePath := range []string{"repo-main/README.md", "repo-main/src/go.mod"} {
+		fileWriter, err := zipWriter.Create(filePath)
+		assert.NoError(t, err)
+		_, err = fileWriter.Write([]byte("frog"))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zipWriter.Close())
+
+	destDir := t.TempDir()
+	assert.NoError(t, ExtractArchive(destDir, bytes.NewReader(zipContent.Bytes()), ZipArchive, true, []string{"src"}, ExtractionLimits{}))
+	assert.FileExists(t, filepath.Join(destDir, "src", "go.mod"))
+	assert.NoFileExists(t, filepath.Join(destDir, "README.md"))
+
+	destDir, tarball := openTarball(t)
+	defer func() {
+		assert.NoError(t, tarball.Close())
+		assert.NoError(t, RemoveTempDir(destDir))
+	}()
+	assert.NoError(t, ExtractArchive(destDir, tarball, TarGzArchive, true, nil, ExtractionLimits{}))
+	assert.FileExists(t, filepath.Join(destDir, "b", "c", "file"))
+
+	assert.Error(t, ExtractArchive(t.TempDir(), bytes.NewReader(nil), "rar", false, nil, ExtractionLimits{}))
+}
+
+func TestExtractArchiveLimits(t *testing.T) {
+	tarball := createTestTarball(t, map[string]string{"a": "frog", "b": "froggit"}, nil)
+
+	err := ExtractArchive(t.TempDir(), bytes.NewReader(tarball), TarGzArchive, false, nil, ExtractionLimits{MaxExtractedSize: 10})
+	assert.ErrorIs(t, err, ErrExtractionLimitExceeded)
+	err = ExtractArchive(t.TempDir(), bytes.NewReader(tarball), TarGzArchive, false, nil, ExtractionLimits{MaxExtractedSize: 11})
+	assert.NoError(t, err)
+
+	err = ExtractArchive(t.TempDir(), bytes.NewReader(tarball), TarGzArchive, false, nil, ExtractionLimits{MaxExtractedEntries: 1})
+	assert.ErrorIs(t, err, ErrExtractionLimitExceeded)
+	err = ExtractArchive(t.TempDir(), bytes.NewReader(tarball), TarGzArchive, false, nil, ExtractionLimits{MaxExtractedEntries: -1, MaxExtractedSize: -1})
+	assert.NoError(t, err)
+
+	zipContent := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(zipContent)
+	fileWriter, err := zipWriter.Create("bomb")
+	assert.NoError(t, err)
+	_, err = fileWriter.Write(make([]byte, 1<<20))
+	assert.NoError(t, err)
+	assert.NoError(t, zipWriter.Close())
+	err = ExtractArchive(t.TempDir(), bytes.NewReader(zipContent.Bytes()), ZipArchive, false, nil, ExtractionLimits{MaxExtractedSize: 1 << 10})
+	assert.ErrorIs(t, err, ErrExtractionLimitExceeded)
+}
+
+func TestExtractArchivePathTraversal(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "dest")
+	err := ExtractArchive(destDir, bytes.NewReader(createTestTarball(t, map[string]string{"../evil": "frog"}, nil)), TarGzArchive, false, nil, ExtractionLimits{})
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(destDir, "..", "evil"))
+
+	zipContent := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(zipContent)
+	_, err = zipWriter.Create("../evil")
+	assert.NoError(t, err)
+	assert.NoError(t, zipWriter.Close())
+	err = ExtractArchive(destDir, bytes.NewReader(zipContent.Bytes()), ZipArchive, false, nil, ExtractionLimits{})
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(destDir, "..", "evil"))
+}
+
+func TestExtractArchiveSymlinks(t *testing.T) {
+	destDir := t.TempDir()
+	symlinks := map[string]string{"docs/inside": "guide.md", "parent": "docs/../README.md", "outside": "../../etc", "absolute": "/etc/passwd"}
+	tarball := createTestTarball(t, map[string]string{"README.md": "frog"}, symlinks)
+	assert.NoError(t, ExtractArchive(destDir, bytes.NewReader(tarball), TarGzArchive, false, nil, ExtractionLimits{}))
+
+	linkTarget, err := os.Readlink(filepath.Join(destDir, "docs", "inside"))
+	assert.NoError(t, err)
+	assert.Equal(t, "guide.md", linkTarget)
+	// Links which may point outside of the destination are skipped
+	_, err = os.Lstat(filepath.Join(destDir, "outside"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Lstat(filepath.Join(destDir, "parent"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Lstat(filepath.Join(destDir, "absolute"))
+	assert.True(t, os.IsNotExist(err))
+
+	zipContent := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(zipContent)
+	for linkName, linkTarget := range symlinks {
+		header := &zip.FileHeader{Name: linkName}
+		header.SetMode(os.ModeSymlink | 0777)
+		fileWriter, err := zipWriter.CreateHeader(header)
+		assert.NoError(t, err)
+		_, err = fileWriter.Write([]byte(linkTarget))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zipWriter.Close())
+	destDir = t.TempDir()
+	assert.NoError(t, ExtractArchive(destDir, bytes.NewReader(zipContent.Bytes()), ZipArchive, false, nil, ExtractionLimits{}))
+	linkTarget, err = os.Readlink(filepath.Join(destDir, "docs", "inside"))
+	assert.NoError(t, err)
+	assert.Equal(t, "guide.md", linkTarget)
+	_, err = os.Lstat(filepath.Join(destDir, "outside"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Lstat(filepath.Join(destDir, "parent"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractArchiveChainedSymlinks(t *testing.T) {
+	// Each link looks inside of the destination on its own, but l3 resolves through l2 to the parent of the destination
+	entries := []chainedArchiveEntry{
+		{name: "root/l2", linkTarget: "."},
+		{name: "root/l3", linkTarget: "l2/.."},
+		{name: "root/l3/escaped.txt", content: "frog"},
+	}
+
+	parentDir := t.TempDir()
+	destDir := filepath.Join(parentDir, "dest")
+	_ = ExtractArchive(destDir, bytes.NewReader(createChainedTarball(t, entries)), TarGzArchive, true, nil, ExtractionLimits{})
+	assert.NoFileExists(t, filepath.Join(parentDir, "escaped.txt"))
+
+	parentDir = t.TempDir()
+	destDir = filepath.Join(parentDir, "dest")
+	_ = ExtractArchive(destDir, bytes.NewReader(createChainedZip(t, entries)), ZipArchive, true, nil, ExtractionLimits{})
+	assert.NoFileExists(t, filepath.Join(parentDir, "escaped.txt"))
+
+	// Files are never written through a link, even when it points inside of the destination
+	entries = []chainedArchiveEntry{
+		{name: "root/l2", linkTarget: "."},
+		{name: "root/l2/file.txt", content: "frog"},
+	}
+	destDir = t.TempDir()
+	assert.Error(t, ExtractArchive(destDir, bytes.NewReader(createChainedTarball(t, entries)), TarGzArchive, true, nil, ExtractionLimits{}))
+	assert.NoFileExists(t, filepath.Join(destDir, "file.txt"))
+	destDir = t.TempDir()
+	assert.Error(t, ExtractArchive(destDir, bytes.NewReader(createChainedZip(t, entries)), ZipArchive, true, nil, ExtractionLimits{}))
+	assert.NoFileExists(t, filepath.Join(destDir, "file.txt"))
+}
+
+// chainedArchiveEntry is a file, or a symbolic link if linkTarget is set, of an archive whose entries are kept in order
+type chainedArchiveEntry struct {
+	name       string
+	linkTarget string
+	content    string
+}
+
+func createChainedTarball(t *testing.T, entries []chainedArchiveEntry) []byte {
+	archive := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		if entry.linkTarget != "" {
+			assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: entry.name, Linkname: entry.linkTarget, Mode: 0777, Typeflag: tar.TypeSymlink}))
+			continue
+		}
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: entry.name, Mode: 0644, Size: int64(len(entry.content)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write([]byte(entry.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+	return archive.Bytes()
+}
+
+func createChainedZip(t *testing.T, entries []chainedArchiveEntry) []byte {
+	archive := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(archive)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name}
+		content := entry.content
+		if entry.linkTarget != "" {
+			header.SetMode(os.ModeSymlink | 0777)
+			content = entry.linkTarget
+		}
+		fileWriter, err := zipWriter.CreateHeader(header)
+		assert.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zipWriter.Close())
+	return archive.Bytes()
+}
+
+// createTestTarball returns a tar.gz archive of the given files and symbolic links
+func createTestTarball(t *testing.T, files map[string]string, symlinks map[string]string) []byte {
+	archive := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for filePath, content := range files {
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: filePath, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	for linkName, linkTarget := range symlinks {
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: linkName, Linkname: linkTarget, Mode: 0777, Typeflag: tar.TypeSymlink}))
+	}
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+	return archive.Bytes()
+}
+
 func TestAddBranchPrefix(t *testing.T) {
 	branch := "sampleBranch"
 	branchWithPrefix := AddBranchPrefix(branch)
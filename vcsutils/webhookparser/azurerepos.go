@@ -0,0 +1,387 @@
+package webhookparser
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	// azureReposBasicAuthUsername is the basic authentication username of service hooks created by the Azure Repos client.
+	// The password is the webhook token.
+	azureReposBasicAuthUsername = "froggit-go"
+	azureReposBranchPrefix      = "refs/heads/"
+)
+
+// azureReposWebhookParser represents an incoming service hook on Azure Repos
+type azureReposWebhookParser struct {
+	logger vcsutils.Log
+}
+
+// newAzureReposWebhookParser create a new azureReposWebhookParser instance
+func newAzureReposWebhookParser(logger vcsutils.Log) *azureReposWebhookParser {
+	return &azureReposWebhookParser{
+		logger: logger,
+	}
+}
+
+// Azure Repos doesn't sign the payloads of service hooks, hence the token is sent as the basic authentication password
+func (webhook *azureReposWebhookParser) validatePayload(_ context.Context, request *http.Request, token []byte) ([]byte, error) {
+	username, password, basicAuthExists := request.BasicAuth()
+	if len(token) > 0 || basicAuthExists {
+		if username != azureReposBasicAuthUsername || !hmac.Equal([]byte(password), token) {
+			return nil, errors.New("token mismatch")
+		}
+	}
+	payload := new(bytes.Buffer)
+	if _, err := payload.ReadFrom(request.Body); err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
+
+func (webhook *azureReposWebhookParser) deliveryDetails(_ *http.Request, payload []byte) webhookDelivery {
+	event := &azureReposWebhook{}
+	if json.Unmarshal(payload, event) != nil {
+		return webhookDelivery{}
+	}
+	return webhookDelivery{id: event.ID, time: event.CreatedDate}
+}
+
+func (webhook *azureReposWebhookParser) parseIncomingWebhook(_ context.Context, _ *http.Request, payload []byte) (*WebhookInfo, error) {
+	event := &azureReposWebhook{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+	switch event.EventType {
+	case "git.push":
+		push := &azureReposPush{}
+		if err := json.Unmarshal(event.Resource, push); err != nil {
+			return nil, err
+		}
+		return webhook.parsePushEvent(event, push), nil
+	case "git.pullrequest.created", "git.pullrequest.updated", "git.pullrequest.merged":
+		pullRequest := &azureReposPullRequest{}
+		if err := json.Unmarshal(event.Resource, pullRequest); err != nil {
+			return nil, err
+		}
+		return webhook.parsePrEvents(event, pullRequest), nil
+	case "ms.vss-code.git-pullrequest-comment-event":
+		commentEvent := &azureReposPullRequestComment{}
+		if err := json.Unmarshal(event.Resource, commentEvent); err != nil {
+			return nil, err
+		}
+		return webhook.parsePrCommentEvent(event, commentEvent), nil
+	}
+	return nil, nil
+}
+
+func (webhook *azureReposWebhookParser) parsePushEvent(event *azureReposWebhook, push *azureReposPush) *WebhookInfo {
+	if len(push.RefUpdates) == 0 {
+		return nil
+	}
+	refUpdate := push.RefUpdates[0]
+	if strings.HasPrefix(refUpdate.Name, vcsutils.TagPrefix) {
+		return webhook.parseTagEvent(push, refUpdate)
+	}
+	lastCommit := webhook.getLastCommit(push, refUpdate.NewObjectID)
+	status := branchStatus(refUpdate.OldObjectID != gitNilHash, refUpdate.NewObjectID != gitNilHash)
+	return &WebhookInfo{
+		TargetRepositoryDetails: push.Repository.repoDetails(),
+		TargetBranch:            strings.TrimPrefix(refUpdate.Name, azureReposBranchPrefix),
+		Timestamp:               event.CreatedDate.UTC().Unix(),
+		Event:                   pushEvent(status),
+		Commit: WebHookInfoCommit{
+			Hash:    refUpdate.newObjectID(),
+			Message: lastCommit.Comment,
+			Url:     lastCommit.URL,
+		},
+		BeforeCommit: WebHookInfoCommit{
+			Hash: refUpdate.oldObjectID(),
+		},
+		BranchStatus: status,
+		TriggeredBy:  push.PushedBy.user(),
+		Committer: WebHookInfoUser{
+			DisplayName: lastCommit.Committer.Name,
+			Email:       lastCommit.Committer.Email,
+		},
+		Author: WebHookInfoUser{
+			DisplayName: lastCommit.Author.Name,
+			Email:       lastCommit.Author.Email,
+		},
+		CompareUrl: webhook.compareURL(push.Repository, refUpdate),
+		Push:       webhook.parsePushCommits(push),
+	}
+}
+
+// parsePushCommits returns the pushed commits. Azure Repos sends the commits from the newest, and up to 20 commits.
+func (webhook *azureReposWebhookParser) parsePushCommits(push *azureReposPush) *WebhookInfoPush {
+	pushInfo := &WebhookInfoPush{}
+	for i := len(push.Commits) - 1; i >= 0; i-- {
+		commit := push.Commits[i]
+		pushInfo.Commits = append(pushInfo.Commits, WebhookInfoPushCommit{
+			Hash:      commit.CommitID,
+			Message:   commit.Comment,
+			Url:       commit.URL,
+			Timestamp: commit.Author.Date.UTC().Unix(),
+			Author: WebHookInfoUser{
+				DisplayName: commit.Author.Name,
+				Email:       commit.Author.Email,
+			},
+			Committer: WebHookInfoUser{
+				DisplayName: commit.Committer.Name,
+				Email:       commit.Committer.Email,
+			},
+		})
+	}
+	return pushInfo
+}
+
+// getLastCommit returns the commit the pushed ref points to. The commits of the payload are sorted from the newest.
+func (webhook *azureReposWebhookParser) getLastCommit(push *azureReposPush, commitID string) azureReposCommit {
+	for _, commit := range push.Commits {
+		if commit.CommitID == commitID {
+			return commit
+		}
+	}
+	if len(push.Commits) == 0 || commitID == gitNilHash {
+		return azureReposCommit{}
+	}
+	return push.Commits[0]
+}
+
+// compareURL generates the HTML URL for the comparison between commits before and after push
+func (webhook *azureReposWebhookParser) compareURL(repository azureReposRepository, refUpdate azureReposRefUpdate) string {
+	if refUpdate.oldObjectID() == "" || refUpdate.newObjectID() == "" || repository.RemoteURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/branchCompare?baseVersion=GC%s&targetVersion=GC%s", repository.RemoteURL, refUpdate.OldObjectID, refUpdate.NewObjectID)
+}
+
+func (webhook *azureReposWebhookParser) parseTagEvent(push *azureReposPush, refUpdate azureReposRefUpdate) *WebhookInfo {
+	info := &WebhookInfo{
+		Tag: &WebhookInfoTag{
+			Name:       strings.TrimPrefix(refUpdate.Name, vcsutils.TagPrefix),
+			Repository: push.Repository.repoDetails(),
+			Author:     push.PushedBy.user(),
+		},
+	}
+	if refUpdate.NewObjectID != gitNilHash {
+		info.Event = vcsutils.TagPushed
+		info.Tag.Hash = refUpdate.NewObjectID
+	} else {
+		info.Event = vcsutils.TagRemoved
+		info.Tag.Hash = refUpdate.OldObjectID
+	}
+	return info
+}
+
+func (webhook *azureReposWebhookParser) parsePrEvents(event *azureReposWebhook, pullRequest *azureReposPullRequest) *WebhookInfo {
+	var webhookEvent vcsutils.WebhookEvent
+	switch event.EventType {
+	case "git.pullrequest.created":
+		webhookEvent = vcsutils.PrOpened
+	case "git.pullrequest.updated":
+		switch pullRequest.Status {
+		case "active":
+			webhookEvent = vcsutils.PrEdited
+		case "abandoned":
+			webhookEvent = vcsutils.PrClosed
+		default:
+			// Completed pull requests are reported by the merged event
+			return nil
+		}
+	case "git.pullrequest.merged":
+		// Merge attempts are reported for active pull requests as well, whenever their source branch is updated
+		if pullRequest.Status != "completed" || pullRequest.MergeStatus != "succeeded" {
+			return nil
+		}
+		webhookEvent = vcsutils.PrMerged
+	}
+	repository := pullRequest.Repository.repoDetails()
+	sourceBranch := strings.TrimPrefix(pullRequest.SourceRefName, azureReposBranchPrefix)
+	targetBranch := strings.TrimPrefix(pullRequest.TargetRefName, azureReposBranchPrefix)
+	timestamp := event.CreatedDate.UTC().Unix()
+	return &WebhookInfo{
+		PullRequestId:           pullRequest.PullRequestID,
+		TargetRepositoryDetails: repository,
+		TargetBranch:            targetBranch,
+		SourceRepositoryDetails: repository,
+		SourceBranch:            sourceBranch,
+		Timestamp:               timestamp,
+		Event:                   webhookEvent,
+		PullRequest: &WebhookInfoPullRequest{
+			ID:               pullRequest.PullRequestID,
+			Title:            pullRequest.Title,
+			CompareUrl:       webhook.pullRequestURL(pullRequest),
+			Timestamp:        timestamp,
+			Author:           pullRequest.CreatedBy.user(),
+			TriggeredBy:      pullRequest.CreatedBy.user(),
+			SkipDecryption:   true,
+			TargetRepository: repository,
+			TargetBranch:     targetBranch,
+			TargetHash:       pullRequest.LastMergeTargetCommit.CommitID,
+			SourceRepository: repository,
+			SourceBranch:     sourceBranch,
+			SourceHash:       pullRequest.LastMergeSourceCommit.CommitID,
+			Labels:           webhook.labels(pullRequest),
+		},
+	}
+}
+
+func (webhook *azureReposWebhookParser) labels(pullRequest *azureReposPullRequest) []string {
+	var labels []string
+	for _, label := range pullRequest.Labels {
+		labels = append(labels, label.Name)
+	}
+	return labels
+}
+
+// parsePrCommentEvent parses a comment on a pull request. Azure Repos sends the same event for created and edited comments.
+func (webhook *azureReposWebhookParser) parsePrCommentEvent(event *azureReposWebhook, commentEvent *azureReposPullRequestComment) *WebhookInfo {
+	comment := commentEvent.Comment
+	if comment.CommentType == "system" {
+		// System comments, such as votes and status changes, are not comments
+		return nil
+	}
+	info := webhook.parsePrEvents(event, &commentEvent.PullRequest)
+	info.Event = vcsutils.PrCommentCreated
+	if comment.LastContentUpdatedDate.After(comment.PublishedDate) {
+		info.Event = vcsutils.PrCommentEdited
+	}
+	info.Comment = &WebhookInfoComment{
+		ID:        comment.ID,
+		Body:      comment.Content,
+		Timestamp: comment.LastContentUpdatedDate.UTC().Unix(),
+		Author:    comment.Author.user(),
+	}
+	return info
+}
+
+// pullRequestURL generates the HTML URL of the pull request, since the URL of the payload is of the REST API
+func (webhook *azureReposWebhookParser) pullRequestURL(pullRequest *azureReposPullRequest) string {
+	if pullRequest.Repository.RemoteURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/pullrequest/%d", pullRequest.Repository.RemoteURL, pullRequest.PullRequestID)
+}
+
+type azureReposWebhook struct {
+	ID          string          `json:"id,omitempty"`
+	EventType   string          `json:"eventType,omitempty"`
+	CreatedDate time.Time       `json:"createdDate,omitempty"`
+	Resource    json.RawMessage `json:"resource,omitempty"`
+}
+
+type azureReposPullRequestComment struct {
+	Comment struct {
+		ID                     int64              `json:"id,omitempty"`
+		Content                string             `json:"content,omitempty"`
+		CommentType            string             `json:"commentType,omitempty"`
+		Author                 azureReposIdentity `json:"author,omitempty"`
+		PublishedDate          time.Time          `json:"publishedDate,omitempty"`
+		LastContentUpdatedDate time.Time          `json:"lastContentUpdatedDate,omitempty"`
+	} `json:"comment,omitempty"`
+	PullRequest azureReposPullRequest `json:"pullRequest,omitempty"`
+}
+
+type azureReposPush struct {
+	Commits    []azureReposCommit    `json:"commits,omitempty"`
+	RefUpdates []azureReposRefUpdate `json:"refUpdates,omitempty"`
+	Repository azureReposRepository  `json:"repository,omitempty"`
+	PushedBy   azureReposIdentity    `json:"pushedBy,omitempty"`
+}
+
+type azureReposCommit struct {
+	CommitID  string            `json:"commitId,omitempty"`
+	Comment   string            `json:"comment,omitempty"`
+	URL       string            `json:"url,omitempty"`
+	Author    azureReposGitUser `json:"author,omitempty"`
+	Committer azureReposGitUser `json:"committer,omitempty"`
+}
+
+type azureReposGitUser struct {
+	Name  string    `json:"name,omitempty"`
+	Email string    `json:"email,omitempty"`
+	Date  time.Time `json:"date,omitempty"`
+}
+
+type azureReposRefUpdate struct {
+	Name        string `json:"name,omitempty"`
+	OldObjectID string `json:"oldObjectId,omitempty"`
+	NewObjectID string `json:"newObjectId,omitempty"`
+}
+
+// oldObjectID returns the commit the ref pointed to before the push, or an empty string if the ref was created
+func (refUpdate azureReposRefUpdate) oldObjectID() string {
+	if refUpdate.OldObjectID == gitNilHash {
+		return ""
+	}
+	return refUpdate.OldObjectID
+}
+
+// newObjectID returns the commit the ref points to after the push, or an empty string if the ref was deleted
+func (refUpdate azureReposRefUpdate) newObjectID() string {
+	if refUpdate.NewObjectID == gitNilHash {
+		return ""
+	}
+	return refUpdate.NewObjectID
+}
+
+type azureReposRepository struct {
+	Name      string `json:"name,omitempty"`
+	RemoteURL string `json:"remoteUrl,omitempty"`
+	Project   struct {
+		Name string `json:"name,omitempty"`
+	} `json:"project,omitempty"`
+}
+
+// repoDetails returns the repository details, where the owner is the Azure DevOps project
+func (repository azureReposRepository) repoDetails() WebHookInfoRepoDetails {
+	return WebHookInfoRepoDetails{
+		Name:  repository.Name,
+		Owner: repository.Project.Name,
+	}
+}
+
+type azureReposIdentity struct {
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+}
+
+func (identity azureReposIdentity) user() WebHookInfoUser {
+	return WebHookInfoUser{
+		Login:       identity.UniqueName,
+		DisplayName: identity.DisplayName,
+		AvatarUrl:   identity.ImageURL,
+	}
+}
+
+type azureReposPullRequest struct {
+	PullRequestID int                  `json:"pullRequestId,omitempty"`
+	Status        string               `json:"status,omitempty"`
+	MergeStatus   string               `json:"mergeStatus,omitempty"`
+	Title         string               `json:"title,omitempty"`
+	SourceRefName string               `json:"sourceRefName,omitempty"`
+	TargetRefName string               `json:"targetRefName,omitempty"`
+	Repository    azureReposRepository `json:"repository,omitempty"`
+	CreatedBy     azureReposIdentity   `json:"createdBy,omitempty"`
+	Labels        []struct {
+		Name string `json:"name,omitempty"`
+	} `json:"labels,omitempty"`
+	LastMergeSourceCommit struct {
+		CommitID string `json:"commitId,omitempty"`
+	} `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit struct {
+		CommitID string `json:"commitId,omitempty"`
+	} `json:"lastMergeTargetCommit,omitempty"`
+}
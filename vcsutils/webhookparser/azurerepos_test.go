@@ -0,0 +1,236 @@
+package webhookparser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	azureReposPushExpectedTime      = int64(1630305683)
+	azureReposPrCreateExpectedTime  = int64(1631202047)
+	azureReposPrUpdateExpectedTime  = int64(1631202266)
+	azureReposPrAbandonExpectedTime = int64(1638864453)
+	azureReposPrMergeExpectedTime   = int64(1638866119)
+	azureReposPrCommentExpectedTime = int64(1638868521)
+	azureReposPrEditCommentTime     = int64(1638868622)
+	azureReposExpectedPrID          = 1
+	azureReposExpectedAvatarUrl     = "https://dev.azure.com/jfrog/_api/_common/identityImage?id=00067ffed5d543b3b5e5d7e6f0e56b5a"
+)
+
+var azureReposExpectedUser = WebHookInfoUser{Login: "yahavi@example.com", DisplayName: "Yahav Itzhak", AvatarUrl: azureReposExpectedAvatarUrl}
+
+func TestAzureReposParseIncomingPushWebhook(t *testing.T) {
+	actual, err := parseAzureReposTestPayload(t, "pushpayload.json", azureReposBasicAuthUsername, string(token))
+	assert.NoError(t, err)
+
+	assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+	assert.Equal(t, expectedOwner, actual.TargetRepositoryDetails.Owner)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, azureReposPushExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, WebHookInfoUser{DisplayName: "Yahav Itzhak", Email: "yahavi@example.com"}, actual.Author)
+	assert.Equal(t, WebHookInfoUser{DisplayName: "Yahav Itzhak", Email: "yahavi@example.com"}, actual.Committer)
+	assert.Equal(t, azureReposExpectedUser, actual.TriggeredBy)
+	assert.Equal(t, WebHookInfoCommit{
+		Hash:    "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+		Message: "Update README.md",
+		Url:     "https://dev.azure.com/jfrog/_git/hello-world/commit/450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+	}, actual.Commit)
+	assert.Equal(t, WebHookInfoCommit{Hash: "aad8feacf6f8063150476a7b2bd9770f4ba1c7c3"}, actual.BeforeCommit)
+	assert.Equal(t, WebhookInfoBranchStatusUpdated, actual.BranchStatus)
+	assert.Equal(t, "https://dev.azure.com/jfrog/yahavi/_git/hello-world/branchCompare?baseVersion=GCaad8feacf6f8063150476a7b2bd9770f4ba1c7c3&targetVersion=GC450cd4687e3644d544ca4cb3a7a355fea9e6f0dc", actual.CompareUrl)
+	assert.Equal(t, &WebhookInfoPush{Commits: []WebhookInfoPushCommit{{
+		Hash:      "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+		Message:   "Update README.md",
+		Url:       "https://dev.azure.com/jfrog/_git/hello-world/commit/450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+		Timestamp: azureReposPushExpectedTime,
+		Author:    WebHookInfoUser{DisplayName: "Yahav Itzhak", Email: "yahavi@example.com"},
+		Committer: WebHookInfoUser{DisplayName: "Yahav Itzhak", Email: "yahavi@example.com"},
+	}}}, actual.Push)
+}
+
+func TestAzureReposParseIncomingBranchDeleteWebhook(t *testing.T) {
+	actual, err := parseAzureReposTestPayload(t, "branchdeletepayload.json", azureReposBasicAuthUsername, string(token))
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+	assert.Equal(t, azureReposPushExpectedTime, actual.Timestamp)
+	assert.Equal(t, WebHookInfoCommit{Hash: "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc"}, actual.BeforeCommit)
+	assert.Equal(t, WebhookInfoBranchStatusDeleted, actual.BranchStatus)
+	assert.Empty(t, actual.Push.Commits)
+}
+
+func TestAzureReposParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		expectedTime      int64
+		expectedEventType vcsutils.WebhookEvent
+		expectedLabels    []string
+	}{
+		{name: "create", payloadFilename: "prcreatepayload.json", expectedTime: azureReposPrCreateExpectedTime, expectedEventType: vcsutils.PrOpened},
+		{name: "update", payloadFilename: "prupdatepayload.json", expectedTime: azureReposPrUpdateExpectedTime, expectedEventType: vcsutils.PrEdited, expectedLabels: []string{"security"}},
+		{name: "abandon", payloadFilename: "prabandonpayload.json", expectedTime: azureReposPrAbandonExpectedTime, expectedEventType: vcsutils.PrClosed},
+		{name: "merge", payloadFilename: "prmergepayload.json", expectedTime: azureReposPrMergeExpectedTime, expectedEventType: vcsutils.PrMerged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseAzureReposTestPayload(t, tt.payloadFilename, azureReposBasicAuthUsername, string(token))
+			assert.NoError(t, err)
+
+			assert.Equal(t, azureReposExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.SourceRepositoryDetails)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, &WebhookInfoPullRequest{
+				ID:               azureReposExpectedPrID,
+				Title:            "Update README.md",
+				CompareUrl:       "https://dev.azure.com/jfrog/yahavi/_git/hello-world/pullrequest/1",
+				Timestamp:        tt.expectedTime,
+				Author:           azureReposExpectedUser,
+				TriggeredBy:      azureReposExpectedUser,
+				SkipDecryption:   true,
+				TargetRepository: WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner},
+				TargetBranch:     expectedBranch,
+				TargetHash:       "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+				SourceRepository: WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner},
+				SourceBranch:     expectedSourceBranch,
+				SourceHash:       "72108853aa0eac9d1b72fe34710aeed256d193d5",
+				Labels:           tt.expectedLabels,
+			}, actual.PullRequest)
+		})
+	}
+}
+
+func TestAzureReposParseIncomingPrCommentWebhook(t *testing.T) {
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		expectedEventType vcsutils.WebhookEvent
+		expectedBody      string
+		expectedTime      int64
+	}{
+		{name: "create", payloadFilename: "prcommentpayload.json", expectedEventType: vcsutils.PrCommentCreated, expectedBody: "rescan", expectedTime: azureReposPrCommentExpectedTime},
+		{name: "edit", payloadFilename: "preditcommentpayload.json", expectedEventType: vcsutils.PrCommentEdited, expectedBody: "rescan please", expectedTime: azureReposPrEditCommentTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseAzureReposTestPayload(t, tt.payloadFilename, azureReposBasicAuthUsername, string(token))
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, azureReposExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.NotNil(t, actual.PullRequest)
+			assert.Equal(t, &WebhookInfoComment{
+				ID:        3,
+				Body:      tt.expectedBody,
+				Timestamp: tt.expectedTime,
+				Author:    azureReposExpectedUser,
+			}, actual.Comment)
+		})
+	}
+}
+
+func TestAzureReposParseIncomingWebhookTagEvents(t *testing.T) {
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		expectedEventType vcsutils.WebhookEvent
+	}{
+		{name: "created", payloadFilename: "tagcreatepayload.json", expectedEventType: vcsutils.TagPushed},
+		{name: "deleted", payloadFilename: "tagdeletepayload.json", expectedEventType: vcsutils.TagRemoved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseAzureReposTestPayload(t, tt.payloadFilename, azureReposBasicAuthUsername, string(token))
+			assert.NoError(t, err)
+			assert.Equal(t, &WebhookInfo{
+				Event: tt.expectedEventType,
+				Tag: &WebhookInfoTag{
+					Name:       "first_tag",
+					Hash:       "45abefa4485f0e03fa5db86a998d16a0a1df07b7",
+					Repository: WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner},
+					Author:     azureReposExpectedUser,
+				},
+			}, actual)
+		})
+	}
+}
+
+func TestAzureReposParseIncomingWebhookIgnoredEvents(t *testing.T) {
+	// Completed pull requests are reported by the merged event only
+	webhook := newAzureReposWebhookParser(vcsutils.EmptyLogger{})
+	actual, err := webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"eventType":"git.pullrequest.updated","resource":{"pullRequestId":1,"status":"completed"}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	// Merge attempts of active pull requests
+	actual, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"eventType":"git.pullrequest.merged","resource":{"pullRequestId":1,"status":"active","mergeStatus":"succeeded"}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	// System comments
+	actual, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"eventType":"ms.vss-code.git-pullrequest-comment-event","resource":{"comment":{"id":1,"commentType":"system"}}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	actual, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"eventType":"build.complete","resource":{}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestAzureReposParseIncomingWebhookError(t *testing.T) {
+	webhook := newAzureReposWebhookParser(vcsutils.EmptyLogger{})
+	_, err := webhook.parseIncomingWebhook(context.Background(), nil, []byte{})
+	assert.Error(t, err)
+
+	_, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"eventType":"git.push","resource":[]}`))
+	assert.Error(t, err)
+}
+
+func TestAzureReposPayloadMismatchToken(t *testing.T) {
+	_, err := parseAzureReposTestPayload(t, "pushpayload.json", azureReposBasicAuthUsername, "wrong-token")
+	assert.EqualError(t, err, "token mismatch")
+
+	_, err = parseAzureReposTestPayload(t, "pushpayload.json", "wrong-username", string(token))
+	assert.EqualError(t, err, "token mismatch")
+
+	_, err = parseAzureReposTestPayload(t, "pushpayload.json", "", "")
+	assert.EqualError(t, err, "token mismatch")
+}
+
+// parseAzureReposTestPayload parses a payload from the testdata, sent with the basic authentication credentials.
+// Empty credentials are not sent.
+func parseAzureReposTestPayload(t *testing.T, payloadFilename, username, password string) (*WebhookInfo, error) {
+	reader, err := os.Open(filepath.Join("testdata", "azurerepos", payloadFilename))
+	assert.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+	if username != "" {
+		request.SetBasicAuth(username, password)
+	}
+	return ParseIncomingWebhook(context.Background(),
+		vcsutils.EmptyLogger{},
+		WebhookOrigin{
+			VcsProvider: vcsutils.AzureRepos,
+			Token:       token,
+		}, request)
+}
@@ -3,6 +3,7 @@ package webhookparser
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +15,9 @@ import (
 	"github.com/jfrog/froggit-go/vcsutils"
 )
 
+// bitbucketCloudRequestUUIDHeader is the unique ID of the webhook request
+const bitbucketCloudRequestUUIDHeader = "X-Request-UUID"
+
 // bitbucketCloudWebhookParser represents an incoming webhook on Bitbucket cloud
 type bitbucketCloudWebhookParser struct {
 	logger vcsutils.Log
@@ -27,19 +31,33 @@ func newBitbucketCloudWebhookParser(logger vcsutils.Log) *bitbucketCloudWebhookP
 }
 
 func (webhook *bitbucketCloudWebhookParser) validatePayload(_ context.Context, request *http.Request, token []byte) ([]byte, error) {
-	keys, tokenParamsExist := request.URL.Query()["token"]
-	if len(token) > 0 || tokenParamsExist {
-		if keys[0] != string(token) {
-			return nil, errors.New("token mismatch")
+	// Webhooks with a secret are signed like in Bitbucket server.
+	// Otherwise, the token is sent in the "token" query parameter of the webhook URL.
+	signature := request.Header.Get(sha256Signature)
+	if len(signature) == 0 {
+		keys, tokenParamsExist := request.URL.Query()["token"]
+		if len(token) > 0 || tokenParamsExist {
+			if !tokenParamsExist || !hmac.Equal([]byte(keys[0]), token) {
+				return nil, errors.New("token mismatch")
+			}
 		}
 	}
 	payload := new(bytes.Buffer)
 	if _, err := payload.ReadFrom(request.Body); err != nil {
 		return nil, err
 	}
+	if len(signature) > 0 {
+		if err := validatePayloadSignature(payload.Bytes(), signature, token); err != nil {
+			return nil, err
+		}
+	}
 	return payload.Bytes(), nil
 }
 
+func (webhook *bitbucketCloudWebhookParser) deliveryDetails(request *http.Request, _ []byte) webhookDelivery {
+	return webhookDelivery{id: request.Header.Get(bitbucketCloudRequestUUIDHeader)}
+}
+
 func (webhook *bitbucketCloudWebhookParser) parseIncomingWebhook(_ context.Context, request *http.Request, payload []byte) (*WebhookInfo, error) {
 	bitbucketCloudWebHook := &bitbucketCloudWebHook{}
 	err := json.Unmarshal(payload, bitbucketCloudWebHook)
@@ -59,6 +77,12 @@ func (webhook *bitbucketCloudWebhookParser) parseIncomingWebhook(_ context.Conte
 		return webhook.parsePrEvents(bitbucketCloudWebHook, vcsutils.PrMerged), nil
 	case "pullrequest:rejected":
 		return webhook.parsePrEvents(bitbucketCloudWebHook, vcsutils.PrRejected), nil
+	case "pullrequest:comment_created":
+		return webhook.parsePrCommentEvents(bitbucketCloudWebHook, vcsutils.PrCommentCreated), nil
+	case "pullrequest:comment_updated":
+		return webhook.parsePrCommentEvents(bitbucketCloudWebHook, vcsutils.PrCommentEdited), nil
+	case "issue:comment_created":
+		return webhook.parseIssueCommentEvent(bitbucketCloudWebHook), nil
 	}
 	return nil, nil
 }
@@ -141,6 +165,38 @@ func (webhook *bitbucketCloudWebhookParser) parsePrEvents(bitbucketCloudWebHook
 	}
 }
 
+func (webhook *bitbucketCloudWebhookParser) parsePrCommentEvents(bitbucketCloudWebHook *bitbucketCloudWebHook, event vcsutils.WebhookEvent) *WebhookInfo {
+	info := webhook.parsePrEvents(bitbucketCloudWebHook, event)
+	info.Comment = webhook.parseComment(bitbucketCloudWebHook.Comment)
+	info.Timestamp = info.Comment.Timestamp
+	return info
+}
+
+func (webhook *bitbucketCloudWebhookParser) parseIssueCommentEvent(bitbucketCloudWebHook *bitbucketCloudWebHook) *WebhookInfo {
+	comment := webhook.parseComment(bitbucketCloudWebHook.Comment)
+	comment.IssueID = bitbucketCloudWebHook.Issue.ID
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.parseRepoFullName(bitbucketCloudWebHook.Repository.FullName),
+		Timestamp:               comment.Timestamp,
+		Event:                   vcsutils.IssueCommentCreated,
+		Comment:                 comment,
+	}
+}
+
+func (webhook *bitbucketCloudWebhookParser) parseComment(comment bitbucketCloudComment) *WebhookInfoComment {
+	return &WebhookInfoComment{
+		ID:        comment.ID,
+		Body:      comment.Content.Raw,
+		Url:       comment.Links.Html.Href,
+		Timestamp: comment.UpdatedOn.UTC().Unix(),
+		Author: WebHookInfoUser{
+			Login:       comment.User.Nickname,
+			DisplayName: comment.User.DisplayName,
+			AvatarUrl:   comment.User.Links.Avatar.Href,
+		},
+	}
+}
+
 func (webhook *bitbucketCloudWebhookParser) parseRepoFullName(fullName string) WebHookInfoRepoDetails {
 	// From https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Repository
 	// "full_name : The workspace and repository slugs joined with a '/'."
@@ -176,11 +232,16 @@ func (webhook *bitbucketCloudWebhookParser) parseBranchChangeEvent(hook *bitbuck
 	change := hook.Push.Changes[changeIdx]
 	lastCommit := change.New.Target
 	beforeCommitHash := webhook.parentOfLastCommit(lastCommit)
+	status := webhook.branchStatus(change)
+	if status == WebhookInfoBranchStatusDeleted {
+		// The deleted branch has no new commits, hence the before commit is the last commit of the deleted branch
+		beforeCommitHash = change.Old.Target.Hash
+	}
 	return &WebhookInfo{
 		TargetRepositoryDetails: webhook.parseRepoFullName(hook.Repository.FullName),
 		TargetBranch:            webhook.branchName(change),
 		Timestamp:               lastCommit.Date.UTC().Unix(),
-		Event:                   vcsutils.Push,
+		Event:                   pushEvent(status),
 		Commit: WebHookInfoCommit{
 			Hash:    lastCommit.Hash,
 			Message: lastCommit.Message,
@@ -189,7 +250,7 @@ func (webhook *bitbucketCloudWebhookParser) parseBranchChangeEvent(hook *bitbuck
 		BeforeCommit: WebHookInfoCommit{
 			Hash: beforeCommitHash,
 		},
-		BranchStatus: webhook.branchStatus(change),
+		BranchStatus: status,
 		TriggeredBy: WebHookInfoUser{
 			Login: hook.Actor.Nickname,
 		},
@@ -201,7 +262,27 @@ func (webhook *bitbucketCloudWebhookParser) parseBranchChangeEvent(hook *bitbuck
 			Email: webhook.email(lastCommit),
 		},
 		CompareUrl: webhook.compareURL(hook, lastCommit, beforeCommitHash),
+		Push:       webhook.parsePushCommits(hook, change),
+	}
+}
+
+// parsePushCommits returns the pushed commits. Bitbucket sends the commits from the newest, and up to 5 commits.
+func (webhook *bitbucketCloudWebhookParser) parsePushCommits(hook *bitbucketCloudWebHook, change bitbucketChange) *WebhookInfoPush {
+	push := &WebhookInfoPush{}
+	for i := len(change.Commits) - 1; i >= 0; i-- {
+		commit := change.Commits[i]
+		push.Commits = append(push.Commits, WebhookInfoPushCommit{
+			Hash:      commit.Hash,
+			Message:   commit.Message,
+			Url:       commit.Links.Html.Ref,
+			Timestamp: commit.Date.UTC().Unix(),
+			Author: WebHookInfoUser{
+				Login: webhook.login(hook, commit),
+				Email: webhook.email(commit),
+			},
+		})
 	}
+	return push
 }
 
 func (webhook *bitbucketCloudWebhookParser) parseTagEvent(hook *bitbucketCloudWebHook, changeIdx int) *WebhookInfo {
@@ -236,6 +317,32 @@ type bitbucketCloudWebHook struct {
 	Actor       struct {
 		Nickname string `json:"nickname,omitempty"`
 	} `json:"actor,omitempty"`
+	Comment bitbucketCloudComment `json:"comment,omitempty"`
+	Issue   struct {
+		ID int `json:"id,omitempty"`
+	} `json:"issue,omitempty"`
+}
+
+type bitbucketCloudComment struct {
+	ID      int64 `json:"id,omitempty"`
+	Content struct {
+		Raw string `json:"raw,omitempty"`
+	} `json:"content,omitempty"`
+	User struct {
+		Nickname    string `json:"nickname,omitempty"`
+		DisplayName string `json:"display_name,omitempty"`
+		Links       struct {
+			Avatar struct {
+				Href string `json:"href,omitempty"`
+			} `json:"avatar,omitempty"`
+		} `json:"links,omitempty"`
+	} `json:"user,omitempty"`
+	UpdatedOn time.Time `json:"updated_on,omitempty"`
+	Links     struct {
+		Html struct {
+			Href string `json:"href,omitempty"`
+		} `json:"html,omitempty"`
+	} `json:"links,omitempty"`
 }
 
 type bitbucketPullRequest struct {
@@ -269,6 +376,8 @@ type bitbucketChange struct {
 	New bitbucketResourceChange `json:"new,omitempty"`
 	// Old is an existing resource.
 	Old bitbucketResourceChange `json:"old,omitempty"`
+	// Commits are the pushed commits.
+	Commits []bitbucketCommit `json:"commits,omitempty"`
 }
 
 type bitbucketResourceChange struct {
@@ -1,6 +1,7 @@
 package webhookparser
 
 import (
+	"bytes"
 	"context"
 	"net/http"
 	"net/http/httptest"
@@ -20,6 +21,10 @@ const (
 	bitbucketCloudPrMergeExpectedTime  = int64(1638783257)
 	bitbucketCloudPrCloseExpectedTime  = int64(1638784487)
 	bitbucketCloudExpectedPrID         = 2
+	// Comment events
+	bitbucketCloudPrCommentCreateExpectedTime = int64(1638784203)
+	bitbucketCloudPrCommentUpdateExpectedTime = int64(1638784305)
+	bitbucketCloudIssueCommentExpectedTime    = int64(1638784401)
 )
 
 func TestBitbucketCloudParseIncomingPushWebhook(t *testing.T) {
@@ -59,6 +64,37 @@ func TestBitbucketCloudParseIncomingPushWebhook(t *testing.T) {
 	}, actual.BeforeCommit)
 	assert.Equal(t, WebhookInfoBranchStatusUpdated, actual.BranchStatus)
 	assert.Equal(t, "https://bitbucket.org/yahavi/hello-world/branches/compare/fa8c303777d0006fa99b843b830ad1ed18a6928e..a2b4032ae25e08844b894e413d80ee75b4c1995b#diff", actual.CompareUrl)
+	assert.Equal(t, &WebhookInfoPush{Commits: []WebhookInfoPushCommit{{
+		Hash:      "fa8c303777d0006fa99b843b830ad1ed18a6928e",
+		Message:   "README.md edited online with Bitbucket",
+		Timestamp: bitbucketCloudPushExpectedTime,
+		Author:    WebHookInfoUser{Login: "yahavi", Email: "yahavitz@gmail.com"},
+	}}}, actual.Push)
+}
+
+func TestBitbucketCloudParseIncomingBranchDeleteWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", "branchdeletepayload.json"))
+	assert.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1?token="+string(token), reader)
+	request.Header.Add(EventHeaderKey, "repo:push")
+
+	actual, err := ParseIncomingWebhook(context.Background(),
+		vcsutils.EmptyLogger{},
+		WebhookOrigin{
+			VcsProvider: vcsutils.BitbucketCloud,
+			Token:       token,
+		},
+		request)
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+	assert.Equal(t, WebHookInfoCommit{Hash: "fa8c303777d0006fa99b843b830ad1ed18a6928e"}, actual.BeforeCommit)
+	assert.Equal(t, WebhookInfoBranchStatusDeleted, actual.BranchStatus)
+	assert.Empty(t, actual.CompareUrl)
 }
 
 func TestBitbucketCloudParseIncomingPrWebhook(t *testing.T) {
@@ -311,6 +347,92 @@ func TestBitbucketCloudParseIncomingWebhookTagEvents(t *testing.T) {
 	}
 }
 
+func TestBitbucketCloudParseIncomingCommentWebhook(t *testing.T) {
+	expectedAuthor := WebHookInfoUser{
+		Login:       "yahavi",
+		DisplayName: "Yahav Itzhak",
+		AvatarUrl: "https://secure.gravatar.com/avatar/9680da1674e22a1de17acb19bb233ebf?d=https%3A%2F%2F" +
+			"avatar-management--avatars.us-west-2.prod.public.atl-paas.net%2Finitials%2FYI-5.png",
+	}
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		eventHeader       string
+		expectedEventType vcsutils.WebhookEvent
+		expectedPrID      int
+		expectedComment   *WebhookInfoComment
+	}{
+		{
+			name:              "pull request comment created",
+			payloadFilename:   "prcommentcreatepayload.json",
+			eventHeader:       "pullrequest:comment_created",
+			expectedEventType: vcsutils.PrCommentCreated,
+			expectedPrID:      bitbucketCloudExpectedPrID,
+			expectedComment: &WebhookInfoComment{
+				ID:        263528112,
+				Body:      "rescan",
+				Url:       "https://bitbucket.org/yahavi/hello-world/pull-requests/2#comment-263528112",
+				Timestamp: bitbucketCloudPrCommentCreateExpectedTime,
+				Author:    expectedAuthor,
+			},
+		},
+		{
+			name:              "pull request comment updated",
+			payloadFilename:   "prcommentupdatepayload.json",
+			eventHeader:       "pullrequest:comment_updated",
+			expectedEventType: vcsutils.PrCommentEdited,
+			expectedPrID:      bitbucketCloudExpectedPrID,
+			expectedComment: &WebhookInfoComment{
+				ID:        263528112,
+				Body:      "rescan please",
+				Url:       "https://bitbucket.org/yahavi/hello-world/pull-requests/2#comment-263528112",
+				Timestamp: bitbucketCloudPrCommentUpdateExpectedTime,
+				Author:    expectedAuthor,
+			},
+		},
+		{
+			name:              "issue comment created",
+			payloadFilename:   "issuecommentcreatepayload.json",
+			eventHeader:       "issue:comment_created",
+			expectedEventType: vcsutils.IssueCommentCreated,
+			expectedComment: &WebhookInfoComment{
+				ID:        61973413,
+				Body:      "Thanks for the report",
+				Url:       "https://bitbucket.org/yahavi/hello-world/issues/3#comment-61973413",
+				Timestamp: bitbucketCloudIssueCommentExpectedTime,
+				Author:    expectedAuthor,
+				IssueID:   3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "bitbucketcloud", tt.payloadFilename))
+			assert.NoError(t, err)
+			defer close(reader)
+
+			request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1?token="+string(token), reader)
+			request.Header.Add(EventHeaderKey, tt.eventHeader)
+
+			actual, err := ParseIncomingWebhook(context.Background(),
+				vcsutils.EmptyLogger{},
+				WebhookOrigin{
+					VcsProvider: vcsutils.BitbucketCloud,
+					Token:       token,
+				},
+				request)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, tt.expectedPrID, actual.PullRequestId)
+			assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+			assert.Equal(t, tt.expectedComment.Timestamp, actual.Timestamp)
+			assert.Equal(t, tt.expectedComment, actual.Comment)
+			assert.Equal(t, tt.expectedPrID != 0, actual.PullRequest != nil)
+		})
+	}
+}
+
 func TestBitbucketCloudParseIncomingWebhookError(t *testing.T) {
 	request := &http.Request{URL: &url.URL{RawQuery: "token=a"}}
 	_, err := ParseIncomingWebhook(context.Background(),
@@ -346,3 +468,38 @@ func TestBitbucketCloudPayloadMismatchToken(t *testing.T) {
 		request)
 	assert.EqualError(t, err, "token mismatch")
 }
+
+func TestBitbucketCloudParseIncomingSignedWebhook(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pushpayload.json"))
+	assert.NoError(t, err)
+	tests := []struct {
+		name          string
+		signature     string
+		expectedError string
+	}{
+		{name: "valid signature", signature: "sha256=" + calculatePayloadSignature(payload, token)},
+		{name: "invalid signature", signature: "sha256=" + calculatePayloadSignature(payload, []byte("wrong-token")), expectedError: "payload signature mismatch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", bytes.NewReader(payload))
+			request.Header.Add(EventHeaderKey, "repo:push")
+			request.Header.Add(sha256Signature, tt.signature)
+
+			actual, err := ParseIncomingWebhook(context.Background(),
+				vcsutils.EmptyLogger{},
+				WebhookOrigin{
+					VcsProvider: vcsutils.BitbucketCloud,
+					Token:       token,
+				},
+				request)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, vcsutils.Push, actual.Event)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+		})
+	}
+}
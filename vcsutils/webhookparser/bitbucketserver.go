@@ -3,11 +3,7 @@ package webhookparser
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -20,6 +16,10 @@ import (
 
 const sha256Signature = "X-Hub-Signature"
 const bitbucketServerEventHeader = "X-Event-Key"
+const bitbucketServerRequestIDHeader = "X-Request-Id"
+
+// bitbucketServerPingEvent is sent by the "Test connection" button of the webhook
+const bitbucketServerPingEvent = "diagnostics:ping"
 
 // bitbucketServerWebhookParser represents an incoming webhook on Bitbucket server
 type bitbucketServerWebhookParser struct {
@@ -41,24 +41,38 @@ func (webhook *bitbucketServerWebhookParser) validatePayload(_ context.Context,
 		return nil, err
 	}
 
-	expectedSignature := request.Header.Get(sha256Signature)
-	if len(token) > 0 || len(expectedSignature) > 0 {
-		actualSignature := calculatePayloadSignature(payload.Bytes(), token)
-		if expectedSignature != "sha256="+actualSignature {
-			return nil, errors.New("payload signature mismatch")
+	signature := request.Header.Get(sha256Signature)
+	if len(token) > 0 || len(signature) > 0 {
+		if err := validatePayloadSignature(payload.Bytes(), signature, token); err != nil {
+			return nil, err
 		}
 	}
 	return payload.Bytes(), nil
 }
 
+func (webhook *bitbucketServerWebhookParser) deliveryDetails(request *http.Request, payload []byte) webhookDelivery {
+	delivery := webhookDelivery{id: request.Header.Get(bitbucketServerRequestIDHeader)}
+	bitbucketServerWebHook := &bitbucketServerWebHook{}
+	if json.Unmarshal(payload, bitbucketServerWebHook) == nil {
+		// The date is a part of the signed payload, hence it can't be forged
+		delivery.time, _ = time.Parse("2006-01-02T15:04:05-0700", bitbucketServerWebHook.Date)
+	}
+	return delivery
+}
+
 func (webhook *bitbucketServerWebhookParser) parseIncomingWebhook(_ context.Context, request *http.Request, payload []byte) (*WebhookInfo, error) {
+	event := request.Header.Get(bitbucketServerEventHeader)
+	if event == bitbucketServerPingEvent {
+		// The payload of the "Test connection" ping doesn't contain any details
+		return &WebhookInfo{Event: vcsutils.Ping}, nil
+	}
+
 	bitbucketServerWebHook := &bitbucketServerWebHook{}
 	err := json.Unmarshal(payload, bitbucketServerWebHook)
 	if err != nil {
 		return nil, err
 	}
 
-	event := request.Header.Get(bitbucketServerEventHeader)
 	switch event {
 	case "repo:refs_changed":
 		return webhook.parseRefChange(bitbucketServerWebHook)
@@ -68,18 +82,18 @@ func (webhook *bitbucketServerWebhookParser) parseIncomingWebhook(_ context.Cont
 		return webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrEdited)
 	case "pr:merged":
 		return webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrMerged)
-	case "pr:declined", "pr:deleted":
+	case "pr:declined":
 		return webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrRejected)
+	case "pr:deleted":
+		return webhook.parsePrEvents(bitbucketServerWebHook, vcsutils.PrClosed)
+	case "pr:comment:added":
+		return webhook.parsePrCommentEvents(bitbucketServerWebHook, vcsutils.PrCommentCreated)
+	case "pr:comment:edited":
+		return webhook.parsePrCommentEvents(bitbucketServerWebHook, vcsutils.PrCommentEdited)
 	}
 	return nil, nil
 }
 
-func calculatePayloadSignature(payload []byte, token []byte) string {
-	hmacHash := hmac.New(sha256.New, token)
-	hmacHash.Write(payload)
-	return hex.EncodeToString(hmacHash.Sum(nil))
-}
-
 func (webhook *bitbucketServerWebhookParser) parsePushEvent(bitbucketCloudWebHook *bitbucketServerWebHook) (*WebhookInfo, error) {
 	eventTime, err := time.Parse("2006-01-02T15:04:05-0700", bitbucketCloudWebHook.Date)
 	if err != nil {
@@ -92,11 +106,12 @@ func (webhook *bitbucketServerWebhookParser) parsePushEvent(bitbucketCloudWebHoo
 		commitURL = fmt.Sprintf("%s/projects/%s/repos/%s/commits/%s", webhook.endpoint,
 			repositoryDetails.Owner, repositoryDetails.Name, bitbucketCloudWebHook.Changes[0].ToHash)
 	}
+	status := webhook.branchStatus(bitbucketCloudWebHook.Changes[0].ToHash, bitbucketCloudWebHook.Changes[0].FromHash)
 	return &WebhookInfo{
 		TargetRepositoryDetails: repositoryDetails,
 		TargetBranch:            strings.TrimPrefix(bitbucketCloudWebHook.Changes[0].RefID, "refs/heads/"),
 		Timestamp:               eventTime.UTC().Unix(),
-		Event:                   vcsutils.Push,
+		Event:                   pushEvent(status),
 		Commit: WebHookInfoCommit{
 			Hash: bitbucketCloudWebHook.Changes[0].ToHash,
 			Url:  commitURL,
@@ -104,7 +119,7 @@ func (webhook *bitbucketServerWebhookParser) parsePushEvent(bitbucketCloudWebHoo
 		BeforeCommit: WebHookInfoCommit{
 			Hash: bitbucketCloudWebHook.Changes[0].FromHash,
 		},
-		BranchStatus: webhook.branchStatus(bitbucketCloudWebHook.Changes[0].ToHash, bitbucketCloudWebHook.Changes[0].FromHash),
+		BranchStatus: status,
 		TriggeredBy: WebHookInfoUser{
 			Login:       bitbucketCloudWebHook.Actor.Name,
 			DisplayName: bitbucketCloudWebHook.Actor.DisplayName,
@@ -117,9 +132,19 @@ func (webhook *bitbucketServerWebhookParser) parsePushEvent(bitbucketCloudWebHoo
 			Email:       bitbucketCloudWebHook.Actor.EmailAddress,
 			DisplayName: bitbucketCloudWebHook.Actor.DisplayName,
 		},
+		Push: webhook.parsePushCommits(bitbucketCloudWebHook.Changes[0].ToHash, commitURL),
 	}, nil
 }
 
+// parsePushCommits returns the pushed commits. Bitbucket Server doesn't send the pushed commits, hence only the last commit is returned.
+func (webhook *bitbucketServerWebhookParser) parsePushCommits(lastCommitHash, lastCommitURL string) *WebhookInfoPush {
+	push := &WebhookInfoPush{}
+	if lastCommitHash != gitNilHash {
+		push.Commits = []WebhookInfoPushCommit{{Hash: lastCommitHash, Url: lastCommitURL}}
+	}
+	return push
+}
+
 func (webhook *bitbucketServerWebhookParser) parseRefChange(bitbucketCloudWebHook *bitbucketServerWebHook) (*WebhookInfo, error) {
 	for _, change := range bitbucketCloudWebHook.Changes {
 		if change.Ref.Type == "BRANCH" {
@@ -208,6 +233,31 @@ func (webhook *bitbucketServerWebhookParser) parsePrEvents(bitbucketCloudWebHook
 	}, nil
 }
 
+func (webhook *bitbucketServerWebhookParser) parsePrCommentEvents(bitbucketServerWebHook *bitbucketServerWebHook, event vcsutils.WebhookEvent) (*WebhookInfo, error) {
+	info, err := webhook.parsePrEvents(bitbucketServerWebHook, event)
+	if err != nil {
+		return nil, err
+	}
+	comment := bitbucketServerWebHook.Comment
+	commentURL := ""
+	if pullRequestURL := webhook.getFirstHrefFromLinks(bitbucketServerWebHook.PullRequest.Links); pullRequestURL != "" {
+		commentURL = fmt.Sprintf("%s/overview?commentId=%d", pullRequestURL, comment.ID)
+	}
+	info.Comment = &WebhookInfoComment{
+		ID:        comment.ID,
+		Body:      comment.Text,
+		Url:       commentURL,
+		Timestamp: time.UnixMilli(comment.UpdatedDate).UTC().Unix(),
+		Author: WebHookInfoUser{
+			Login:       comment.Author.Name,
+			DisplayName: comment.Author.DisplayName,
+			Email:       comment.Author.EmailAddress,
+			AvatarUrl:   webhook.getFirstHrefFromLinks(comment.Author.Links),
+		},
+	}
+	return info, nil
+}
+
 func (webhook *bitbucketServerWebhookParser) getFirstHrefFromLinks(links bitbucketv1.Links) string {
 	if len(links.Self) > 0 {
 		return links.Self[0].Href
@@ -228,6 +278,14 @@ type bitbucketServerWebHook struct {
 	PullRequest bitbucketv1.PullRequest         `json:"pullRequest,omitempty"`
 	Changes     []bitbucketServerWebHookChanges `json:"changes,omitempty"`
 	Actor       bitbucketServerWebHookActor     `json:"actor,omitempty"`
+	Comment     bitbucketServerWebHookComment   `json:"comment,omitempty"`
+}
+
+type bitbucketServerWebHookComment struct {
+	ID          int64                       `json:"id,omitempty"`
+	Text        string                      `json:"text,omitempty"`
+	Author      bitbucketServerWebHookActor `json:"author,omitempty"`
+	UpdatedDate int64                       `json:"updatedDate,omitempty"`
 }
 
 type bitbucketServerWebHookChanges struct {
@@ -35,6 +35,16 @@ const (
 	bitbucketServerPrDeleteExpectedTime = int64(1638794581)
 	bitbucketServerPrDeletedSha256      = "b0ccbd0f97ca030aa469cfa559f7051732c33fc63e7e3a8b5b8e2d157af71806"
 
+	bitbucketServerPrCommentAddExpectedTime  = int64(1638868923)
+	bitbucketServerPrCommentAddedSha256      = "60f3cea1f79a8613d680d92bbb15a36b877de605ff5d36f140b2e3a5694403a3"
+	bitbucketServerPrCommentEditExpectedTime = int64(1638869021)
+	bitbucketServerPrCommentEditedSha256     = "42c09efbab9af5fe726ade9731d424c31288de38001d1dc48d31fa7f94c050c4"
+
+	bitbucketServerBranchDeleteExpectedTime = int64(1631179004)
+	bitbucketServerBranchDeletedSha256      = "7af0b5f991c507732ed5e9e58999455cd83f716e1b487e02f11b96e841cd96e0"
+
+	bitbucketServerPingSha256 = "60ad6acec6a4997202c9662ed51e3c3c0553aefa49e6eef260d0a7f89c42501d"
+
 	bitbucketServerTagPushedSha256  = "f55f6b6317b24cd19c21876db1832460978b5c6376ba6ce740b8649c1bcd41e0"
 	bitbucketServerTagRemovedSha256 = "0c951396d86b353de850d49bd76556f2b2336c3ff4f437086113f44be5421bb9"
 
@@ -53,7 +63,7 @@ func TestBitbucketServerParseIncomingPushWebhook(t *testing.T) {
 
 	// Parse webhook
 	parser := newBitbucketServerWebhookParser(vcsutils.EmptyLogger{}, "https://bitbucket.test/rest")
-	actual, err := validateAndParseHttpRequest(context.Background(), parser, token, request)
+	actual, err := validateAndParseHttpRequest(context.Background(), parser, WebhookOrigin{Token: token}, request)
 	assert.NoError(t, err)
 
 	// Check values
@@ -75,6 +85,57 @@ func TestBitbucketServerParseIncomingPushWebhook(t *testing.T) {
 	}, actual.BeforeCommit)
 	assert.Equal(t, WebhookInfoBranchStatusCreated, actual.BranchStatus)
 	assert.Equal(t, "", actual.CompareUrl)
+	assert.Equal(t, &WebhookInfoPush{Commits: []WebhookInfoPushCommit{{
+		Hash: "929d3054cf60e11a38672966f948bb5d95f48f0e",
+		Url:  "https://bitbucket.test/rest/projects/~YAHAVI/repos/hello-world/commits/929d3054cf60e11a38672966f948bb5d95f48f0e",
+	}}}, actual.Push)
+}
+
+func TestBitbucketServerParseIncomingBranchDeleteWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketserver", "branchdeletepayload.json"))
+	assert.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, "repo:refs_changed")
+	request.Header.Add(sha256Signature, "sha256="+bitbucketServerBranchDeletedSha256)
+
+	actual, err := ParseIncomingWebhook(context.Background(),
+		vcsutils.EmptyLogger{},
+		WebhookOrigin{
+			VcsProvider: vcsutils.BitbucketServer,
+			Token:       token,
+		},
+		request)
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: formatOwnerForBitbucketServer(expectedOwner)}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+	assert.Equal(t, bitbucketServerBranchDeleteExpectedTime, actual.Timestamp)
+	assert.Equal(t, WebHookInfoCommit{Hash: "b3fc2f0a02761b443fca72022a2ac897cc2ceb3a"}, actual.BeforeCommit)
+	assert.Equal(t, WebhookInfoBranchStatusDeleted, actual.BranchStatus)
+	assert.Empty(t, actual.Push.Commits)
+}
+
+func TestBitbucketServerParseIncomingPingWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "bitbucketserver", "pingpayload.json"))
+	assert.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+	request.Header.Add(EventHeaderKey, bitbucketServerPingEvent)
+	request.Header.Add(sha256Signature, "sha256="+bitbucketServerPingSha256)
+
+	actual, err := ParseIncomingWebhook(context.Background(),
+		vcsutils.EmptyLogger{},
+		WebhookOrigin{
+			VcsProvider: vcsutils.BitbucketServer,
+			Token:       token,
+		},
+		request)
+	assert.NoError(t, err)
+	assert.Equal(t, &WebhookInfo{Event: vcsutils.Ping}, actual)
 }
 
 func TestBitbucketServerParseIncomingPrWebhook(t *testing.T) {
@@ -207,7 +268,7 @@ func TestBitbucketServerParseIncomingPrWebhook(t *testing.T) {
 			eventHeader:       "pr:deleted",
 			payloadSha:        bitbucketServerPrDeletedSha256,
 			expectedTime:      bitbucketServerPrDeleteExpectedTime,
-			expectedEventType: vcsutils.PrRejected,
+			expectedEventType: vcsutils.PrClosed,
 			expectedPullRequestInfo: &WebhookInfoPullRequest{
 				ID:         bitbucketServerExpectedPrID,
 				Title:      title,
@@ -262,6 +323,78 @@ func TestBitbucketServerParseIncomingPrWebhook(t *testing.T) {
 		})
 	}
 }
+func TestBitbucketServerParseIncomingPrCommentWebhook(t *testing.T) {
+	author := WebHookInfoUser{
+		Login:       "yahavi",
+		DisplayName: "Yahav Itzhak",
+		Email:       "yahavi@jfrog.com",
+		AvatarUrl:   "https://git.acme.info/users/yahavi",
+	}
+	const commentUrl = "https://git.acme.info/users/yahavi/repos/hello-world/pull-requests/3/overview?commentId=17"
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		eventHeader       string
+		payloadSha        string
+		expectedTime      int64
+		expectedEventType vcsutils.WebhookEvent
+		expectedBody      string
+	}{
+		{
+			name:              "added",
+			payloadFilename:   "prcommentaddpayload.json",
+			eventHeader:       "pr:comment:added",
+			payloadSha:        bitbucketServerPrCommentAddedSha256,
+			expectedTime:      bitbucketServerPrCommentAddExpectedTime,
+			expectedEventType: vcsutils.PrCommentCreated,
+			expectedBody:      "rescan",
+		},
+		{
+			name:              "edited",
+			payloadFilename:   "prcommenteditpayload.json",
+			eventHeader:       "pr:comment:edited",
+			payloadSha:        bitbucketServerPrCommentEditedSha256,
+			expectedTime:      bitbucketServerPrCommentEditExpectedTime,
+			expectedEventType: vcsutils.PrCommentEdited,
+			expectedBody:      "rescan please",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "bitbucketserver", tt.payloadFilename))
+			assert.NoError(t, err)
+			defer close(reader)
+
+			request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+			request.Header.Add(EventHeaderKey, tt.eventHeader)
+			request.Header.Add(sha256Signature, "sha256="+tt.payloadSha)
+
+			actual, err := ParseIncomingWebhook(context.Background(),
+				vcsutils.EmptyLogger{},
+				WebhookOrigin{
+					VcsProvider: vcsutils.BitbucketServer,
+					Token:       token,
+				},
+				request)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, bitbucketServerExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.NotNil(t, actual.PullRequest)
+			assert.Equal(t, &WebhookInfoComment{
+				ID:        17,
+				Body:      tt.expectedBody,
+				Url:       commentUrl,
+				Timestamp: tt.expectedTime,
+				Author:    author,
+			}, actual.Comment)
+		})
+	}
+}
+
 func TestBitbucketServerParseIncomingWebhookTagEvents(t *testing.T) {
 	t.Parallel()
 	author := WebHookInfoUser{
@@ -0,0 +1,364 @@
+package webhookparser
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	// codeCommitSnsMessageTypeHeader is sent by Amazon SNS with every HTTP/S notification
+	codeCommitSnsMessageTypeHeader = "X-Amz-Sns-Message-Type"
+	// codeCommitApiDestinationsUserAgent is the user agent prefix of Amazon EventBridge API destinations
+	codeCommitApiDestinationsUserAgent = "Amazon/EventBridge/ApiDestinations"
+	codeCommitBranchPrefix             = "refs/heads/"
+)
+
+// codeCommitSnsSubscribeURLPattern restricts the subscription confirmation requests to Amazon SNS endpoints
+var codeCommitSnsSubscribeURLPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// codeCommitWebhookParser represents an incoming Amazon EventBridge event of AWS CodeCommit.
+// The events are delivered either by an Amazon SNS HTTP/S subscription, or by an Amazon EventBridge API destination.
+type codeCommitWebhookParser struct {
+	logger vcsutils.Log
+	// httpClient confirms the Amazon SNS subscriptions
+	httpClient *http.Client
+}
+
+// newCodeCommitWebhookParser create a new codeCommitWebhookParser instance
+func newCodeCommitWebhookParser(logger vcsutils.Log) *codeCommitWebhookParser {
+	return &codeCommitWebhookParser{
+		logger:     logger,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// AWS CodeCommit events aren't signed, hence the token is sent as the basic authentication password.
+// Both Amazon SNS (credentials in the subscription URL) and EventBridge API destinations (basic authorization connection) support it.
+func (webhook *codeCommitWebhookParser) validatePayload(_ context.Context, request *http.Request, token []byte) ([]byte, error) {
+	_, password, basicAuthExists := request.BasicAuth()
+	if len(token) > 0 || basicAuthExists {
+		if !hmac.Equal([]byte(password), token) {
+			return nil, errors.New("token mismatch")
+		}
+	}
+	payload := new(bytes.Buffer)
+	if _, err := payload.ReadFrom(request.Body); err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
+
+func (webhook *codeCommitWebhookParser) deliveryDetails(_ *http.Request, payload []byte) webhookDelivery {
+	message := &codeCommitSnsMessage{}
+	if json.Unmarshal(payload, message) != nil {
+		return webhookDelivery{}
+	}
+	if message.Type != "" {
+		return webhookDelivery{id: message.MessageID, time: message.Timestamp}
+	}
+	event := &codeCommitEvent{}
+	if json.Unmarshal(payload, event) != nil {
+		return webhookDelivery{}
+	}
+	return webhookDelivery{id: event.ID, time: event.Time}
+}
+
+func (webhook *codeCommitWebhookParser) parseIncomingWebhook(ctx context.Context, _ *http.Request, payload []byte) (*WebhookInfo, error) {
+	message := &codeCommitSnsMessage{}
+	if err := json.Unmarshal(payload, message); err != nil {
+		return nil, err
+	}
+	switch message.Type {
+	case "SubscriptionConfirmation":
+		if err := webhook.confirmSubscription(ctx, message.SubscribeURL); err != nil {
+			return nil, err
+		}
+		return &WebhookInfo{Event: vcsutils.Ping}, nil
+	case "Notification":
+		payload = []byte(message.Message)
+	case "":
+		// Sent by an Amazon EventBridge API destination, without the Amazon SNS envelope
+	default:
+		return nil, nil
+	}
+
+	event := &codeCommitEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+	switch event.DetailType {
+	case "CodeCommit Repository State Change":
+		return webhook.parseReferenceEvent(event)
+	case "CodeCommit Pull Request State Change":
+		return webhook.parsePrEvents(event)
+	case "CodeCommit Comment on Pull Request":
+		return webhook.parsePrCommentEvent(event)
+	}
+	return nil, nil
+}
+
+// confirmSubscription confirms the Amazon SNS subscription, by visiting the subscribe URL of the confirmation message
+func (webhook *codeCommitWebhookParser) confirmSubscription(ctx context.Context, subscribeURL string) (err error) {
+	parsedURL, err := url.Parse(subscribeURL)
+	if err != nil {
+		return err
+	}
+	if parsedURL.Scheme != "https" || !codeCommitSnsSubscribeURLPattern.MatchString(parsedURL.Hostname()) {
+		return fmt.Errorf("unexpected Amazon SNS subscribe URL: %s", subscribeURL)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, subscribeURL, nil)
+	if err != nil {
+		return err
+	}
+	response, err := webhook.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, response.Body.Close())
+	}()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to confirm the Amazon SNS subscription, status: %s", response.Status)
+	}
+	webhook.logger.Info("Confirmed the Amazon SNS subscription")
+	return nil
+}
+
+func (webhook *codeCommitWebhookParser) parseReferenceEvent(event *codeCommitEvent) (*WebhookInfo, error) {
+	reference := &codeCommitReferenceDetail{}
+	if err := json.Unmarshal(event.Detail, reference); err != nil {
+		return nil, err
+	}
+	repository := WebHookInfoRepoDetails{Name: reference.RepositoryName, Owner: event.Account}
+	if reference.ReferenceType == "tag" {
+		info := &WebhookInfo{
+			Tag: &WebhookInfoTag{
+				Name:       reference.ReferenceName,
+				Hash:       reference.CommitID,
+				Repository: repository,
+				Author:     codeCommitUser(reference.CallerUserArn),
+			},
+			Event: vcsutils.TagPushed,
+		}
+		if reference.Event == "referenceDeleted" {
+			info.Event = vcsutils.TagRemoved
+			info.Tag.Hash = reference.OldCommitID
+		}
+		return info, nil
+	}
+	status := branchStatus(reference.Event != "referenceCreated", reference.Event != "referenceDeleted")
+	info := &WebhookInfo{
+		TargetRepositoryDetails: repository,
+		TargetBranch:            reference.ReferenceName,
+		Timestamp:               event.Time.UTC().Unix(),
+		Event:                   pushEvent(status),
+		BeforeCommit:            WebHookInfoCommit{Hash: reference.OldCommitID},
+		BranchStatus:            status,
+		TriggeredBy:             codeCommitUser(reference.CallerUserArn),
+		// The event doesn't contain the pushed commits
+		Push: &WebhookInfoPush{},
+	}
+	if status != WebhookInfoBranchStatusDeleted {
+		info.Commit = WebHookInfoCommit{
+			Hash: reference.CommitID,
+			Url:  codeCommitConsoleURL(event.Region, reference.RepositoryName, "commit/"+reference.CommitID),
+		}
+	}
+	return info, nil
+}
+
+func (webhook *codeCommitWebhookParser) parsePrEvents(event *codeCommitEvent) (*WebhookInfo, error) {
+	pullRequest := &codeCommitPullRequestDetail{}
+	if err := json.Unmarshal(event.Detail, pullRequest); err != nil {
+		return nil, err
+	}
+	var webhookEvent vcsutils.WebhookEvent
+	switch pullRequest.Event {
+	case "pullRequestCreated":
+		webhookEvent = vcsutils.PrOpened
+	case "pullRequestSourceBranchUpdated":
+		webhookEvent = vcsutils.PrEdited
+	case "pullRequestStatusChanged":
+		if pullRequest.IsMerged == "True" {
+			// Merged pull requests are reported by the merge status event
+			return nil, nil
+		}
+		webhookEvent = vcsutils.PrClosed
+		if pullRequest.PullRequestStatus == "Open" {
+			webhookEvent = vcsutils.PrReopened
+		}
+	case "pullRequestMergeStatusUpdated":
+		if pullRequest.IsMerged != "True" {
+			return nil, nil
+		}
+		webhookEvent = vcsutils.PrMerged
+	default:
+		return nil, nil
+	}
+	pullRequestID, err := strconv.Atoi(pullRequest.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	repository := WebHookInfoRepoDetails{Name: pullRequest.repositoryName(), Owner: event.Account}
+	sourceBranch := strings.TrimPrefix(pullRequest.SourceReference, codeCommitBranchPrefix)
+	targetBranch := strings.TrimPrefix(pullRequest.DestinationReference, codeCommitBranchPrefix)
+	timestamp := event.Time.UTC().Unix()
+	return &WebhookInfo{
+		PullRequestId:           pullRequestID,
+		TargetRepositoryDetails: repository,
+		TargetBranch:            targetBranch,
+		SourceRepositoryDetails: repository,
+		SourceBranch:            sourceBranch,
+		Timestamp:               timestamp,
+		Event:                   webhookEvent,
+		PullRequest: &WebhookInfoPullRequest{
+			ID:               pullRequestID,
+			Title:            pullRequest.Title,
+			CompareUrl:       codeCommitConsoleURL(event.Region, repository.Name, "pull-requests/"+pullRequest.PullRequestID+"/details"),
+			Timestamp:        timestamp,
+			Author:           codeCommitUser(pullRequest.Author),
+			TriggeredBy:      codeCommitUser(pullRequest.CallerUserArn),
+			SkipDecryption:   true,
+			TargetRepository: repository,
+			TargetBranch:     targetBranch,
+			TargetHash:       pullRequest.DestinationCommit,
+			SourceRepository: repository,
+			SourceBranch:     sourceBranch,
+			SourceHash:       pullRequest.SourceCommit,
+		},
+	}, nil
+}
+
+// parsePrCommentEvent parses a comment on a pull request.
+// The event contains neither the comment content nor the branches, and the comment ID isn't numeric.
+func (webhook *codeCommitWebhookParser) parsePrCommentEvent(event *codeCommitEvent) (*WebhookInfo, error) {
+	comment := &codeCommitCommentDetail{}
+	if err := json.Unmarshal(event.Detail, comment); err != nil {
+		return nil, err
+	}
+	var webhookEvent vcsutils.WebhookEvent
+	switch comment.Event {
+	case "commentOnPullRequestCreated":
+		webhookEvent = vcsutils.PrCommentCreated
+	case "commentOnPullRequestUpdated":
+		webhookEvent = vcsutils.PrCommentEdited
+	default:
+		return nil, nil
+	}
+	pullRequestID, err := strconv.Atoi(comment.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	repository := WebHookInfoRepoDetails{Name: comment.RepositoryName, Owner: event.Account}
+	timestamp := event.Time.UTC().Unix()
+	return &WebhookInfo{
+		PullRequestId:           pullRequestID,
+		TargetRepositoryDetails: repository,
+		SourceRepositoryDetails: repository,
+		Timestamp:               timestamp,
+		Event:                   webhookEvent,
+		PullRequest: &WebhookInfoPullRequest{
+			ID:               pullRequestID,
+			CompareUrl:       codeCommitConsoleURL(event.Region, repository.Name, "pull-requests/"+comment.PullRequestID+"/details"),
+			Timestamp:        timestamp,
+			TriggeredBy:      codeCommitUser(comment.CallerUserArn),
+			SkipDecryption:   true,
+			TargetRepository: repository,
+			TargetHash:       comment.BeforeCommitID,
+			SourceRepository: repository,
+			SourceHash:       comment.AfterCommitID,
+		},
+		Comment: &WebhookInfoComment{
+			Timestamp: timestamp,
+			Author:    codeCommitUser(comment.CallerUserArn),
+		},
+	}, nil
+}
+
+// codeCommitConsoleURL returns the URL of a repository page in the AWS console
+func codeCommitConsoleURL(region, repository, page string) string {
+	if region == "" || repository == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/%s?region=%s",
+		region, url.PathEscape(repository), page, region)
+}
+
+// codeCommitUser returns the user of an IAM ARN, where the login is the name of the IAM identity
+func codeCommitUser(arn string) WebHookInfoUser {
+	if arn == "" {
+		return WebHookInfoUser{}
+	}
+	return WebHookInfoUser{Login: arn[strings.LastIndex(arn, "/")+1:]}
+}
+
+type codeCommitSnsMessage struct {
+	Type         string    `json:"Type,omitempty"`
+	MessageID    string    `json:"MessageId,omitempty"`
+	Message      string    `json:"Message,omitempty"`
+	Timestamp    time.Time `json:"Timestamp,omitempty"`
+	SubscribeURL string    `json:"SubscribeURL,omitempty"`
+}
+
+type codeCommitEvent struct {
+	ID         string          `json:"id,omitempty"`
+	DetailType string          `json:"detail-type,omitempty"`
+	Account    string          `json:"account,omitempty"`
+	Region     string          `json:"region,omitempty"`
+	Time       time.Time       `json:"time,omitempty"`
+	Detail     json.RawMessage `json:"detail,omitempty"`
+}
+
+type codeCommitReferenceDetail struct {
+	Event          string `json:"event,omitempty"`
+	RepositoryName string `json:"repositoryName,omitempty"`
+	ReferenceType  string `json:"referenceType,omitempty"`
+	ReferenceName  string `json:"referenceName,omitempty"`
+	CommitID       string `json:"commitId,omitempty"`
+	OldCommitID    string `json:"oldCommitId,omitempty"`
+	CallerUserArn  string `json:"callerUserArn,omitempty"`
+}
+
+type codeCommitPullRequestDetail struct {
+	Event                string   `json:"event,omitempty"`
+	PullRequestID        string   `json:"pullRequestId,omitempty"`
+	PullRequestStatus    string   `json:"pullRequestStatus,omitempty"`
+	IsMerged             string   `json:"isMerged,omitempty"`
+	Title                string   `json:"title,omitempty"`
+	Author               string   `json:"author,omitempty"`
+	CallerUserArn        string   `json:"callerUserArn,omitempty"`
+	RepositoryNames      []string `json:"repositoryNames,omitempty"`
+	SourceReference      string   `json:"sourceReference,omitempty"`
+	SourceCommit         string   `json:"sourceCommit,omitempty"`
+	DestinationReference string   `json:"destinationReference,omitempty"`
+	DestinationCommit    string   `json:"destinationCommit,omitempty"`
+}
+
+// repositoryName returns the repository of the pull request. Pull requests have a single target, since they are created by CreatePullRequest.
+func (pullRequest codeCommitPullRequestDetail) repositoryName() string {
+	if len(pullRequest.RepositoryNames) == 0 {
+		return ""
+	}
+	return pullRequest.RepositoryNames[0]
+}
+
+type codeCommitCommentDetail struct {
+	Event          string `json:"event,omitempty"`
+	PullRequestID  string `json:"pullRequestId,omitempty"`
+	RepositoryName string `json:"repositoryName,omitempty"`
+	BeforeCommitID string `json:"beforeCommitId,omitempty"`
+	AfterCommitID  string `json:"afterCommitId,omitempty"`
+	CallerUserArn  string `json:"callerUserArn,omitempty"`
+}
@@ -0,0 +1,253 @@
+package webhookparser
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	codeCommitExpectedTime    = int64(1718187823)
+	codeCommitExpectedAccount = "123456789012"
+	codeCommitExpectedPrID    = 1
+	codeCommitExpectedHash    = "9a81f14f2aa8f4d52f3f8f5c5ac2e8d5fdb6e2f1"
+	codeCommitExpectedOldHash = "0f3d0e3c4a0b5e1c2d7a8b9c6e5f4a3b2c1d0e9f"
+)
+
+var codeCommitExpectedRepository = WebHookInfoRepoDetails{Name: expectedRepoName, Owner: codeCommitExpectedAccount}
+
+func TestCodeCommitParseIncomingPushWebhook(t *testing.T) {
+	actual, err := parseCodeCommitTestPayload(t, "pushpayload.json", string(token))
+	assert.NoError(t, err)
+
+	assert.Equal(t, &WebhookInfo{
+		TargetRepositoryDetails: codeCommitExpectedRepository,
+		TargetBranch:            expectedBranch,
+		Timestamp:               codeCommitExpectedTime,
+		Event:                   vcsutils.Push,
+		Commit: WebHookInfoCommit{
+			Hash: codeCommitExpectedHash,
+			Url:  "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/hello-world/commit/" + codeCommitExpectedHash + "?region=eu-west-1",
+		},
+		BeforeCommit: WebHookInfoCommit{Hash: codeCommitExpectedOldHash},
+		BranchStatus: WebhookInfoBranchStatusUpdated,
+		TriggeredBy:  WebHookInfoUser{Login: expectedOwner},
+		Push:         &WebhookInfoPush{},
+	}, actual)
+}
+
+func TestCodeCommitParseIncomingBranchDeleteWebhook(t *testing.T) {
+	actual, err := parseCodeCommitTestPayload(t, "branchdeletepayload.json", string(token))
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, codeCommitExpectedRepository, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+	assert.Equal(t, WebHookInfoCommit{}, actual.Commit)
+	assert.Equal(t, WebHookInfoCommit{Hash: codeCommitExpectedHash}, actual.BeforeCommit)
+	assert.Equal(t, WebhookInfoBranchStatusDeleted, actual.BranchStatus)
+}
+
+func TestCodeCommitParseIncomingTagWebhook(t *testing.T) {
+	actual, err := parseCodeCommitTestPayload(t, "tagcreatepayload.json", string(token))
+	assert.NoError(t, err)
+
+	assert.Equal(t, &WebhookInfo{
+		Event: vcsutils.TagPushed,
+		Tag: &WebhookInfoTag{
+			Name:       "v1.0.0",
+			Hash:       codeCommitExpectedHash,
+			Repository: codeCommitExpectedRepository,
+			Author:     WebHookInfoUser{Login: expectedOwner},
+		},
+	}, actual)
+}
+
+func TestCodeCommitParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		expectedEventType vcsutils.WebhookEvent
+	}{
+		{name: "create", payloadFilename: "prcreatepayload.json", expectedEventType: vcsutils.PrOpened},
+		{name: "update", payloadFilename: "prupdatepayload.json", expectedEventType: vcsutils.PrEdited},
+		{name: "close", payloadFilename: "prclosepayload.json", expectedEventType: vcsutils.PrClosed},
+		{name: "reopen", payloadFilename: "prreopenpayload.json", expectedEventType: vcsutils.PrReopened},
+		{name: "merge", payloadFilename: "prmergepayload.json", expectedEventType: vcsutils.PrMerged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseCodeCommitTestPayload(t, tt.payloadFilename, string(token))
+			assert.NoError(t, err)
+
+			assert.Equal(t, codeCommitExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, codeCommitExpectedRepository, actual.TargetRepositoryDetails)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, codeCommitExpectedRepository, actual.SourceRepositoryDetails)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, codeCommitExpectedTime, actual.Timestamp)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, &WebhookInfoPullRequest{
+				ID:               codeCommitExpectedPrID,
+				Title:            "Update README.md",
+				CompareUrl:       "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/hello-world/pull-requests/1/details?region=eu-west-1",
+				Timestamp:        codeCommitExpectedTime,
+				Author:           WebHookInfoUser{Login: expectedOwner},
+				TriggeredBy:      WebHookInfoUser{Login: "frogger"},
+				SkipDecryption:   true,
+				TargetRepository: codeCommitExpectedRepository,
+				TargetBranch:     expectedBranch,
+				TargetHash:       codeCommitExpectedOldHash,
+				SourceRepository: codeCommitExpectedRepository,
+				SourceBranch:     expectedSourceBranch,
+				SourceHash:       codeCommitExpectedHash,
+			}, actual.PullRequest)
+		})
+	}
+}
+
+func TestCodeCommitParseIncomingPrCommentWebhook(t *testing.T) {
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		expectedEventType vcsutils.WebhookEvent
+	}{
+		{name: "create", payloadFilename: "prcommentpayload.json", expectedEventType: vcsutils.PrCommentCreated},
+		{name: "edit", payloadFilename: "preditcommentpayload.json", expectedEventType: vcsutils.PrCommentEdited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseCodeCommitTestPayload(t, tt.payloadFilename, string(token))
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, codeCommitExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, codeCommitExpectedRepository, actual.TargetRepositoryDetails)
+			assert.Equal(t, codeCommitExpectedTime, actual.Timestamp)
+			assert.Equal(t, codeCommitExpectedHash, actual.PullRequest.SourceHash)
+			assert.Equal(t, codeCommitExpectedOldHash, actual.PullRequest.TargetHash)
+			assert.Equal(t, &WebhookInfoComment{
+				Timestamp: codeCommitExpectedTime,
+				Author:    WebHookInfoUser{Login: expectedOwner},
+			}, actual.Comment)
+		})
+	}
+}
+
+func TestCodeCommitParseIncomingSubscriptionConfirmation(t *testing.T) {
+	var confirmationURL string
+	webhook := newCodeCommitWebhookParser(vcsutils.EmptyLogger{})
+	webhook.httpClient = &http.Client{Transport: codeCommitRoundTripper(func(request *http.Request) (*http.Response, error) {
+		confirmationURL = request.URL.String()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("<ConfirmSubscriptionResponse/>"))}, nil
+	})}
+	payload, err := os.ReadFile(filepath.Join("testdata", "codecommit", "subscriptionconfirmationpayload.json"))
+	assert.NoError(t, err)
+
+	actual, err := webhook.parseIncomingWebhook(context.Background(), nil, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, &WebhookInfo{Event: vcsutils.Ping}, actual)
+	assert.Equal(t, "https://sns.eu-west-1.amazonaws.com/?Action=ConfirmSubscription&TopicArn=arn:aws:sns:eu-west-1:123456789012:codecommit-events&Token=2336412f37EXAMPLE", confirmationURL)
+
+	// Subscribe URLs of other hosts aren't visited
+	confirmationURL = ""
+	_, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"Type":"SubscriptionConfirmation","SubscribeURL":"https://sns.eu-west-1.amazonaws.com.example.com/"}`))
+	assert.Error(t, err)
+	_, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"Type":"SubscriptionConfirmation","SubscribeURL":"http://sns.eu-west-1.amazonaws.com/"}`))
+	assert.Error(t, err)
+	assert.Empty(t, confirmationURL)
+
+	webhook.httpClient = &http.Client{Transport: codeCommitRoundTripper(func(_ *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})}
+	_, err = webhook.parseIncomingWebhook(context.Background(), nil, payload)
+	assert.Error(t, err)
+}
+
+func TestCodeCommitParseIncomingWebhookIgnoredEvents(t *testing.T) {
+	webhook := newCodeCommitWebhookParser(vcsutils.EmptyLogger{})
+	// Merged pull requests are reported by the merge status event only
+	actual, err := webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"detail-type":"CodeCommit Pull Request State Change","detail":{"event":"pullRequestStatusChanged","pullRequestId":"1","isMerged":"True"}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	actual, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"detail-type":"CodeCommit Pull Request State Change","detail":{"event":"pullRequestApprovalStateChanged","pullRequestId":"1"}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	actual, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"Type":"UnsubscribeConfirmation","MessageId":"1"}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+
+	actual, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"detail-type":"CodeCommit Approval Rule Template Change","detail":{}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestCodeCommitParseIncomingWebhookError(t *testing.T) {
+	webhook := newCodeCommitWebhookParser(vcsutils.EmptyLogger{})
+	_, err := webhook.parseIncomingWebhook(context.Background(), nil, []byte{})
+	assert.Error(t, err)
+
+	_, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"Type":"Notification","Message":"invalid"}`))
+	assert.Error(t, err)
+
+	_, err = webhook.parseIncomingWebhook(context.Background(), nil, []byte(`{"detail-type":"CodeCommit Pull Request State Change","detail":{"event":"pullRequestCreated","pullRequestId":"invalid"}}`))
+	assert.Error(t, err)
+}
+
+func TestCodeCommitPayloadMismatchToken(t *testing.T) {
+	_, err := parseCodeCommitTestPayload(t, "pushpayload.json", "wrong-token")
+	assert.EqualError(t, err, "token mismatch")
+
+	_, err = parseCodeCommitTestPayload(t, "pushpayload.json", "")
+	assert.EqualError(t, err, "token mismatch")
+}
+
+func TestCodeCommitDeliveryDetails(t *testing.T) {
+	webhook := newCodeCommitWebhookParser(vcsutils.EmptyLogger{})
+	snsDelivery := webhook.deliveryDetails(nil, []byte(`{"Type":"Notification","MessageId":"sns-delivery","Timestamp":"2021-09-09T09:16:44.123Z"}`))
+	assert.Equal(t, "sns-delivery", snsDelivery.id)
+	assert.Equal(t, int64(1631179004), snsDelivery.time.Unix())
+
+	eventBridgeDelivery := webhook.deliveryDetails(nil, []byte(`{"id":"eventbridge-delivery","time":"2021-09-09T09:16:44Z"}`))
+	assert.Equal(t, "eventbridge-delivery", eventBridgeDelivery.id)
+	assert.Equal(t, int64(1631179004), eventBridgeDelivery.time.Unix())
+
+	assert.Equal(t, webhookDelivery{}, webhook.deliveryDetails(nil, []byte("invalid")))
+}
+
+type codeCommitRoundTripper func(*http.Request) (*http.Response, error)
+
+func (roundTripper codeCommitRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	return roundTripper(request)
+}
+
+// parseCodeCommitTestPayload parses a payload from the testdata, sent with the basic authentication password.
+// An empty password is not sent.
+func parseCodeCommitTestPayload(t *testing.T, payloadFilename, password string) (*WebhookInfo, error) {
+	reader, err := os.Open(filepath.Join("testdata", "codecommit", payloadFilename))
+	assert.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+	if password != "" {
+		request.SetBasicAuth("frogger", password)
+	}
+	return ParseIncomingWebhook(context.Background(),
+		vcsutils.EmptyLogger{},
+		WebhookOrigin{
+			VcsProvider: vcsutils.CodeCommit,
+			Token:       token,
+		}, request)
+}
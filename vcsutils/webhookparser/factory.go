@@ -1,11 +1,18 @@
 package webhookparser
 
 import (
+	"errors"
+	"net/http"
 	"strings"
 
+	"github.com/google/go-github/v56/github"
+
 	"github.com/jfrog/froggit-go/vcsutils"
 )
 
+// bitbucketCloudHookUUIDHeader is sent by Bitbucket Cloud only
+const bitbucketCloudHookUUIDHeader = "X-Hook-UUID"
+
 func createWebhookParser(logger vcsutils.Log, origin WebhookOrigin) webhookParser {
 	origin.OriginURL = strings.TrimSuffix(origin.OriginURL, "/")
 	switch origin.VcsProvider {
@@ -17,6 +24,54 @@ func createWebhookParser(logger vcsutils.Log, origin WebhookOrigin) webhookParse
 		return newBitbucketServerWebhookParser(logger, origin.OriginURL)
 	case vcsutils.BitbucketCloud:
 		return newBitbucketCloudWebhookParser(logger)
+	case vcsutils.AzureRepos:
+		return newAzureReposWebhookParser(logger)
+	case vcsutils.Gitea:
+		return newGiteaWebhookParser(logger, origin.OriginURL)
+	case vcsutils.CodeCommit:
+		return newCodeCommitWebhookParser(logger)
 	}
 	return nil
 }
+
+// DetectVcsProvider returns the VCS provider that sent the incoming webhook HTTP request, according to its headers.
+// Use it to serve webhooks of multiple VCS providers by a single endpoint.
+// Azure Repos service hooks are detected by the basic authentication username set by the CreateWebhook command.
+// AWS CodeCommit events are detected by the headers of Amazon SNS and Amazon EventBridge API destinations.
+func DetectVcsProvider(request *http.Request) (vcsutils.VcsProvider, error) {
+	header := request.Header
+	switch {
+	// Gitea sends the GitHub headers as well, hence it is detected first
+	case header.Get(giteaEventHeader) != "":
+		return vcsutils.Gitea, nil
+	case header.Get(github.EventTypeHeader) != "" || header.Get(github.SHA256SignatureHeader) != "":
+		return vcsutils.GitHub, nil
+	case header.Get(gitLabEventHeader) != "" || header.Get(gitLabKeyHeader) != "":
+		return vcsutils.GitLab, nil
+	case header.Get(EventHeaderKey) != "":
+		return detectBitbucketProvider(header), nil
+	case header.Get(codeCommitSnsMessageTypeHeader) != "" || strings.HasPrefix(request.UserAgent(), codeCommitApiDestinationsUserAgent):
+		return vcsutils.CodeCommit, nil
+	}
+	if username, _, ok := request.BasicAuth(); ok && username == azureReposBasicAuthUsername {
+		return vcsutils.AzureRepos, nil
+	}
+	return 0, errors.New("couldn't detect the VCS provider of the incoming webhook")
+}
+
+// detectBitbucketProvider distinguishes between Bitbucket Server and Bitbucket Cloud, which both send the X-Event-Key header
+func detectBitbucketProvider(header http.Header) vcsutils.VcsProvider {
+	if header.Get(bitbucketCloudHookUUIDHeader) != "" {
+		return vcsutils.BitbucketCloud
+	}
+	// Bitbucket Cloud sends this header as well if the webhook has a secret, but it is detected by its hook UUID header.
+	// GitHub sends this header as well, but it is detected by its event header.
+	if header.Get(sha256Signature) != "" {
+		return vcsutils.BitbucketServer
+	}
+	event := header.Get(EventHeaderKey)
+	if strings.HasPrefix(event, "pr:") || event == "repo:refs_changed" || event == bitbucketServerPingEvent {
+		return vcsutils.BitbucketServer
+	}
+	return vcsutils.BitbucketCloud
+}
@@ -1,6 +1,8 @@
 package webhookparser
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,7 +15,11 @@ func TestCreateWebhookParser(t *testing.T) {
 	assert.IsType(t, &gitLabWebhookParser{}, newParser(vcsutils.GitLab))
 	assert.IsType(t, &bitbucketServerWebhookParser{}, newParser(vcsutils.BitbucketServer))
 	assert.IsType(t, &bitbucketCloudWebhookParser{}, newParser(vcsutils.BitbucketCloud))
-	assert.Nil(t, newParser(5))
+	assert.IsType(t, &azureReposWebhookParser{}, newParser(vcsutils.AzureRepos))
+	assert.IsType(t, &giteaWebhookParser{}, newParser(vcsutils.Gitea))
+	assert.IsType(t, &codeCommitWebhookParser{}, newParser(vcsutils.CodeCommit))
+	assert.Nil(t, newParser(vcsutils.Gerrit))
+	assert.Nil(t, newParser(vcsutils.LocalGit))
 }
 
 func newParser(provider vcsutils.VcsProvider) webhookParser {
@@ -23,3 +29,51 @@ func newParser(provider vcsutils.VcsProvider) webhookParser {
 			VcsProvider: provider,
 		})
 }
+
+func TestDetectVcsProvider(t *testing.T) {
+	tests := []struct {
+		name             string
+		headers          map[string]string
+		basicAuth        []string
+		expectedProvider vcsutils.VcsProvider
+	}{
+		{name: "GitHub", headers: map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature": "sha1=abc"}, expectedProvider: vcsutils.GitHub},
+		{name: "GitHub signature only", headers: map[string]string{"X-Hub-Signature-256": "sha256=abc"}, expectedProvider: vcsutils.GitHub},
+		{name: "GitLab", headers: map[string]string{"X-Gitlab-Event": "Push Hook"}, expectedProvider: vcsutils.GitLab},
+		{name: "GitLab token only", headers: map[string]string{"X-Gitlab-Token": "abc123"}, expectedProvider: vcsutils.GitLab},
+		{name: "Bitbucket Server signed", headers: map[string]string{EventHeaderKey: "repo:modified", "X-Hub-Signature": "sha256=abc"}, expectedProvider: vcsutils.BitbucketServer},
+		{name: "Bitbucket Server pull request", headers: map[string]string{EventHeaderKey: "pr:opened"}, expectedProvider: vcsutils.BitbucketServer},
+		{name: "Bitbucket Server push", headers: map[string]string{EventHeaderKey: "repo:refs_changed"}, expectedProvider: vcsutils.BitbucketServer},
+		{name: "Bitbucket Server ping", headers: map[string]string{EventHeaderKey: "diagnostics:ping"}, expectedProvider: vcsutils.BitbucketServer},
+		{name: "Bitbucket Cloud", headers: map[string]string{EventHeaderKey: "repo:push", "X-Hook-UUID": "e8f2f4d3"}, expectedProvider: vcsutils.BitbucketCloud},
+		{name: "Bitbucket Cloud event only", headers: map[string]string{EventHeaderKey: "pullrequest:created"}, expectedProvider: vcsutils.BitbucketCloud},
+		{name: "Gitea", headers: map[string]string{"X-Gitea-Event": "push", "X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=abc"}, expectedProvider: vcsutils.Gitea},
+		{name: "AWS CodeCommit SNS", headers: map[string]string{"X-Amz-Sns-Message-Type": "Notification"}, basicAuth: []string{"frogger", "abc123"}, expectedProvider: vcsutils.CodeCommit},
+		{name: "AWS CodeCommit API destination", headers: map[string]string{"User-Agent": "Amazon/EventBridge/ApiDestinations"}, expectedProvider: vcsutils.CodeCommit},
+		{name: "Azure Repos", basicAuth: []string{azureReposBasicAuthUsername, "abc123"}, expectedProvider: vcsutils.AzureRepos},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", nil)
+			for key, value := range tt.headers {
+				request.Header.Set(key, value)
+			}
+			if tt.basicAuth != nil {
+				request.SetBasicAuth(tt.basicAuth[0], tt.basicAuth[1])
+			}
+			provider, err := DetectVcsProvider(request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedProvider, provider)
+		})
+	}
+}
+
+func TestDetectVcsProviderError(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", nil)
+	_, err := DetectVcsProvider(request)
+	assert.Error(t, err)
+
+	request.SetBasicAuth("user", "abc123")
+	_, err = DetectVcsProvider(request)
+	assert.Error(t, err)
+}
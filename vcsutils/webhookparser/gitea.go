@@ -0,0 +1,106 @@
+package webhookparser
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v56/github"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	// giteaEventHeader is the event type of the incoming webhook. Gitea sends the GitHub event headers as well.
+	giteaEventHeader = "X-Gitea-Event"
+	// giteaSignatureHeader is the hex encoded HMAC-SHA256 signature of the payload
+	giteaSignatureHeader = "X-Gitea-Signature"
+	// giteaDeliveryHeader is the unique ID of the webhook delivery
+	giteaDeliveryHeader = "X-Gitea-Delivery"
+)
+
+// giteaWebhookParser represents an incoming webhook on Gitea or Forgejo.
+// The payloads of Gitea follow the payloads of GitHub, hence they are parsed by the GitHub webhook parser.
+type giteaWebhookParser struct {
+	logger              vcsutils.Log
+	gitHubWebhookParser *gitHubWebhookParser
+}
+
+// newGiteaWebhookParser create a new giteaWebhookParser instance
+func newGiteaWebhookParser(logger vcsutils.Log, endpoint string) *giteaWebhookParser {
+	return &giteaWebhookParser{
+		logger:              logger,
+		gitHubWebhookParser: &gitHubWebhookParser{logger: logger, endpoint: endpoint},
+	}
+}
+
+func (webhook *giteaWebhookParser) validatePayload(_ context.Context, request *http.Request, token []byte) ([]byte, error) {
+	signature := request.Header.Get(giteaSignatureHeader)
+	if len(token) > 0 && len(signature) == 0 {
+		return nil, errors.New(giteaSignatureHeader + " header is missing")
+	}
+	payload := new(bytes.Buffer)
+	if _, err := payload.ReadFrom(request.Body); err != nil {
+		return nil, err
+	}
+	if len(token) > 0 && !hmac.Equal([]byte(signature), []byte(calculatePayloadSignature(payload.Bytes(), token))) {
+		return nil, errors.New("payload signature mismatch")
+	}
+	return payload.Bytes(), nil
+}
+
+func (webhook *giteaWebhookParser) deliveryDetails(request *http.Request, _ []byte) webhookDelivery {
+	return webhookDelivery{id: request.Header.Get(giteaDeliveryHeader)}
+}
+
+func (webhook *giteaWebhookParser) parseIncomingWebhook(_ context.Context, request *http.Request, payload []byte) (*WebhookInfo, error) {
+	event, err := github.ParseWebHook(request.Header.Get(giteaEventHeader), payload)
+	if err != nil {
+		return nil, err
+	}
+	switch event := event.(type) {
+	case *github.PushEvent:
+		// Unlike GitHub, Gitea doesn't report whether the ref was created or deleted
+		event.Created = vcsutils.PointerOf(event.GetBefore() == gitNilHash)
+		event.Deleted = vcsutils.PointerOf(event.GetAfter() == gitNilHash)
+		if webhook.gitHubWebhookParser.isTagEvent(event) {
+			return webhook.gitHubWebhookParser.parseTagEvent(event), nil
+		}
+		return webhook.gitHubWebhookParser.parseChangeEvent(event), nil
+	case *github.PullRequestEvent:
+		if event.GetAction() == "synchronized" {
+			info := webhook.gitHubWebhookParser.pullRequestWebhookInfo(event.GetPullRequest(), event.GetSender())
+			info.Event = vcsutils.PrEdited
+			return info, nil
+		}
+		return webhook.gitHubWebhookParser.parsePrEvents(event), nil
+	case *github.IssueCommentEvent:
+		// Gitea sends the comments on pull requests, including the comments on their diffs, as issue comments
+		return webhook.gitHubWebhookParser.parseIssueCommentEvent(event), nil
+	case *github.ReleaseEvent:
+		return webhook.gitHubWebhookParser.parseReleaseEvent(event), nil
+	case *github.DeleteEvent:
+		// Gitea sends a delete event instead of a push event when a branch is deleted
+		return webhook.parseDeleteEvent(event), nil
+	}
+	return nil, nil
+}
+
+func (webhook *giteaWebhookParser) parseDeleteEvent(event *github.DeleteEvent) *WebhookInfo {
+	if event.GetRefType() != "branch" {
+		// Deleted tags are reported by push events
+		return nil
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		TargetBranch: webhook.gitHubWebhookParser.trimRefPrefix(event.GetRef()),
+		Event:        vcsutils.BranchDeleted,
+		BranchStatus: WebhookInfoBranchStatusDeleted,
+		TriggeredBy:  webhook.gitHubWebhookParser.webhookUser(event.GetSender()),
+	}
+}
@@ -0,0 +1,172 @@
+package webhookparser
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+const (
+	giteaEndpoint     = "https://gitea.example.com"
+	giteaExpectedPrID = 2
+)
+
+func TestGiteaParseIncomingPushWebhook(t *testing.T) {
+	actual, err := parseGiteaTestPayload(t, "pushpayload.json", "push")
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, int64(1710232831), actual.Timestamp)
+	assert.Equal(t, WebhookInfoBranchStatusUpdated, actual.BranchStatus)
+	assert.Equal(t, "4f5a1c0a52e4c1f2e0e2d6d7e9b1c7f3a2e4b6d8", actual.Commit.Hash)
+	assert.Equal(t, "Add main.go\n", actual.Commit.Message)
+	assert.Equal(t, "9e2b1d3a7c6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d", actual.BeforeCommit.Hash)
+	assert.Equal(t, WebHookInfoUser{Login: expectedOwner, DisplayName: "Yahav Itzhak", Email: "yahavi@example.com"}, actual.Author)
+	assert.Equal(t, giteaEndpoint+"/yahavi/hello-world/compare/9e2b1d3a7c6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d...4f5a1c0a52e4c1f2e0e2d6d7e9b1c7f3a2e4b6d8", actual.CompareUrl)
+	assert.Len(t, actual.Push.Commits, 1)
+	assert.Equal(t, []WebHookInfoFile{{Path: "main.go"}}, actual.Push.Commits[0].Added)
+	assert.Equal(t, []WebHookInfoFile{{Path: "README.md"}}, actual.Push.Commits[0].Modified)
+}
+
+func TestGiteaParseIncomingWebhookTagEvents(t *testing.T) {
+	tests := []struct {
+		payloadFilename   string
+		expectedEventType vcsutils.WebhookEvent
+	}{
+		{payloadFilename: "tagcreatepayload.json", expectedEventType: vcsutils.TagPushed},
+		{payloadFilename: "tagdeletepayload.json", expectedEventType: vcsutils.TagRemoved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payloadFilename, func(t *testing.T) {
+			actual, err := parseGiteaTestPayload(t, tt.payloadFilename, "push")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, "v1.0.0", actual.Tag.Name)
+			assert.Equal(t, "b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8", actual.Tag.Hash)
+			assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.Tag.Repository)
+		})
+	}
+}
+
+func TestGiteaParseIncomingBranchDeleteWebhook(t *testing.T) {
+	actual, err := parseGiteaTestPayload(t, "branchdeletepayload.json", "delete")
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+	assert.Equal(t, WebhookInfoBranchStatusDeleted, actual.BranchStatus)
+	assert.Equal(t, expectedOwner, actual.TriggeredBy.Login)
+}
+
+func TestGiteaParseIncomingPrWebhook(t *testing.T) {
+	tests := []struct {
+		payloadFilename   string
+		expectedEventType vcsutils.WebhookEvent
+		expectedTime      int64
+	}{
+		{payloadFilename: "propenpayload.json", expectedEventType: vcsutils.PrOpened, expectedTime: 1710234902},
+		{payloadFilename: "prsyncpayload.json", expectedEventType: vcsutils.PrEdited, expectedTime: 1710235818},
+		{payloadFilename: "prmergepayload.json", expectedEventType: vcsutils.PrMerged, expectedTime: 1710237771},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payloadFilename, func(t *testing.T) {
+			actual, err := parseGiteaTestPayload(t, tt.payloadFilename, "pull_request")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedEventType, actual.Event)
+			assert.Equal(t, giteaExpectedPrID, actual.PullRequestId)
+			assert.Equal(t, tt.expectedTime, actual.Timestamp)
+			assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+			assert.Equal(t, expectedBranch, actual.TargetBranch)
+			assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+			assert.Equal(t, "Update README", actual.PullRequest.Title)
+			assert.Equal(t, "omerzi", actual.PullRequest.Author.Login)
+			assert.Equal(t, "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b", actual.PullRequest.SourceHash)
+			assert.Equal(t, []string{"documentation"}, actual.PullRequest.Labels)
+		})
+	}
+}
+
+func TestGiteaParseIncomingCommentWebhook(t *testing.T) {
+	actual, err := parseGiteaTestPayload(t, "prcommentpayload.json", "issue_comment")
+	assert.NoError(t, err)
+	assert.Equal(t, vcsutils.PrCommentCreated, actual.Event)
+	assert.Equal(t, giteaExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, int64(41), actual.Comment.ID)
+	assert.Equal(t, "Looks good", actual.Comment.Body)
+	assert.Equal(t, expectedOwner, actual.Comment.Author.Login)
+	assert.Equal(t, "Update README", actual.PullRequest.Title)
+
+	actual, err = parseGiteaTestPayload(t, "issuecommentpayload.json", "issue_comment")
+	assert.NoError(t, err)
+	assert.Equal(t, vcsutils.IssueCommentCreated, actual.Event)
+	assert.Nil(t, actual.PullRequest)
+	assert.Equal(t, 3, actual.Comment.IssueID)
+	assert.Equal(t, "Fixed in main", actual.Comment.Body)
+}
+
+func TestGiteaParseIncomingReleaseWebhook(t *testing.T) {
+	actual, err := parseGiteaTestPayload(t, "releasepublishpayload.json", "release")
+	assert.NoError(t, err)
+	assert.Equal(t, vcsutils.ReleasePublished, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, &WebhookInfoRelease{
+		ID:        5,
+		Name:      "First release",
+		TagName:   "v1.0.0",
+		Body:      "Initial release",
+		Url:       giteaEndpoint + "/yahavi/hello-world/releases/tag/v1.0.0",
+		Timestamp: 1710238800,
+		Author:    WebHookInfoUser{Login: expectedOwner, AvatarUrl: giteaEndpoint + "/avatars/1"},
+	}, actual.Release)
+}
+
+func TestGiteaParseIncomingWebhookUnsupportedEvent(t *testing.T) {
+	actual, err := parseGiteaTestPayload(t, "branchdeletepayload.json", "fork")
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestGiteaPayloadSignature(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitea", "pushpayload.json"))
+	assert.NoError(t, err)
+	parse := func(signature string) error {
+		request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", bytes.NewReader(payload))
+		request.Header.Add(giteaEventHeader, "push")
+		if signature != "" {
+			request.Header.Add(giteaSignatureHeader, signature)
+		}
+		_, err := ParseIncomingWebhook(context.Background(), vcsutils.EmptyLogger{}, WebhookOrigin{VcsProvider: vcsutils.Gitea, Token: token}, request)
+		return err
+	}
+	assert.EqualError(t, parse(""), giteaSignatureHeader+" header is missing")
+	assert.EqualError(t, parse("wrongsignature"), "payload signature mismatch")
+	// Gitea signs the payload without the "sha256=" prefix
+	assert.EqualError(t, parse("sha256="+calculatePayloadSignature(payload, token)), "payload signature mismatch")
+	assert.NoError(t, parse(calculatePayloadSignature(payload, token)))
+}
+
+func parseGiteaTestPayload(t *testing.T, payloadFilename, event string) (*WebhookInfo, error) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitea", payloadFilename))
+	assert.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", bytes.NewReader(payload))
+	request.Header.Add("content-type", "application/json")
+	request.Header.Add(giteaEventHeader, event)
+	request.Header.Add(giteaSignatureHeader, calculatePayloadSignature(payload, token))
+	return ParseIncomingWebhook(context.Background(), vcsutils.EmptyLogger{}, WebhookOrigin{
+		VcsProvider: vcsutils.Gitea,
+		OriginURL:   giteaEndpoint,
+		Token:       token,
+	}, request)
+}
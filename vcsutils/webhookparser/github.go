@@ -50,6 +50,10 @@ func (webhook *gitHubWebhookParser) validatePayload(_ context.Context, request *
 	return payload, nil
 }
 
+func (webhook *gitHubWebhookParser) deliveryDetails(request *http.Request, _ []byte) webhookDelivery {
+	return webhookDelivery{id: github.DeliveryID(request)}
+}
+
 func (webhook *gitHubWebhookParser) parseIncomingWebhook(_ context.Context, request *http.Request, payload []byte) (*WebhookInfo, error) {
 	event, err := github.ParseWebHook(github.WebHookType(request), payload)
 	if err != nil {
@@ -63,6 +67,14 @@ func (webhook *gitHubWebhookParser) parseIncomingWebhook(_ context.Context, requ
 		return webhook.parseChangeEvent(event), nil
 	case *github.PullRequestEvent:
 		return webhook.parsePrEvents(event), nil
+	case *github.IssueCommentEvent:
+		return webhook.parseIssueCommentEvent(event), nil
+	case *github.PullRequestReviewCommentEvent:
+		return webhook.parsePrReviewCommentEvent(event), nil
+	case *github.ReleaseEvent:
+		return webhook.parseReleaseEvent(event), nil
+	case *github.PingEvent:
+		return webhook.parsePingEvent(event), nil
 	}
 	return nil, nil
 }
@@ -98,11 +110,12 @@ func (webhook *gitHubWebhookParser) parseChangeEvent(event *github.PushEvent) *W
 		compareURL = fmt.Sprintf("%s/%s/%s/compare/%s...%s", webhook.endpoint, repoDetails.Owner, repoDetails.Name,
 			event.GetBefore(), event.GetAfter())
 	}
+	status := webhook.branchStatus(event)
 	return &WebhookInfo{
 		TargetRepositoryDetails: repoDetails,
 		TargetBranch:            webhook.trimRefPrefix(event.GetRef()),
 		Timestamp:               event.GetHeadCommit().GetTimestamp().UTC().Unix(),
-		Event:                   vcsutils.Push,
+		Event:                   pushEvent(status),
 		Commit: WebHookInfoCommit{
 			Hash:    event.GetAfter(),
 			Message: vcsutils.DefaultIfNotNil(vcsutils.DefaultIfNotNil(event.HeadCommit).Message),
@@ -111,12 +124,31 @@ func (webhook *gitHubWebhookParser) parseChangeEvent(event *github.PushEvent) *W
 		BeforeCommit: WebHookInfoCommit{
 			Hash: event.GetBefore(),
 		},
-		BranchStatus: webhook.branchStatus(event),
+		BranchStatus: status,
 		TriggeredBy:  webhook.user(event.Pusher),
 		Committer:    webhook.commitAuthor(vcsutils.DefaultIfNotNil(event.HeadCommit).Committer),
 		Author:       webhook.commitAuthor(vcsutils.DefaultIfNotNil(event.HeadCommit).Author),
 		CompareUrl:   compareURL,
+		Push:         webhook.parsePushCommits(event),
+	}
+}
+
+func (webhook *gitHubWebhookParser) parsePushCommits(event *github.PushEvent) *WebhookInfoPush {
+	push := &WebhookInfoPush{}
+	for _, commit := range event.Commits {
+		push.Commits = append(push.Commits, WebhookInfoPushCommit{
+			Hash:      commit.GetID(),
+			Message:   commit.GetMessage(),
+			Url:       commit.GetURL(),
+			Timestamp: commit.GetTimestamp().UTC().Unix(),
+			Author:    webhook.commitAuthor(commit.Author),
+			Committer: webhook.commitAuthor(commit.Committer),
+			Added:     webhookInfoFiles(commit.Added),
+			Modified:  webhookInfoFiles(commit.Modified),
+			Removed:   webhookInfoFiles(commit.Removed),
+		})
 	}
+	return push
 }
 
 func (webhook *gitHubWebhookParser) trimRefPrefix(ref string) string {
@@ -150,8 +182,10 @@ func (webhook *gitHubWebhookParser) commitAuthor(u *github.CommitAuthor) WebHook
 func (webhook *gitHubWebhookParser) parsePrEvents(event *github.PullRequestEvent) *WebhookInfo {
 	var webhookEvent vcsutils.WebhookEvent
 	switch event.GetAction() {
-	case "opened", "reopened":
+	case "opened":
 		webhookEvent = vcsutils.PrOpened
+	case "reopened":
+		webhookEvent = vcsutils.PrReopened
 	case "synchronize", "edited":
 		webhookEvent = vcsutils.PrEdited
 	case "closed":
@@ -160,7 +194,13 @@ func (webhook *gitHubWebhookParser) parsePrEvents(event *github.PullRequestEvent
 		// Action is not supported
 		return nil
 	}
-	pullRequest := event.GetPullRequest()
+	info := webhook.pullRequestWebhookInfo(event.GetPullRequest(), event.GetSender())
+	info.Event = webhookEvent
+	return info
+}
+
+// pullRequestWebhookInfo returns the details of a pull request, which are common to the pull request and the pull request comment events
+func (webhook *gitHubWebhookParser) pullRequestWebhookInfo(pullRequest *github.PullRequest, sender *github.User) *WebhookInfo {
 	targetRepository := WebHookInfoRepoDetails{
 		Name:  pullRequest.GetBase().GetRepo().GetName(),
 		Owner: pullRequest.GetBase().GetRepo().GetOwner().GetLogin(),
@@ -176,8 +216,6 @@ func (webhook *gitHubWebhookParser) parsePrEvents(event *github.PullRequestEvent
 		SourceRepositoryDetails: sourceRepository,
 		SourceBranch:            pullRequest.GetHead().GetRef(),
 		Timestamp:               pullRequest.GetUpdatedAt().UTC().Unix(),
-		Event:                   webhookEvent,
-
 		PullRequest: &WebhookInfoPullRequest{
 			ID:         pullRequest.GetNumber(),
 			Title:      pullRequest.GetTitle(),
@@ -190,8 +228,8 @@ func (webhook *gitHubWebhookParser) parsePrEvents(event *github.PullRequestEvent
 				AvatarUrl:   pullRequest.GetUser().GetAvatarURL(),
 			},
 			TriggeredBy: WebHookInfoUser{
-				Login:     event.GetSender().GetLogin(),
-				AvatarUrl: event.GetSender().GetAvatarURL(),
+				Login:     sender.GetLogin(),
+				AvatarUrl: sender.GetAvatarURL(),
 			},
 			TargetRepository: targetRepository,
 			TargetBranch:     pullRequest.GetBase().GetRef(),
@@ -199,7 +237,155 @@ func (webhook *gitHubWebhookParser) parsePrEvents(event *github.PullRequestEvent
 			SourceRepository: sourceRepository,
 			SourceBranch:     pullRequest.GetHead().GetRef(),
 			SourceHash:       pullRequest.GetHead().GetSHA(),
+			Labels:           webhook.labels(pullRequest.Labels),
+		},
+	}
+}
+
+func (webhook *gitHubWebhookParser) labels(labels []*github.Label) []string {
+	var names []string
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+// parseIssueCommentEvent parses a comment on an issue. GitHub sends the comments on pull requests as issue comments as well.
+func (webhook *gitHubWebhookParser) parseReleaseEvent(event *github.ReleaseEvent) *WebhookInfo {
+	var webhookEvent vcsutils.WebhookEvent
+	switch event.GetAction() {
+	case "created":
+		webhookEvent = vcsutils.ReleaseCreated
+	case "published":
+		webhookEvent = vcsutils.ReleasePublished
+	default:
+		// Action is not supported
+		return nil
+	}
+	release := event.GetRelease()
+	// Draft releases are not published yet
+	timestamp := release.GetCreatedAt().UTC().Unix()
+	if release.PublishedAt != nil {
+		timestamp = release.GetPublishedAt().UTC().Unix()
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		Timestamp: timestamp,
+		Event:     webhookEvent,
+		Release: &WebhookInfoRelease{
+			ID:         release.GetID(),
+			Name:       release.GetName(),
+			TagName:    release.GetTagName(),
+			Body:       release.GetBody(),
+			Url:        release.GetHTMLURL(),
+			Timestamp:  timestamp,
+			Author:     webhook.webhookUser(release.GetAuthor()),
+			Draft:      release.GetDraft(),
+			Prerelease: release.GetPrerelease(),
+		},
+	}
+}
+
+func (webhook *gitHubWebhookParser) parsePingEvent(event *github.PingEvent) *WebhookInfo {
+	return &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{
+			Name:  event.GetRepo().GetName(),
+			Owner: event.GetRepo().GetOwner().GetLogin(),
+		},
+		Event: vcsutils.Ping,
+	}
+}
+
+func (webhook *gitHubWebhookParser) parseIssueCommentEvent(event *github.IssueCommentEvent) *WebhookInfo {
+	issue := event.GetIssue()
+	repository := WebHookInfoRepoDetails{
+		Name:  event.GetRepo().GetName(),
+		Owner: event.GetRepo().GetOwner().GetLogin(),
+	}
+	comment := event.GetComment()
+	commentInfo := &WebhookInfoComment{
+		ID:        comment.GetID(),
+		Body:      comment.GetBody(),
+		Url:       comment.GetHTMLURL(),
+		Timestamp: comment.GetUpdatedAt().UTC().Unix(),
+		Author:    webhook.webhookUser(comment.GetUser()),
+	}
+	if !issue.IsPullRequest() {
+		if event.GetAction() != "created" {
+			// Action is not supported
+			return nil
+		}
+		commentInfo.IssueID = issue.GetNumber()
+		return &WebhookInfo{
+			TargetRepositoryDetails: repository,
+			Timestamp:               commentInfo.Timestamp,
+			Event:                   vcsutils.IssueCommentCreated,
+			Comment:                 commentInfo,
+		}
+	}
+	webhookEvent := webhook.resolveCommentEventType(event.GetAction())
+	if webhookEvent == "" {
+		return nil
+	}
+	// The issue of a pull request doesn't contain its branches
+	return &WebhookInfo{
+		PullRequestId:           issue.GetNumber(),
+		TargetRepositoryDetails: repository,
+		Timestamp:               commentInfo.Timestamp,
+		Event:                   webhookEvent,
+		PullRequest: &WebhookInfoPullRequest{
+			ID:               issue.GetNumber(),
+			Title:            issue.GetTitle(),
+			CompareUrl:       issue.GetHTMLURL() + "/files",
+			Timestamp:        issue.GetUpdatedAt().Unix(),
+			Author:           webhook.webhookUser(issue.GetUser()),
+			TriggeredBy:      webhook.webhookUser(event.GetSender()),
+			TargetRepository: repository,
+			Labels:           webhook.labels(issue.Labels),
 		},
+		Comment: commentInfo,
+	}
+}
+
+// parsePrReviewCommentEvent parses a comment on the diff of a pull request
+func (webhook *gitHubWebhookParser) parsePrReviewCommentEvent(event *github.PullRequestReviewCommentEvent) *WebhookInfo {
+	webhookEvent := webhook.resolveCommentEventType(event.GetAction())
+	if webhookEvent == "" {
+		return nil
+	}
+	comment := event.GetComment()
+	info := webhook.pullRequestWebhookInfo(event.GetPullRequest(), event.GetSender())
+	info.Event = webhookEvent
+	info.Timestamp = comment.GetUpdatedAt().UTC().Unix()
+	info.Comment = &WebhookInfoComment{
+		ID:        comment.GetID(),
+		Body:      comment.GetBody(),
+		Url:       comment.GetHTMLURL(),
+		Timestamp: info.Timestamp,
+		Author:    webhook.webhookUser(comment.GetUser()),
+	}
+	return info
+}
+
+func (webhook *gitHubWebhookParser) resolveCommentEventType(action string) vcsutils.WebhookEvent {
+	switch action {
+	case "created":
+		return vcsutils.PrCommentCreated
+	case "edited":
+		return vcsutils.PrCommentEdited
+	default:
+		// Action is not supported
+		return ""
+	}
+}
+
+func (webhook *gitHubWebhookParser) webhookUser(u *github.User) WebHookInfoUser {
+	return WebHookInfoUser{
+		Login:     u.GetLogin(),
+		AvatarUrl: u.GetAvatarURL(),
 	}
 }
 
@@ -207,7 +393,7 @@ func (webhook *gitHubWebhookParser) resolveClosedEventType(event *github.PullReq
 	if event.GetPullRequest().GetMerged() {
 		return vcsutils.PrMerged
 	}
-	return vcsutils.PrRejected
+	return vcsutils.PrClosed
 }
 
 func (webhook *gitHubWebhookParser) branchStatus(event *github.PushEvent) WebHookInfoBranchStatus {
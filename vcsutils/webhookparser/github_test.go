@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-github/v56/github"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/jfrog/froggit-go/vcsutils"
@@ -39,7 +40,21 @@ const (
 	githubPrMergeExpectedTime = int64(1638805994)
 	githubTagPushSha256       = "38e8a96afe9fce748694cb2e634566243fb9c6e086c2eafe9c35f0b5bafea1b4"
 	githubTagDeleteSha256     = "dceff78c506536b305a3088a888db7d89323f05ead7d7b7f0348054de7fd7ec1"
-	gitHubExpectedPrID        = 2
+	githubBranchDeleteSha256  = "00b3cebc5b54523f2e5e552152777a51c2cb958c7646855e3db9fe04ba6e1d9a"
+	githubPingSha256          = "840f4f213a2ae11b59016cf4c7b9b5ed301835090ed1841d687da28b959c25d3"
+	// Release events
+	githubReleaseCreateSha256        = "4e4a28b1e267592e2f04a8e86e2d8d538a32328068500f5a6fb22a386dbeb345"
+	githubReleaseCreateExpectedTime  = int64(1634030072)
+	githubReleasePublishSha256       = "6d62a05b4d17f986f806060aacd54521a535b0a1a467a10653c413088af69ed7"
+	githubReleasePublishExpectedTime = int64(1634030411)
+	// Comment events
+	githubPrCommentSha256             = "e8834ea2a4ede706e7693fbe3dc885a638f12e9fd7b6f12a2b8a5ccbfcde2e4e"
+	githubPrCommentExpectedTime       = int64(1638806712)
+	githubIssueCommentSha256          = "67b397d2e3ecf402d054bba63f04a5a7a0b34a3112f5351db9feaf4a547ce078"
+	githubIssueCommentExpectedTime    = int64(1638806832)
+	githubPrReviewCommentSha256       = "cac4a9a6dd1794fd43278a1df960b2ba534fd7851641dc8987abf0190b156c84"
+	githubPrReviewCommentExpectedTime = int64(1638806952)
+	gitHubExpectedPrID                = 2
 )
 
 func TestGitHubParseIncomingPushWebhook(t *testing.T) {
@@ -81,6 +96,15 @@ func TestGitHubParseIncomingPushWebhook(t *testing.T) {
 	}, actual.BeforeCommit)
 	assert.Equal(t, WebhookInfoBranchStatusUpdated, actual.BranchStatus)
 	assert.Equal(t, "https://github.com/yahavi/hello-world/compare/a82aa1b065b4fa17db4b7a055109044be377ddf7...9d497bd67a395a8063774f200338769ccbcee916", actual.CompareUrl)
+	assert.Equal(t, &WebhookInfoPush{Commits: []WebhookInfoPushCommit{{
+		Hash:      "9d497bd67a395a8063774f200338769ccbcee916",
+		Message:   "Update README.md",
+		Url:       "https://github.com/yahavi/hello-world/commit/9d497bd67a395a8063774f200338769ccbcee916",
+		Timestamp: githubPushExpectedTime,
+		Author:    WebHookInfoUser{Login: "yahavi", DisplayName: "Yahav Itzhak", Email: "yahavi@users.noreply.github.com"},
+		Committer: WebHookInfoUser{Login: "web-flow", DisplayName: "GitHub", Email: "noreply@github.com"},
+		Modified:  []WebHookInfoFile{{Path: "README.md"}},
+	}}}, actual.Push)
 }
 
 func TestGithubParseIncomingPrWebhook(t *testing.T) {
@@ -130,7 +154,7 @@ func TestGithubParseIncomingPrWebhook(t *testing.T) {
 			payloadFilename:   "prreopenpayload",
 			payloadSha:        githubPrReopenSha256,
 			expectedTime:      githubPrReopenExpectedTime,
-			expectedEventType: vcsutils.PrOpened,
+			expectedEventType: vcsutils.PrReopened,
 			expectedPullRequestInfo: &WebhookInfoPullRequest{
 				ID:         2,
 				Title:      "Update+README.md+now",
@@ -229,7 +253,7 @@ func TestGithubParseIncomingPrWebhook(t *testing.T) {
 			payloadFilename:   "prclosepayload",
 			payloadSha:        githubPrCloseSha256,
 			expectedTime:      githubPrCloseExpectedTime,
-			expectedEventType: vcsutils.PrRejected,
+			expectedEventType: vcsutils.PrClosed,
 			expectedPullRequestInfo: &WebhookInfoPullRequest{
 				ID:         2,
 				Title:      "Update+README.md+now",
@@ -399,6 +423,183 @@ func TestGitHubParseIncomingWebhookTagEvents(t *testing.T) {
 	}
 }
 
+func TestGitHubParseIncomingBranchDeleteWebhook(t *testing.T) {
+	actual, err := parseGitHubJsonTestPayload(t, "branchdeletepayload.json", githubBranchDeleteSha256, "push")
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+	assert.Equal(t, WebHookInfoCommit{Hash: "9d497bd67a395a8063774f200338769ccbcee916"}, actual.BeforeCommit)
+	assert.Equal(t, WebhookInfoBranchStatusDeleted, actual.BranchStatus)
+	assert.Empty(t, actual.Push.Commits)
+}
+
+func TestGitHubParseIncomingReleaseWebhook(t *testing.T) {
+	expectedRelease := WebhookInfoRelease{
+		ID:      51258424,
+		Name:    "Hello World 1.0.0",
+		TagName: "v1.0.0",
+		Body:    "The first release of hello-world",
+		Url:     "https://github.com/yahavi/hello-world/releases/tag/v1.0.0",
+		Author:  WebHookInfoUser{Login: "yahavi", AvatarUrl: "https://avatars.githubusercontent.com/u/11367982?v=4"},
+	}
+	tests := []struct {
+		name              string
+		payloadFilename   string
+		payloadSha        string
+		expectedEventType vcsutils.WebhookEvent
+		expectedTime      int64
+		expectedDraft     bool
+	}{
+		{
+			name:              "created",
+			payloadFilename:   "releasecreatepayload.json",
+			payloadSha:        githubReleaseCreateSha256,
+			expectedEventType: vcsutils.ReleaseCreated,
+			expectedTime:      githubReleaseCreateExpectedTime,
+			expectedDraft:     true,
+		},
+		{
+			name:              "published",
+			payloadFilename:   "releasepublishpayload.json",
+			payloadSha:        githubReleasePublishSha256,
+			expectedEventType: vcsutils.ReleasePublished,
+			expectedTime:      githubReleasePublishExpectedTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseGitHubJsonTestPayload(t, tt.payloadFilename, tt.payloadSha, "release")
+			assert.NoError(t, err)
+
+			release := expectedRelease
+			release.Timestamp = tt.expectedTime
+			release.Draft = tt.expectedDraft
+			assert.Equal(t, &WebhookInfo{
+				TargetRepositoryDetails: WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner},
+				Timestamp:               tt.expectedTime,
+				Event:                   tt.expectedEventType,
+				Release:                 &release,
+			}, actual)
+		})
+	}
+}
+
+func TestGitHubParseIncomingPingWebhook(t *testing.T) {
+	actual, err := parseGitHubJsonTestPayload(t, "pingpayload.json", githubPingSha256, "ping")
+	assert.NoError(t, err)
+
+	assert.Equal(t, &WebhookInfo{
+		TargetRepositoryDetails: WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner},
+		Event:                   vcsutils.Ping,
+	}, actual)
+}
+
+func TestGitHubParseIncomingCommentWebhook(t *testing.T) {
+	expectedUser := WebHookInfoUser{Login: "yahavi", AvatarUrl: "https://avatars.githubusercontent.com/u/11367982?v=4"}
+	expectedRepository := WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}
+	tests := []struct {
+		name            string
+		payloadFilename string
+		payloadSha      string
+		eventHeader     string
+		expected        *WebhookInfo
+	}{
+		{
+			name:            "pull request comment",
+			payloadFilename: "prcommentpayload.json",
+			payloadSha:      githubPrCommentSha256,
+			eventHeader:     "issue_comment",
+			expected: &WebhookInfo{
+				PullRequestId:           gitHubExpectedPrID,
+				TargetRepositoryDetails: expectedRepository,
+				Timestamp:               githubPrCommentExpectedTime,
+				Event:                   vcsutils.PrCommentCreated,
+				PullRequest: &WebhookInfoPullRequest{
+					ID:               gitHubExpectedPrID,
+					Title:            "Update README.md",
+					CompareUrl:       "https://github.com/yahavi/hello-world/pull/2/files",
+					Timestamp:        githubPrCommentExpectedTime,
+					Author:           expectedUser,
+					TriggeredBy:      expectedUser,
+					TargetRepository: expectedRepository,
+				},
+				Comment: &WebhookInfoComment{
+					ID:        987654321,
+					Body:      "rescan",
+					Url:       "https://github.com/yahavi/hello-world/pull/2#issuecomment-987654321",
+					Timestamp: githubPrCommentExpectedTime,
+					Author:    expectedUser,
+				},
+			},
+		},
+		{
+			name:            "issue comment",
+			payloadFilename: "issuecommentpayload.json",
+			payloadSha:      githubIssueCommentSha256,
+			eventHeader:     "issue_comment",
+			expected: &WebhookInfo{
+				TargetRepositoryDetails: expectedRepository,
+				Timestamp:               githubIssueCommentExpectedTime,
+				Event:                   vcsutils.IssueCommentCreated,
+				Comment: &WebhookInfoComment{
+					ID:        987654322,
+					Body:      "Thanks for the report",
+					Url:       "https://github.com/yahavi/hello-world/issues/3#issuecomment-987654322",
+					Timestamp: githubIssueCommentExpectedTime,
+					Author:    expectedUser,
+					IssueID:   3,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseGitHubJsonTestPayload(t, tt.payloadFilename, tt.payloadSha, tt.eventHeader)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestGitHubParseIncomingPrReviewCommentWebhook(t *testing.T) {
+	actual, err := parseGitHubJsonTestPayload(t, "prreviewcommentpayload.json", githubPrReviewCommentSha256, "pull_request_review_comment")
+	assert.NoError(t, err)
+
+	assert.Equal(t, gitHubExpectedPrID, actual.PullRequestId)
+	assert.Equal(t, expectedBranch, actual.TargetBranch)
+	assert.Equal(t, expectedSourceBranch, actual.SourceBranch)
+	assert.Equal(t, githubPrReviewCommentExpectedTime, actual.Timestamp)
+	assert.Equal(t, vcsutils.PrCommentEdited, actual.Event)
+	assert.Equal(t, "c0e22e5ac1277cc24575882e4ca2407f739ae886", actual.PullRequest.SourceHash)
+	assert.Equal(t, &WebhookInfoComment{
+		ID:        761234567,
+		Body:      "Please fix this line",
+		Url:       "https://github.com/yahavi/hello-world/pull/2#discussion_r761234567",
+		Timestamp: githubPrReviewCommentExpectedTime,
+		Author:    WebHookInfoUser{Login: "yahavi", AvatarUrl: "https://avatars.githubusercontent.com/u/11367982?v=4"},
+	}, actual.Comment)
+}
+
+// parseGitHubJsonTestPayload parses a JSON payload from the testdata
+func parseGitHubJsonTestPayload(t *testing.T, payloadFilename, payloadSha, eventHeader string) (*WebhookInfo, error) {
+	reader, err := os.Open(filepath.Join("testdata", "github", payloadFilename))
+	assert.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+	request.Header.Add("content-type", "application/json")
+	request.Header.Add(githubSha256Header, "sha256="+payloadSha)
+	request.Header.Add(githubEventHeader, eventHeader)
+	return ParseIncomingWebhook(context.Background(),
+		vcsutils.EmptyLogger{},
+		WebhookOrigin{
+			VcsProvider: vcsutils.GitHub,
+			Token:       token,
+		}, request)
+}
+
 func TestGitHubParseIncomingWebhookError(t *testing.T) {
 	request := &http.Request{}
 	_, err := ParseIncomingWebhook(context.Background(),
@@ -415,6 +616,17 @@ func TestGitHubParseIncomingWebhookError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGitHubParsePrEventsLabels(t *testing.T) {
+	webhook := gitHubWebhookParser{}
+	actual := webhook.parsePrEvents(&github.PullRequestEvent{
+		Action: github.String("opened"),
+		PullRequest: &github.PullRequest{
+			Labels: []*github.Label{{Name: github.String("security")}, {Name: github.String("dependencies")}},
+		},
+	})
+	assert.Equal(t, []string{"security", "dependencies"}, actual.PullRequest.Labels)
+}
+
 func TestGitHubParsePrEventsError(t *testing.T) {
 	webhook := gitHubWebhookParser{}
 	assert.Nil(t, webhook.parsePrEvents(nil))
@@ -3,6 +3,8 @@ package webhookparser
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
@@ -13,7 +15,13 @@ import (
 	"github.com/jfrog/froggit-go/vcsutils"
 )
 
-const gitLabKeyHeader = "X-GitLab-Token"
+const (
+	gitLabKeyHeader   = "X-GitLab-Token"
+	gitLabEventHeader = "X-GitLab-Event"
+	// gitLabEventUUIDHeader is the unique ID of the webhook event
+	gitLabEventUUIDHeader = "X-Gitlab-Event-UUID"
+	gitLabTimeFormat      = "2006-01-02 15:04:05 MST"
+)
 
 // gitLabWebhookParser represents an incoming webhook on GitLab
 type gitLabWebhookParser struct {
@@ -30,7 +38,7 @@ func newGitLabWebhookParser(logger vcsutils.Log) *gitLabWebhookParser {
 func (webhook *gitLabWebhookParser) validatePayload(_ context.Context, request *http.Request, token []byte) ([]byte, error) {
 	actualToken := request.Header.Get(gitLabKeyHeader)
 	if len(token) != 0 || len(actualToken) > 0 {
-		if actualToken != string(token) {
+		if !hmac.Equal([]byte(actualToken), token) {
 			return nil, errors.New("token mismatch")
 		}
 	}
@@ -41,6 +49,11 @@ func (webhook *gitLabWebhookParser) validatePayload(_ context.Context, request *
 	}
 	return payload.Bytes(), nil
 }
+
+func (webhook *gitLabWebhookParser) deliveryDetails(request *http.Request, _ []byte) webhookDelivery {
+	return webhookDelivery{id: request.Header.Get(gitLabEventUUIDHeader)}
+}
+
 func (webhook *gitLabWebhookParser) parseIncomingWebhook(_ context.Context, request *http.Request, payload []byte) (*WebhookInfo, error) {
 	event, err := gitlab.ParseWebhook(gitlab.WebhookEventType(request), payload)
 	if err != nil {
@@ -53,6 +66,12 @@ func (webhook *gitLabWebhookParser) parseIncomingWebhook(_ context.Context, requ
 		return webhook.parsePrEvents(event)
 	case *gitlab.TagEvent:
 		return webhook.parseTagEvents(event)
+	case *gitlab.MergeCommentEvent:
+		return webhook.parseMergeCommentEvent(event, payload)
+	case *gitlab.IssueCommentEvent:
+		return webhook.parseIssueCommentEvent(event, payload)
+	case *gitlab.ReleaseEvent:
+		return webhook.parseReleaseEvent(event)
 	}
 	return nil, nil
 }
@@ -63,11 +82,12 @@ func (webhook *gitLabWebhookParser) parsePushEvent(event *gitlab.PushEvent) *Web
 		localTimestamp = event.Commits[0].Timestamp.Local().Unix()
 	}
 	lastCommit := vcsutils.DefaultIfNotNil(webhook.getLastCommit(event))
+	status := webhook.branchStatus(event)
 	return &WebhookInfo{
 		TargetRepositoryDetails: webhook.parseRepoDetails(event.Project.PathWithNamespace),
 		TargetBranch:            strings.TrimPrefix(event.Ref, "refs/heads/"),
 		Timestamp:               localTimestamp,
-		Event:                   vcsutils.Push,
+		Event:                   pushEvent(status),
 		Commit: WebHookInfoCommit{
 			Hash:    event.After,
 			Message: lastCommit.Message,
@@ -76,7 +96,7 @@ func (webhook *gitLabWebhookParser) parsePushEvent(event *gitlab.PushEvent) *Web
 		BeforeCommit: WebHookInfoCommit{
 			Hash: event.Before,
 		},
-		BranchStatus: webhook.branchStatus(event),
+		BranchStatus: status,
 		TriggeredBy: WebHookInfoUser{
 			Login:       event.UserUsername,
 			Email:       event.UserEmail,
@@ -91,9 +111,34 @@ func (webhook *gitLabWebhookParser) parsePushEvent(event *gitlab.PushEvent) *Web
 			DisplayName: lastCommit.Author.Name,
 			Email:       lastCommit.Author.Email,
 		},
+		Push: webhook.parsePushCommits(event),
 	}
 }
 
+func (webhook *gitLabWebhookParser) parsePushCommits(event *gitlab.PushEvent) *WebhookInfoPush {
+	push := &WebhookInfoPush{}
+	for _, commit := range event.Commits {
+		var timestamp int64
+		if commit.Timestamp != nil {
+			timestamp = commit.Timestamp.UTC().Unix()
+		}
+		push.Commits = append(push.Commits, WebhookInfoPushCommit{
+			Hash:      commit.ID,
+			Message:   commit.Message,
+			Url:       commit.URL,
+			Timestamp: timestamp,
+			Author: WebHookInfoUser{
+				DisplayName: commit.Author.Name,
+				Email:       commit.Author.Email,
+			},
+			Added:    webhookInfoFiles(commit.Added),
+			Modified: webhookInfoFiles(commit.Modified),
+			Removed:  webhookInfoFiles(commit.Removed),
+		})
+	}
+	return push
+}
+
 func (webhook *gitLabWebhookParser) getLastCommit(event *gitlab.PushEvent) *struct {
 	ID        string     `json:"id"`
 	Message   string     `json:"message"`
@@ -125,19 +170,21 @@ func (webhook *gitLabWebhookParser) parseRepoDetails(pathWithNamespace string) W
 func (webhook *gitLabWebhookParser) parsePrEvents(event *gitlab.MergeEvent) (*WebhookInfo, error) {
 	var webhookEvent vcsutils.WebhookEvent
 	switch event.ObjectAttributes.Action {
-	case "open", "reopen":
+	case "open":
 		webhookEvent = vcsutils.PrOpened
+	case "reopen":
+		webhookEvent = vcsutils.PrReopened
 	case "update":
 		webhookEvent = vcsutils.PrEdited
 	case "merge":
 		webhookEvent = vcsutils.PrMerged
 	case "close":
-		webhookEvent = vcsutils.PrRejected
+		webhookEvent = vcsutils.PrClosed
 	default:
 		// Action is not supported
 		return nil, nil
 	}
-	eventTime, err := time.Parse("2006-01-02 15:04:05 MST", event.ObjectAttributes.UpdatedAt)
+	eventTime, err := time.Parse(gitLabTimeFormat, event.ObjectAttributes.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +218,7 @@ func (webhook *gitLabWebhookParser) parsePrEvents(event *gitlab.MergeEvent) (*We
 			SourceRepository: webhook.parseRepoDetails(event.ObjectAttributes.Source.PathWithNamespace),
 			SourceBranch:     event.ObjectAttributes.SourceBranch,
 			SourceHash:       event.ObjectAttributes.LastCommit.ID,
+			Labels:           webhook.eventLabels(event.Labels),
 		},
 	}, nil
 }
@@ -205,3 +253,150 @@ func (webhook *gitLabWebhookParser) parseTagEvents(event *gitlab.TagEvent) (*Web
 	}
 	return info, nil
 }
+
+func (webhook *gitLabWebhookParser) parseMergeCommentEvent(event *gitlab.MergeCommentEvent, payload []byte) (*WebhookInfo, error) {
+	if event.ObjectAttributes.System {
+		// System notes, such as "added 1 commit", are not comments
+		return nil, nil
+	}
+	webhookEvent := vcsutils.PrCommentCreated
+	if webhook.noteAction(payload) == "update" {
+		webhookEvent = vcsutils.PrCommentEdited
+	}
+	eventTime, err := time.Parse(gitLabTimeFormat, event.ObjectAttributes.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	mergeRequest := event.MergeRequest
+	targetRepository := webhook.parseNoteRepoDetails(mergeRequest.Target, event.Project.PathWithNamespace)
+	sourceRepository := webhook.parseNoteRepoDetails(mergeRequest.Source, event.Project.PathWithNamespace)
+	// The URL of the note is the URL of the merge request, followed by the note anchor
+	mergeRequestURL, _, _ := strings.Cut(event.ObjectAttributes.URL, "#")
+	return &WebhookInfo{
+		PullRequestId:           mergeRequest.IID,
+		SourceRepositoryDetails: sourceRepository,
+		SourceBranch:            mergeRequest.SourceBranch,
+		TargetRepositoryDetails: targetRepository,
+		TargetBranch:            mergeRequest.TargetBranch,
+		Timestamp:               eventTime.UTC().Unix(),
+		Event:                   webhookEvent,
+		PullRequest: &WebhookInfoPullRequest{
+			ID:               mergeRequest.IID,
+			Title:            mergeRequest.Title,
+			CompareUrl:       mergeRequestURL,
+			TriggeredBy:      webhook.eventUser(event.User),
+			SkipDecryption:   true,
+			TargetRepository: targetRepository,
+			TargetBranch:     mergeRequest.TargetBranch,
+			SourceRepository: sourceRepository,
+			SourceBranch:     mergeRequest.SourceBranch,
+			SourceHash:       mergeRequest.LastCommit.ID,
+			Labels:           webhook.eventLabels(mergeRequest.Labels),
+		},
+		Comment: &WebhookInfoComment{
+			ID:        int64(event.ObjectAttributes.ID),
+			Body:      event.ObjectAttributes.Note,
+			Url:       event.ObjectAttributes.URL,
+			Timestamp: eventTime.UTC().Unix(),
+			Author:    webhook.eventUser(event.User),
+		},
+	}, nil
+}
+
+func (webhook *gitLabWebhookParser) parseIssueCommentEvent(event *gitlab.IssueCommentEvent, payload []byte) (*WebhookInfo, error) {
+	if event.ObjectAttributes.System || webhook.noteAction(payload) == "update" {
+		// System notes and edited issue comments are not supported
+		return nil, nil
+	}
+	eventTime, err := time.Parse(gitLabTimeFormat, event.ObjectAttributes.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	var author WebHookInfoUser
+	if event.User != nil {
+		author = WebHookInfoUser{
+			Login:       event.User.Username,
+			DisplayName: event.User.Name,
+			Email:       event.User.Email,
+			AvatarUrl:   event.User.AvatarURL,
+		}
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.parseRepoDetails(event.Project.PathWithNamespace),
+		Timestamp:               eventTime.UTC().Unix(),
+		Event:                   vcsutils.IssueCommentCreated,
+		Comment: &WebhookInfoComment{
+			ID:        int64(event.ObjectAttributes.ID),
+			Body:      event.ObjectAttributes.Note,
+			Url:       event.ObjectAttributes.URL,
+			Timestamp: eventTime.UTC().Unix(),
+			Author:    author,
+			IssueID:   event.Issue.IID,
+		},
+	}, nil
+}
+
+func (webhook *gitLabWebhookParser) parseReleaseEvent(event *gitlab.ReleaseEvent) (*WebhookInfo, error) {
+	if event.Action != "create" {
+		// Updated and deleted releases are not supported
+		return nil, nil
+	}
+	releaseTime, err := time.Parse(gitLabTimeFormat, event.ReleasedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookInfo{
+		TargetRepositoryDetails: webhook.parseRepoDetails(event.Project.PathWithNamespace),
+		Timestamp:               releaseTime.UTC().Unix(),
+		Event:                   vcsutils.ReleasePublished,
+		Release: &WebhookInfoRelease{
+			ID:         int64(event.ID),
+			Name:       event.Name,
+			TagName:    event.Tag,
+			TargetHash: event.Commit.ID,
+			Body:       event.Description,
+			Url:        event.URL,
+			Timestamp:  releaseTime.UTC().Unix(),
+		},
+	}, nil
+}
+
+// noteAction returns the action of a note event - "create" or "update". go-gitlab doesn't parse it.
+func (webhook *gitLabWebhookParser) noteAction(payload []byte) string {
+	var noteEvent struct {
+		ObjectAttributes struct {
+			Action string `json:"action"`
+		} `json:"object_attributes"`
+	}
+	if err := json.Unmarshal(payload, &noteEvent); err != nil {
+		return ""
+	}
+	return noteEvent.ObjectAttributes.Action
+}
+
+func (webhook *gitLabWebhookParser) parseNoteRepoDetails(repository *gitlab.Repository, defaultPathWithNamespace string) WebHookInfoRepoDetails {
+	if repository == nil || repository.PathWithNamespace == "" {
+		return webhook.parseRepoDetails(defaultPathWithNamespace)
+	}
+	return webhook.parseRepoDetails(repository.PathWithNamespace)
+}
+
+func (webhook *gitLabWebhookParser) eventUser(user *gitlab.EventUser) WebHookInfoUser {
+	if user == nil {
+		return WebHookInfoUser{}
+	}
+	return WebHookInfoUser{
+		Login:       user.Username,
+		DisplayName: user.Name,
+		Email:       user.Email,
+		AvatarUrl:   user.AvatarURL,
+	}
+}
+
+func (webhook *gitLabWebhookParser) eventLabels(eventLabels []*gitlab.EventLabel) []string {
+	var labels []string
+	for _, label := range eventLabels {
+		labels = append(labels, label.Title)
+	}
+	return labels
+}
@@ -16,7 +16,6 @@ import (
 )
 
 const (
-	gitLabEventHeader          = "X-GitLab-Event"
 	gitlabPushExpectedTime     = int64(1630306883)
 	gitlabPrOpenExpectedTime   = int64(1631202047)
 	gitlabPrReopenExpectedTime = int64(1638865856)
@@ -24,8 +23,39 @@ const (
 	gitlabPrCloseExpectedTime  = int64(1638864453)
 	gitlabPrMergeExpectedTime  = int64(1638866119)
 	gitlabExpectedPrID         = 1
+	// Comment events
+	gitlabPrCommentExpectedTime     = int64(1638866919)
+	gitlabPrEditCommentExpectedTime = int64(1638867012)
+	gitlabIssueCommentExpectedTime  = int64(1638867123)
+	// Release events
+	gitlabReleaseExpectedTime = int64(1634030072)
 )
 
+func TestGitLabParseIncomingBranchDeleteWebhook(t *testing.T) {
+	reader, err := os.Open(filepath.Join("testdata", "gitlab", "branchdeletepayload.json"))
+	assert.NoError(t, err)
+	defer close(reader)
+
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+	request.Header.Add(gitLabKeyHeader, string(token))
+	request.Header.Add(gitLabEventHeader, "Push Hook")
+
+	actual, err := ParseIncomingWebhook(context.Background(),
+		vcsutils.EmptyLogger{},
+		WebhookOrigin{
+			VcsProvider: vcsutils.GitLab,
+			Token:       token,
+		}, request)
+	assert.NoError(t, err)
+
+	assert.Equal(t, vcsutils.BranchDeleted, actual.Event)
+	assert.Equal(t, WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}, actual.TargetRepositoryDetails)
+	assert.Equal(t, expectedSourceBranch, actual.TargetBranch)
+	assert.Equal(t, WebHookInfoCommit{Hash: "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc"}, actual.BeforeCommit)
+	assert.Equal(t, WebhookInfoBranchStatusDeleted, actual.BranchStatus)
+	assert.Empty(t, actual.Push.Commits)
+}
+
 func TestGitLabParseIncomingPushWebhook(t *testing.T) {
 	reader, err := os.Open(filepath.Join("testdata", "gitlab", "pushpayload.json"))
 	assert.NoError(t, err)
@@ -64,6 +94,14 @@ func TestGitLabParseIncomingPushWebhook(t *testing.T) {
 	}, actual.BeforeCommit)
 	assert.Equal(t, WebhookInfoBranchStatusUpdated, actual.BranchStatus)
 	assert.Equal(t, "", actual.CompareUrl)
+	assert.Equal(t, &WebhookInfoPush{Commits: []WebhookInfoPushCommit{{
+		Hash:      "450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+		Message:   "Initial commit",
+		Url:       "https://gitlab.com/yahavi/hello-world/-/commit/450cd4687e3644d544ca4cb3a7a355fea9e6f0dc",
+		Timestamp: gitlabPushExpectedTime,
+		Author:    WebHookInfoUser{DisplayName: "Yahav Itzhak", Email: "yahavitz@gmail.com"},
+		Added:     []WebHookInfoFile{{Path: "README.md"}},
+	}}}, actual.Push)
 }
 
 func TestGitLabParseIncomingPrWebhook(t *testing.T) {
@@ -113,7 +151,7 @@ func TestGitLabParseIncomingPrWebhook(t *testing.T) {
 			name:              "reopen",
 			payloadFilename:   "prreopenpayload.json",
 			expectedTime:      gitlabPrReopenExpectedTime,
-			expectedEventType: vcsutils.PrOpened,
+			expectedEventType: vcsutils.PrReopened,
 			expectedPullRequestInfo: &WebhookInfoPullRequest{
 				ID:         1,
 				Title:      "Update README.md",
@@ -177,13 +215,14 @@ func TestGitLabParseIncomingPrWebhook(t *testing.T) {
 				},
 				SourceBranch: "dev",
 				SourceHash:   "3fcd302505fb3df664143df4ddcb6cfc50ff2ea8",
+				Labels:       []string{"security"},
 			},
 		},
 		{
 			name:              "close",
 			payloadFilename:   "prclosepayload.json",
 			expectedTime:      gitlabPrCloseExpectedTime,
-			expectedEventType: vcsutils.PrRejected,
+			expectedEventType: vcsutils.PrClosed,
 			expectedPullRequestInfo: &WebhookInfoPullRequest{
 				ID:         1,
 				Title:      "Update README.md",
@@ -357,6 +396,165 @@ func TestGitLabParseIncomingWebhookTagEvents(t *testing.T) {
 	}
 }
 
+func TestGitLabParseIncomingCommentWebhook(t *testing.T) {
+	expectedUser := WebHookInfoUser{
+		Login:       "yahavi",
+		DisplayName: "Yahav Itzhak",
+		Email:       "yahavitz@gmail.com",
+		AvatarUrl:   "https://secure.gravatar.com/avatar/9680da1674e22a1de17acb19bb233ebf?s=80&d=identicon",
+	}
+	expectedRepository := WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner}
+	expectedPullRequest := &WebhookInfoPullRequest{
+		ID:               gitlabExpectedPrID,
+		Title:            "Update README.md",
+		CompareUrl:       "https://gitlab.com/yahavi/hello-world/-/merge_requests/1",
+		TriggeredBy:      expectedUser,
+		SkipDecryption:   true,
+		TargetRepository: expectedRepository,
+		TargetBranch:     expectedBranch,
+		SourceRepository: expectedRepository,
+		SourceBranch:     expectedSourceBranch,
+		SourceHash:       "3fcd302505fb3df664143df4ddcb6cfc50ff2ea8",
+		Labels:           []string{"security"},
+	}
+	tests := []struct {
+		name            string
+		payloadFilename string
+		expected        *WebhookInfo
+	}{
+		{
+			name:            "pull request comment created",
+			payloadFilename: "prcommentpayload.json",
+			expected: &WebhookInfo{
+				PullRequestId:           gitlabExpectedPrID,
+				TargetRepositoryDetails: expectedRepository,
+				TargetBranch:            expectedBranch,
+				SourceRepositoryDetails: expectedRepository,
+				SourceBranch:            expectedSourceBranch,
+				Timestamp:               gitlabPrCommentExpectedTime,
+				Event:                   vcsutils.PrCommentCreated,
+				PullRequest:             expectedPullRequest,
+				Comment: &WebhookInfoComment{
+					ID:        745301467,
+					Body:      "rescan",
+					Url:       "https://gitlab.com/yahavi/hello-world/-/merge_requests/1#note_745301467",
+					Timestamp: gitlabPrCommentExpectedTime,
+					Author:    expectedUser,
+				},
+			},
+		},
+		{
+			name:            "pull request comment edited",
+			payloadFilename: "preditcommentpayload.json",
+			expected: &WebhookInfo{
+				PullRequestId:           gitlabExpectedPrID,
+				TargetRepositoryDetails: expectedRepository,
+				TargetBranch:            expectedBranch,
+				SourceRepositoryDetails: expectedRepository,
+				SourceBranch:            expectedSourceBranch,
+				Timestamp:               gitlabPrEditCommentExpectedTime,
+				Event:                   vcsutils.PrCommentEdited,
+				PullRequest:             expectedPullRequest,
+				Comment: &WebhookInfoComment{
+					ID:        745301467,
+					Body:      "rescan please",
+					Url:       "https://gitlab.com/yahavi/hello-world/-/merge_requests/1#note_745301467",
+					Timestamp: gitlabPrEditCommentExpectedTime,
+					Author:    expectedUser,
+				},
+			},
+		},
+		{
+			name:            "issue comment",
+			payloadFilename: "issuecommentpayload.json",
+			expected: &WebhookInfo{
+				TargetRepositoryDetails: expectedRepository,
+				Timestamp:               gitlabIssueCommentExpectedTime,
+				Event:                   vcsutils.IssueCommentCreated,
+				Comment: &WebhookInfoComment{
+					ID:        745301470,
+					Body:      "Thanks for the report",
+					Url:       "https://gitlab.com/yahavi/hello-world/-/issues/3#note_745301470",
+					Timestamp: gitlabIssueCommentExpectedTime,
+					Author:    expectedUser,
+					IssueID:   3,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "gitlab", tt.payloadFilename))
+			assert.NoError(t, err)
+			defer close(reader)
+
+			request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+			request.Header.Add(gitLabKeyHeader, string(token))
+			request.Header.Add(gitLabEventHeader, string(gitlab.EventTypeNote))
+
+			actual, err := ParseIncomingWebhook(context.Background(),
+				vcsutils.EmptyLogger{},
+				WebhookOrigin{
+					VcsProvider: vcsutils.GitLab,
+					Token:       token,
+				}, request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestGitLabParseIncomingReleaseWebhook(t *testing.T) {
+	tests := []struct {
+		name            string
+		payloadFilename string
+		expected        *WebhookInfo
+	}{
+		{
+			name:            "create",
+			payloadFilename: "releasecreatepayload.json",
+			expected: &WebhookInfo{
+				TargetRepositoryDetails: WebHookInfoRepoDetails{Name: expectedRepoName, Owner: expectedOwner},
+				Timestamp:               gitlabReleaseExpectedTime,
+				Event:                   vcsutils.ReleasePublished,
+				Release: &WebhookInfoRelease{
+					ID:         4287216,
+					Name:       "Hello World 1.0.0",
+					TagName:    "v1.0.0",
+					TargetHash: "4bb1bd4cfea4ab4ed77bd1cd8f2a3d0d2f6e1b1f",
+					Body:       "The first release of hello-world",
+					Url:        "https://gitlab.com/yahavi/hello-world/-/releases/v1.0.0",
+					Timestamp:  gitlabReleaseExpectedTime,
+				},
+			},
+		},
+		{
+			name:            "update",
+			payloadFilename: "releaseupdatepayload.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "gitlab", tt.payloadFilename))
+			assert.NoError(t, err)
+			defer close(reader)
+
+			request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+			request.Header.Add(gitLabKeyHeader, string(token))
+			request.Header.Add(gitLabEventHeader, string(gitlab.EventTypeRelease))
+
+			actual, err := ParseIncomingWebhook(context.Background(),
+				vcsutils.EmptyLogger{},
+				WebhookOrigin{
+					VcsProvider: vcsutils.GitLab,
+					Token:       token,
+				}, request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
 func TestGitLabParseIncomingWebhookError(t *testing.T) {
 	request := &http.Request{}
 	_, err := ParseIncomingWebhook(context.Background(),
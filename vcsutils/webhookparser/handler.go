@@ -0,0 +1,100 @@
+package webhookparser
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+// SecretProvider returns the candidate tokens used to authenticate the incoming webhooks of the VCS provider.
+// The token is the random key generated in the CreateWebhook command. While rotating the webhook secret,
+// return both the new and the old token.
+type SecretProvider func(ctx context.Context, vcsProvider vcsutils.VcsProvider) ([][]byte, error)
+
+// WebhookCallback handles a parsed incoming webhook
+type WebhookCallback func(ctx context.Context, webhookInfo *WebhookInfo) error
+
+// Handler returns an HTTP handler of incoming webhooks of all the supported VCS providers.
+// The handler detects the VCS provider, validates the payload with the token of the secret provider, parses it, and invokes the callback.
+// The handler responds with:
+//   - 200 - The callback handled the webhook successfully
+//   - 204 - The event isn't supported, hence the callback isn't invoked
+//   - 400 - The VCS provider couldn't be detected or the payload couldn't be parsed
+//   - 401 - The payload signature or token is invalid
+//   - 409 - The webhook was rejected by the replay protection
+//   - 500 - The secret provider or the callback returned an error
+//
+// logger - Used to log any trace about the handling
+// secretProvider - Returns the token of the VCS provider
+// callback - Invoked with the parsed webhook
+func Handler(logger vcsutils.Log, secretProvider SecretProvider, callback WebhookCallback) *WebhookHandler {
+	return &WebhookHandler{
+		logger:         logger,
+		secretProvider: secretProvider,
+		callback:       callback,
+	}
+}
+
+// WebhookHandler is an HTTP handler of incoming webhooks, created by Handler
+type WebhookHandler struct {
+	logger           vcsutils.Log
+	secretProvider   SecretProvider
+	callback         WebhookCallback
+	replayProtection *ReplayProtection
+}
+
+// ReplayProtection sets the replay protection of the handler, to reject webhooks that were already handled or were sent too long ago
+func (handler *WebhookHandler) ReplayProtection(replayProtection *ReplayProtection) *WebhookHandler {
+	handler.replayProtection = replayProtection
+	return handler
+}
+
+func (handler *WebhookHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	status, err := handler.handle(request)
+	if err != nil {
+		handler.logger.Error("Failed to handle the incoming webhook: ", err.Error())
+		http.Error(writer, http.StatusText(status), status)
+		return
+	}
+	writer.WriteHeader(status)
+}
+
+// handle handles the incoming webhook and returns the HTTP status of the response.
+// The request body is closed by the HTTP server.
+func (handler *WebhookHandler) handle(request *http.Request) (int, error) {
+	ctx := request.Context()
+	vcsProvider, err := DetectVcsProvider(request)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	tokens, err := handler.secretProvider(ctx, vcsProvider)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	origin := WebhookOrigin{VcsProvider: vcsProvider, Tokens: tokens}
+	parser := createWebhookParser(handler.logger, origin)
+	payload, matchedToken, err := validatePayloadWithTokens(ctx, parser, origin.candidateTokens(), request)
+	if err != nil {
+		return http.StatusUnauthorized, err
+	}
+	if err = handler.replayProtection.validateDelivery(parser.deliveryDetails(request, payload)); err != nil {
+		return http.StatusConflict, err
+	}
+	webhookInfo, err := parser.parseIncomingWebhook(ctx, request, payload)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if webhookInfo == nil {
+		handler.logger.Debug("Ignoring an unsupported ", vcsProvider.String(), " webhook event")
+		return http.StatusNoContent, nil
+	}
+	if matchedToken != nil {
+		webhookInfo.MatchedToken = matchedToken
+	}
+	if err = handler.callback(ctx, webhookInfo); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
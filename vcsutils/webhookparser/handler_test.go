@@ -0,0 +1,116 @@
+package webhookparser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+func TestHandler(t *testing.T) {
+	secretProvider := func(_ context.Context, vcsProvider vcsutils.VcsProvider) ([][]byte, error) {
+		if vcsProvider != vcsutils.GitLab {
+			return nil, errors.New("unexpected VCS provider")
+		}
+		return [][]byte{[]byte("new-token"), token}, nil
+	}
+	errCallback := errors.New("callback error")
+	tests := []struct {
+		name             string
+		eventHeader      string
+		token            string
+		callbackErr      error
+		expectedStatus   int
+		expectedCallback bool
+	}{
+		{name: "handled", eventHeader: "Push Hook", token: string(token), expectedStatus: http.StatusOK, expectedCallback: true},
+		{name: "unsupported event", eventHeader: "Job Hook", token: string(token), expectedStatus: http.StatusNoContent},
+		{name: "token mismatch", eventHeader: "Push Hook", token: "wrong-token", expectedStatus: http.StatusUnauthorized},
+		{name: "callback error", eventHeader: "Push Hook", token: string(token), callbackErr: errCallback, expectedStatus: http.StatusInternalServerError, expectedCallback: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := os.Open(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+			assert.NoError(t, err)
+			defer close(reader)
+
+			request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", reader)
+			request.Header.Add(gitLabKeyHeader, tt.token)
+			request.Header.Add(gitLabEventHeader, tt.eventHeader)
+
+			var actual *WebhookInfo
+			callback := func(_ context.Context, webhookInfo *WebhookInfo) error {
+				actual = webhookInfo
+				return tt.callbackErr
+			}
+			recorder := httptest.NewRecorder()
+			Handler(vcsutils.EmptyLogger{}, secretProvider, callback).ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedCallback, actual != nil)
+			if tt.expectedCallback {
+				assert.Equal(t, vcsutils.Push, actual.Event)
+				assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+				assert.Equal(t, token, actual.MatchedToken)
+			}
+		})
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	secretProvider := func(_ context.Context, _ vcsutils.VcsProvider) ([][]byte, error) {
+		return nil, errors.New("secret error")
+	}
+	callback := func(_ context.Context, _ *WebhookInfo) error {
+		assert.Fail(t, "callback shouldn't be invoked")
+		return nil
+	}
+	handler := Handler(vcsutils.EmptyLogger{}, secretProvider, callback)
+
+	// Unknown VCS provider
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "https://127.0.0.1", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// Secret provider error
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", nil)
+	request.Header.Add(gitLabEventHeader, "Push Hook")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestHandlerReplayProtection(t *testing.T) {
+	secretProvider := func(_ context.Context, _ vcsutils.VcsProvider) ([][]byte, error) {
+		return [][]byte{token}, nil
+	}
+	callbacks := 0
+	callback := func(_ context.Context, _ *WebhookInfo) error {
+		callbacks++
+		return nil
+	}
+	handler := Handler(vcsutils.EmptyLogger{}, secretProvider, callback).
+		ReplayProtection(&ReplayProtection{DeliveryCache: NewMemoryDeliveryCache(time.Hour)})
+
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	assert.NoError(t, err)
+	for _, expectedStatus := range []int{http.StatusOK, http.StatusConflict} {
+		request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", bytes.NewReader(payload))
+		request.Header.Add(gitLabKeyHeader, string(token))
+		request.Header.Add(gitLabEventHeader, "Push Hook")
+		request.Header.Add(gitLabEventUUIDHeader, "13792a34-cac6-4fda-95a8-c58e00a3954e")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedStatus, recorder.Code)
+	}
+	assert.Equal(t, 1, callbacks)
+}
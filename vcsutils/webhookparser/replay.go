@@ -0,0 +1,92 @@
+package webhookparser
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrReplayedWebhook is returned when replay protection rejects an incoming webhook
+var ErrReplayedWebhook = errors.New("replayed webhook")
+
+// ReplayProtection rejects incoming webhooks that were already handled or were sent too long ago.
+// Notice - The delivery ID and the delivery time are checked only after the webhook is authenticated,
+// and only if the VCS provider reports them:
+//   - GitHub - X-GitHub-Delivery header
+//   - GitLab - X-Gitlab-Event-UUID header
+//   - Bitbucket Server - X-Request-Id header and the signed "date" field of the payload
+//   - Bitbucket Cloud - X-Request-UUID header
+//   - Azure Repos - The "id" and "createdDate" fields of the payload
+//   - Gitea - X-Gitea-Delivery header
+//   - AWS CodeCommit - The "MessageId" and "Timestamp" fields of the Amazon SNS message, or the "id" and "time" fields of the Amazon EventBridge event
+type ReplayProtection struct {
+	// MaxAge rejects webhooks sent more than MaxAge ago. If zero, the delivery time is not checked.
+	MaxAge time.Duration
+	// DeliveryCache rejects webhooks whose delivery ID was already handled. If nil, the delivery ID is not checked.
+	DeliveryCache DeliveryCache
+}
+
+// DeliveryCache stores the delivery IDs of the handled webhooks
+type DeliveryCache interface {
+	// Add stores the delivery ID. Returns false if the delivery ID was already stored.
+	Add(deliveryID string) bool
+}
+
+// webhookDelivery is the delivery information of an incoming webhook, as reported by the VCS provider
+type webhookDelivery struct {
+	id   string
+	time time.Time
+}
+
+// validateDelivery returns ErrReplayedWebhook if the webhook was already handled or was sent too long ago
+func (replayProtection *ReplayProtection) validateDelivery(delivery webhookDelivery) error {
+	if replayProtection == nil {
+		return nil
+	}
+	if replayProtection.MaxAge > 0 && !delivery.time.IsZero() {
+		if age := time.Since(delivery.time); age > replayProtection.MaxAge {
+			return fmt.Errorf("%w: the webhook was sent %s ago", ErrReplayedWebhook, age.Round(time.Second))
+		}
+	}
+	if replayProtection.DeliveryCache != nil && delivery.id != "" {
+		if !replayProtection.DeliveryCache.Add(delivery.id) {
+			return fmt.Errorf("%w: delivery %s was already handled", ErrReplayedWebhook, delivery.id)
+		}
+	}
+	return nil
+}
+
+// NewMemoryDeliveryCache creates an in-memory DeliveryCache, safe for concurrent use.
+// ttl - The duration to remember each delivery ID. Should be at least ReplayProtection.MaxAge.
+func NewMemoryDeliveryCache(ttl time.Duration) DeliveryCache {
+	return &memoryDeliveryCache{
+		ttl:        ttl,
+		deliveries: map[string]time.Time{},
+		now:        time.Now,
+	}
+}
+
+type memoryDeliveryCache struct {
+	mutex      sync.Mutex
+	ttl        time.Duration
+	deliveries map[string]time.Time
+	now        func() time.Time
+}
+
+func (cache *memoryDeliveryCache) Add(deliveryID string) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	now := cache.now()
+	// Evict the expired delivery IDs, to keep the cache size bounded
+	for id, expiry := range cache.deliveries {
+		if now.After(expiry) {
+			delete(cache.deliveries, id)
+		}
+	}
+	if _, exists := cache.deliveries[deliveryID]; exists {
+		return false
+	}
+	cache.deliveries[deliveryID] = now.Add(cache.ttl)
+	return true
+}
@@ -0,0 +1,107 @@
+package webhookparser
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
+)
+
+func TestMemoryDeliveryCache(t *testing.T) {
+	now := time.Now()
+	cache := &memoryDeliveryCache{ttl: time.Minute, deliveries: map[string]time.Time{}, now: func() time.Time { return now }}
+	assert.True(t, cache.Add("1"))
+	assert.True(t, cache.Add("2"))
+	assert.False(t, cache.Add("1"))
+
+	// The delivery IDs are evicted after the TTL
+	now = now.Add(2 * time.Minute)
+	assert.True(t, cache.Add("1"))
+	assert.Len(t, cache.deliveries, 1)
+}
+
+func TestValidateDelivery(t *testing.T) {
+	recentDelivery := webhookDelivery{id: "1", time: time.Now().Add(-time.Minute)}
+	oldDelivery := webhookDelivery{id: "2", time: time.Now().Add(-time.Hour)}
+
+	var replayProtection *ReplayProtection
+	assert.NoError(t, replayProtection.validateDelivery(oldDelivery))
+
+	replayProtection = &ReplayProtection{MaxAge: 10 * time.Minute}
+	assert.NoError(t, replayProtection.validateDelivery(recentDelivery))
+	assert.NoError(t, replayProtection.validateDelivery(webhookDelivery{id: "3"}))
+	assert.ErrorIs(t, replayProtection.validateDelivery(oldDelivery), ErrReplayedWebhook)
+
+	replayProtection = &ReplayProtection{DeliveryCache: NewMemoryDeliveryCache(time.Hour)}
+	assert.NoError(t, replayProtection.validateDelivery(recentDelivery))
+	assert.ErrorIs(t, replayProtection.validateDelivery(recentDelivery), ErrReplayedWebhook)
+	assert.NoError(t, replayProtection.validateDelivery(webhookDelivery{}))
+	assert.NoError(t, replayProtection.validateDelivery(webhookDelivery{}))
+}
+
+func TestParseIncomingWebhookReplayProtection(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "bitbucketserver", "pushpayload.json"))
+	assert.NoError(t, err)
+	parse := func(replayProtection *ReplayProtection, signature string) (*WebhookInfo, error) {
+		request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", bytes.NewReader(payload))
+		request.Header.Add(EventHeaderKey, "repo:refs_changed")
+		request.Header.Add(sha256Signature, signature)
+		request.Header.Add(bitbucketServerRequestIDHeader, "7d0a3ad6-3e6e-4a3b-8c3c-14a86d1c6e3f")
+		return ParseIncomingWebhook(context.Background(), vcsutils.EmptyLogger{}, WebhookOrigin{
+			VcsProvider:      vcsutils.BitbucketServer,
+			Token:            token,
+			ReplayProtection: replayProtection,
+		}, request)
+	}
+
+	// The payload was sent in 2021
+	_, err = parse(&ReplayProtection{MaxAge: time.Hour}, "sha256="+bitbucketServerPushSha256)
+	assert.ErrorIs(t, err, ErrReplayedWebhook)
+
+	// Replays are rejected by the delivery ID
+	replayProtection := &ReplayProtection{DeliveryCache: NewMemoryDeliveryCache(time.Hour)}
+	actual, err := parse(replayProtection, "sha256="+bitbucketServerPushSha256)
+	assert.NoError(t, err)
+	assert.Equal(t, vcsutils.Push, actual.Event)
+	_, err = parse(replayProtection, "sha256="+bitbucketServerPushSha256)
+	assert.ErrorIs(t, err, ErrReplayedWebhook)
+
+	// Unauthenticated webhooks don't consume the delivery ID
+	replayProtection = &ReplayProtection{DeliveryCache: NewMemoryDeliveryCache(time.Hour)}
+	_, err = parse(replayProtection, "sha256=invalid")
+	assert.EqualError(t, err, "payload signature mismatch")
+	_, err = parse(replayProtection, "sha256="+bitbucketServerPushSha256)
+	assert.NoError(t, err)
+}
+
+func TestDeliveryDetails(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", nil)
+	request.Header.Set(github.DeliveryIDHeader, "github-delivery")
+	request.Header.Set(gitLabEventUUIDHeader, "gitlab-delivery")
+	request.Header.Set(bitbucketCloudRequestUUIDHeader, "bitbucket-cloud-delivery")
+	request.Header.Set(bitbucketServerRequestIDHeader, "bitbucket-server-delivery")
+	request.Header.Set(giteaDeliveryHeader, "gitea-delivery")
+
+	assert.Equal(t, webhookDelivery{id: "github-delivery"}, newGitHubWebhookParser(vcsutils.EmptyLogger{}, "").deliveryDetails(request, nil))
+	assert.Equal(t, webhookDelivery{id: "gitlab-delivery"}, newGitLabWebhookParser(vcsutils.EmptyLogger{}).deliveryDetails(request, nil))
+	assert.Equal(t, webhookDelivery{id: "bitbucket-cloud-delivery"}, newBitbucketCloudWebhookParser(vcsutils.EmptyLogger{}).deliveryDetails(request, nil))
+	assert.Equal(t, webhookDelivery{id: "gitea-delivery"}, newGiteaWebhookParser(vcsutils.EmptyLogger{}, "").deliveryDetails(request, nil))
+
+	bitbucketServerDelivery := newBitbucketServerWebhookParser(vcsutils.EmptyLogger{}, "").deliveryDetails(request, []byte(`{"date":"2021-09-09T12:16:44+0300"}`))
+	assert.Equal(t, "bitbucket-server-delivery", bitbucketServerDelivery.id)
+	assert.Equal(t, int64(1631179004), bitbucketServerDelivery.time.Unix())
+
+	azureReposDelivery := newAzureReposWebhookParser(vcsutils.EmptyLogger{}).deliveryDetails(request, []byte(`{"id":"azure-delivery","createdDate":"2021-09-09T09:16:44Z"}`))
+	assert.Equal(t, "azure-delivery", azureReposDelivery.id)
+	assert.Equal(t, int64(1631179004), azureReposDelivery.time.Unix())
+	assert.Equal(t, webhookDelivery{}, newAzureReposWebhookParser(vcsutils.EmptyLogger{}).deliveryDetails(request, []byte("invalid")))
+}
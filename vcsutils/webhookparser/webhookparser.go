@@ -1,9 +1,19 @@
 package webhookparser
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v56/github"
 
 	"github.com/jfrog/froggit-go/vcsutils"
 )
@@ -45,10 +55,18 @@ type WebhookInfo struct {
 	Author WebHookInfoUser `json:"author,omitempty"`
 	// CompareUrl is HTML URL to see git comparison between commits (Push event only)
 	CompareUrl string `json:"compare_url,omitempty"`
+	// Push encapsulates information of the push event.
+	Push *WebhookInfoPush `json:"push,omitempty"`
 	// PullRequest encapsulates information of the pull request.
 	PullRequest *WebhookInfoPullRequest `json:"pull_request,omitempty"`
 	// Tag encapsulates information about the tag event.
 	Tag *WebhookInfoTag `json:"tag,omitempty"`
+	// Comment encapsulates information about the comment event.
+	Comment *WebhookInfoComment `json:"comment,omitempty"`
+	// Release encapsulates information about the release event.
+	Release *WebhookInfoRelease `json:"release,omitempty"`
+	// MatchedToken is the token that authenticated the webhook, if multiple candidate tokens are set in WebhookOrigin.Tokens.
+	MatchedToken []byte `json:"-"`
 }
 
 // WebhookInfoPullRequest contains information about a pull request event received via a webhook.
@@ -79,6 +97,37 @@ type WebhookInfoPullRequest struct {
 	SourceBranch string `json:"source_branch,omitempty"`
 	// SourceHash is a commit SHA of the source branch.
 	SourceHash string `json:"source_hash,omitempty"`
+	// Labels are the names of the labels of the pull request.
+	Labels []string `json:"labels,omitempty"`
+}
+
+// WebhookInfoPush contains information about a push event received via a webhook.
+type WebhookInfoPush struct {
+	// Commits are the pushed commits, from the oldest to the newest.
+	// The VCS providers limit the number of commits in the payload, hence it may not contain all the pushed commits.
+	Commits []WebhookInfoPushCommit `json:"commits,omitempty"`
+}
+
+// WebhookInfoPushCommit contains information about a commit of a push event.
+type WebhookInfoPushCommit struct {
+	// Hash is an SHA of the commit.
+	Hash string `json:"hash,omitempty"`
+	// Message is the commit message.
+	Message string `json:"message,omitempty"`
+	// Url is a hyperlink to the commit.
+	Url string `json:"url,omitempty"`
+	// Timestamp of the commit (Unix timestamp).
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Author is the commit author.
+	Author WebHookInfoUser `json:"author,omitempty"`
+	// Committer is the commit committer.
+	Committer WebHookInfoUser `json:"committer,omitempty"`
+	// Added are the files added by the commit.
+	Added []WebHookInfoFile `json:"added,omitempty"`
+	// Modified are the files modified by the commit.
+	Modified []WebHookInfoFile `json:"modified,omitempty"`
+	// Removed are the files removed by the commit.
+	Removed []WebHookInfoFile `json:"removed,omitempty"`
 }
 
 // WebHookInfoRepoDetails represents repository info of an incoming webhook
@@ -103,6 +152,48 @@ type WebhookInfoTag struct {
 	Author WebHookInfoUser `json:"author,omitempty"`
 }
 
+// WebhookInfoComment contains information about a comment event received via a webhook.
+// In pull request comment events, the commented pull request is set in the PullRequest field of the WebhookInfo.
+type WebhookInfoComment struct {
+	// ID is a unique identifier of the comment.
+	ID int64 `json:"id,omitempty"`
+	// Body is the content of the comment.
+	Body string `json:"body,omitempty"`
+	// Url is a hyperlink to the comment.
+	Url string `json:"url,omitempty"`
+	// Timestamp of the last update (Unix timestamp).
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Author is an info about the comment author.
+	Author WebHookInfoUser `json:"author,omitempty"`
+	// IssueID is the ID of the commented issue (Issue comment events only).
+	IssueID int `json:"issue_id,omitempty"`
+}
+
+// WebhookInfoRelease contains information about a release event received via a webhook.
+// The repository of the release is set in the TargetRepositoryDetails field of the WebhookInfo.
+type WebhookInfoRelease struct {
+	// ID is a unique identifier of the release.
+	ID int64 `json:"id,omitempty"`
+	// Name is the title of the release.
+	Name string `json:"name,omitempty"`
+	// TagName is the name of the tag of the release.
+	TagName string `json:"tag_name,omitempty"`
+	// TargetHash is an SHA of the commit the release points to (GitLab only).
+	TargetHash string `json:"target_hash,omitempty"`
+	// Body is the description of the release.
+	Body string `json:"body,omitempty"`
+	// Url is a hyperlink to the release.
+	Url string `json:"url,omitempty"`
+	// Timestamp of the release (Unix timestamp).
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Author is an info about the release author (GitHub only).
+	Author WebHookInfoUser `json:"author,omitempty"`
+	// Draft is true if the release is not published yet (GitHub only).
+	Draft bool `json:"draft,omitempty"`
+	// Prerelease is true if the release is marked as a pre-release (GitHub only).
+	Prerelease bool `json:"prerelease,omitempty"`
+}
+
 // WebHookInfoCommit represents a commit info of an incoming webhook
 type WebHookInfoCommit struct {
 	Hash    string `json:"hash,omitempty"`
@@ -118,6 +209,14 @@ const (
 	WebhookInfoBranchStatusDeleted WebHookInfoBranchStatus = "deleted"
 )
 
+// pushEvent returns the event of a push to a branch. Pushes that delete the branch are reported as BranchDeleted.
+func pushEvent(status WebHookInfoBranchStatus) vcsutils.WebhookEvent {
+	if status == WebhookInfoBranchStatusDeleted {
+		return vcsutils.BranchDeleted
+	}
+	return vcsutils.Push
+}
+
 func branchStatus(existedBefore, existsAfter bool) WebHookInfoBranchStatus {
 	switch {
 	case existsAfter && !existedBefore:
@@ -140,12 +239,22 @@ type WebHookInfoFile struct {
 	Path string `json:"path,omitempty"`
 }
 
+func webhookInfoFiles(paths []string) []WebHookInfoFile {
+	var files []WebHookInfoFile
+	for _, path := range paths {
+		files = append(files, WebHookInfoFile{Path: path})
+	}
+	return files
+}
+
 // webhookParser is a webhook parser of an incoming webhook from a VCS server
 type webhookParser interface {
 	// Validate the webhook payload with the expected token and return the payload
 	validatePayload(ctx context.Context, request *http.Request, token []byte) ([]byte, error)
 	// Parse the webhook payload and return WebhookInfo
 	parseIncomingWebhook(ctx context.Context, request *http.Request, payload []byte) (*WebhookInfo, error)
+	// Return the delivery ID and time of the webhook, if reported by the VCS provider
+	deliveryDetails(request *http.Request, payload []byte) webhookDelivery
 }
 
 // ParseIncomingWebhook parses incoming webhook HTTP request into WebhookInfo struct.
@@ -155,7 +264,77 @@ type webhookParser interface {
 // request - Received HTTP request
 func ParseIncomingWebhook(ctx context.Context, logger vcsutils.Log, origin WebhookOrigin, request *http.Request) (*WebhookInfo, error) {
 	parser := createWebhookParser(logger, origin)
-	return validateAndParseHttpRequest(ctx, parser, origin.Token, request)
+	return validateAndParseHttpRequest(ctx, parser, origin, request)
+}
+
+// ParseIncomingWebhookFromPayload parses the payload of an incoming webhook into WebhookInfo struct.
+// Use it when the webhook is not received as an HTTP request, for example when it is consumed from a queue.
+// ctx - Go context
+// logger - Used to log any trace about the parsing
+// origin - Information about the hook origin
+// eventHeader - The event type header of the webhook (X-GitHub-Event, X-Gitlab-Event, X-Event-Key or X-Gitea-Event). Ignored in Azure Repos and AWS CodeCommit.
+// signatureHeader - The header authenticating the webhook:
+//   - GitHub - X-Hub-Signature-256
+//   - GitLab - X-Gitlab-Token
+//   - Bitbucket Server - X-Hub-Signature
+//   - Bitbucket Cloud - X-Hub-Signature if the webhook has a secret, or the "token" query parameter of the webhook URL
+//   - Azure Repos - Authorization
+//   - Gitea - X-Gitea-Signature
+//   - AWS CodeCommit - Authorization
+//
+// body - The payload of the webhook
+func ParseIncomingWebhookFromPayload(ctx context.Context, logger vcsutils.Log, origin WebhookOrigin, eventHeader, signatureHeader string, body []byte) (*WebhookInfo, error) {
+	request, err := newPayloadRequest(ctx, origin.VcsProvider, eventHeader, signatureHeader, body)
+	if err != nil {
+		return nil, err
+	}
+	parser := createWebhookParser(logger, origin)
+	return validateAndParseHttpRequest(ctx, parser, origin, request)
+}
+
+// newPayloadRequest creates the HTTP request the VCS provider would have sent with the webhook payload
+func newPayloadRequest(ctx context.Context, provider vcsutils.VcsProvider, eventHeader, signatureHeader string, body []byte) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	setHeader := func(key, value string) {
+		if value != "" {
+			request.Header.Set(key, value)
+		}
+	}
+	switch provider {
+	case vcsutils.GitHub:
+		setHeader(github.EventTypeHeader, eventHeader)
+		setHeader(github.SHA256SignatureHeader, signatureHeader)
+		// GitHub sends the payload either as JSON or as a form with a single "payload" field
+		contentType := "application/json"
+		if bytes.HasPrefix(body, []byte("payload=")) {
+			contentType = "application/x-www-form-urlencoded"
+		}
+		request.Header.Set("Content-Type", contentType)
+	case vcsutils.GitLab:
+		setHeader(gitLabEventHeader, eventHeader)
+		setHeader(gitLabKeyHeader, signatureHeader)
+	case vcsutils.BitbucketServer:
+		setHeader(bitbucketServerEventHeader, eventHeader)
+		setHeader(sha256Signature, signatureHeader)
+	case vcsutils.BitbucketCloud:
+		setHeader(EventHeaderKey, eventHeader)
+		if strings.HasPrefix(signatureHeader, "sha256=") {
+			setHeader(sha256Signature, signatureHeader)
+		} else if signatureHeader != "" {
+			request.URL.RawQuery = url.Values{"token": {signatureHeader}}.Encode()
+		}
+	case vcsutils.AzureRepos, vcsutils.CodeCommit:
+		setHeader("Authorization", signatureHeader)
+	case vcsutils.Gitea:
+		setHeader(giteaEventHeader, eventHeader)
+		setHeader(giteaSignatureHeader, signatureHeader)
+	default:
+		return nil, fmt.Errorf("unsupported VCS provider: %s", provider.String())
+	}
+	return request, nil
 }
 
 // WebhookOrigin provides information about the hook to parse.
@@ -167,20 +346,92 @@ type WebhookOrigin struct {
 	// Token is used to authenticate incoming webhooks. If empty, signature will not be verified.
 	// The token is a random key generated in the CreateWebhook command.
 	Token []byte
+	// Tokens are candidate tokens to authenticate incoming webhooks, used instead of Token if not empty.
+	// The webhook is authenticated if any of the tokens matches, for example the new and the old token while rotating
+	// the webhook secret. The matched token is returned in WebhookInfo.MatchedToken.
+	Tokens [][]byte
+	// ReplayProtection rejects authenticated webhooks that were already handled or were sent too long ago, with ErrReplayedWebhook.
+	// If nil, replays are not detected.
+	ReplayProtection *ReplayProtection
 }
 
-func validateAndParseHttpRequest(ctx context.Context, parser webhookParser, token []byte, request *http.Request) (webhook *WebhookInfo, err error) {
-	if request.Body != nil {
+// candidateTokens returns the tokens to authenticate the incoming webhook with
+func (origin WebhookOrigin) candidateTokens() [][]byte {
+	var tokens [][]byte
+	for _, token := range origin.Tokens {
+		// Empty tokens would skip the authentication
+		if len(token) > 0 {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		return [][]byte{origin.Token}
+	}
+	return tokens
+}
+
+func validateAndParseHttpRequest(ctx context.Context, parser webhookParser, origin WebhookOrigin, request *http.Request) (webhook *WebhookInfo, err error) {
+	// Validating with multiple tokens replaces the body of the request, so the original body is restored and closed
+	if body := request.Body; body != nil {
 		defer func() {
-			err = errors.Join(err, request.Body.Close())
+			request.Body = body
+			err = errors.Join(err, body.Close())
 		}()
 	}
 
-	payload, err := parser.validatePayload(ctx, request, token)
+	payload, matchedToken, err := validatePayloadWithTokens(ctx, parser, origin.candidateTokens(), request)
 	if err != nil {
 		return
 	}
+	if err = origin.ReplayProtection.validateDelivery(parser.deliveryDetails(request, payload)); err != nil {
+		return
+	}
 
 	webhook, err = parser.parseIncomingWebhook(ctx, request, payload)
+	if webhook != nil && matchedToken != nil {
+		webhook.MatchedToken = matchedToken
+	}
 	return
 }
+
+// validatePayloadWithTokens validates the webhook payload with each of the candidate tokens, until one of them matches.
+// Returns the payload and the matched token, if there are multiple candidate tokens.
+func validatePayloadWithTokens(ctx context.Context, parser webhookParser, tokens [][]byte, request *http.Request) ([]byte, []byte, error) {
+	if len(tokens) == 1 {
+		payload, err := parser.validatePayload(ctx, request, tokens[0])
+		return payload, nil, err
+	}
+	// The body can be read once, hence it is restored before each validation
+	body := []byte{}
+	if request.Body != nil {
+		var err error
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, nil, err
+		}
+	}
+	var validationErr error
+	for _, token := range tokens {
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		payload, err := parser.validatePayload(ctx, request, token)
+		if err == nil {
+			return payload, token, nil
+		}
+		validationErr = errors.Join(validationErr, err)
+	}
+	return nil, nil, validationErr
+}
+
+// validatePayloadSignature validates the "sha256=<HMAC>" signature of the payload in constant time
+func validatePayloadSignature(payload []byte, signature string, token []byte) error {
+	expectedSignature := "sha256=" + calculatePayloadSignature(payload, token)
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return errors.New("payload signature mismatch")
+	}
+	return nil
+}
+
+func calculatePayloadSignature(payload []byte, token []byte) string {
+	hmacHash := hmac.New(sha256.New, token)
+	hmacHash.Write(payload)
+	return hex.EncodeToString(hmacHash.Sum(nil))
+}
@@ -1,9 +1,19 @@
 package webhookparser
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/jfrog/froggit-go/vcsutils"
 )
 
 func TestBranchStatus(t *testing.T) {
@@ -13,3 +23,163 @@ func TestBranchStatus(t *testing.T) {
 	// this one should never happen
 	assert.Equal(t, WebhookInfoBranchStatusUpdated, branchStatus(false, false))
 }
+
+func TestPushEvent(t *testing.T) {
+	assert.Equal(t, vcsutils.BranchDeleted, pushEvent(WebhookInfoBranchStatusDeleted))
+	assert.Equal(t, vcsutils.Push, pushEvent(WebhookInfoBranchStatusCreated))
+	assert.Equal(t, vcsutils.Push, pushEvent(WebhookInfoBranchStatusUpdated))
+}
+
+func TestParseIncomingWebhookFromPayload(t *testing.T) {
+	azureReposAuthorization := "Basic " + base64.StdEncoding.EncodeToString([]byte(azureReposBasicAuthUsername+":"+string(token)))
+	bitbucketCloudPayload, err := os.ReadFile(filepath.Join("testdata", "bitbucketcloud", "pushpayload.json"))
+	assert.NoError(t, err)
+	giteaPayload, err := os.ReadFile(filepath.Join("testdata", "gitea", "pushpayload.json"))
+	assert.NoError(t, err)
+	tests := []struct {
+		name            string
+		provider        vcsutils.VcsProvider
+		payloadPath     string
+		eventHeader     string
+		signatureHeader string
+		expectedEvent   vcsutils.WebhookEvent
+	}{
+		{
+			name:            "GitHub form",
+			provider:        vcsutils.GitHub,
+			payloadPath:     filepath.Join("github", "pushpayload"),
+			eventHeader:     "push",
+			signatureHeader: "sha256=" + githubPushSha256,
+			expectedEvent:   vcsutils.Push,
+		},
+		{
+			name:            "GitHub JSON",
+			provider:        vcsutils.GitHub,
+			payloadPath:     filepath.Join("github", "branchdeletepayload.json"),
+			eventHeader:     "push",
+			signatureHeader: "sha256=" + githubBranchDeleteSha256,
+			expectedEvent:   vcsutils.BranchDeleted,
+		},
+		{
+			name:            "GitLab",
+			provider:        vcsutils.GitLab,
+			payloadPath:     filepath.Join("gitlab", "pushpayload.json"),
+			eventHeader:     "Push Hook",
+			signatureHeader: string(token),
+			expectedEvent:   vcsutils.Push,
+		},
+		{
+			name:            "Bitbucket Server",
+			provider:        vcsutils.BitbucketServer,
+			payloadPath:     filepath.Join("bitbucketserver", "pushpayload.json"),
+			eventHeader:     "repo:refs_changed",
+			signatureHeader: "sha256=" + bitbucketServerPushSha256,
+			expectedEvent:   vcsutils.Push,
+		},
+		{
+			name:            "Bitbucket Cloud",
+			provider:        vcsutils.BitbucketCloud,
+			payloadPath:     filepath.Join("bitbucketcloud", "pushpayload.json"),
+			eventHeader:     "repo:push",
+			signatureHeader: string(token),
+			expectedEvent:   vcsutils.Push,
+		},
+		{
+			name:            "Bitbucket Cloud signed",
+			provider:        vcsutils.BitbucketCloud,
+			payloadPath:     filepath.Join("bitbucketcloud", "pushpayload.json"),
+			eventHeader:     "repo:push",
+			signatureHeader: "sha256=" + calculatePayloadSignature(bitbucketCloudPayload, token),
+			expectedEvent:   vcsutils.Push,
+		},
+		{
+			name:            "Gitea",
+			provider:        vcsutils.Gitea,
+			payloadPath:     filepath.Join("gitea", "pushpayload.json"),
+			eventHeader:     "push",
+			signatureHeader: calculatePayloadSignature(giteaPayload, token),
+			expectedEvent:   vcsutils.Push,
+		},
+		{
+			name:            "Azure Repos",
+			provider:        vcsutils.AzureRepos,
+			payloadPath:     filepath.Join("azurerepos", "pushpayload.json"),
+			signatureHeader: azureReposAuthorization,
+			expectedEvent:   vcsutils.Push,
+		},
+		{
+			name:            "AWS CodeCommit",
+			provider:        vcsutils.CodeCommit,
+			payloadPath:     filepath.Join("codecommit", "pushpayload.json"),
+			signatureHeader: azureReposAuthorization,
+			expectedEvent:   vcsutils.Push,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := os.ReadFile(filepath.Join("testdata", tt.payloadPath))
+			assert.NoError(t, err)
+			origin := WebhookOrigin{VcsProvider: tt.provider, Token: token}
+
+			actual, err := ParseIncomingWebhookFromPayload(context.Background(), vcsutils.EmptyLogger{}, origin, tt.eventHeader, tt.signatureHeader, payload)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedEvent, actual.Event)
+			assert.Equal(t, expectedRepoName, actual.TargetRepositoryDetails.Name)
+			assert.Nil(t, actual.MatchedToken)
+
+			_, err = ParseIncomingWebhookFromPayload(context.Background(), vcsutils.EmptyLogger{}, origin, tt.eventHeader, "", payload)
+			assert.Error(t, err)
+
+			// Rotated secret
+			origin.Token = nil
+			origin.Tokens = [][]byte{[]byte("new-token"), token}
+			actual, err = ParseIncomingWebhookFromPayload(context.Background(), vcsutils.EmptyLogger{}, origin, tt.eventHeader, tt.signatureHeader, payload)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedEvent, actual.Event)
+			assert.Equal(t, token, actual.MatchedToken)
+
+			origin.Tokens = [][]byte{[]byte("new-token"), []byte("old-token")}
+			_, err = ParseIncomingWebhookFromPayload(context.Background(), vcsutils.EmptyLogger{}, origin, tt.eventHeader, tt.signatureHeader, payload)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCandidateTokens(t *testing.T) {
+	assert.Equal(t, [][]byte{token}, WebhookOrigin{Token: token}.candidateTokens())
+	assert.Equal(t, [][]byte{nil}, WebhookOrigin{}.candidateTokens())
+	assert.Equal(t, [][]byte{[]byte("new-token"), token}, WebhookOrigin{Token: []byte("ignored"), Tokens: [][]byte{[]byte("new-token"), {}, token}}.candidateTokens())
+	// Empty tokens are ignored, so the authentication can't be skipped
+	assert.Equal(t, [][]byte{token}, WebhookOrigin{Token: token, Tokens: [][]byte{nil, {}}}.candidateTokens())
+}
+
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (body *closeTrackingBody) Close() error {
+	body.closed = true
+	return nil
+}
+
+func TestParseIncomingWebhookWithTokensClosesRequestBody(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", "pushpayload.json"))
+	assert.NoError(t, err)
+	body := &closeTrackingBody{Reader: bytes.NewReader(payload)}
+	request := httptest.NewRequest(http.MethodPost, "https://127.0.0.1", body)
+	request.Header.Add(gitLabKeyHeader, string(token))
+	request.Header.Add(gitLabEventHeader, "Push Hook")
+
+	origin := WebhookOrigin{VcsProvider: vcsutils.GitLab, Tokens: [][]byte{[]byte("new-token"), token}}
+	actual, err := ParseIncomingWebhook(context.Background(), vcsutils.EmptyLogger{}, origin, request)
+	assert.NoError(t, err)
+	assert.Equal(t, token, actual.MatchedToken)
+	assert.True(t, body.closed)
+	assert.Same(t, body, request.Body)
+}
+
+func TestParseIncomingWebhookFromPayloadUnsupportedProvider(t *testing.T) {
+	_, err := ParseIncomingWebhookFromPayload(context.Background(), vcsutils.EmptyLogger{}, WebhookOrigin{VcsProvider: vcsutils.VcsProvider(-1)}, "", "", []byte("{}"))
+	assert.EqualError(t, err, "unsupported VCS provider: ")
+}